@@ -0,0 +1,100 @@
+package protocli
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// accessLogSampler picks which successful calls the daemon's access log
+// records: 1 in every N per method, counting from the first. Failed calls are
+// always logged.
+type accessLogSampler struct {
+	every   int            // Default N; 1 logs every call
+	methods map[string]int // Per-method N by full method name
+
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+func newAccessLogSampler(every int, methods map[string]int) *accessLogSampler {
+	return &accessLogSampler{every: every, methods: methods, counts: make(map[string]uint64)}
+}
+
+// rate returns N for fullMethod.
+func (s *accessLogSampler) rate(fullMethod string) int {
+	if every, ok := s.methods[fullMethod]; ok {
+		return every
+	}
+	return s.every
+}
+
+// sample reports whether a successful call to fullMethod should be logged.
+func (s *accessLogSampler) sample(fullMethod string) bool {
+	every := s.rate(fullMethod)
+	if every <= 1 {
+		return true
+	}
+	s.mu.Lock()
+	n := s.counts[fullMethod]
+	s.counts[fullMethod] = n + 1
+	s.mu.Unlock()
+	return n%uint64(every) == 0
+}
+
+// log records a finished call. Successful calls sampled out are dropped; a
+// sampled line carries sample_rate so counts can be scaled back up.
+func (s *accessLogSampler) log(ctx context.Context, fullMethod string, start time.Time, err error) {
+	attrs := []any{
+		"method", fullMethod,
+		"code", status.Code(err).String(),
+		"duration", time.Since(start),
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "gRPC request failed", append(attrs, "error", err)...)
+		return
+	}
+	if !s.sample(fullMethod) {
+		return
+	}
+	if every := s.rate(fullMethod); every > 1 {
+		attrs = append(attrs, "sample_rate", every)
+	}
+	slog.InfoContext(ctx, "gRPC request", attrs...)
+}
+
+// accessLogUnaryInterceptor returns a unary server interceptor that logs
+// calls as chosen by s.
+func accessLogUnaryInterceptor(s *accessLogSampler) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		s.log(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// accessLogStreamInterceptor returns a stream server interceptor that logs
+// streams once they end, as chosen by s.
+func accessLogStreamInterceptor(s *accessLogSampler) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		s.log(ss.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
@@ -0,0 +1,138 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// lockedBuffer is a bytes.Buffer safe for the daemon's logger to write while
+// the test reads it.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// count returns the number of log lines containing all of substrs.
+func (b *lockedBuffer) count(substrs ...string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	for line := range strings.SplitSeq(b.buf.String(), "\n") {
+		matches := true
+		for _, s := range substrs {
+			matches = matches && strings.Contains(line, s)
+		}
+		if matches {
+			n++
+		}
+	}
+	return n
+}
+
+// startAccessLoggedDaemon runs the daemon on port with opts until the test
+// ends, logging to the returned buffer. GetUser fails for id 0.
+func startAccessLoggedDaemon(t *testing.T, port string, opts ...protocli.RootOption) (simple.UserServiceClient, *lockedBuffer) {
+	t.Helper()
+	preventExit(t)
+
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			if req.GetId() == 0 {
+				return nil, errors.New("no such user")
+			}
+			return &simple.UserResponse{}, nil
+		},
+		CreateUserFunc: func(context.Context, *simple.CreateUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{}, nil
+		},
+	}
+	factory := func(*simple.UserServiceConfig) simple.UserServiceServer { return mock }
+
+	logs := &lockedBuffer{}
+	ctx, cancel := context.WithCancel(context.Background())
+	readyCh := make(chan struct{})
+	rootCmd, err := protocli.RootCommand("testcli", append([]protocli.RootOption{
+		protocli.Service(simple.UserServiceCommand(ctx, factory)),
+		protocli.ConfigureLogging(func(context.Context, protocli.SlogConfigurationContext) *slog.Logger {
+			return slog.New(slog.NewTextHandler(logs, nil))
+		}),
+		protocli.OnDaemonReady(func(context.Context) { close(readyCh) }),
+		protocli.WithGracefulShutdownTimeout(2 * time.Second),
+	}, opts...)...)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = rootCmd.Run(ctx, []string{"testcli", "daemonize", "--port", port})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		waitForDone(t, done)
+	})
+	waitForReady(t, readyCh)
+
+	conn, err := grpc.NewClient("localhost:"+port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return simple.NewUserServiceClient(conn), logs
+}
+
+// TestIntegration_AccessLog_Sampling tests that 1 in N successful calls are
+// logged per method, that failed calls bypass sampling and that per-method
+// rates override the default.
+func TestIntegration_AccessLog_Sampling(t *testing.T) {
+	client, logs := startAccessLoggedDaemon(t, "50212",
+		protocli.WithAccessLogSampling(10),
+		protocli.WithAccessLogMethodSampling("/example.UserService/CreateUser", 1),
+	)
+	ctx := context.Background()
+
+	for range 100 {
+		_, err := client.GetUser(ctx, &simple.GetUserRequest{Id: 1})
+		require.NoError(t, err)
+	}
+	for range 7 {
+		_, err := client.GetUser(ctx, &simple.GetUserRequest{Id: 0})
+		require.Error(t, err)
+	}
+	for range 5 {
+		_, err := client.CreateUser(ctx, &simple.CreateUserRequest{Name: "ada", Email: "ada@example.com"})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 10, logs.count(`msg="gRPC request"`, "method=/example.UserService/GetUser", "sample_rate=10"),
+		"1 in 10 successful GetUser calls")
+	assert.Equal(t, 7, logs.count(`msg="gRPC request failed"`, "method=/example.UserService/GetUser", "code=Unknown"),
+		"every failed call")
+	assert.Equal(t, 5, logs.count(`msg="gRPC request"`, "method=/example.UserService/CreateUser"),
+		"the override logs every CreateUser call")
+	assert.Zero(t, logs.count("method=/example.UserService/CreateUser", "sample_rate"))
+}
+
+func TestIntegration_AccessLog_Disabled(t *testing.T) {
+	client, logs := startAccessLoggedDaemon(t, "50213")
+
+	_, err := client.GetUser(context.Background(), &simple.GetUserRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Zero(t, logs.count("gRPC request"))
+}
@@ -0,0 +1,82 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// newAliasTestRoot builds a root command with UserService registered using
+// opts and returns it with the mock that records calls.
+func newAliasTestRoot(t *testing.T, opts ...protocli.ServiceRegistrationOption) (*cli.Command, *simple.MockUserServiceServer) {
+	t.Helper()
+	ctx := context.Background()
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, _ *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{}, nil
+		},
+	}
+	factory := func(_ *simple.UserServiceConfig) simple.UserServiceServer { return mock }
+	userCLI := simple.UserServiceCommand(ctx, factory, protocli.WithOutputFormats(protocli.JSON()))
+	rootCmd, err := protocli.RootCommand("testcli", protocli.Service(userCLI, opts...))
+	require.NoError(t, err)
+	setWriterOnAllCommands(rootCmd, &bytes.Buffer{})
+	return rootCmd, mock
+}
+
+func TestIntegration_Aliases_Nested(t *testing.T) {
+	for _, args := range [][]string{
+		{"testcli", "user-service", "get"},
+		{"testcli", "u", "g"},
+		{"testcli", "users", "get"},
+		{"testcli", "user-service", "g"},
+	} {
+		t.Run(args[1]+" "+args[2], func(t *testing.T) {
+			rootCmd, mock := newAliasTestRoot(t)
+			err := rootCmd.Run(context.Background(), append(args, "--id", "1", "--db-url", "postgres://localhost/test"))
+			require.NoError(t, err)
+			assert.Len(t, mock.CallsTo("GetUser"), 1)
+		})
+	}
+}
+
+func TestIntegration_Aliases_Hoisted(t *testing.T) {
+	rootCmd, mock := newAliasTestRoot(t, protocli.Hoisted())
+	err := rootCmd.Run(context.Background(), []string{"testcli", "g", "--id", "1", "--db-url", "postgres://localhost/test"})
+	require.NoError(t, err)
+	assert.Len(t, mock.CallsTo("GetUser"), 1)
+}
+
+func TestIntegration_Aliases_Collision(t *testing.T) {
+	ctx := context.Background()
+	userCLI := simple.UserServiceCommand(ctx, &simple.UnimplementedUserServiceServer{})
+	other := &protocli.ServiceCLI{
+		Command:     &cli.Command{Name: "u", Usage: "Conflicts with the user-service alias"},
+		ServiceName: "other",
+	}
+
+	_, err := protocli.RootCommand("testcli",
+		protocli.Service(userCLI),
+		protocli.Service(other),
+	)
+	require.ErrorIs(t, err, protocli.ErrAmbiguousCommandInvocation)
+	assert.Contains(t, err.Error(), "'u'")
+
+	// Hoisted command aliases are checked against every other top-level name
+	hoistedOther := &protocli.ServiceCLI{
+		Command:     &cli.Command{Name: "other", Commands: []*cli.Command{{Name: "new"}}},
+		ServiceName: "other",
+	}
+	_, err = protocli.RootCommand("testcli",
+		protocli.Service(userCLI, protocli.Hoisted()),
+		protocli.Service(hoistedOther, protocli.Hoisted()),
+	)
+	require.ErrorIs(t, err, protocli.ErrAmbiguousCommandInvocation)
+	assert.Contains(t, err.Error(), "'new'")
+}
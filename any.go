@@ -0,0 +1,53 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ErrInvalidAny is returned for a google.protobuf.Any flag value that is not
+// of the form <type URL>=<JSON>.
+var ErrInvalidAny = errors.New("invalid Any value")
+
+// anyTypeURLPrefix is prepended to type URLs given without a host, so that
+// "example.Address" means "type.googleapis.com/example.Address".
+const anyTypeURLPrefix = "type.googleapis.com/"
+
+// ParseAny parses a google.protobuf.Any flag value of the form
+// <type URL>=<JSON>, e.g. `type.googleapis.com/example.Address={"city":"Oslo"}`,
+// into an Any holding that message. The type URL may omit its host. The
+// message type must be linked into the binary (registered in
+// protoregistry.GlobalTypes, as every generated Go proto package does when
+// imported); the same registry lets the built-in output formats show the
+// concrete message of an Any.
+func ParseAny(value string) (*anypb.Any, error) {
+	typeURL, body, ok := strings.Cut(value, "=")
+	if !ok || typeURL == "" {
+		return nil, fmt.Errorf("%w %q: expected <type URL>=<JSON>", ErrInvalidAny, value)
+	}
+	if !strings.Contains(typeURL, "/") {
+		typeURL = anyTypeURLPrefix + typeURL
+	}
+
+	mt, err := protoregistry.GlobalTypes.FindMessageByURL(typeURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown message type %s: %w", ErrInvalidAny, typeURL, err)
+	}
+	msg := mt.New().Interface()
+	if err := protojson.Unmarshal([]byte(body), msg); err != nil {
+		return nil, fmt.Errorf("%w: invalid JSON for %s: %w", ErrInvalidAny, mt.Descriptor().FullName(), err)
+	}
+
+	packed := &anypb.Any{}
+	if err := anypb.MarshalFrom(packed, msg, proto.MarshalOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", mt.Descriptor().FullName(), err)
+	}
+	packed.TypeUrl = typeURL
+	return packed, nil
+}
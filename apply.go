@@ -0,0 +1,302 @@
+package protocli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownApplyTarget is returned by apply when a file does not map to a
+// method command.
+var ErrUnknownApplyTarget = errors.New("no method command for apply file")
+
+// applyOrderPrefix matches the ordering prefix of apply file names, e.g. "01-".
+var applyOrderPrefix = regexp.MustCompile(`^\d+[-_]`)
+
+// applyStep is one file of an apply directory and the command it runs.
+type applyStep struct {
+	file     string   // File name within the directory
+	path     []string // Command names below the root, e.g. ["user-service", "get"]
+	request  string   // File holding the request, which differs from file for method/request files
+	required []string // Required flags of the command, set from the request's fields
+}
+
+// applyEnvelope is the form of an apply file naming its method with a
+// top-level key instead of its file name.
+type applyEnvelope struct {
+	Method  string         `json:"method"`  // Command path, e.g. "user-service create"
+	Request map[string]any `json:"request"` // Request message
+}
+
+// parseApplyFile decodes a JSON or YAML apply file. ok reports whether it is an
+// envelope; otherwise the whole file is the request. Only objects whose keys
+// are exactly "method" (a string) and optionally "request" count as envelopes.
+func parseApplyFile(data []byte) (env applyEnvelope, ok bool, err error) {
+	var top map[string]any
+	if err := yaml.Unmarshal(data, &top); err != nil {
+		return applyEnvelope{}, false, fmt.Errorf("invalid request file: %w", err)
+	}
+	method, isString := top["method"].(string)
+	request, isObject := top["request"].(map[string]any)
+	for key := range top {
+		if key != "method" && key != "request" {
+			isString = false
+		}
+	}
+	if !isString || (top["request"] != nil && !isObject) {
+		return applyEnvelope{Request: top}, false, nil
+	}
+	return applyEnvelope{Method: method, Request: request}, true, nil
+}
+
+// applyFilePath derives a command path from a file name: the ordering prefix
+// and extension are dropped and the rest is split on dots, so
+// "01-user-service.create.yaml" runs "user-service create".
+func applyFilePath(name string) []string {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	return strings.Split(applyOrderPrefix.ReplaceAllString(base, ""), ".")
+}
+
+// findMethodCommand resolves path below root to a generated method command,
+// i.e. one that reads --input-file.
+func findMethodCommand(root *cli.Command, path []string) (*cli.Command, bool) {
+	cmd := root
+	for _, name := range path {
+		if cmd = cmd.Command(name); cmd == nil {
+			return nil, false
+		}
+	}
+	if cmd == root || cmd.Action == nil {
+		return nil, false
+	}
+	for _, flag := range cmd.Flags {
+		if slices.Contains(flag.Names(), "input-file") {
+			return cmd, true
+		}
+	}
+	return nil, false
+}
+
+// planApply maps each JSON or YAML file of dir, in file name order, to the
+// command it runs. Envelope requests are written to files under tmpDir.
+func planApply(root *cli.Command, dir, tmpDir string) ([]applyStep, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read apply dir: %w", err)
+	}
+
+	var steps []applyStep
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".json" && ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		file := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		env, isEnvelope, err := parseApplyFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		step := applyStep{file: entry.Name(), path: applyFilePath(entry.Name()), request: file}
+		if isEnvelope {
+			step.path = strings.Fields(env.Method)
+			if step.request, err = writeApplyRequest(tmpDir, len(steps), env.Request); err != nil {
+				return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+			}
+		}
+		cmd, ok := findMethodCommand(root, step.path)
+		if !ok {
+			return nil, fmt.Errorf("%w %s: %q", ErrUnknownApplyTarget, entry.Name(), strings.Join(step.path, " "))
+		}
+		step.required = requiredFlagArgs(cmd, env.Request)
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// requiredFlagArgs returns --name=value arguments for the required flags of cmd
+// whose field has a scalar value in request, since urfave/cli checks required
+// flags before --input-file is read. The field may be named like the flag, in
+// snake_case or in lowerCamelCase. Fields missing from the request are left for
+// the required flag check to report.
+func requiredFlagArgs(cmd *cli.Command, request map[string]any) []string {
+	var args []string
+	for _, flag := range cmd.Flags {
+		if !isRequiredFlag(flag) {
+			continue
+		}
+		name := flag.Names()[0]
+		switch v := requestField(request, name).(type) {
+		case string, bool, int, int64, uint64:
+			args = append(args, fmt.Sprintf("--%s=%v", name, v))
+		case float64:
+			args = append(args, "--"+name+"="+strconv.FormatFloat(v, 'f', -1, 64))
+		}
+	}
+	return args
+}
+
+// requestField returns the value of the request field for flag name, or nil.
+func requestField(request map[string]any, name string) any {
+	for _, key := range []string{name, strings.ReplaceAll(name, "-", "_"), kebabToLowerCamel(name)} {
+		if value, ok := request[key]; ok {
+			return value
+		}
+	}
+	return nil
+}
+
+// kebabToLowerCamel converts "user-id" to "userId".
+func kebabToLowerCamel(s string) string {
+	parts := strings.Split(s, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// writeApplyRequest writes an envelope's request to a JSON file for --input-file.
+func writeApplyRequest(tmpDir string, index int, request map[string]any) (string, error) {
+	if request == nil {
+		request = map[string]any{}
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+	path := filepath.Join(tmpDir, fmt.Sprintf("%03d.json", index))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write request: %w", err)
+	}
+	return path, nil
+}
+
+// runApply runs every step of dir in order, stopping at the first failure.
+// extraArgs are appended to each command, e.g. --remote. With dryRun, the
+// commands are only printed.
+func runApply(ctx context.Context, root *cli.Command, dir string, dryRun bool, extraArgs []string) error {
+	tmpDir, err := os.MkdirTemp("", "apply-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	steps, err := planApply(root, dir, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	// The plan is the output of a dry run; otherwise progress goes to stderr
+	// alongside the commands' own output
+	w, fallback := root.ErrWriter, os.Stderr
+	if dryRun {
+		w, fallback = root.Writer, os.Stdout
+	}
+	if w == nil {
+		w = fallback
+	}
+	for _, step := range steps {
+		args := append(append(append([]string{root.Name}, step.path...), step.required...), extraArgs...)
+		if dryRun {
+			_, _ = fmt.Fprintf(w, "%s: %s\n", step.file, shellJoin(args))
+			continue
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s: %w", step.file, context.Cause(ctx))
+		}
+		args = append(args, "--input-file", step.request)
+		_, _ = fmt.Fprintf(w, "Applying %s: %s\n", step.file, strings.Join(step.path, " "))
+		resetFlags(root, step.path)
+		if err := root.Run(rootContext{ctx}, args); err != nil {
+			return fmt.Errorf("%s: %w", step.file, err)
+		}
+	}
+	return nil
+}
+
+// resetFlags replaces the flags of root and of the commands on path below it
+// with fresh copies, so that a step sees none of the flags an earlier step set.
+// urfave/cli keeps whether a flag was set in the flag itself and never clears
+// it, so a flag given to one run would otherwise count as set, with its default
+// value, in every later run of the same command.
+func resetFlags(root *cli.Command, path []string) {
+	cmd := root
+	for {
+		for i, flag := range cmd.Flags {
+			cmd.Flags[i] = freshFlag(flag)
+		}
+		if len(path) == 0 {
+			return
+		}
+		if cmd = cmd.Command(path[0]); cmd == nil {
+			return
+		}
+		path = path[1:]
+	}
+}
+
+// freshFlag returns a copy of flag as it was declared: the exported fields are
+// kept and the parse state held in unexported fields is dropped. Flags that are
+// not pointers to structs are returned as they are.
+func freshFlag(flag cli.Flag) cli.Flag {
+	v := reflect.ValueOf(flag)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return flag
+	}
+	declared := v.Elem()
+	fresh := reflect.New(declared.Type())
+	for i := range declared.NumField() {
+		if declared.Type().Field(i).IsExported() {
+			fresh.Elem().Field(i).Set(declared.Field(i))
+		}
+	}
+	if f, ok := fresh.Interface().(cli.Flag); ok {
+		return f
+	}
+	return flag
+}
+
+// newApplyCommand returns `apply`, which runs the requests stored in a
+// directory, one method call per file.
+func newApplyCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "apply",
+		Usage:     "Run the requests stored in a directory of JSON or YAML files",
+		ArgsUsage: "[-- flags for every command]",
+		Description: "Files run in file name order. A file is either a bare request named after\n" +
+			"its command, with an optional ordering prefix (01-user-service.create.json),\n" +
+			"or an object with \"method\" (the command path) and \"request\" keys.\n" +
+			"Example: mycli apply --dir ./requests -- --remote localhost:50051",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "dir",
+				Usage:    "Directory of request files",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the commands without running them",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return runApply(ctx, cmd.Root(), cmd.String("dir"), cmd.Bool("dry-run"), cmd.Args().Slice())
+		},
+	}
+}
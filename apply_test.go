@@ -0,0 +1,123 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingUserService records the calls it receives, in order.
+type recordingUserService struct {
+	mockUserService
+	calls   []string
+	created []*simple.CreateUserRequest
+}
+
+func (s *recordingUserService) CreateUser(_ context.Context, req *simple.CreateUserRequest) (*simple.UserResponse, error) {
+	s.calls = append(s.calls, "create:"+req.GetName())
+	s.created = append(s.created, req)
+	return &simple.UserResponse{User: &simple.User{Id: 1, Name: req.GetName()}}, nil
+}
+
+func (s *recordingUserService) GetUser(ctx context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+	s.calls = append(s.calls, "get")
+	return s.mockUserService.GetUser(ctx, req)
+}
+
+// writeApplyDir writes files into a new temp dir and returns its path.
+func writeApplyDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+// runApply runs `testcli apply args...` against a recording user service.
+func runApply(t *testing.T, args ...string) (*recordingUserService, string, error) {
+	t.Helper()
+	setupTestCLI(t)
+	ctx := context.Background()
+	svc := &recordingUserService{}
+
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(simple.UserServiceCommand(ctx, func(*simple.UserServiceConfig) simple.UserServiceServer { return svc })),
+	)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	setWriterOnAllCommands(rootCmd, &out)
+	rootCmd.ErrWriter = &bytes.Buffer{}
+	err = rootCmd.Run(ctx, append([]string{"testcli", "apply"}, args...))
+	return svc, out.String(), err
+}
+
+func TestIntegration_Apply_RunsFilesInOrder(t *testing.T) {
+	dir := writeApplyDir(t, map[string]string{
+		"03-user-service.get.yaml":    "id: 1\n",
+		"01-user-service.create.json": `{"name": "Ada", "email": "ada@example.com"}`,
+		"02-second.yaml":              "method: user-service create\nrequest:\n  name: Grace\n  email: grace@example.com\n",
+		"README.md":                   "not a request",
+	})
+
+	svc, out, err := runApply(t, "--dir", dir, "--", "--db-url", "postgres://localhost/db")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"create:Ada", "create:Grace", "get"}, svc.calls)
+	assert.Contains(t, out, "Ada")
+	assert.Contains(t, out, "Grace")
+}
+
+func TestIntegration_Apply_StepsDoNotShareFlags(t *testing.T) {
+	// Every step runs user-service create. The last one passes --name but not
+	// the required --email, which must not carry over from the earlier runs as
+	// set and empty.
+	dir := writeApplyDir(t, map[string]string{
+		"01-user-service.create.json": `{"name": "Ada", "email": "ada@example.com"}`,
+		"02-user-service.create.json": `{"name": "Grace", "email": "grace@example.com", "nickname": "gh"}`,
+		"03-user-service.create.json": `{"name": "Linus"}`,
+	})
+
+	svc, _, err := runApply(t, "--dir", dir, "--", "--db-url", "postgres://localhost/db")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `03-user-service.create.json: Required flag "email" not set`)
+
+	require.Len(t, svc.created, 2, "the third request never reaches the service")
+	assert.Equal(t, "Grace", svc.created[1].GetName())
+	assert.Equal(t, "grace@example.com", svc.created[1].GetEmail())
+	assert.Equal(t, "gh", svc.created[1].GetNickname())
+}
+
+func TestIntegration_Apply_DryRun(t *testing.T) {
+	dir := writeApplyDir(t, map[string]string{
+		"01-user-service.create.json": `{"name": "Ada"}`,
+		"02-lookup.json":              `{"method": "user-service get", "request": {"id": 7}}`,
+	})
+
+	svc, out, err := runApply(t, "--dir", dir, "--dry-run")
+	require.NoError(t, err)
+
+	assert.Empty(t, svc.calls)
+	// Required flags are taken from the request, so they are listed too
+	assert.Equal(t, "01-user-service.create.json: testcli user-service create --name=Ada\n"+
+		"02-lookup.json: testcli user-service get --id=7\n", out)
+}
+
+func TestIntegration_Apply_UnknownTarget(t *testing.T) {
+	dir := writeApplyDir(t, map[string]string{
+		"01-user-service.create.json": `{"name": "Ada"}`,
+		"02-user-service.delete.json": `{"id": 1}`,
+	})
+
+	svc, _, err := runApply(t, "--dir", dir, "--", "--db-url", "postgres://localhost/db")
+	require.ErrorIs(t, err, protocli.ErrUnknownApplyTarget)
+	assert.Empty(t, svc.calls, "nothing runs when any file has no command")
+}
@@ -0,0 +1,35 @@
+package protocli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+// argErrorHandlerKey is the Metadata key storing the WithArgErrorHandler handler on the root command.
+const argErrorHandlerKey = "protocli:argErrorHandler"
+
+// ArgErrorHandler decides what happens when a command that takes no positional
+// arguments is given one; arg is the first of them. Returning nil lets the
+// command run with the arguments available through cmd.Args().
+type ArgErrorHandler func(cmd *cli.Command, arg string) error
+
+// DefaultArgErrorHandler fails with `unsupported argument: "<arg>"` and exit
+// code 3. It is used unless WithArgErrorHandler sets another handler.
+func DefaultArgErrorHandler(_ *cli.Command, arg string) error {
+	return cli.Exit(fmt.Sprintf("unsupported argument: %q", arg), 3)
+}
+
+// CheckArgs passes the first positional argument of cmd, if any, to the
+// root's ArgErrorHandler and returns its result. Generated commands call this
+// before doing anything else.
+func CheckArgs(cmd *cli.Command) error {
+	if cmd.Args().Len() == 0 {
+		return nil
+	}
+	handler, ok := cmd.Root().Metadata[argErrorHandlerKey].(ArgErrorHandler)
+	if !ok {
+		handler = DefaultArgErrorHandler
+	}
+	return handler(cmd, cmd.Args().First())
+}
@@ -0,0 +1,171 @@
+package protocli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/drewfead/proto-cli/cliauth"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// auditCallKey is the Metadata key under which generated actions record the
+// RPC method and request for the invocation currently being audited.
+const auditCallKey = "protocli:auditCall"
+
+// Audit record statuses.
+const (
+	AuditStatusOK    = "ok"
+	AuditStatusError = "error"
+)
+
+// AuditRecord describes a single command invocation.
+type AuditRecord struct {
+	Time     time.Time       `json:"time"`              // When the command started
+	User     string          `json:"user,omitempty"`    // Auth status reported by the login provider, if any
+	Command  string          `json:"command"`           // Command path, e.g. "user-service/get"
+	Method   string          `json:"method,omitempty"`  // Full RPC method, e.g. "/example.UserService/GetUser"
+	Request  json.RawMessage `json:"request,omitempty"` // Request as JSON with sensitive fields redacted
+	Status   string          `json:"status"`            // AuditStatusOK or AuditStatusError
+	Error    string          `json:"error,omitempty"`   // Error message when Status is AuditStatusError
+	Duration time.Duration   `json:"duration"`          // Time spent running the command
+}
+
+// AuditSink receives an AuditRecord after every command invocation.
+// Implementations must be safe for concurrent use.
+type AuditSink interface {
+	WriteAudit(ctx context.Context, record AuditRecord) error
+}
+
+// AuditSinkFunc adapts a function to the AuditSink interface.
+type AuditSinkFunc func(ctx context.Context, record AuditRecord) error
+
+// WriteAudit calls f(ctx, record).
+func (f AuditSinkFunc) WriteAudit(ctx context.Context, record AuditRecord) error {
+	return f(ctx, record)
+}
+
+type jsonAuditSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// JSONAuditSink returns an AuditSink that writes each record to w as a line of JSON.
+func JSONAuditSink(w io.Writer) AuditSink {
+	return &jsonAuditSink{w: w}
+}
+
+func (s *jsonAuditSink) WriteAudit(_ context.Context, record AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// FileAuditSink returns an AuditSink that appends each record as a line of JSON
+// to the file at path, creating it with mode 0600 if needed.
+func FileAuditSink(path string) AuditSink {
+	var mu sync.Mutex
+	return AuditSinkFunc(func(ctx context.Context, record AuditRecord) error {
+		mu.Lock()
+		defer mu.Unlock()
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600) //nolint:gosec // path is chosen by the application
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+		defer f.Close()
+		return JSONAuditSink(f).WriteAudit(ctx, record)
+	})
+}
+
+// auditCall is the method and request recorded by a generated action.
+type auditCall struct {
+	method string
+	req    proto.Message
+}
+
+// RecordAuditRequest records the RPC method and request of the running command
+// so they are included in its audit record. A no-op unless WithAuditLog is set.
+// Called by generated actions once the request is built.
+func RecordAuditRequest(cmd *cli.Command, method string, req proto.Message) {
+	root := cmd.Root()
+	if _, ok := root.Metadata[auditCallKey]; !ok {
+		return
+	}
+	root.Metadata[auditCallKey] = &auditCall{method: method, req: req}
+}
+
+// wrapAuditActions wraps the Action of every command below cmd so each
+// invocation, successful or not, is written to sink. Sink failures are logged
+// rather than returned so auditing never changes a command's outcome.
+func wrapAuditActions(cmd *cli.Command, sink AuditSink, authCfg *cliauth.Config) {
+	for _, sub := range cmd.Commands {
+		wrapAuditActions(sub, sink, authCfg)
+		if sub.Action == nil {
+			continue
+		}
+		action := sub.Action
+		sub.Action = func(ctx context.Context, c *cli.Command) error {
+			root := c.Root()
+			if root.Metadata == nil {
+				root.Metadata = make(map[string]interface{})
+			}
+			// A nil entry marks auditing as active for RecordAuditRequest
+			root.Metadata[auditCallKey] = (*auditCall)(nil)
+
+			start := time.Now()
+			err := action(ctx, c)
+
+			record := AuditRecord{
+				Time:     start.UTC(),
+				User:     auditUser(ctx, authCfg),
+				Command:  commandPath(c),
+				Status:   AuditStatusOK,
+				Duration: time.Since(start),
+			}
+			if call, ok := root.Metadata[auditCallKey].(*auditCall); ok && call != nil {
+				record.Method = call.method
+				if data, marshalErr := protojson.Marshal(RedactSensitive(call.req)); marshalErr == nil {
+					record.Request = data
+				}
+			}
+			delete(root.Metadata, auditCallKey)
+			if err != nil {
+				record.Status = AuditStatusError
+				record.Error = err.Error()
+			}
+			if writeErr := sink.WriteAudit(ctx, record); writeErr != nil {
+				slog.Warn("Failed to write audit record", "command", record.Command, "error", writeErr)
+			}
+			return err
+		}
+	}
+}
+
+// auditUser returns the auth status reported by the configured login provider,
+// or "" when auth is not configured or the provider cannot report status.
+func auditUser(ctx context.Context, authCfg *cliauth.Config) string {
+	if authCfg == nil {
+		return ""
+	}
+	status, ok := authCfg.Provider.(cliauth.StatusProvider)
+	if !ok {
+		return ""
+	}
+	user, err := status.Status(ctx, authCfg.Store)
+	if err != nil {
+		return ""
+	}
+	return user
+}
@@ -0,0 +1,108 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/cliauth"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runAudited(t *testing.T, mock *simple.MockUserServiceServer, args ...string) ([]protocli.AuditRecord, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	var auditBuf bytes.Buffer
+	store := &mockStore{token: []byte("alice")}
+	factory := func(_ *simple.UserServiceConfig) simple.UserServiceServer { return mock }
+	userCLI := simple.UserServiceCommand(ctx, factory, protocli.WithOutputFormats(protocli.JSON()))
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(userCLI),
+		protocli.WithAuth(&mockFullProvider{}, cliauth.WithStore(store)),
+		protocli.WithAuditLog(protocli.JSONAuditSink(&auditBuf)),
+	)
+	require.NoError(t, err)
+	setWriterOnAllCommands(rootCmd, &bytes.Buffer{})
+
+	runErr := rootCmd.Run(ctx, append([]string{"testcli", "user-service"}, args...))
+
+	var records []protocli.AuditRecord
+	for _, line := range strings.Split(strings.TrimSpace(auditBuf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record protocli.AuditRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records, runErr
+}
+
+func TestIntegration_AuditLog_Success(t *testing.T) {
+	mock := &simple.MockUserServiceServer{
+		CreateUserFunc: func(_ context.Context, req *simple.CreateUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{User: &simple.User{Name: req.GetName()}}, nil
+		},
+	}
+
+	records, err := runAudited(t, mock, "create",
+		"--name", "Alice", "--email", "alice@example.com",
+		"--initial-password", "hunter2",
+		"--db-url", "postgres://localhost/test",
+	)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	record := records[0]
+	assert.Equal(t, "user-service/create", record.Command)
+	assert.Equal(t, "/example.UserService/CreateUser", record.Method)
+	assert.Equal(t, protocli.AuditStatusOK, record.Status)
+	assert.Empty(t, record.Error)
+	assert.Equal(t, "Authenticated with token: alice", record.User)
+	assert.False(t, record.Time.IsZero())
+
+	var req map[string]any
+	require.NoError(t, json.Unmarshal(record.Request, &req))
+	assert.Equal(t, "Alice", req["name"])
+	assert.Equal(t, protocli.RedactedPlaceholder, req["initialPassword"])
+	assert.NotContains(t, string(record.Request), "hunter2")
+}
+
+func TestIntegration_AuditLog_Failure(t *testing.T) {
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(context.Context, *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return nil, errors.New("database unavailable")
+		},
+	}
+
+	records, err := runAudited(t, mock, "get", "--id", "7", "--db-url", "postgres://localhost/test")
+	require.Error(t, err)
+	require.Len(t, records, 1)
+
+	record := records[0]
+	assert.Equal(t, "/example.UserService/GetUser", record.Method)
+	assert.Equal(t, protocli.AuditStatusError, record.Status)
+	assert.Contains(t, record.Error, "database unavailable")
+	assert.JSONEq(t, `{"id":"7"}`, string(record.Request))
+}
+
+func TestUnit_RedactSensitive(t *testing.T) {
+	req := &simple.CreateUserRequest{Name: "Alice", InitialPassword: "hunter2"}
+
+	redacted, ok := protocli.RedactSensitive(req).(*simple.CreateUserRequest)
+	require.True(t, ok)
+	assert.Equal(t, "Alice", redacted.GetName())
+	assert.Equal(t, protocli.RedactedPlaceholder, redacted.GetInitialPassword())
+	assert.Equal(t, "hunter2", req.GetInitialPassword(), "original message is unchanged")
+
+	empty, ok := protocli.RedactSensitive(&simple.CreateUserRequest{Name: "Bob"}).(*simple.CreateUserRequest)
+	require.True(t, ok)
+	assert.Empty(t, empty.GetInitialPassword(), "unset sensitive fields stay unset")
+}
@@ -0,0 +1,44 @@
+package protocli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/drewfead/proto-cli/cliauth"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// authStatusWriter renders `auth status --format` through formats, falling
+// back to the built-in formats when the root registers none.
+func authStatusWriter(formats []OutputFormat) cliauth.StatusWriter {
+	if len(formats) == 0 {
+		formats = []OutputFormat{JSON(), YAML(), Go()}
+	}
+	return func(ctx context.Context, cmd *cli.Command, format string, status cliauth.Status) error {
+		msg, err := authStatusMessage(status)
+		if err != nil {
+			return err
+		}
+		return WriteFormatted(ctx, cmd, cmd.Writer, formats, format, msg)
+	}
+}
+
+// authStatusMessage converts status to a Struct with snake_case keys, e.g.
+// {"logged_in": true, "subject": "alice", "expires_at": "2030-01-02T15:04:05Z"}.
+// Unknown subject and expiry are omitted.
+func authStatusMessage(status cliauth.Status) (*structpb.Struct, error) {
+	fields := map[string]any{"logged_in": status.LoggedIn}
+	if status.Subject != "" {
+		fields["subject"] = status.Subject
+	}
+	if !status.ExpiresAt.IsZero() {
+		fields["expires_at"] = status.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	msg, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build auth status: %w", err)
+	}
+	return msg, nil
+}
@@ -0,0 +1,45 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runWithBanner(t *testing.T, opt protocli.RootOption, args ...string) (stdout, stderr string) {
+	t.Helper()
+	rootCmd, err := protocli.RootCommand("testapp", opt)
+	require.NoError(t, err)
+
+	var out, errOut bytes.Buffer
+	rootCmd.Writer = &out
+	rootCmd.ErrWriter = &errOut
+	require.NoError(t, rootCmd.Run(context.Background(), append([]string{"testapp"}, args...)))
+	return out.String(), errOut.String()
+}
+
+func TestIntegration_StartupBanner_WritesToStderr(t *testing.T) {
+	stdout, stderr := runWithBanner(t, protocli.WithStartupBanner("== testapp =="))
+	assert.Equal(t, "== testapp ==\n", stderr)
+	assert.NotContains(t, stdout, "== testapp ==")
+}
+
+func TestIntegration_StartupBanner_SuppressedByQuiet(t *testing.T) {
+	stdout, stderr := runWithBanner(t, protocli.WithStartupBanner("== testapp =="), "--quiet")
+	assert.Empty(t, stderr)
+	assert.NotContains(t, stdout, "== testapp ==")
+}
+
+func TestIntegration_BannerFunc(t *testing.T) {
+	banner := protocli.WithBannerFunc(func(w io.Writer) {
+		_, _ = fmt.Fprintf(w, "testapp %s\n", "v1.2.3")
+	})
+	_, stderr := runWithBanner(t, banner)
+	assert.Equal(t, "testapp v1.2.3\n", stderr)
+}
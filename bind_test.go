@@ -0,0 +1,82 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// daemonListenAddress runs daemonize with args until it is ready and returns
+// the address the gRPC server reported listening on.
+func daemonListenAddress(t *testing.T, opts []protocli.RootOption, args ...string) string {
+	t.Helper()
+	preventExit(t)
+	prevLogger := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(prevLogger) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var logs bytes.Buffer
+	readyCh := make(chan struct{})
+	opts = append(opts,
+		protocli.Service(simple.UserServiceCommand(ctx, newUserService)),
+		protocli.ConfigureLogging(func(_ context.Context, _ protocli.SlogConfigurationContext) *slog.Logger {
+			return slog.New(slog.NewJSONHandler(&logs, nil))
+		}),
+		protocli.OnDaemonReady(func(_ context.Context) { close(readyCh) }),
+	)
+	rootCmd, err := protocli.RootCommand("testcli", opts...)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = rootCmd.Run(ctx, append([]string{"testcli", "daemonize", "--port", "0"}, args...))
+	}()
+	waitForReady(t, readyCh)
+	cancel()
+	waitForDone(t, done)
+
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		if record["msg"] == "Starting gRPC server" {
+			addr, _ := record["address"].(string)
+			return addr
+		}
+	}
+	t.Fatalf("no listen address logged:\n%s", logs.String())
+	return ""
+}
+
+func listenHost(t *testing.T, addr string) net.IP {
+	t.Helper()
+	host, _, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	return net.ParseIP(host)
+}
+
+func TestIntegration_Daemon_DefaultBindLoopback(t *testing.T) {
+	addr := daemonListenAddress(t, []protocli.RootOption{protocli.WithDefaultBindLoopback()})
+	assert.True(t, listenHost(t, addr).IsLoopback(), "listening on %s", addr)
+}
+
+func TestIntegration_Daemon_BindOverridesLoopbackDefault(t *testing.T) {
+	addr := daemonListenAddress(t, []protocli.RootOption{protocli.WithDefaultBindLoopback()}, "--bind", "0.0.0.0")
+	assert.True(t, listenHost(t, addr).IsUnspecified(), "listening on %s", addr)
+}
+
+func TestIntegration_Daemon_DefaultBindAllInterfaces(t *testing.T) {
+	addr := daemonListenAddress(t, nil)
+	assert.True(t, listenHost(t, addr).IsUnspecified(), "listening on %s", addr)
+}
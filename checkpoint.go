@@ -0,0 +1,235 @@
+package protocli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// StreamCheckpoint records how far a resumable server stream has been written
+// for --checkpoint-file, so that a re-run with --resume continues after the
+// last written message instead of starting over. Generated streaming commands
+// for methods with a resume_token_field create one per invocation, pass each
+// received message to Observe and call Save once it is written. Both do
+// nothing without --checkpoint-file.
+type StreamCheckpoint struct {
+	path      string
+	tokenPath []protoreflect.Name
+	state     checkpointState
+	token     protoreflect.Value           // Token of the observed message, written by Save
+	tokenDesc protoreflect.FieldDescriptor // Field holding token; nil if the message had none
+}
+
+// checkpointState is the JSON content of a checkpoint file.
+type checkpointState struct {
+	Messages int             `json:"messages"`        // Messages written so far, across resumed runs
+	Token    json.RawMessage `json:"token,omitempty"` // Resume token of the last written message
+}
+
+// LoadStreamCheckpoint reads --checkpoint-file and --resume from cmd. With --resume and an existing
+// checkpoint, the saved token is copied into req at requestField (defaulting
+// to tokenField) and --output-append is set so the output continues the file
+// written by the interrupted run. Without a checkpoint, --resume starts from
+// the beginning.
+func LoadStreamCheckpoint(cmd *cli.Command, tokenField, requestField string, req proto.Message) (*StreamCheckpoint, error) {
+	path := cmd.String("checkpoint-file")
+	if path == "" && cmd.Bool("resume") {
+		return nil, cli.Exit("--resume requires --checkpoint-file", 3)
+	}
+	if requestField == "" {
+		requestField = tokenField
+	}
+	c := &StreamCheckpoint{path: path, tokenPath: splitFieldPath(tokenField)}
+	if !cmd.Bool("resume") {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, &c.state); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %w", path, err)
+	}
+	if len(c.state.Token) > 0 {
+		requestPath := splitFieldPath(requestField)
+		fd := fieldDescriptorAtPath(req.ProtoReflect().Descriptor(), requestPath)
+		if fd == nil {
+			return nil, fmt.Errorf("%w %q in request", ErrUnknownFieldPath, requestField)
+		}
+		token, err := decodeCheckpointToken(fd, c.state.Token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint %s: %w", path, err)
+		}
+		setFieldPath(req.ProtoReflect(), requestPath, token)
+	}
+	if err := cmd.Set("output-append", "true"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Observe records the resume token of msg, a received message that is about
+// to be written.
+func (c *StreamCheckpoint) Observe(msg proto.Message) {
+	if c.path == "" {
+		return
+	}
+	c.token, c.tokenDesc, _ = getFieldPath(msg.ProtoReflect(), c.tokenPath)
+}
+
+// Save records the observed message as the last one written. The checkpoint
+// file is replaced atomically, so an interruption leaves either the previous
+// or the new checkpoint. A message without a token keeps the previous one.
+func (c *StreamCheckpoint) Save() error {
+	if c.path == "" {
+		return nil
+	}
+	c.state.Messages++
+	if c.tokenDesc != nil {
+		raw, err := encodeCheckpointToken(c.tokenDesc, c.token)
+		if err != nil {
+			return fmt.Errorf("failed to encode resume token: %w", err)
+		}
+		c.state.Token = raw
+	}
+	data, err := json.Marshal(c.state)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(c.path, data); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it
+// over path.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// fieldDescriptorAtPath returns the descriptor of the field at path in
+// messages described by desc, or nil if the path does not resolve.
+func fieldDescriptorAtPath(desc protoreflect.MessageDescriptor, path []protoreflect.Name) protoreflect.FieldDescriptor {
+	var fd protoreflect.FieldDescriptor
+	for i, name := range path {
+		if i > 0 {
+			if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+				return nil
+			}
+			desc = fd.Message()
+		}
+		if fd = desc.Fields().ByName(name); fd == nil {
+			return nil
+		}
+	}
+	return fd
+}
+
+// encodeCheckpointToken encodes a resume token held by field fd as JSON,
+// spelling 64-bit integers as strings as protojson does.
+func encodeCheckpointToken(fd protoreflect.FieldDescriptor, v protoreflect.Value) (json.RawMessage, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return protojson.Marshal(v.Message().Interface())
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return json.Marshal(strconv.FormatInt(v.Int(), 10))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return json.Marshal(strconv.FormatUint(v.Uint(), 10))
+	case protoreflect.EnumKind:
+		return json.Marshal(int32(v.Enum()))
+	default:
+		return json.Marshal(v.Interface())
+	}
+}
+
+// decodeCheckpointToken decodes a token written by encodeCheckpointToken into
+// a value for field fd.
+func decodeCheckpointToken(fd protoreflect.FieldDescriptor, raw json.RawMessage) (protoreflect.Value, error) {
+	decode := func(v any) error { return json.Unmarshal(raw, v) }
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		mt, err := protoregistry.GlobalTypes.FindMessageByName(fd.Message().FullName())
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		msg := mt.New()
+		if err := protojson.Unmarshal(raw, msg.Interface()); err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfMessage(msg), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var s string
+		if err := decode(&s); err != nil {
+			return protoreflect.Value{}, err
+		}
+		if fd.Kind() == protoreflect.Uint64Kind || fd.Kind() == protoreflect.Fixed64Kind {
+			n, err := strconv.ParseUint(s, 10, 64)
+			return protoreflect.ValueOfUint64(n), err
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var n int32
+		err := decode(&n)
+		return protoreflect.ValueOfInt32(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var n uint32
+		err := decode(&n)
+		return protoreflect.ValueOfUint32(n), err
+	case protoreflect.EnumKind:
+		var n int32
+		err := decode(&n)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), err
+	case protoreflect.FloatKind:
+		var f float32
+		err := decode(&f)
+		return protoreflect.ValueOfFloat32(f), err
+	case protoreflect.DoubleKind:
+		var f float64
+		err := decode(&f)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.BoolKind:
+		var b bool
+		err := decode(&b)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.BytesKind:
+		var b []byte
+		err := decode(&b)
+		return protoreflect.ValueOfBytes(b), err
+	default:
+		var s string
+		err := decode(&s)
+		return protoreflect.ValueOfString(s), err
+	}
+}
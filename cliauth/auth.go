@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/urfave/cli/v3"
 )
@@ -40,6 +41,34 @@ type StatusProvider interface {
 	Status(ctx context.Context, store AuthStore) (string, error)
 }
 
+// Status is the machine-readable authentication state printed by
+// `auth status --format <name>`.
+type Status struct {
+	LoggedIn  bool      // Credentials are stored
+	Subject   string    // Authenticated principal, e.g. a user or service account ("" if unknown)
+	ExpiresAt time.Time // When the credentials expire (zero if unknown)
+}
+
+// StatusDetailsProvider can be implemented alongside StatusProvider to report
+// the subject and expiry of the stored credentials for structured output.
+type StatusDetailsProvider interface {
+	StatusDetails(ctx context.Context, store AuthStore) (Status, error)
+}
+
+// StatusWriter renders status in the named output format. protocli installs
+// one that routes through the CLI's registered output formats.
+type StatusWriter func(ctx context.Context, cmd *cli.Command, format string, status Status) error
+
+// RefreshableProvider adds token refresh to the auth command suite. Before
+// credentials are attached to a remote call, a stored token expiring within
+// the refresh threshold is exchanged for a new one, which is saved to the store.
+type RefreshableProvider interface {
+	// TokenExpiry returns when token expires, or the zero time if it never does.
+	TokenExpiry(ctx context.Context, token []byte) (time.Time, error)
+	// Refresh returns a new token to replace token.
+	Refresh(ctx context.Context, token []byte) ([]byte, error)
+}
+
 // AuthDecorator decorates outgoing gRPC requests with authentication metadata.
 type AuthDecorator interface {
 	Decorate(ctx context.Context, store AuthStore) (map[string]string, error)
@@ -2,6 +2,7 @@ package cliauth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -84,12 +85,33 @@ func logoutCommand(cfg *Config) *cli.Command {
 	}
 }
 
+// textStatusFormat is the default `auth status` format: the provider's message.
+const textStatusFormat = "text"
+
 // statusCommand builds the "auth status" subcommand.
 func statusCommand(cfg *Config) *cli.Command {
+	var flags []cli.Flag
+	if cfg.StatusWriter != nil {
+		flags = append(flags, &cli.StringFlag{
+			Name:  "format",
+			Value: textStatusFormat,
+			Usage: "Output format (text, or an output format such as json or yaml)",
+		})
+	}
+
 	return &cli.Command{
 		Name:  "status",
 		Usage: "show authentication status",
+		Flags: flags,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if format := cmd.String("format"); cfg.StatusWriter != nil && format != textStatusFormat {
+				status, err := CurrentStatus(ctx, cfg)
+				if err != nil {
+					return err
+				}
+				return cfg.StatusWriter(ctx, cmd, format, status)
+			}
+
 			msg, err := cfg.Provider.(StatusProvider).Status(ctx, cfg.Store)
 			if err != nil {
 				return err
@@ -103,6 +125,21 @@ func statusCommand(cfg *Config) *cli.Command {
 	}
 }
 
+// CurrentStatus reports whether credentials are stored, with the subject and
+// expiry when the provider implements StatusDetailsProvider.
+func CurrentStatus(ctx context.Context, cfg *Config) (Status, error) {
+	if details, ok := cfg.Provider.(StatusDetailsProvider); ok {
+		return details.StatusDetails(ctx, cfg.Store)
+	}
+	if _, err := cfg.Store.Load(ctx); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return Status{}, nil
+		}
+		return Status{}, err
+	}
+	return Status{LoggedIn: true}, nil
+}
+
 // anyProviderFlagSet returns true if any of the given provider flags are set on cmd.
 func anyProviderFlagSet(cmd *cli.Command, flags []cli.Flag) bool {
 	for _, f := range flags {
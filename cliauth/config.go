@@ -1,10 +1,23 @@
 package cliauth
 
+import (
+	"sync"
+	"time"
+)
+
+// DefaultRefreshThreshold is how long before expiry a RefreshableProvider's
+// token is refreshed unless WithRefreshThreshold says otherwise.
+const DefaultRefreshThreshold = time.Minute
+
 // Config holds the auth configuration assembled from a LoginProvider and options.
 type Config struct {
-	Provider  LoginProvider
-	Store     AuthStore
-	Decorator AuthDecorator
+	Provider         LoginProvider
+	Store            AuthStore
+	Decorator        AuthDecorator
+	StatusWriter     StatusWriter
+	RefreshThreshold time.Duration
+
+	refreshMu sync.Mutex // Serializes token refreshes across concurrent calls
 }
 
 // Option configures an auth Config.
@@ -24,11 +37,28 @@ func WithDecorator(decorator AuthDecorator) Option {
 	}
 }
 
+// WithStatusWriter adds a --format flag to `auth status` that renders the
+// status with w for any format other than the default "text".
+func WithStatusWriter(w StatusWriter) Option {
+	return func(c *Config) {
+		c.StatusWriter = w
+	}
+}
+
+// WithRefreshThreshold sets how long before expiry a RefreshableProvider's
+// token is refreshed. Defaults to DefaultRefreshThreshold.
+func WithRefreshThreshold(d time.Duration) Option {
+	return func(c *Config) {
+		c.RefreshThreshold = d
+	}
+}
+
 // NewConfig creates a Config for the given provider with sensible defaults.
 // If no Store is provided via options, a KeychainStore is used.
 func NewConfig(appName string, provider LoginProvider, opts ...Option) *Config {
 	cfg := &Config{
-		Provider: provider,
+		Provider:         provider,
+		RefreshThreshold: DefaultRefreshThreshold,
 	}
 	for _, opt := range opts {
 		opt(cfg)
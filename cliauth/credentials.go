@@ -0,0 +1,30 @@
+package cliauth
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// perRPCCredentials attaches the decorator's metadata to every call, refreshing
+// the token first when needed.
+type perRPCCredentials struct {
+	cfg *Config
+}
+
+// PerRPCCredentials returns gRPC per-RPC credentials for cfg, for use with
+// grpc.WithPerRPCCredentials. Like DecorateContext it is lenient: calls proceed
+// without credentials when no token is stored or decoration fails.
+func PerRPCCredentials(cfg *Config) credentials.PerRPCCredentials {
+	return perRPCCredentials{cfg: cfg}
+}
+
+func (c perRPCCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	return decorate(ctx, c.cfg), nil
+}
+
+// RequireTransportSecurity is false so credentials also work with plaintext
+// connections to local daemons.
+func (c perRPCCredentials) RequireTransportSecurity() bool {
+	return false
+}
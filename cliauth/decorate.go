@@ -2,22 +2,20 @@ package cliauth
 
 import (
 	"context"
+	"log/slog"
 
 	"google.golang.org/grpc/metadata"
 )
 
 // DecorateContext calls the configured AuthDecorator and appends the resulting
-// key-value pairs to the outgoing gRPC metadata on the context.
+// key-value pairs to the outgoing gRPC metadata on the context. A token close
+// to expiry is refreshed first (see RefreshableProvider).
 // Returns the original context unchanged if there is no decorator, on error,
 // or when the decorator returns an empty map (lenient — allows unauthenticated
 // commands to proceed).
 func DecorateContext(ctx context.Context, cfg *Config) context.Context {
-	if cfg.Decorator == nil {
-		return ctx
-	}
-
-	md, err := cfg.Decorator.Decorate(ctx, cfg.Store)
-	if err != nil || len(md) == 0 {
+	md := decorate(ctx, cfg)
+	if len(md) == 0 {
 		return ctx
 	}
 
@@ -27,3 +25,22 @@ func DecorateContext(ctx context.Context, cfg *Config) context.Context {
 	}
 	return metadata.AppendToOutgoingContext(ctx, kvs...)
 }
+
+// decorate refreshes the token if needed and returns the decorator's metadata,
+// or nil when there is no decorator or it fails. A failed refresh is logged
+// and the current token is used.
+func decorate(ctx context.Context, cfg *Config) map[string]string {
+	if cfg.Decorator == nil {
+		return nil
+	}
+
+	if err := RefreshIfNeeded(ctx, cfg); err != nil {
+		slog.Warn("Failed to refresh auth token", "error", err)
+	}
+
+	md, err := cfg.Decorator.Decorate(ctx, cfg.Store)
+	if err != nil {
+		return nil
+	}
+	return md
+}
@@ -0,0 +1,46 @@
+package cliauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RefreshIfNeeded refreshes the stored token when the provider implements
+// RefreshableProvider and the token expires within cfg.RefreshThreshold,
+// saving the new token to the store. It does nothing when no token is stored.
+func RefreshIfNeeded(ctx context.Context, cfg *Config) error {
+	refresher, ok := cfg.Provider.(RefreshableProvider)
+	if !ok {
+		return nil
+	}
+
+	cfg.refreshMu.Lock()
+	defer cfg.refreshMu.Unlock()
+
+	token, err := cfg.Store.Load(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load token: %w", err)
+	}
+
+	expiry, err := refresher.TokenExpiry(ctx, token)
+	if err != nil {
+		return fmt.Errorf("failed to read token expiry: %w", err)
+	}
+	if expiry.IsZero() || time.Until(expiry) > cfg.RefreshThreshold {
+		return nil
+	}
+
+	refreshed, err := refresher.Refresh(ctx, token)
+	if err != nil {
+		return fmt.Errorf("failed to refresh token: %w", err)
+	}
+	if err := cfg.Store.Save(ctx, refreshed); err != nil {
+		return fmt.Errorf("failed to save refreshed token: %w", err)
+	}
+	return nil
+}
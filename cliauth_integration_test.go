@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	protocli "github.com/drewfead/proto-cli"
 	"github.com/drewfead/proto-cli/cliauth"
@@ -392,3 +393,150 @@ func TestIntegration_Auth_NoDecorator_OriginalContext(t *testing.T) {
 	_, ok := metadata.FromOutgoingContext(newCtx)
 	require.False(t, ok)
 }
+
+// mockDetailedProvider reports the subject and expiry of its stored token.
+type mockDetailedProvider struct {
+	mockFullProvider
+	expiresAt time.Time
+}
+
+func (p *mockDetailedProvider) StatusDetails(ctx context.Context, store cliauth.AuthStore) (cliauth.Status, error) {
+	token, err := store.Load(ctx)
+	if errors.Is(err, cliauth.ErrNotFound) {
+		return cliauth.Status{}, nil
+	}
+	if err != nil {
+		return cliauth.Status{}, err
+	}
+	return cliauth.Status{LoggedIn: true, Subject: string(token), ExpiresAt: p.expiresAt}, nil
+}
+
+func runAuthStatus(t *testing.T, provider cliauth.LoginProvider, store cliauth.AuthStore, args ...string) string {
+	t.Helper()
+	rootCmd, err := protocli.RootCommand("testapp",
+		protocli.Service(dummyServiceCLI()),
+		protocli.WithAuth(provider, cliauth.WithStore(store)),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	setWriterRecursive(rootCmd, &buf)
+	require.NoError(t, rootCmd.Run(context.Background(), append([]string{"testapp", "auth", "status"}, args...)))
+	return buf.String()
+}
+
+func TestIntegration_Auth_StatusJSON(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+	provider := &mockDetailedProvider{expiresAt: expiresAt}
+
+	loggedIn := runAuthStatus(t, provider, &mockStore{token: []byte("alice")}, "--format", "json")
+	require.JSONEq(t, `{"logged_in": true, "subject": "alice", "expires_at": "2030-01-02T15:04:05Z"}`, loggedIn)
+
+	loggedOut := runAuthStatus(t, provider, &mockStore{}, "--format", "json")
+	require.JSONEq(t, `{"logged_in": false}`, loggedOut)
+}
+
+func TestIntegration_Auth_StatusJSONWithoutDetails(t *testing.T) {
+	// Providers without StatusDetails report only whether credentials are stored
+	out := runAuthStatus(t, &mockFullProvider{}, &mockStore{token: []byte("tok")}, "--format", "json")
+	require.JSONEq(t, `{"logged_in": true}`, out)
+
+	out = runAuthStatus(t, &mockFullProvider{}, &mockStore{}, "--format", "yaml")
+	require.Equal(t, "logged_in: false\n", out)
+}
+
+func TestIntegration_Auth_StatusTextIsDefault(t *testing.T) {
+	out := runAuthStatus(t, &mockDetailedProvider{}, &mockStore{token: []byte("alice")})
+	require.Equal(t, "Authenticated with token: alice\n", out)
+}
+
+func TestIntegration_Auth_StatusUnknownFormat(t *testing.T) {
+	rootCmd, err := protocli.RootCommand("testapp",
+		protocli.Service(dummyServiceCLI()),
+		protocli.WithAuth(&mockFullProvider{}, cliauth.WithStore(&mockStore{})),
+	)
+	require.NoError(t, err)
+	setWriterRecursive(rootCmd, &bytes.Buffer{})
+
+	err = rootCmd.Run(context.Background(), []string{"testapp", "auth", "status", "--format", "xml"})
+	require.ErrorIs(t, err, protocli.ErrUnknownFormat)
+}
+
+// mockRefreshableProvider issues tokens that expire at expiries[token] and
+// refreshes any token to "new".
+type mockRefreshableProvider struct {
+	mockLoginProvider
+	expiries     map[string]time.Time
+	refreshCalls int
+}
+
+func (p *mockRefreshableProvider) TokenExpiry(_ context.Context, token []byte) (time.Time, error) {
+	return p.expiries[string(token)], nil
+}
+
+func (p *mockRefreshableProvider) Refresh(_ context.Context, _ []byte) ([]byte, error) {
+	p.refreshCalls++
+	return []byte("new"), nil
+}
+
+func newRefreshTestConfig(store *mockStore) (*cliauth.Config, *mockRefreshableProvider) {
+	provider := &mockRefreshableProvider{expiries: map[string]time.Time{
+		"old": time.Now().Add(30 * time.Second),
+		"new": time.Now().Add(time.Hour),
+	}}
+	cfg := cliauth.NewConfig("testapp", provider,
+		cliauth.WithStore(store),
+		cliauth.WithDecorator(&mockDecorator{}),
+	)
+	return cfg, provider
+}
+
+func TestIntegration_Auth_RefreshNearExpiry(t *testing.T) {
+	store := &mockStore{token: []byte("old")}
+	cfg, provider := newRefreshTestConfig(store)
+
+	for range 3 {
+		ctx := cliauth.DecorateContext(context.Background(), cfg)
+		md, ok := metadata.FromOutgoingContext(ctx)
+		require.True(t, ok)
+		require.Equal(t, []string{"Bearer new"}, md.Get("authorization"))
+	}
+
+	require.Equal(t, 1, provider.refreshCalls)
+	require.Equal(t, []byte("new"), store.token)
+}
+
+func TestIntegration_Auth_PerRPCCredentialsRefresh(t *testing.T) {
+	store := &mockStore{token: []byte("old")}
+	cfg, provider := newRefreshTestConfig(store)
+	creds := cliauth.PerRPCCredentials(cfg)
+
+	for range 3 {
+		md, err := creds.GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"authorization": "Bearer new"}, md)
+	}
+
+	require.Equal(t, 1, provider.refreshCalls)
+	require.Equal(t, []byte("new"), store.token)
+}
+
+func TestIntegration_Auth_NoRefreshOutsideThreshold(t *testing.T) {
+	store := &mockStore{token: []byte("old")}
+	provider := &mockRefreshableProvider{expiries: map[string]time.Time{
+		"old": time.Now().Add(30 * time.Second),
+	}}
+	cfg := cliauth.NewConfig("testapp", provider,
+		cliauth.WithStore(store),
+		cliauth.WithRefreshThreshold(10*time.Second),
+	)
+
+	require.NoError(t, cliauth.RefreshIfNeeded(context.Background(), cfg))
+	require.Equal(t, 0, provider.refreshCalls)
+	require.Equal(t, []byte("old"), store.token)
+
+	// Nothing stored: nothing to refresh
+	cfg.Store = &mockStore{}
+	require.NoError(t, cliauth.RefreshIfNeeded(context.Background(), cfg))
+	require.Equal(t, 0, provider.refreshCalls)
+}
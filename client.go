@@ -0,0 +1,143 @@
+package protocli
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+
+	"github.com/drewfead/proto-cli/cliauth"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc"
+)
+
+// ErrNoRemote is returned by DialRemote when no remote address was given by
+// option or flag.
+var ErrNoRemote = errors.New("no remote address")
+
+// maxRetryAttempts is the most attempts gRPC allows in a retry policy.
+const maxRetryAttempts = 5
+
+// ClientOption configures the connection opened by DialRemote and the
+// generated New<Service>CLIClient constructors.
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	remote      string
+	cmd         *cli.Command
+	tlsConfig   *tls.Config
+	auth        *cliauth.Config
+	retries     int
+	dialOptions []grpc.DialOption
+}
+
+// WithRemote sets the server address (host:port) explicitly. It takes
+// precedence over WithRemoteCommand.
+func WithRemote(addr string) ClientOption {
+	return func(o *clientOptions) { o.remote = addr }
+}
+
+// WithRemoteCommand resolves the remote the way cmd's generated commands do,
+// so programs embedding a generated CLI can dial the same server: the address
+// is cmd's --remote flag, which --context fills in from the remotes file (see
+// WithRemotesFile), and a remote selected with --context brings its TLS
+// setting and authorization header along.
+func WithRemoteCommand(cmd *cli.Command) ClientOption {
+	return func(o *clientOptions) { o.cmd = cmd }
+}
+
+// WithClientTLS connects with TLS using cfg instead of plaintext.
+func WithClientTLS(cfg *tls.Config) ClientOption {
+	return func(o *clientOptions) { o.tlsConfig = cfg }
+}
+
+// WithClientAuth decorates every call with the metadata from the auth config's
+// AuthDecorator, as generated commands do for apps built with WithAuth. Tokens
+// near expiry are refreshed first when the provider is a RefreshableProvider.
+func WithClientAuth(cfg *cliauth.Config) ClientOption {
+	return func(o *clientOptions) { o.auth = cfg }
+}
+
+// WithClientRetries retries calls that fail with UNAVAILABLE, making up to
+// attempts attempts in total (capped at 5 by gRPC).
+func WithClientRetries(attempts int) ClientOption {
+	return func(o *clientOptions) { o.retries = attempts }
+}
+
+// WithDialOptions appends raw gRPC dial options, applied after the ones
+// derived from the other client options.
+func WithDialOptions(opts ...grpc.DialOption) ClientOption {
+	return func(o *clientOptions) { o.dialOptions = append(o.dialOptions, opts...) }
+}
+
+// DialRemote opens a client connection configured by opts. The address comes
+// from WithRemote, then the --remote flag of the command given with
+// WithRemoteCommand; ErrNoRemote is returned when neither is set. Generated
+// New<Service>CLIClient constructors call this.
+func DialRemote(opts ...ClientOption) (*grpc.ClientConn, error) {
+	o := &clientOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	addr := o.remote
+	if addr == "" && o.cmd != nil {
+		addr = o.cmd.String("remote")
+	}
+	if addr == "" {
+		return nil, fmt.Errorf("%w: use WithRemote, or WithRemoteCommand with --remote or --context", ErrNoRemote)
+	}
+
+	conn, err := grpc.NewClient(addr, o.grpcDialOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote %s: %w", addr, err)
+	}
+	return conn, nil
+}
+
+// grpcDialOptions translates the client options into gRPC dial options,
+// starting from the RemoteDialOptions of the WithRemoteCommand command.
+func (o *clientOptions) grpcDialOptions() []grpc.DialOption {
+	var dialOpts []grpc.DialOption
+	var remote Remote
+	if o.cmd != nil {
+		dialOpts = RemoteDialOptions(o.cmd)
+		remote, _ = o.cmd.Root().Metadata[remoteContextKey].(Remote)
+	}
+	if o.tlsConfig != nil || o.cmd == nil {
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials(o.tlsConfig))}
+	}
+
+	if o.retries > 1 {
+		dialOpts = append(dialOpts, grpc.WithDefaultServiceConfig(retryServiceConfig(o.retries)))
+	}
+
+	if o.auth != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(cliauth.PerRPCCredentials(o.auth)))
+	} else if remote.Auth != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(remoteAuth(remote.Auth)))
+	}
+
+	return append(dialOpts, o.dialOptions...)
+}
+
+// remoteAuth sends the auth of a remote selected with --context as the
+// authorization header of every call.
+type remoteAuth string
+
+func (a remoteAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": string(a)}, nil
+}
+
+func (a remoteAuth) RequireTransportSecurity() bool {
+	return false
+}
+
+// retryServiceConfig returns a gRPC service config retrying UNAVAILABLE calls
+// to every method.
+func retryServiceConfig(attempts int) string {
+	attempts = min(attempts, maxRetryAttempts)
+	return fmt.Sprintf(`{"methodConfig": [{"name": [{}], "retryPolicy": {`+
+		`"maxAttempts": %d, "initialBackoff": "0.1s", "maxBackoff": "1s", `+
+		`"backoffMultiplier": 2, "retryableStatusCodes": ["UNAVAILABLE"]}}]}`, attempts)
+}
@@ -0,0 +1,180 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/cliauth"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// selfSignedCert returns a certificate for 127.0.0.1 and a pool trusting it.
+func selfSignedCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, pool
+}
+
+// startClientTestServer serves the health service, recording the incoming
+// metadata of each call.
+func startClientTestServer(t *testing.T, opts ...grpc.ServerOption) (string, *metadata.MD) {
+	t.Helper()
+	lis, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var seen metadata.MD
+	opts = append(opts, grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		seen, _ = metadata.FromIncomingContext(ctx)
+		return handler(ctx, req)
+	}))
+	server := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String(), &seen
+}
+
+func checkHealth(t *testing.T, conn *grpc.ClientConn) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestIntegration_DialRemote_TLS(t *testing.T) {
+	cert, pool := selfSignedCert(t)
+	addr, _ := startClientTestServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	})))
+
+	conn, err := protocli.DialRemote(protocli.WithRemote(addr), protocli.WithClientTLS(&tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}))
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, checkHealth(t, conn))
+
+	plain, err := protocli.DialRemote(protocli.WithRemote(addr))
+	require.NoError(t, err)
+	defer plain.Close()
+	require.Error(t, checkHealth(t, plain), "a plaintext client cannot talk to a TLS server")
+}
+
+func TestIntegration_DialRemote_Auth(t *testing.T) {
+	addr, seen := startClientTestServer(t)
+	cfg := cliauth.NewConfig("testcli", &mockLoginProvider{},
+		cliauth.WithStore(&mockStore{token: []byte("secret")}),
+		cliauth.WithDecorator(&mockDecorator{}),
+	)
+
+	conn, err := protocli.DialRemote(protocli.WithRemote(addr), protocli.WithClientAuth(cfg))
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, checkHealth(t, conn))
+
+	assert.Equal(t, []string{"Bearer secret"}, seen.Get("authorization"))
+}
+
+func TestIntegration_DialRemote_Retries(t *testing.T) {
+	addr, _ := startClientTestServer(t)
+
+	conn, err := protocli.DialRemote(protocli.WithRemote(addr), protocli.WithClientRetries(3))
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, checkHealth(t, conn))
+}
+
+// dialFromCommand runs a user-service get with args and dials the remote of
+// the command from a BeforeCommand hook, then stops it before the call.
+func dialFromCommand(t *testing.T, remotesFile string, args ...string) (*grpc.ClientConn, error) {
+	t.Helper()
+	errDialed := errors.New("dialed")
+	var conn *grpc.ClientConn
+	var dialErr error
+	ctx := context.Background()
+	factory := func(*simple.UserServiceConfig) simple.UserServiceServer { return &simple.MockUserServiceServer{} }
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(simple.UserServiceCommand(ctx, factory,
+			protocli.BeforeCommand(func(_ context.Context, cmd *cli.Command) error {
+				conn, dialErr = protocli.DialRemote(protocli.WithRemoteCommand(cmd))
+				return errDialed
+			}),
+		)),
+		protocli.WithRemotesFile(remotesFile),
+	)
+	require.NoError(t, err)
+	setWriterOnAllCommands(rootCmd, &bytes.Buffer{})
+	args = append(append([]string{"testcli"}, args...), "--id", "1", "--db-url", "postgres://localhost/test")
+	require.ErrorIs(t, rootCmd.Run(ctx, args), errDialed)
+	return conn, dialErr
+}
+
+// TestIntegration_DialRemote_FromCommand tests that WithRemoteCommand dials
+// the --remote of a command, or the remote selected with --context along with
+// its authorization header.
+func TestIntegration_DialRemote_FromCommand(t *testing.T) {
+	addr, seen := startClientTestServer(t)
+	remotesFile := writeRemotesFile(t, "remotes:\n  local:\n    address: "+addr+"\n    auth: Bearer local-token\n")
+
+	conn, err := dialFromCommand(t, remotesFile, "--context", "local", "user-service", "get")
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, addr, conn.Target())
+	require.NoError(t, checkHealth(t, conn))
+	assert.Equal(t, []string{"Bearer local-token"}, seen.Get("authorization"))
+
+	conn, err = dialFromCommand(t, remotesFile, "user-service", "get", "--remote", "127.0.0.1:1")
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, "127.0.0.1:1", conn.Target())
+
+	_, err = dialFromCommand(t, remotesFile, "user-service", "get")
+	require.ErrorIs(t, err, protocli.ErrNoRemote)
+}
+
+func TestUnit_DialRemote_NoRemote(t *testing.T) {
+	_, err := protocli.DialRemote()
+	require.ErrorIs(t, err, protocli.ErrNoRemote)
+}
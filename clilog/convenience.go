@@ -15,6 +15,10 @@ type SlogConfigurationContext interface {
 
 	// Level returns the configured log level from the --verbosity flag
 	Level() slog.Level
+
+	// Color reports whether logs written to stderr should be colored, as
+	// decided by the --color flag
+	Color() bool
 }
 
 // MachineFriendlySlogHandler returns a JSON handler for machine-readable logging.
@@ -29,7 +33,8 @@ func MachineFriendlySlogHandler(w io.Writer, opts *slog.HandlerOptions) slog.Han
 // human-friendly logging format, regardless of daemon mode. This is useful for CLIs
 // that want a consistent user-friendly logging experience.
 //
-// The returned callback respects the --verbosity flag for log level filtering.
+// The returned callback respects the --verbosity flag for log level filtering
+// and the --color flag for colorized levels.
 //
 // Example:
 //
@@ -41,7 +46,7 @@ func AlwaysHumanFriendly() func(context.Context, SlogConfigurationContext) *slog
 	return func(_ context.Context, config SlogConfigurationContext) *slog.Logger {
 		handler := HumanFriendlySlogHandler(os.Stderr, &slog.HandlerOptions{
 			Level: config.Level(), // Respects --verbosity flag
-		})
+		}).WithColor(config.Color()) // Respects --color flag
 		return slog.New(handler)
 	}
 }
@@ -71,7 +76,8 @@ func AlwaysMachineFriendly() func(context.Context, SlogConfigurationContext) *sl
 // for single commands and machine-friendly (JSON) logging for daemon mode.
 // This is the recommended default for most CLI applications.
 //
-// The returned callback respects the --verbosity flag for log level filtering.
+// The returned callback respects the --verbosity flag for log level filtering
+// and the --color flag for colorized levels.
 //
 // Example:
 //
@@ -91,7 +97,7 @@ func Default() func(context.Context, SlogConfigurationContext) *slog.Logger {
 			// Single command mode: Human-friendly to stderr
 			handler = HumanFriendlySlogHandler(os.Stderr, &slog.HandlerOptions{
 				Level: config.Level(),
-			})
+			}).WithColor(config.Color())
 		}
 		return slog.New(handler)
 	}
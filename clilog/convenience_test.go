@@ -17,10 +17,12 @@ import (
 type mockConfig struct {
 	isDaemon bool
 	level    slog.Level
+	color    bool
 }
 
 func (m *mockConfig) IsDaemon() bool    { return m.isDaemon }
 func (m *mockConfig) Level() slog.Level { return m.level }
+func (m *mockConfig) Color() bool       { return m.color }
 
 func TestUnit_AlwaysHumanFriendly(t *testing.T) {
 	ctx := context.Background()
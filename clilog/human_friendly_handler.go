@@ -17,9 +17,11 @@ type HumanFriendlyHandler struct {
 	w     io.Writer
 	level slog.Leveler
 	attrs []slog.Attr
+	plain bool // Levels are written without ANSI colors
 }
 
 // HumanFriendlySlogHandler creates a new HumanFriendlyHandler that writes to w.
+// Levels are colorized unless disabled with WithColor.
 func HumanFriendlySlogHandler(w io.Writer, opts *slog.HandlerOptions) *HumanFriendlyHandler {
 	h := &HumanFriendlyHandler{
 		w: w,
@@ -44,7 +46,11 @@ func (h *HumanFriendlyHandler) Handle(_ context.Context, r slog.Record) error {
 	var buf []byte
 
 	// Add colorized level
-	buf = append(buf, colorizeLevel(r.Level)...)
+	if h.plain {
+		buf = append(buf, levelLabel(r.Level)...)
+	} else {
+		buf = append(buf, colorizeLevel(r.Level)...)
+	}
 	buf = append(buf, ' ')
 
 	// Add message
@@ -78,9 +84,18 @@ func (h *HumanFriendlyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 		w:     h.w,
 		level: h.level,
 		attrs: newAttrs,
+		plain: h.plain,
 	}
 }
 
+// WithColor returns a copy of the handler that colorizes levels only if color
+// is true, e.g. as decided by the --color flag.
+func (h *HumanFriendlyHandler) WithColor(color bool) *HumanFriendlyHandler {
+	c := *h
+	c.plain = !color
+	return &c
+}
+
 // WithGroup returns a new handler with the given group added.
 // Groups are not supported by HumanFriendlyHandler and this returns the same handler.
 func (h *HumanFriendlyHandler) WithGroup(_ string) slog.Handler {
@@ -101,13 +116,27 @@ const (
 func colorizeLevel(level slog.Level) string {
 	switch {
 	case level >= slog.LevelError:
-		return colorRed + "[ERROR]" + colorReset
+		return colorRed + levelLabel(level) + colorReset
+	case level >= slog.LevelWarn:
+		return colorYellow + levelLabel(level) + colorReset
+	case level >= slog.LevelInfo:
+		return colorBlue + levelLabel(level) + colorReset
+	default:
+		return colorGray + levelLabel(level) + colorReset
+	}
+}
+
+// levelLabel returns the plain string representation of the log level.
+func levelLabel(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "[ERROR]"
 	case level >= slog.LevelWarn:
-		return colorYellow + "[WARN]" + colorReset
+		return "[WARN]"
 	case level >= slog.LevelInfo:
-		return colorBlue + "[INFO]" + colorReset
+		return "[INFO]"
 	default:
-		return colorGray + "[DEBUG]" + colorReset
+		return "[DEBUG]"
 	}
 }
 
@@ -228,6 +228,18 @@ func TestUnit_HumanHandler_Colorization(t *testing.T) {
 	}
 }
 
+func TestUnit_HumanHandler_WithColor(t *testing.T) {
+	var buf bytes.Buffer
+	handler := clilog.HumanFriendlySlogHandler(&buf, nil)
+
+	slog.New(handler.WithColor(false).WithAttrs([]slog.Attr{slog.String("k", "v")})).Warn("test")
+	assert.Equal(t, "[WARN] test k=v\n", buf.String(), "attributes keep the handler plain")
+
+	buf.Reset()
+	slog.New(handler.WithColor(true)).Warn("test")
+	assert.Contains(t, buf.String(), "\033[33m[WARN]\033[0m")
+}
+
 func TestUnit_HumanHandler_NilOptions(t *testing.T) {
 	var buf bytes.Buffer
 	// Should not panic with nil options
@@ -1,20 +1,34 @@
 package main
 
 import (
+	"flag"
+
 	"github.com/drewfead/proto-cli/internal/generate"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
 func main() {
-	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+	var opts generate.Options
+	var flags flag.FlagSet
+	flags.Var(&opts.FlagCase, "flag_case", "flag name casing: kebab, snake, or camel")
+	flags.BoolVar(&opts.AllServices, "all_services", false, "emit BuildAllServicesCLI wiring every service in a file")
+	flags.Var(&opts.CLIFramework, "cli_framework", "command backend: urfave or cobra")
+	flags.BoolVar(&opts.Mocks, "mocks", false, "emit Mock<Service>Server test doubles")
+	flags.Var(&opts.SplitOutput, "split_output", "split generated code into files: none, per-service, or per-method")
+	flags.BoolVar(&opts.FileFlags, "file_flags", false, "let every string flag read its value from @file or @- (stdin)")
+	flags.Var(&opts.Instrument, "instrument", "tracing baked into generated actions: none or otel")
+
+	protogen.Options{ParamFunc: flags.Set}.Run(func(gen *protogen.Plugin) error {
 		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
 
 		for _, f := range gen.Files {
 			if !f.Generate {
 				continue
 			}
-			generate.GenerateFile(gen, f)
+			if err := generate.GenerateFile(gen, f, opts); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
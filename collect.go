@@ -0,0 +1,110 @@
+package protocli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// StreamCollector gathers the messages of a server stream for --collect into
+// one container message, e.g. WatchItemsResults{items: [...]}, which is
+// written once the stream ends. Without --collect it is inactive and does
+// nothing.
+type StreamCollector struct {
+	results *dynamicpb.Message
+	items   protoreflect.List
+}
+
+// NewStreamCollector returns a collector for streams of item's type whose
+// container is named name (a full name, e.g. "example.WatchItemsResults").
+// The collector is inactive unless the --collect flag is set.
+func NewStreamCollector(cmd *cli.Command, name protoreflect.FullName, item proto.Message) (*StreamCollector, error) {
+	if !cmd.Bool("collect") {
+		return &StreamCollector{}, nil
+	}
+	desc, err := collectResultsDescriptor(name, item.ProtoReflect().Descriptor())
+	if err != nil {
+		return nil, fmt.Errorf("failed to build --collect message: %w", err)
+	}
+	results := dynamicpb.NewMessage(desc)
+	return &StreamCollector{
+		results: results,
+		items:   results.Mutable(desc.Fields().ByNumber(1)).List(),
+	}, nil
+}
+
+// collectResultsDescriptor builds the descriptor of a container message named
+// name with a single field `repeated <item> items = 1`.
+func collectResultsDescriptor(name protoreflect.FullName, item protoreflect.MessageDescriptor) (protoreflect.MessageDescriptor, error) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("protocli/collect/" + string(name) + ".proto"),
+		Package:    proto.String(string(name.Parent())),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{item.ParentFile().Path()},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String(string(name.Name())),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("items"),
+				JsonName: proto.String("items"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String("." + string(item.FullName())),
+			}},
+		}},
+	}
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	if err != nil {
+		return nil, err
+	}
+	return fd.Messages().Get(0), nil
+}
+
+// Collecting reports whether --collect is set, i.e. messages go into the
+// container instead of being written one by one.
+func (c *StreamCollector) Collecting() bool {
+	return c.results != nil
+}
+
+// Add appends msg to the container and reports whether it was collected, in
+// which case the caller must not write it itself.
+func (c *StreamCollector) Add(msg proto.Message) bool {
+	if !c.Collecting() {
+		return false
+	}
+	c.items.Append(protoreflect.ValueOfMessage(msg.ProtoReflect()))
+	return true
+}
+
+// Write renders the container with outputFmt followed by a newline unless the
+// format opts out with TrailingNewlineControl, or as one length-prefixed frame
+// with --raw. It does nothing unless collecting.
+func (c *StreamCollector) Write(ctx context.Context, cmd *cli.Command, w io.Writer, outputFmt OutputFormat, raw bool) error {
+	if !c.Collecting() {
+		return nil
+	}
+	if raw {
+		if err := WriteRawMessage(w, c.results); err != nil {
+			return fmt.Errorf("failed to write message: %w", err)
+		}
+		return nil
+	}
+	if err := FormatMessage(ctx, cmd, outputFmt, w, c.results); err != nil {
+		return fmt.Errorf("format failed: %w", err)
+	}
+	if !NeedsTrailingNewline(outputFmt) {
+		return nil
+	}
+	if _, err := w.Write([]byte("\n")); err != nil {
+		return fmt.Errorf("failed to write final newline: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+)
+
+// Modes of the global --color flag.
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// ErrUnknownColorMode is returned for a --color value other than auto,
+// always, or never.
+var ErrUnknownColorMode = errors.New("unknown color mode")
+
+// ColorFlags returns the global --color flag and --no-color, its shorthand for
+// --color=never. RootCommand adds them to the root command; other CLI
+// frameworks can register them to give formats the same color control.
+func ColorFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "color",
+			Value: colorAuto,
+			Usage: "Color output and logs: auto (only on a terminal, unless NO_COLOR is set), always, or never",
+		},
+		&cli.BoolFlag{
+			Name:  "no-color",
+			Usage: "Same as --color=never",
+		},
+	}
+}
+
+// ColorEnabled reports whether output written to w should be colored, as
+// decided by the --color and --no-color flags of cmd's root command. In auto mode, the default,
+// output is colored only when w is a terminal and the NO_COLOR env var is
+// unset. Output formats call this with the writer they are given.
+func ColorEnabled(cmd *cli.Command, w any) bool {
+	enabled, _ := colorEnabled(cmd, w, isTerminal)
+	return enabled
+}
+
+// colorEnabled implements ColorEnabled with terminal reporting whether w is a
+// terminal, and fails for an unknown --color mode. The flags are read from
+// the root so a command's own flags can't shadow them.
+func colorEnabled(cmd *cli.Command, w any, terminal func(any) bool) (bool, error) {
+	root := cmd.Root()
+	if root.Bool("no-color") {
+		return false, nil
+	}
+	switch mode := root.String("color"); mode {
+	case "", colorAuto:
+		return os.Getenv("NO_COLOR") == "" && terminal(w), nil
+	case colorAlways:
+		return true, nil
+	case colorNever:
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w %q (available: %s, %s, %s)", ErrUnknownColorMode, mode, colorAuto, colorAlways, colorNever)
+	}
+}
@@ -0,0 +1,116 @@
+package protocli
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// resolveColor parses args against the color flags and resolves them for a
+// writer that is a terminal if terminal is set.
+func resolveColor(t *testing.T, terminal bool, args ...string) (bool, error) {
+	t.Helper()
+	var enabled bool
+	var resolveErr error
+	cmd := &cli.Command{
+		Name:  "get",
+		Flags: ColorFlags(),
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			enabled, resolveErr = colorEnabled(cmd, nil, func(any) bool { return terminal })
+			return nil
+		},
+	}
+	require.NoError(t, cmd.Run(context.Background(), append([]string{"get"}, args...)))
+	return enabled, resolveErr
+}
+
+func TestUnit_ColorEnabled_Modes(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		args     []string
+		terminal bool
+		noColor  string
+		want     bool
+	}{
+		{name: "auto on a terminal", terminal: true, want: true},
+		{name: "auto when piped", terminal: false, want: false},
+		{name: "auto with NO_COLOR", terminal: true, noColor: "1", want: false},
+		{name: "explicit auto", args: []string{"--color", "auto"}, terminal: true, want: true},
+		{name: "always when piped", args: []string{"--color", "always"}, terminal: false, want: true},
+		{name: "always overrides NO_COLOR", args: []string{"--color", "always"}, noColor: "1", want: true},
+		{name: "never on a terminal", args: []string{"--color", "never"}, terminal: true, want: false},
+		{name: "--no-color on a terminal", args: []string{"--no-color"}, terminal: true, want: false},
+		{name: "--no-color wins over always", args: []string{"--color", "always", "--no-color"}, terminal: true, want: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			got, err := resolveColor(t, tt.terminal, tt.args...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnit_ColorEnabled_UnknownMode(t *testing.T) {
+	_, err := resolveColor(t, true, "--color", "sometimes")
+	require.ErrorIs(t, err, ErrUnknownColorMode)
+	assert.Contains(t, err.Error(), `"sometimes"`)
+}
+
+// TestUnit_ColorEnabled_ReadsRoot tests that a subcommand flag named color
+// doesn't shadow the global one.
+func TestUnit_ColorEnabled_ReadsRoot(t *testing.T) {
+	var enabled bool
+	root := &cli.Command{
+		Name:  "testcli",
+		Flags: ColorFlags(),
+		Commands: []*cli.Command{{
+			Name:  "paint",
+			Flags: []cli.Flag{&cli.StringFlag{Name: "color"}},
+			Action: func(_ context.Context, cmd *cli.Command) error {
+				var err error
+				enabled, err = colorEnabled(cmd, nil, func(any) bool { return false })
+				return err
+			},
+		}},
+	}
+	require.NoError(t, root.Run(context.Background(), []string{"testcli", "--color", "always", "paint", "--color", "red"}))
+	assert.True(t, enabled)
+}
+
+// runColorRoot runs a root command with args and returns the color decision
+// passed to its logging configuration.
+func runColorRoot(t *testing.T, args ...string) (bool, error) {
+	t.Helper()
+	var color bool
+	rootCmd, err := RootCommand("testcli",
+		ConfigureLogging(func(_ context.Context, config SlogConfigurationContext) *slog.Logger {
+			color = config.Color()
+			return slog.Default()
+		}),
+	)
+	require.NoError(t, err)
+	rootCmd.Writer = io.Discard
+	err = rootCmd.Run(context.Background(), append([]string{"testcli"}, args...))
+	return color, err
+}
+
+func TestIntegration_Color_LoggingConfig(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	color, err := runColorRoot(t, "--color", "always")
+	require.NoError(t, err)
+	assert.True(t, color)
+
+	color, err = runColorRoot(t, "--color", "always", "--no-color")
+	require.NoError(t, err)
+	assert.False(t, color)
+
+	_, err = runColorRoot(t, "--color", "sometimes")
+	require.ErrorIs(t, err, ErrUnknownColorMode)
+}
@@ -0,0 +1,111 @@
+package protocli
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadCommandSettings returns the flag settings for the command at path
+// (e.g. "user-service/get") from the commands section of the config files:
+//
+//	commands:
+//	  user-service/get:
+//	    format: yaml
+//	    yaml-indent: 4
+//
+// Settings from later files override earlier ones per flag. Files that do not
+// exist are skipped, as with LoadServiceConfig.
+func (l *ConfigLoader) LoadCommandSettings(path string) (map[string]any, error) {
+	settings := make(map[string]any)
+	merge := func(data []byte) error {
+		var root struct {
+			Commands map[string]map[string]any `yaml:"commands"`
+		}
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return fmt.Errorf("invalid YAML: %w", err)
+		}
+		maps.Copy(settings, root.Commands[path])
+		return nil
+	}
+
+	for _, configPath := range l.configPaths {
+		data, err := os.ReadFile(configPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", configPath, err)
+		}
+		if err := merge(data); err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", configPath, err)
+		}
+	}
+	for i, reader := range l.configReaders {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config reader %d: %w", i, err)
+		}
+		if err := merge(data); err != nil {
+			return nil, fmt.Errorf("failed to load config reader %d: %w", i, err)
+		}
+	}
+	return settings, nil
+}
+
+// ApplyCommandConfig sets the flags of cmd that were not given on the command
+// line (or by environment variable) from its entry in the commands section of
+// the --config files, so teams can standardize e.g. the output format of a
+// command. Generated commands call this before reading their flags.
+func ApplyCommandConfig(cmd *cli.Command) error {
+	path := commandPath(cmd)
+	loader := NewConfigLoader(SingleCommandMode, FileConfig(cmd.Root().StringSlice("config")...))
+	settings, err := loader.LoadCommandSettings(path)
+	if err != nil {
+		return fmt.Errorf("failed to load command config: %w", err)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(settings)) {
+		if cmd.IsSet(name) {
+			continue
+		}
+		values, err := settingValues(settings[name])
+		if err == nil {
+			for _, v := range values {
+				if err = cmd.Set(name, v); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("config commands[%q]: --%s: %w", path, name, err)
+		}
+	}
+	return nil
+}
+
+// settingValues converts a YAML setting to flag values: one for a scalar, one
+// per element for a list.
+func settingValues(value any) ([]string, error) {
+	switch v := value.(type) {
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, elem := range v {
+			s, err := settingValues(elem)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, s...)
+		}
+		return values, nil
+	case map[string]any:
+		return nil, fmt.Errorf("unsupported value %v: expected a scalar or a list", v)
+	default:
+		return []string{fmt.Sprint(v)}, nil
+	}
+}
@@ -0,0 +1,70 @@
+package protocli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+// MethodMetadataKey is the Metadata key holding the gRPC full method name
+// (e.g. "/example.UserService/GetUser") on generated method commands.
+const MethodMetadataKey = "protocli:method"
+
+// ServiceMetadataKey is the Metadata key holding the service command name
+// (e.g. "user-service") on generated service commands, and on the commands of
+// hoisted services. Generated cobra service commands hold it in Annotations.
+const ServiceMetadataKey = "protocli:service"
+
+// RequestFlagsMetadataKey is the Metadata key holding the names ([]string) of
+// the flags that set request fields on generated method commands, as opposed
+// to the flags the framework adds, such as --format or --remote.
+const RequestFlagsMetadataKey = "protocli:request-flags"
+
+// CommandFilter decides, when the command tree is assembled, whether the
+// command for the gRPC full method fullMethod is listed in help (visible) and
+// whether it can be run (enabled).
+type CommandFilter func(fullMethod string) (visible bool, enabled bool)
+
+// ErrCommandNotAvailable is wrapped by the error returned when a command
+// disabled by WithCommandFilter is run.
+var ErrCommandNotAvailable = errors.New("not available")
+
+// applyCommandFilter hides the method commands under cmds that filter marks
+// invisible and replaces those it marks disabled with disabledCommand.
+func applyCommandFilter(cmds []*cli.Command, filter CommandFilter) {
+	for i, cmd := range cmds {
+		applyCommandFilter(cmd.Commands, filter)
+		method, ok := cmd.Metadata[MethodMetadataKey].(string)
+		if !ok {
+			continue
+		}
+		visible, enabled := filter(method)
+		if !enabled {
+			cmds[i] = disabledCommand(cmd)
+		}
+		if !visible {
+			cmds[i].Hidden = true
+		}
+	}
+}
+
+// disabledCommand returns a stand-in for cmd that keeps its name and help
+// entry but ignores flags and arguments and fails with ErrCommandNotAvailable,
+// so required flags are not reported for a command that cannot run anyway.
+func disabledCommand(cmd *cli.Command) *cli.Command {
+	return &cli.Command{
+		Name:            cmd.Name,
+		Aliases:         cmd.Aliases,
+		Usage:           cmd.Usage,
+		Category:        cmd.Category,
+		Hidden:          cmd.Hidden,
+		Metadata:        cmd.Metadata,
+		SkipFlagParsing: true,
+		HideHelp:        true,
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			return fmt.Errorf("command %q is %w", cmd.FullName(), ErrCommandNotAvailable)
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	// Registers the gzip compressor, so that --compression gzip works for
+	// clients and the daemon can decompress such calls.
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// compressionNone disables compression for --compression and WithRemoteCompression.
+const compressionNone = "none"
+
+// ErrUnknownCompression is returned for a --compression or
+// WithRemoteCompression value naming no registered gRPC compressor.
+var ErrUnknownCompression = errors.New("unknown compression")
+
+// RemoteCallOptions returns the gRPC call options for the command's remote
+// calls: grpc.UseCompressor for --compression unless it is empty or "none".
+// Generated commands pass these to every remote call.
+func RemoteCallOptions(cmd *cli.Command) ([]grpc.CallOption, error) {
+	name := cmd.String("compression")
+	if name == "" || name == compressionNone {
+		return nil, nil
+	}
+	if err := checkCompression(name); err != nil {
+		return nil, err
+	}
+	return []grpc.CallOption{grpc.UseCompressor(name)}, nil
+}
+
+// checkCompression returns ErrUnknownCompression unless name is a registered
+// gRPC compressor.
+func checkCompression(name string) error {
+	if encoding.GetCompressor(name) == nil {
+		return fmt.Errorf("%w %q (available: gzip, %s)", ErrUnknownCompression, name, compressionNone)
+	}
+	return nil
+}
+
+// applyCompressionDefault makes the --compression flag of every command under
+// cmd default to name.
+func applyCompressionDefault(cmd *cli.Command, name string) {
+	for _, sub := range cmd.Commands {
+		applyCompressionDefault(sub, name)
+		for _, flag := range sub.Flags {
+			if f, ok := flag.(*cli.StringFlag); ok && f.Name == "compression" && f.Value == "" {
+				f.Value = name
+			}
+		}
+	}
+}
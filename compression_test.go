@@ -0,0 +1,95 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+)
+
+// compressionRecorder is a server stats handler recording the compression of
+// each incoming call.
+type compressionRecorder struct {
+	mu          sync.Mutex
+	compressors []string
+}
+
+func (r *compressionRecorder) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if h, ok := s.(*stats.InHeader); ok {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.compressors = append(r.compressors, h.Compression)
+	}
+}
+
+func (r *compressionRecorder) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (r *compressionRecorder) HandleConn(context.Context, stats.ConnStats) {}
+
+func (r *compressionRecorder) last() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.compressors) == 0 {
+		return "<no call>"
+	}
+	return r.compressors[len(r.compressors)-1]
+}
+
+// runRemoteGet runs `user-service get` against the daemon at addr with a
+// client root command built with opts.
+func runRemoteGet(t *testing.T, addr string, opts []protocli.RootOption, args ...string) error {
+	t.Helper()
+	ctx := context.Background()
+	factory := func(*simple.UserServiceConfig) simple.UserServiceServer { return &simple.MockUserServiceServer{} }
+	rootCmd, err := protocli.RootCommand("testcli", append([]protocli.RootOption{
+		protocli.Service(simple.UserServiceCommand(ctx, factory, protocli.WithOutputFormats(protocli.JSON()))),
+	}, opts...)...)
+	require.NoError(t, err)
+	setWriterOnAllCommands(rootCmd, &bytes.Buffer{})
+	return rootCmd.Run(ctx, append([]string{
+		"testcli", "user-service", "get", "--id", "1", "--db-url", "postgres://localhost/test", "--remote", addr,
+	}, args...))
+}
+
+// TestIntegration_RemoteCompression tests that --compression gzip compresses
+// remote calls, that the daemon decompresses them and that
+// WithRemoteCompression sets the default.
+func TestIntegration_RemoteCompression(t *testing.T) {
+	recorder := &compressionRecorder{}
+	startAccessLoggedDaemon(t, "50214", protocli.WithGRPCServerOptions(grpc.StatsHandler(recorder)))
+	addr := "localhost:50214"
+
+	require.NoError(t, runRemoteGet(t, addr, nil))
+	assert.Empty(t, recorder.last(), "calls are uncompressed by default")
+
+	require.NoError(t, runRemoteGet(t, addr, nil, "--compression", "gzip"))
+	assert.Equal(t, "gzip", recorder.last())
+
+	withDefault := []protocli.RootOption{protocli.WithRemoteCompression("gzip")}
+	require.NoError(t, runRemoteGet(t, addr, withDefault))
+	assert.Equal(t, "gzip", recorder.last(), "WithRemoteCompression sets the default")
+
+	require.NoError(t, runRemoteGet(t, addr, withDefault, "--compression", "none"))
+	assert.Empty(t, recorder.last(), "--compression none overrides the default")
+}
+
+func TestUnit_RemoteCompression_Unknown(t *testing.T) {
+	err := runRemoteGet(t, "localhost:1", nil, "--compression", "zstd")
+	require.ErrorIs(t, err, protocli.ErrUnknownCompression)
+
+	_, err = protocli.RootCommand("testcli", protocli.WithRemoteCompression("zstd"))
+	require.ErrorIs(t, err, protocli.ErrUnknownCompression)
+}
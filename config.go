@@ -33,18 +33,23 @@ type ConfigDebugInfo struct {
 	FilesLoaded    []string          // Paths that were successfully loaded
 	FilesFailed    map[string]string // Paths that failed with error message
 	EnvVarsApplied map[string]string // Env vars that were applied (name -> value)
+	SecretsApplied map[string]string // Secret files that were applied (path -> field, values are not recorded)
 	FlagsApplied   map[string]string // CLI flags that were applied (name -> value)
 	FinalConfig    any               // Final merged config (for display)
 }
 
-// ConfigLoader loads configuration with precedence: CLI flags > env vars > files.
+// ConfigLoader loads configuration with precedence: CLI flags > secret files > env vars > files.
 type ConfigLoader struct {
 	configPaths   []string
 	configReaders []io.Reader
 	envPrefix     string
+	secretDir     string
 	mode          ConfigMode
 	debug         bool
 	debugInfo     *ConfigDebugInfo
+	migrations    []ConfigMigration
+	migratedEnv   map[string]string // Values of renamed env vars, keyed by their new name
+	validate      bool              // Check required fields once config is merged
 }
 
 // ConfigLoaderOption is a functional option for configuring a ConfigLoader.
@@ -71,6 +76,17 @@ func EnvPrefix(prefix string) ConfigLoaderOption {
 	}
 }
 
+// SecretDir sets a directory of single-value secret files, such as a mounted
+// Kubernetes secret. A file named after a config field path in snake_case or
+// kebab-case (database_password or database-password for database.password)
+// sets that field to its trimmed contents, overriding env vars. An empty path
+// disables secret files.
+func SecretDir(path string) ConfigLoaderOption {
+	return func(l *ConfigLoader) {
+		l.secretDir = path
+	}
+}
+
 // DebugMode enables config loading debug information.
 func DebugMode(enabled bool) ConfigLoaderOption {
 	return func(l *ConfigLoader) {
@@ -81,6 +97,7 @@ func DebugMode(enabled bool) ConfigLoaderOption {
 				FilesLoaded:    []string{},
 				FilesFailed:    make(map[string]string),
 				EnvVarsApplied: make(map[string]string),
+				SecretsApplied: make(map[string]string),
 				FlagsApplied:   make(map[string]string),
 			}
 		}
@@ -103,6 +120,9 @@ func (l *ConfigLoader) DebugInfo() *ConfigDebugInfo {
 	return l.debugInfo
 }
 
+// configLoadedHooksKey is the Metadata key storing the WithConfigLoadedHook hooks on the root command.
+const configLoadedHooksKey = "protocli:configLoadedHooks"
+
 // DefaultConfigPaths returns default paths for config files.
 func DefaultConfigPaths(rootCommandName string) []string {
 	home, _ := os.UserHomeDir()
@@ -122,33 +142,58 @@ func (l *ConfigLoader) LoadServiceConfig(
 	serviceName string,
 	target proto.Message,
 ) error {
+	migrations := l.configMigrations(cmd)
+
 	// 1. Load and deep merge from all config files
-	if err := l.loadFromFiles(serviceName, target); err != nil {
+	if err := l.loadFromFiles(serviceName, target, migrations); err != nil {
 		return fmt.Errorf("failed to load config from files: %w", err)
 	}
 
 	// 2. Override with environment variables
+	if err := l.migrateEnvVars(serviceName, migrations); err != nil {
+		return fmt.Errorf("failed to apply environment variables: %w", err)
+	}
 	if err := l.applyEnvVars(target); err != nil {
 		return fmt.Errorf("failed to apply environment variables: %w", err)
 	}
 
-	// 3. Override with CLI flags (only if mode == SingleCommandMode)
+	// 3. Override with secret files
+	if err := l.applySecretDir(target); err != nil {
+		return fmt.Errorf("failed to apply secret files: %w", err)
+	}
+
+	// 4. Override with CLI flags (only if mode == SingleCommandMode)
 	if l.mode == SingleCommandMode && cmd != nil {
 		if err := l.applyFlags(cmd, target); err != nil {
 			return fmt.Errorf("failed to apply CLI flags: %w", err)
 		}
 	}
 
-	// 4. Save final config for debugging
+	// 5. Check that required fields were set by some source
+	if l.validateRequired(cmd) {
+		if err := ValidateRequiredConfig(serviceName, target); err != nil {
+			return err
+		}
+	}
+
+	// 6. Save final config for debugging
 	if l.debug {
 		l.debugInfo.FinalConfig = target
 	}
 
+	// 7. Let WithConfigLoadedHook observe the result
+	if cmd != nil {
+		hooks, _ := cmd.Root().Metadata[configLoadedHooksKey].([]ConfigLoadedHook)
+		for _, hook := range hooks {
+			hook(serviceName, target)
+		}
+	}
+
 	return nil
 }
 
 // loadFromFiles loads and deep merges config from multiple YAML files and readers.
-func (l *ConfigLoader) loadFromFiles(serviceName string, target proto.Message) error {
+func (l *ConfigLoader) loadFromFiles(serviceName string, target proto.Message, migrations []ConfigMigration) error {
 	// Load from file paths
 	for _, path := range l.configPaths {
 		if l.debug {
@@ -171,7 +216,7 @@ func (l *ConfigLoader) loadFromFiles(serviceName string, target proto.Message) e
 			return fmt.Errorf("failed to read %s: %w", path, err)
 		}
 
-		if err := l.loadYAMLServiceFromData(data, serviceName, target); err != nil {
+		if err := l.loadYAMLServiceFromData(data, serviceName, target, migrations); err != nil {
 			if l.debug {
 				l.debugInfo.FilesFailed[path] = err.Error()
 			}
@@ -190,7 +235,7 @@ func (l *ConfigLoader) loadFromFiles(serviceName string, target proto.Message) e
 			return fmt.Errorf("failed to read config reader %d: %w", i, err)
 		}
 
-		if err := l.loadYAMLServiceFromData(data, serviceName, target); err != nil {
+		if err := l.loadYAMLServiceFromData(data, serviceName, target, migrations); err != nil {
 			return fmt.Errorf("failed to load config reader %d: %w", i, err)
 		}
 	}
@@ -198,14 +243,24 @@ func (l *ConfigLoader) loadFromFiles(serviceName string, target proto.Message) e
 	return nil
 }
 
-// loadYAMLServiceFromData loads YAML from bytes and extracts service section.
-func (l *ConfigLoader) loadYAMLServiceFromData(data []byte, serviceName string, target proto.Message) error {
+// loadYAMLServiceFromData loads YAML from bytes, applies migrations and
+// extracts the service section.
+func (l *ConfigLoader) loadYAMLServiceFromData(data []byte, serviceName string, target proto.Message, migrations []ConfigMigration) error {
 	// Parse YAML into map
 	var root map[string]any
 	if err := yaml.Unmarshal(data, &root); err != nil {
 		return fmt.Errorf("invalid YAML: %w", err)
 	}
 
+	// Rename keys written for older versions
+	if root != nil {
+		applied, err := migrateConfigData(root, migrations)
+		if err != nil {
+			return err
+		}
+		warnMigratedConfigKeys(applied)
+	}
+
 	// Extract services section
 	services, ok := root["services"].(map[string]any)
 	if !ok {
@@ -858,7 +913,7 @@ func (l *ConfigLoader) applyEnvVarsWithPath(msg protoreflect.Message, prefix str
 		}
 
 		// Check if env var is set
-		envValue, exists := os.LookupEnv(envName)
+		envValue, exists := l.lookupEnv(envName)
 		if !exists {
 			continue
 		}
@@ -877,6 +932,82 @@ func (l *ConfigLoader) applyEnvVarsWithPath(msg protoreflect.Message, prefix str
 	return nil
 }
 
+// applySecretDir overrides fields with the contents of files in the secret dir.
+func (l *ConfigLoader) applySecretDir(target proto.Message) error {
+	if l.secretDir == "" {
+		return nil
+	}
+
+	return l.applySecretsWithPath(target.ProtoReflect(), "", "")
+}
+
+// applySecretsWithPath mirrors applyEnvVarsWithPath: nested messages extend the
+// file name with "_" (or "-" in its kebab-case form).
+func (l *ConfigLoader) applySecretsWithPath(msg protoreflect.Message, prefix string, fieldPath string) error {
+	fields := msg.Descriptor().Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+
+		fieldName := string(field.Name())
+		secretName := fieldName
+		currentPath := fieldName
+		if prefix != "" {
+			secretName = prefix + "_" + fieldName
+			currentPath = fieldPath + "." + fieldName
+		}
+
+		// Handle nested messages recursively
+		if field.Kind() == protoreflect.MessageKind && !field.IsList() && !field.IsMap() {
+			if !msg.Has(field) {
+				nestedMsg := msg.NewField(field).Message()
+				msg.Set(field, protoreflect.ValueOfMessage(nestedMsg))
+			}
+
+			if err := l.applySecretsWithPath(msg.Get(field).Message(), secretName, currentPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		path, value, found, err := l.readSecretFile(secretName)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+
+		// Track debug info (never the secret value itself)
+		if l.debug {
+			l.debugInfo.SecretsApplied[path] = currentPath
+		}
+
+		if err := l.setFieldFromString(msg, field, value); err != nil {
+			return fmt.Errorf("failed to set field %s from secret file %s: %w", currentPath, path, err)
+		}
+	}
+
+	return nil
+}
+
+// readSecretFile returns the trimmed contents of the secret file for the
+// snake_case name, trying the snake_case then the kebab-case file name.
+func (l *ConfigLoader) readSecretFile(snakeName string) (path, value string, found bool, err error) {
+	for _, name := range []string{snakeName, strings.ReplaceAll(snakeName, "_", "-")} {
+		path = filepath.Join(l.secretDir, name)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", "", false, fmt.Errorf("failed to read secret file %s: %w", path, err)
+		}
+		return path, strings.TrimSpace(string(data)), true, nil
+	}
+	return "", "", false, nil
+}
+
 // applyFlags overrides fields with CLI flags (single-command mode only).
 func (l *ConfigLoader) applyFlags(cmd *cli.Command, target proto.Message) error {
 	return l.applyFlagsRecursive(cmd, target.ProtoReflect(), "", "")
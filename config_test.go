@@ -5,6 +5,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -542,6 +543,63 @@ services:
 	assert.Equal(t, int32(30), config.Database.TimeoutSeconds)
 }
 
+// TestUnit_ConfigLoader_SecretDir tests that single-value secret files map to
+// top-level and nested fields by snake_case or kebab-case name, overriding env vars.
+func TestUnit_ConfigLoader_SecretDir(t *testing.T) {
+	yamlContent := `
+services:
+  userservice:
+    database-url: postgresql://file/db
+    database:
+      timeout-seconds: 5
+`
+	dir := t.TempDir()
+	secrets := map[string]string{
+		"database_url":              "postgresql://secret/db\n",
+		"database-max-connections":  "  25  ",
+		"database_timeout_seconds":  "60",
+		"max_connections":           "7",
+		"unrelated-file-is-ignored": "x",
+	}
+	for name, value := range secrets {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(value), 0o600))
+	}
+	t.Setenv("TEST_SECRET_MAX_CONNECTIONS", "50")
+
+	loader := protocli.NewConfigLoader(
+		protocli.DaemonMode,
+		protocli.ReaderConfig(bytes.NewBufferString(yamlContent)),
+		protocli.EnvPrefix("TEST_SECRET"),
+		protocli.SecretDir(dir),
+		protocli.DebugMode(true),
+	)
+
+	config := &simple.UserServiceConfig{}
+	require.NoError(t, loader.LoadServiceConfig(nil, "userservice", config))
+
+	assert.Equal(t, "postgresql://secret/db", config.GetDatabaseUrl(), "contents are trimmed")
+	assert.Equal(t, int64(7), config.GetMaxConnections(), "secret files override env vars")
+	require.NotNil(t, config.GetDatabase())
+	assert.Equal(t, int32(25), config.GetDatabase().GetMaxConnections(), "kebab-case file name")
+	assert.Equal(t, int32(60), config.GetDatabase().GetTimeoutSeconds(), "snake_case file name")
+
+	applied := loader.DebugInfo().SecretsApplied
+	assert.Equal(t, "database.max_connections", applied[filepath.Join(dir, "database-max-connections")])
+	assert.NotContains(t, applied, filepath.Join(dir, "unrelated-file-is-ignored"))
+}
+
+// TestUnit_ConfigLoader_SecretDirInvalidValue tests that a secret file that
+// does not parse reports the field and file.
+func TestUnit_ConfigLoader_SecretDirInvalidValue(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "max-connections"), []byte("lots"), 0o600))
+
+	loader := protocli.NewConfigLoader(protocli.DaemonMode, protocli.SecretDir(dir))
+	err := loader.LoadServiceConfig(nil, "userservice", &simple.UserServiceConfig{})
+	require.ErrorContains(t, err, "max_connections")
+	require.ErrorContains(t, err, "max-connections")
+}
+
 // TestUnit_ConfigLoader_OneofTypes tests oneof (union) type support.
 func TestUnit_ConfigLoader_OneofTypes(t *testing.T) {
 	tests := []struct {
@@ -878,3 +936,36 @@ services:
 	assert.NotNil(t, debug.FlagsApplied)
 	assert.NotNil(t, debug.FinalConfig)
 }
+
+func TestUnit_ConfigLoader_LoadCommandSettings(t *testing.T) {
+	base := `
+commands:
+  user-service/get:
+    format: json
+    pretty: true
+  user-service/list:
+    format: yaml
+`
+	override := `
+services:
+  userservice:
+    database-url: postgresql://file/db
+commands:
+  user-service/get:
+    format: yaml
+    fields: [id, name]
+`
+	loader := protocli.NewConfigLoader(
+		protocli.SingleCommandMode,
+		protocli.FileConfig(filepath.Join(t.TempDir(), "missing.yaml")),
+		protocli.ReaderConfig(bytes.NewBufferString(base), bytes.NewBufferString(override)),
+	)
+
+	settings, err := loader.LoadCommandSettings("user-service/get")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"format": "yaml",
+		"pretty": true,
+		"fields": []any{"id", "name"},
+	}, settings, "later sources override earlier ones per flag")
+}
@@ -0,0 +1,85 @@
+package protocli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// configEnvVar describes one environment variable recognized by the loader.
+type configEnvVar struct {
+	Name  string `json:"name"`  // Environment variable, e.g. "USERCLI_DATABASE_URL"
+	Field string `json:"field"` // Proto field path, e.g. "database.url"
+	Set   bool   `json:"set"`   // Whether the variable is set in the current environment
+}
+
+// configEnvVars lists the environment variables applyEnvVars consults for
+// configMsg, in field order. Empty without an env prefix.
+func configEnvVars(loader *ConfigLoader, configMsg proto.Message, serviceName string) []configEnvVar {
+	vars := []configEnvVar{}
+	if loader.envPrefix == "" {
+		return vars
+	}
+	for _, sources := range loader.configFieldSources(configMsg.ProtoReflect().Descriptor(), serviceName) {
+		_, set := os.LookupEnv(sources.EnvVar)
+		vars = append(vars, configEnvVar{Name: sources.EnvVar, Field: sources.Field, Set: set})
+	}
+	return vars
+}
+
+// writeConfigEnvVars prints vars as an aligned table, or as a JSON array when
+// format is "json".
+func writeConfigEnvVars(w io.Writer, vars []configEnvVar, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(vars)
+	case "text":
+		if len(vars) == 0 {
+			_, err := fmt.Fprintln(w, "(none: no env prefix configured)")
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "VARIABLE\tFIELD\tSET")
+		for _, v := range vars {
+			set := "no"
+			if v.Set {
+				set = "yes"
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Name, v.Field, set)
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("%w %q (available: [text json])", ErrUnknownFormat, format)
+	}
+}
+
+// newEnvCommand returns `env`, which lists every environment variable that can
+// override a config field and whether it is currently set.
+func newEnvCommand(configMsg proto.Message, serviceName string) *cli.Command {
+	return &cli.Command{
+		Name:  "env",
+		Usage: "List the environment variables that override config fields",
+		Description: "Variable names are derived from the env prefix and the config field path.\n" +
+			"Example: mycli env --format json",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "text",
+				Usage: "Output format (text or json)",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			loader := NewConfigLoader(SingleCommandMode, EnvPrefix(cmd.Root().String("env-prefix")))
+			vars := configEnvVars(loader, configMsg, serviceName)
+			return writeConfigEnvVars(cmd.Root().Writer, vars, cmd.String("format"))
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runConfigEnv(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	rootCmd, err := protocli.RootCommand("testapp",
+		protocli.WithConfigManagementCommands(&simple.UserServiceConfig{}, "testapp", "userservice"),
+		protocli.WithEnvPrefix("TESTAPP"),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	err = rootCmd.Run(context.Background(), append([]string{"testapp", "env"}, args...))
+	return buf.String(), err
+}
+
+func TestIntegration_ConfigEnv_NestedConfig(t *testing.T) {
+	t.Setenv("TESTAPP_DATABASE_MAX_CONNECTIONS", "20")
+	golden := filepath.Join("testdata", "config_env_nested.golden")
+
+	out, err := runConfigEnv(t)
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile(golden, []byte(out), 0o600))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), out)
+}
+
+func TestIntegration_ConfigEnv_JSON(t *testing.T) {
+	t.Setenv("TESTAPP_DATABASE_URL", "postgres://localhost")
+
+	out, err := runConfigEnv(t, "--format", "json")
+	require.NoError(t, err)
+
+	var vars []struct {
+		Name  string `json:"name"`
+		Field string `json:"field"`
+		Set   bool   `json:"set"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &vars))
+	require.NotEmpty(t, vars)
+	assert.Equal(t, "TESTAPP_DATABASE_URL", vars[0].Name)
+	assert.Equal(t, "database_url", vars[0].Field)
+	for _, v := range vars {
+		// database.url maps to the same variable as the top-level database_url
+		assert.Equal(t, v.Name == "TESTAPP_DATABASE_URL", v.Set, v.Name)
+	}
+}
+
+func TestIntegration_ConfigEnv_UnsupportedFormat(t *testing.T) {
+	_, err := runConfigEnv(t, "--format", "xml")
+	require.ErrorIs(t, err, protocli.ErrUnknownFormat)
+}
@@ -0,0 +1,111 @@
+package protocli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ConfigFieldSources lists every input the ConfigLoader consults for a single
+// config field, using the same naming rules as the loader itself.
+type ConfigFieldSources struct {
+	Field    string // Proto field path, e.g. "database.url"
+	YAMLPath string // Key path in config files, e.g. "services.userservice.database.url"
+	EnvVar   string // Environment variable, e.g. "USERCLI_DATABASE_URL" ("" without an env prefix)
+	Flag     string // Command-line flag without dashes, e.g. "database-url"
+}
+
+// ExplainConfigField returns the sources that can set the config field named
+// by key. key may be the proto field path ("database.url"), the config file
+// path ("database.max-connections") or the flag name ("database-url").
+// Returns ErrUnknownField if nothing in target matches.
+func (l *ConfigLoader) ExplainConfigField(target proto.Message, serviceName, key string) (ConfigFieldSources, error) {
+	for _, sources := range l.configFieldSources(target.ProtoReflect().Descriptor(), serviceName) {
+		if key == sources.Field || key == sources.Flag ||
+			key == strings.TrimPrefix(sources.YAMLPath, "services."+serviceName+".") {
+			return sources, nil
+		}
+	}
+	return ConfigFieldSources{}, fmt.Errorf("%w: %s", ErrUnknownField, key)
+}
+
+// configFieldSources lists the sources of every scalar, list and map field in desc.
+func (l *ConfigLoader) configFieldSources(desc protoreflect.MessageDescriptor, serviceName string) []ConfigFieldSources {
+	var all []ConfigFieldSources
+	l.collectConfigFieldSources(desc, ConfigFieldSources{
+		YAMLPath: "services." + serviceName,
+		EnvVar:   l.envPrefix,
+	}, &all)
+	return all
+}
+
+// collectConfigFieldSources mirrors mergeConfigWithPath, applyEnvVarsWithPath
+// and applyFlagsRecursive: nested messages extend the YAML path with ".",
+// the env var with "_" and the flag name with "-".
+func (l *ConfigLoader) collectConfigFieldSources(desc protoreflect.MessageDescriptor, parent ConfigFieldSources, all *[]ConfigFieldSources) {
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldName := string(field.Name())
+
+		sources := ConfigFieldSources{
+			Field:    fieldName,
+			YAMLPath: parent.YAMLPath + "." + strings.ReplaceAll(fieldName, "_", "-"),
+			Flag:     l.getFlagName(field),
+		}
+		if parent.Field != "" {
+			sources.Field = parent.Field + "." + fieldName
+		}
+		if parent.Flag != "" {
+			sources.Flag = parent.Flag + "-" + sources.Flag
+		}
+		if parent.EnvVar != "" {
+			sources.EnvVar = parent.EnvVar + "_" + strings.ToUpper(fieldName)
+		}
+
+		if field.Kind() == protoreflect.MessageKind && !field.IsList() && !field.IsMap() {
+			l.collectConfigFieldSources(field.Message(), sources, all)
+			continue
+		}
+		*all = append(*all, sources)
+	}
+}
+
+// writeConfigFieldSources prints sources in precedence order (highest first).
+func writeConfigFieldSources(w io.Writer, sources ConfigFieldSources) error {
+	envVar := sources.EnvVar
+	if envVar == "" {
+		envVar = "(none: no env prefix configured)"
+	}
+	_, err := fmt.Fprintf(w, "%s\n  flag:        --%s\n  env var:     %s\n  config file: %s\n",
+		sources.Field, sources.Flag, envVar, sources.YAMLPath)
+	return err
+}
+
+// newConfigExplainCommand returns `config explain <key>`, which lists every
+// source that can set a config field in the order they take precedence.
+func newConfigExplainCommand(configMsg proto.Message, serviceName string) *cli.Command {
+	return &cli.Command{
+		Name:      "explain",
+		Usage:     "Show every flag, env var and config file key that can set a value",
+		ArgsUsage: "<key>",
+		Description: "Sources are listed in precedence order: flag > env var > config file.\n" +
+			"Example: mycli config explain database.url",
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() != 1 {
+				return cli.Exit("usage: config explain <key>", 3)
+			}
+			loader := NewConfigLoader(SingleCommandMode, EnvPrefix(cmd.Root().String("env-prefix")))
+			sources, err := loader.ExplainConfigField(configMsg, serviceName, cmd.Args().First())
+			if err != nil {
+				return err
+			}
+			return writeConfigFieldSources(cmd.Root().Writer, sources)
+		},
+	}
+}
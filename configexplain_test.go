@@ -0,0 +1,62 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func runConfigExplain(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	rootCmd, err := protocli.RootCommand("testapp",
+		protocli.WithConfigManagementCommands(&simple.UserServiceConfig{}, "testapp", "userservice"),
+		protocli.WithEnvPrefix("TESTAPP"),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	err = rootCmd.Run(context.Background(), append([]string{"testapp", "config", "explain"}, args...))
+	return buf.String(), err
+}
+
+func TestIntegration_ConfigExplain_NestedField(t *testing.T) {
+	golden := filepath.Join("testdata", "config_explain_nested.golden")
+
+	// The proto path, config file path and flag name all resolve to the same field
+	for _, key := range []string{"database.max_connections", "database.max-connections", "database-max-connections"} {
+		out, err := runConfigExplain(t, key)
+		require.NoError(t, err, key)
+
+		if *update {
+			require.NoError(t, os.WriteFile(golden, []byte(out), 0o600))
+		}
+		want, err := os.ReadFile(golden)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), out, key)
+	}
+}
+
+func TestIntegration_ConfigExplain_TopLevelFlagName(t *testing.T) {
+	out, err := runConfigExplain(t, "db-url")
+	require.NoError(t, err)
+	assert.Contains(t, out, "database_url\n")
+	assert.Contains(t, out, "--db-url")
+	assert.Contains(t, out, "TESTAPP_DATABASE_URL")
+	assert.Contains(t, out, "services.userservice.database-url")
+}
+
+func TestIntegration_ConfigExplain_UnknownKey(t *testing.T) {
+	_, err := runConfigExplain(t, "database.nope")
+	require.ErrorIs(t, err, protocli.ErrUnknownField)
+}
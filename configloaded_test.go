@@ -0,0 +1,69 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestIntegration_ConfigLoadedHook tests that WithConfigLoadedHook receives
+// the config merged from the config file and flags before the
+// factory is called.
+func TestIntegration_ConfigLoadedHook(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "testcli.yaml")
+	require.NoError(t, os.WriteFile(configPath,
+		[]byte("services:\n  userservice:\n    database-url: postgres://file/db\n    max-connections: 5\n"), 0o600))
+
+	var calls []string
+	var loaded *simple.UserServiceConfig
+	factory := func(cfg *simple.UserServiceConfig) simple.UserServiceServer {
+		calls = append(calls, "factory")
+		return &simple.MockUserServiceServer{
+			GetUserFunc: func(context.Context, *simple.GetUserRequest) (*simple.UserResponse, error) {
+				return &simple.UserResponse{}, nil
+			},
+		}
+	}
+	hook := func(serviceName string, cfg proto.Message) {
+		calls = append(calls, "hook:"+serviceName)
+		loaded = proto.Clone(cfg).(*simple.UserServiceConfig)
+	}
+
+	ctx := context.Background()
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(simple.UserServiceCommand(ctx, factory, protocli.WithOutputFormats(protocli.JSON()))),
+		protocli.WithConfigFile(configPath),
+		protocli.WithConfigLoadedHook(hook),
+	)
+	require.NoError(t, err)
+	setWriterOnAllCommands(rootCmd, &bytes.Buffer{})
+
+	err = rootCmd.Run(ctx, []string{"testcli", "user-service", "get", "--id", "1", "--db-url", "postgres://flag/db"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hook:userservice", "factory"}, calls)
+	require.NotNil(t, loaded)
+	assert.Equal(t, "postgres://flag/db", loaded.GetDatabaseUrl(), "flags override the config file")
+	assert.Equal(t, int64(5), loaded.GetMaxConnections(), "values from the config file are kept")
+}
+
+func TestUnit_ConfigLoadedHook_NotCalledOnError(t *testing.T) {
+	called := false
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.WithRequiredConfigValidation(),
+		protocli.WithConfigLoadedHook(func(string, proto.Message) { called = true }),
+	)
+	require.NoError(t, err)
+
+	loader := protocli.NewConfigLoader(protocli.DaemonMode)
+	err = loader.LoadServiceConfig(rootCmd, "userservice", &simple.UserServiceConfig{})
+	require.ErrorIs(t, err, protocli.ErrMissingRequiredConfig)
+	assert.False(t, called)
+}
@@ -0,0 +1,415 @@
+package protocli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrConfigMigrationConflict is returned when a migration's ToKey passes
+// through a config file value that is not a section.
+var ErrConfigMigrationConflict = errors.New("config migration conflict")
+
+// configMigrationsKey is the Metadata key storing the WithConfigMigrations
+// migrations on the root command.
+const configMigrationsKey = "protocli:configMigrations"
+
+// ConfigMigration renames a config key. Keys are dot-separated paths in config
+// files. Keys under services.<service> also rename the matching env var, e.g.
+// USERCLI_DB_URL to USERCLI_DATABASE_URL for the keys below.
+type ConfigMigration struct {
+	FromKey   string                       // Old key, e.g. "services.userservice.db-url"
+	ToKey     string                       // New key, e.g. "services.userservice.database.url"
+	Transform func(value any) (any, error) // Converts the old value (nil keeps it unchanged)
+}
+
+// ConfigMigrations makes the loader read config written for older versions by
+// applying migrations to every config file and env var, logging a deprecation
+// warning for each old key it finds. Generated commands also pick up the
+// migrations registered with WithConfigMigrations.
+func ConfigMigrations(migrations ...ConfigMigration) ConfigLoaderOption {
+	return func(l *ConfigLoader) {
+		l.migrations = append(l.migrations, migrations...)
+	}
+}
+
+// configMigrations returns the loader's migrations followed by those registered
+// on the root of cmd with WithConfigMigrations.
+func (l *ConfigLoader) configMigrations(cmd *cli.Command) []ConfigMigration {
+	if cmd == nil {
+		return l.migrations
+	}
+	rootMigrations, _ := cmd.Root().Metadata[configMigrationsKey].([]ConfigMigration)
+	return append(append([]ConfigMigration(nil), l.migrations...), rootMigrations...)
+}
+
+// migrateConfigData applies migrations in order to parsed config file data and
+// returns the ones whose FromKey was present. A value already at ToKey wins
+// over the migrated one.
+func migrateConfigData(data map[string]any, migrations []ConfigMigration) ([]ConfigMigration, error) {
+	var applied []ConfigMigration
+	for _, m := range migrations {
+		value, ok := removeConfigKey(data, strings.Split(m.FromKey, "."))
+		if !ok {
+			continue
+		}
+		applied = append(applied, m)
+		if m.Transform != nil {
+			var err error
+			if value, err = m.Transform(value); err != nil {
+				return nil, fmt.Errorf("failed to migrate %s to %s: %w", m.FromKey, m.ToKey, err)
+			}
+		}
+		if err := setConfigKey(data, strings.Split(m.ToKey, "."), value); err != nil {
+			return nil, fmt.Errorf("failed to migrate %s to %s: %w", m.FromKey, m.ToKey, err)
+		}
+	}
+	return applied, nil
+}
+
+// removeConfigKey deletes the value at path from data, along with sections
+// left empty, and returns it.
+func removeConfigKey(data map[string]any, path []string) (any, bool) {
+	if len(path) == 1 {
+		value, ok := data[path[0]]
+		delete(data, path[0])
+		return value, ok
+	}
+	section, ok := data[path[0]].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	value, ok := removeConfigKey(section, path[1:])
+	if ok && len(section) == 0 {
+		delete(data, path[0])
+	}
+	return value, ok
+}
+
+// setConfigKey stores value at path in data, creating missing sections. An
+// existing value at path is kept.
+func setConfigKey(data map[string]any, path []string, value any) error {
+	for i, key := range path[:len(path)-1] {
+		section, ok := data[key].(map[string]any)
+		if !ok {
+			if _, exists := data[key]; exists {
+				return fmt.Errorf("%w: %s is not a section", ErrConfigMigrationConflict, strings.Join(path[:i+1], "."))
+			}
+			section = make(map[string]any)
+			data[key] = section
+		}
+		data = section
+	}
+	if _, exists := data[path[len(path)-1]]; !exists {
+		data[path[len(path)-1]] = value
+	}
+	return nil
+}
+
+// warnMigratedConfigKeys logs a deprecation warning for each applied migration.
+func warnMigratedConfigKeys(applied []ConfigMigration) {
+	for _, m := range applied {
+		slog.Warn("Config key is deprecated, run `config migrate` to update config files",
+			"key", m.FromKey, "replacement", m.ToKey)
+	}
+}
+
+// migrateEnvVars makes lookupEnv return the values of the env vars renamed by
+// migrations under services.<serviceName> under their new name. Old env vars
+// are ignored when the new one is set.
+func (l *ConfigLoader) migrateEnvVars(serviceName string, migrations []ConfigMigration) error {
+	migrated := make(map[string]string)
+	l.migratedEnv = migrated
+	if l.envPrefix == "" {
+		return nil
+	}
+	for _, m := range migrations {
+		fromEnv, fromOK := l.migrationEnvVar(serviceName, m.FromKey)
+		toEnv, toOK := l.migrationEnvVar(serviceName, m.ToKey)
+		if !fromOK || !toOK {
+			continue
+		}
+		value, ok := os.LookupEnv(fromEnv)
+		if !ok {
+			if value, ok = migrated[fromEnv]; !ok {
+				continue
+			}
+			delete(migrated, fromEnv)
+		}
+		if _, set := os.LookupEnv(toEnv); set {
+			continue
+		}
+		if m.Transform != nil {
+			transformed, err := m.Transform(value)
+			if err != nil {
+				return fmt.Errorf("failed to migrate %s to %s: %w", fromEnv, toEnv, err)
+			}
+			value = fmt.Sprint(transformed)
+		}
+		slog.Warn("Environment variable is deprecated", "env", fromEnv, "replacement", toEnv)
+		migrated[toEnv] = value
+	}
+	return nil
+}
+
+// migrationEnvVar returns the env var the loader reads for a config key under
+// services.<serviceName>, e.g. USERCLI_DATABASE_URL for
+// services.userservice.database.url.
+func (l *ConfigLoader) migrationEnvVar(serviceName, key string) (string, bool) {
+	field, ok := strings.CutPrefix(key, "services."+serviceName+".")
+	if !ok {
+		return "", false
+	}
+	return l.envPrefix + "_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(field)), true
+}
+
+// lookupEnv looks up an env var, falling back to the value of an old env var
+// renamed by a migration.
+func (l *ConfigLoader) lookupEnv(name string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+	value, ok := l.migratedEnv[name]
+	return value, ok
+}
+
+// MigrateConfigFile rewrites the config file at path with migrations applied,
+// after copying the original to path.bak, or to path.bak.1, path.bak.2 and so
+// on if that exists. Keys are renamed in place, so comments and key order are
+// kept. It returns the backup path, or "" for files without old keys, which are
+// left untouched.
+func MigrateConfigFile(path string, migrations []ConfigMigration) (string, error) {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(original, &doc); err != nil {
+		return "", fmt.Errorf("invalid YAML in %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return "", nil
+	}
+	applied, err := migrateConfigNode(doc.Content[0], migrations)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+	if len(applied) == 0 {
+		return "", nil
+	}
+
+	var migrated bytes.Buffer
+	enc := yaml.NewEncoder(&migrated)
+	enc.SetIndent(yamlIndent(original))
+	if err := enc.Encode(&doc); err != nil {
+		return "", fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	backup, err := backupConfigFile(path, original, info.Mode().Perm())
+	if err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, migrated.Bytes(), info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return backup, nil
+}
+
+// migrateConfigNode applies migrations to the top-level mapping of a config
+// file like migrateConfigData does to parsed data, but moves the key and value
+// nodes so their comments are kept. A key renamed within its section keeps its
+// position; otherwise it is appended to the new section.
+func migrateConfigNode(root *yaml.Node, migrations []ConfigMigration) ([]ConfigMigration, error) {
+	var applied []ConfigMigration
+	for _, m := range migrations {
+		removed, ok := removeConfigNode(root, strings.Split(m.FromKey, "."))
+		if !ok {
+			continue
+		}
+		applied = append(applied, m)
+		if m.Transform != nil {
+			var err error
+			if removed.value, err = transformConfigNode(removed.value, m.Transform); err != nil {
+				return nil, fmt.Errorf("failed to migrate %s to %s: %w", m.FromKey, m.ToKey, err)
+			}
+		}
+		if err := setConfigNode(root, strings.Split(m.ToKey, "."), removed); err != nil {
+			return nil, fmt.Errorf("failed to migrate %s to %s: %w", m.FromKey, m.ToKey, err)
+		}
+	}
+	return applied, nil
+}
+
+// removedConfigNode is a key/value pair removed from the mapping section at
+// index, where a key renamed within the same section is put back.
+type removedConfigNode struct {
+	key, value *yaml.Node
+	section    *yaml.Node
+	index      int
+}
+
+// removeConfigNode deletes the pair at path from mapping, along with sections
+// left empty, and returns it.
+func removeConfigNode(mapping *yaml.Node, path []string) (removedConfigNode, bool) {
+	i := configNodeIndex(mapping, path[0])
+	if i < 0 {
+		return removedConfigNode{}, false
+	}
+	if len(path) == 1 {
+		removed := removedConfigNode{key: mapping.Content[i], value: mapping.Content[i+1], section: mapping, index: i}
+		mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+		return removed, true
+	}
+	section := mapping.Content[i+1]
+	if section.Kind != yaml.MappingNode {
+		return removedConfigNode{}, false
+	}
+	removed, ok := removeConfigNode(section, path[1:])
+	if ok && len(section.Content) == 0 {
+		mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+	}
+	return removed, ok
+}
+
+// setConfigNode stores the removed pair at path in mapping, creating missing
+// sections. An existing value at path is kept.
+func setConfigNode(mapping *yaml.Node, path []string, removed removedConfigNode) error {
+	for i, key := range path[:len(path)-1] {
+		j := configNodeIndex(mapping, key)
+		if j < 0 {
+			section := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, section)
+			mapping = section
+			continue
+		}
+		if mapping.Content[j+1].Kind != yaml.MappingNode {
+			return fmt.Errorf("%w: %s is not a section", ErrConfigMigrationConflict, strings.Join(path[:i+1], "."))
+		}
+		mapping = mapping.Content[j+1]
+	}
+	if configNodeIndex(mapping, path[len(path)-1]) >= 0 {
+		return nil
+	}
+	removed.key.Value = path[len(path)-1]
+	pair := []*yaml.Node{removed.key, removed.value}
+	if mapping == removed.section {
+		mapping.Content = append(mapping.Content[:removed.index], append(pair, mapping.Content[removed.index:]...)...)
+	} else {
+		mapping.Content = append(mapping.Content, pair...)
+	}
+	return nil
+}
+
+// configNodeIndex returns the index of the key node named key in mapping, or
+// -1 if there is none.
+func configNodeIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// transformConfigNode returns value converted by transform, keeping its
+// comments.
+func transformConfigNode(value *yaml.Node, transform func(any) (any, error)) (*yaml.Node, error) {
+	var decoded any
+	if err := value.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	transformed, err := transform(decoded)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := node.Encode(transformed); err != nil {
+		return nil, err
+	}
+	node.HeadComment, node.LineComment, node.FootComment = value.HeadComment, value.LineComment, value.FootComment
+	return &node, nil
+}
+
+// yamlIndent returns the indentation of the first indented line of a YAML
+// document, 2 if it has none.
+func yamlIndent(doc []byte) int {
+	for _, line := range strings.Split(string(doc), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if indent := len(line) - len(trimmed); indent >= 2 && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return indent
+		}
+	}
+	return 2
+}
+
+// backupConfigFile writes data to path.bak, or to path.bak.N for the lowest N
+// that does not exist, and returns the backup path. Existing backups are never
+// overwritten.
+func backupConfigFile(path string, data []byte, perm os.FileMode) (string, error) {
+	for n := 0; ; n++ {
+		backup := path + ".bak"
+		if n > 0 {
+			backup = fmt.Sprintf("%s.bak.%d", path, n)
+		}
+		f, err := os.OpenFile(backup, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		_, err = f.Write(data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return backup, err
+	}
+}
+
+// newConfigMigrateCommand returns `config migrate [file...]`, which rewrites
+// config files (by default those of --config that exist) to the current keys.
+func newConfigMigrateCommand(migrations []ConfigMigration) *cli.Command {
+	return &cli.Command{
+		Name:      "migrate",
+		Usage:     "Rewrite config files that use renamed keys, keeping a .bak copy of each",
+		ArgsUsage: "[file...]",
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			paths := cmd.Args().Slice()
+			if len(paths) == 0 {
+				for _, path := range cmd.Root().StringSlice("config") {
+					if _, err := os.Stat(path); err == nil {
+						paths = append(paths, path)
+					}
+				}
+			}
+
+			w := cmd.Root().Writer
+			for _, path := range paths {
+				backup, err := MigrateConfigFile(path, migrations)
+				if err != nil {
+					return err
+				}
+				if backup != "" {
+					_, _ = fmt.Fprintf(w, "Migrated %s (original saved to %s)\n", path, backup)
+				} else {
+					_, _ = fmt.Fprintf(w, "%s is up to date\n", path)
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,189 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+var userServiceMigrations = []protocli.ConfigMigration{
+	{
+		FromKey: "services.userservice.db-host",
+		ToKey:   "services.userservice.database-url",
+		Transform: func(value any) (any, error) {
+			return fmt.Sprintf("postgres://%v/db", value), nil
+		},
+	},
+	{FromKey: "services.userservice.pool.size", ToKey: "services.userservice.max-connections"},
+}
+
+// captureSlog routes the default logger to a buffer for the rest of the test.
+func captureSlog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &logs
+}
+
+// TestUnit_ConfigMigrations_RenamedKey tests that the loader reads renamed
+// config file keys under their new name and warns about the old one.
+func TestUnit_ConfigMigrations_RenamedKey(t *testing.T) {
+	logs := captureSlog(t)
+	loader := protocli.NewConfigLoader(protocli.SingleCommandMode,
+		protocli.ReaderConfig(strings.NewReader(`
+services:
+  userservice:
+    db-host: old
+    pool:
+      size: 7
+`)),
+		protocli.ConfigMigrations(userServiceMigrations...),
+	)
+
+	config := &simple.UserServiceConfig{}
+	require.NoError(t, loader.LoadServiceConfig(nil, "userservice", config))
+	assert.Equal(t, "postgres://old/db", config.DatabaseUrl)
+	assert.Equal(t, int64(7), config.MaxConnections)
+	assert.Contains(t, logs.String(), "key=services.userservice.db-host replacement=services.userservice.database-url")
+}
+
+func TestUnit_ConfigMigrations_NewKeyWins(t *testing.T) {
+	loader := protocli.NewConfigLoader(protocli.SingleCommandMode,
+		protocli.ReaderConfig(strings.NewReader(`
+services:
+  userservice:
+    db-host: old
+    database-url: postgres://new/db
+`)),
+		protocli.ConfigMigrations(userServiceMigrations...),
+	)
+
+	config := &simple.UserServiceConfig{}
+	require.NoError(t, loader.LoadServiceConfig(nil, "userservice", config))
+	assert.Equal(t, "postgres://new/db", config.DatabaseUrl)
+}
+
+// TestUnit_ConfigMigrations_RenamedEnvVar tests that the env var of a renamed
+// key is still applied under the new name unless the new env var is set.
+func TestUnit_ConfigMigrations_RenamedEnvVar(t *testing.T) {
+	logs := captureSlog(t)
+	t.Setenv("TEST_PREFIX_DB_HOST", "env")
+	t.Setenv("TEST_PREFIX_POOL_SIZE", "12")
+
+	load := func() *simple.UserServiceConfig {
+		loader := protocli.NewConfigLoader(protocli.SingleCommandMode,
+			protocli.EnvPrefix("TEST_PREFIX"),
+			protocli.ConfigMigrations(userServiceMigrations...),
+		)
+		config := &simple.UserServiceConfig{}
+		require.NoError(t, loader.LoadServiceConfig(nil, "userservice", config))
+		return config
+	}
+
+	config := load()
+	assert.Equal(t, "postgres://env/db", config.DatabaseUrl)
+	assert.Equal(t, int64(12), config.MaxConnections)
+	assert.Contains(t, logs.String(), "env=TEST_PREFIX_DB_HOST replacement=TEST_PREFIX_DATABASE_URL")
+
+	t.Setenv("TEST_PREFIX_DATABASE_URL", "postgres://env-new/db")
+	assert.Equal(t, "postgres://env-new/db", load().DatabaseUrl)
+}
+
+// TestIntegration_ConfigMigrateCommand tests that `config migrate` rewrites a
+// config file to the new keys and keeps the original as a .bak file.
+func TestIntegration_ConfigMigrateCommand(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "testapp.yaml")
+	original := "services:\n  userservice:\n    db-host: old\n    max-connections: 3\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(original), 0o600))
+
+	serviceCLI := &protocli.ServiceCLI{
+		Command:           &cli.Command{Name: "userservice"},
+		ServiceName:       "userservice",
+		ConfigMessageType: "example.UserServiceConfig",
+		ConfigPrototype:   &simple.UserServiceConfig{},
+	}
+	rootCmd, err := protocli.RootCommand("testapp",
+		protocli.Service(serviceCLI),
+		protocli.WithConfigManagementCommands(&simple.UserServiceConfig{}, "testapp", "userservice"),
+		protocli.WithConfigFile(configPath),
+		protocli.WithConfigMigrations(userServiceMigrations),
+	)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	rootCmd.Writer = &out
+
+	require.NoError(t, rootCmd.Run(context.Background(), []string{"testapp", "config", "migrate"}))
+	assert.Contains(t, out.String(), "Migrated "+configPath)
+
+	backup, err := os.ReadFile(configPath + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, original, string(backup))
+
+	migrated, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(migrated), "database-url: postgres://old/db")
+	assert.NotContains(t, string(migrated), "db-host")
+	assert.Contains(t, string(migrated), "max-connections: 3")
+
+	// A second run finds nothing left to migrate
+	out.Reset()
+	require.NoError(t, rootCmd.Run(context.Background(), []string{"testapp", "config", "migrate"}))
+	assert.Contains(t, out.String(), configPath+" is up to date")
+}
+
+// TestUnit_MigrateConfigFile_KeepsCommentsAndBackups tests that migrating a
+// file keeps its comments and key order, and that a second migration keeps the
+// first backup.
+func TestUnit_MigrateConfigFile_KeepsCommentsAndBackups(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "testapp.yaml")
+	original := `# Written by hand
+services:
+  userservice:
+    # Primary database
+    db-host: old # moved in 2.0
+    max-connections: 3
+    pool:
+      size: 7
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(original), 0o600))
+
+	backup, err := protocli.MigrateConfigFile(configPath, userServiceMigrations[:1])
+	require.NoError(t, err)
+	assert.Equal(t, configPath+".bak", backup)
+
+	migrated, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, `# Written by hand
+services:
+  userservice:
+    # Primary database
+    database-url: postgres://old/db # moved in 2.0
+    max-connections: 3
+    pool:
+      size: 7
+`, string(migrated))
+
+	backup, err = protocli.MigrateConfigFile(configPath, userServiceMigrations[1:])
+	require.NoError(t, err)
+	assert.Equal(t, configPath+".bak.1", backup)
+
+	first, err := os.ReadFile(configPath + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, original, string(first), "the first backup is not overwritten")
+	second, err := os.ReadFile(configPath + ".bak.1")
+	require.NoError(t, err)
+	assert.Equal(t, string(migrated), string(second))
+}
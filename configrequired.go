@@ -0,0 +1,87 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	annotations "github.com/drewfead/proto-cli/proto/cli/v1"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrMissingRequiredConfig is returned when required config fields are unset
+// after loading (see WithRequiredConfigValidation).
+var ErrMissingRequiredConfig = errors.New("missing required config")
+
+// requiredConfigValidationKey is the Metadata key set on the root command by
+// WithRequiredConfigValidation.
+const requiredConfigValidationKey = "protocli:requiredConfigValidation"
+
+// RequiredConfigValidation makes the loader check that every config field
+// annotated with (cli.flag).required is set once all sources are merged.
+// Generated commands also enable it when WithRequiredConfigValidation is set.
+func RequiredConfigValidation() ConfigLoaderOption {
+	return func(l *ConfigLoader) {
+		l.validate = true
+	}
+}
+
+// validateRequired reports whether the loader or the root of cmd enabled
+// required config validation.
+func (l *ConfigLoader) validateRequired(cmd *cli.Command) bool {
+	if l.validate || cmd == nil {
+		return l.validate
+	}
+	enabled, _ := cmd.Root().Metadata[requiredConfigValidationKey].(bool)
+	return enabled
+}
+
+// ValidateRequiredConfig checks that every field of config annotated with
+// (cli.flag).required, including those of set nested messages, has a non-zero
+// value. The returned error wraps ErrMissingRequiredConfig and names all
+// missing fields by their config key under services.<serviceName>, followed by
+// the flag that sets each one.
+func ValidateRequiredConfig(serviceName string, config proto.Message) error {
+	missing := missingRequiredFields(config.ProtoReflect(), "services."+serviceName, "")
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrMissingRequiredConfig, strings.Join(missing, ", "))
+}
+
+// missingRequiredFields returns the unset required fields of msg as
+// "<keyPath>.<key> (--<flag>)", where flags of nested fields start with
+// flagPrefix as in applyFlagsRecursive.
+func missingRequiredFields(msg protoreflect.Message, keyPath, flagPrefix string) []string {
+	var missing []string
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		key := keyPath + "." + strings.ReplaceAll(string(field.Name()), "_", "-")
+		flagName := (&ConfigLoader{}).getFlagName(field)
+		if flagPrefix != "" {
+			flagName = flagPrefix + "-" + flagName
+		}
+
+		set := msg.Has(field)
+		if set && field.Kind() == protoreflect.MessageKind && !field.IsList() && !field.IsMap() {
+			missing = append(missing, missingRequiredFields(msg.Get(field).Message(), key, flagName)...)
+		}
+		if !set && isRequiredField(field) {
+			missing = append(missing, fmt.Sprintf("%s (--%s)", key, flagName))
+		}
+	}
+	return missing
+}
+
+// isRequiredField reports whether field is annotated with (cli.flag).required.
+func isRequiredField(field protoreflect.FieldDescriptor) bool {
+	opts := field.Options()
+	if opts == nil || !proto.HasExtension(opts, annotations.E_Flag) {
+		return false
+	}
+	flagOpts, ok := proto.GetExtension(opts, annotations.E_Flag).(*annotations.FlagOptions)
+	return ok && flagOpts.GetRequired()
+}
@@ -0,0 +1,50 @@
+package protocli_test
+
+import (
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadUserServiceConfig(t *testing.T, yamlConfig string, opts ...protocli.ConfigLoaderOption) error {
+	t.Helper()
+	loader := protocli.NewConfigLoader(protocli.DaemonMode,
+		append([]protocli.ConfigLoaderOption{protocli.ReaderConfig(strings.NewReader(yamlConfig))}, opts...)...)
+	return loader.LoadServiceConfig(nil, "userservice", &simple.UserServiceConfig{})
+}
+
+// TestUnit_RequiredConfigValidation_Missing tests that a required field left
+// unset by every source is reported by its config key and flag.
+func TestUnit_RequiredConfigValidation_Missing(t *testing.T) {
+	err := loadUserServiceConfig(t, "services:\n  userservice:\n    max-connections: 5\n",
+		protocli.RequiredConfigValidation())
+	require.ErrorIs(t, err, protocli.ErrMissingRequiredConfig)
+	assert.Contains(t, err.Error(), "services.userservice.database-url (--db-url)")
+
+	// Without validation the same config loads as before
+	require.NoError(t, loadUserServiceConfig(t, "services:\n  userservice:\n    max-connections: 5\n"))
+}
+
+func TestUnit_RequiredConfigValidation_Present(t *testing.T) {
+	require.NoError(t, loadUserServiceConfig(t, "services:\n  userservice:\n    database-url: postgres://localhost/db\n",
+		protocli.RequiredConfigValidation()))
+
+	t.Setenv("TEST_PREFIX_DATABASE_URL", "postgres://env/db")
+	require.NoError(t, loadUserServiceConfig(t, "", protocli.EnvPrefix("TEST_PREFIX"), protocli.RequiredConfigValidation()),
+		"env vars count as a source")
+}
+
+// TestUnit_WithRequiredConfigValidation tests that loaders used under a root
+// command with WithRequiredConfigValidation validate without the loader option.
+func TestUnit_WithRequiredConfigValidation(t *testing.T) {
+	rootCmd, err := protocli.RootCommand("testcli", protocli.WithRequiredConfigValidation())
+	require.NoError(t, err)
+
+	loader := protocli.NewConfigLoader(protocli.DaemonMode, protocli.ReaderConfig(strings.NewReader("")))
+	err = loader.LoadServiceConfig(rootCmd, "userservice", &simple.UserServiceConfig{})
+	require.ErrorIs(t, err, protocli.ErrMissingRequiredConfig)
+}
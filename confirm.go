@@ -0,0 +1,63 @@
+package protocli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+var (
+	// ErrConfirmationRequired is returned when a command annotated with
+	// cli.command.confirm runs without --yes and stdin is not a terminal.
+	ErrConfirmationRequired = errors.New("confirmation required")
+	// ErrNotConfirmed is returned when the user does not answer yes to the
+	// confirmation prompt.
+	ErrNotConfirmed = errors.New("not confirmed")
+)
+
+// ConfirmBefore asks "Are you sure? [y/N]" on stderr and reads the answer from
+// stdin, failing with ErrNotConfirmed unless it is "y" or "yes". It is the
+// Before hook of commands annotated with cli.command.confirm. --yes skips the
+// prompt, and is required (ErrConfirmationRequired) when stdin is not a
+// terminal so that scripts never block on it.
+func ConfirmBefore(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	if cmd.Bool("yes") {
+		return ctx, nil
+	}
+	in := cmd.Root().Reader
+	if in == nil {
+		in = os.Stdin
+	}
+	w := cmd.Root().ErrWriter
+	if w == nil {
+		w = os.Stderr
+	}
+	return ctx, Confirm(cmd.FullName(), in, w)
+}
+
+// Confirm asks "Are you sure? [y/N]" on w before running the command name and
+// reads the answer from in, as ConfirmBefore does once --yes is ruled out. It
+// is exported for the cobra backend.
+func Confirm(name string, in io.Reader, w io.Writer) error {
+	if !isTerminal(in) {
+		return fmt.Errorf("%w: stdin is not a terminal, pass --yes to run %s", ErrConfirmationRequired, name)
+	}
+
+	_, _ = fmt.Fprint(w, "Are you sure? [y/N] ")
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return fmt.Errorf("%s %w: %w", name, ErrNotConfirmed, err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return fmt.Errorf("%s %w", name, ErrNotConfirmed)
+	}
+}
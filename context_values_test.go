@@ -0,0 +1,47 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type greetingKey struct{}
+type tenantKey struct{}
+
+func TestIntegration_ContextValues_VisibleToService(t *testing.T) {
+	ctx := context.Background()
+
+	var gotGreeting, gotTenant any
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(ctx context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			gotGreeting = ctx.Value(greetingKey{})
+			gotTenant = ctx.Value(tenantKey{})
+			return &simple.UserResponse{User: &simple.User{Id: req.GetId()}}, nil
+		},
+	}
+	factory := func(_ *simple.UserServiceConfig) simple.UserServiceServer { return mock }
+
+	userCLI := simple.UserServiceCommand(ctx, factory, protocli.WithOutputFormats(protocli.JSON()))
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(userCLI),
+		protocli.WithContextValues(func(ctx context.Context) context.Context {
+			return context.WithValue(ctx, greetingKey{}, "hello")
+		}),
+		// Later functions see values set by earlier ones
+		protocli.WithContextValues(func(ctx context.Context) context.Context {
+			return context.WithValue(ctx, tenantKey{}, ctx.Value(greetingKey{}).(string)+"-tenant")
+		}),
+	)
+	require.NoError(t, err)
+	setWriterOnAllCommands(rootCmd, &bytes.Buffer{})
+
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "user-service", "get", "--id", "1", "--db-url", "postgres://localhost/test"}))
+	assert.Equal(t, "hello", gotGreeting)
+	assert.Equal(t, "hello-tenant", gotTenant)
+}
@@ -0,0 +1,286 @@
+// Package cobracli provides the runtime glue for CLIs generated with the
+// cli_framework=cobra plugin option. Generated cobra commands share request
+// building, validation and output formatting with the default urfave/cli
+// backend; this package adapts cobra's flags and root command to that runtime.
+package cobracli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// FlagSet adapts a pflag.FlagSet to protocli.FlagReader so generated request
+// builders can read cobra flags. Accessors return the zero value when a flag is
+// not defined or has a different type, matching urfave/cli behaviour.
+type FlagSet struct {
+	fs *pflag.FlagSet
+}
+
+var _ protocli.FlagReader = FlagSet{}
+
+// Flags returns a protocli.FlagReader over the flags of c.
+func Flags(c *cobra.Command) FlagSet {
+	return FlagSet{fs: c.Flags()}
+}
+
+// IsSet reports whether the named flag was explicitly set on the command line.
+func (f FlagSet) IsSet(name string) bool {
+	flag := f.fs.Lookup(name)
+	return flag != nil && flag.Changed
+}
+
+func (f FlagSet) String(name string) string {
+	v, _ := f.fs.GetString(name)
+	return v
+}
+
+func (f FlagSet) Bool(name string) bool {
+	v, _ := f.fs.GetBool(name)
+	return v
+}
+
+func (f FlagSet) Int(name string) int {
+	v, _ := f.fs.GetInt(name)
+	return v
+}
+
+func (f FlagSet) Int32(name string) int32 {
+	v, _ := f.fs.GetInt32(name)
+	return v
+}
+
+func (f FlagSet) Int64(name string) int64 {
+	v, _ := f.fs.GetInt64(name)
+	return v
+}
+
+func (f FlagSet) Uint(name string) uint {
+	v, _ := f.fs.GetUint(name)
+	return v
+}
+
+func (f FlagSet) Uint32(name string) uint32 {
+	v, _ := f.fs.GetUint32(name)
+	return v
+}
+
+func (f FlagSet) Uint64(name string) uint64 {
+	v, _ := f.fs.GetUint64(name)
+	return v
+}
+
+func (f FlagSet) Float(name string) float64 {
+	return f.Float64(name)
+}
+
+func (f FlagSet) Float32(name string) float32 {
+	v, _ := f.fs.GetFloat32(name)
+	return v
+}
+
+func (f FlagSet) Float64(name string) float64 {
+	v, _ := f.fs.GetFloat64(name)
+	return v
+}
+
+func (f FlagSet) StringSlice(name string) []string {
+	v, _ := f.fs.GetStringSlice(name)
+	return v
+}
+
+func (f FlagSet) Int32Slice(name string) []int32 {
+	v, _ := f.fs.GetInt32Slice(name)
+	return v
+}
+
+func (f FlagSet) Int64Slice(name string) []int64 {
+	v, _ := f.fs.GetInt64Slice(name)
+	return v
+}
+
+// Uint32Slice reads a flag registered with pflag's UintSlice, which has no
+// fixed-width unsigned variants.
+func (f FlagSet) Uint32Slice(name string) []uint32 {
+	v, _ := f.fs.GetUintSlice(name)
+	if v == nil {
+		return nil
+	}
+	out := make([]uint32, len(v))
+	for i, n := range v {
+		out[i] = uint32(n) //nolint:gosec // values were parsed from uint flags declared for uint32 fields
+	}
+	return out
+}
+
+// Uint64Slice reads a flag registered with pflag's UintSlice.
+func (f FlagSet) Uint64Slice(name string) []uint64 {
+	v, _ := f.fs.GetUintSlice(name)
+	if v == nil {
+		return nil
+	}
+	out := make([]uint64, len(v))
+	for i, n := range v {
+		out[i] = uint64(n)
+	}
+	return out
+}
+
+func (f FlagSet) Float32Slice(name string) []float32 {
+	v, _ := f.fs.GetFloat32Slice(name)
+	return v
+}
+
+func (f FlagSet) Float64Slice(name string) []float64 {
+	v, _ := f.fs.GetFloat64Slice(name)
+	return v
+}
+
+// RootCommand creates a cobra root command named appName with the persistent
+// --config, --env-prefix and --secret-dir flags read by generated commands and the
+// --color and --no-color flags read by output formats, and registers cmds
+// (typically <Service>CobraCommand results) as subcommands.
+func RootCommand(appName string, cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:           appName,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.PersistentFlags().StringSlice("config", protocli.DefaultConfigPaths(appName), "Config file paths (can be repeated)")
+	root.PersistentFlags().String("env-prefix", "", "Environment variable prefix for config overrides")
+	root.PersistentFlags().String("secret-dir", "", "Directory of single-value secret files for config overrides")
+	root.PersistentFlags().String("color", "auto", "Color output: auto (only on a terminal, unless NO_COLOR is set), always, or never")
+	root.PersistentFlags().Bool("no-color", false, "Same as --color=never")
+	root.AddCommand(cmds...)
+	return root
+}
+
+// LoadServiceConfig loads the named service's configuration into target from
+// the files given by the root --config flag, environment variables prefixed
+// by --env-prefix and the secret files in --secret-dir. Config flag overrides
+// are not supported by the cobra backend.
+func LoadServiceConfig(c *cobra.Command, serviceName string, target proto.Message) error {
+	flags := c.Root().PersistentFlags()
+	configPaths, _ := flags.GetStringSlice("config")
+	envPrefix, _ := flags.GetString("env-prefix")
+	secretDir, _ := flags.GetString("secret-dir")
+
+	loader := protocli.NewConfigLoader(
+		protocli.SingleCommandMode,
+		protocli.FileConfig(configPaths...),
+		protocli.EnvPrefix(envPrefix),
+		protocli.SecretDir(secretDir),
+	)
+	return loader.LoadServiceConfig(nil, serviceName, target)
+}
+
+// OutputWriter opens the output file at path, or returns the command's output
+// writer (stdout unless overridden with SetOut) when path is empty or "-".
+// The --output-mode and --output-append flags are honored when registered, and
+// path is expanded with protocli.ExpandOutputPath.
+func OutputWriter(c *cobra.Command, path string) (io.Writer, error) {
+	if path == "-" || path == "" {
+		return c.OutOrStdout(), nil
+	}
+	data := protocli.OutputPathData{Method: c.Name(), Time: time.Now()}
+	for p := c; p != nil; p = p.Parent() {
+		if service, ok := p.Annotations[protocli.ServiceMetadataKey]; ok {
+			data.Service = service
+			break
+		}
+	}
+	data.Format, _ = c.Flags().GetString("format")
+	path, err := protocli.ExpandOutputPath(path, data)
+	if err != nil {
+		return nil, err
+	}
+	mode, _ := c.Flags().GetString("output-mode")
+	appendMode, _ := c.Flags().GetBool("output-append")
+	return protocli.OpenOutputFile(path, mode, appendMode)
+}
+
+// ShowInput writes req to the command's error writer in the --format output
+// format when --show-input is set, like protocli.ShowInput does for urfave/cli
+// commands.
+func ShowInput(ctx context.Context, c *cobra.Command, formats []protocli.OutputFormat, req proto.Message) error {
+	if show, _ := c.Flags().GetBool("show-input"); !show {
+		return nil
+	}
+	formatCmd, err := FormatCommand(ctx, c, formats)
+	if err != nil {
+		return err
+	}
+	format, _ := c.Flags().GetString("format")
+	return protocli.WriteFormatted(ctx, formatCmd, c.ErrOrStderr(), formats, format, req)
+}
+
+// ConfirmPreRun is the PreRunE of commands annotated with cli.command.confirm.
+// Like protocli.ConfirmBefore, it asks "Are you sure? [y/N]" unless --yes is
+// set, and fails when stdin is not a terminal.
+func ConfirmPreRun(c *cobra.Command, _ []string) error {
+	if yes, _ := c.Flags().GetBool("yes"); yes {
+		return nil
+	}
+	return protocli.Confirm(c.CommandPath(), c.InOrStdin(), c.ErrOrStderr())
+}
+
+// AddFormatFlags registers the flags declared by FlagConfiguredOutputFormat
+// formats (e.g. --pretty) on c. Flags that collide with an existing flag are
+// skipped. Boolean flags become pflag bools; all others are registered as strings.
+func AddFormatFlags(c *cobra.Command, formats []protocli.OutputFormat) {
+	for _, flag := range protocli.FormatFlags(formats) {
+		name := flag.Names()[0]
+		if c.Flags().Lookup(name) != nil {
+			continue
+		}
+		var usage string
+		if docFlag, ok := flag.(cli.DocGenerationFlag); ok {
+			usage = docFlag.GetUsage()
+		}
+		if _, ok := flag.(*cli.BoolFlag); ok {
+			c.Flags().Bool(name, false, usage)
+		} else {
+			c.Flags().String(name, "", usage)
+		}
+	}
+}
+
+// FormatCommand returns a *cli.Command carrying the values of the format flags
+// and the --color and --no-color flags set on c, so OutputFormat
+// implementations that read their own flags or call protocli.ColorEnabled work
+// unchanged under cobra.
+func FormatCommand(ctx context.Context, c *cobra.Command, formats []protocli.OutputFormat) (*cli.Command, error) {
+	flags := protocli.ColorFlags()
+	args := []string{c.Name()}
+	for _, flag := range flags {
+		name := flag.Names()[0]
+		if pf := c.Flag(name); pf != nil && pf.Changed {
+			args = append(args, "--"+name+"="+pf.Value.String())
+		}
+	}
+	for _, flag := range protocli.FormatFlags(formats) {
+		flags = append(flags, flag)
+		name := flag.Names()[0]
+		if pf := c.Flags().Lookup(name); pf != nil && pf.Changed {
+			args = append(args, "--"+name+"="+pf.Value.String())
+		}
+	}
+
+	formatCmd := &cli.Command{
+		Name:   c.Name(),
+		Flags:  flags,
+		Writer: c.OutOrStdout(),
+		Action: func(context.Context, *cli.Command) error { return nil },
+	}
+	if err := formatCmd.Run(ctx, args); err != nil {
+		return nil, fmt.Errorf("failed to parse format flags: %w", err)
+	}
+	return formatCmd, nil
+}
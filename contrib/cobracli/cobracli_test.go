@@ -0,0 +1,104 @@
+package cobracli
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestFlags_ReadsPflagValues(t *testing.T) {
+	c := &cobra.Command{Use: "test"}
+	c.Flags().String("name", "default", "")
+	c.Flags().Int32("count", 0, "")
+	c.Flags().UintSlice("ids", nil, "")
+	c.Flags().Bool("verbose", false, "")
+	require.NoError(t, c.ParseFlags([]string{"--count=3", "--ids=1,2"}))
+
+	flags := Flags(c)
+	assert.Equal(t, "default", flags.String("name"))
+	assert.False(t, flags.IsSet("name"))
+	assert.Equal(t, int32(3), flags.Int32("count"))
+	assert.True(t, flags.IsSet("count"))
+	assert.Equal(t, []uint32{1, 2}, flags.Uint32Slice("ids"))
+	assert.Equal(t, []uint64{1, 2}, flags.Uint64Slice("ids"))
+	assert.False(t, flags.Bool("verbose"))
+
+	// Undefined or mistyped flags read as zero values
+	assert.False(t, flags.IsSet("missing"))
+	assert.Empty(t, flags.String("missing"))
+	assert.Zero(t, flags.Int64("count"))
+}
+
+func TestFormatCommand_CarriesFormatFlags(t *testing.T) {
+	formats := []protocli.OutputFormat{protocli.JSON()}
+	c := &cobra.Command{Use: "test"}
+	AddFormatFlags(c, formats)
+	require.NotNil(t, c.Flags().Lookup("pretty"))
+	require.NoError(t, c.ParseFlags([]string{"--pretty"}))
+
+	formatCmd, err := FormatCommand(context.Background(), c, formats)
+	require.NoError(t, err)
+	assert.True(t, formatCmd.Bool("pretty"))
+}
+
+func TestRootCommand_RegistersConfigFlags(t *testing.T) {
+	sub := &cobra.Command{Use: "svc"}
+	root := RootCommand("myapp", sub)
+
+	assert.NotNil(t, root.PersistentFlags().Lookup("config"))
+	assert.NotNil(t, root.PersistentFlags().Lookup("env-prefix"))
+	assert.Equal(t, root, sub.Root())
+}
+
+func TestConfirmPreRun(t *testing.T) {
+	c := &cobra.Command{Use: "purge-cache"}
+	c.Flags().Bool("yes", false, "")
+	c.SetIn(strings.NewReader("y\n"))
+
+	// A reader that is not a terminal can't answer the prompt
+	require.NoError(t, c.ParseFlags(nil))
+	require.ErrorIs(t, ConfirmPreRun(c, nil), protocli.ErrConfirmationRequired)
+
+	require.NoError(t, c.ParseFlags([]string{"--yes"}))
+	require.NoError(t, ConfirmPreRun(c, nil))
+}
+
+func TestOutputWriter_ServiceOfGroupedCommand(t *testing.T) {
+	ban := &cobra.Command{Use: "ban"}
+	group := &cobra.Command{Use: "admin"}
+	group.AddCommand(ban)
+	service := &cobra.Command{Use: "user-service", Annotations: map[string]string{protocli.ServiceMetadataKey: "user-service"}}
+	service.AddCommand(group)
+	RootCommand("testcli", service)
+
+	dir := t.TempDir()
+	w, err := OutputWriter(ban, dir+"/{{.Service}}-{{.Method}}.out")
+	require.NoError(t, err)
+	require.NoError(t, w.(io.Closer).Close())
+	assert.FileExists(t, dir+"/user-service-ban.out")
+}
+
+func TestShowInput(t *testing.T) {
+	formats := []protocli.OutputFormat{protocli.JSON()}
+	c := &cobra.Command{Use: "test"}
+	c.Flags().String("format", "json", "")
+	c.Flags().Bool("show-input", false, "")
+	AddFormatFlags(c, formats)
+	var stderr strings.Builder
+	c.SetErr(&stderr)
+	req := wrapperspb.String("hello")
+
+	require.NoError(t, ShowInput(context.Background(), c, formats, req))
+	assert.Empty(t, stderr.String(), "nothing is shown without --show-input")
+
+	require.NoError(t, c.ParseFlags([]string{"--show-input"}))
+	require.NoError(t, ShowInput(context.Background(), c, formats, req))
+	assert.Contains(t, stderr.String(), `"hello"`)
+}
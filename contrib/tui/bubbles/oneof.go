@@ -0,0 +1,190 @@
+package bubbles
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	protocli "github.com/drewfead/proto-cli"
+	"google.golang.org/protobuf/proto"
+)
+
+// OneofSelector is implemented by controls that stand for a group of mutually
+// exclusive fields, such as the control returned by NewOneofControl. Selected
+// returns the chosen member and its control, or ok=false when no member is
+// chosen.
+type OneofSelector interface {
+	Selected() (field protocli.TUIFieldDescriptor, ctrl FormControl, ok bool)
+}
+
+// oneofControl presents the members of a proto oneof as a selector and shows
+// only the chosen member's control below it.
+//
+// Navigation:
+//
+//	←/→      choose a member (or none)
+//	↓/Tab    move into the chosen member's input
+//	↑/⇧Tab   move from the member's input back to the selector
+type oneofControl struct {
+	members  []protocli.TUIFieldDescriptor
+	controls []FormControl
+	names    []string // member labels without their breadcrumb prefix
+	selected int      // index into members; -1 when none is chosen
+	focused  bool
+	editing  bool // focus is on the chosen member's control, not the selector
+	styles   Styles
+}
+
+// NewOneofControl returns a FormControl for the members of one oneof, where
+// controls[i] is the input for members[i]. The first member with a default
+// value starts out chosen. On submit only the chosen member is applied, so at
+// most one field of the oneof is ever set.
+func NewOneofControl(members []protocli.TUIFieldDescriptor, controls []FormControl, styles Styles) FormControl {
+	c := &oneofControl{members: members, controls: controls, selected: -1, styles: styles}
+	for i, m := range members {
+		name := m.Label
+		if idx := strings.LastIndex(name, " › "); idx >= 0 {
+			name = name[idx+len(" › "):]
+		}
+		c.names = append(c.names, name)
+		if c.selected < 0 && m.DefaultValue != "" {
+			c.selected = i
+		}
+	}
+	return c
+}
+
+// Selected returns the chosen member and its control.
+func (c *oneofControl) Selected() (protocli.TUIFieldDescriptor, FormControl, bool) {
+	if c.selected < 0 {
+		return protocli.TUIFieldDescriptor{}, nil, false
+	}
+	return c.members[c.selected], c.controls[c.selected], true
+}
+
+func (c *oneofControl) Focus() tea.Cmd {
+	c.focused = true
+	c.editing = false
+	return nil
+}
+
+func (c *oneofControl) Blur() {
+	if c.editing {
+		c.controls[c.selected].Blur()
+	}
+	c.focused = false
+	c.editing = false
+}
+
+// Value returns the chosen member's value, or "" when none is chosen.
+func (c *oneofControl) Value() string {
+	if _, ctrl, ok := c.Selected(); ok {
+		return ctrl.Value()
+	}
+	return ""
+}
+
+// Apply sets the chosen member on msg and leaves every other member unset.
+func (c *oneofControl) Apply(msg proto.Message) error {
+	field, ctrl, ok := c.Selected()
+	if !ok {
+		return nil
+	}
+	if applier, ok := ctrl.(FieldApplier); ok {
+		return applier.Apply(msg)
+	}
+	val := ctrl.Value()
+	if val == "" || field.Setter == nil {
+		return nil
+	}
+	return field.Setter(msg, val)
+}
+
+func (c *oneofControl) CapturesKey(key string) bool {
+	if c.editing {
+		if kc, ok := c.controls[c.selected].(KeyCapturer); ok && kc.CapturesKey(key) {
+			return true
+		}
+		return key == "up" || key == "shift+tab"
+	}
+	return (key == "down" || key == "tab") && c.selected >= 0
+}
+
+func (c *oneofControl) IsMultiline() bool {
+	if !c.editing {
+		return false
+	}
+	ml, ok := c.controls[c.selected].(MultilineInput)
+	return ok && ml.IsMultiline()
+}
+
+func (c *oneofControl) HelpText() string {
+	if c.editing {
+		if htp, ok := c.controls[c.selected].(HelpTextProvider); ok {
+			return htp.HelpText()
+		}
+		return FormHelpText(
+			KeyBind{"↑↓/Tab", "navigate"},
+			KeyBind{"Enter", "submit"},
+		)
+	}
+	return FormHelpText(
+		KeyBind{"←→", "choose"},
+		KeyBind{"↑↓/Tab", "navigate"},
+		KeyBind{"Enter", "submit"},
+	)
+}
+
+func (c *oneofControl) Update(msg tea.Msg) tea.Cmd {
+	key, isKey := msg.(tea.KeyMsg)
+	if c.editing {
+		if isKey && (key.String() == "up" || key.String() == "shift+tab") {
+			if kc, ok := c.controls[c.selected].(KeyCapturer); !ok || !kc.CapturesKey(key.String()) {
+				c.controls[c.selected].Blur()
+				c.editing = false
+				return nil
+			}
+		}
+		return c.controls[c.selected].Update(msg)
+	}
+	if !isKey {
+		return nil
+	}
+	switch key.String() {
+	case "left":
+		// -1 (none) sits before the first member.
+		c.selected--
+		if c.selected < -1 {
+			c.selected = len(c.members) - 1
+		}
+	case "right":
+		c.selected++
+		if c.selected >= len(c.members) {
+			c.selected = -1
+		}
+	case "down", "tab":
+		if c.selected >= 0 {
+			c.editing = true
+			return c.controls[c.selected].Focus()
+		}
+	}
+	return nil
+}
+
+func (c *oneofControl) View() string {
+	options := make([]string, 0, len(c.names)+1)
+	for i, name := range append([]string{"none"}, c.names...) {
+		switch {
+		case i-1 != c.selected:
+			options = append(options, c.styles.DateSegment.Render(name))
+		case c.focused && !c.editing:
+			options = append(options, c.styles.DateActiveSegment.Render(name))
+		default:
+			options = append(options, c.styles.ToggleOn.Render(name))
+		}
+	}
+	view := "‹ " + strings.Join(options, "  ") + " ›"
+	if _, ctrl, ok := c.Selected(); ok {
+		view += "\n" + ctrl.View()
+	}
+	return view
+}
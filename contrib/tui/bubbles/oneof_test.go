@@ -0,0 +1,86 @@
+package bubbles
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// newContactOneof returns a oneof control over email and phone text members
+// whose setters record the values they are given in set.
+func newContactOneof(set map[string]string, emailDefault string) FormControl {
+	member := func(name, dv string) protocli.TUIFieldDescriptor {
+		return protocli.TUIFieldDescriptor{
+			Name:         name,
+			Label:        "Contact › " + name,
+			DefaultValue: dv,
+			OneofGroup:   "contact",
+			Setter: func(_ proto.Message, s string) error {
+				set[name] = s
+				return nil
+			},
+		}
+	}
+	styles := DefaultStyles()
+	return NewOneofControl(
+		[]protocli.TUIFieldDescriptor{member("email", emailDefault), member("phone", "")},
+		[]FormControl{NewTextControl("", emailDefault, styles), NewTextControl("", "", styles)},
+		styles,
+	)
+}
+
+func typeKeys(c FormControl, keys ...tea.KeyMsg) {
+	for _, k := range keys {
+		c.Update(k)
+	}
+}
+
+func TestOneofControl_AppliesOnlySelectedMember(t *testing.T) {
+	set := map[string]string{}
+	c := newContactOneof(set, "a@example.com")
+	c.Focus()
+
+	sel := c.(OneofSelector)
+	field, _, ok := sel.Selected()
+	require.True(t, ok, "the member with a default starts out chosen")
+	assert.Equal(t, "email", field.Name)
+
+	typeKeys(c, tea.KeyMsg{Type: tea.KeyRight})
+	field, _, ok = sel.Selected()
+	require.True(t, ok)
+	assert.Equal(t, "phone", field.Name)
+
+	require.True(t, c.(KeyCapturer).CapturesKey("down"), "down moves into the chosen member")
+	typeKeys(c, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("555")})
+	assert.Equal(t, "555", c.Value())
+
+	require.NoError(t, c.(FieldApplier).Apply(&emptypb.Empty{}))
+	assert.Equal(t, map[string]string{"phone": "555"}, set, "the email default is not applied")
+}
+
+func TestOneofControl_NoneSelected(t *testing.T) {
+	set := map[string]string{}
+	c := newContactOneof(set, "")
+	c.Focus()
+
+	_, _, ok := c.(OneofSelector).Selected()
+	assert.False(t, ok, "nothing is chosen without a default")
+	assert.False(t, c.(KeyCapturer).CapturesKey("down"), "with nothing chosen down leaves the control")
+
+	// Left from none wraps to the last member, and right wraps back to none.
+	typeKeys(c, tea.KeyMsg{Type: tea.KeyLeft})
+	field, _, ok := c.(OneofSelector).Selected()
+	require.True(t, ok)
+	assert.Equal(t, "phone", field.Name)
+	typeKeys(c, tea.KeyMsg{Type: tea.KeyRight})
+	_, _, ok = c.(OneofSelector).Selected()
+	assert.False(t, ok)
+
+	require.NoError(t, c.(FieldApplier).Apply(&emptypb.Empty{}))
+	assert.Empty(t, set)
+}
@@ -50,6 +50,43 @@ type StreamingResponseView interface {
 	Done(err error)
 }
 
+// FooterProvider is an optional interface for ResponseViews that render a
+// footer below the content area, such as a legend for the colours a view uses.
+// The footer may span several lines and is shown above the help line; the
+// content area passed to Init and SetSize shrinks to make room for it. An
+// empty footer is not shown.
+type FooterProvider interface {
+	Footer() string
+}
+
+// StreamPolicy controls what the TUI does when a server-streaming RPC produces
+// messages faster than the StreamingResponseView renders them. Up to
+// StreamBufferSize messages are held for the view; the policy applies once that
+// buffer is full.
+type StreamPolicy int
+
+const (
+	// StreamBlock pauses the stream until the view catches up. This is the default.
+	StreamBlock StreamPolicy = iota
+	// StreamDropOldest discards the oldest buffered message to make room for
+	// the new one.
+	StreamDropOldest
+	// StreamCoalesce discards every buffered message, keeping only the newest.
+	StreamCoalesce
+)
+
+// StreamBufferSize is the number of stream messages buffered between the RPC
+// and the view before the StreamPolicy applies.
+const StreamBufferSize = 16
+
+// StreamPolicyProvider is an optional interface for StreamingResponseViews
+// that choose how the TUI handles a stream they cannot keep up with. Messages
+// discarded by the policy are counted and shown as "(N dropped)" in the help
+// line. Views that do not implement it get StreamBlock.
+type StreamPolicyProvider interface {
+	StreamPolicy() StreamPolicy
+}
+
 // ResponseViewFactory creates a ResponseView for a single RPC invocation.
 // The desc parameter describes the response type so factories can dispatch to
 // different presentations based on the response message full name or method
@@ -169,20 +206,24 @@ type tableRow struct {
 // Nested objects and arrays are compacted to a single JSON line.
 // The table uses the active palette: primary for the header, accent for field
 // names, and muted for the border.
-// It also implements StreamingResponseView: each Append call adds the new
-// message's fields as rows, growing the table incrementally.
+// It also implements StreamingResponseView: each streamed message becomes a
+// row of a multi-column table whose header is taken from the first message.
 type tableResponseView struct {
 	vp         viewport.Model
 	styles     Styles
 	width      int
 	height     int
-	streamRows []tableRow // accumulated rows from streaming Append calls
+	streamCols []string   // column headers, from the first streamed message
+	streamRows [][]string // one row of cell values per streamed message
+	streamErr  error      // error the stream ended with, if any
 }
 
 // NewTableResponseView returns a ResponseViewFactory that renders responses as
 // a styled two-column lipgloss table. Register it via tui.WithResponseView:
 //
 //	tui.New(tui.WithResponseView(bubbles.NewTableResponseView()))
+//
+// For server-streaming methods each message is appended live as a table row.
 func NewTableResponseView() ResponseViewFactory {
 	return func(_ protocli.TUIResponseDescriptor, styles Styles) ResponseView {
 		return &tableResponseView{styles: styles}
@@ -193,42 +234,52 @@ func (v *tableResponseView) Init(_ context.Context, msg proto.Message, width, he
 	v.width = width
 	v.height = height
 	v.vp = viewport.New(width, height)
+	v.streamCols = nil
 	v.streamRows = nil
+	v.streamErr = nil
 	if msg != nil {
 		v.vp.SetContent(v.render(msg))
+	} else {
+		// Streaming start: show the empty table until the first message
+		v.vp.SetContent(v.renderStream())
 	}
 	return nil
 }
 
-// Append implements StreamingResponseView. Each call extracts the new message's
-// fields as (key, value) pairs and appends them to the growing table.
+// Append implements StreamingResponseView. Each call adds the message as a new
+// row. The columns are the fields of the first message; fields missing from a
+// later message are left blank and fields it adds are not shown.
 func (v *tableResponseView) Append(msg proto.Message) {
-	raw := marshalResponse(msg)
-	var fields map[string]json.RawMessage
-	if err := json.Unmarshal([]byte(raw), &fields); err == nil {
-		keys := make([]string, 0, len(fields))
-		for k := range fields {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		for _, k := range keys {
-			val := fields[k]
-			var s string
-			if err := json.Unmarshal(val, &s); err == nil {
-				v.streamRows = append(v.streamRows, tableRow{k, s})
-			} else {
-				var buf bytes.Buffer
-				_ = json.Compact(&buf, val)
-				v.streamRows = append(v.streamRows, tableRow{k, buf.String()})
-			}
+	pairs, ok := tableFields(msg)
+	if !ok {
+		pairs = []tableRow{{"value", marshalResponse(msg)}}
+	}
+	if len(v.streamCols) == 0 {
+		for _, p := range pairs {
+			v.streamCols = append(v.streamCols, p.key)
 		}
 	}
-	v.vp.SetContent(v.renderPairs(v.streamRows))
+	values := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		values[p.key] = p.val
+	}
+	row := make([]string, len(v.streamCols))
+	for i, col := range v.streamCols {
+		row[i] = values[col]
+	}
+	v.streamRows = append(v.streamRows, row)
+	v.vp.SetContent(v.renderStream())
 }
 
-// Done implements StreamingResponseView. No additional rendering needed;
-// the table is already up to date from Append calls.
-func (v *tableResponseView) Done(_ error) {}
+// Done implements StreamingResponseView. A non-nil err is shown below the
+// rows received before the stream failed.
+func (v *tableResponseView) Done(err error) {
+	v.streamErr = err
+	v.vp.SetContent(v.renderStream())
+	if err != nil {
+		v.vp.GotoBottom()
+	}
+}
 
 func (v *tableResponseView) SetSize(width, height int) {
 	v.width = width
@@ -246,15 +297,23 @@ func (v *tableResponseView) Update(msg tea.Msg) tea.Cmd {
 func (v *tableResponseView) View() string { return v.vp.View() }
 
 func (v *tableResponseView) render(msg proto.Message) string {
-	raw := marshalResponse(msg)
-
-	var fields map[string]json.RawMessage
-	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
-		return v.styles.Response.Render(raw)
+	rows, ok := tableFields(msg)
+	if !ok {
+		return v.styles.Response.Render(marshalResponse(msg))
 	}
-	if len(fields) == 0 {
+	if len(rows) == 0 {
 		return v.styles.Subtitle.Render("(empty response)")
 	}
+	return v.renderPairs(rows)
+}
+
+// tableFields flattens msg into (field-name, value) pairs sorted by field
+// name. It reports false if the message does not marshal to a JSON object.
+func tableFields(msg proto.Message) ([]tableRow, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(marshalResponse(msg)), &fields); err != nil {
+		return nil, false
+	}
 
 	keys := make([]string, 0, len(fields))
 	for k := range fields {
@@ -274,11 +333,45 @@ func (v *tableResponseView) render(msg proto.Message) string {
 			rows = append(rows, tableRow{k, buf.String()})
 		}
 	}
-	return v.renderPairs(rows)
+	return rows, true
+}
+
+// renderStream builds a table with one row per streamed message, followed by
+// the stream error if there is one.
+func (v *tableResponseView) renderStream() string {
+	var out string
+	if len(v.streamRows) == 0 {
+		out = v.styles.Subtitle.Render("(no messages yet)")
+	} else {
+		headerStyle := lipgloss.NewStyle().Bold(true).Foreground(v.styles.Colors.Primary).Padding(0, 1)
+		cellStyle := lipgloss.NewStyle().Padding(0, 1)
+		altCellStyle := lipgloss.NewStyle().Faint(true).Padding(0, 1)
+		t := table.New().
+			Border(v.styles.CardBox.GetBorderStyle()).
+			BorderStyle(lipgloss.NewStyle().Foreground(v.styles.Colors.SecondaryBorder)).
+			StyleFunc(func(row, _ int) lipgloss.Style {
+				switch {
+				case row == table.HeaderRow:
+					return headerStyle
+				case row%2 == 1:
+					return altCellStyle
+				default:
+					return cellStyle
+				}
+			}).
+			Headers(v.streamCols...).
+			Rows(v.streamRows...).
+			Width(v.width)
+		out = t.String()
+	}
+	if v.streamErr != nil {
+		out += "\n" + v.styles.Error.Render("stream error: "+v.streamErr.Error())
+	}
+	return out
 }
 
 // renderPairs builds a two-column lipgloss table from an ordered slice of
-// (key, value) pairs. Used by both render (single message) and Append (streaming).
+// (key, value) pairs for a single message.
 func (v *tableResponseView) renderPairs(rows []tableRow) string {
 	if len(rows) == 0 {
 		return v.styles.Subtitle.Render("(no messages yet)")
@@ -436,6 +529,12 @@ func WithFillWidth() CardGridOption {
 	return func(v *cardGridResponseView) { v.fillWidth = true }
 }
 
+// WithStreamPolicy sets how a server stream that outpaces the grid is handled:
+// StreamBlock (the default), StreamDropOldest or StreamCoalesce.
+func WithStreamPolicy(policy StreamPolicy) CardGridOption {
+	return func(v *cardGridResponseView) { v.streamPolicy = policy }
+}
+
 // WithCardAction registers a CardActionHandler that makes cards focusable with
 // arrow-key navigation. The handler declares its key bindings (single keys or
 // multi-char sequences such as ":g") via KeyBindings, and Handle is called
@@ -467,6 +566,7 @@ type cardGridResponseView struct {
 	actionHandler CardActionHandler  // nil = cards not focusable
 	commandBuf    string             // in-progress multi-char key sequence; empty when idle
 	focusedCard   int                // index of the focused card
+	streamPolicy  StreamPolicy       // handling of streams that outpace rendering
 }
 
 // cardEntry holds both the parsed rows (for the default renderer) and the
@@ -490,6 +590,10 @@ type cardEntry struct {
 //	    bubbles.WithCardWidth(32),
 //	    bubbles.WithColumns(3),
 //	)
+//
+// For fast streams, drop messages rather than pausing the server:
+//
+//	bubbles.NewCardGridResponseView(bubbles.WithStreamPolicy(bubbles.StreamDropOldest))
 func NewCardGridResponseView(opts ...CardGridOption) ResponseViewFactory {
 	return func(_ protocli.TUIResponseDescriptor, styles Styles) ResponseView {
 		rv := &cardGridResponseView{styles: styles}
@@ -682,6 +786,9 @@ func (v *cardGridResponseView) Append(msg proto.Message) {
 // Done implements StreamingResponseView. No additional rendering needed.
 func (v *cardGridResponseView) Done(_ error) {}
 
+// StreamPolicy implements StreamPolicyProvider.
+func (v *cardGridResponseView) StreamPolicy() StreamPolicy { return v.streamPolicy }
+
 // parseRows extracts sorted (field, value) pairs from a proto message's JSON.
 func (v *cardGridResponseView) parseRows(msg proto.Message) []tableRow {
 	raw := marshalResponse(msg)
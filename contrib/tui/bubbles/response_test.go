@@ -0,0 +1,75 @@
+package bubbles
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newStreamingTable(t *testing.T) *tableResponseView {
+	t.Helper()
+	rv := NewTableResponseView()(protocli.TUIResponseDescriptor{}, DefaultStyles())
+	_, ok := rv.(StreamingResponseView)
+	require.True(t, ok, "the table view supports streaming")
+	rv.Init(context.Background(), nil, 80, 20)
+	return rv.(*tableResponseView)
+}
+
+func streamRow(t *testing.T, fields map[string]any) *structpb.Struct {
+	t.Helper()
+	s, err := structpb.NewStruct(fields)
+	require.NoError(t, err)
+	return s
+}
+
+func TestTableResponseView_AppendAddsOneRowPerMessage(t *testing.T) {
+	v := newStreamingTable(t)
+	assert.Contains(t, v.View(), "(no messages yet)")
+
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		v.Append(streamRow(t, map[string]any{"name": name, "size": len(name)}))
+	}
+
+	assert.Equal(t, []string{"name", "size"}, v.streamCols)
+	require.Len(t, v.streamRows, 3)
+	assert.Equal(t, []string{"gamma", "5"}, v.streamRows[2])
+
+	view := v.View()
+	for _, want := range []string{"name", "size", "alpha", "beta", "gamma"} {
+		assert.Contains(t, view, want)
+	}
+}
+
+func TestTableResponseView_HeaderFromFirstMessage(t *testing.T) {
+	v := newStreamingTable(t)
+	v.Append(streamRow(t, map[string]any{"id": "1", "name": "alpha"}))
+	v.Append(streamRow(t, map[string]any{"id": "2", "extra": "ignored"}))
+
+	assert.Equal(t, []string{"id", "name"}, v.streamCols)
+	assert.Equal(t, []string{"2", ""}, v.streamRows[1], "missing fields are blank")
+}
+
+func TestTableResponseView_DoneSurfacesError(t *testing.T) {
+	v := newStreamingTable(t)
+	v.Append(streamRow(t, map[string]any{"id": "1"}))
+	v.Done(errors.New("connection reset"))
+
+	assert.Contains(t, v.View(), "stream error: connection reset")
+	assert.Len(t, v.streamRows, 1, "rows received before the error are kept")
+}
+
+func TestTableResponseView_InitResetsStream(t *testing.T) {
+	v := newStreamingTable(t)
+	v.Append(streamRow(t, map[string]any{"id": "1"}))
+	v.Done(errors.New("boom"))
+
+	v.Init(context.Background(), nil, 80, 20)
+	assert.Empty(t, v.streamRows)
+	assert.Empty(t, v.streamCols)
+	assert.NotContains(t, v.View(), "boom")
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/contrib/tui/bubbles"
+)
+
+// buildCLICommand reconstructs the command line equivalent to submitting the form
+// for method. Empty fields are omitted, booleans become bare flags, and repeated
+// fields emit one flag per comma-separated element. Every value is shell-quoted.
+func buildCLICommand(appName, serviceName string, method protocli.TUIMethod, fields []protocli.TUIFieldDescriptor, controls []bubbles.FormControl) string {
+	parts := []string{protocli.ShellQuote(appName), protocli.ShellQuote(serviceName), protocli.ShellQuote(method.TUIName())}
+
+	for i, ctrl := range controls {
+		field := fields[i]
+		// A oneof contributes the flag of its chosen member, if any.
+		if sel, ok := ctrl.(bubbles.OneofSelector); ok {
+			member, memberCtrl, chosen := sel.Selected()
+			if !chosen {
+				continue
+			}
+			field, ctrl = member, memberCtrl
+		}
+		val := ctrl.Value()
+		if val == "" {
+			continue
+		}
+		flag := "--" + field.Name
+
+		switch field.Kind {
+		case protocli.TUIFieldKindBool:
+			if val == "true" {
+				parts = append(parts, flag)
+			}
+		case protocli.TUIFieldKindRepeated:
+			for _, elem := range strings.Split(val, ",") {
+				elem = strings.TrimSpace(elem)
+				if elem == "" {
+					continue
+				}
+				parts = append(parts, flag, protocli.ShellQuote(elem))
+			}
+		default:
+			parts = append(parts, flag, protocli.ShellQuote(val))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// copyAsCommand builds the CLI command for the current form, copies it to the
+// system clipboard when available, and shows it in a modal either way.
+func (m rootModel) copyAsCommand() rootModel {
+	svc := m.services[m.selectedService]
+	method := svc.TUIMethods()[m.selectedMethod]
+	command := buildCLICommand(m.cmd.Root().Name, svc.TUIName(), method, m.form.fields, m.form.controls)
+
+	title := "CLI command"
+	if err := clipboard.WriteAll(command); err == nil {
+		title = "CLI command (copied to clipboard)"
+	}
+
+	m.modalActive = true
+	m.modalTitle = title
+	m.modalContent = command
+	return m
+}
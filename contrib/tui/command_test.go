@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/contrib/tui/bubbles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubMethod is a minimal TUIMethod for command reconstruction tests.
+type stubMethod struct{ protocli.TUIMethod }
+
+func (stubMethod) TUIName() string { return "create-user" }
+
+func TestBuildCLICommand(t *testing.T) {
+	styles := bubbles.DefaultStyles()
+	fields := []protocli.TUIFieldDescriptor{
+		{Name: "name", Kind: protocli.TUIFieldKindString},
+		{Name: "email", Kind: protocli.TUIFieldKindString},
+		{Name: "admin", Kind: protocli.TUIFieldKindBool},
+		{Name: "tags", Kind: protocli.TUIFieldKindRepeated},
+		{Name: "glob", Kind: protocli.TUIFieldKindString},
+	}
+	controls := []bubbles.FormControl{
+		bubbles.NewTextControl("", "it's me", styles),
+		bubbles.NewTextControl("", "", styles),
+		bubbles.NewToggleControl("true", styles),
+		bubbles.NewListControl("", "a, b c", styles),
+		bubbles.NewTextControl("", "*.go", styles),
+	}
+
+	got := buildCLICommand("usercli", "user-service", stubMethod{}, fields, controls)
+	assert.Equal(t, `usercli user-service create-user --name 'it'\''s me' --admin --tags a --tags 'b c' --glob '*.go'`, got)
+}
+
+// fieldsMethod is a stubMethod with the given input fields.
+type fieldsMethod struct {
+	stubMethod
+	fields []protocli.TUIFieldDescriptor
+}
+
+func (m fieldsMethod) TUIInputFields() []protocli.TUIFieldDescriptor { return m.fields }
+
+func TestBuildCLICommand_Oneof(t *testing.T) {
+	method := fieldsMethod{fields: []protocli.TUIFieldDescriptor{
+		{Name: "name", Label: "Name", Kind: protocli.TUIFieldKindString},
+		{Name: "email", Label: "Email", Kind: protocli.TUIFieldKindString, OneofGroup: "contact_method"},
+		{Name: "phone", Label: "Phone", Kind: protocli.TUIFieldKindString, OneofGroup: "contact_method"},
+	}}
+	fm := newFormModel(method, bubbles.DefaultStyles(), nil, nil, map[string]string{"name": "ann", "phone": "555"})
+
+	require.Len(t, fm.controls, 2, "the oneof members share one control")
+	assert.Equal(t, "Contact method", fm.fields[1].Label)
+
+	got := buildCLICommand("usercli", "user-service", method, fm.fields, fm.controls)
+	assert.Equal(t, "usercli user-service create-user --name ann --phone 555", got)
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/drewfead/proto-cli/contrib/tui/bubbles"
+	"google.golang.org/protobuf/proto"
+)
+
+// streamQueue carries messages from a server-streaming RPC goroutine to the
+// TUI. The TUI acknowledges each message by taking it with next, which frees
+// room for the producer; once limit messages are waiting, policy decides
+// whether the producer blocks or messages are discarded.
+type streamQueue struct {
+	policy bubbles.StreamPolicy
+	limit  int
+
+	mu      sync.Mutex
+	items   []proto.Message
+	dropped int
+	done    bool
+	err     error
+
+	ready chan struct{} // signalled when items arrive or the stream ends
+	space chan struct{} // signalled when the TUI takes an item
+}
+
+func newStreamQueue(policy bubbles.StreamPolicy, limit int) *streamQueue {
+	return &streamQueue{
+		policy: policy,
+		limit:  limit,
+		ready:  make(chan struct{}, 1),
+		space:  make(chan struct{}, 1),
+	}
+}
+
+// signal wakes a waiter on ch without blocking if one is already pending.
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// push queues msg, applying the policy when the queue is full. Under
+// StreamBlock it waits for room and returns ctx.Err() if ctx ends first.
+func (q *streamQueue) push(ctx context.Context, msg proto.Message) error {
+	for {
+		q.mu.Lock()
+		switch {
+		case len(q.items) < q.limit:
+			q.items = append(q.items, msg)
+		case q.policy == bubbles.StreamDropOldest:
+			q.items = append(q.items[1:], msg)
+			q.dropped++
+		case q.policy == bubbles.StreamCoalesce:
+			q.dropped += len(q.items)
+			q.items = append(q.items[:0], msg)
+		default:
+			q.mu.Unlock()
+			select {
+			case <-q.space:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		q.mu.Unlock()
+		signal(q.ready)
+		return nil
+	}
+}
+
+// finish records the end of the stream. Queued messages are still delivered
+// before the streamDoneMsg.
+func (q *streamQueue) finish(err error) {
+	q.mu.Lock()
+	q.done = true
+	q.err = err
+	q.mu.Unlock()
+	signal(q.ready)
+}
+
+// next blocks until a message or the end of the stream is available and
+// returns it as a streamItemMsg or streamDoneMsg.
+func (q *streamQueue) next() tea.Msg {
+	for {
+		q.mu.Lock()
+		if len(q.items) > 0 {
+			msg := q.items[0]
+			q.items = q.items[1:]
+			dropped := q.dropped
+			q.mu.Unlock()
+			signal(q.space)
+			return streamItemMsg{queue: q, msg: msg, dropped: dropped}
+		}
+		if q.done {
+			msg := streamDoneMsg{queue: q, err: q.err, dropped: q.dropped}
+			q.mu.Unlock()
+			return msg
+		}
+		q.mu.Unlock()
+		<-q.ready
+	}
+}
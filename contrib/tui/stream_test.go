@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/contrib/tui/bubbles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// drain reads q until the stream ends, returning the delivered values and the
+// final message.
+func drain(t *testing.T, q *streamQueue) ([]int32, streamDoneMsg) {
+	t.Helper()
+	var got []int32
+	for {
+		switch msg := q.next().(type) {
+		case streamItemMsg:
+			got = append(got, msg.msg.(*wrapperspb.Int32Value).GetValue())
+		case streamDoneMsg:
+			return got, msg
+		default:
+			t.Fatalf("unexpected message %T", msg)
+		}
+	}
+}
+
+func pushN(t *testing.T, q *streamQueue, n int) {
+	t.Helper()
+	for i := range n {
+		require.NoError(t, q.push(context.Background(), wrapperspb.Int32(int32(i))))
+	}
+}
+
+func TestStreamQueue_DropOldestCountsDrops(t *testing.T) {
+	q := newStreamQueue(bubbles.StreamDropOldest, 4)
+	pushN(t, q, 10)
+	q.finish(nil)
+
+	got, done := drain(t, q)
+	assert.Equal(t, []int32{6, 7, 8, 9}, got, "the newest messages are kept")
+	assert.Equal(t, 6, done.dropped)
+	assert.NoError(t, done.err)
+}
+
+func TestStreamQueue_CoalesceKeepsNewest(t *testing.T) {
+	q := newStreamQueue(bubbles.StreamCoalesce, 4)
+	pushN(t, q, 6)
+	q.finish(nil)
+
+	got, done := drain(t, q)
+	assert.Equal(t, []int32{4, 5}, got)
+	assert.Equal(t, 4, done.dropped)
+}
+
+func TestStreamQueue_NoDropsWithinLimit(t *testing.T) {
+	q := newStreamQueue(bubbles.StreamDropOldest, 4)
+	pushN(t, q, 4)
+	streamErr := errors.New("boom")
+	q.finish(streamErr)
+
+	got, done := drain(t, q)
+	assert.Equal(t, []int32{0, 1, 2, 3}, got)
+	assert.Zero(t, done.dropped)
+	assert.ErrorIs(t, done.err, streamErr)
+}
+
+func TestStreamQueue_ReportsDropsOnItems(t *testing.T) {
+	q := newStreamQueue(bubbles.StreamDropOldest, 2)
+	pushN(t, q, 5)
+
+	msg, ok := q.next().(streamItemMsg)
+	require.True(t, ok)
+	assert.Equal(t, 3, msg.dropped)
+}
+
+func TestStreamQueue_BlockWaitsForAck(t *testing.T) {
+	q := newStreamQueue(bubbles.StreamBlock, 1)
+	pushN(t, q, 1)
+
+	pushed := make(chan error, 1)
+	go func() { pushed <- q.push(context.Background(), wrapperspb.Int32(1)) }()
+
+	select {
+	case <-pushed:
+		t.Fatal("push returned while the queue was full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	_, ok := q.next().(streamItemMsg)
+	require.True(t, ok)
+	require.NoError(t, <-pushed)
+
+	q.finish(nil)
+	got, done := drain(t, q)
+	assert.Equal(t, []int32{1}, got)
+	assert.Zero(t, done.dropped)
+}
+
+func TestStreamQueue_BlockReleasedByCancel(t *testing.T) {
+	q := newStreamQueue(bubbles.StreamBlock, 1)
+	pushN(t, q, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, q.push(ctx, wrapperspb.Int32(1)), context.Canceled)
+}
+
+func TestCardGrid_StreamPolicy(t *testing.T) {
+	rv := bubbles.NewCardGridResponseView(bubbles.WithStreamPolicy(bubbles.StreamCoalesce))(protocli.TUIResponseDescriptor{}, bubbles.DefaultStyles())
+	spp, ok := rv.(bubbles.StreamPolicyProvider)
+	require.True(t, ok)
+	assert.Equal(t, bubbles.StreamCoalesce, spp.StreamPolicy())
+
+	rv = bubbles.NewCardGridResponseView()(protocli.TUIResponseDescriptor{}, bubbles.DefaultStyles())
+	assert.Equal(t, bubbles.StreamBlock, rv.(bubbles.StreamPolicyProvider).StreamPolicy())
+}
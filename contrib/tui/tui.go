@@ -66,6 +66,29 @@
 // JSON viewport with any bubbletea sub-model:
 //
 //	tui.New(tui.WithResponseView(bubbles.NewTableResponseView()))
+//
+// Use WithResponseViewForType to pick a view by response message type instead,
+// e.g. a card grid wherever a method returns a list of people:
+//
+//	tui.New(tui.WithResponseViewForType("example.PersonList", bubbles.NewCardGridResponseView()))
+//
+// A view can implement bubbles.FooterProvider to show a multi-line footer
+// below its content, such as a legend for its colours.
+//
+// Oneof fields
+//
+// The members of a proto oneof share one form entry: ←/→ choose a member and
+// only the chosen member's input is shown and applied, so at most one member
+// is ever set. A message member is part of the choice only when a custom
+// control is registered for its type; otherwise its sub-fields appear as
+// ordinary fields.
+//
+// Inline mode
+//
+// By default the TUI takes over the terminal with the alternate screen. Use
+// WithInlineMode to render in the normal buffer instead:
+//
+//	tui.New(tui.WithInlineMode())
 package tui
 
 import (
@@ -119,7 +142,8 @@ type NavigateToFormMsg struct {
 
 // NavigateToForm returns a tea.Cmd that navigates the TUI to the named
 // service/method form, pre-filling any fields specified in fieldValues.
-// Use it from a bubbles.CardSelectHandler to jump context after a card selection:
+// Use it from a bubbles.CardSelectHandler to jump context after a card selection;
+// Esc on the form then returns to the card grid:
 //
 //	bubbles.WithOnSelect(func(ctx context.Context, msg proto.Message) bubbles.CardSelectResult {
 //	    person := msg.(*pb.PersonCard)
@@ -134,22 +158,41 @@ func NavigateToForm(serviceName, methodName string, fieldValues map[string]strin
 }
 
 // streamItemMsg carries a single message received from a server-streaming RPC.
-type streamItemMsg struct{ msg proto.Message }
+// dropped is the number of messages discarded so far by the stream policy.
+type streamItemMsg struct {
+	queue   *streamQueue
+	msg     proto.Message
+	dropped int
+}
 
 // streamDoneMsg signals that a server-streaming RPC has ended.
 // err is nil on clean completion or context cancellation; non-nil on error.
-type streamDoneMsg struct{ err error }
+type streamDoneMsg struct {
+	queue   *streamQueue
+	err     error
+	dropped int
+}
 
-// readStreamCh returns a tea.Cmd that blocks until the next value is
-// available on ch and delivers it as a tea.Msg. Call it again from Update
-// after each streamItemMsg to keep polling the stream.
-func readStreamCh(ch <-chan tea.Msg) tea.Cmd {
-	return func() tea.Msg { return <-ch }
+// readStream returns a tea.Cmd that blocks until the next message or the end
+// of the stream is available on q. Call it again from Update after each
+// streamItemMsg to keep polling the stream.
+func readStream(q *streamQueue) tea.Cmd {
+	return q.next
 }
 
 // Option configures the TUI provider.
 type Option func(*provider)
 
+// WithInlineMode runs the TUI in the normal terminal buffer instead of taking
+// over the screen with the alternate buffer, so it suits short interactions
+// from scripts and small prompts, and its last screen stays in the scrollback
+// once it exits.
+func WithInlineMode() Option {
+	return func(p *provider) {
+		p.inline = true
+	}
+}
+
 // WithStyles replaces the provider's full style set. Obtain the defaults via
 // bubbles.DefaultStyles, modify the fields you care about, then pass the result here.
 func WithStyles(styles bubbles.Styles) Option {
@@ -232,12 +275,27 @@ func WithResponseView(factory bubbles.ResponseViewFactory) Option {
 	}
 }
 
+// WithResponseViewForType registers a factory that creates the ResponseView
+// for every method whose response has the given proto message full name (e.g.
+// "example.PersonList"), matched against TUIResponseDescriptor.MessageFullName.
+// Type-based registrations take priority over WithResponseView.
+func WithResponseViewForType(messageFullName string, factory bubbles.ResponseViewFactory) Option {
+	return func(p *provider) {
+		if p.responseViewFactoriesByType == nil {
+			p.responseViewFactoriesByType = make(map[string]bubbles.ResponseViewFactory)
+		}
+		p.responseViewFactoriesByType[messageFullName] = factory
+	}
+}
+
 // provider implements protocli.TUIProvider.
 type provider struct {
-	styles               bubbles.Styles
-	customControls       map[string]bubbles.ControlFactory // keyed by proto message full name
-	customControlsByName map[string]bubbles.ControlFactory // keyed by field flag name
-	responseViewFactory  bubbles.ResponseViewFactory       // nil = default JSON viewport
+	styles                      bubbles.Styles
+	customControls              map[string]bubbles.ControlFactory      // keyed by proto message full name
+	customControlsByName        map[string]bubbles.ControlFactory      // keyed by field flag name
+	responseViewFactory         bubbles.ResponseViewFactory            // nil = default JSON viewport
+	responseViewFactoriesByType map[string]bubbles.ResponseViewFactory // keyed by response message full name
+	inline                      bool                                   // run without the alt screen
 }
 
 // New creates a new TUI provider. Default styles are applied before any options.
@@ -255,12 +313,21 @@ func (p *provider) Run(ctx context.Context, cmd *cli.Command, services []protocl
 	for _, opt := range opts {
 		opt(&cfg)
 	}
-	m := newRootModel(ctx, cmd, services, p.styles, p.customControls, p.customControlsByName, p.responseViewFactory, cfg)
-	prog := tea.NewProgram(m, tea.WithAltScreen())
+	m := newRootModel(ctx, cmd, services, p.styles, p.customControls, p.customControlsByName, p.responseViewFactory, p.responseViewFactoriesByType, cfg)
+	prog := tea.NewProgram(m, p.programOptions()...)
 	_, err := prog.Run()
 	return err
 }
 
+// programOptions returns the bubbletea options the TUI program runs with: the
+// alt screen, unless WithInlineMode is set.
+func (p *provider) programOptions() []tea.ProgramOption {
+	if p.inline {
+		return nil
+	}
+	return []tea.ProgramOption{tea.WithAltScreen()}
+}
+
 // screen identifies which screen is currently displayed.
 type screen int
 
@@ -270,6 +337,17 @@ const (
 	screenResponse
 )
 
+// navState is a screen the user navigated away from, along with the state
+// needed to show it again as it was.
+type navState struct {
+	screen          screen
+	selectedService int
+	selectedMethod  int
+	methodList      list.Model
+	form            formModel
+	responseView    bubbles.ResponseView
+}
+
 // rootModel is the top-level bubbletea model.
 type rootModel struct {
 	ctx      context.Context
@@ -280,6 +358,9 @@ type rootModel struct {
 	selectedService int
 	selectedMethod  int
 
+	// history holds the screens to return to on Esc, most recent last.
+	history []navState
+
 	// Sub-models
 	methodList   list.Model
 	form         formModel
@@ -288,11 +369,12 @@ type rootModel struct {
 	errorText string
 
 	// Streaming state. Active only while a server-streaming RPC is in flight.
-	streamCh     <-chan tea.Msg  // nil when no stream is active
-	streamActive bool
-	streamCount  int
-	streamCancel context.CancelFunc // cancels the stream's derived context
-	streamBuf    []proto.Message    // fallback buffer for non-StreamingResponseView factories
+	streamQueue   *streamQueue // nil when no stream is active
+	streamActive  bool
+	streamCount   int
+	streamDropped int                // messages discarded by the view's StreamPolicy
+	streamCancel  context.CancelFunc // cancels the stream's derived context
+	streamBuf     []proto.Message    // fallback buffer for non-StreamingResponseView factories
 
 	width  int
 	height int
@@ -302,10 +384,11 @@ type rootModel struct {
 	modalTitle   string
 	modalContent string
 
-	styles               bubbles.Styles
-	customControls       map[string]bubbles.ControlFactory
-	customControlsByName map[string]bubbles.ControlFactory
-	responseViewFactory  bubbles.ResponseViewFactory // nil = default JSON viewport
+	styles                      bubbles.Styles
+	customControls              map[string]bubbles.ControlFactory
+	customControlsByName        map[string]bubbles.ControlFactory
+	responseViewFactory         bubbles.ResponseViewFactory // nil = default JSON viewport
+	responseViewFactoriesByType map[string]bubbles.ResponseViewFactory
 }
 
 // methodItem implements list.Item for method descriptors.
@@ -325,18 +408,20 @@ func newRootModel(
 	customControls map[string]bubbles.ControlFactory,
 	customControlsByName map[string]bubbles.ControlFactory,
 	responseViewFactory bubbles.ResponseViewFactory,
+	responseViewFactoriesByType map[string]bubbles.ResponseViewFactory,
 	cfg protocli.TUIRunConfig,
 ) rootModel {
 	m := rootModel{
-		ctx:                  ctx,
-		cmd:                  cmd,
-		services:             services,
-		currentScreen:        screenMethodList,
-		selectedService:      0,
-		styles:               styles,
-		customControls:       customControls,
-		customControlsByName: customControlsByName,
-		responseViewFactory:  responseViewFactory,
+		ctx:                         ctx,
+		cmd:                         cmd,
+		services:                    services,
+		currentScreen:               screenMethodList,
+		selectedService:             0,
+		styles:                      styles,
+		customControls:              customControls,
+		customControlsByName:        customControlsByName,
+		responseViewFactory:         responseViewFactory,
+		responseViewFactoriesByType: responseViewFactoriesByType,
 	}
 
 	// Apply deep-link: find the requested starting service.
@@ -410,6 +495,27 @@ func (m rootModel) headerHeight(method protocli.TUIMethod) int {
 	return m.tabBarHeight() + m.breadcrumbHeight(method)
 }
 
+// responseViewHeight returns the height of the content area of rv, the
+// response view of method: the screen minus the header, the help line and
+// rv's footer.
+func (m rootModel) responseViewHeight(method protocli.TUIMethod, rv bubbles.ResponseView) int {
+	height := m.height - m.headerHeight(method) - 2
+	if footer := responseFooter(rv); footer != "" {
+		height -= strings.Count(footer, "\n") + 1
+	}
+	return height
+}
+
+// responseFooter returns the footer of rv if it implements
+// bubbles.FooterProvider, without trailing newlines.
+func responseFooter(rv bubbles.ResponseView) string {
+	fp, ok := rv.(bubbles.FooterProvider)
+	if !ok {
+		return ""
+	}
+	return strings.TrimRight(fp.Footer(), "\n")
+}
+
 // viewHeader renders the consistent top-of-screen area: the service tab bar
 // (when multiple services are present) followed by an optional method
 // breadcrumb. All screens call this to anchor the same north-edge structure.
@@ -450,11 +556,12 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if svc.TUIName() != msg.ServiceName {
 				continue
 			}
-			m.selectedService = i
-			m.methodList = newMethodList(svc)
-			m.methodList.SetSize(m.width, m.height-m.tabBarHeight())
 			for j, method := range svc.TUIMethods() {
 				if method.TUIName() == msg.MethodName && !method.TUIHidden() {
+					m.pushHistory()
+					m.selectedService = i
+					m.methodList = newMethodList(svc)
+					m.methodList.SetSize(m.width, m.height-m.tabBarHeight())
 					m.selectedMethod = j
 					m.form = newFormModel(method, m.styles, m.customControls, m.customControlsByName, msg.FieldValues)
 					m.currentScreen = screenForm
@@ -471,17 +578,26 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case streamItemMsg:
+		if msg.queue != m.streamQueue {
+			// Left over from a stream that was cancelled
+			return m, nil
+		}
 		m.streamCount++
+		m.streamDropped = msg.dropped
 		if srv, ok := m.responseView.(bubbles.StreamingResponseView); ok {
 			srv.Append(msg.msg)
 		} else {
 			m.streamBuf = append(m.streamBuf, msg.msg)
 		}
-		return m, readStreamCh(m.streamCh)
+		return m, readStream(m.streamQueue)
 
 	case streamDoneMsg:
+		if msg.queue != m.streamQueue {
+			return m, nil
+		}
 		m.streamActive = false
-		m.streamCh = nil
+		m.streamQueue = nil
+		m.streamDropped = msg.dropped
 		m.streamCancel = nil
 		if srv, ok := m.responseView.(bubbles.StreamingResponseView); ok {
 			srv.Done(msg.err)
@@ -492,8 +608,7 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.errorText = msg.err.Error()
 			} else if len(m.streamBuf) > 0 {
 				method := m.services[m.selectedService].TUIMethods()[m.selectedMethod]
-				respViewHeight := m.height - m.headerHeight(method) - 2
-				m.responseView.Init(m.ctx, m.streamBuf[len(m.streamBuf)-1], m.width, respViewHeight)
+				m.responseView.Init(m.ctx, m.streamBuf[len(m.streamBuf)-1], m.width, m.responseViewHeight(method, m.responseView))
 			}
 			m.streamBuf = nil
 		}
@@ -505,7 +620,7 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.methodList.SetSize(msg.Width, msg.Height-m.tabBarHeight())
 		if m.currentScreen == screenResponse && m.responseView != nil {
 			method := m.services[m.selectedService].TUIMethods()[m.selectedMethod]
-			m.responseView.SetSize(msg.Width, msg.Height-m.headerHeight(method)-2)
+			m.responseView.SetSize(msg.Width, m.responseViewHeight(method, m.responseView))
 		}
 
 	case tea.KeyMsg:
@@ -531,7 +646,9 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, cmd
 					}
 				}
-				m.currentScreen = screenMethodList
+				if !m.popHistory() {
+					m.currentScreen = screenMethodList
+				}
 				m.errorText = ""
 				return m, nil
 			case screenResponse:
@@ -540,15 +657,22 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.streamCancel()
 					}
 					m.streamActive = false
-					m.streamCh = nil
+					m.streamQueue = nil
 					m.streamBuf = nil
 					m.streamCancel = nil
 				}
-				m.currentScreen = screenForm
+				if !m.popHistory() {
+					m.currentScreen = screenForm
+				}
 				m.errorText = ""
 				return m, nil
 			}
 
+		case "ctrl+y":
+			if m.currentScreen == screenForm && !m.modalActive {
+				return m.copyAsCommand(), nil
+			}
+
 		case "left":
 			if m.currentScreen == screenMethodList && m.methodList.FilterState() != list.Filtering {
 				m.selectedService = (m.selectedService - 1 + len(m.services)) % len(m.services)
@@ -575,6 +699,7 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							break
 						}
 					}
+					m.pushHistory()
 					m.form = newFormModel(selected.method, m.styles, m.customControls, m.customControlsByName, nil)
 					m.currentScreen = screenForm
 					m.errorText = ""
@@ -603,6 +728,7 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 					m.currentScreen = screenMethodList
+					m.history = nil
 					return m, nil
 				}
 			}
@@ -745,8 +871,9 @@ func (m rootModel) viewForm() string {
 	}
 
 	helpText := bubbles.FormHelpText(
-		bubbles.KeyBind{"↑↓/Tab", "navigate"},
-		bubbles.KeyBind{"Enter", "submit"},
+		bubbles.KeyBind{Keys: "↑↓/Tab", Op: "navigate"},
+		bubbles.KeyBind{Keys: "Enter", Op: "submit"},
+		bubbles.KeyBind{Keys: "Ctrl+Y", Op: "copy as command"},
 	)
 	if m.form.focused < len(m.form.controls) {
 		ctrl := m.form.controls[m.form.focused]
@@ -770,12 +897,18 @@ func (m rootModel) viewResponse() string {
 	}
 	if m.responseView != nil {
 		sb.WriteString(m.responseView.View())
+		if footer := responseFooter(m.responseView); footer != "" {
+			sb.WriteString("\n" + footer)
+		}
 	}
 	var helpText string
 	if m.streamActive {
 		helpText = fmt.Sprintf("Streaming… (%d received) • Esc: cancel", m.streamCount)
+		if m.streamDropped > 0 {
+			helpText = fmt.Sprintf("Streaming… (%d received, %d dropped) • Esc: cancel", m.streamCount, m.streamDropped)
+		}
 	} else {
-		helpText = "Esc: back to form • Enter: back to methods"
+		helpText = "Esc: back • Enter: back to methods"
 		if m.responseView != nil {
 			if htp, ok := m.responseView.(bubbles.HelpTextProvider); ok {
 				if t := htp.HelpText(); t != "" {
@@ -783,11 +916,62 @@ func (m rootModel) viewResponse() string {
 				}
 			}
 		}
+		if m.streamDropped > 0 {
+			helpText = fmt.Sprintf("(%d dropped) • %s", m.streamDropped, helpText)
+		}
 	}
 	sb.WriteString("\n" + m.styles.Help.Render(helpText))
 	return sb.String()
 }
 
+// pushHistory records the current screen so that Esc can return to it.
+func (m *rootModel) pushHistory() {
+	m.history = append(m.history, navState{
+		screen:          m.currentScreen,
+		selectedService: m.selectedService,
+		selectedMethod:  m.selectedMethod,
+		methodList:      m.methodList,
+		form:            m.form,
+		responseView:    m.responseView,
+	})
+}
+
+// popHistory returns to the most recently recorded screen. It reports false
+// if there is none, e.g. after starting on a deep-linked form.
+func (m *rootModel) popHistory() bool {
+	if len(m.history) == 0 {
+		return false
+	}
+	prev := m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+
+	m.currentScreen = prev.screen
+	m.selectedService = prev.selectedService
+	m.selectedMethod = prev.selectedMethod
+	m.methodList = prev.methodList
+	m.methodList.SetSize(m.width, m.height-m.tabBarHeight())
+	m.form = prev.form
+	m.responseView = prev.responseView
+	if m.currentScreen == screenResponse && m.responseView != nil {
+		method := m.services[m.selectedService].TUIMethods()[m.selectedMethod]
+		m.responseView.SetSize(m.width, m.responseViewHeight(method, m.responseView))
+	}
+	return true
+}
+
+// responseViewFactoryFor returns the factory for responses described by desc:
+// the one registered for its message type, else the WithResponseView one, else
+// the default JSON viewport.
+func (m rootModel) responseViewFactoryFor(desc protocli.TUIResponseDescriptor) bubbles.ResponseViewFactory {
+	if fac, ok := m.responseViewFactoriesByType[desc.MessageFullName]; ok {
+		return fac
+	}
+	if m.responseViewFactory != nil {
+		return m.responseViewFactory
+	}
+	return bubbles.NewViewportResponseView()
+}
+
 func (m rootModel) submitForm() (tea.Model, tea.Cmd) {
 	method := m.services[m.selectedService].TUIMethods()[m.selectedMethod]
 	req := method.TUINewRequest()
@@ -835,38 +1019,39 @@ func (m rootModel) submitForm() (tea.Model, tea.Cmd) {
 		}
 	}
 
-	fac := m.responseViewFactory
-	if fac == nil {
-		fac = bubbles.NewViewportResponseView()
-	}
-	rv := fac(method.TUIResponseDescriptor(), m.styles)
-	respViewHeight := m.height - m.headerHeight(method) - 2
+	m.pushHistory()
+	desc := method.TUIResponseDescriptor()
+	rv := m.responseViewFactoryFor(desc)(desc, m.styles)
+	respViewHeight := m.responseViewHeight(method, rv)
+	m.streamDropped = 0
 
 	if method.TUIIsStreaming() {
 		// Derive a cancellable context so Esc can abort the stream.
 		streamCtx, cancel := context.WithCancel(m.ctx)
 
-		// Buffered so the goroutine can finish writing even if the user
-		// navigates away before all messages are consumed.
-		ch := make(chan tea.Msg, 16)
+		// The view decides whether a stream it cannot keep up with is paused
+		// or thinned out; cancelling the context releases a paused producer.
+		policy := bubbles.StreamBlock
+		if spp, ok := rv.(bubbles.StreamPolicyProvider); ok {
+			policy = spp.StreamPolicy()
+		}
+		queue := newStreamQueue(policy, bubbles.StreamBufferSize)
 		go func() {
-			err := method.TUIInvokeStream(streamCtx, m.cmd, req, func(msg proto.Message) error {
-				ch <- streamItemMsg{msg: msg}
-				return nil
-			})
-			ch <- streamDoneMsg{err: err}
+			queue.finish(method.TUIInvokeStream(streamCtx, m.cmd, req, func(msg proto.Message) error {
+				return queue.push(streamCtx, msg)
+			}))
 		}()
 
 		rv.Init(streamCtx, nil, m.width, respViewHeight)
 		m.responseView = rv
-		m.streamCh = ch
+		m.streamQueue = queue
 		m.streamActive = true
 		m.streamCount = 0
 		m.streamCancel = cancel
 		m.streamBuf = nil
 		m.currentScreen = screenResponse
 		m.errorText = ""
-		return m, readStreamCh(ch)
+		return m, readStream(queue)
 	}
 
 	// Unary method
@@ -939,10 +1124,8 @@ func newFormModel(method protocli.TUIMethod, styles bubbles.Styles, customContro
 
 	flatFields := flattenFields(method.TUIInputFields(), "", customControls)
 
-	for _, field := range flatFields {
-		if field.Hidden {
-			continue
-		}
+	for i := 0; i < len(flatFields); i++ {
+		field := flatFields[i]
 
 		// Prefill overrides the proto-defined default for this field.
 		// Mutate the local copy so both the default control constructors and
@@ -951,59 +1134,34 @@ func newFormModel(method protocli.TUIMethod, styles bubbles.Styles, customContro
 			field.DefaultValue = v
 		}
 
-		var ctrl bubbles.FormControl
-
-		// Name-based override has highest priority — checked before type-based so
-		// WithCustomControlForField("when", ...) can override WithTimestampControl().
-		if factory, ok := customControlsByName[field.Name]; ok {
-			ctrl = factory(field, styles)
-		}
-
-		// Type-based custom control by proto message full name. Covers both ordinary
-		// TUIFieldKindMessage fields and WKT fields (e.g. google.protobuf.Timestamp)
-		// which the generator promotes to TUIFieldKindString but still annotates with
-		// MessageFullName, allowing WithTimestampControl() to target them.
-		if ctrl == nil && field.MessageFullName != "" {
-			if factory, ok := customControls[field.MessageFullName]; ok {
-				ctrl = factory(field, styles)
+		// Members of a oneof are adjacent; gather them into a single selector
+		// control so at most one of them is set.
+		if field.OneofGroup != "" {
+			var members []protocli.TUIFieldDescriptor
+			var memberControls []bubbles.FormControl
+			for ; i < len(flatFields) && flatFields[i].OneofGroup == field.OneofGroup; i++ {
+				member := flatFields[i]
+				if v, ok := prefill[member.Name]; ok {
+					member.DefaultValue = v
+				}
+				if ctrl := newFieldControl(member, styles, customControls, customControlsByName); ctrl != nil {
+					members = append(members, member)
+					memberControls = append(memberControls, ctrl)
+				}
 			}
-		}
-
-		// TUIFieldKindMessage fields only reach here when flattenFields kept them
-		// because a custom control was registered. Guard against the unexpected case.
-		if field.Kind == protocli.TUIFieldKindMessage && ctrl == nil {
+			i--
+			if len(members) == 0 {
+				continue
+			}
+			fm.fields = append(fm.fields, oneofField(field.OneofGroup, members))
+			fm.controls = append(fm.controls, bubbles.NewOneofControl(members, memberControls, styles))
 			continue
 		}
 
+		ctrl := newFieldControl(field, styles, customControls, customControlsByName)
 		if ctrl == nil {
-			switch field.Kind {
-			case protocli.TUIFieldKindBool:
-				ctrl = bubbles.NewToggleControl(field.DefaultValue, styles)
-			case protocli.TUIFieldKindInt:
-				ctrl = bubbles.NewNumberControl(field.Usage, field.DefaultValue, false, styles)
-			case protocli.TUIFieldKindFloat:
-				ctrl = bubbles.NewNumberControl(field.Usage, field.DefaultValue, true, styles)
-			case protocli.TUIFieldKindRepeated:
-				if field.Appender == nil {
-					// Repeated messages without a custom control cannot be handled.
-					continue
-				}
-				ctrl = bubbles.NewListControl("item, item, …", field.DefaultValue, styles)
-			case protocli.TUIFieldKindEnum:
-				placeholder := field.Usage
-				if len(field.EnumValues) > 0 {
-					names := make([]string, 0, len(field.EnumValues))
-					for _, ev := range field.EnumValues {
-						names = append(names, ev.Name)
-					}
-					placeholder = strings.Join(names, "|")
-				}
-				ctrl = bubbles.NewTextControl(placeholder, field.DefaultValue, styles)
-			default:
-				ctrl = bubbles.NewTextControl(field.Usage, field.DefaultValue, styles)
-			}
+			continue
 		}
-
 		fm.fields = append(fm.fields, field)
 		fm.controls = append(fm.controls, ctrl)
 	}
@@ -1015,6 +1173,78 @@ func newFormModel(method protocli.TUIMethod, styles bubbles.Styles, customContro
 	return fm
 }
 
+// newFieldControl returns the control for a single form field: a control
+// registered for the field's name, then one registered for its message type,
+// then the default for its kind. It returns nil for fields the form cannot
+// edit.
+func newFieldControl(field protocli.TUIFieldDescriptor, styles bubbles.Styles, customControls map[string]bubbles.ControlFactory, customControlsByName map[string]bubbles.ControlFactory) bubbles.FormControl {
+	var ctrl bubbles.FormControl
+
+	// Name-based override has highest priority — checked before type-based so
+	// WithCustomControlForField("when", ...) can override WithTimestampControl().
+	if factory, ok := customControlsByName[field.Name]; ok {
+		ctrl = factory(field, styles)
+	}
+
+	// Type-based custom control by proto message full name. Covers both ordinary
+	// TUIFieldKindMessage fields and WKT fields (e.g. google.protobuf.Timestamp)
+	// which the generator promotes to TUIFieldKindString but still annotates with
+	// MessageFullName, allowing WithTimestampControl() to target them.
+	if ctrl == nil && field.MessageFullName != "" {
+		if factory, ok := customControls[field.MessageFullName]; ok {
+			ctrl = factory(field, styles)
+		}
+	}
+
+	// TUIFieldKindMessage fields only reach here when flattenFields kept them
+	// because a custom control was registered. Guard against the unexpected case.
+	if field.Kind == protocli.TUIFieldKindMessage && ctrl == nil {
+		return nil
+	}
+
+	if ctrl == nil {
+		switch field.Kind {
+		case protocli.TUIFieldKindBool:
+			ctrl = bubbles.NewToggleControl(field.DefaultValue, styles)
+		case protocli.TUIFieldKindInt:
+			ctrl = bubbles.NewNumberControl(field.Usage, field.DefaultValue, false, styles)
+		case protocli.TUIFieldKindFloat:
+			ctrl = bubbles.NewNumberControl(field.Usage, field.DefaultValue, true, styles)
+		case protocli.TUIFieldKindRepeated:
+			if field.Appender == nil {
+				// Repeated messages without a custom control cannot be handled.
+				return nil
+			}
+			ctrl = bubbles.NewListControl("item, item, …", field.DefaultValue, styles)
+		case protocli.TUIFieldKindEnum:
+			placeholder := field.Usage
+			if len(field.EnumValues) > 0 {
+				names := make([]string, 0, len(field.EnumValues))
+				for _, ev := range field.EnumValues {
+					names = append(names, ev.Name)
+				}
+				placeholder = strings.Join(names, "|")
+			}
+			ctrl = bubbles.NewTextControl(placeholder, field.DefaultValue, styles)
+		default:
+			ctrl = bubbles.NewTextControl(field.Usage, field.DefaultValue, styles)
+		}
+	}
+
+	return ctrl
+}
+
+// oneofField describes the form entry standing for the members of a oneof,
+// labelled with the oneof name under the members' breadcrumb.
+func oneofField(group string, members []protocli.TUIFieldDescriptor) protocli.TUIFieldDescriptor {
+	label := strings.ToUpper(group[:1]) + group[1:]
+	label = strings.Join(strings.FieldsFunc(label, func(r rune) bool { return r == '_' }), " ")
+	if idx := strings.LastIndex(members[0].Label, " › "); idx >= 0 {
+		label = members[0].Label[:idx] + " › " + label
+	}
+	return protocli.TUIFieldDescriptor{Name: group, Label: label, OneofGroup: group}
+}
+
 func (fm formModel) nextField() (formModel, tea.Cmd) {
 	if len(fm.controls) == 0 {
 		return fm, nil
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/contrib/tui/bubbles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// namedView is a ResponseView that only records which factory created it.
+type namedView struct{ name string }
+
+func (namedView) Init(context.Context, proto.Message, int, int) tea.Cmd { return nil }
+func (namedView) SetSize(int, int)                                      {}
+func (namedView) Update(tea.Msg) tea.Cmd                                { return nil }
+func (namedView) View() string                                          { return "" }
+
+func namedViewFactory(name string) bubbles.ResponseViewFactory {
+	return func(protocli.TUIResponseDescriptor, bubbles.Styles) bubbles.ResponseView {
+		return namedView{name: name}
+	}
+}
+
+// selectedView returns the view the provider built with opts creates for a
+// response of the given message type.
+func selectedView(opts []Option, messageFullName string) bubbles.ResponseView {
+	p := New(opts...).(*provider)
+	m := newRootModel(context.Background(), nil, nil, p.styles, p.customControls, p.customControlsByName,
+		p.responseViewFactory, p.responseViewFactoriesByType, protocli.TUIRunConfig{})
+	desc := protocli.TUIResponseDescriptor{MethodName: "list-people", MessageFullName: messageFullName}
+	return m.responseViewFactoryFor(desc)(desc, m.styles)
+}
+
+func TestResponseViewFactoryFor_TypeRegistrationWins(t *testing.T) {
+	opts := []Option{
+		WithResponseView(namedViewFactory("method")),
+		WithResponseViewForType("example.PersonList", namedViewFactory("person-list")),
+		WithResponseViewForType("example.Person", namedViewFactory("person")),
+	}
+
+	assert.Equal(t, namedView{name: "person-list"}, selectedView(opts, "example.PersonList"))
+	assert.Equal(t, namedView{name: "person"}, selectedView(opts, "example.Person"))
+	assert.Equal(t, namedView{name: "method"}, selectedView(opts, "example.Other"),
+		"other types fall back to WithResponseView")
+}
+
+func TestResponseViewFactoryFor_DefaultViewport(t *testing.T) {
+	opts := []Option{WithResponseViewForType("example.PersonList", namedViewFactory("person-list"))}
+
+	assert.IsType(t, namedView{}, selectedView(opts, "example.PersonList"))
+	_, custom := selectedView(opts, "example.Other").(namedView)
+	assert.False(t, custom, "unregistered types use the default JSON viewport")
+}
+
+// navMethod is a unary TUIMethod with no input fields that returns an empty
+// response.
+type navMethod struct{ name string }
+
+func (n navMethod) TUIName() string        { return n.name }
+func (n navMethod) TUIDisplayName() string { return n.name }
+func (navMethod) TUIDescription() string   { return "" }
+func (navMethod) TUIHidden() bool          { return false }
+func (navMethod) TUIIsStreaming() bool     { return false }
+func (n navMethod) TUIResponseDescriptor() protocli.TUIResponseDescriptor {
+	return protocli.TUIResponseDescriptor{MethodName: n.name, MessageFullName: "example." + n.name}
+}
+func (navMethod) TUINewRequest() proto.Message                  { return &emptypb.Empty{} }
+func (navMethod) TUIInputFields() []protocli.TUIFieldDescriptor { return nil }
+func (navMethod) TUIInvoke(context.Context, *cli.Command, proto.Message) (proto.Message, error) {
+	return &emptypb.Empty{}, nil
+}
+func (navMethod) TUIInvokeStream(context.Context, *cli.Command, proto.Message, func(proto.Message) error) error {
+	return nil
+}
+
+type navService struct{ methods []protocli.TUIMethod }
+
+func (navService) TUIName() string                    { return "people" }
+func (navService) TUIDisplayName() string             { return "People" }
+func (navService) TUIDescription() string             { return "" }
+func (s navService) TUIMethods() []protocli.TUIMethod { return s.methods }
+
+// update feeds msg to m and returns the resulting model.
+func update(t *testing.T, m rootModel, msg tea.Msg) rootModel {
+	t.Helper()
+	next, _ := m.Update(msg)
+	return next.(rootModel)
+}
+
+// TestRootModel_BackReturnsToOriginatingGrid tests that Esc after
+// NavigateToForm goes back to the response grid the user came from, and then
+// on through the screens that led to it.
+func TestRootModel_BackReturnsToOriginatingGrid(t *testing.T) {
+	services := []protocli.TUIService{navService{methods: []protocli.TUIMethod{
+		navMethod{name: "list-people"},
+		navMethod{name: "greet"},
+	}}}
+	grid := &namedView{name: "grid"}
+	byType := map[string]bubbles.ResponseViewFactory{
+		"example.list-people": func(protocli.TUIResponseDescriptor, bubbles.Styles) bubbles.ResponseView { return grid },
+	}
+	m := newRootModel(context.Background(), nil, services, bubbles.DefaultStyles(), nil, nil, nil, byType, protocli.TUIRunConfig{})
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter}) // open the list-people form
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter}) // submit it
+	require.Equal(t, screenResponse, m.currentScreen)
+	require.Same(t, grid, m.responseView)
+
+	m = update(t, m, NavigateToFormMsg{ServiceName: "people", MethodName: "greet"})
+	require.Equal(t, screenForm, m.currentScreen)
+	assert.Equal(t, 1, m.selectedMethod)
+
+	m = update(t, m, esc)
+	assert.Equal(t, screenResponse, m.currentScreen, "Esc returns to the grid")
+	assert.Same(t, grid, m.responseView)
+	assert.Equal(t, 0, m.selectedMethod)
+
+	m = update(t, m, esc)
+	assert.Equal(t, screenForm, m.currentScreen)
+	assert.Equal(t, "list-people", m.form.method.TUIName())
+
+	m = update(t, m, esc)
+	assert.Equal(t, screenMethodList, m.currentScreen)
+	assert.Empty(t, m.history)
+}
+
+func TestRootModel_BackFromDeepLinkedForm(t *testing.T) {
+	services := []protocli.TUIService{navService{methods: []protocli.TUIMethod{navMethod{name: "greet"}}}}
+	m := newRootModel(context.Background(), nil, services, bubbles.DefaultStyles(), nil, nil, nil, nil,
+		protocli.TUIRunConfig{StartServiceName: "people", StartMethodName: "greet"})
+	require.Equal(t, screenForm, m.currentScreen)
+
+	m = update(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, screenMethodList, m.currentScreen, "without history Esc falls back to the method list")
+}
+
+// footerView is a ResponseView with a multi-line footer that records the
+// content height it was given.
+type footerView struct {
+	namedView
+	footer string
+	height int
+}
+
+func (v *footerView) Init(_ context.Context, _ proto.Message, _, height int) tea.Cmd {
+	v.height = height
+	return nil
+}
+func (v *footerView) SetSize(_, height int) { v.height = height }
+func (v *footerView) View() string          { return "content" }
+func (v *footerView) Footer() string        { return v.footer }
+
+// TestRootModel_ResponseFooter tests that the footer of a FooterProvider view
+// is rendered between its content and the help line, and that the content
+// area shrinks by the footer's height.
+func TestRootModel_ResponseFooter(t *testing.T) {
+	tests := []struct {
+		name       string
+		footer     string
+		wantHeight int
+	}{
+		{name: "multi-line footer", footer: "red: failed\ngreen: passed\n", wantHeight: 18 - 2},
+		{name: "empty footer", footer: "", wantHeight: 18},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services := []protocli.TUIService{navService{methods: []protocli.TUIMethod{navMethod{name: "list-people"}}}}
+			view := &footerView{footer: tt.footer}
+			factory := func(protocli.TUIResponseDescriptor, bubbles.Styles) bubbles.ResponseView { return view }
+			m := newRootModel(context.Background(), nil, services, bubbles.DefaultStyles(), nil, nil, factory, nil, protocli.TUIRunConfig{})
+			m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 20})
+			wantHeight := tt.wantHeight - m.headerHeight(services[0].TUIMethods()[0])
+
+			m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter}) // open the form
+			m = update(t, m, tea.KeyMsg{Type: tea.KeyEnter}) // submit it
+			require.Equal(t, screenResponse, m.currentScreen)
+			assert.Equal(t, wantHeight, view.height)
+
+			out := m.View()
+			if tt.footer == "" {
+				assert.NotContains(t, out, "content\n\n", "no blank footer is added")
+				return
+			}
+			footerAt := strings.Index(out, "red: failed\ngreen: passed")
+			require.NotEqual(t, -1, footerAt, "footer missing from:\n%s", out)
+			assert.Less(t, strings.Index(out, "content"), footerAt, "footer follows the content")
+			assert.Less(t, footerAt, strings.Index(out, "Esc: back"), "footer precedes the help line")
+
+			m = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 30})
+			assert.Equal(t, wantHeight+10, view.height, "resizing keeps room for the footer")
+		})
+	}
+}
+
+func TestProvider_ProgramOptions(t *testing.T) {
+	assert.Len(t, New().(*provider).programOptions(), 1, "the alt screen is used by default")
+	assert.Empty(t, New(WithInlineMode()).(*provider).programOptions(), "inline mode runs without the alt screen")
+}
@@ -362,6 +362,78 @@ func TestIntegration_DaemonLifecycle_AccessToServerInStartup(t *testing.T) {
 	waitForDone(t, done)
 }
 
+// poolKey is the context key of the resource opened by the startup hook in
+// TestIntegration_DaemonLifecycle_StartupContextReachesShutdown.
+type poolKey struct{}
+
+// TestIntegration_DaemonLifecycle_StartupContextReachesShutdown verifies that a
+// value added to the context by a startup hook is visible to later startup,
+// ready and shutdown hooks.
+func TestIntegration_DaemonLifecycle_StartupContextReachesShutdown(t *testing.T) {
+	preventExit(t)
+
+	var (
+		mu             sync.Mutex
+		laterStartup   any
+		atReady        any
+		atShutdown     any
+		shutdownCalled bool
+	)
+	readyCh := make(chan struct{})
+
+	startup := func(ctx context.Context, _ *grpc.Server, _ *runtime.ServeMux) (context.Context, error) {
+		return context.WithValue(ctx, poolKey{}, "pool"), nil
+	}
+	plainStartup := func(ctx context.Context, _ *grpc.Server, _ *runtime.ServeMux) error {
+		mu.Lock()
+		laterStartup = ctx.Value(poolKey{})
+		mu.Unlock()
+		return nil
+	}
+	ready := func(ctx context.Context) {
+		mu.Lock()
+		atReady = ctx.Value(poolKey{})
+		mu.Unlock()
+		close(readyCh)
+	}
+	shutdown := func(ctx context.Context) {
+		mu.Lock()
+		atShutdown = ctx.Value(poolKey{})
+		shutdownCalled = true
+		mu.Unlock()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(simple.UserServiceCommand(ctx, newUserService)),
+		protocli.OnDaemonStartupContext(startup),
+		protocli.OnDaemonStartup(plainStartup),
+		protocli.OnDaemonReady(ready),
+		protocli.OnDaemonShutdown(shutdown),
+	)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = rootCmd.Run(ctx, []string{"testcli", "daemonize", "--port", "50211"})
+	}()
+	waitForReady(t, readyCh)
+
+	// Cancelling the original context still shuts the daemon down
+	cancel()
+	waitForDone(t, done)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.True(t, shutdownCalled)
+	assert.Equal(t, "pool", laterStartup)
+	assert.Equal(t, "pool", atReady)
+	assert.Equal(t, "pool", atShutdown)
+}
+
 // Helper: userService implementation for tests.
 type testUserService struct {
 	simple.UnimplementedUserServiceServer
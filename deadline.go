@@ -0,0 +1,77 @@
+package protocli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+// ErrGlobalDeadlineExceeded is returned when an invocation runs longer than
+// the root --deadline flag (see WithGlobalTimeout).
+var ErrGlobalDeadlineExceeded = errors.New("global deadline exceeded")
+
+// globalDeadlineKey is the context key of the *globalDeadline of a run.
+type globalDeadlineKey struct{}
+
+// globalDeadline is the --deadline resolved for a run. cancel is set only for
+// the run that started the deadline, not for nested runs that inherit it.
+type globalDeadline struct {
+	d      time.Duration
+	cancel context.CancelFunc
+}
+
+// startGlobalDeadline bounds the rest of the run by the root --deadline flag.
+// It is called from the root Before hook, so the Before hooks of services and
+// methods are bounded along with the action. Nested runs of the root command
+// (apply steps, --pick) inherit the deadline of the outer run rather than
+// reading --deadline again. daemonize runs until it is stopped.
+func startGlobalDeadline(ctx context.Context, root *cli.Command) context.Context {
+	if outer, ok := ctx.Value(globalDeadlineKey{}).(*globalDeadline); ok {
+		return context.WithValue(ctx, globalDeadlineKey{}, &globalDeadline{d: outer.d})
+	}
+	deadline := &globalDeadline{d: root.Duration("deadline")}
+	if deadline.d > 0 && selectedCommand(root).Name != "daemonize" {
+		ctx, deadline.cancel = context.WithTimeoutCause(ctx, deadline.d,
+			fmt.Errorf("%w after %s", ErrGlobalDeadlineExceeded, deadline.d))
+	}
+	return context.WithValue(ctx, globalDeadlineKey{}, deadline)
+}
+
+// stopGlobalDeadline releases the deadline started by this run, from the root
+// After hook.
+func stopGlobalDeadline(ctx context.Context) {
+	if deadline, ok := ctx.Value(globalDeadlineKey{}).(*globalDeadline); ok && deadline.cancel != nil {
+		deadline.cancel()
+	}
+}
+
+// wrapDeadlineErrors replaces whatever error a Before hook or action below cmd
+// returns once the global deadline has passed with ErrGlobalDeadlineExceeded.
+func wrapDeadlineErrors(cmd *cli.Command) {
+	for _, sub := range cmd.Commands {
+		wrapDeadlineErrors(sub)
+		if before := sub.Before; before != nil {
+			sub.Before = func(ctx context.Context, c *cli.Command) (context.Context, error) {
+				newCtx, err := before(ctx, c)
+				return newCtx, deadlineError(ctx, err)
+			}
+		}
+		if action := sub.Action; action != nil {
+			sub.Action = func(ctx context.Context, c *cli.Command) error {
+				return deadlineError(ctx, action(ctx, c))
+			}
+		}
+	}
+}
+
+// deadlineError returns the global deadline error in place of err when ctx
+// was canceled by it.
+func deadlineError(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); err != nil && errors.Is(cause, ErrGlobalDeadlineExceeded) {
+		return cause
+	}
+	return err
+}
@@ -0,0 +1,136 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// slowUserService takes delay to create each user, or until ctx is done.
+type slowUserService struct {
+	mockUserService
+	delay   time.Duration
+	created atomic.Int32
+}
+
+func (s *slowUserService) CreateUser(ctx context.Context, req *simple.CreateUserRequest) (*simple.UserResponse, error) {
+	select {
+	case <-time.After(s.delay):
+		s.created.Add(1)
+		return &simple.UserResponse{User: &simple.User{Name: req.GetName()}}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func runDeadlineCLI(t *testing.T, svc simple.UserServiceServer, opts []protocli.RootOption, args ...string) error {
+	t.Helper()
+	setupTestCLI(t)
+	ctx := context.Background()
+	rootCmd, err := protocli.RootCommand("testcli", append([]protocli.RootOption{
+		protocli.Service(simple.UserServiceCommand(ctx, func(*simple.UserServiceConfig) simple.UserServiceServer { return svc })),
+	}, opts...)...)
+	require.NoError(t, err)
+
+	setWriterOnAllCommands(rootCmd, &bytes.Buffer{})
+	rootCmd.ErrWriter = &bytes.Buffer{}
+	return rootCmd.Run(ctx, append([]string{"testcli"}, args...))
+}
+
+// TestIntegration_GlobalTimeout_AbortsBatch tests that a long apply batch
+// stops at the global deadline instead of running every file.
+func TestIntegration_GlobalTimeout_AbortsBatch(t *testing.T) {
+	files := make(map[string]string)
+	for i := range 50 {
+		files[fmt.Sprintf("%02d-user-service.create.json", i)] = fmt.Sprintf(`{"name": "user%d", "email": "user%d@example.com"}`, i, i)
+	}
+	dir := writeApplyDir(t, files)
+	svc := &slowUserService{delay: 20 * time.Millisecond}
+
+	start := time.Now()
+	err := runDeadlineCLI(t, svc, []protocli.RootOption{protocli.WithGlobalTimeout(100 * time.Millisecond)},
+		"apply", "--dir", dir, "--", "--db-url", "postgres://localhost/db")
+	elapsed := time.Since(start)
+
+	require.ErrorIs(t, err, protocli.ErrGlobalDeadlineExceeded)
+	assert.Contains(t, err.Error(), "global deadline exceeded after 100ms")
+	assert.Less(t, svc.created.Load(), int32(50), "the batch does not run to completion")
+	assert.Less(t, elapsed, time.Second)
+}
+
+func TestIntegration_GlobalTimeout_DeadlineFlagOverridesOption(t *testing.T) {
+	svc := &slowUserService{delay: 50 * time.Millisecond}
+	args := []string{"user-service", "create", "--db-url", "postgres://localhost/db", "--name", "Ada", "--email", "ada@example.com"}
+
+	err := runDeadlineCLI(t, svc, []protocli.RootOption{protocli.WithGlobalTimeout(10 * time.Millisecond)}, args...)
+	require.ErrorIs(t, err, protocli.ErrGlobalDeadlineExceeded)
+
+	err = runDeadlineCLI(t, svc, []protocli.RootOption{protocli.WithGlobalTimeout(10 * time.Millisecond)},
+		append([]string{"--deadline", "0"}, args...)...)
+	require.NoError(t, err, "--deadline 0 disables the default")
+
+	err = runDeadlineCLI(t, svc, nil, append([]string{"--deadline", "10ms"}, args...)...)
+	require.ErrorIs(t, err, protocli.ErrGlobalDeadlineExceeded)
+}
+
+// TestIntegration_GlobalTimeout_TighterWins tests that a command's own timeout
+// and the global deadline compose: whichever expires first ends the command.
+func TestIntegration_GlobalTimeout_TighterWins(t *testing.T) {
+	// Nothing listens on port 1, so ping waits until a deadline expires
+	err := runDeadlineCLI(t, &slowUserService{}, nil, "--deadline", "50ms", "ping", "--remote", "127.0.0.1:1", "--timeout", "10s")
+	require.ErrorIs(t, err, protocli.ErrGlobalDeadlineExceeded)
+
+	err = runDeadlineCLI(t, &slowUserService{}, nil, "--deadline", "10s", "ping", "--remote", "127.0.0.1:1", "--timeout", "50ms")
+	require.ErrorIs(t, err, protocli.ErrRemoteUnreachable)
+	assert.NotErrorIs(t, err, protocli.ErrGlobalDeadlineExceeded)
+}
+
+// TestIntegration_GlobalTimeout_NestedRunsInherit tests that apply steps keep
+// the deadline of the outer run instead of falling back to the default.
+func TestIntegration_GlobalTimeout_NestedRunsInherit(t *testing.T) {
+	dir := writeApplyDir(t, map[string]string{
+		"01-user-service.create.json": `{"name": "a", "email": "a@example.com"}`,
+		"02-user-service.create.json": `{"name": "b", "email": "b@example.com"}`,
+	})
+	svc := &slowUserService{delay: 50 * time.Millisecond}
+	opts := []protocli.RootOption{protocli.WithGlobalTimeout(20 * time.Millisecond)}
+
+	for _, deadline := range []string{"0", "5m"} {
+		err := runDeadlineCLI(t, svc, opts,
+			"--deadline", deadline, "apply", "--dir", dir, "--", "--db-url", "postgres://localhost/db")
+		require.NoError(t, err, "--deadline %s", deadline)
+	}
+	assert.Equal(t, int32(4), svc.created.Load())
+}
+
+// TestIntegration_GlobalTimeout_BoundsBeforeHooks tests that the deadline
+// covers the Before hooks of service commands, not just the action.
+func TestIntegration_GlobalTimeout_BoundsBeforeHooks(t *testing.T) {
+	setupTestCLI(t)
+	ctx := context.Background()
+	userCLI := simple.UserServiceCommand(ctx, newMockUserService)
+	userCLI.Command.Before = func(ctx context.Context, _ *cli.Command) (context.Context, error) {
+		select {
+		case <-ctx.Done():
+			return ctx, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return ctx, errors.New("before hook was not canceled")
+		}
+	}
+	rootCmd, err := protocli.RootCommand("testcli", protocli.Service(userCLI))
+	require.NoError(t, err)
+	setWriterOnAllCommands(rootCmd, &bytes.Buffer{})
+
+	err = rootCmd.Run(ctx, []string{"testcli", "--deadline", "20ms", "user-service", "get", "--id", "1", "--db-url", "postgres://localhost/db"})
+	require.ErrorIs(t, err, protocli.ErrGlobalDeadlineExceeded)
+}
@@ -0,0 +1,86 @@
+package protocli
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnknownDefaultGenerator is returned when a field's default_generator
+// annotation names a generator that has not been registered.
+var ErrUnknownDefaultGenerator = errors.New("unknown default generator")
+
+var (
+	defaultGeneratorsMu sync.RWMutex
+	defaultGenerators   = map[string]func() string{
+		"uuid":      newUUID,
+		"ulid":      newULID,
+		"timestamp": newTimestamp,
+	}
+)
+
+// RegisterDefaultGenerator makes fn available to fields annotated with
+// (cli.flag).default_generator = name. Registering an existing name, including
+// the built-in "uuid", "ulid" and "timestamp", replaces it.
+func RegisterDefaultGenerator(name string, fn func() string) {
+	defaultGeneratorsMu.Lock()
+	defer defaultGeneratorsMu.Unlock()
+	defaultGenerators[name] = fn
+}
+
+// DefaultGenerator returns the generator registered under name.
+func DefaultGenerator(name string) (func() string, bool) {
+	defaultGeneratorsMu.RLock()
+	defer defaultGeneratorsMu.RUnlock()
+	fn, ok := defaultGenerators[name]
+	return fn, ok
+}
+
+// GenerateDefault returns a fresh value from the generator registered under
+// name. Generated commands call this for fields whose flag was not given.
+func GenerateDefault(name string) (string, error) {
+	fn, ok := DefaultGenerator(name)
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownDefaultGenerator, name)
+	}
+	return fn(), nil
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	return uuid.NewString()
+}
+
+// crockfordBase32 is the ULID alphabet.
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID returns a ULID: a 48-bit millisecond timestamp followed by 80 random
+// bits, encoded as 26 characters of Crockford base32.
+func newULID() string {
+	var id [16]byte
+	ms := uint64(time.Now().UnixMilli()) //nolint:gosec // timestamps after 1970 are positive
+	binary.BigEndian.PutUint16(id[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+	_, _ = rand.Read(id[6:])
+
+	// 128 bits encode to 26 characters; the first carries only the top 3 bits.
+	var out [26]byte
+	hi := binary.BigEndian.Uint64(id[0:8])
+	lo := binary.BigEndian.Uint64(id[8:16])
+	for i := 25; i >= 0; i-- {
+		out[i] = crockfordBase32[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// newTimestamp returns the current time in RFC 3339 format (UTC).
+func newTimestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
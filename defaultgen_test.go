@@ -0,0 +1,49 @@
+package protocli_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_GenerateDefault_BuiltIns(t *testing.T) {
+	id, err := protocli.GenerateDefault("uuid")
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), id)
+
+	ulid, err := protocli.GenerateDefault("ulid")
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`), ulid)
+	later, err := protocli.GenerateDefault("ulid")
+	require.NoError(t, err)
+	assert.NotEqual(t, ulid, later)
+
+	ts, err := protocli.GenerateDefault("timestamp")
+	require.NoError(t, err)
+	_, err = time.Parse(time.RFC3339, ts)
+	assert.NoError(t, err)
+}
+
+func TestUnit_GenerateDefault_Custom(t *testing.T) {
+	n := 0
+	protocli.RegisterDefaultGenerator("test-counter", func() string {
+		n++
+		return "ticket-" + string(rune('0'+n))
+	})
+
+	first, err := protocli.GenerateDefault("test-counter")
+	require.NoError(t, err)
+	second, err := protocli.GenerateDefault("test-counter")
+	require.NoError(t, err)
+	assert.Equal(t, "ticket-1", first)
+	assert.Equal(t, "ticket-2", second)
+}
+
+func TestUnit_GenerateDefault_Unknown(t *testing.T) {
+	_, err := protocli.GenerateDefault("does-not-exist")
+	require.ErrorIs(t, err, protocli.ErrUnknownDefaultGenerator)
+}
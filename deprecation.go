@@ -0,0 +1,61 @@
+package protocli
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// deprecationWarningsKey is the Metadata key tracking which deprecation
+// warnings were already logged during the current run.
+const deprecationWarningsKey = "protocli:deprecationWarnings"
+
+// WarnDeprecatedCommand logs a warning that cmd is deprecated. Called by
+// generated actions for methods annotated with cli.command.deprecated.
+// The warning is logged at most once per run.
+func WarnDeprecatedCommand(cmd *cli.Command, message string) {
+	name := strings.ReplaceAll(commandPath(cmd), "/", " ")
+	if !markDeprecationWarned(cmd, "command:"+name) {
+		return
+	}
+	slog.Warn("Command is deprecated", "command", name, "reason", message)
+}
+
+// WarnDeprecatedFlag logs a warning when the named flag was set on cmd. Called
+// by generated actions for fields annotated with cli.flag.deprecated.
+// The warning is logged at most once per run.
+func WarnDeprecatedFlag(cmd *cli.Command, flagName, message string) {
+	if !cmd.IsSet(flagName) {
+		return
+	}
+	if !markDeprecationWarned(cmd, "flag:"+commandPath(cmd)+":"+flagName) {
+		return
+	}
+	slog.Warn("Flag is deprecated", "flag", "--"+flagName, "reason", message)
+}
+
+// markDeprecationWarned records key on the root command and reports whether
+// this is the first time it was seen during the current run.
+func markDeprecationWarned(cmd *cli.Command, key string) bool {
+	return markWarned(cmd, deprecationWarningsKey, key)
+}
+
+// markWarned records key in the set stored under metadataKey on the root
+// command and reports whether this is the first time it was seen.
+func markWarned(cmd *cli.Command, metadataKey, key string) bool {
+	root := cmd.Root()
+	if root.Metadata == nil {
+		root.Metadata = make(map[string]interface{})
+	}
+	warned, ok := root.Metadata[metadataKey].(map[string]bool)
+	if !ok {
+		warned = make(map[string]bool)
+		root.Metadata[metadataKey] = warned
+	}
+	if warned[key] {
+		return false
+	}
+	warned[key] = true
+	return true
+}
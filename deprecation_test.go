@@ -0,0 +1,16 @@
+package protocli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v3"
+)
+
+func TestUnit_MarkDeprecationWarned_OncePerKey(t *testing.T) {
+	cmd := &cli.Command{Name: "get"}
+
+	assert.True(t, markDeprecationWarned(cmd, "command:get"))
+	assert.False(t, markDeprecationWarned(cmd, "command:get"))
+	assert.True(t, markDeprecationWarned(cmd, "flag:get:old"))
+}
@@ -40,8 +40,8 @@
 // Built-in formats (use factory functions to create them):
 //
 //   - protocli.Go(): Default Go %+v formatting (automatically used if no formats registered)
-//   - protocli.JSON(): JSON output with optional --pretty flag
-//   - protocli.YAML(): YAML-style output
+//   - protocli.JSON(): JSON output with optional --pretty and --json-null-optionals flags
+//   - protocli.YAML(): YAML-style output with optional --yaml-flow and --yaml-indent flags
 //
 // If no formats are explicitly registered via WithOutputFormats, the Go format is used
 // as the default. Custom formats can be registered and will define additional flags
@@ -0,0 +1,257 @@
+package protocli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/drewfead/proto-cli/cliauth"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrDoctorFailed is returned by doctor when at least one check fails.
+var ErrDoctorFailed = errors.New("doctor found problems")
+
+// doctorStatus is the outcome of a single doctor check.
+type doctorStatus int
+
+const (
+	doctorPass doctorStatus = iota
+	doctorWarn
+	doctorFail
+)
+
+func (s doctorStatus) String() string {
+	switch s {
+	case doctorPass:
+		return "PASS"
+	case doctorWarn:
+		return "WARN"
+	default:
+		return "FAIL"
+	}
+}
+
+// doctorResult is one line of the doctor checklist.
+type doctorResult struct {
+	Check  string // Subsystem checked, e.g. "config" or "remote"
+	Status doctorStatus
+	Detail string
+}
+
+// doctorCheck inspects one subsystem, returning a result per item it found.
+type doctorCheck func(ctx context.Context, cmd *cli.Command) []doctorResult
+
+// doctorChecks returns the checks that apply to a root command built with
+// these settings: config files and env vars always, auth only with a login
+// provider and the terminal only with a TUI provider. The remote check skips
+// itself when no remote is given with --remote or --context or in the config.
+func doctorChecks(configMsg proto.Message, serviceName string, authCfg *cliauth.Config, tui bool) []doctorCheck {
+	checks := []doctorCheck{
+		checkConfigFiles,
+		func(_ context.Context, cmd *cli.Command) []doctorResult {
+			return checkEnvPrefix(cmd, configMsg, serviceName)
+		},
+		checkRemoteReachable,
+	}
+	if authCfg != nil {
+		checks = append(checks, func(ctx context.Context, _ *cli.Command) []doctorResult {
+			return checkAuth(ctx, authCfg, time.Now())
+		})
+	}
+	if tui {
+		checks = append(checks, func(context.Context, *cli.Command) []doctorResult {
+			return checkTerminal(os.Stdin, os.Stdout)
+		})
+	}
+	return checks
+}
+
+// newDoctorCommand returns `doctor`, which runs checks and prints a
+// pass/warn/fail checklist, failing when any check fails.
+func newDoctorCommand(checks []doctorCheck) *cli.Command {
+	return &cli.Command{
+		Name:  "doctor",
+		Usage: "Diagnose common configuration, connectivity and auth problems",
+		Description: "Checks that config files parse, which env prefix variables are set, that the\n" +
+			"remote is reachable, that stored credentials are valid and that the terminal\n" +
+			"supports the TUI. The remote is --remote, or the one selected with --context;\n" +
+			"a default can be set in the config file under commands.doctor.remote.\n" +
+			"Example: mycli doctor --remote localhost:50051",
+		Flags: remoteCheckFlags(false),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			// A config file that fails to load is reported by checkConfigFiles
+			_ = ApplyCommandConfig(cmd)
+			var results []doctorResult
+			for _, check := range checks {
+				results = append(results, check(ctx, cmd)...)
+			}
+			return writeDoctorResults(cmd, results)
+		},
+	}
+}
+
+// writeDoctorResults prints results followed by a summary line, returning
+// ErrDoctorFailed if any result failed.
+func writeDoctorResults(cmd *cli.Command, results []doctorResult) error {
+	w := cmd.Root().Writer
+	var counts [doctorFail + 1]int
+	for _, r := range results {
+		counts[r.Status]++
+		if _, err := fmt.Fprintf(w, "[%s] %s: %s\n", r.Status, r.Check, r.Detail); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%d passed, %d warnings, %d failed\n",
+		counts[doctorPass], counts[doctorWarn], counts[doctorFail]); err != nil {
+		return err
+	}
+	if counts[doctorFail] > 0 {
+		return fmt.Errorf("%w: %d of %d checks failed", ErrDoctorFailed, counts[doctorFail], len(results))
+	}
+	return nil
+}
+
+// checkConfigFiles reports each --config file that exists and whether it is
+// valid YAML, warning when none of them exist.
+func checkConfigFiles(_ context.Context, cmd *cli.Command) []doctorResult {
+	paths := cmd.Root().StringSlice("config")
+	var results []doctorResult
+	for _, path := range paths {
+		data, err := os.ReadFile(path) //nolint:gosec // path is the user's --config flag
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			results = append(results, doctorResult{"config", doctorFail, fmt.Sprintf("cannot read %s: %v", path, err)})
+			continue
+		}
+		var parsed map[string]any
+		if err := yaml.Unmarshal(data, &parsed); err != nil {
+			results = append(results, doctorResult{"config", doctorFail, fmt.Sprintf("%s is not valid YAML: %v", path, err)})
+			continue
+		}
+		results = append(results, doctorResult{"config", doctorPass, "loaded " + path})
+	}
+	if len(results) == 0 {
+		detail := "no config file found"
+		if len(paths) > 0 {
+			detail += " (searched " + strings.Join(paths, ", ") + ")"
+		}
+		results = append(results, doctorResult{"config", doctorWarn, detail})
+	}
+	return results
+}
+
+// checkEnvPrefix lists the set environment variables starting with the env
+// prefix, warning about any that match no field of configMsg (usually a typo).
+// Returns nothing when no env prefix is configured.
+func checkEnvPrefix(cmd *cli.Command, configMsg proto.Message, serviceName string) []doctorResult {
+	prefix := cmd.Root().String("env-prefix")
+	if prefix == "" {
+		return nil
+	}
+
+	var set []string
+	for _, kv := range os.Environ() {
+		if name, _, _ := strings.Cut(kv, "="); strings.HasPrefix(name, prefix+"_") {
+			set = append(set, name)
+		}
+	}
+	slices.Sort(set)
+	if len(set) == 0 {
+		return []doctorResult{{"env", doctorPass, fmt.Sprintf("no %s_* variables set", prefix)}}
+	}
+	if configMsg == nil {
+		return []doctorResult{{"env", doctorPass, "set: " + strings.Join(set, ", ")}}
+	}
+
+	known := make(map[string]bool)
+	for _, v := range configEnvVars(NewConfigLoader(SingleCommandMode, EnvPrefix(prefix)), configMsg, serviceName) {
+		known[v.Name] = true
+	}
+	var results []doctorResult
+	var recognized []string
+	for _, name := range set {
+		if known[name] {
+			recognized = append(recognized, name)
+			continue
+		}
+		results = append(results, doctorResult{"env", doctorWarn, name + " is set but matches no config field"})
+	}
+	if len(recognized) > 0 {
+		results = append([]doctorResult{{"env", doctorPass, "set: " + strings.Join(recognized, ", ")}}, results...)
+	}
+	return results
+}
+
+// checkRemoteReachable pings --remote when one is given. Like generated
+// commands, doctor gets the address and --tls of the remote selected with
+// --context from the root Before hook, and its auth header through ctx.
+func checkRemoteReachable(ctx context.Context, cmd *cli.Command) []doctorResult {
+	addr := cmd.String("remote")
+	if addr == "" {
+		return nil
+	}
+	creds, err := pingCredentials(cmd)
+	if err != nil {
+		return []doctorResult{{"remote", doctorFail, err.Error()}}
+	}
+	result, err := checkRemote(ctx, addr, cmd.Duration("timeout"), creds)
+	if err != nil {
+		return []doctorResult{{"remote", doctorFail, err.Error()}}
+	}
+	return []doctorResult{{"remote", doctorPass, fmt.Sprintf("%s READY in %s (health: %s)",
+		addr, result.connectLatency.Round(time.Microsecond), result.health)}}
+}
+
+// checkAuth reports whether credentials are stored and unexpired at now.
+// Expired credentials only warn when the provider can refresh them.
+func checkAuth(ctx context.Context, cfg *cliauth.Config, now time.Time) []doctorResult {
+	status, err := cliauth.CurrentStatus(ctx, cfg)
+	switch {
+	case err != nil:
+		return []doctorResult{{"auth", doctorFail, fmt.Sprintf("cannot read stored credentials: %v", err)}}
+	case !status.LoggedIn:
+		return []doctorResult{{"auth", doctorFail, "not logged in (run auth login)"}}
+	}
+
+	detail := "logged in"
+	if status.Subject != "" {
+		detail += " as " + status.Subject
+	}
+	if status.ExpiresAt.IsZero() || status.ExpiresAt.After(now) {
+		if !status.ExpiresAt.IsZero() {
+			detail += fmt.Sprintf(", expires in %s", status.ExpiresAt.Sub(now).Round(time.Second))
+		}
+		return []doctorResult{{"auth", doctorPass, detail}}
+	}
+
+	detail += ", credentials expired at " + status.ExpiresAt.UTC().Format(time.RFC3339)
+	if _, ok := cfg.Provider.(cliauth.RefreshableProvider); ok {
+		return []doctorResult{{"auth", doctorWarn, detail + " (refreshed on next call)"}}
+	}
+	return []doctorResult{{"auth", doctorFail, detail + " (run auth login)"}}
+}
+
+// checkTerminal reports whether in and out are terminals the TUI can run on,
+// and whether colors are available.
+func checkTerminal(in, out any) []doctorResult {
+	if !isTerminal(in) || !isTerminal(out) {
+		return []doctorResult{{"terminal", doctorWarn, "stdin or stdout is not a terminal; --interactive is unavailable"}}
+	}
+	switch term := os.Getenv("TERM"); {
+	case term == "" || term == "dumb":
+		return []doctorResult{{"terminal", doctorWarn, fmt.Sprintf("TERM=%q does not support the TUI", term)}}
+	case os.Getenv("NO_COLOR") != "":
+		return []doctorResult{{"terminal", doctorWarn, "NO_COLOR is set; the TUI renders without colors"}}
+	default:
+		return []doctorResult{{"terminal", doctorPass, "TERM=" + term}}
+	}
+}
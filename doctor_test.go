@@ -0,0 +1,118 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/cliauth"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+)
+
+// expiringProvider is a LoginProvider whose stored credentials expire at a
+// fixed time.
+type expiringProvider struct {
+	mockLoginProvider
+	expiresAt time.Time
+}
+
+func (p *expiringProvider) StatusDetails(ctx context.Context, store cliauth.AuthStore) (cliauth.Status, error) {
+	if _, err := store.Load(ctx); err != nil {
+		return cliauth.Status{}, nil //nolint:nilerr // no stored token means logged out
+	}
+	return cliauth.Status{LoggedIn: true, Subject: "alice", ExpiresAt: p.expiresAt}, nil
+}
+
+func runDoctor(t *testing.T, opts []protocli.RootOption, args ...string) (string, error) {
+	t.Helper()
+	rootCmd, err := protocli.RootCommand("testcli", opts...)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	err = rootCmd.Run(context.Background(), append([]string{"testcli", "doctor"}, args...))
+	return buf.String(), err
+}
+
+// TestIntegration_Doctor_Passes tests a healthy environment: a valid config
+// file, a recognized env var, a reachable remote and unexpired credentials.
+func TestIntegration_Doctor_Passes(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "testcli.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("services:\n  userservice:\n    max-connections: 5\n"), 0o600))
+	t.Setenv("TESTCLI_DATABASE_URL", "postgres://localhost/db")
+	addr := startPingServer(t, health.NewServer())
+	store := &mockStore{token: []byte("token")}
+
+	out, err := runDoctor(t, []protocli.RootOption{
+		protocli.WithConfigFile(configPath),
+		protocli.WithEnvPrefix("TESTCLI"),
+		protocli.WithConfigManagementCommands(&simple.UserServiceConfig{}, "testcli", "userservice"),
+		protocli.WithAuth(&expiringProvider{expiresAt: time.Now().Add(time.Hour)}, cliauth.WithStore(store)),
+	}, "--remote", addr)
+	require.NoError(t, err)
+	assert.Contains(t, out, "[PASS] config: loaded "+configPath)
+	assert.Contains(t, out, "[PASS] env: set: TESTCLI_DATABASE_URL")
+	assert.Contains(t, out, "[PASS] remote: "+addr+" READY in ")
+	assert.Contains(t, out, "[PASS] auth: logged in as alice, expires in ")
+	assert.Contains(t, out, "4 passed, 0 warnings, 0 failed")
+}
+
+func TestIntegration_Doctor_Failures(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "testcli.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("services: [unclosed\n"), 0o600))
+	t.Setenv("TESTCLI_DATABSE_URL", "postgres://localhost/db")
+	store := &mockStore{token: []byte("token")}
+
+	out, err := runDoctor(t, []protocli.RootOption{
+		protocli.WithConfigFile(configPath),
+		protocli.WithEnvPrefix("TESTCLI"),
+		protocli.WithConfigManagementCommands(&simple.UserServiceConfig{}, "testcli", "userservice"),
+		protocli.WithAuth(&expiringProvider{expiresAt: time.Now().Add(-time.Hour)}, cliauth.WithStore(store)),
+	})
+	require.ErrorIs(t, err, protocli.ErrDoctorFailed)
+	assert.Contains(t, out, "[FAIL] config: "+configPath+" is not valid YAML")
+	assert.Contains(t, out, "[WARN] env: TESTCLI_DATABSE_URL is set but matches no config field")
+	assert.Contains(t, out, "[FAIL] auth: logged in as alice, credentials expired at ")
+	assert.NotContains(t, out, "remote:", "the remote check is skipped without --remote")
+	assert.Contains(t, out, "0 passed, 1 warnings, 2 failed")
+}
+
+// TestIntegration_Doctor_DefaultRemoteFromConfig tests that the remote to
+// check can be set under commands.doctor in the config file.
+func TestIntegration_Doctor_DefaultRemoteFromConfig(t *testing.T) {
+	addr := startPingServer(t, nil)
+	configPath := filepath.Join(t.TempDir(), "testcli.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("commands:\n  doctor:\n    remote: "+addr+"\n"), 0o600))
+
+	out, err := runDoctor(t, []protocli.RootOption{protocli.WithConfigFile(configPath)})
+	require.NoError(t, err)
+	assert.Contains(t, out, "[PASS] remote: "+addr+" READY in ")
+	assert.Contains(t, out, "health: no health service")
+}
+
+// TestIntegration_Doctor_RemoteFromContext tests that the remote selected with
+// --context is checked like the one generated commands would call, and that
+// it wins over commands.doctor.remote in the config file.
+func TestIntegration_Doctor_RemoteFromContext(t *testing.T) {
+	addr := startPingServer(t, nil)
+	remotesPath := writeRemotesFile(t, "remotes:\n  local:\n    address: "+addr+"\n")
+	configPath := filepath.Join(t.TempDir(), "testcli.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("commands:\n  doctor:\n    remote: localhost:1\n"), 0o600))
+
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.WithConfigFile(configPath),
+		protocli.WithRemotesFile(remotesPath),
+	)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	require.NoError(t, rootCmd.Run(context.Background(), []string{"testcli", "doctor", "--context", "local"}))
+	assert.Contains(t, buf.String(), "[PASS] remote: "+addr+" READY in ")
+}
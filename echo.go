@@ -0,0 +1,162 @@
+package protocli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrEchoRequestFormat is returned when --echo-request is used with an output
+// format that cannot render the request/response envelope.
+var ErrEchoRequestFormat = errors.New("--echo-request requires a JSON or YAML output format")
+
+// WriteRequestResponse renders resp with WriteResponse, or with --echo-request
+// an envelope holding both messages, {"request": ..., "response": ...}, so the
+// output records what produced it. Each message is rendered with the selected
+// format and its flags before they are combined, which JSON and YAML formats
+// support; with --get the pointer is evaluated against the envelope (e.g.
+// /request/id). Generated unary commands call this.
+func WriteRequestResponse(ctx context.Context, cmd *cli.Command, w io.Writer, formats []OutputFormat, formatName string, req, resp proto.Message) error {
+	if !cmd.Bool("echo-request") {
+		return WriteResponse(ctx, cmd, w, formats, formatName, resp)
+	}
+
+	if pointer := cmd.String("get"); pointer != "" {
+		outputFmt, err := jsonOutputFormat(cmd, formats, formatName)
+		if err != nil {
+			return err
+		}
+		doc, err := jsonEnvelope(ctx, cmd, outputFmt, req, resp, "")
+		if err != nil {
+			return err
+		}
+		return writeJSONPointerValue(w, doc, pointer)
+	}
+
+	var outputFmt OutputFormat
+	for _, f := range formats {
+		if f.Name() == formatName {
+			outputFmt = f
+			break
+		}
+	}
+	if outputFmt == nil {
+		// Report the unknown format the same way as without --echo-request
+		return WriteFormatted(ctx, cmd, w, formats, formatName, resp)
+	}
+
+	var envelope []byte
+	var err error
+	switch {
+	case isJSONFormat(outputFmt):
+		indent := ""
+		if cmd.Bool("pretty") {
+			indent = "  "
+		}
+		colored, isColor := outputFmt.(*jsonColorFormat)
+		if isColor {
+			indent = "  "
+		}
+		envelope, err = jsonEnvelope(ctx, cmd, plainJSONFormat(outputFmt), req, resp, indent)
+		if err == nil && isColor {
+			envelope = colored.highlight(cmd, w, envelope)
+		}
+	case isYAMLFormat(outputFmt):
+		envelope, err = yamlEnvelope(ctx, cmd, outputFmt, req, resp)
+	default:
+		return fmt.Errorf("%w, not %q", ErrEchoRequestFormat, formatName)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(envelope, '\n'))
+	return err
+}
+
+// isYAMLFormat reports whether f renders YAML: the built-in "yaml" format or
+// one whose media type is application/yaml.
+func isYAMLFormat(f OutputFormat) bool {
+	if f.Name() == "yaml" {
+		return true
+	}
+	mf, ok := f.(MediaTypeOutputFormat)
+	return ok && mf.MediaType() == "application/yaml"
+}
+
+// jsonEnvelope renders req and resp with the JSON format outputFmt and
+// combines them into one JSON object, indented by indent if it is not empty.
+func jsonEnvelope(ctx context.Context, cmd *cli.Command, outputFmt OutputFormat, req, resp proto.Message, indent string) ([]byte, error) {
+	var envelope bytes.Buffer
+	for i, part := range []struct {
+		key string
+		msg proto.Message
+	}{{"request", req}, {"response", resp}} {
+		var rendered bytes.Buffer
+		if err := FormatMessage(ctx, cmd, outputFmt, &rendered, part.msg); err != nil {
+			return nil, fmt.Errorf("format failed: %w", err)
+		}
+		if i == 0 {
+			envelope.WriteByte('{')
+		} else {
+			envelope.WriteByte(',')
+		}
+		envelope.WriteString(`"` + part.key + `":`)
+		if err := json.Compact(&envelope, rendered.Bytes()); err != nil {
+			return nil, fmt.Errorf("%s is not valid JSON: %w", part.key, err)
+		}
+	}
+	envelope.WriteByte('}')
+
+	if indent == "" {
+		return envelope.Bytes(), nil
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, envelope.Bytes(), "", indent); err != nil {
+		return nil, fmt.Errorf("failed to indent JSON: %w", err)
+	}
+	return indented.Bytes(), nil
+}
+
+// yamlEnvelope renders req and resp with the YAML format outputFmt and nests
+// them under request and response keys, in flow style with --yaml-flow and
+// indented by --yaml-indent spaces otherwise.
+func yamlEnvelope(ctx context.Context, cmd *cli.Command, outputFmt OutputFormat, req, resp proto.Message) ([]byte, error) {
+	rendered := make([]string, 2)
+	for i, msg := range []proto.Message{req, resp} {
+		var buf bytes.Buffer
+		if err := FormatMessage(ctx, cmd, outputFmt, &buf, msg); err != nil {
+			return nil, fmt.Errorf("format failed: %w", err)
+		}
+		rendered[i] = strings.TrimRight(buf.String(), "\n")
+	}
+
+	if cmd.Bool("yaml-flow") {
+		return []byte("{request: " + rendered[0] + ", response: " + rendered[1] + "}"), nil
+	}
+	unit := strings.Repeat(" ", defaultYAMLIndent)
+	if n := cmd.Int("yaml-indent"); n > 0 {
+		unit = strings.Repeat(" ", n)
+	}
+	var envelope strings.Builder
+	for i, key := range []string{"request", "response"} {
+		if i > 0 {
+			envelope.WriteByte('\n')
+		}
+		if rendered[i] == "" {
+			envelope.WriteString(key + ": {}")
+			continue
+		}
+		envelope.WriteString(key + ":")
+		for _, line := range strings.Split(rendered[i], "\n") {
+			envelope.WriteString("\n" + unit + line)
+		}
+	}
+	return []byte(envelope.String()), nil
+}
@@ -0,0 +1,59 @@
+package protocli_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func enumRequest() *simple.CreateUserRequest {
+	return &simple.CreateUserRequest{
+		Name:              "ada",
+		LogLevel:          simple.LogLevel_WARN.Enum(),
+		NotificationLevel: simple.LogLevel_INFO,
+	}
+}
+
+func TestUnit_JSONEnumNumbers(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		wantLogLevel      any
+		wantNotifications any
+	}{
+		{name: "names by default", wantLogLevel: "WARN", wantNotifications: "INFO"},
+		{name: "numbers with flag", args: []string{"--json-enum-numbers"}, wantLogLevel: 3.0, wantNotifications: 2.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := formatWith(t, protocli.JSON(), enumRequest(), tt.args...)
+			require.NoError(t, err)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal([]byte(out), &got))
+			assert.Equal(t, tt.wantLogLevel, got["logLevel"])
+			assert.Equal(t, tt.wantNotifications, got["notificationLevel"])
+			assert.Equal(t, "ada", got["name"])
+		})
+	}
+}
+
+func TestUnit_YAMLEnumNumbers(t *testing.T) {
+	out, err := formatWith(t, protocli.YAML(), enumRequest())
+	require.NoError(t, err)
+	assert.Contains(t, out, "logLevel: WARN\n")
+	assert.Contains(t, out, "notificationLevel: INFO\n")
+
+	out, err = formatWith(t, protocli.YAML(), enumRequest(), "--yaml-enum-numbers")
+	require.NoError(t, err)
+	assert.Contains(t, out, "logLevel: 3\n")
+	assert.Contains(t, out, "notificationLevel: 2\n")
+
+	out, err = formatWith(t, protocli.YAML(), enumRequest(), "--yaml-enum-numbers", "--yaml-flow")
+	require.NoError(t, err)
+	assert.Contains(t, out, "logLevel: 3,")
+}
@@ -0,0 +1,77 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// TestAnyField_PacksFlagValue tests that an Any flag given as
+// <type URL>=<JSON> reaches the method packed with the concrete message.
+func TestAnyField_PacksFlagValue(t *testing.T) {
+	for _, typeURL := range []string{"type.googleapis.com/example.Address", "example.Address"} {
+		t.Run(typeURL, func(t *testing.T) {
+			got, err := tryCreateUser(t, "--name", "Ada", "--email", "ada@example.com", "--metadata", typeURL+`={"city":"Oslo","zipCode":"0150"}`)
+			require.NoError(t, err)
+
+			require.NotNil(t, got.GetMetadata())
+			assert.Equal(t, "type.googleapis.com/example.Address", got.GetMetadata().GetTypeUrl())
+			addr := &simple.Address{}
+			require.NoError(t, got.GetMetadata().UnmarshalTo(addr))
+			assert.Equal(t, "Oslo", addr.GetCity())
+			assert.Equal(t, "0150", addr.GetZipCode())
+		})
+	}
+}
+
+func TestAnyField_InvalidValues(t *testing.T) {
+	for name, value := range map[string]string{
+		"missing JSON":    "example.Address",
+		"unknown type":    `example.Nope={}`,
+		"mismatched JSON": `example.Address={"planet":"Mars"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := tryCreateUser(t, "--name", "Ada", "--email", "ada@example.com", "--metadata", value)
+			require.ErrorIs(t, err, protocli.ErrInvalidAny)
+			assert.Contains(t, err.Error(), "invalid value for --metadata")
+		})
+	}
+}
+
+// TestAnyField_FormatsUnpackConcreteType tests that the built-in formats show
+// the message inside an Any rather than its encoded bytes.
+func TestAnyField_FormatsUnpackConcreteType(t *testing.T) {
+	packed, err := anypb.New(&simple.Address{City: "Oslo"})
+	require.NoError(t, err)
+	req := &simple.CreateUserRequest{Name: "Ada", Metadata: packed}
+
+	for format, want := range map[string][]string{
+		"json": {`"@type":"type.googleapis.com/example.Address"`, `"city":"Oslo"`},
+		"yaml": {`"@type": type.googleapis.com/example.Address`, "city: Oslo"},
+		"go":   {"[type.googleapis.com/example.Address]", `city:"Oslo"`},
+	} {
+		t.Run(format, func(t *testing.T) {
+			formats := []protocli.OutputFormat{protocli.JSON(), protocli.YAML(), protocli.Go()}
+			userCLI := simple.UserServiceCommand(context.Background(), newUserService, protocli.WithOutputFormats(formats...))
+			rootCmd, err := protocli.RootCommand("testcli", protocli.Service(userCLI))
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			for _, f := range formats {
+				if f.Name() == format {
+					require.NoError(t, protocli.FormatMessage(context.Background(), rootCmd, f, &buf, req))
+				}
+			}
+			out := string(bytes.Join(bytes.Fields(buf.Bytes()), nil))
+			for _, w := range want {
+				assert.Contains(t, out, string(bytes.Join(bytes.Fields([]byte(w)), nil)))
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// runGetWithArgs runs `user-service get` with a trailing positional argument
+// and returns whether the method was called, the exit code passed to
+// cli.OsExiter (-1 if none) and the error.
+func runGetWithArgs(t *testing.T, opts ...protocli.RootOption) (bool, int, error) {
+	t.Helper()
+	exitCode := -1
+	origExiter := cli.OsExiter
+	t.Cleanup(func() { cli.OsExiter = origExiter })
+	cli.OsExiter = func(code int) { exitCode = code }
+
+	called := false
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			called = true
+			return &simple.UserResponse{User: &simple.User{Id: req.GetId()}}, nil
+		},
+	}
+	var buf bytes.Buffer
+	err := newUserRoot(t, mock, &buf, nil, opts...).Run(context.Background(), []string{
+		"testcli", "user-service", "get", "--id", "1", "--db-url", "postgres://localhost/test", "extra",
+	})
+	return called, exitCode, err
+}
+
+func TestArgErrorHandler_Default(t *testing.T) {
+	called, exitCode, err := runGetWithArgs(t)
+	require.EqualError(t, err, `unsupported argument: "extra"`)
+	assert.Equal(t, 3, exitCode)
+	assert.False(t, called)
+}
+
+// TestArgErrorHandler_CustomExitCode tests that WithArgErrorHandler replaces
+// the message and exit code for unexpected positional arguments.
+func TestArgErrorHandler_CustomExitCode(t *testing.T) {
+	var gotCommand string
+	called, exitCode, err := runGetWithArgs(t, protocli.WithArgErrorHandler(func(cmd *cli.Command, arg string) error {
+		gotCommand = cmd.Name
+		return cli.Exit(fmt.Sprintf("%s takes no arguments (got %q)", cmd.Name, arg), 64)
+	}))
+	require.EqualError(t, err, `get takes no arguments (got "extra")`)
+	assert.Equal(t, 64, exitCode)
+	assert.Equal(t, "get", gotCommand)
+	assert.False(t, called)
+}
+
+func TestArgErrorHandler_Passthrough(t *testing.T) {
+	called, exitCode, err := runGetWithArgs(t, protocli.WithArgErrorHandler(func(*cli.Command, string) error {
+		return nil
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, -1, exitCode)
+	assert.True(t, called, "the command runs when the handler allows the argument")
+}
@@ -0,0 +1,34 @@
+package simple_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// TestIntegration_CLIClient_Remote tests that the generated
+// NewUserServiceCLIClient dials the given remote.
+func TestIntegration_CLIClient_Remote(t *testing.T) {
+	lis, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	simple.RegisterUserServiceServer(server, newUserService(&simple.UserServiceConfig{}))
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	client, conn, err := simple.NewUserServiceCLIClient(protocli.WithRemote(lis.Addr().String()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	resp, err := client.GetUser(context.Background(), &simple.GetUserRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Test User", resp.GetUser().GetName())
+}
@@ -0,0 +1,58 @@
+package simple_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runWithCommandConfig runs `user-service get` with a config file whose
+// commands section sets the command's output format and format options.
+func runWithCommandConfig(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "testcli.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+commands:
+  user-service/get:
+    format: yaml
+    yaml-indent: 4
+`), 0o600))
+
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{
+				User: &simple.User{Id: req.GetId(), Name: "Alice", Address: &simple.Address{City: "Springfield"}},
+			}, nil
+		},
+	}
+	return runUserCommand(t, mock, []protocli.ServiceOption{protocli.WithOutputFormats(protocli.JSON(), protocli.YAML())}, append([]string{
+		"--config", configPath, "user-service", "get",
+		"--id", "1", "--db-url", "postgres://localhost/test",
+	}, args...)...)
+}
+
+// TestCommandConfig_DefaultFormat tests that the format and format options in
+// the config's commands section are used when no flags are given.
+func TestCommandConfig_DefaultFormat(t *testing.T) {
+	out, err := runWithCommandConfig(t)
+	require.NoError(t, err)
+	assert.Contains(t, out, "user:\n    address:\n        city: Springfield\n")
+	assert.Contains(t, out, "\n    name: Alice\n")
+}
+
+func TestCommandConfig_FlagsOverrideConfig(t *testing.T) {
+	out, err := runWithCommandConfig(t, "--format", "json")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"name":"Alice"`)
+	assert.NotContains(t, out, "name: Alice")
+
+	out, err = runWithCommandConfig(t, "--yaml-indent", "2")
+	require.NoError(t, err)
+	assert.Contains(t, out, "user:\n  address:\n    city: Springfield\n", "format still comes from config")
+}
@@ -0,0 +1,68 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// newFilteredRoot returns a root where get is hidden and disabled and create
+// is listed but disabled, as a license check might decide.
+func newFilteredRoot(t *testing.T, buf *bytes.Buffer) *cli.Command {
+	t.Helper()
+	var called bool
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, _ *simple.GetUserRequest) (*simple.UserResponse, error) {
+			called = true
+			return &simple.UserResponse{}, nil
+		},
+	}
+	t.Cleanup(func() { assert.False(t, called, "a disabled command must not call the service") })
+	return newUserRoot(t, mock, buf, nil,
+		protocli.WithCommandFilter(func(fullMethod string) (bool, bool) {
+			switch fullMethod {
+			case "/example.UserService/GetUser":
+				return false, false
+			case "/example.UserService/CreateUser":
+				return true, false
+			default:
+				return true, true
+			}
+		}),
+	)
+}
+
+// TestCommandFilter_HidesCommands tests that only commands the filter marks
+// visible are listed in help.
+func TestCommandFilter_HidesCommands(t *testing.T) {
+	var buf bytes.Buffer
+	rootCmd := newFilteredRoot(t, &buf)
+
+	require.NoError(t, rootCmd.Run(context.Background(), []string{"testcli", "user-service", "--help"}))
+	assert.Contains(t, buf.String(), "create")
+	assert.NotRegexp(t, `(?m)^\s+get\b`, buf.String())
+}
+
+// TestCommandFilter_DisabledCommandErrors tests that invoking a disabled
+// command directly, hidden or not, fails without calling the service, even
+// when its required flags are missing.
+func TestCommandFilter_DisabledCommandErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"testcli", "user-service", "get", "--id", "1", "--db-url", "postgres://localhost/test"},
+		{"testcli", "user-service", "create"},
+	} {
+		var buf bytes.Buffer
+		rootCmd := newFilteredRoot(t, &buf)
+
+		err := rootCmd.Run(context.Background(), args)
+		require.ErrorIs(t, err, protocli.ErrCommandNotAvailable)
+		assert.EqualError(t, err, `command "testcli user-service `+args[2]+`" is not available`)
+		assert.Empty(t, buf.String())
+	}
+}
@@ -0,0 +1,70 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runPurgeCache runs `admin purge-cache` with stdin read from in and
+// reports whether the implementation was called.
+func runPurgeCache(t *testing.T, in string, args ...string) (bool, string, error) {
+	t.Helper()
+	ctx := context.Background()
+	called := false
+	mock := &simple.MockAdminServiceServer{
+		PurgeCacheFunc: func(_ context.Context, _ *simple.AdminRequest) (*simple.AdminResponse, error) {
+			called = true
+			return &simple.AdminResponse{Message: "Cache purged", Success: true}, nil
+		},
+	}
+	adminCLI := simple.AdminServiceCommand(ctx, mock, protocli.WithOutputFormats(protocli.JSON()))
+	rootCmd, err := protocli.RootCommand("testcli", protocli.Service(adminCLI))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	setWriterOnAllCommands(rootCmd, &buf)
+	rootCmd.Reader = strings.NewReader(in)
+	err = rootCmd.Run(ctx, append([]string{"testcli", "admin", "purge-cache"}, args...))
+	return called, buf.String(), err
+}
+
+func TestConfirm_YesSkipsPrompt(t *testing.T) {
+	called, out, err := runPurgeCache(t, "", "--yes")
+	require.NoError(t, err)
+	assert.True(t, called)
+	assert.Contains(t, out, "Cache purged")
+	assert.NotContains(t, out, "Are you sure?")
+}
+
+// TestConfirm_NonTTYRequiresYes tests that piped stdin is never read as an
+// answer, so scripts fail fast instead of silently confirming.
+func TestConfirm_NonTTYRequiresYes(t *testing.T) {
+	called, out, err := runPurgeCache(t, "y\n")
+	require.ErrorIs(t, err, protocli.ErrConfirmationRequired)
+	assert.Contains(t, err.Error(), "pass --yes to run testcli admin purge-cache")
+	assert.False(t, called, "the method is not called")
+	assert.Empty(t, out)
+}
+
+func TestConfirm_OtherCommandsDoNotPrompt(t *testing.T) {
+	ctx := context.Background()
+	adminCLI := simple.AdminServiceCommand(ctx, &simple.MockAdminServiceServer{
+		HealthCheckFunc: func(_ context.Context, _ *simple.AdminRequest) (*simple.AdminResponse, error) {
+			return &simple.AdminResponse{Success: true}, nil
+		},
+	}, protocli.WithOutputFormats(protocli.JSON()))
+	rootCmd, err := protocli.RootCommand("testcli", protocli.Service(adminCLI))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	setWriterOnAllCommands(rootCmd, &buf)
+	rootCmd.Reader = strings.NewReader("")
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "admin", "health"}))
+}
@@ -0,0 +1,67 @@
+package simple_test
+
+import (
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCreateUser runs `user-service create` with args and returns the request
+// the service received.
+func runCreateUser(t *testing.T, args ...string) *simple.CreateUserRequest {
+	t.Helper()
+	got, err := tryCreateUser(t, args...)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	return got
+}
+
+// tryCreateUser is runCreateUser without the success assertions.
+func tryCreateUser(t *testing.T, args ...string) (*simple.CreateUserRequest, error) {
+	t.Helper()
+	var got *simple.CreateUserRequest
+	mock := &simple.MockUserServiceServer{
+		CreateUserFunc: func(_ context.Context, req *simple.CreateUserRequest) (*simple.UserResponse, error) {
+			got = req
+			return &simple.UserResponse{}, nil
+		},
+	}
+	_, err := runUserCommand(t, mock, nil, append([]string{
+		"user-service", "create",
+		"--db-url", "postgres://localhost/test",
+		"--name", "Ada",
+		"--email", "ada@example.com",
+	}, args...)...)
+	return got, err
+}
+
+// TestDefaultGenerator_UUID tests that a field annotated with
+// default_generator: "uuid" gets a fresh UUID per invocation when its flag is
+// not given, and that an explicit flag value wins.
+func TestDefaultGenerator_UUID(t *testing.T) {
+	first := runCreateUser(t).GetRequestId()
+	_, err := uuid.Parse(first)
+	require.NoError(t, err, "request_id should be a UUID, got %q", first)
+
+	second := runCreateUser(t).GetRequestId()
+	assert.NotEqual(t, first, second, "each invocation generates a new value")
+
+	explicit := runCreateUser(t, "--request-id", "req-42").GetRequestId()
+	assert.Equal(t, "req-42", explicit)
+}
+
+// TestDefaultGenerator_Custom tests that RegisterDefaultGenerator replaces the
+// generator used by annotated fields.
+func TestDefaultGenerator_Custom(t *testing.T) {
+	original, ok := protocli.DefaultGenerator("uuid")
+	require.True(t, ok)
+	t.Cleanup(func() { protocli.RegisterDefaultGenerator("uuid", original) })
+
+	protocli.RegisterDefaultGenerator("uuid", func() string { return "custom-id" })
+	assert.Equal(t, "custom-id", runCreateUser(t).GetRequestId())
+}
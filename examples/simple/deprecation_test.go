@@ -0,0 +1,68 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cli "github.com/urfave/cli/v3"
+)
+
+// pingAdminService implements the deprecated Ping RPC.
+type pingAdminService struct {
+	simple.UnimplementedAdminServiceServer
+}
+
+func (s *pingAdminService) Ping(_ context.Context, _ *simple.AdminRequest) (*simple.AdminResponse, error) {
+	return &simple.AdminResponse{Message: "pong", Success: true}, nil
+}
+
+// TestDeprecation_WarnsOncePerRun tests that deprecated commands and flags log a
+// single warning per invocation and are marked in help text.
+func TestDeprecation_WarnsOncePerRun(t *testing.T) {
+	ctx := context.Background()
+
+	var logs, out bytes.Buffer
+	newRoot := func() *cli.Command {
+		adminCLI := simple.AdminServiceCommand(ctx, &pingAdminService{},
+			protocli.WithOutputFormats(protocli.JSON()),
+		)
+		rootCmd, err := protocli.RootCommand("testcli",
+			protocli.Service(adminCLI),
+			protocli.ConfigureLogging(func(_ context.Context, _ protocli.SlogConfigurationContext) *slog.Logger {
+				return slog.New(slog.NewTextHandler(&logs, nil))
+			}),
+		)
+		require.NoError(t, err)
+		rootCmd.Writer = &out
+		setWriterOnAllCommands(rootCmd, &out)
+		return rootCmd
+	}
+
+	// Repeated runs of the same root each warn exactly once
+	rootCmd := newRoot()
+	for range 2 {
+		logs.Reset()
+		require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "admin", "ping", "--verbose"}))
+		assert.Equal(t, 1, strings.Count(logs.String(), "Command is deprecated"))
+		assert.Equal(t, 1, strings.Count(logs.String(), "Flag is deprecated"))
+		assert.Contains(t, logs.String(), "use health instead")
+	}
+
+	// Deprecated flag unset: only the command warning fires
+	logs.Reset()
+	require.NoError(t, newRoot().Run(ctx, []string{"testcli", "admin", "ping"}))
+	assert.Equal(t, 1, strings.Count(logs.String(), "Command is deprecated"))
+	assert.NotContains(t, logs.String(), "Flag is deprecated")
+
+	// Help text reflects deprecation
+	out.Reset()
+	require.NoError(t, newRoot().Run(ctx, []string{"testcli", "admin", "--help"}))
+	assert.Contains(t, out.String(), "(deprecated: use health instead)")
+}
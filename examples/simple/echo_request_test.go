@@ -0,0 +1,75 @@
+package simple_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEchoRequest_JSONEnvelope(t *testing.T) {
+	out, err := runGetWithPointer(t, "--format", "json", "--echo-request")
+	require.NoError(t, err)
+
+	var envelope struct {
+		Request  map[string]any `json:"request"`
+		Response struct {
+			User    map[string]any `json:"user"`
+			Message string         `json:"message"`
+		} `json:"response"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &envelope), out)
+	assert.Equal(t, "7", envelope.Request["id"])
+	assert.Equal(t, "Ada Lovelace", envelope.Response.User["name"])
+	assert.Equal(t, "found", envelope.Response.Message)
+}
+
+func TestEchoRequest_JSONEnvelopePretty(t *testing.T) {
+	out, err := runGetWithPointer(t, "--format", "json", "--echo-request", "--pretty")
+	require.NoError(t, err)
+	assert.Contains(t, out, "{\n  \"request\": {\n    \"id\": \"7\",\n    \"includeDetails\": false\n  },\n  \"response\": {\n    \"user\": {\n      \"id\": \"7\",")
+}
+
+func TestEchoRequest_YAMLEnvelope(t *testing.T) {
+	out, err := runGetWithPointer(t, "--format", "yaml", "--echo-request")
+	require.NoError(t, err)
+	assert.Contains(t, out, "request:\n  id: 7\n  includeDetails: false\nresponse:\n  message: found\n  user:\n    address:\n      city: London\n")
+	assert.Contains(t, out, "    name: Ada Lovelace\n")
+
+	out, err = runGetWithPointer(t, "--format", "yaml", "--echo-request", "--yaml-flow")
+	require.NoError(t, err)
+	assert.Contains(t, out, `{request: {id: "7", includeDetails: false}, response: {message: found, user: {`)
+}
+
+func TestEchoRequest_GetEvaluatesAgainstEnvelope(t *testing.T) {
+	out, err := runGetWithPointer(t, "--echo-request", "--get", "/request/id")
+	require.NoError(t, err)
+	assert.Equal(t, "7\n", out)
+
+	out, err = runGetWithPointer(t, "--echo-request", "--get", "/response/user/email")
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com\n", out)
+}
+
+func TestEchoRequest_WithoutFlagWritesResponseOnly(t *testing.T) {
+	out, err := runGetWithPointer(t, "--format", "json")
+	require.NoError(t, err)
+	assert.NotContains(t, out, `"request"`)
+	assert.Contains(t, out, `"message":"found"`)
+}
+
+func TestEchoRequest_UnsupportedFormat(t *testing.T) {
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{User: &simple.User{Id: req.GetId()}}, nil
+		},
+	}
+	_, err := runUserCommand(t, mock, []protocli.ServiceOption{protocli.WithOutputFormats(protocli.Go())},
+		"user-service", "get", "--id", "7", "--db-url", "postgres://localhost/test", "--echo-request")
+	require.ErrorIs(t, err, protocli.ErrEchoRequestFormat)
+	assert.EqualError(t, err, `--echo-request requires a JSON or YAML output format, not "go"`)
+}
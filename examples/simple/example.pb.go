@@ -10,6 +10,9 @@ import (
 	_ "github.com/drewfead/proto-cli/proto/cli/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -642,12 +645,29 @@ type CreateUserRequest struct {
 	// Field without annotation - demonstrates kebab-case default
 	PhoneNumber string `protobuf:"bytes,5,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
 	// Optional fields demonstrate explicit presence tracking
-	Nickname      *string   `protobuf:"bytes,6,opt,name=nickname,proto3,oneof" json:"nickname,omitempty"`
-	Age           *int32    `protobuf:"varint,7,opt,name=age,proto3,oneof" json:"age,omitempty"`
-	Verified      *bool     `protobuf:"varint,8,opt,name=verified,proto3,oneof" json:"verified,omitempty"`
-	LogLevel      *LogLevel `protobuf:"varint,9,opt,name=log_level,json=logLevel,proto3,enum=example.LogLevel,oneof" json:"log_level,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Nickname *string   `protobuf:"bytes,6,opt,name=nickname,proto3,oneof" json:"nickname,omitempty"`
+	Age      *int32    `protobuf:"varint,7,opt,name=age,proto3,oneof" json:"age,omitempty"`
+	Verified *bool     `protobuf:"varint,8,opt,name=verified,proto3,oneof" json:"verified,omitempty"`
+	LogLevel *LogLevel `protobuf:"varint,9,opt,name=log_level,json=logLevel,proto3,enum=example.LogLevel,oneof" json:"log_level,omitempty"`
+	Plan     string    `protobuf:"bytes,10,opt,name=plan,proto3" json:"plan,omitempty"`
+	// Enum and well-known-type fields with annotation defaults
+	NotificationLevel LogLevel             `protobuf:"varint,11,opt,name=notification_level,json=notificationLevel,proto3,enum=example.LogLevel" json:"notification_level,omitempty"`
+	SessionTtl        *durationpb.Duration `protobuf:"bytes,12,opt,name=session_ttl,json=sessionTtl,proto3" json:"session_ttl,omitempty"`
+	// Sensitive fields are masked in audit records
+	InitialPassword string `protobuf:"bytes,13,opt,name=initial_password,json=initialPassword,proto3" json:"initial_password,omitempty"`
+	// Filled with a fresh UUID when --request-id is not given
+	RequestId string `protobuf:"bytes,14,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Long text can be read from a file with --bio @path or from stdin with --bio @-
+	Bio string `protobuf:"bytes,15,opt,name=bio,proto3" json:"bio,omitempty"`
+	// Built-in FieldMask parsing: --update-mask name,email
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,16,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Built-in Any parsing: --metadata 'type.googleapis.com/example.Address={"city":"Oslo"}'
+	Metadata *anypb.Any `protobuf:"bytes,17,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Repeated messages without a deserializer are read from an array file:
+	// --previous-address @addresses.json
+	PreviousAddresses []*Address `protobuf:"bytes,18,rep,name=previous_addresses,json=previousAddresses,proto3" json:"previous_addresses,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *CreateUserRequest) Reset() {
@@ -743,6 +763,69 @@ func (x *CreateUserRequest) GetLogLevel() LogLevel {
 	return LogLevel_LOG_LEVEL_UNSPECIFIED
 }
 
+func (x *CreateUserRequest) GetPlan() string {
+	if x != nil {
+		return x.Plan
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetNotificationLevel() LogLevel {
+	if x != nil {
+		return x.NotificationLevel
+	}
+	return LogLevel_LOG_LEVEL_UNSPECIFIED
+}
+
+func (x *CreateUserRequest) GetSessionTtl() *durationpb.Duration {
+	if x != nil {
+		return x.SessionTtl
+	}
+	return nil
+}
+
+func (x *CreateUserRequest) GetInitialPassword() string {
+	if x != nil {
+		return x.InitialPassword
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *CreateUserRequest) GetMetadata() *anypb.Any {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateUserRequest) GetPreviousAddresses() []*Address {
+	if x != nil {
+		return x.PreviousAddresses
+	}
+	return nil
+}
+
 // Response containing a user
 type UserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -799,6 +882,7 @@ func (x *UserResponse) GetMessage() string {
 // Empty request for admin operations
 type AdminRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verbose       bool                   `protobuf:"varint,1,opt,name=verbose,proto3" json:"verbose,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -833,6 +917,13 @@ func (*AdminRequest) Descriptor() ([]byte, []int) {
 	return file_examples_simple_example_proto_rawDescGZIP(), []int{9}
 }
 
+func (x *AdminRequest) GetVerbose() bool {
+	if x != nil {
+		return x.Verbose
+	}
+	return false
+}
+
 // Response for admin operations
 type AdminResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -886,11 +977,207 @@ func (x *AdminResponse) GetSuccess() bool {
 	return false
 }
 
+// Request to run a named check
+type CheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckRequest) Reset() {
+	*x = CheckRequest{}
+	mi := &file_examples_simple_example_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRequest) ProtoMessage() {}
+
+func (x *CheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_simple_example_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRequest.ProtoReflect.Descriptor instead.
+func (*CheckRequest) Descriptor() ([]byte, []int) {
+	return file_examples_simple_example_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CheckRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Result of a check, reported like a monitoring plugin
+type CheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"` // 0 ok, 1 warning, 2 critical, 3 unknown
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResponse) Reset() {
+	*x = CheckResponse{}
+	mi := &file_examples_simple_example_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResponse) ProtoMessage() {}
+
+func (x *CheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_simple_example_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResponse.ProtoReflect.Descriptor instead.
+func (*CheckResponse) Descriptor() ([]byte, []int) {
+	return file_examples_simple_example_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CheckResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CheckResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *CheckResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Command to run on the server, passed through after "--"
+type ExecRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Args          []string               `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecRequest) Reset() {
+	*x = ExecRequest{}
+	mi := &file_examples_simple_example_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecRequest) ProtoMessage() {}
+
+func (x *ExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_simple_example_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecRequest.ProtoReflect.Descriptor instead.
+func (*ExecRequest) Descriptor() ([]byte, []int) {
+	return file_examples_simple_example_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExecRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+// Output of a command run on the server
+type ExecResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Output        string                 `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecResponse) Reset() {
+	*x = ExecResponse{}
+	mi := &file_examples_simple_example_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecResponse) ProtoMessage() {}
+
+func (x *ExecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_simple_example_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecResponse.ProtoReflect.Descriptor instead.
+func (*ExecResponse) Descriptor() ([]byte, []int) {
+	return file_examples_simple_example_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExecResponse) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
 var File_examples_simple_example_proto protoreflect.FileDescriptor
 
 const file_examples_simple_example_proto_rawDesc = "" +
 	"\n" +
-	"\x1dexamples/simple/example.proto\x12\aexample\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16proto/cli/v1/cli.proto\"\xfb\x01\n" +
+	"\x1dexamples/simple/example.proto\x12\aexample\x1a\x19google/protobuf/any.proto\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16proto/cli/v1/cli.proto\"\xfb\x01\n" +
 	"\x0eDatabaseConfig\x124\n" +
 	"\x03url\x18\x01 \x01(\tB\"\x92\xb5\x18\x1e\n" +
 	"\x03url\x1a\x17Database connection URLR\x03url\x12\\\n" +
@@ -949,23 +1236,47 @@ const file_examples_simple_example_proto_rawDesc = "" +
 	"timeout_ms\x18\x04 \x01(\x05B1\x92\xb5\x18-\n" +
 	"\atimeout\x12\x01t\x1a\x1fRequest timeout in millisecondsH\x01R\ttimeoutMs\x88\x01\x01B\x10\n" +
 	"\x0e_fields_filterB\r\n" +
-	"\v_timeout_ms\"\xa3\x05\n" +
+	"\v_timeout_ms\"\xb6\r\n" +
 	"\x11CreateUserRequest\x125\n" +
 	"\x04name\x18\x01 \x01(\tB!\x92\xb5\x18\x1d\n" +
-	"\x04name\x12\x01n\x1a\x10User's full name \x01R\x04name\x12<\n" +
-	"\x05email\x18\x02 \x01(\tB&\x92\xb5\x18\"\n" +
-	"\x05email\x12\x01e\x1a\x14User's email address \x01R\x05email\x12*\n" +
+	"\x04name\x12\x01n\x1a\x10User's full name \x01R\x04name\x12O\n" +
+	"\x05email\x18\x02 \x01(\tB9\x92\xb5\x185\n" +
+	"\x05email\x12\x01e\x1a\x14User's email address \x01B\x11^[^@\\s]+@[^@\\s]+$R\x05email\x12*\n" +
 	"\aaddress\x18\x03 \x01(\v2\x10.example.AddressR\aaddress\x12G\n" +
 	"\x11registration_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x10registrationDate\x12!\n" +
 	"\fphone_number\x18\x05 \x01(\tR\vphoneNumber\x12O\n" +
 	"\bnickname\x18\x06 \x01(\tB.\x92\xb5\x18*\n" +
-	"\bnickname\x1a\x1eOptional nickname for the userH\x00R\bnickname\x88\x01\x01\x125\n" +
-	"\x03age\x18\a \x01(\x05B\x1e\x92\xb5\x18\x1a\n" +
-	"\x03age\x1a\x13User's age in yearsH\x01R\x03age\x88\x01\x01\x12S\n" +
+	"\bnickname\x1a\x1eOptional nickname for the userH\x00R\bnickname\x88\x01\x01\x12G\n" +
+	"\x03age\x18\a \x01(\x05B0\x92\xb5\x18,\n" +
+	"\x03age\x1a\x13User's age in yearsY\x00\x00\x00\x00\x00\x00\x00\x00i\x00\x00\x00\x00\x00\xc0b@H\x01R\x03age\x88\x01\x01\x12S\n" +
 	"\bverified\x18\b \x01(\bB2\x92\xb5\x18.\n" +
 	"\bverified\x1a\"Whether the user email is verifiedH\x02R\bverified\x88\x01\x01\x12t\n" +
 	"\tlog_level\x18\t \x01(\x0e2\x11.example.LogLevelB?\x92\xb5\x18;\n" +
-	"\tlog-level\x1a.Optional logging level preference for the userH\x03R\blogLevel\x88\x01\x01B\v\n" +
+	"\tlog-level\x1a.Optional logging level preference for the userH\x03R\blogLevel\x88\x01\x01\x12H\n" +
+	"\x04plan\x18\n" +
+	" \x01(\tB4\x92\xb5\x180\n" +
+	"\x04plan\x1a\x11Subscription planJ\x04freeJ\x03proJ\n" +
+	"enterpriseR\x04plan\x12\x84\x01\n" +
+	"\x12notification_level\x18\v \x01(\x0e2\x11.example.LogLevelBB\x92\xb5\x18>\n" +
+	"\x12notification-level\x1a\"Verbosity of account notificationsb\x04infoR\x11notificationLevel\x12y\n" +
+	"\vsession_ttl\x18\f \x01(\v2\x19.google.protobuf.DurationB=\x92\xb5\x189\n" +
+	"\vsession-ttl\x1a%Lifetime of the user's login sessionsb\x0324hR\n" +
+	"sessionTtl\x12f\n" +
+	"\x10initial_password\x18\r \x01(\tB;\x92\xb5\x184\n" +
+	"\x10initial-password\x1a Initial password for the account\x80\x01\x01R\x0finitialPassword\x12]\n" +
+	"\n" +
+	"request_id\x18\x0e \x01(\tB>\x92\xb5\x18:\n" +
+	"\n" +
+	"request-id\x1a&Idempotency key for the create requestr\x04uuidR\trequestId\x12F\n" +
+	"\x03bio\x18\x0f \x01(\tB4\x92\xb5\x180\n" +
+	"\x03bio\x1a'Short biography (@file or @- for stdin)x\x01R\x03bio\x12\x94\x01\n" +
+	"\vupdate_mask\x18\x10 \x01(\v2\x1a.google.protobuf.FieldMaskBW\x92\xb5\x18S\n" +
+	"\vupdate-mask\x1a5User fields to set, comma-separated (e.g. name,email)\x82\x01\fexample.UserR\n" +
+	"updateMask\x12g\n" +
+	"\bmetadata\x18\x11 \x01(\v2\x14.google.protobuf.AnyB5\x92\xb5\x181\n" +
+	"\bmetadata\x1a%Extra typed data as <type URL>=<JSON>R\bmetadata\x12\x90\x01\n" +
+	"\x12previous_addresses\x18\x12 \x03(\v2\x10.example.AddressBO\x92\xb5\x18K\n" +
+	"\x10previous-address\x1a7Former addresses, as @file holding a JSON or YAML arrayR\x11previousAddressesB\v\n" +
 	"\t_nicknameB\x06\n" +
 	"\x04_ageB\v\n" +
 	"\t_verifiedB\f\n" +
@@ -973,11 +1284,23 @@ const file_examples_simple_example_proto_rawDesc = "" +
 	"_log_level\"K\n" +
 	"\fUserResponse\x12!\n" +
 	"\x04user\x18\x01 \x01(\v2\r.example.UserR\x04user\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\x0e\n" +
-	"\fAdminRequest\"C\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"s\n" +
+	"\fAdminRequest\x12c\n" +
+	"\averbose\x18\x01 \x01(\bBI\x92\xb5\x18E\x1a$Include extra detail in the response:\x1dresponses are always detailedR\averbose\"C\n" +
 	"\rAdminResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess*\x81\x01\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"B\n" +
+	"\fCheckRequest\x122\n" +
+	"\x04name\x18\x01 \x01(\tB\x1e\x92\xb5\x18\x1a\x1a\x18Name of the check to runR\x04name\"^\n" +
+	"\rCheckResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vstatus_code\x18\x02 \x01(\x05R\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"[\n" +
+	"\vExecRequest\x12L\n" +
+	"\x04args\x18\x01 \x03(\tB8\x92\xb5\x184\x1a2Command and its arguments (usually given after --)R\x04args\"&\n" +
+	"\fExecResponse\x12\x16\n" +
+	"\x06output\x18\x01 \x01(\tR\x06output*\x81\x01\n" +
 	"\bLogLevel\x12\x19\n" +
 	"\x15LOG_LEVEL_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x05DEBUG\x10\x01\x1a\v\xa2\xb5\x18\a\n" +
@@ -989,10 +1312,10 @@ const file_examples_simple_example_proto_rawDesc = "" +
 	"\xa2\xb5\x18\x06\n" +
 	"\x04warn\x12\x16\n" +
 	"\x05ERROR\x10\x04\x1a\v\xa2\xb5\x18\a\n" +
-	"\x05error2\x94\n" +
+	"\x05error2\xe1\n" +
 	"\n" +
-	"\vUserService\x12\xb5\x05\n" +
-	"\aGetUser\x12\x17.example.GetUserRequest\x1a\x15.example.UserResponse\"\xf9\x04\x8a\xb5\x18\xf4\x04\n" +
+	"\vUserService\x12\xd6\x05\n" +
+	"\aGetUser\x12\x17.example.GetUserRequest\x1a\x15.example.UserResponse\"\x9a\x05\x8a\xb5\x18\x95\x05\n" +
 	"\x03get\x12\x15Retrieve a user by ID\x1a\x95\x04Fetch detailed information about a user from the database.\n" +
 	"\n" +
 	"This command queries the user service to retrieve a user record by their unique ID. You can optionally include additional details like profile information and preferences. Use --fields to specify which fields to return in the response.\n" +
@@ -1000,12 +1323,14 @@ const file_examples_simple_example_proto_rawDesc = "" +
 	"Examples:\n" +
 	"  Get basic user info:       usercli user-service get --id 123\n" +
 	"  Get with details:          usercli user-service get --id 123 --include-details\n" +
-	"  Get specific fields:       usercli user-service get --id 123 --fields name,email\">get --id <user-id> [--include-details] [--fields <field-list>]\x12`\n" +
+	"  Get specific fields:       usercli user-service get --id 123 --fields name,email\">get --id <user-id> [--include-details] [--fields <field-list>]j\x01gp\x02\x9a\x01\x19\n" +
+	"\x06fields\x12\x0finclude-details\x12\x81\x01\n" +
 	"\n" +
-	"CreateUser\x12\x1a.example.CreateUserRequest\x1a\x15.example.UserResponse\"\x1f\x8a\xb5\x18\x1b\n" +
-	"\x06create\x12\x11Create a new user\x12[\n" +
+	"CreateUser\x12\x1a.example.CreateUserRequest\x1a\x15.example.UserResponse\"@\x8a\xb5\x18<\n" +
+	"\x06create\x12\x11Create a new userj\x01cj\x03newp\x01\x92\x01\x14\n" +
+	"\bnickname\x12\bverified\x12[\n" +
 	"\tListUsers\x12\x17.example.GetUserRequest\x1a\x15.example.UserResponse\"\x1a\x8a\xb5\x18\x16\n" +
-	"\x04list\x12\x0eList all users(\x010\x01\x1a\x8d\x03\x82\xb5\x18\xf1\x02\n" +
+	"\x04list\x12\x0eList all users(\x010\x01\x1a\x97\x03\x82\xb5\x18\xfb\x02\n" +
 	"\fuser-service\x12\x18User management commands\x1a\xc6\x02Comprehensive user management service for CRUD operations.\n" +
 	"\n" +
 	"This service provides complete user lifecycle management including:\n" +
@@ -1014,12 +1339,27 @@ const file_examples_simple_example_proto_rawDesc = "" +
 	"- Updating user profiles\n" +
 	"- Managing user authentication and preferences\n" +
 	"\n" +
-	"All commands require appropriate authentication and authorization.\x9a\xb5\x18\x13\n" +
-	"\x11UserServiceConfig2\x98\x01\n" +
+	"All commands require appropriate authentication and authorization.2\x05users2\x01u\x9a\xb5\x18\x13\n" +
+	"\x11UserServiceConfig2\xc9\x05\n" +
 	"\fAdminService\x12`\n" +
 	"\vHealthCheck\x12\x15.example.AdminRequest\x1a\x16.example.AdminResponse\"\"\x8a\xb5\x18\x1e\n" +
-	"\x06health\x12\x14Check service health\x1a&\x82\xb5\x18\"\n" +
-	"\x05admin\x12\x19Administrative operationsB&Z$github.com/drewfead/proto-cli/simpleb\x06proto3"
+	"\x06health\x12\x14Check service health\x12m\n" +
+	"\x04Ping\x12\x15.example.AdminRequest\x1a\x16.example.AdminResponse\"6\x8a\xb5\x182\n" +
+	"\x04ping\x12\x16Check service livenessJ\x12use health instead\x12l\n" +
+	"\vDiagnostics\x12\x15.example.AdminRequest\x1a\x16.example.AdminResponse\".\x8a\xb5\x18*\n" +
+	"\vdiagnostics\x12\x19Dump internal diagnostics@\x01\x12\x84\x01\n" +
+	"\bRunCheck\x12\x15.example.CheckRequest\x1a\x16.example.CheckResponse\"I\x8a\xb5\x18E\n" +
+	"\x05check\x12/Run a named check and exit with its status codez\vstatus_code\x12g\n" +
+	"\n" +
+	"PurgeCache\x12\x15.example.AdminRequest\x1a\x16.example.AdminResponse\"*\x8a\xb5\x18&\n" +
+	"\vpurge-cache\x12\x14Drop all cached data\x80\x01\x01\x12b\n" +
+	"\x04Exec\x12\x14.example.ExecRequest\x1a\x15.example.ExecResponse\"-\x8a\xb5\x18)\n" +
+	"\x03run\x12\x1bRun a command on the server\x8a\x01\x04args\x1a&\x82\xb5\x18\"\n" +
+	"\x05admin\x12\x19Administrative operationsB\x9f\x01\xaa\xb5\x18u\n" +
+	"8\n" +
+	"\x06tenant\x12\x1bTenant to scope requests to\x1a\x01t2\x0eUSERCLI_TENANT\n" +
+	"9\n" +
+	"\x0frequest-timeout\x12\x1fTimeout applied to each request \x05*\x0330sZ$github.com/drewfead/proto-cli/simpleb\x06proto3"
 
 var (
 	file_examples_simple_example_proto_rawDescOnce sync.Once
@@ -1034,7 +1374,7 @@ func file_examples_simple_example_proto_rawDescGZIP() []byte {
 }
 
 var file_examples_simple_example_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_examples_simple_example_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_examples_simple_example_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_examples_simple_example_proto_goTypes = []any{
 	(LogLevel)(0),                 // 0: example.LogLevel
 	(*DatabaseConfig)(nil),        // 1: example.DatabaseConfig
@@ -1048,34 +1388,56 @@ var file_examples_simple_example_proto_goTypes = []any{
 	(*UserResponse)(nil),          // 9: example.UserResponse
 	(*AdminRequest)(nil),          // 10: example.AdminRequest
 	(*AdminResponse)(nil),         // 11: example.AdminResponse
-	nil,                           // 12: example.UserServiceConfig.FeatureFlagsEntry
-	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+	(*CheckRequest)(nil),          // 12: example.CheckRequest
+	(*CheckResponse)(nil),         // 13: example.CheckResponse
+	(*ExecRequest)(nil),           // 14: example.ExecRequest
+	(*ExecResponse)(nil),          // 15: example.ExecResponse
+	nil,                           // 16: example.UserServiceConfig.FeatureFlagsEntry
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 18: google.protobuf.Duration
+	(*fieldmaskpb.FieldMask)(nil), // 19: google.protobuf.FieldMask
+	(*anypb.Any)(nil),             // 20: google.protobuf.Any
 }
 var file_examples_simple_example_proto_depIdxs = []int32{
 	1,  // 0: example.UserServiceConfig.database:type_name -> example.DatabaseConfig
 	0,  // 1: example.UserServiceConfig.log_level:type_name -> example.LogLevel
-	12, // 2: example.UserServiceConfig.feature_flags:type_name -> example.UserServiceConfig.FeatureFlagsEntry
+	16, // 2: example.UserServiceConfig.feature_flags:type_name -> example.UserServiceConfig.FeatureFlagsEntry
 	2,  // 3: example.UserServiceConfig.postgres:type_name -> example.PostgresBackend
 	3,  // 4: example.UserServiceConfig.mysql:type_name -> example.MySQLBackend
-	13, // 5: example.User.created_at:type_name -> google.protobuf.Timestamp
+	17, // 5: example.User.created_at:type_name -> google.protobuf.Timestamp
 	5,  // 6: example.User.address:type_name -> example.Address
 	5,  // 7: example.CreateUserRequest.address:type_name -> example.Address
-	13, // 8: example.CreateUserRequest.registration_date:type_name -> google.protobuf.Timestamp
+	17, // 8: example.CreateUserRequest.registration_date:type_name -> google.protobuf.Timestamp
 	0,  // 9: example.CreateUserRequest.log_level:type_name -> example.LogLevel
-	6,  // 10: example.UserResponse.user:type_name -> example.User
-	7,  // 11: example.UserService.GetUser:input_type -> example.GetUserRequest
-	8,  // 12: example.UserService.CreateUser:input_type -> example.CreateUserRequest
-	7,  // 13: example.UserService.ListUsers:input_type -> example.GetUserRequest
-	10, // 14: example.AdminService.HealthCheck:input_type -> example.AdminRequest
-	9,  // 15: example.UserService.GetUser:output_type -> example.UserResponse
-	9,  // 16: example.UserService.CreateUser:output_type -> example.UserResponse
-	9,  // 17: example.UserService.ListUsers:output_type -> example.UserResponse
-	11, // 18: example.AdminService.HealthCheck:output_type -> example.AdminResponse
-	15, // [15:19] is the sub-list for method output_type
-	11, // [11:15] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	0,  // 10: example.CreateUserRequest.notification_level:type_name -> example.LogLevel
+	18, // 11: example.CreateUserRequest.session_ttl:type_name -> google.protobuf.Duration
+	19, // 12: example.CreateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	20, // 13: example.CreateUserRequest.metadata:type_name -> google.protobuf.Any
+	5,  // 14: example.CreateUserRequest.previous_addresses:type_name -> example.Address
+	6,  // 15: example.UserResponse.user:type_name -> example.User
+	7,  // 16: example.UserService.GetUser:input_type -> example.GetUserRequest
+	8,  // 17: example.UserService.CreateUser:input_type -> example.CreateUserRequest
+	7,  // 18: example.UserService.ListUsers:input_type -> example.GetUserRequest
+	10, // 19: example.AdminService.HealthCheck:input_type -> example.AdminRequest
+	10, // 20: example.AdminService.Ping:input_type -> example.AdminRequest
+	10, // 21: example.AdminService.Diagnostics:input_type -> example.AdminRequest
+	12, // 22: example.AdminService.RunCheck:input_type -> example.CheckRequest
+	10, // 23: example.AdminService.PurgeCache:input_type -> example.AdminRequest
+	14, // 24: example.AdminService.Exec:input_type -> example.ExecRequest
+	9,  // 25: example.UserService.GetUser:output_type -> example.UserResponse
+	9,  // 26: example.UserService.CreateUser:output_type -> example.UserResponse
+	9,  // 27: example.UserService.ListUsers:output_type -> example.UserResponse
+	11, // 28: example.AdminService.HealthCheck:output_type -> example.AdminResponse
+	11, // 29: example.AdminService.Ping:output_type -> example.AdminResponse
+	11, // 30: example.AdminService.Diagnostics:output_type -> example.AdminResponse
+	13, // 31: example.AdminService.RunCheck:output_type -> example.CheckResponse
+	11, // 32: example.AdminService.PurgeCache:output_type -> example.AdminResponse
+	15, // 33: example.AdminService.Exec:output_type -> example.ExecResponse
+	25, // [25:34] is the sub-list for method output_type
+	16, // [16:25] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_examples_simple_example_proto_init() }
@@ -1095,7 +1457,7 @@ func file_examples_simple_example_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_examples_simple_example_proto_rawDesc), len(file_examples_simple_example_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   12,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
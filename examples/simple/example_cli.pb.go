@@ -4,17 +4,21 @@ package simple
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	protocli "github.com/drewfead/proto-cli"
 	v3 "github.com/urfave/cli/v3"
 	grpc "google.golang.org/grpc"
-	insecure "google.golang.org/grpc/credentials/insecure"
+	proto "google.golang.org/protobuf/proto"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	"io"
 	"log/slog"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // getUserServiceOutputWriter opens the specified output file or returns cmd.Writer (if set) or stdout
@@ -29,7 +33,11 @@ func getUserServiceOutputWriter(cmd *v3.Command, path string) (io.Writer, error)
 		}
 		return os.Stdout, nil
 	}
-	return os.Create(path)
+	path, err := protocli.OutputPath(cmd, path)
+	if err != nil {
+		return nil, err
+	}
+	return protocli.OpenOutputFile(path, cmd.String("output-mode"), cmd.Bool("output-append"))
 }
 
 // parseUserServiceLogLevel parses a string value to LogLevel enum
@@ -60,6 +68,9 @@ func parseUserServiceLogLevel(value string) (LogLevel, error) {
 	return 0, fmt.Errorf("invalid %s value: %q (valid values: %s)", "LogLevel", value, "debug, info, warn, error")
 }
 
+// userServiceCreateUserEmailPattern is the compiled pattern annotation of example.CreateUserRequest.email
+var userServiceCreateUserEmailPattern = regexp.MustCompile("^[^@\\s]+@[^@\\s]+$")
+
 // UserServiceCommand creates a CLI for UserService with options
 // The implOrFactory parameter can be either a direct service implementation or a factory function
 func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) *protocli.ServiceCLI {
@@ -73,248 +84,6 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 
 	var commands []*v3.Command
 
-	// Build flags for get
-	flags_get := []v3.Flag{&v3.StringFlag{
-		Name:  "remote",
-		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
-	}, &v3.StringFlag{
-		Name:  "format",
-		Usage: "Output format (use --format to see available formats)",
-		Value: defaultFormat,
-	}, &v3.StringFlag{
-		Name:  "output",
-		Usage: "Output file (- for stdout)",
-		Value: "-",
-	}, &v3.StringFlag{
-		Name:  "input-file",
-		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
-	}, &v3.StringFlag{
-		Name:  "input-format",
-		Usage: "Input file format (auto-detected from extension if not set)",
-	}}
-
-	flags_get = append(flags_get, &v3.Int64Flag{
-		Aliases:  []string{"i"},
-		Name:     "id",
-		Required: true,
-		Usage:    "User ID to retrieve",
-	})
-	flags_get = append(flags_get, &v3.BoolFlag{
-		Aliases: []string{"d"},
-		Name:    "include-details",
-		Usage:   "Include detailed user information",
-	})
-	flags_get = append(flags_get, &v3.StringFlag{
-		Aliases: []string{"f"},
-		Name:    "fields",
-		Usage:   "Comma-separated list of fields to return (e.g., 'name,email')",
-	})
-	flags_get = append(flags_get, &v3.Int32Flag{
-		Aliases: []string{"t"},
-		Name:    "timeout",
-		Usage:   "Request timeout in milliseconds",
-	})
-
-	// Add config field flags for single-command mode
-	flags_get = append(flags_get, &v3.StringFlag{
-		Name:     "db-url",
-		Required: true,
-		Usage:    "PostgreSQL connection URL",
-	})
-	flags_get = append(flags_get, &v3.Int64Flag{
-		Name:  "max-conns",
-		Usage: "Maximum database connections",
-	})
-	flags_get = append(flags_get, &v3.StringFlag{
-		Name:  "log-level",
-		Usage: "Logging level [debug|info|warn|error]",
-	})
-	flags_get = append(flags_get, &v3.StringSliceFlag{
-		Name:  "allowed-origins",
-		Usage: "CORS allowed origins",
-	})
-
-	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_get = append(flags_get, flagConfigured.Flags()...)
-		}
-	}
-
-	commands = append(commands, &v3.Command{
-		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
-			}
-
-			defer func() {
-				hooks := options.AfterCommandHooks()
-				for i := len(hooks) - 1; i >= 0; i-- {
-					if err := hooks[i](cmdCtx, cmd); err != nil {
-						slog.Warn("after hook failed", "error", err)
-					}
-				}
-			}()
-
-			for _, hook := range options.BeforeCommandHooks() {
-				if err := hook(cmdCtx, cmd); err != nil {
-					return fmt.Errorf("before hook failed: %w", err)
-				}
-			}
-
-			// Build request message
-			var req *GetUserRequest
-
-			// Check for file-based input
-			inputFile := cmd.String("input-file")
-			if inputFile != "" {
-				// Read request from file
-				req = &GetUserRequest{}
-				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
-					return err
-				}
-				// Apply flag overrides (only explicitly-set flags)
-				if cmd.IsSet("id") {
-					req.Id = cmd.Int64("id")
-				}
-				if cmd.IsSet("include-details") {
-					req.IncludeDetails = cmd.Bool("include-details")
-				}
-				if cmd.IsSet("fields-filter") {
-					val := cmd.String("fields-filter")
-					req.FieldsFilter = &val
-				}
-				if cmd.IsSet("timeout-ms") {
-					val := cmd.Int32("timeout-ms")
-					req.TimeoutMs = &val
-				}
-			} else {
-				// Check for custom flag deserializer for example.GetUserRequest
-				deserializer, hasDeserializer := options.FlagDeserializer("example.GetUserRequest")
-				if hasDeserializer {
-					// Use custom deserializer for top-level request
-					// Create FlagContainer (deserializer can access multiple flags via Command())
-					requestFlags := protocli.NewFlagContainer(cmd, "")
-					msg, err := deserializer(cmdCtx, requestFlags)
-					if err != nil {
-						return fmt.Errorf("custom deserializer failed: %w", err)
-					}
-					// Handle nil return from deserializer
-					if msg == nil {
-						return fmt.Errorf("custom deserializer returned nil message")
-					}
-					var ok bool
-					req, ok = msg.(*GetUserRequest)
-					if !ok {
-						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "GetUserRequest", msg)
-					}
-				} else {
-					// Use auto-generated flag parsing
-					req = &GetUserRequest{}
-					req.Id = cmd.Int64("id")
-					req.IncludeDetails = cmd.Bool("include-details")
-					if cmd.IsSet("fields-filter") {
-						val := cmd.String("fields-filter")
-						req.FieldsFilter = &val
-					}
-					if cmd.IsSet("timeout-ms") {
-						val := cmd.Int32("timeout-ms")
-						req.TimeoutMs = &val
-					}
-				}
-			}
-
-			// Check if using remote gRPC call or direct implementation call
-			remoteAddr := cmd.String("remote")
-			var resp *UserResponse
-			var err error
-
-			if remoteAddr != "" {
-				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-				if connErr != nil {
-					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
-				}
-				defer conn.Close()
-
-				client := NewUserServiceClient(conn)
-				resp, err = client.GetUser(cmdCtx, req)
-				if err != nil {
-					return fmt.Errorf("remote call failed: %w", err)
-				}
-			} else {
-				// Load config and create service implementation
-				// Get config paths and env prefix from root command
-				rootCmd := cmd.Root()
-				configPaths := rootCmd.StringSlice("config")
-				envPrefix := rootCmd.String("env-prefix")
-
-				// Create config loader (single-command mode = uses files + env + flags)
-				loader := protocli.NewConfigLoader(protocli.SingleCommandMode, protocli.FileConfig(configPaths...), protocli.EnvPrefix(envPrefix))
-
-				// Create config instance and load configuration
-				config := &UserServiceConfig{}
-				if err := loader.LoadServiceConfig(cmd, "userservice", config); err != nil {
-					return fmt.Errorf("failed to load config: %w", err)
-				}
-
-				// Call factory to create service implementation
-				svcImpl, err := protocli.CallFactory(implOrFactory, config)
-				if err != nil {
-					return fmt.Errorf("failed to create service: %w", err)
-				}
-
-				// Call the RPC method
-				resp, err = svcImpl.(UserServiceServer).GetUser(cmdCtx, req)
-				if err != nil {
-					return fmt.Errorf("method failed: %w", err)
-				}
-			}
-
-			// Open output writer
-			outputWriter, err := getUserServiceOutputWriter(cmd, cmd.String("output"))
-			if err != nil {
-				return fmt.Errorf("failed to open output: %w", err)
-			}
-			if closer, ok := outputWriter.(io.Closer); ok {
-				defer closer.Close()
-			}
-
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
-
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
-		},
-		Description: "Fetch detailed information about a user from the database.\n\nThis command queries the user service to retrieve a user record by their unique ID. You can optionally include additional details like profile information and preferences. Use --fields to specify which fields to return in the response.\n\nExamples:\n  Get basic user info:       usercli user-service get --id 123\n  Get with details:          usercli user-service get --id 123 --include-details\n  Get specific fields:       usercli user-service get --id 123 --fields name,email",
-		Flags:       flags_get,
-		Name:        "get",
-		Usage:       "Retrieve a user by ID",
-		UsageText:   "get --id <user-id> [--include-details] [--fields <field-list>]",
-	})
-
 	// Build flags for create
 	flags_create := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
@@ -327,12 +96,34 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_create = append(flags_create, &v3.StringFlag{
@@ -375,6 +166,47 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 		Name:  "log-level",
 		Usage: "Optional logging level preference for the user [debug|info|warn|error]",
 	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		Name:  "plan",
+		Usage: "Subscription plan",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		DefaultText: "info",
+		Name:        "notification-level",
+		Usage:       "Verbosity of account notifications [debug|info|warn|error]",
+		Value:       "info",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		DefaultText: "24h",
+		Name:        "session-ttl",
+		Usage:       "Lifetime of the user's login sessions",
+		Value:       "24h",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		Name:  "initial-password",
+		Usage: "Initial password for the account",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		DefaultText: "generated uuid",
+		Name:        "request-id",
+		Usage:       "Idempotency key for the create request",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		Name:  "bio",
+		Usage: "Short biography (@file or @- for stdin)",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		Name:  "update-mask",
+		Usage: "User fields to set, comma-separated (e.g. name,email)",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		Name:  "metadata",
+		Usage: "Extra typed data as <type URL>=<JSON>",
+	})
+	flags_create = append(flags_create, &v3.StringSliceFlag{
+		Name:  "previous-address",
+		Usage: "Former addresses, as @file holding a JSON or YAML array",
+	})
 
 	// Add config field flags for single-command mode
 	flags_create = append(flags_create, &v3.StringFlag{
@@ -396,19 +228,22 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_create = append(flags_create, flagConfigured.Flags()...)
-		}
-	}
+	flags_create = append(flags_create, protocli.FormatFlags(options.OutputFormats())...)
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -424,6 +259,7 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *CreateUserRequest
 
@@ -437,10 +273,18 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("email") {
-					req.Email = cmd.String("email")
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "email", cmd.String("email"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --email: %w", err)
+					}
+					req.Email = val
 				}
 				if cmd.IsSet("address") {
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("example.Address"); hasFieldDeserializer {
@@ -479,10 +323,17 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 					}
 				}
 				if cmd.IsSet("phone-number") {
-					req.PhoneNumber = cmd.String("phone-number")
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "phone_number", cmd.String("phone-number"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --phone-number: %w", err)
+					}
+					req.PhoneNumber = val
 				}
 				if cmd.IsSet("nickname") {
-					val := cmd.String("nickname")
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "nickname", cmd.String("nickname"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --nickname: %w", err)
+					}
 					req.Nickname = &val
 				}
 				if cmd.IsSet("age") {
@@ -500,31 +351,148 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 					}
 					req.LogLevel = &val
 				}
-			} else {
-				// Check for custom flag deserializer for example.CreateUserRequest
-				deserializer, hasDeserializer := options.FlagDeserializer("example.CreateUserRequest")
-				if hasDeserializer {
-					// Use custom deserializer for top-level request
-					// Create FlagContainer (deserializer can access multiple flags via Command())
-					requestFlags := protocli.NewFlagContainer(cmd, "")
-					msg, err := deserializer(cmdCtx, requestFlags)
+				if cmd.IsSet("plan") {
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "plan", cmd.String("plan"))
 					if err != nil {
-						return fmt.Errorf("custom deserializer failed: %w", err)
+						return fmt.Errorf("invalid value for --plan: %w", err)
 					}
-					// Handle nil return from deserializer
-					if msg == nil {
-						return fmt.Errorf("custom deserializer returned nil message")
+					req.Plan = val
+				}
+				if cmd.IsSet("notification-level") {
+					val, err := parseUserServiceLogLevel(cmd.String("notification-level"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --notification-level: %w", err)
 					}
-					var ok bool
-					req, ok = msg.(*CreateUserRequest)
-					if !ok {
-						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "CreateUserRequest", msg)
+					req.NotificationLevel = val
+				}
+				if cmd.IsSet("session-ttl") {
+					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Duration"); hasFieldDeserializer {
+						fieldFlags := protocli.NewFlagContainer(cmd, "session-ttl")
+						fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+						if fieldErr != nil {
+							return fmt.Errorf("failed to deserialize field SessionTtl: %w", fieldErr)
+						}
+						if fieldMsg != nil {
+							typedField, fieldOk := fieldMsg.(*durationpb.Duration)
+							if !fieldOk {
+								return fmt.Errorf("custom deserializer for google.protobuf.Duration returned wrong type: expected *Duration, got %T", fieldMsg)
+							}
+							req.SessionTtl = typedField
+						}
+					} else {
+						return fmt.Errorf("flag --session-ttl requires a custom deserializer for google.protobuf.Duration (register with protocli.WithFlagDeserializer)")
+					}
+				}
+				if cmd.IsSet("initial-password") {
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "initial_password", cmd.String("initial-password"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --initial-password: %w", err)
+					}
+					req.InitialPassword = val
+				}
+				if cmd.IsSet("request-id") {
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "request_id", cmd.String("request-id"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --request-id: %w", err)
+					}
+					req.RequestId = val
+				}
+				if cmd.IsSet("bio") {
+					val, err := protocli.ReadFileFlagValue(cmd.String("bio"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --bio: %w", err)
+					}
+					val, err = protocli.TransformFlagValue("example.CreateUserRequest", "bio", val)
+					if err != nil {
+						return fmt.Errorf("invalid value for --bio: %w", err)
+					}
+					req.Bio = val
+				}
+				if cmd.IsSet("update-mask") {
+					mask, err := protocli.ParseFieldMask(cmd.String("update-mask"), "example.User")
+					if err != nil {
+						return fmt.Errorf("invalid value for --update-mask: %w", err)
+					}
+					req.UpdateMask = mask
+				}
+				if cmd.IsSet("metadata") {
+					packed, err := protocli.ParseAny(cmd.String("metadata"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --metadata: %w", err)
+					}
+					req.Metadata = packed
+				}
+				if cmd.IsSet("previous-address") {
+					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("example.Address"); hasFieldDeserializer {
+						req.PreviousAddresses = nil
+						for _, s := range cmd.StringSlice("previous-address") {
+							elemFlags := protocli.NewStringValueFlagContainer(s, cmd)
+							elemMsg, elemErr := fieldDeserializer(cmdCtx, elemFlags)
+							if elemErr != nil {
+								return fmt.Errorf("failed to deserialize element for --previous-address: %w", elemErr)
+							}
+							if elemMsg != nil {
+								typedElem, elemOk := elemMsg.(*Address)
+								if !elemOk {
+									return fmt.Errorf("custom deserializer for example.Address returned wrong type: expected *Address, got %T", elemMsg)
+								}
+								req.PreviousAddresses = append(req.PreviousAddresses, typedElem)
+							}
+						}
+					} else {
+						req.PreviousAddresses = nil
+						for _, s := range cmd.StringSlice("previous-address") {
+							listPath, isFile := strings.CutPrefix(s, "@")
+							if !isFile {
+								return fmt.Errorf("flag --previous-address requires a custom deserializer for example.Address (register with protocli.WithFlagDeserializer) or an @file holding a JSON or YAML array")
+							}
+							elems, listErr := protocli.ReadInputFileList(listPath, options.InputFormats(), func() *Address {
+								return &Address{}
+							})
+							if listErr != nil {
+								return fmt.Errorf("invalid value for --previous-address: %w", listErr)
+							}
+							req.PreviousAddresses = append(req.PreviousAddresses, elems...)
+						}
+					}
+				}
+			} else {
+				// Check for custom flag deserializer for example.CreateUserRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.CreateUserRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*CreateUserRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "CreateUserRequest", msg)
 					}
 				} else {
 					// Use auto-generated flag parsing
 					req = &CreateUserRequest{}
-					req.Name = cmd.String("name")
-					req.Email = cmd.String("email")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
+					if s := cmd.String("email"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "email", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --email: %w", err)
+						}
+						req.Email = val
+					}
 					// Field Address: check for custom deserializer for example.Address
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("example.Address"); hasFieldDeserializer {
 						// Use custom deserializer for nested message
@@ -573,9 +541,18 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 						}
 						// No value provided - leave field as nil
 					}
-					req.PhoneNumber = cmd.String("phone-number")
+					if s := cmd.String("phone-number"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "phone_number", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --phone-number: %w", err)
+						}
+						req.PhoneNumber = val
+					}
 					if cmd.IsSet("nickname") {
-						val := cmd.String("nickname")
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "nickname", cmd.String("nickname"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --nickname: %w", err)
+						}
 						req.Nickname = &val
 					}
 					if cmd.IsSet("age") {
@@ -593,7 +570,165 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 						}
 						req.LogLevel = &val
 					}
+					if s := cmd.String("plan"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "plan", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --plan: %w", err)
+						}
+						req.Plan = val
+					}
+					if cmd.String("notification-level") != "" {
+						val, err := parseUserServiceLogLevel(cmd.String("notification-level"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --notification-level: %w", err)
+						}
+						req.NotificationLevel = val
+					}
+					// Field SessionTtl: check for custom deserializer for google.protobuf.Duration
+					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Duration"); hasFieldDeserializer {
+						// Use custom deserializer for nested message
+						// Create FlagContainer for field flag: session-ttl
+						fieldFlags := protocli.NewFlagContainer(cmd, "session-ttl")
+						fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+						if fieldErr != nil {
+							return fmt.Errorf("failed to deserialize field SessionTtl: %w", fieldErr)
+						}
+						// Handle nil return from deserializer (means skip/use default)
+						if fieldMsg != nil {
+							typedField, fieldOk := fieldMsg.(*durationpb.Duration)
+							if !fieldOk {
+								return fmt.Errorf("custom deserializer for google.protobuf.Duration returned wrong type: expected *Duration, got %T", fieldMsg)
+							}
+							req.SessionTtl = typedField
+						}
+					} else {
+						// No custom deserializer - check if user provided a value
+						if cmd.IsSet("session-ttl") {
+							return fmt.Errorf("flag --session-ttl requires a custom deserializer for google.protobuf.Duration (register with protocli.WithFlagDeserializer)")
+						}
+						// No value provided - leave field as nil
+					}
+					if s := cmd.String("initial-password"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "initial_password", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --initial-password: %w", err)
+						}
+						req.InitialPassword = val
+					}
+					if s := cmd.String("request-id"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "request_id", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --request-id: %w", err)
+						}
+						req.RequestId = val
+					}
+					if s := cmd.String("bio"); s != "" {
+						val, err := protocli.ReadFileFlagValue(s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --bio: %w", err)
+						}
+						val, err = protocli.TransformFlagValue("example.CreateUserRequest", "bio", val)
+						if err != nil {
+							return fmt.Errorf("invalid value for --bio: %w", err)
+						}
+						req.Bio = val
+					}
+					if cmd.IsSet("update-mask") {
+						mask, err := protocli.ParseFieldMask(cmd.String("update-mask"), "example.User")
+						if err != nil {
+							return fmt.Errorf("invalid value for --update-mask: %w", err)
+						}
+						req.UpdateMask = mask
+					}
+					if cmd.IsSet("metadata") {
+						packed, err := protocli.ParseAny(cmd.String("metadata"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --metadata: %w", err)
+						}
+						req.Metadata = packed
+					}
+					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("example.Address"); hasFieldDeserializer {
+						for _, s := range cmd.StringSlice("previous-address") {
+							elemFlags := protocli.NewStringValueFlagContainer(s, cmd)
+							elemMsg, elemErr := fieldDeserializer(cmdCtx, elemFlags)
+							if elemErr != nil {
+								return fmt.Errorf("failed to deserialize element for --previous-address: %w", elemErr)
+							}
+							if elemMsg != nil {
+								typedElem, elemOk := elemMsg.(*Address)
+								if !elemOk {
+									return fmt.Errorf("custom deserializer for example.Address returned wrong type: expected *Address, got %T", elemMsg)
+								}
+								req.PreviousAddresses = append(req.PreviousAddresses, typedElem)
+							}
+						}
+					} else {
+						for _, s := range cmd.StringSlice("previous-address") {
+							listPath, isFile := strings.CutPrefix(s, "@")
+							if !isFile {
+								return fmt.Errorf("flag --previous-address requires a custom deserializer for example.Address (register with protocli.WithFlagDeserializer) or an @file holding a JSON or YAML array")
+							}
+							elems, listErr := protocli.ReadInputFileList(listPath, options.InputFormats(), func() *Address {
+								return &Address{}
+							})
+							if listErr != nil {
+								return fmt.Errorf("invalid value for --previous-address: %w", listErr)
+							}
+							req.PreviousAddresses = append(req.PreviousAddresses, elems...)
+						}
+					}
+				}
+			}
+
+			// Generate --request-id with "uuid" when not given
+			if !cmd.IsSet("request-id") && req.GetRequestId() == "" {
+				v, err := protocli.GenerateDefault("uuid")
+				if err != nil {
+					return fmt.Errorf("failed to generate --request-id: %w", err)
 				}
+				req.RequestId = v
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.UserService/CreateUser", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Validate flag value constraints
+			if err := errors.Join(
+				protocli.FieldConstraint{
+					Flag:    "email",
+					Pattern: userServiceCreateUserEmailPattern,
+					Set:     cmd.IsSet("email"),
+				}.Check(req.Email),
+				protocli.FieldConstraint{
+					Flag: "age",
+					Max:  proto.Float64(150.0),
+					Min:  proto.Float64(0.0),
+					Set:  cmd.IsSet("age"),
+				}.Check(req.Age),
+				protocli.FieldConstraint{
+					Flag:  "plan",
+					OneOf: []string{"free", "pro", "enterprise"},
+					Set:   cmd.IsSet("plan"),
+				}.Check(req.Plan),
+			); err != nil {
+				return err
+			}
+
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(
+				cmd,
+				options.FlagRules("/example.UserService/CreateUser"),
+				protocli.RequiresFlag("nickname", "verified"),
+			); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &UserResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
 			}
 
 			// Check if using remote gRPC call or direct implementation call
@@ -601,28 +736,41 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 			var resp *UserResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*UserResponse](cmd, "/example.UserService/CreateUser", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewUserServiceClient(conn)
-				resp, err = client.CreateUser(cmdCtx, req)
+				resp, err = client.CreateUser(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Load config and create service implementation
+				timings.Phase("config load")
 				// Get config paths and env prefix from root command
 				rootCmd := cmd.Root()
 				configPaths := rootCmd.StringSlice("config")
 				envPrefix := rootCmd.String("env-prefix")
 
 				// Create config loader (single-command mode = uses files + env + flags)
-				loader := protocli.NewConfigLoader(protocli.SingleCommandMode, protocli.FileConfig(configPaths...), protocli.EnvPrefix(envPrefix))
+				loader := protocli.NewConfigLoader(protocli.SingleCommandMode, protocli.FileConfig(configPaths...), protocli.EnvPrefix(envPrefix), protocli.SecretDir(rootCmd.String("secret-dir")))
 
 				// Create config instance and load configuration
 				config := &UserServiceConfig{}
@@ -631,18 +779,32 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 				}
 
 				// Call factory to create service implementation
+				timings.Phase("service call")
 				svcImpl, err := protocli.CallFactory(implOrFactory, config)
 				if err != nil {
 					return fmt.Errorf("failed to create service: %w", err)
 				}
 
 				// Call the RPC method
-				resp, err = svcImpl.(UserServiceServer).CreateUser(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "UserService.CreateUser", func() (*UserResponse, error) {
+					return svcImpl.(UserServiceServer).CreateUser(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.UserService/CreateUser", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getUserServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -652,70 +814,22 @@ func UserServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Aliases: []string{"c", "new"},
+		Flags:   flags_create,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.UserService/CreateUser",
+			protocli.RequestFlagsMetadataKey: []string{"name", "email", "address", "registration-date", "phone-number", "nickname", "age", "verified", "log-level", "plan", "notification-level", "session-ttl", "initial-password", "request-id", "bio", "update-mask", "metadata", "previous-address"},
 		},
-		Flags: flags_create,
 		Name:  "create",
 		Usage: "Create a new user",
 	})
 
-	return &protocli.ServiceCLI{
-		Command: &v3.Command{
-			Commands:    commands,
-			Description: "Comprehensive user management service for CRUD operations.\n\nThis service provides complete user lifecycle management including:\n- Creating new user accounts\n- Retrieving user information\n- Updating user profiles\n- Managing user authentication and preferences\n\nAll commands require appropriate authentication and authorization.",
-			Name:        "user-service",
-			Usage:       "User management commands",
-		},
-		ConfigMessageType: "UserServiceConfig",
-		ConfigPrototype:   &UserServiceConfig{},
-		FactoryOrImpl:     implOrFactory,
-		RegisterFunc: func(s *grpc.Server, impl interface{}) {
-			RegisterUserServiceServer(s, impl.(UserServiceServer))
-		},
-		ServiceName: "user-service",
-	}
-}
-
-// UserServiceCommandsFlat creates a flat command structure for UserService (for single-service CLIs)
-// This returns RPC commands directly at the root level instead of nested under a service command.
-// The implOrFactory parameter can be either a direct service implementation or a factory function
-// The returned slice includes all RPC commands plus a daemonize command for starting a gRPC server.
-func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) []*v3.Command {
-	options := protocli.ApplyServiceOptions(opts...)
-
-	// Determine default format (first registered format, or empty if none)
-	var defaultFormat string
-	if len(options.OutputFormats()) > 0 {
-		defaultFormat = options.OutputFormats()[0].Name()
-	}
-
-	var commands []*v3.Command
-
 	// Build flags for get
 	flags_get := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
@@ -728,12 +842,34 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_get = append(flags_get, &v3.Int64Flag{
@@ -778,19 +914,22 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_get = append(flags_get, flagConfigured.Flags()...)
-		}
-	}
+	flags_get = append(flags_get, protocli.FormatFlags(options.OutputFormats())...)
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -806,6 +945,7 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *GetUserRequest
 
@@ -824,12 +964,15 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 				if cmd.IsSet("include-details") {
 					req.IncludeDetails = cmd.Bool("include-details")
 				}
-				if cmd.IsSet("fields-filter") {
-					val := cmd.String("fields-filter")
+				if cmd.IsSet("fields") {
+					val, err := protocli.TransformFlagValue("example.GetUserRequest", "fields_filter", cmd.String("fields"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --fields: %w", err)
+					}
 					req.FieldsFilter = &val
 				}
-				if cmd.IsSet("timeout-ms") {
-					val := cmd.Int32("timeout-ms")
+				if cmd.IsSet("timeout") {
+					val := cmd.Int32("timeout")
 					req.TimeoutMs = &val
 				}
 			} else {
@@ -857,44 +1000,80 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 					req = &GetUserRequest{}
 					req.Id = cmd.Int64("id")
 					req.IncludeDetails = cmd.Bool("include-details")
-					if cmd.IsSet("fields-filter") {
-						val := cmd.String("fields-filter")
+					if cmd.IsSet("fields") {
+						val, err := protocli.TransformFlagValue("example.GetUserRequest", "fields_filter", cmd.String("fields"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --fields: %w", err)
+						}
 						req.FieldsFilter = &val
 					}
-					if cmd.IsSet("timeout-ms") {
-						val := cmd.Int32("timeout-ms")
+					if cmd.IsSet("timeout") {
+						val := cmd.Int32("timeout")
 						req.TimeoutMs = &val
 					}
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/example.UserService/GetUser", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(
+				cmd,
+				options.FlagRules("/example.UserService/GetUser"),
+				protocli.ConflictingFlags("fields", "include-details"),
+			); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &UserResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *UserResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*UserResponse](cmd, "/example.UserService/GetUser", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewUserServiceClient(conn)
-				resp, err = client.GetUser(cmdCtx, req)
+				resp, err = client.GetUser(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Load config and create service implementation
+				timings.Phase("config load")
 				// Get config paths and env prefix from root command
 				rootCmd := cmd.Root()
 				configPaths := rootCmd.StringSlice("config")
 				envPrefix := rootCmd.String("env-prefix")
 
 				// Create config loader (single-command mode = uses files + env + flags)
-				loader := protocli.NewConfigLoader(protocli.SingleCommandMode, protocli.FileConfig(configPaths...), protocli.EnvPrefix(envPrefix))
+				loader := protocli.NewConfigLoader(protocli.SingleCommandMode, protocli.FileConfig(configPaths...), protocli.EnvPrefix(envPrefix), protocli.SecretDir(rootCmd.String("secret-dir")))
 
 				// Create config instance and load configuration
 				config := &UserServiceConfig{}
@@ -903,18 +1082,32 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 				}
 
 				// Call factory to create service implementation
+				timings.Phase("service call")
 				svcImpl, err := protocli.CallFactory(implOrFactory, config)
 				if err != nil {
 					return fmt.Errorf("failed to create service: %w", err)
 				}
 
 				// Call the RPC method
-				resp, err = svcImpl.(UserServiceServer).GetUser(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "UserService.GetUser", func() (*UserResponse, error) {
+					return svcImpl.(UserServiceServer).GetUser(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.UserService/GetUser", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getUserServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -924,40 +1117,139 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
-
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
+		Aliases:     []string{"g"},
 		Description: "Fetch detailed information about a user from the database.\n\nThis command queries the user service to retrieve a user record by their unique ID. You can optionally include additional details like profile information and preferences. Use --fields to specify which fields to return in the response.\n\nExamples:\n  Get basic user info:       usercli user-service get --id 123\n  Get with details:          usercli user-service get --id 123 --include-details\n  Get specific fields:       usercli user-service get --id 123 --fields name,email",
 		Flags:       flags_get,
-		Name:        "get",
-		Usage:       "Retrieve a user by ID",
-		UsageText:   "get --id <user-id> [--include-details] [--fields <field-list>]",
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.UserService/GetUser",
+			protocli.RequestFlagsMetadataKey: []string{"id", "include-details", "fields", "timeout"},
+		},
+		Name:      "get",
+		Usage:     "Retrieve a user by ID",
+		UsageText: "get --id <user-id> [--include-details] [--fields <field-list>]",
 	})
 
+	return &protocli.ServiceCLI{
+		Command: &v3.Command{
+			Aliases:     []string{"users", "u"},
+			Commands:    commands,
+			Description: "Comprehensive user management service for CRUD operations.\n\nThis service provides complete user lifecycle management including:\n- Creating new user accounts\n- Retrieving user information\n- Updating user profiles\n- Managing user authentication and preferences\n\nAll commands require appropriate authentication and authorization.",
+			Metadata:    map[string]any{protocli.ServiceMetadataKey: "user-service"},
+			Name:        "user-service",
+			Usage:       "User management commands",
+		},
+		ConfigMessageType: "UserServiceConfig",
+		ConfigPrototype:   &UserServiceConfig{},
+		FactoryOrImpl:     implOrFactory,
+		InputFormats:      options.InputFormats(),
+		OutputFormats:     options.OutputFormats(),
+		RegisterFunc: func(s *grpc.Server, impl interface{}) {
+			RegisterUserServiceServer(s, impl.(UserServiceServer))
+		},
+		RootFlags: []v3.Flag{
+			&v3.StringFlag{
+				Aliases: []string{"t"},
+				Name:    "tenant",
+				Sources: v3.EnvVars("USERCLI_TENANT"),
+				Usage:   "Tenant to scope requests to",
+			},
+			&v3.DurationFlag{
+				Name:  "request-timeout",
+				Usage: "Timeout applied to each request",
+				Value: 30 * time.Second,
+			},
+		},
+		ServiceName: "user-service",
+		ValidateCommands: []*v3.Command{
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &CreateUserRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					// Validate flag value constraints
+					if err := errors.Join(
+						protocli.FieldConstraint{
+							Flag:    "email",
+							Pattern: userServiceCreateUserEmailPattern,
+							Set:     cmd.IsSet("email"),
+						}.Check(req.Email),
+						protocli.FieldConstraint{
+							Flag: "age",
+							Max:  proto.Float64(150.0),
+							Min:  proto.Float64(0.0),
+							Set:  cmd.IsSet("age"),
+						}.Check(req.Age),
+						protocli.FieldConstraint{
+							Flag:  "plan",
+							OneOf: []string{"free", "pro", "enterprise"},
+							Set:   cmd.IsSet("plan"),
+						}.Check(req.Plan),
+					); err != nil {
+						return err
+					}
+
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Aliases: []string{"c", "new"},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "create",
+				Usage: "Check that --input-file is a valid create request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &GetUserRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Aliases: []string{"g"},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "get",
+				Usage: "Check that --input-file is a valid get request",
+			},
+		},
+	}
+}
+
+// UserServiceCommandsFlat creates a flat command structure for UserService (for single-service CLIs)
+// This returns RPC commands directly at the root level instead of nested under a service command.
+// The implOrFactory parameter can be either a direct service implementation or a factory function
+// The returned slice includes all RPC commands plus a daemonize command for starting a gRPC server.
+func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) []*v3.Command {
+	options := protocli.ApplyServiceOptions(opts...)
+
+	// Determine default format (first registered format, or empty if none)
+	var defaultFormat string
+	if len(options.OutputFormats()) > 0 {
+		defaultFormat = options.OutputFormats()[0].Name()
+	}
+
+	var commands []*v3.Command
+
 	// Build flags for create
 	flags_create := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
@@ -970,12 +1262,34 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_create = append(flags_create, &v3.StringFlag{
@@ -1018,15 +1332,56 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 		Name:  "log-level",
 		Usage: "Optional logging level preference for the user [debug|info|warn|error]",
 	})
-
-	// Add config field flags for single-command mode
 	flags_create = append(flags_create, &v3.StringFlag{
-		Name:     "db-url",
-		Required: true,
-		Usage:    "PostgreSQL connection URL",
+		Name:  "plan",
+		Usage: "Subscription plan",
 	})
-	flags_create = append(flags_create, &v3.Int64Flag{
-		Name:  "max-conns",
+	flags_create = append(flags_create, &v3.StringFlag{
+		DefaultText: "info",
+		Name:        "notification-level",
+		Usage:       "Verbosity of account notifications [debug|info|warn|error]",
+		Value:       "info",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		DefaultText: "24h",
+		Name:        "session-ttl",
+		Usage:       "Lifetime of the user's login sessions",
+		Value:       "24h",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		Name:  "initial-password",
+		Usage: "Initial password for the account",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		DefaultText: "generated uuid",
+		Name:        "request-id",
+		Usage:       "Idempotency key for the create request",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		Name:  "bio",
+		Usage: "Short biography (@file or @- for stdin)",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		Name:  "update-mask",
+		Usage: "User fields to set, comma-separated (e.g. name,email)",
+	})
+	flags_create = append(flags_create, &v3.StringFlag{
+		Name:  "metadata",
+		Usage: "Extra typed data as <type URL>=<JSON>",
+	})
+	flags_create = append(flags_create, &v3.StringSliceFlag{
+		Name:  "previous-address",
+		Usage: "Former addresses, as @file holding a JSON or YAML array",
+	})
+
+	// Add config field flags for single-command mode
+	flags_create = append(flags_create, &v3.StringFlag{
+		Name:     "db-url",
+		Required: true,
+		Usage:    "PostgreSQL connection URL",
+	})
+	flags_create = append(flags_create, &v3.Int64Flag{
+		Name:  "max-conns",
 		Usage: "Maximum database connections",
 	})
 	flags_create = append(flags_create, &v3.StringFlag{
@@ -1039,19 +1394,22 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_create = append(flags_create, flagConfigured.Flags()...)
-		}
-	}
+	flags_create = append(flags_create, protocli.FormatFlags(options.OutputFormats())...)
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -1067,6 +1425,7 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *CreateUserRequest
 
@@ -1080,10 +1439,18 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("email") {
-					req.Email = cmd.String("email")
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "email", cmd.String("email"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --email: %w", err)
+					}
+					req.Email = val
 				}
 				if cmd.IsSet("address") {
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("example.Address"); hasFieldDeserializer {
@@ -1122,10 +1489,17 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 					}
 				}
 				if cmd.IsSet("phone-number") {
-					req.PhoneNumber = cmd.String("phone-number")
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "phone_number", cmd.String("phone-number"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --phone-number: %w", err)
+					}
+					req.PhoneNumber = val
 				}
 				if cmd.IsSet("nickname") {
-					val := cmd.String("nickname")
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "nickname", cmd.String("nickname"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --nickname: %w", err)
+					}
 					req.Nickname = &val
 				}
 				if cmd.IsSet("age") {
@@ -1143,6 +1517,111 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 					}
 					req.LogLevel = &val
 				}
+				if cmd.IsSet("plan") {
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "plan", cmd.String("plan"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --plan: %w", err)
+					}
+					req.Plan = val
+				}
+				if cmd.IsSet("notification-level") {
+					val, err := parseUserServiceLogLevel(cmd.String("notification-level"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --notification-level: %w", err)
+					}
+					req.NotificationLevel = val
+				}
+				if cmd.IsSet("session-ttl") {
+					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Duration"); hasFieldDeserializer {
+						fieldFlags := protocli.NewFlagContainer(cmd, "session-ttl")
+						fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+						if fieldErr != nil {
+							return fmt.Errorf("failed to deserialize field SessionTtl: %w", fieldErr)
+						}
+						if fieldMsg != nil {
+							typedField, fieldOk := fieldMsg.(*durationpb.Duration)
+							if !fieldOk {
+								return fmt.Errorf("custom deserializer for google.protobuf.Duration returned wrong type: expected *Duration, got %T", fieldMsg)
+							}
+							req.SessionTtl = typedField
+						}
+					} else {
+						return fmt.Errorf("flag --session-ttl requires a custom deserializer for google.protobuf.Duration (register with protocli.WithFlagDeserializer)")
+					}
+				}
+				if cmd.IsSet("initial-password") {
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "initial_password", cmd.String("initial-password"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --initial-password: %w", err)
+					}
+					req.InitialPassword = val
+				}
+				if cmd.IsSet("request-id") {
+					val, err := protocli.TransformFlagValue("example.CreateUserRequest", "request_id", cmd.String("request-id"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --request-id: %w", err)
+					}
+					req.RequestId = val
+				}
+				if cmd.IsSet("bio") {
+					val, err := protocli.ReadFileFlagValue(cmd.String("bio"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --bio: %w", err)
+					}
+					val, err = protocli.TransformFlagValue("example.CreateUserRequest", "bio", val)
+					if err != nil {
+						return fmt.Errorf("invalid value for --bio: %w", err)
+					}
+					req.Bio = val
+				}
+				if cmd.IsSet("update-mask") {
+					mask, err := protocli.ParseFieldMask(cmd.String("update-mask"), "example.User")
+					if err != nil {
+						return fmt.Errorf("invalid value for --update-mask: %w", err)
+					}
+					req.UpdateMask = mask
+				}
+				if cmd.IsSet("metadata") {
+					packed, err := protocli.ParseAny(cmd.String("metadata"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --metadata: %w", err)
+					}
+					req.Metadata = packed
+				}
+				if cmd.IsSet("previous-address") {
+					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("example.Address"); hasFieldDeserializer {
+						req.PreviousAddresses = nil
+						for _, s := range cmd.StringSlice("previous-address") {
+							elemFlags := protocli.NewStringValueFlagContainer(s, cmd)
+							elemMsg, elemErr := fieldDeserializer(cmdCtx, elemFlags)
+							if elemErr != nil {
+								return fmt.Errorf("failed to deserialize element for --previous-address: %w", elemErr)
+							}
+							if elemMsg != nil {
+								typedElem, elemOk := elemMsg.(*Address)
+								if !elemOk {
+									return fmt.Errorf("custom deserializer for example.Address returned wrong type: expected *Address, got %T", elemMsg)
+								}
+								req.PreviousAddresses = append(req.PreviousAddresses, typedElem)
+							}
+						}
+					} else {
+						req.PreviousAddresses = nil
+						for _, s := range cmd.StringSlice("previous-address") {
+							listPath, isFile := strings.CutPrefix(s, "@")
+							if !isFile {
+								return fmt.Errorf("flag --previous-address requires a custom deserializer for example.Address (register with protocli.WithFlagDeserializer) or an @file holding a JSON or YAML array")
+							}
+							elems, listErr := protocli.ReadInputFileList(listPath, options.InputFormats(), func() *Address {
+								return &Address{}
+							})
+							if listErr != nil {
+								return fmt.Errorf("invalid value for --previous-address: %w", listErr)
+							}
+							req.PreviousAddresses = append(req.PreviousAddresses, elems...)
+						}
+					}
+				}
 			} else {
 				// Check for custom flag deserializer for example.CreateUserRequest
 				deserializer, hasDeserializer := options.FlagDeserializer("example.CreateUserRequest")
@@ -1166,8 +1645,20 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 				} else {
 					// Use auto-generated flag parsing
 					req = &CreateUserRequest{}
-					req.Name = cmd.String("name")
-					req.Email = cmd.String("email")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
+					if s := cmd.String("email"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "email", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --email: %w", err)
+						}
+						req.Email = val
+					}
 					// Field Address: check for custom deserializer for example.Address
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("example.Address"); hasFieldDeserializer {
 						// Use custom deserializer for nested message
@@ -1216,78 +1707,3008 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 						}
 						// No value provided - leave field as nil
 					}
-					req.PhoneNumber = cmd.String("phone-number")
-					if cmd.IsSet("nickname") {
-						val := cmd.String("nickname")
-						req.Nickname = &val
+					if s := cmd.String("phone-number"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "phone_number", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --phone-number: %w", err)
+						}
+						req.PhoneNumber = val
+					}
+					if cmd.IsSet("nickname") {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "nickname", cmd.String("nickname"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --nickname: %w", err)
+						}
+						req.Nickname = &val
+					}
+					if cmd.IsSet("age") {
+						val := cmd.Int32("age")
+						req.Age = &val
+					}
+					if cmd.IsSet("verified") {
+						val := cmd.Bool("verified")
+						req.Verified = &val
+					}
+					if cmd.IsSet("log-level") {
+						val, err := parseUserServiceLogLevel(cmd.String("log-level"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --log-level: %w", err)
+						}
+						req.LogLevel = &val
+					}
+					if s := cmd.String("plan"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "plan", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --plan: %w", err)
+						}
+						req.Plan = val
+					}
+					if cmd.String("notification-level") != "" {
+						val, err := parseUserServiceLogLevel(cmd.String("notification-level"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --notification-level: %w", err)
+						}
+						req.NotificationLevel = val
+					}
+					// Field SessionTtl: check for custom deserializer for google.protobuf.Duration
+					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Duration"); hasFieldDeserializer {
+						// Use custom deserializer for nested message
+						// Create FlagContainer for field flag: session-ttl
+						fieldFlags := protocli.NewFlagContainer(cmd, "session-ttl")
+						fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
+						if fieldErr != nil {
+							return fmt.Errorf("failed to deserialize field SessionTtl: %w", fieldErr)
+						}
+						// Handle nil return from deserializer (means skip/use default)
+						if fieldMsg != nil {
+							typedField, fieldOk := fieldMsg.(*durationpb.Duration)
+							if !fieldOk {
+								return fmt.Errorf("custom deserializer for google.protobuf.Duration returned wrong type: expected *Duration, got %T", fieldMsg)
+							}
+							req.SessionTtl = typedField
+						}
+					} else {
+						// No custom deserializer - check if user provided a value
+						if cmd.IsSet("session-ttl") {
+							return fmt.Errorf("flag --session-ttl requires a custom deserializer for google.protobuf.Duration (register with protocli.WithFlagDeserializer)")
+						}
+						// No value provided - leave field as nil
+					}
+					if s := cmd.String("initial-password"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "initial_password", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --initial-password: %w", err)
+						}
+						req.InitialPassword = val
+					}
+					if s := cmd.String("request-id"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CreateUserRequest", "request_id", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --request-id: %w", err)
+						}
+						req.RequestId = val
+					}
+					if s := cmd.String("bio"); s != "" {
+						val, err := protocli.ReadFileFlagValue(s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --bio: %w", err)
+						}
+						val, err = protocli.TransformFlagValue("example.CreateUserRequest", "bio", val)
+						if err != nil {
+							return fmt.Errorf("invalid value for --bio: %w", err)
+						}
+						req.Bio = val
+					}
+					if cmd.IsSet("update-mask") {
+						mask, err := protocli.ParseFieldMask(cmd.String("update-mask"), "example.User")
+						if err != nil {
+							return fmt.Errorf("invalid value for --update-mask: %w", err)
+						}
+						req.UpdateMask = mask
+					}
+					if cmd.IsSet("metadata") {
+						packed, err := protocli.ParseAny(cmd.String("metadata"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --metadata: %w", err)
+						}
+						req.Metadata = packed
+					}
+					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("example.Address"); hasFieldDeserializer {
+						for _, s := range cmd.StringSlice("previous-address") {
+							elemFlags := protocli.NewStringValueFlagContainer(s, cmd)
+							elemMsg, elemErr := fieldDeserializer(cmdCtx, elemFlags)
+							if elemErr != nil {
+								return fmt.Errorf("failed to deserialize element for --previous-address: %w", elemErr)
+							}
+							if elemMsg != nil {
+								typedElem, elemOk := elemMsg.(*Address)
+								if !elemOk {
+									return fmt.Errorf("custom deserializer for example.Address returned wrong type: expected *Address, got %T", elemMsg)
+								}
+								req.PreviousAddresses = append(req.PreviousAddresses, typedElem)
+							}
+						}
+					} else {
+						for _, s := range cmd.StringSlice("previous-address") {
+							listPath, isFile := strings.CutPrefix(s, "@")
+							if !isFile {
+								return fmt.Errorf("flag --previous-address requires a custom deserializer for example.Address (register with protocli.WithFlagDeserializer) or an @file holding a JSON or YAML array")
+							}
+							elems, listErr := protocli.ReadInputFileList(listPath, options.InputFormats(), func() *Address {
+								return &Address{}
+							})
+							if listErr != nil {
+								return fmt.Errorf("invalid value for --previous-address: %w", listErr)
+							}
+							req.PreviousAddresses = append(req.PreviousAddresses, elems...)
+						}
+					}
+				}
+			}
+
+			// Generate --request-id with "uuid" when not given
+			if !cmd.IsSet("request-id") && req.GetRequestId() == "" {
+				v, err := protocli.GenerateDefault("uuid")
+				if err != nil {
+					return fmt.Errorf("failed to generate --request-id: %w", err)
+				}
+				req.RequestId = v
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.UserService/CreateUser", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Validate flag value constraints
+			if err := errors.Join(
+				protocli.FieldConstraint{
+					Flag:    "email",
+					Pattern: userServiceCreateUserEmailPattern,
+					Set:     cmd.IsSet("email"),
+				}.Check(req.Email),
+				protocli.FieldConstraint{
+					Flag: "age",
+					Max:  proto.Float64(150.0),
+					Min:  proto.Float64(0.0),
+					Set:  cmd.IsSet("age"),
+				}.Check(req.Age),
+				protocli.FieldConstraint{
+					Flag:  "plan",
+					OneOf: []string{"free", "pro", "enterprise"},
+					Set:   cmd.IsSet("plan"),
+				}.Check(req.Plan),
+			); err != nil {
+				return err
+			}
+
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(
+				cmd,
+				options.FlagRules("/example.UserService/CreateUser"),
+				protocli.RequiresFlag("nickname", "verified"),
+			); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &UserResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *UserResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*UserResponse](cmd, "/example.UserService/CreateUser", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewUserServiceClient(conn)
+				resp, err = client.CreateUser(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Load config and create service implementation
+				timings.Phase("config load")
+				// Get config paths and env prefix from root command
+				rootCmd := cmd.Root()
+				configPaths := rootCmd.StringSlice("config")
+				envPrefix := rootCmd.String("env-prefix")
+
+				// Create config loader (single-command mode = uses files + env + flags)
+				loader := protocli.NewConfigLoader(protocli.SingleCommandMode, protocli.FileConfig(configPaths...), protocli.EnvPrefix(envPrefix), protocli.SecretDir(rootCmd.String("secret-dir")))
+
+				// Create config instance and load configuration
+				config := &UserServiceConfig{}
+				if err := loader.LoadServiceConfig(cmd, "userservice", config); err != nil {
+					return fmt.Errorf("failed to load config: %w", err)
+				}
+
+				// Call factory to create service implementation
+				timings.Phase("service call")
+				svcImpl, err := protocli.CallFactory(implOrFactory, config)
+				if err != nil {
+					return fmt.Errorf("failed to create service: %w", err)
+				}
+
+				// Call the RPC method
+				resp, err = protocli.CallLocal(cmd, "UserService.CreateUser", func() (*UserResponse, error) {
+					return svcImpl.(UserServiceServer).CreateUser(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.UserService/CreateUser", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getUserServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Aliases: []string{"c", "new"},
+		Flags:   flags_create,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.UserService/CreateUser",
+			protocli.RequestFlagsMetadataKey: []string{"name", "email", "address", "registration-date", "phone-number", "nickname", "age", "verified", "log-level", "plan", "notification-level", "session-ttl", "initial-password", "request-id", "bio", "update-mask", "metadata", "previous-address"},
+		},
+		Name:  "create",
+		Usage: "Create a new user",
+	})
+
+	// Build flags for get
+	flags_get := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	flags_get = append(flags_get, &v3.Int64Flag{
+		Aliases:  []string{"i"},
+		Name:     "id",
+		Required: true,
+		Usage:    "User ID to retrieve",
+	})
+	flags_get = append(flags_get, &v3.BoolFlag{
+		Aliases: []string{"d"},
+		Name:    "include-details",
+		Usage:   "Include detailed user information",
+	})
+	flags_get = append(flags_get, &v3.StringFlag{
+		Aliases: []string{"f"},
+		Name:    "fields",
+		Usage:   "Comma-separated list of fields to return (e.g., 'name,email')",
+	})
+	flags_get = append(flags_get, &v3.Int32Flag{
+		Aliases: []string{"t"},
+		Name:    "timeout",
+		Usage:   "Request timeout in milliseconds",
+	})
+
+	// Add config field flags for single-command mode
+	flags_get = append(flags_get, &v3.StringFlag{
+		Name:     "db-url",
+		Required: true,
+		Usage:    "PostgreSQL connection URL",
+	})
+	flags_get = append(flags_get, &v3.Int64Flag{
+		Name:  "max-conns",
+		Usage: "Maximum database connections",
+	})
+	flags_get = append(flags_get, &v3.StringFlag{
+		Name:  "log-level",
+		Usage: "Logging level [debug|info|warn|error]",
+	})
+	flags_get = append(flags_get, &v3.StringSliceFlag{
+		Name:  "allowed-origins",
+		Usage: "CORS allowed origins",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_get = append(flags_get, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *GetUserRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &GetUserRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("id") {
+					req.Id = cmd.Int64("id")
+				}
+				if cmd.IsSet("include-details") {
+					req.IncludeDetails = cmd.Bool("include-details")
+				}
+				if cmd.IsSet("fields") {
+					val, err := protocli.TransformFlagValue("example.GetUserRequest", "fields_filter", cmd.String("fields"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --fields: %w", err)
+					}
+					req.FieldsFilter = &val
+				}
+				if cmd.IsSet("timeout") {
+					val := cmd.Int32("timeout")
+					req.TimeoutMs = &val
+				}
+			} else {
+				// Check for custom flag deserializer for example.GetUserRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.GetUserRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*GetUserRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "GetUserRequest", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &GetUserRequest{}
+					req.Id = cmd.Int64("id")
+					req.IncludeDetails = cmd.Bool("include-details")
+					if cmd.IsSet("fields") {
+						val, err := protocli.TransformFlagValue("example.GetUserRequest", "fields_filter", cmd.String("fields"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --fields: %w", err)
+						}
+						req.FieldsFilter = &val
+					}
+					if cmd.IsSet("timeout") {
+						val := cmd.Int32("timeout")
+						req.TimeoutMs = &val
+					}
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.UserService/GetUser", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(
+				cmd,
+				options.FlagRules("/example.UserService/GetUser"),
+				protocli.ConflictingFlags("fields", "include-details"),
+			); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &UserResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *UserResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*UserResponse](cmd, "/example.UserService/GetUser", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewUserServiceClient(conn)
+				resp, err = client.GetUser(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Load config and create service implementation
+				timings.Phase("config load")
+				// Get config paths and env prefix from root command
+				rootCmd := cmd.Root()
+				configPaths := rootCmd.StringSlice("config")
+				envPrefix := rootCmd.String("env-prefix")
+
+				// Create config loader (single-command mode = uses files + env + flags)
+				loader := protocli.NewConfigLoader(protocli.SingleCommandMode, protocli.FileConfig(configPaths...), protocli.EnvPrefix(envPrefix), protocli.SecretDir(rootCmd.String("secret-dir")))
+
+				// Create config instance and load configuration
+				config := &UserServiceConfig{}
+				if err := loader.LoadServiceConfig(cmd, "userservice", config); err != nil {
+					return fmt.Errorf("failed to load config: %w", err)
+				}
+
+				// Call factory to create service implementation
+				timings.Phase("service call")
+				svcImpl, err := protocli.CallFactory(implOrFactory, config)
+				if err != nil {
+					return fmt.Errorf("failed to create service: %w", err)
+				}
+
+				// Call the RPC method
+				resp, err = protocli.CallLocal(cmd, "UserService.GetUser", func() (*UserResponse, error) {
+					return svcImpl.(UserServiceServer).GetUser(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.UserService/GetUser", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getUserServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Aliases:     []string{"g"},
+		Description: "Fetch detailed information about a user from the database.\n\nThis command queries the user service to retrieve a user record by their unique ID. You can optionally include additional details like profile information and preferences. Use --fields to specify which fields to return in the response.\n\nExamples:\n  Get basic user info:       usercli user-service get --id 123\n  Get with details:          usercli user-service get --id 123 --include-details\n  Get specific fields:       usercli user-service get --id 123 --fields name,email",
+		Flags:       flags_get,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.UserService/GetUser",
+			protocli.RequestFlagsMetadataKey: []string{"id", "include-details", "fields", "timeout"},
+		},
+		Name:      "get",
+		Usage:     "Retrieve a user by ID",
+		UsageText: "get --id <user-id> [--include-details] [--fields <field-list>]",
+	})
+
+	// Create ServiceCLI for daemonize command
+	serviceCLI := &protocli.ServiceCLI{
+		ConfigMessageType: "UserServiceConfig",
+		ConfigPrototype:   &UserServiceConfig{},
+		FactoryOrImpl:     implOrFactory,
+		RegisterFunc: func(s *grpc.Server, impl interface{}) {
+			RegisterUserServiceServer(s, impl.(UserServiceServer))
+		},
+		ServiceName: "user-service",
+	}
+
+	// Create daemonize command for starting gRPC server
+	daemonCmd := protocli.NewDaemonizeCommand(ctx, []*protocli.ServiceCLI{serviceCLI}, options)
+
+	// Append daemonize command to the list
+	commands = append(commands, daemonCmd)
+
+	return commands
+}
+
+// NewUserServiceCLIClient dials the remote given by WithRemote or resolved from a
+// command's --remote and --context flags (WithRemoteCommand), applying TLS,
+// retry and auth options, and returns the UserServiceClient. The caller must close the connection.
+func NewUserServiceCLIClient(opts ...protocli.ClientOption) (UserServiceClient, *grpc.ClientConn, error) {
+	conn, err := protocli.DialRemote(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewUserServiceClient(conn), conn, nil
+}
+
+// getAdminServiceOutputWriter opens the specified output file or returns cmd.Writer (if set) or stdout
+func getAdminServiceOutputWriter(cmd *v3.Command, path string) (io.Writer, error) {
+	if path == "-" || path == "" {
+		// Use cmd.Writer if set, otherwise try root command's Writer, otherwise stdout
+		if cmd.Writer != nil {
+			return cmd.Writer, nil
+		}
+		if cmd.Root().Writer != nil {
+			return cmd.Root().Writer, nil
+		}
+		return os.Stdout, nil
+	}
+	path, err := protocli.OutputPath(cmd, path)
+	if err != nil {
+		return nil, err
+	}
+	return protocli.OpenOutputFile(path, cmd.String("output-mode"), cmd.Bool("output-append"))
+}
+
+// AdminServiceCommand creates a CLI for AdminService with options
+// The implOrFactory parameter can be either a direct service implementation or a factory function
+func AdminServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) *protocli.ServiceCLI {
+	options := protocli.ApplyServiceOptions(opts...)
+
+	// Determine default format (first registered format, or empty if none)
+	var defaultFormat string
+	if len(options.OutputFormats()) > 0 {
+		defaultFormat = options.OutputFormats()[0].Name()
+	}
+
+	var commands []*v3.Command
+
+	// Build flags for health
+	flags_health := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	flags_health = append(flags_health, &v3.BoolFlag{
+		Name:  "verbose",
+		Usage: "Include extra detail in the response (deprecated: responses are always detailed)",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_health = append(flags_health, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			protocli.WarnDeprecatedFlag(cmd, "verbose", "responses are always detailed")
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *AdminRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &AdminRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("verbose") {
+					req.Verbose = cmd.Bool("verbose")
+				}
+			} else {
+				// Check for custom flag deserializer for example.AdminRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.AdminRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*AdminRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "AdminRequest", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &AdminRequest{}
+					req.Verbose = cmd.Bool("verbose")
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/HealthCheck", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/HealthCheck")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &AdminResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AdminResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*AdminResponse](cmd, "/example.AdminService/HealthCheck", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewAdminServiceClient(conn)
+				resp, err = client.HealthCheck(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(AdminServiceServer)
+				resp, err = protocli.CallLocal(cmd, "AdminService.HealthCheck", func() (*AdminResponse, error) {
+					return svcImpl.HealthCheck(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/HealthCheck", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Flags: flags_health,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/HealthCheck",
+			protocli.RequestFlagsMetadataKey: []string{"verbose"},
+		},
+		Name:  "health",
+		Usage: "Check service health",
+	})
+
+	// Build flags for ping
+	flags_ping := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	flags_ping = append(flags_ping, &v3.BoolFlag{
+		Name:  "verbose",
+		Usage: "Include extra detail in the response (deprecated: responses are always detailed)",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_ping = append(flags_ping, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			protocli.WarnDeprecatedCommand(cmd, "use health instead")
+			protocli.WarnDeprecatedFlag(cmd, "verbose", "responses are always detailed")
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *AdminRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &AdminRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("verbose") {
+					req.Verbose = cmd.Bool("verbose")
+				}
+			} else {
+				// Check for custom flag deserializer for example.AdminRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.AdminRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*AdminRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "AdminRequest", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &AdminRequest{}
+					req.Verbose = cmd.Bool("verbose")
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/Ping", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/Ping")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &AdminResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AdminResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*AdminResponse](cmd, "/example.AdminService/Ping", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewAdminServiceClient(conn)
+				resp, err = client.Ping(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(AdminServiceServer)
+				resp, err = protocli.CallLocal(cmd, "AdminService.Ping", func() (*AdminResponse, error) {
+					return svcImpl.Ping(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/Ping", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Flags: flags_ping,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/Ping",
+			protocli.RequestFlagsMetadataKey: []string{"verbose"},
+		},
+		Name:  "ping",
+		Usage: "Check service liveness (deprecated: use health instead)",
+	})
+
+	// Build flags for diagnostics
+	flags_diagnostics := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	flags_diagnostics = append(flags_diagnostics, &v3.BoolFlag{
+		Name:  "verbose",
+		Usage: "Include extra detail in the response (deprecated: responses are always detailed)",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_diagnostics = append(flags_diagnostics, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			protocli.WarnDeprecatedFlag(cmd, "verbose", "responses are always detailed")
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *AdminRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &AdminRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("verbose") {
+					req.Verbose = cmd.Bool("verbose")
+				}
+			} else {
+				// Check for custom flag deserializer for example.AdminRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.AdminRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*AdminRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "AdminRequest", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &AdminRequest{}
+					req.Verbose = cmd.Bool("verbose")
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/Diagnostics", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/Diagnostics")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &AdminResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AdminResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*AdminResponse](cmd, "/example.AdminService/Diagnostics", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewAdminServiceClient(conn)
+				resp, err = client.Diagnostics(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(AdminServiceServer)
+				resp, err = protocli.CallLocal(cmd, "AdminService.Diagnostics", func() (*AdminResponse, error) {
+					return svcImpl.Diagnostics(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/Diagnostics", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Flags:  flags_diagnostics,
+		Hidden: true,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/Diagnostics",
+			protocli.RequestFlagsMetadataKey: []string{"verbose"},
+		},
+		Name:  "diagnostics",
+		Usage: "Dump internal diagnostics",
+	})
+
+	// Build flags for check
+	flags_check := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	flags_check = append(flags_check, &v3.StringFlag{
+		Name:  "name",
+		Usage: "Name of the check to run",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_check = append(flags_check, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *CheckRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &CheckRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("name") {
+					val, err := protocli.TransformFlagValue("example.CheckRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
+				}
+			} else {
+				// Check for custom flag deserializer for example.CheckRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.CheckRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*CheckRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "CheckRequest", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &CheckRequest{}
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CheckRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/RunCheck", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/RunCheck")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &CheckResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *CheckResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*CheckResponse](cmd, "/example.AdminService/RunCheck", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewAdminServiceClient(conn)
+				resp, err = client.RunCheck(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(AdminServiceServer)
+				resp, err = protocli.CallLocal(cmd, "AdminService.RunCheck", func() (*CheckResponse, error) {
+					return svcImpl.RunCheck(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/RunCheck", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			if err := protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp); err != nil {
+				return err
+			}
+
+			// Exit with the response's status_code (exit_code_field)
+			return protocli.ResponseExitCode(resp, "status_code")
+		},
+		Flags: flags_check,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/RunCheck",
+			protocli.RequestFlagsMetadataKey: []string{"name"},
+		},
+		Name:  "check",
+		Usage: "Run a named check and exit with its status code",
+	})
+
+	// Build flags for purge-cache
+	flags_purge_cache := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}, &v3.BoolFlag{
+		Name:  "yes",
+		Usage: "Run without asking for confirmation (required when stdin is not a terminal)",
+	}}
+
+	flags_purge_cache = append(flags_purge_cache, &v3.BoolFlag{
+		Name:  "verbose",
+		Usage: "Include extra detail in the response (deprecated: responses are always detailed)",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_purge_cache = append(flags_purge_cache, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			protocli.WarnDeprecatedFlag(cmd, "verbose", "responses are always detailed")
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *AdminRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &AdminRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("verbose") {
+					req.Verbose = cmd.Bool("verbose")
+				}
+			} else {
+				// Check for custom flag deserializer for example.AdminRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.AdminRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*AdminRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "AdminRequest", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &AdminRequest{}
+					req.Verbose = cmd.Bool("verbose")
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/PurgeCache", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/PurgeCache")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &AdminResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AdminResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*AdminResponse](cmd, "/example.AdminService/PurgeCache", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewAdminServiceClient(conn)
+				resp, err = client.PurgeCache(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(AdminServiceServer)
+				resp, err = protocli.CallLocal(cmd, "AdminService.PurgeCache", func() (*AdminResponse, error) {
+					return svcImpl.PurgeCache(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/PurgeCache", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Before: protocli.ConfirmBefore,
+		Flags:  flags_purge_cache,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/PurgeCache",
+			protocli.RequestFlagsMetadataKey: []string{"verbose"},
+		},
+		Name:  "purge-cache",
+		Usage: "Drop all cached data",
+	})
+
+	// Build flags for run
+	flags_run := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	flags_run = append(flags_run, &v3.StringSliceFlag{
+		Name:  "args",
+		Usage: "Command and its arguments (usually given after --)",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_run = append(flags_run, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *ExecRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &ExecRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("args") {
+					req.Args = cmd.StringSlice("args")
+				}
+			} else {
+				// Check for custom flag deserializer for example.ExecRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.ExecRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*ExecRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ExecRequest", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &ExecRequest{}
+					req.Args = cmd.StringSlice("args")
+				}
+			}
+
+			// Positional arguments (everything after "--") go to the passthrough field
+			if passthrough := cmd.Args().Slice(); len(passthrough) > 0 {
+				req.Args = passthrough
+			}
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/Exec", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/Exec")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ExecResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *ExecResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*ExecResponse](cmd, "/example.AdminService/Exec", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewAdminServiceClient(conn)
+				resp, err = client.Exec(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(AdminServiceServer)
+				resp, err = protocli.CallLocal(cmd, "AdminService.Exec", func() (*ExecResponse, error) {
+					return svcImpl.Exec(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/Exec", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Flags: flags_run,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/Exec",
+			protocli.RequestFlagsMetadataKey: []string{"args"},
+		},
+		Name:  "run",
+		Usage: "Run a command on the server",
+	})
+
+	return &protocli.ServiceCLI{
+		Command: &v3.Command{
+			Commands: commands,
+			Metadata: map[string]any{protocli.ServiceMetadataKey: "admin"},
+			Name:     "admin",
+			Usage:    "Administrative operations",
+		},
+		ConfigMessageType: "",
+		FactoryOrImpl:     implOrFactory,
+		InputFormats:      options.InputFormats(),
+		OutputFormats:     options.OutputFormats(),
+		RegisterFunc: func(s *grpc.Server, impl interface{}) {
+			RegisterAdminServiceServer(s, impl.(AdminServiceServer))
+		},
+		RootFlags: []v3.Flag{
+			&v3.StringFlag{
+				Aliases: []string{"t"},
+				Name:    "tenant",
+				Sources: v3.EnvVars("USERCLI_TENANT"),
+				Usage:   "Tenant to scope requests to",
+			},
+			&v3.DurationFlag{
+				Name:  "request-timeout",
+				Usage: "Timeout applied to each request",
+				Value: 30 * time.Second,
+			},
+		},
+		ServiceName: "admin",
+		ValidateCommands: []*v3.Command{
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &AdminRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "health",
+				Usage: "Check that --input-file is a valid health request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &AdminRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "ping",
+				Usage: "Check that --input-file is a valid ping request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &AdminRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Hidden: true,
+				Name:   "diagnostics",
+				Usage:  "Check that --input-file is a valid diagnostics request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &CheckRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "check",
+				Usage: "Check that --input-file is a valid check request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &AdminRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "purge-cache",
+				Usage: "Check that --input-file is a valid purge-cache request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &ExecRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "run",
+				Usage: "Check that --input-file is a valid run request",
+			},
+		},
+	}
+}
+
+// AdminServiceCommandsFlat creates a flat command structure for AdminService (for single-service CLIs)
+// This returns RPC commands directly at the root level instead of nested under a service command.
+// The implOrFactory parameter can be either a direct service implementation or a factory function
+// The returned slice includes all RPC commands plus a daemonize command for starting a gRPC server.
+func AdminServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) []*v3.Command {
+	options := protocli.ApplyServiceOptions(opts...)
+
+	// Determine default format (first registered format, or empty if none)
+	var defaultFormat string
+	if len(options.OutputFormats()) > 0 {
+		defaultFormat = options.OutputFormats()[0].Name()
+	}
+
+	var commands []*v3.Command
+
+	// Build flags for health
+	flags_health := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	flags_health = append(flags_health, &v3.BoolFlag{
+		Name:  "verbose",
+		Usage: "Include extra detail in the response (deprecated: responses are always detailed)",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_health = append(flags_health, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			protocli.WarnDeprecatedFlag(cmd, "verbose", "responses are always detailed")
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *AdminRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &AdminRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("verbose") {
+					req.Verbose = cmd.Bool("verbose")
+				}
+			} else {
+				// Check for custom flag deserializer for example.AdminRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.AdminRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*AdminRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "AdminRequest", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &AdminRequest{}
+					req.Verbose = cmd.Bool("verbose")
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/HealthCheck", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/HealthCheck")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &AdminResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AdminResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*AdminResponse](cmd, "/example.AdminService/HealthCheck", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewAdminServiceClient(conn)
+				resp, err = client.HealthCheck(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(AdminServiceServer)
+				resp, err = protocli.CallLocal(cmd, "AdminService.HealthCheck", func() (*AdminResponse, error) {
+					return svcImpl.HealthCheck(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/HealthCheck", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Flags: flags_health,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/HealthCheck",
+			protocli.RequestFlagsMetadataKey: []string{"verbose"},
+		},
+		Name:  "health",
+		Usage: "Check service health",
+	})
+
+	// Build flags for ping
+	flags_ping := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	flags_ping = append(flags_ping, &v3.BoolFlag{
+		Name:  "verbose",
+		Usage: "Include extra detail in the response (deprecated: responses are always detailed)",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_ping = append(flags_ping, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			protocli.WarnDeprecatedCommand(cmd, "use health instead")
+			protocli.WarnDeprecatedFlag(cmd, "verbose", "responses are always detailed")
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *AdminRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &AdminRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("verbose") {
+					req.Verbose = cmd.Bool("verbose")
+				}
+			} else {
+				// Check for custom flag deserializer for example.AdminRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.AdminRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*AdminRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "AdminRequest", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &AdminRequest{}
+					req.Verbose = cmd.Bool("verbose")
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/Ping", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/Ping")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &AdminResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AdminResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*AdminResponse](cmd, "/example.AdminService/Ping", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewAdminServiceClient(conn)
+				resp, err = client.Ping(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(AdminServiceServer)
+				resp, err = protocli.CallLocal(cmd, "AdminService.Ping", func() (*AdminResponse, error) {
+					return svcImpl.Ping(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/Ping", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Flags: flags_ping,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/Ping",
+			protocli.RequestFlagsMetadataKey: []string{"verbose"},
+		},
+		Name:  "ping",
+		Usage: "Check service liveness (deprecated: use health instead)",
+	})
+
+	// Build flags for diagnostics
+	flags_diagnostics := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	flags_diagnostics = append(flags_diagnostics, &v3.BoolFlag{
+		Name:  "verbose",
+		Usage: "Include extra detail in the response (deprecated: responses are always detailed)",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_diagnostics = append(flags_diagnostics, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			protocli.WarnDeprecatedFlag(cmd, "verbose", "responses are always detailed")
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *AdminRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &AdminRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("verbose") {
+					req.Verbose = cmd.Bool("verbose")
+				}
+			} else {
+				// Check for custom flag deserializer for example.AdminRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.AdminRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*AdminRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "AdminRequest", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &AdminRequest{}
+					req.Verbose = cmd.Bool("verbose")
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/Diagnostics", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/Diagnostics")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &AdminResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *AdminResponse
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*AdminResponse](cmd, "/example.AdminService/Diagnostics", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewAdminServiceClient(conn)
+				resp, err = client.Diagnostics(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(AdminServiceServer)
+				resp, err = protocli.CallLocal(cmd, "AdminService.Diagnostics", func() (*AdminResponse, error) {
+					return svcImpl.Diagnostics(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/Diagnostics", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Flags:  flags_diagnostics,
+		Hidden: true,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/Diagnostics",
+			protocli.RequestFlagsMetadataKey: []string{"verbose"},
+		},
+		Name:  "diagnostics",
+		Usage: "Dump internal diagnostics",
+	})
+
+	// Build flags for check
+	flags_check := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	flags_check = append(flags_check, &v3.StringFlag{
+		Name:  "name",
+		Usage: "Name of the check to run",
+	})
+
+	// Add format-specific flags from registered formats
+	flags_check = append(flags_check, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *CheckRequest
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &CheckRequest{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("name") {
+					val, err := protocli.TransformFlagValue("example.CheckRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
+				}
+			} else {
+				// Check for custom flag deserializer for example.CheckRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.CheckRequest")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
 					}
-					if cmd.IsSet("age") {
-						val := cmd.Int32("age")
-						req.Age = &val
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
 					}
-					if cmd.IsSet("verified") {
-						val := cmd.Bool("verified")
-						req.Verified = &val
+					var ok bool
+					req, ok = msg.(*CheckRequest)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "CheckRequest", msg)
 					}
-					if cmd.IsSet("log-level") {
-						val, err := parseUserServiceLogLevel(cmd.String("log-level"))
+				} else {
+					// Use auto-generated flag parsing
+					req = &CheckRequest{}
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("example.CheckRequest", "name", s)
 						if err != nil {
-							return fmt.Errorf("invalid value for --log-level: %w", err)
+							return fmt.Errorf("invalid value for --name: %w", err)
 						}
-						req.LogLevel = &val
+						req.Name = val
 					}
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/RunCheck", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/RunCheck")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &CheckResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
-			var resp *UserResponse
+			var resp *CheckResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*CheckResponse](cmd, "/example.AdminService/RunCheck", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
-				client := NewUserServiceClient(conn)
-				resp, err = client.CreateUser(cmdCtx, req)
+				client := NewAdminServiceClient(conn)
+				resp, err = client.RunCheck(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
-				// Load config and create service implementation
-				// Get config paths and env prefix from root command
-				rootCmd := cmd.Root()
-				configPaths := rootCmd.StringSlice("config")
-				envPrefix := rootCmd.String("env-prefix")
-
-				// Create config loader (single-command mode = uses files + env + flags)
-				loader := protocli.NewConfigLoader(protocli.SingleCommandMode, protocli.FileConfig(configPaths...), protocli.EnvPrefix(envPrefix))
-
-				// Create config instance and load configuration
-				config := &UserServiceConfig{}
-				if err := loader.LoadServiceConfig(cmd, "userservice", config); err != nil {
-					return fmt.Errorf("failed to load config: %w", err)
-				}
-
-				// Call factory to create service implementation
-				svcImpl, err := protocli.CallFactory(implOrFactory, config)
-				if err != nil {
-					return fmt.Errorf("failed to create service: %w", err)
-				}
-
-				// Call the RPC method
-				resp, err = svcImpl.(UserServiceServer).CreateUser(cmdCtx, req)
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(AdminServiceServer)
+				resp, err = protocli.CallLocal(cmd, "AdminService.RunCheck", func() (*CheckResponse, error) {
+					return svcImpl.RunCheck(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/RunCheck", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
-			outputWriter, err := getUserServiceOutputWriter(cmd, cmd.String("output"))
+			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
 				return fmt.Errorf("failed to open output: %w", err)
 			}
@@ -1295,88 +4716,28 @@ func UserServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opt
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			if err := protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp); err != nil {
+				return err
 			}
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Exit with the response's status_code (exit_code_field)
+			return protocli.ResponseExitCode(resp, "status_code")
 		},
-		Flags: flags_create,
-		Name:  "create",
-		Usage: "Create a new user",
-	})
-
-	// Create ServiceCLI for daemonize command
-	serviceCLI := &protocli.ServiceCLI{
-		ConfigMessageType: "UserServiceConfig",
-		ConfigPrototype:   &UserServiceConfig{},
-		FactoryOrImpl:     implOrFactory,
-		RegisterFunc: func(s *grpc.Server, impl interface{}) {
-			RegisterUserServiceServer(s, impl.(UserServiceServer))
+		Flags: flags_check,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/RunCheck",
+			protocli.RequestFlagsMetadataKey: []string{"name"},
 		},
-		ServiceName: "user-service",
-	}
-
-	// Create daemonize command for starting gRPC server
-	daemonCmd := protocli.NewDaemonizeCommand(ctx, []*protocli.ServiceCLI{serviceCLI}, options)
-
-	// Append daemonize command to the list
-	commands = append(commands, daemonCmd)
-
-	return commands
-}
-
-// getAdminServiceOutputWriter opens the specified output file or returns cmd.Writer (if set) or stdout
-func getAdminServiceOutputWriter(cmd *v3.Command, path string) (io.Writer, error) {
-	if path == "-" || path == "" {
-		// Use cmd.Writer if set, otherwise try root command's Writer, otherwise stdout
-		if cmd.Writer != nil {
-			return cmd.Writer, nil
-		}
-		if cmd.Root().Writer != nil {
-			return cmd.Root().Writer, nil
-		}
-		return os.Stdout, nil
-	}
-	return os.Create(path)
-}
-
-// AdminServiceCommand creates a CLI for AdminService with options
-// The implOrFactory parameter can be either a direct service implementation or a factory function
-func AdminServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) *protocli.ServiceCLI {
-	options := protocli.ApplyServiceOptions(opts...)
-
-	// Determine default format (first registered format, or empty if none)
-	var defaultFormat string
-	if len(options.OutputFormats()) > 0 {
-		defaultFormat = options.OutputFormats()[0].Name()
-	}
-
-	var commands []*v3.Command
+		Name:  "check",
+		Usage: "Run a named check and exit with its status code",
+	})
 
-	// Build flags for health
-	flags_health := []v3.Flag{&v3.StringFlag{
+	// Build flags for purge-cache
+	flags_purge_cache := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
 	}, &v3.StringFlag{
@@ -1387,28 +4748,63 @@ func AdminServiceCommand(ctx context.Context, implOrFactory interface{}, opts ..
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}, &v3.BoolFlag{
+		Name:  "yes",
+		Usage: "Run without asking for confirmation (required when stdin is not a terminal)",
 	}}
 
+	flags_purge_cache = append(flags_purge_cache, &v3.BoolFlag{
+		Name:  "verbose",
+		Usage: "Include extra detail in the response (deprecated: responses are always detailed)",
+	})
+
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_health = append(flags_health, flagConfigured.Flags()...)
-		}
-	}
+	flags_purge_cache = append(flags_purge_cache, protocli.FormatFlags(options.OutputFormats())...)
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			protocli.WarnDeprecatedFlag(cmd, "verbose", "responses are always detailed")
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -1424,6 +4820,7 @@ func AdminServiceCommand(ctx context.Context, implOrFactory interface{}, opts ..
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *AdminRequest
 
@@ -1436,6 +4833,9 @@ func AdminServiceCommand(ctx context.Context, implOrFactory interface{}, opts ..
 					return err
 				}
 				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("verbose") {
+					req.Verbose = cmd.Bool("verbose")
+				}
 			} else {
 				// Check for custom flag deserializer for example.AdminRequest
 				deserializer, hasDeserializer := options.FlagDeserializer("example.AdminRequest")
@@ -1459,36 +4859,79 @@ func AdminServiceCommand(ctx context.Context, implOrFactory interface{}, opts ..
 				} else {
 					// Use auto-generated flag parsing
 					req = &AdminRequest{}
+					req.Verbose = cmd.Bool("verbose")
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/PurgeCache", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/PurgeCache")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &AdminResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *AdminResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*AdminResponse](cmd, "/example.AdminService/PurgeCache", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewAdminServiceClient(conn)
-				resp, err = client.HealthCheck(cmdCtx, req)
+				resp, err = client.PurgeCache(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(AdminServiceServer)
-				resp, err = svcImpl.HealthCheck(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "AdminService.PurgeCache", func() (*AdminResponse, error) {
+					return svcImpl.PurgeCache(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/PurgeCache", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -1498,70 +4941,24 @@ func AdminServiceCommand(ctx context.Context, implOrFactory interface{}, opts ..
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
-
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
-		},
-		Flags: flags_health,
-		Name:  "health",
-		Usage: "Check service health",
-	})
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-	return &protocli.ServiceCLI{
-		Command: &v3.Command{
-			Commands: commands,
-			Name:     "admin",
-			Usage:    "Administrative operations",
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
-		ConfigMessageType: "",
-		FactoryOrImpl:     implOrFactory,
-		RegisterFunc: func(s *grpc.Server, impl interface{}) {
-			RegisterAdminServiceServer(s, impl.(AdminServiceServer))
+		Before: protocli.ConfirmBefore,
+		Flags:  flags_purge_cache,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/PurgeCache",
+			protocli.RequestFlagsMetadataKey: []string{"verbose"},
 		},
-		ServiceName: "admin",
-	}
-}
-
-// AdminServiceCommandsFlat creates a flat command structure for AdminService (for single-service CLIs)
-// This returns RPC commands directly at the root level instead of nested under a service command.
-// The implOrFactory parameter can be either a direct service implementation or a factory function
-// The returned slice includes all RPC commands plus a daemonize command for starting a gRPC server.
-func AdminServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) []*v3.Command {
-	options := protocli.ApplyServiceOptions(opts...)
-
-	// Determine default format (first registered format, or empty if none)
-	var defaultFormat string
-	if len(options.OutputFormats()) > 0 {
-		defaultFormat = options.OutputFormats()[0].Name()
-	}
-
-	var commands []*v3.Command
+		Name:  "purge-cache",
+		Usage: "Drop all cached data",
+	})
 
-	// Build flags for health
-	flags_health := []v3.Flag{&v3.StringFlag{
+	// Build flags for run
+	flags_run := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
 	}, &v3.StringFlag{
@@ -1572,28 +4969,55 @@ func AdminServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, op
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
+	flags_run = append(flags_run, &v3.StringSliceFlag{
+		Name:  "args",
+		Usage: "Command and its arguments (usually given after --)",
+	})
+
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_health = append(flags_health, flagConfigured.Flags()...)
-		}
-	}
+	flags_run = append(flags_run, protocli.FormatFlags(options.OutputFormats())...)
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -1609,21 +5033,25 @@ func AdminServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, op
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
-			var req *AdminRequest
+			var req *ExecRequest
 
 			// Check for file-based input
 			inputFile := cmd.String("input-file")
 			if inputFile != "" {
 				// Read request from file
-				req = &AdminRequest{}
+				req = &ExecRequest{}
 				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
 					return err
 				}
 				// Apply flag overrides (only explicitly-set flags)
+				if cmd.IsSet("args") {
+					req.Args = cmd.StringSlice("args")
+				}
 			} else {
-				// Check for custom flag deserializer for example.AdminRequest
-				deserializer, hasDeserializer := options.FlagDeserializer("example.AdminRequest")
+				// Check for custom flag deserializer for example.ExecRequest
+				deserializer, hasDeserializer := options.FlagDeserializer("example.ExecRequest")
 				if hasDeserializer {
 					// Use custom deserializer for top-level request
 					// Create FlagContainer (deserializer can access multiple flags via Command())
@@ -1637,43 +5065,90 @@ func AdminServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, op
 						return fmt.Errorf("custom deserializer returned nil message")
 					}
 					var ok bool
-					req, ok = msg.(*AdminRequest)
+					req, ok = msg.(*ExecRequest)
 					if !ok {
-						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "AdminRequest", msg)
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "ExecRequest", msg)
 					}
 				} else {
 					// Use auto-generated flag parsing
-					req = &AdminRequest{}
+					req = &ExecRequest{}
+					req.Args = cmd.StringSlice("args")
 				}
 			}
 
+			// Positional arguments (everything after "--") go to the passthrough field
+			if passthrough := cmd.Args().Slice(); len(passthrough) > 0 {
+				req.Args = passthrough
+			}
+			protocli.RecordAuditRequest(cmd, "/example.AdminService/Exec", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/example.AdminService/Exec")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ExecResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
-			var resp *AdminResponse
+			var resp *ExecResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*ExecResponse](cmd, "/example.AdminService/Exec", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewAdminServiceClient(conn)
-				resp, err = client.HealthCheck(cmdCtx, req)
+				resp, err = client.Exec(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(AdminServiceServer)
-				resp, err = svcImpl.HealthCheck(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "AdminService.Exec", func() (*ExecResponse, error) {
+					return svcImpl.Exec(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/example.AdminService/Exec", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getAdminServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -1683,36 +5158,19 @@ func AdminServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, op
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
-
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
-		Flags: flags_health,
-		Name:  "health",
-		Usage: "Check service health",
+		Flags: flags_run,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/example.AdminService/Exec",
+			protocli.RequestFlagsMetadataKey: []string{"args"},
+		},
+		Name:  "run",
+		Usage: "Run a command on the server",
 	})
 
 	// Create ServiceCLI for daemonize command
@@ -1733,3 +5191,23 @@ func AdminServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, op
 
 	return commands
 }
+
+// NewAdminServiceCLIClient dials the remote given by WithRemote or resolved from a
+// command's --remote and --context flags (WithRemoteCommand), applying TLS,
+// retry and auth options, and returns the AdminServiceClient. The caller must close the connection.
+func NewAdminServiceCLIClient(opts ...protocli.ClientOption) (AdminServiceClient, *grpc.ClientConn, error) {
+	conn, err := protocli.DialRemote(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewAdminServiceClient(conn), conn, nil
+}
+
+// BuildAllServicesCLI creates a root command with every service in this file registered.
+// Each impl parameter can be either a direct service implementation or a factory function.
+// Additional root options are applied after the service registrations.
+func BuildAllServicesCLI(ctx context.Context, appName string, userServiceImpl interface{}, adminServiceImpl interface{}, opts ...protocli.RootOption) (*v3.Command, error) {
+	rootOpts := []protocli.RootOption{protocli.Service(UserServiceCommand(ctx, userServiceImpl)), protocli.Service(AdminServiceCommand(ctx, adminServiceImpl))}
+	rootOpts = append(rootOpts, opts...)
+	return protocli.RootCommand(appName, rootOpts...)
+}
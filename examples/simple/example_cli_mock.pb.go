@@ -0,0 +1,110 @@
+// Code generated by protoc-gen-cli. DO NOT EDIT.
+
+package simple
+
+import (
+	"context"
+	protocli "github.com/drewfead/proto-cli"
+)
+
+// MockUserServiceServer is an in-memory UserServiceServer for tests. Set a method's Func field
+// to return a canned response; methods without one return codes.Unimplemented.
+// Every call is recorded and can be inspected with Calls or CallsTo.
+type MockUserServiceServer struct {
+	UnimplementedUserServiceServer
+	protocli.CallRecorder
+
+	GetUserFunc    func(ctx context.Context, req *GetUserRequest) (*UserResponse, error)
+	CreateUserFunc func(ctx context.Context, req *CreateUserRequest) (*UserResponse, error)
+}
+
+var _ UserServiceServer = (*MockUserServiceServer)(nil)
+
+// GetUser records the call and invokes GetUserFunc when set.
+func (m *MockUserServiceServer) GetUser(ctx context.Context, req *GetUserRequest) (*UserResponse, error) {
+	m.Record("GetUser", req)
+	if m.GetUserFunc != nil {
+		return m.GetUserFunc(ctx, req)
+	}
+	return m.UnimplementedUserServiceServer.GetUser(ctx, req)
+}
+
+// CreateUser records the call and invokes CreateUserFunc when set.
+func (m *MockUserServiceServer) CreateUser(ctx context.Context, req *CreateUserRequest) (*UserResponse, error) {
+	m.Record("CreateUser", req)
+	if m.CreateUserFunc != nil {
+		return m.CreateUserFunc(ctx, req)
+	}
+	return m.UnimplementedUserServiceServer.CreateUser(ctx, req)
+}
+
+// MockAdminServiceServer is an in-memory AdminServiceServer for tests. Set a method's Func field
+// to return a canned response; methods without one return codes.Unimplemented.
+// Every call is recorded and can be inspected with Calls or CallsTo.
+type MockAdminServiceServer struct {
+	UnimplementedAdminServiceServer
+	protocli.CallRecorder
+
+	HealthCheckFunc func(ctx context.Context, req *AdminRequest) (*AdminResponse, error)
+	PingFunc        func(ctx context.Context, req *AdminRequest) (*AdminResponse, error)
+	DiagnosticsFunc func(ctx context.Context, req *AdminRequest) (*AdminResponse, error)
+	RunCheckFunc    func(ctx context.Context, req *CheckRequest) (*CheckResponse, error)
+	PurgeCacheFunc  func(ctx context.Context, req *AdminRequest) (*AdminResponse, error)
+	ExecFunc        func(ctx context.Context, req *ExecRequest) (*ExecResponse, error)
+}
+
+var _ AdminServiceServer = (*MockAdminServiceServer)(nil)
+
+// HealthCheck records the call and invokes HealthCheckFunc when set.
+func (m *MockAdminServiceServer) HealthCheck(ctx context.Context, req *AdminRequest) (*AdminResponse, error) {
+	m.Record("HealthCheck", req)
+	if m.HealthCheckFunc != nil {
+		return m.HealthCheckFunc(ctx, req)
+	}
+	return m.UnimplementedAdminServiceServer.HealthCheck(ctx, req)
+}
+
+// Ping records the call and invokes PingFunc when set.
+func (m *MockAdminServiceServer) Ping(ctx context.Context, req *AdminRequest) (*AdminResponse, error) {
+	m.Record("Ping", req)
+	if m.PingFunc != nil {
+		return m.PingFunc(ctx, req)
+	}
+	return m.UnimplementedAdminServiceServer.Ping(ctx, req)
+}
+
+// Diagnostics records the call and invokes DiagnosticsFunc when set.
+func (m *MockAdminServiceServer) Diagnostics(ctx context.Context, req *AdminRequest) (*AdminResponse, error) {
+	m.Record("Diagnostics", req)
+	if m.DiagnosticsFunc != nil {
+		return m.DiagnosticsFunc(ctx, req)
+	}
+	return m.UnimplementedAdminServiceServer.Diagnostics(ctx, req)
+}
+
+// RunCheck records the call and invokes RunCheckFunc when set.
+func (m *MockAdminServiceServer) RunCheck(ctx context.Context, req *CheckRequest) (*CheckResponse, error) {
+	m.Record("RunCheck", req)
+	if m.RunCheckFunc != nil {
+		return m.RunCheckFunc(ctx, req)
+	}
+	return m.UnimplementedAdminServiceServer.RunCheck(ctx, req)
+}
+
+// PurgeCache records the call and invokes PurgeCacheFunc when set.
+func (m *MockAdminServiceServer) PurgeCache(ctx context.Context, req *AdminRequest) (*AdminResponse, error) {
+	m.Record("PurgeCache", req)
+	if m.PurgeCacheFunc != nil {
+		return m.PurgeCacheFunc(ctx, req)
+	}
+	return m.UnimplementedAdminServiceServer.PurgeCache(ctx, req)
+}
+
+// Exec records the call and invokes ExecFunc when set.
+func (m *MockAdminServiceServer) Exec(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
+	m.Record("Exec", req)
+	if m.ExecFunc != nil {
+		return m.ExecFunc(ctx, req)
+	}
+	return m.UnimplementedAdminServiceServer.Exec(ctx, req)
+}
@@ -0,0 +1,58 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// TestExitCodeField_SetsProcessExitCode tests that cli.command.exit_code_field
+// turns the response's status_code into the exit code after the response is written.
+func TestExitCodeField_SetsProcessExitCode(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		status   int32
+		wantCode int
+	}{
+		{name: "non-zero status exits with it", status: 3, wantCode: 3},
+		{name: "zero status exits normally", status: 0, wantCode: -1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode := -1
+			origExiter := cli.OsExiter
+			t.Cleanup(func() { cli.OsExiter = origExiter })
+			cli.OsExiter = func(code int) { exitCode = code }
+
+			ctx := context.Background()
+			mock := &simple.MockAdminServiceServer{
+				RunCheckFunc: func(_ context.Context, req *simple.CheckRequest) (*simple.CheckResponse, error) {
+					return &simple.CheckResponse{Name: req.Name, StatusCode: tt.status, Message: "checked"}, nil
+				},
+			}
+			adminCLI := simple.AdminServiceCommand(ctx, mock, protocli.WithOutputFormats(protocli.JSON()))
+			rootCmd, err := protocli.RootCommand("testcli", protocli.Service(adminCLI))
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			setWriterOnAllCommands(rootCmd, &buf)
+
+			err = rootCmd.Run(ctx, []string{"testcli", "admin", "check", "--name", "disk"})
+			assert.Equal(t, tt.wantCode, exitCode)
+			if tt.wantCode > 0 {
+				var exitErr cli.ExitCoder
+				require.ErrorAs(t, err, &exitErr)
+				assert.Equal(t, tt.wantCode, exitErr.ExitCode())
+				assert.EqualError(t, err, "exit code 3 from response field status_code")
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Contains(t, buf.String(), `"message":"checked"`, "the response is written before exiting")
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/contrib/deserializers"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCreateWithDefaults runs "user-service create" with the given extra args and
+// returns the request received by the service.
+func runCreateWithDefaults(t *testing.T, extraArgs []string, opts ...protocli.ServiceOption) (*simple.CreateUserRequest, error) {
+	t.Helper()
+	var captured *simple.CreateUserRequest
+	mock := &mockUserServiceWithCapture{
+		onCreateUser: func(req *simple.CreateUserRequest) { captured = req },
+	}
+	_, err := runUserCommand(t, mock, opts, append([]string{
+		"user-service", "create",
+		"--name", "Test User",
+		"--email", "test@example.com",
+		"--db-url", "postgres://localhost:5432/testdb",
+	}, extraArgs...)...)
+	return captured, err
+}
+
+func TestFieldDefaults_Enum(t *testing.T) {
+	tests := []struct {
+		name      string
+		extraArgs []string
+		want      simple.LogLevel
+	}{
+		{name: "default applied when flag omitted", want: simple.LogLevel_INFO},
+		{name: "explicit value overrides default", extraArgs: []string{"--notification-level", "warn"}, want: simple.LogLevel_WARN},
+		{name: "proto value name accepted", extraArgs: []string{"--notification-level", "ERROR"}, want: simple.LogLevel_ERROR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := runCreateWithDefaults(t, tt.extraArgs)
+			require.NoError(t, err)
+			require.NotNil(t, req)
+			assert.Equal(t, tt.want, req.GetNotificationLevel())
+		})
+	}
+}
+
+func TestFieldDefaults_Duration(t *testing.T) {
+	withDuration := protocli.WithFlagDeserializer("google.protobuf.Duration", deserializers.Duration())
+
+	t.Run("default applied when flag omitted", func(t *testing.T) {
+		req, err := runCreateWithDefaults(t, nil, withDuration)
+		require.NoError(t, err)
+		require.NotNil(t, req.GetSessionTtl())
+		assert.Equal(t, 24*time.Hour, req.GetSessionTtl().AsDuration())
+	})
+
+	t.Run("explicit value overrides default", func(t *testing.T) {
+		req, err := runCreateWithDefaults(t, []string{"--session-ttl", "90m"}, withDuration)
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Minute, req.GetSessionTtl().AsDuration())
+	})
+
+	t.Run("left unset without a deserializer", func(t *testing.T) {
+		req, err := runCreateWithDefaults(t, nil)
+		require.NoError(t, err)
+		assert.Nil(t, req.GetSessionTtl())
+	})
+}
+
+func TestFieldDefaults_ShownInHelp(t *testing.T) {
+	ctx := context.Background()
+
+	userServiceCLI := simple.UserServiceCommand(ctx, newUserService)
+	rootCmd, err := protocli.RootCommand("testcli", protocli.Service(userServiceCLI))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	setWriterOnAllCommands(rootCmd, &buf)
+
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "user-service", "create", "--help"}))
+	out := buf.String()
+	assert.Contains(t, out, "(default: info)")
+	assert.Contains(t, out, "(default: 24h)")
+}
@@ -0,0 +1,46 @@
+package simple_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFieldMask_ParsesPathList tests that a google.protobuf.FieldMask field is
+// set from a comma-separated flag value without a custom deserializer.
+func TestFieldMask_ParsesPathList(t *testing.T) {
+	req := runCreateUser(t, "--update-mask", "name, email")
+	assert.Equal(t, []string{"name", "email"}, req.GetUpdateMask().GetPaths())
+
+	req = runCreateUser(t, "--update-mask", "address.city")
+	assert.Equal(t, []string{"address.city"}, req.GetUpdateMask().GetPaths())
+}
+
+// TestFieldMask_UnsetLeavesNil tests that the mask stays nil when the flag is
+// not given.
+func TestFieldMask_UnsetLeavesNil(t *testing.T) {
+	assert.Nil(t, runCreateUser(t).GetUpdateMask())
+}
+
+// TestFieldMask_ValidatesAgainstTarget tests that paths are checked against the
+// field_mask_target message.
+func TestFieldMask_ValidatesAgainstTarget(t *testing.T) {
+	_, err := tryCreateUser(t, "--update-mask", "name,nickname")
+	require.ErrorContains(t, err, "invalid value for --update-mask")
+}
+
+// TestFieldMask_OverridesInputFile tests that --update-mask replaces a mask read
+// from --input-file.
+func TestFieldMask_OverridesInputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "req.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"updateMask": "name"}`), 0o600))
+
+	req := runCreateUser(t, "--input-file", path)
+	assert.Equal(t, []string{"name"}, req.GetUpdateMask().GetPaths())
+
+	req = runCreateUser(t, "--input-file", path, "--update-mask", "email")
+	assert.Equal(t, []string{"email"}, req.GetUpdateMask().GetPaths())
+}
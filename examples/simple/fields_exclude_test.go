@@ -0,0 +1,91 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// newFieldsExcludeRoot returns a root command whose get calls a mock service,
+// and whether the service was called.
+func newFieldsExcludeRoot(t *testing.T, buf *bytes.Buffer) (*cli.Command, *bool) {
+	t.Helper()
+	called := new(bool)
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			*called = true
+			return &simple.UserResponse{
+				Message: "found",
+				User: &simple.User{
+					Id:      req.GetId(),
+					Name:    "Alice",
+					Email:   "alice@example.com",
+					Address: &simple.Address{Street: "1 Main St", City: "Springfield"},
+				},
+			}, nil
+		},
+	}
+	return newUserRoot(t, mock, buf, nil), called
+}
+
+// TestFieldsExclude_DropsTopLevelAndNestedFields tests that --fields-exclude
+// removes the listed paths from the rendered response.
+func TestFieldsExclude_DropsTopLevelAndNestedFields(t *testing.T) {
+	var buf bytes.Buffer
+	rootCmd, _ := newFieldsExcludeRoot(t, &buf)
+
+	require.NoError(t, rootCmd.Run(context.Background(), []string{
+		"testcli", "user-service", "get",
+		"--id", "1",
+		"--db-url", "postgres://localhost/test",
+		"--fields-exclude", "message",
+		"--fields-exclude", "user.email,user.address.city",
+	}))
+
+	out := buf.String()
+	assert.Contains(t, out, `"Alice"`)
+	assert.Contains(t, out, `"1 Main St"`)
+	assert.NotContains(t, out, "found")
+	assert.NotContains(t, out, "alice@example.com")
+	assert.NotContains(t, out, "Springfield")
+}
+
+// TestFieldsExclude_UnknownPath tests that a path the response doesn't have
+// is rejected before the service is called.
+func TestFieldsExclude_UnknownPath(t *testing.T) {
+	var buf bytes.Buffer
+	rootCmd, called := newFieldsExcludeRoot(t, &buf)
+
+	err := rootCmd.Run(context.Background(), []string{
+		"testcli", "user-service", "get",
+		"--id", "1",
+		"--db-url", "postgres://localhost/test",
+		"--fields-exclude", "user.nickname",
+	})
+	require.ErrorIs(t, err, protocli.ErrUnknownFieldPath)
+	assert.Empty(t, buf.String())
+	assert.False(t, *called, "the service must not be called")
+}
+
+// TestFieldsExclude_ConflictsWithFields tests that --fields and
+// --fields-exclude cannot be combined.
+func TestFieldsExclude_ConflictsWithFields(t *testing.T) {
+	var buf bytes.Buffer
+	rootCmd, called := newFieldsExcludeRoot(t, &buf)
+
+	err := rootCmd.Run(context.Background(), []string{
+		"testcli", "user-service", "get",
+		"--id", "1",
+		"--db-url", "postgres://localhost/test",
+		"--fields", "name",
+		"--fields-exclude", "user.email",
+	})
+	require.ErrorIs(t, err, protocli.ErrFieldsExcludeConflict)
+	assert.False(t, *called, "the service must not be called")
+}
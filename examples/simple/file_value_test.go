@@ -0,0 +1,52 @@
+package simple_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAllowFile_ReadsValueFromFile tests that --bio @path reads the field from
+// a file, trimming surrounding whitespace.
+func TestAllowFile_ReadsValueFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bio.txt")
+	require.NoError(t, os.WriteFile(path, []byte("\nWrote the first program.\n\n"), 0o600))
+
+	req := runCreateUser(t, "--bio", "@"+path)
+	assert.Equal(t, "Wrote the first program.", req.GetBio())
+}
+
+// TestAllowFile_ReadsValueFromStdin tests that --bio @- reads the field from stdin.
+func TestAllowFile_ReadsValueFromStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString("Analytical Engine enthusiast\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		_ = r.Close()
+	})
+
+	req := runCreateUser(t, "--bio", "@-")
+	assert.Equal(t, "Analytical Engine enthusiast", req.GetBio())
+}
+
+// TestAllowFile_EscapedAt tests that "@@" passes a literal leading "@" through
+// and that values without "@" are used as-is.
+func TestAllowFile_EscapedAt(t *testing.T) {
+	assert.Equal(t, "@ada", runCreateUser(t, "--bio", "@@ada").GetBio())
+	assert.Equal(t, "plain text", runCreateUser(t, "--bio", "plain text").GetBio())
+}
+
+// TestAllowFile_MissingFile tests that an unreadable @path fails the command.
+func TestAllowFile_MissingFile(t *testing.T) {
+	_, err := tryCreateUser(t, "--bio", "@"+filepath.Join(t.TempDir(), "missing.txt"))
+	require.ErrorContains(t, err, "invalid value for --bio")
+}
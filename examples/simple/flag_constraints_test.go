@@ -0,0 +1,75 @@
+package simple_test
+
+import (
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFlagConstraints_Create tests the pattern, one_of and min/max annotations
+// on CreateUserRequest.
+func TestFlagConstraints_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		extraArgs     []string
+		errorContains []string
+	}{
+		{
+			name:      "valid values",
+			extraArgs: []string{"--email", "test@example.com", "--plan", "pro", "--age", "30"},
+		},
+		{
+			name:          "email does not match pattern",
+			extraArgs:     []string{"--email", "not-an-email"},
+			errorContains: []string{"--email", "does not match pattern"},
+		},
+		{
+			name:          "empty email given",
+			extraArgs:     []string{"--email", ""},
+			errorContains: []string{"--email", `"" does not match pattern`},
+		},
+		{
+			name:          "plan not in one_of",
+			extraArgs:     []string{"--email", "test@example.com", "--plan", "platinum"},
+			errorContains: []string{"--plan", "must be one of [free, pro, enterprise]"},
+		},
+		{
+			name:          "age above max",
+			extraArgs:     []string{"--email", "test@example.com", "--age", "200"},
+			errorContains: []string{"--age", "greater than maximum 150"},
+		},
+		{
+			name:          "all violations reported together",
+			extraArgs:     []string{"--email", "nope", "--plan", "gold", "--age", "-1"},
+			errorContains: []string{"--email", "--plan", "less than minimum 0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			mock := &mockUserServiceWithCapture{
+				onCreateUser: func(*simple.CreateUserRequest) { called = true },
+			}
+			_, err := runUserCommand(t, mock, nil, append([]string{
+				"user-service", "create",
+				"--name", "Test User",
+				"--db-url", "postgres://localhost:5432/testdb",
+			}, tt.extraArgs...)...)
+
+			if len(tt.errorContains) == 0 {
+				require.NoError(t, err)
+				assert.True(t, called)
+				return
+			}
+			require.ErrorIs(t, err, protocli.ErrConstraintViolation)
+			for _, want := range tt.errorContains {
+				assert.Contains(t, err.Error(), want)
+			}
+			assert.False(t, called, "service must not be called when validation fails")
+		})
+	}
+}
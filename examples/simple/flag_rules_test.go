@@ -0,0 +1,108 @@
+package simple_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// runWithFlagRules runs `testcli user-service args...` and reports whether the
+// service was called.
+func runWithFlagRules(t *testing.T, opts []protocli.ServiceOption, args ...string) (bool, error) {
+	t.Helper()
+	var called bool
+	mock := &simple.MockUserServiceServer{
+		CreateUserFunc: func(context.Context, *simple.CreateUserRequest) (*simple.UserResponse, error) {
+			called = true
+			return &simple.UserResponse{}, nil
+		},
+		GetUserFunc: func(context.Context, *simple.GetUserRequest) (*simple.UserResponse, error) {
+			called = true
+			return &simple.UserResponse{}, nil
+		},
+	}
+	_, err := runUserCommand(t, mock, opts, append([]string{"user-service"}, append(args, "--db-url", "postgres://localhost/db")...)...)
+	return called, err
+}
+
+// TestFlagRules_Annotations tests the requires and conflicts annotations:
+// CreateUser's --nickname requires --verified and GetUser's --fields conflicts
+// with --include-details.
+func TestFlagRules_Annotations(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{
+			name: "requires pair satisfied",
+			args: []string{"create", "--name", "Ada", "--email", "ada@example.com", "--nickname", "ada", "--verified"},
+		},
+		{
+			name:          "requires pair missing",
+			args:          []string{"create", "--name", "Ada", "--email", "ada@example.com", "--nickname", "ada"},
+			errorContains: "--nickname requires --verified",
+		},
+		{
+			name: "required flag alone",
+			args: []string{"create", "--name", "Ada", "--email", "ada@example.com", "--verified"},
+		},
+		{
+			name: "conflicting flag alone",
+			args: []string{"get", "--id", "1", "--fields", "name"},
+		},
+		{
+			name:          "conflicts pair both set",
+			args:          []string{"get", "--id", "1", "--fields", "name", "--include-details"},
+			errorContains: "--fields and --include-details can't be used together",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called, err := runWithFlagRules(t, nil, tt.args...)
+			if tt.errorContains == "" {
+				require.NoError(t, err)
+				assert.True(t, called)
+				return
+			}
+			require.ErrorIs(t, err, protocli.ErrFlagRuleViolation)
+			assert.Contains(t, err.Error(), tt.errorContains)
+			assert.False(t, called, "the service must not be called when a rule fails")
+		})
+	}
+}
+
+// TestFlagRules_WithFlagRules tests that rules registered with WithFlagRules
+// run for their method only, together with the declared rules.
+func TestFlagRules_WithFlagRules(t *testing.T) {
+	errTimeout := errors.New("--timeout must be at most 1000")
+	opts := []protocli.ServiceOption{
+		protocli.WithFlagRules("/example.UserService/GetUser", func(cmd *cli.Command) error {
+			if cmd.Int32("timeout") > 1000 {
+				return errTimeout
+			}
+			return nil
+		}),
+		protocli.WithFlagRules("/example.UserService/CreateUser", func(*cli.Command) error {
+			return errors.New("registered for another method")
+		}),
+	}
+
+	called, err := runWithFlagRules(t, opts, "get", "--id", "1", "--timeout", "50")
+	require.NoError(t, err)
+	assert.True(t, called)
+
+	called, err = runWithFlagRules(t, opts, "get", "--id", "1", "--timeout", "5000")
+	require.ErrorIs(t, err, errTimeout)
+	assert.False(t, called)
+
+	_, err = runWithFlagRules(t, opts, "get", "--id", "1", "--timeout", "5000", "--fields", "name", "--include-details")
+	require.ErrorIs(t, err, errTimeout, "all failures are reported together")
+	require.ErrorIs(t, err, protocli.ErrFlagRuleViolation)
+}
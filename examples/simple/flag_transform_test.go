@@ -0,0 +1,50 @@
+package simple_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagTransform_LowercasesEmail(t *testing.T) {
+	protocli.RegisterFlagTransform("example.CreateUserRequest", "email", func(s string) (string, error) {
+		return strings.ToLower(strings.TrimSpace(s)), nil
+	})
+	t.Cleanup(func() { protocli.RegisterFlagTransform("example.CreateUserRequest", "email", nil) })
+
+	got, err := tryCreateUser(t, "--name", "Grace", "--email", " Grace@Example.COM")
+	require.NoError(t, err)
+	assert.Equal(t, "grace@example.com", got.GetEmail())
+	assert.Equal(t, "Grace", got.GetName(), "fields without a transform are unchanged")
+}
+
+func TestFlagTransform_ExpandsHomeDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	protocli.RegisterFlagTransform("example.CreateUserRequest", "bio", protocli.ExpandHomeDir)
+	t.Cleanup(func() { protocli.RegisterFlagTransform("example.CreateUserRequest", "bio", nil) })
+
+	got, err := tryCreateUser(t, "--name", "Grace", "--email", "grace@example.com", "--bio", "~/notes.txt")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "notes.txt"), got.GetBio())
+
+	got, err = tryCreateUser(t, "--name", "Grace", "--email", "grace@example.com", "--bio", "~grace/notes.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "~grace/notes.txt", got.GetBio(), "only the current user's ~ is expanded")
+}
+
+func TestFlagTransform_ErrorFailsCommand(t *testing.T) {
+	protocli.RegisterFlagTransform("example.CreateUserRequest", "email", func(string) (string, error) {
+		return "", assert.AnError
+	})
+	t.Cleanup(func() { protocli.RegisterFlagTransform("example.CreateUserRequest", "email", nil) })
+
+	got, err := tryCreateUser(t, "--name", "Grace", "--email", "grace@example.com")
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Contains(t, err.Error(), "invalid value for --email")
+	assert.Nil(t, got, "the method is not called")
+}
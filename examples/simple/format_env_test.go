@@ -0,0 +1,47 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFormatEnv_DefaultsFormatFlag tests that <PREFIX>_FORMAT picks the output
+// format when --format is not given, and that the flag still wins.
+func TestFormatEnv_DefaultsFormatFlag(t *testing.T) {
+	t.Setenv("TESTCLI_FORMAT", "yaml")
+
+	run := func(extraArgs ...string) string {
+		t.Helper()
+		ctx := context.Background()
+		userCLI := simple.UserServiceCommand(ctx, newUserService,
+			protocli.WithOutputFormats(protocli.JSON(), protocli.YAML()),
+		)
+		rootCmd, err := protocli.RootCommand("testcli",
+			protocli.Service(userCLI),
+			protocli.WithEnvPrefix("TESTCLI"),
+		)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		setWriterOnAllCommands(rootCmd, &buf)
+		require.NoError(t, rootCmd.Run(ctx, append([]string{
+			"testcli", "user-service", "get",
+			"--id", "1",
+			"--db-url", "postgres://localhost/test",
+		}, extraArgs...)))
+		return buf.String()
+	}
+
+	yamlOut := run()
+	assert.Contains(t, yamlOut, "user:")
+	assert.NotContains(t, yamlOut, "{")
+
+	jsonOut := run("--format", "json")
+	assert.Contains(t, jsonOut, `"user"`)
+}
@@ -0,0 +1,55 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runGetWithFormatFlags(t *testing.T, args ...string) string {
+	t.Helper()
+	ctx := context.Background()
+	userCLI := simple.UserServiceCommand(ctx, newUserService,
+		protocli.WithOutputFormats(protocli.JSON(), protocli.YAML()),
+	)
+	var logs bytes.Buffer
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(userCLI),
+		protocli.ConfigureLogging(func(_ context.Context, _ protocli.SlogConfigurationContext) *slog.Logger {
+			return slog.New(slog.NewTextHandler(&logs, nil))
+		}),
+	)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	setWriterOnAllCommands(rootCmd, &out)
+	require.NoError(t, rootCmd.Run(ctx, append([]string{
+		"testcli", "user-service", "get",
+		"--id", "1",
+		"--db-url", "postgres://localhost/test",
+	}, args...)))
+	return logs.String()
+}
+
+// TestFormatFlags_WarnsWhenIgnored tests that setting a flag of another output
+// format (--pretty belongs to json) logs one warning naming both formats.
+func TestFormatFlags_WarnsWhenIgnored(t *testing.T) {
+	logs := runGetWithFormatFlags(t, "--format", "yaml", "--pretty", "--json-enum-numbers")
+
+	assert.Equal(t, 1, strings.Count(logs, "flag=--pretty"))
+	assert.Equal(t, 1, strings.Count(logs, "flag=--json-enum-numbers"))
+	assert.Contains(t, logs, "Flag is ignored by the selected output format")
+	assert.Contains(t, logs, "format=yaml used_by=json")
+}
+
+func TestFormatFlags_NoWarningForSelectedFormat(t *testing.T) {
+	assert.NotContains(t, runGetWithFormatFlags(t, "--format", "json", "--pretty"), "ignored")
+	assert.NotContains(t, runGetWithFormatFlags(t, "--format", "yaml"), "ignored")
+}
@@ -0,0 +1,45 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// diagnosticsAdminService implements the hidden Diagnostics RPC.
+type diagnosticsAdminService struct {
+	simple.UnimplementedAdminServiceServer
+}
+
+func (s *diagnosticsAdminService) Diagnostics(_ context.Context, _ *simple.AdminRequest) (*simple.AdminResponse, error) {
+	return &simple.AdminResponse{Message: "diagnostics-ok", Success: true}, nil
+}
+
+// TestHiddenCommand_OmittedFromHelpButRunnable tests that cli.command.hidden
+// removes a command from help output without unregistering it.
+func TestHiddenCommand_OmittedFromHelpButRunnable(t *testing.T) {
+	ctx := context.Background()
+
+	adminCLI := simple.AdminServiceCommand(ctx, &diagnosticsAdminService{},
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+	rootCmd, err := protocli.RootCommand("testcli", protocli.Service(adminCLI))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	setWriterOnAllCommands(rootCmd, &buf)
+
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "admin", "--help"}))
+	assert.Contains(t, buf.String(), "health")
+	assert.NotContains(t, buf.String(), "diagnostics")
+
+	buf.Reset()
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "admin", "diagnostics"}))
+	assert.Contains(t, buf.String(), "diagnostics-ok")
+}
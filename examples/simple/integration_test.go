@@ -403,6 +403,25 @@ func TestIntegration_MultipleServices(t *testing.T) {
 	assert.Equal(t, int64(50), svc2.maxConns)
 }
 
+func TestIntegration_BuildAllServicesCLI(t *testing.T) {
+	ctx := context.Background()
+
+	rootCmd, err := simple.BuildAllServicesCLI(ctx, "usercli",
+		newUserService,
+		&simple.UnimplementedAdminServiceServer{},
+		protocli.WithEnvPrefix("USERCLI"),
+	)
+	require.NoError(t, err)
+
+	var names []string
+	for _, cmd := range rootCmd.Commands {
+		names = append(names, cmd.Name)
+	}
+	assert.Contains(t, names, "user-service")
+	assert.Contains(t, names, "admin")
+	assert.Contains(t, names, "daemonize")
+}
+
 // TestIntegration_RealWorld_ProductionLike tests a production-like scenario.
 func TestIntegration_RealWorld_ProductionLike(t *testing.T) {
 	tmpDir := t.TempDir()
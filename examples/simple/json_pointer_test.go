@@ -0,0 +1,74 @@
+package simple_test
+
+import (
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runGetWithPointer runs `user-service get` with args against a user that has
+// an address and returns what was written to stdout.
+func runGetWithPointer(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{
+				User: &simple.User{
+					Id:      req.GetId(),
+					Name:    "Ada Lovelace",
+					Email:   "ada@example.com",
+					Address: &simple.Address{City: "London", Country: "UK"},
+				},
+				Message: "found",
+			}, nil
+		},
+	}
+	return runUserCommand(t, mock, []protocli.ServiceOption{protocli.WithOutputFormats(protocli.YAML(), protocli.JSON())},
+		append([]string{"user-service", "get", "--id", "7", "--db-url", "postgres://localhost/test"}, args...)...)
+}
+
+func TestJSONPointer_Scalar(t *testing.T) {
+	out, err := runGetWithPointer(t, "--get", "/user/email")
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com\n", out, "strings are printed without quotes")
+
+	out, err = runGetWithPointer(t, "--get", "/user/id")
+	require.NoError(t, err)
+	assert.Equal(t, "7\n", out, "int64 fields are JSON strings, printed without quotes")
+}
+
+func TestJSONPointer_Nested(t *testing.T) {
+	out, err := runGetWithPointer(t, "--get", "/user/address/city")
+	require.NoError(t, err)
+	assert.Equal(t, "London\n", out)
+
+	out, err = runGetWithPointer(t, "--get", "/user/address", "--pretty")
+	require.NoError(t, err)
+	assert.Equal(t, `{"street":"","city":"London","state":"","zipCode":"","country":"UK"}`+"\n", out,
+		"objects are printed as compact JSON")
+}
+
+func TestJSONPointer_MissingPath(t *testing.T) {
+	out, err := runGetWithPointer(t, "--get", "/user/phone")
+	require.ErrorIs(t, err, protocli.ErrJSONPointerNotFound)
+	assert.Contains(t, err.Error(), `"/user/phone"`)
+	assert.Empty(t, out)
+
+	_, err = runGetWithPointer(t, "--get", "user/email")
+	require.ErrorIs(t, err, protocli.ErrInvalidJSONPointer)
+}
+
+// TestJSONPointer_RequiresJSONFormat tests that --get falls back to the JSON
+// format when the default is not JSON, but rejects an explicit non-JSON format.
+func TestJSONPointer_RequiresJSONFormat(t *testing.T) {
+	_, err := runGetWithPointer(t, "--get", "/message", "--format", "yaml")
+	require.ErrorIs(t, err, protocli.ErrGetRequiresJSON)
+
+	out, err := runGetWithPointer(t, "--get", "/message")
+	require.NoError(t, err, "yaml is the default, so JSON is used")
+	assert.Equal(t, "found\n", out)
+}
@@ -0,0 +1,47 @@
+package simple_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMessageListFile_JSONArray tests that a repeated message flag without a
+// deserializer reads every element of a JSON array from --flag @path, in order.
+func TestMessageListFile_JSONArray(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "addresses.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+  {"street": "1 Main St", "city": "Springfield"},
+  {"street": "2 Oak Ave", "city": "Shelbyville"},
+  {"street": "3 Elm Rd", "city": "Capital City", "zip_code": "12345"}
+]`), 0o600))
+
+	req := runCreateUser(t, "--previous-address", "@"+path)
+	require.Len(t, req.GetPreviousAddresses(), 3)
+	assert.Equal(t, "Springfield", req.GetPreviousAddresses()[0].GetCity())
+	assert.Equal(t, "Shelbyville", req.GetPreviousAddresses()[1].GetCity())
+	assert.Equal(t, "12345", req.GetPreviousAddresses()[2].GetZipCode())
+}
+
+func TestMessageListFile_YAMLArray(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "addresses.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- street: 1 Main St\n  city: Springfield\n- city: Shelbyville\n"), 0o600))
+
+	req := runCreateUser(t, "--previous-address", "@"+path)
+	require.Len(t, req.GetPreviousAddresses(), 2)
+	assert.Equal(t, "1 Main St", req.GetPreviousAddresses()[0].GetStreet())
+	assert.Equal(t, "Shelbyville", req.GetPreviousAddresses()[1].GetCity())
+}
+
+func TestMessageListFile_Errors(t *testing.T) {
+	_, err := tryCreateUser(t, "--previous-address", "1 Main St")
+	require.ErrorContains(t, err, "@file holding a JSON or YAML array")
+
+	path := filepath.Join(t.TempDir(), "address.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"city": "Springfield"}`), 0o600))
+	_, err = tryCreateUser(t, "--previous-address", "@"+path)
+	require.ErrorContains(t, err, "invalid value for --previous-address")
+}
@@ -0,0 +1,41 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runPanickingGetUser(t *testing.T, opts ...protocli.RootOption) error {
+	t.Helper()
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, _ *simple.GetUserRequest) (*simple.UserResponse, error) {
+			var users map[int64]*simple.User
+			users[1] = nil // assignment to entry in nil map
+			return &simple.UserResponse{}, nil
+		},
+	}
+	var buf bytes.Buffer
+	err := newUserRoot(t, mock, &buf, nil, opts...).Run(context.Background(), []string{"testcli", "user-service", "get", "--id", "1", "--db-url", "postgres://localhost/test"})
+	assert.Empty(t, buf.String(), "no response is written")
+	return err
+}
+
+// TestMethodPanic_Recovered tests that a panicking implementation fails the
+// command with an error instead of crashing the CLI in local mode.
+func TestMethodPanic_Recovered(t *testing.T) {
+	err := runPanickingGetUser(t)
+	require.ErrorIs(t, err, protocli.ErrMethodPanic)
+	assert.Contains(t, err.Error(), "method UserService.GetUser panicked: assignment to entry in nil map")
+}
+
+func TestMethodPanic_RecoveryDisabled(t *testing.T) {
+	assert.Panics(t, func() {
+		_ = runPanickingGetUser(t, protocli.WithMethodPanicRecovery(false))
+	})
+}
@@ -0,0 +1,48 @@
+package simple_test
+
+import (
+	"context"
+	"testing"
+
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestMockServer_CannedResponseAndRecordedCalls tests that the generated mock
+// returns the configured response and records the request the CLI built.
+func TestMockServer_CannedResponseAndRecordedCalls(t *testing.T) {
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{User: &simple.User{Id: req.GetId(), Name: "Canned"}}, nil
+		},
+	}
+	out, err := runUserCommand(t, mock, nil, "user-service", "get", "--id", "42", "--db-url", "postgres://localhost/test")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"Canned"`)
+
+	calls := mock.CallsTo("GetUser")
+	require.Len(t, calls, 1)
+	req, ok := calls[0].(*simple.GetUserRequest)
+	require.True(t, ok)
+	assert.Equal(t, int64(42), req.GetId())
+	assert.Empty(t, mock.CallsTo("CreateUser"))
+}
+
+// TestMockServer_UnsetMethodIsUnimplemented tests that methods without a Func
+// field fall back to codes.Unimplemented and are still recorded.
+func TestMockServer_UnsetMethodIsUnimplemented(t *testing.T) {
+	mock := &simple.MockAdminServiceServer{}
+
+	_, err := mock.HealthCheck(context.Background(), &simple.AdminRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	calls := mock.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "HealthCheck", calls[0].Method)
+
+	mock.ResetCalls()
+	assert.Empty(t, mock.Calls())
+}
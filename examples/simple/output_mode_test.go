@@ -0,0 +1,71 @@
+package simple_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOutputMode_CreatedFilePermissions tests that --output-mode sets the
+// permissions of the file created by --output, and that --output-append
+// appends to it instead of truncating.
+func TestOutputMode_CreatedFilePermissions(t *testing.T) {
+	ctx := context.Background()
+	outPath := filepath.Join(t.TempDir(), "user.json")
+
+	run := func(extraArgs ...string) {
+		t.Helper()
+		userCLI := simple.UserServiceCommand(ctx, newUserService, protocli.WithOutputFormats(protocli.JSON()))
+		rootCmd, err := protocli.RootCommand("testcli", protocli.Service(userCLI))
+		require.NoError(t, err)
+
+		args := append([]string{
+			"testcli", "user-service", "get",
+			"--id", "1",
+			"--db-url", "postgres://localhost/test",
+			"--output", outPath,
+		}, extraArgs...)
+		require.NoError(t, rootCmd.Run(ctx, args))
+	}
+
+	run("--output-mode", "0600")
+	info, err := os.Stat(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	first, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	require.NotEmpty(t, first)
+
+	run("--output-append")
+	appended, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Len(t, appended, 2*len(first))
+
+	info, err = os.Stat(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "existing file keeps its permissions")
+}
+
+func TestOutputMode_Invalid(t *testing.T) {
+	ctx := context.Background()
+
+	userCLI := simple.UserServiceCommand(ctx, newUserService)
+	rootCmd, err := protocli.RootCommand("testcli", protocli.Service(userCLI))
+	require.NoError(t, err)
+
+	err = rootCmd.Run(ctx, []string{
+		"testcli", "user-service", "get",
+		"--id", "1",
+		"--db-url", "postgres://localhost/test",
+		"--output", filepath.Join(t.TempDir(), "out.json"),
+		"--output-mode", "rw-------",
+	})
+	require.ErrorIs(t, err, protocli.ErrInvalidFileMode)
+}
@@ -0,0 +1,82 @@
+package simple_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runWithOutputConfig runs `user-service get` with a config file whose
+// commands section sets the command's default output path, and returns what
+// was written to stdout.
+func runWithOutputConfig(t *testing.T, output string, args ...string) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "testcli.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+commands:
+  user-service/get:
+    format: json
+    output: `+output+`
+`), 0o600))
+
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{User: &simple.User{Id: req.GetId(), Name: "Alice"}}, nil
+		},
+	}
+	out, err := runUserCommand(t, mock, nil, append([]string{
+		"--config", configPath, "user-service", "get",
+		"--id", "1", "--db-url", "postgres://localhost/test",
+	}, args...)...)
+	require.NoError(t, err)
+	return out
+}
+
+// TestOutputPath_ConfigDefault tests that a templated output path under ~ from
+// the config's commands section is used when --output is not given.
+func TestOutputPath_ConfigDefault(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.Mkdir(filepath.Join(home, "reports"), 0o755))
+
+	stdout := runWithOutputConfig(t, `"~/reports/{{.Service}}-{{.Method}}.{{.Format}}"`)
+	assert.Empty(t, stdout)
+
+	written, err := os.ReadFile(filepath.Join(home, "reports", "user-service-get.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(written), `"name":"Alice"`)
+}
+
+func TestOutputPath_ConfigDirectory(t *testing.T) {
+	dir := t.TempDir()
+	runWithOutputConfig(t, dir)
+
+	written, err := os.ReadFile(filepath.Join(dir, "get.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(written), `"name":"Alice"`)
+}
+
+func TestOutputPath_FlagOverridesConfig(t *testing.T) {
+	dir := t.TempDir()
+	configured := filepath.Join(dir, "configured.json")
+	override := filepath.Join(dir, "override.json")
+
+	runWithOutputConfig(t, configured, "--output", override)
+	assert.FileExists(t, override)
+	assert.NoFileExists(t, configured)
+
+	stdout := runWithOutputConfig(t, configured, "--output", "-")
+	assert.Contains(t, stdout, `"name":"Alice"`)
+	assert.NoFileExists(t, configured)
+}
+
+func TestExpandOutputPath_InvalidTemplate(t *testing.T) {
+	_, err := protocli.ExpandOutputPath("{{.Nope}}.json", protocli.OutputPathData{})
+	require.ErrorIs(t, err, protocli.ErrInvalidOutputPath)
+}
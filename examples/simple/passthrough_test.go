@@ -0,0 +1,50 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPassthrough_CollectsTrailingArgs tests that cli.command.passthrough
+// puts the arguments after "--" into the request's repeated args field
+// without parsing them as flags.
+func TestPassthrough_CollectsTrailingArgs(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		args     []string
+		wantArgs []string
+	}{
+		{name: "after double dash", args: []string{"--", "ls", "-la"}, wantArgs: []string{"ls", "-la"}},
+		{name: "flags after double dash are not parsed", args: []string{"--", "grep", "--format", "x"}, wantArgs: []string{"grep", "--format", "x"}},
+		{name: "args flag without positional args", args: []string{"--args", "uptime"}, wantArgs: []string{"uptime"}},
+		{name: "no args", wantArgs: []string{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var gotArgs []string
+			mock := &simple.MockAdminServiceServer{
+				ExecFunc: func(_ context.Context, req *simple.ExecRequest) (*simple.ExecResponse, error) {
+					gotArgs = req.Args
+					return &simple.ExecResponse{Output: "ok"}, nil
+				},
+			}
+			adminCLI := simple.AdminServiceCommand(ctx, mock, protocli.WithOutputFormats(protocli.JSON()))
+			rootCmd, err := protocli.RootCommand("testcli", protocli.Service(adminCLI))
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			setWriterOnAllCommands(rootCmd, &buf)
+
+			err = rootCmd.Run(ctx, append([]string{"testcli", "admin", "run"}, tt.args...))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+			assert.Contains(t, buf.String(), `"output":"ok"`)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runGetUser runs user-service get against mock with the given root options
+// and returns stdout.
+func runGetUser(t *testing.T, mock *simple.MockUserServiceServer, opts []protocli.RootOption, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	err := newUserRoot(t, mock, &stdout, nil, opts...).Run(context.Background(), append([]string{"testcli"}, args...))
+	return stdout.String(), err
+}
+
+// TestReplay_RecordThenReplay tests that a recorded unary response is replayed
+// with identical output without calling the implementation.
+func TestReplay_RecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	recorder := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{
+				User:    &simple.User{Id: req.GetId(), Name: "Ada", Email: "ada@example.com"},
+				Message: "recorded",
+			}, nil
+		},
+	}
+	get := func(id string) []string {
+		return []string{"user-service", "get", "--db-url", "postgres://localhost/test", "--id", id}
+	}
+
+	recorded, err := runGetUser(t, recorder, []protocli.RootOption{protocli.WithRecord(dir)}, get("7")...)
+	require.NoError(t, err)
+	require.Contains(t, recorded, "Ada")
+
+	impl := &simple.MockUserServiceServer{}
+	replayOpts := []protocli.RootOption{protocli.WithReplay(dir)}
+	replayed, err := runGetUser(t, impl, replayOpts, append([]string{"--replay"}, get("7")...)...)
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+	assert.Empty(t, impl.Calls(), "the implementation is not called")
+
+	_, err = runGetUser(t, impl, replayOpts, append([]string{"--replay"}, get("8")...)...)
+	require.ErrorIs(t, err, protocli.ErrNoRecording, "a different request has no recording")
+}
+
+// TestReplay_FlagRequired tests that WithReplay only replays when --replay is set.
+func TestReplay_FlagRequired(t *testing.T) {
+	impl := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, _ *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{Message: "live"}, nil
+		},
+	}
+	out, err := runGetUser(t, impl, []protocli.RootOption{protocli.WithReplay(t.TempDir())},
+		"user-service", "get", "--db-url", "postgres://localhost/test", "--id", "1")
+	require.NoError(t, err)
+	assert.Contains(t, out, "live")
+	assert.Len(t, impl.Calls(), 1)
+}
@@ -0,0 +1,55 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestMaxRequestSize_RejectsOversizedRequests tests that WithMaxRequestSize
+// lets a request at the limit through and rejects one byte over it before the
+// service is called.
+func TestMaxRequestSize_RejectsOversizedRequests(t *testing.T) {
+	ctx := context.Background()
+	args := []string{
+		"testcli", "user-service", "get",
+		"--id", "12345",
+		"--db-url", "postgres://localhost/test",
+		"--fields", "name,email",
+	}
+
+	run := func(limit int) (*simple.MockUserServiceServer, error) {
+		t.Helper()
+		mock := &simple.MockUserServiceServer{
+			GetUserFunc: func(_ context.Context, _ *simple.GetUserRequest) (*simple.UserResponse, error) {
+				return &simple.UserResponse{}, nil
+			},
+		}
+		var buf bytes.Buffer
+		return mock, newUserRoot(t, mock, &buf, nil, protocli.WithMaxRequestSize(limit)).Run(ctx, args)
+	}
+
+	// Measure the request the CLI builds without a limit
+	mock, err := run(0)
+	require.NoError(t, err)
+	calls := mock.CallsTo("GetUser")
+	require.Len(t, calls, 1)
+	req, ok := calls[0].(proto.Message)
+	require.True(t, ok)
+	size := proto.Size(req)
+	require.Positive(t, size)
+
+	mock, err = run(size)
+	require.NoError(t, err, "a request exactly at the limit is allowed")
+	assert.Len(t, mock.CallsTo("GetUser"), 1)
+
+	mock, err = run(size - 1)
+	require.ErrorIs(t, err, protocli.ErrRequestTooLarge)
+	assert.Empty(t, mock.CallsTo("GetUser"), "oversized requests are never sent")
+}
@@ -0,0 +1,83 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// TestRootFlags_DeclaredInProto tests that root flags declared with the
+// (cli.v1.file) option are added to the root command and readable from hooks.
+func TestRootFlags_DeclaredInProto(t *testing.T) {
+	ctx := context.Background()
+
+	var tenant string
+	var timeout time.Duration
+	adminCLI := simple.AdminServiceCommand(ctx, &diagnosticsAdminService{},
+		protocli.WithOutputFormats(protocli.JSON()),
+		protocli.BeforeCommand(func(_ context.Context, cmd *cli.Command) error {
+			tenant = cmd.Root().String("tenant")
+			timeout = cmd.Root().Duration("request-timeout")
+			return nil
+		}),
+	)
+	userCLI := simple.UserServiceCommand(ctx, newUserService)
+	rootCmd, err := protocli.RootCommand("testcli", protocli.Service(adminCLI), protocli.Service(userCLI))
+	require.NoError(t, err)
+
+	var names []string
+	for _, f := range rootCmd.Flags {
+		names = append(names, f.Names()...)
+	}
+	assert.Contains(t, names, "tenant")
+	assert.Contains(t, names, "request-timeout")
+	// Both services come from the same file, so the flags are only added once
+	assert.Len(t, names, len(uniqueStrings(names)))
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	setWriterOnAllCommands(rootCmd, &buf)
+
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "-t", "acme", "admin", "diagnostics"}))
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, 30*time.Second, timeout)
+}
+
+// TestRootFlags_EnvVar tests that a root flag falls back to its declared env var.
+func TestRootFlags_EnvVar(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("USERCLI_TENANT", "from-env")
+
+	var tenant string
+	adminCLI := simple.AdminServiceCommand(ctx, &diagnosticsAdminService{},
+		protocli.WithOutputFormats(protocli.JSON()),
+		protocli.BeforeCommand(func(_ context.Context, cmd *cli.Command) error {
+			tenant = cmd.Root().String("tenant")
+			return nil
+		}),
+	)
+	rootCmd, err := protocli.RootCommand("testcli", protocli.Service(adminCLI))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	setWriterOnAllCommands(rootCmd, &buf)
+
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "admin", "diagnostics"}))
+	assert.Equal(t, "from-env", tenant)
+}
+
+func uniqueStrings(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,81 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestShowInput_PrintsMergedRequest tests that --show-input writes the request
+// built from --input-file plus flag overrides to stderr, and that the method is
+// still called with that request.
+func TestShowInput_PrintsMergedRequest(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "req.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "Ada", "phoneNumber": "555-0100", "plan": "pro"}`), 0o600))
+
+	var got *simple.CreateUserRequest
+	mock := &simple.MockUserServiceServer{
+		CreateUserFunc: func(_ context.Context, req *simple.CreateUserRequest) (*simple.UserResponse, error) {
+			got = req
+			return &simple.UserResponse{Message: "created"}, nil
+		},
+	}
+	var stdout, stderr bytes.Buffer
+	rootCmd := newUserRoot(t, mock, &stdout, nil)
+	rootCmd.ErrWriter = &stderr
+
+	err := rootCmd.Run(ctx, []string{
+		"testcli", "user-service", "create",
+		"--db-url", "postgres://localhost/test",
+		"--input-file", path,
+		"--name", "Grace",
+		"--email", "grace@example.com",
+		"--request-id", "req-1",
+		"--show-input",
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, got, "the method still runs")
+	assert.Equal(t, "Grace", got.GetName())
+
+	var shown map[string]any
+	require.NoError(t, json.Unmarshal(stderr.Bytes(), &shown))
+	assert.Equal(t, "Grace", shown["name"], "flag overrides the file")
+	assert.Equal(t, "grace@example.com", shown["email"])
+	assert.Equal(t, "555-0100", shown["phoneNumber"], "file values are kept")
+	assert.Equal(t, "pro", shown["plan"])
+	assert.Equal(t, "req-1", shown["requestId"])
+
+	assert.Contains(t, stdout.String(), "created")
+	assert.NotContains(t, stdout.String(), "Grace", "the request is not written to stdout")
+}
+
+// TestShowInput_OffByDefault tests that nothing is written to stderr without
+// --show-input.
+func TestShowInput_OffByDefault(t *testing.T) {
+	ctx := context.Background()
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, _ *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{}, nil
+		},
+	}
+	var stdout, stderr bytes.Buffer
+	rootCmd := newUserRoot(t, mock, &stdout, nil)
+	rootCmd.ErrWriter = &stderr
+
+	err := rootCmd.Run(ctx, []string{
+		"testcli", "user-service", "get",
+		"--db-url", "postgres://localhost/test",
+		"--id", "1",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, stderr.String())
+}
@@ -1,8 +1,14 @@
 package simple_test
 
 import (
+	"bytes"
+	"context"
 	"io"
+	"testing"
 
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v3"
 )
 
@@ -13,3 +19,26 @@ func setWriterOnAllCommands(cmd *cli.Command, w io.Writer) {
 		setWriterOnAllCommands(subCmd, w)
 	}
 }
+
+// newUserRoot returns a testcli root command whose user-service is backed by
+// server and renders JSON, with the service options opts and the root options
+// rootOpts, writing its output to stdout.
+func newUserRoot(t *testing.T, server simple.UserServiceServer, stdout io.Writer, opts []protocli.ServiceOption, rootOpts ...protocli.RootOption) *cli.Command {
+	t.Helper()
+	factory := func(_ *simple.UserServiceConfig) simple.UserServiceServer { return server }
+	opts = append([]protocli.ServiceOption{protocli.WithOutputFormats(protocli.JSON())}, opts...)
+	userCLI := simple.UserServiceCommand(context.Background(), factory, opts...)
+	rootCmd, err := protocli.RootCommand("testcli", append([]protocli.RootOption{protocli.Service(userCLI)}, rootOpts...)...)
+	require.NoError(t, err)
+	setWriterOnAllCommands(rootCmd, stdout)
+	return rootCmd
+}
+
+// runUserCommand runs `testcli args...` against a user-service backed by server
+// (see newUserRoot) and returns stdout.
+func runUserCommand(t *testing.T, server simple.UserServiceServer, opts []protocli.ServiceOption, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	err := newUserRoot(t, server, &stdout, opts).Run(context.Background(), append([]string{"testcli"}, args...))
+	return stdout.String(), err
+}
@@ -0,0 +1,54 @@
+package simple_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runGetUserWithTimings runs `user-service get` with extra root args and
+// returns stdout and stderr.
+func runGetUserWithTimings(t *testing.T, rootArgs ...string) (string, string) {
+	t.Helper()
+	ctx := context.Background()
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(_ context.Context, req *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{User: &simple.User{Id: req.GetId()}}, nil
+		},
+	}
+	var stdout, stderr bytes.Buffer
+	rootCmd := newUserRoot(t, mock, &stdout, nil)
+	rootCmd.ErrWriter = &stderr
+
+	args := append(append([]string{"testcli"}, rootArgs...),
+		"user-service", "get", "--id", "7", "--db-url", "postgres://localhost/test")
+	require.NoError(t, rootCmd.Run(ctx, args))
+	return stdout.String(), stderr.String()
+}
+
+// TestTimings_ReportsPhases tests that --timings prints each phase of a local
+// call, in order, followed by the total.
+func TestTimings_ReportsPhases(t *testing.T) {
+	stdout, stderr := runGetUserWithTimings(t, "--timings")
+
+	assert.Contains(t, stdout, `"id":"7"`)
+	require.True(t, strings.HasPrefix(stderr, "Timings:\n"), stderr)
+
+	var phases []string
+	for _, line := range strings.Split(strings.TrimSpace(stderr), "\n")[1:] {
+		fields := strings.Fields(line)
+		phases = append(phases, strings.Join(fields[:len(fields)-1], " "))
+	}
+	assert.Equal(t, []string{"request build", "config load", "service call", "format", "total"}, phases)
+}
+
+// TestTimings_OffByDefault tests that nothing is printed without --timings.
+func TestTimings_OffByDefault(t *testing.T) {
+	_, stderr := runGetUserWithTimings(t)
+	assert.Empty(t, stderr)
+}
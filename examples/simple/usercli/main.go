@@ -82,6 +82,34 @@ func (s *adminService) HealthCheck(_ context.Context, _ *simple.AdminRequest) (*
 	}, nil
 }
 
+func (s *adminService) Ping(_ context.Context, _ *simple.AdminRequest) (*simple.AdminResponse, error) {
+	return &simple.AdminResponse{
+		Message: "pong",
+		Success: true,
+	}, nil
+}
+
+func (s *adminService) Diagnostics(_ context.Context, _ *simple.AdminRequest) (*simple.AdminResponse, error) {
+	return &simple.AdminResponse{
+		Message: "All subsystems nominal",
+		Success: true,
+	}, nil
+}
+
+func (s *adminService) RunCheck(_ context.Context, req *simple.CheckRequest) (*simple.CheckResponse, error) {
+	if req.Name != "database" {
+		return &simple.CheckResponse{Name: req.Name, StatusCode: 3, Message: "unknown check"}, nil
+	}
+	return &simple.CheckResponse{Name: req.Name, Message: "database reachable"}, nil
+}
+
+func (s *adminService) PurgeCache(_ context.Context, _ *simple.AdminRequest) (*simple.AdminResponse, error) {
+	return &simple.AdminResponse{
+		Message: "Cache purged",
+		Success: true,
+	}, nil
+}
+
 func main() {
 	ctx := context.Background()
 
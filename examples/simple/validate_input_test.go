@@ -0,0 +1,85 @@
+package simple_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runValidateCreate runs `validate user-service create` on a request file
+// holding content and reports whether the service was called.
+func runValidateCreate(t *testing.T, fileName, content string) (string, bool, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), fileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	var called bool
+	mock := &mockUserServiceWithCapture{
+		onCreateUser: func(*simple.CreateUserRequest) { called = true },
+	}
+	out, err := runUserCommand(t, mock, nil, "validate", "user-service", "create", "--input-file", path)
+	return out, called, err
+}
+
+// TestValidateInput tests that the validate subcommand checks a request file
+// against the request message and its field constraints without calling the
+// service.
+func TestValidateInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		fileName      string
+		content       string
+		errorContains []string
+		constraint    bool
+	}{
+		{
+			name:     "valid JSON",
+			fileName: "req.json",
+			content:  `{"name": "Test User", "email": "test@example.com", "plan": "pro", "age": 30}`,
+		},
+		{
+			name:     "valid YAML",
+			fileName: "req.yaml",
+			content:  "name: Test User\nemail: test@example.com\n",
+		},
+		{
+			name:          "unknown field",
+			fileName:      "req.json",
+			content:       `{"name": "Test User", "emial": "test@example.com"}`,
+			errorContains: []string{"emial"},
+		},
+		{
+			name:          "constraint violations",
+			fileName:      "req.json",
+			content:       `{"email": "not-an-email", "plan": "platinum"}`,
+			errorContains: []string{"--email", "does not match pattern", "--plan", "must be one of"},
+			constraint:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, called, err := runValidateCreate(t, tt.fileName, tt.content)
+			assert.False(t, called, "validate must not call the service")
+
+			if len(tt.errorContains) == 0 {
+				require.NoError(t, err)
+				assert.True(t, strings.HasSuffix(out, tt.fileName+": valid\n"), "unexpected output: %q", out)
+				return
+			}
+			require.Error(t, err)
+			if tt.constraint {
+				require.ErrorIs(t, err, protocli.ErrConstraintViolation)
+			}
+			for _, want := range tt.errorContains {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
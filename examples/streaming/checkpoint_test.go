@@ -0,0 +1,115 @@
+package streaming_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// interruptedExport streams items after the request's start_id up to id 6.
+// The first call fails after failAfter items, simulating a crash mid-export.
+type interruptedExport struct {
+	failAfter int
+	startIDs  []int64
+}
+
+func (e *interruptedExport) watch(req *streaming.WatchRequest, stream grpc.ServerStreamingServer[streaming.ItemEvent]) error {
+	e.startIDs = append(e.startIDs, req.GetStartId())
+	for id := req.GetStartId() + 1; id <= 6; id++ {
+		if len(e.startIDs) == 1 && id > int64(e.failAfter) {
+			return status.Error(codes.Unavailable, "connection lost")
+		}
+		if err := stream.Send(&streaming.ItemEvent{EventType: "created", Item: &streaming.Item{Id: id}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runWatchExport(t *testing.T, export *interruptedExport, args ...string) error {
+	t.Helper()
+	ctx := context.Background()
+	mock := &streaming.MockStreamingServiceServer{WatchItemsFunc: export.watch}
+	serviceCLI := streaming.StreamingServiceCommand(ctx, mock, protocli.WithOutputFormats(protocli.JSON()))
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+	return rootCmd.Run(ctx, append([]string{"streamcli", "streaming-service", "watch-items"}, args...))
+}
+
+func readExportedIDs(t *testing.T, path string) []int64 {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var ids []int64
+	for line := range strings.SplitSeq(strings.TrimSpace(string(data)), "\n") {
+		event := &streaming.ItemEvent{}
+		require.NoError(t, protojson.Unmarshal([]byte(line), event))
+		ids = append(ids, event.GetItem().GetId())
+	}
+	return ids
+}
+
+// TestServerStreaming_CheckpointResume tests that re-running an interrupted
+// export with --resume continues after the last written message, so the
+// output holds every message exactly once.
+func TestServerStreaming_CheckpointResume(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "export.jsonl")
+	checkpointPath := filepath.Join(dir, "export.checkpoint")
+	export := &interruptedExport{failAfter: 3}
+
+	err := runWatchExport(t, export, "--output", outPath, "--checkpoint-file", checkpointPath)
+	require.Error(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, readExportedIDs(t, outPath))
+	checkpoint, err := os.ReadFile(checkpointPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"messages":3,"token":"3"}`, string(checkpoint))
+
+	require.NoError(t, runWatchExport(t, export,
+		"--output", outPath, "--checkpoint-file", checkpointPath, "--resume",
+	))
+	assert.Equal(t, []int64{0, 3}, export.startIDs, "the resumed call starts after the checkpointed token")
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6}, readExportedIDs(t, outPath))
+
+	checkpoint, err = os.ReadFile(checkpointPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"messages":6,"token":"6"}`, string(checkpoint))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary checkpoint files are left behind")
+}
+
+// TestServerStreaming_ResumeWithoutCheckpoint tests that --resume starts from
+// the beginning when no checkpoint has been written yet, and that it requires
+// --checkpoint-file.
+func TestServerStreaming_ResumeWithoutCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "export.jsonl")
+	export := &interruptedExport{failAfter: 6}
+
+	require.NoError(t, runWatchExport(t, export,
+		"--output", outPath, "--checkpoint-file", filepath.Join(dir, "export.checkpoint"), "--resume",
+	))
+	assert.Equal(t, []int64{1, 2, 3, 4, 5, 6}, readExportedIDs(t, outPath))
+
+	exitCode := -1
+	origExiter := cli.OsExiter
+	t.Cleanup(func() { cli.OsExiter = origExiter })
+	cli.OsExiter = func(code int) { exitCode = code }
+
+	err := runWatchExport(t, export, "--output", outPath, "--resume")
+	require.EqualError(t, err, "--resume requires --checkpoint-file")
+	assert.Equal(t, 3, exitCode)
+}
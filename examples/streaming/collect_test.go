@@ -0,0 +1,65 @@
+package streaming_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func runCollectedListItems(t *testing.T, impl streaming.StreamingServiceServer, args ...string) []byte {
+	t.Helper()
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, impl,
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+
+	outPath := t.TempDir() + "/output.json"
+	require.NoError(t, rootCmd.Run(ctx, append([]string{
+		"streamcli", "streaming-service", "list-items",
+		"--format", "json",
+		"--output", outPath,
+	}, args...)))
+
+	output, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	return output
+}
+
+// TestServerStreaming_Collect tests that --collect writes the whole stream
+// once, as a ListItemsResults container whose items hold every streamed
+// message in order.
+func TestServerStreaming_Collect(t *testing.T) {
+	output := runCollectedListItems(t, streaming.NewStreamingService(), "--limit", "3", "--collect")
+
+	var results struct {
+		Items []struct {
+			Item struct {
+				ID string `json:"id"`
+			} `json:"item"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(output, &results), "output must be a single JSON document: %s", output)
+	require.Len(t, results.Items, 3)
+	for i, want := range []string{"1", "2", "3"} {
+		assert.Equal(t, want, results.Items[i].Item.ID)
+	}
+}
+
+func TestServerStreaming_CollectEmptyStream(t *testing.T) {
+	empty := &streaming.MockStreamingServiceServer{
+		ListItemsFunc: func(*streaming.ListItemsRequest, grpc.ServerStreamingServer[streaming.ItemResponse]) error {
+			return nil
+		},
+	}
+	output := runCollectedListItems(t, empty, "--collect")
+	assert.JSONEq(t, `{"items":[]}`, string(output))
+}
@@ -0,0 +1,66 @@
+package streaming_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmptyRequest_CatalogStats tests that a method taking google.protobuf.Empty
+// runs without any request flags, with or without an --input-file.
+func TestEmptyRequest_CatalogStats(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name string
+		args []string
+	}{
+		{name: "no flags"},
+		{name: "input file", args: []string{"--input-file", writeFile(t, "req.json", "{}")}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+				protocli.WithOutputFormats(protocli.JSON()),
+			)
+			rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+			require.NoError(t, err)
+
+			outPath := t.TempDir() + "/output.json"
+			args := append([]string{"streamcli", "streaming-service", "catalog-stats", "--output", outPath}, tt.args...)
+			require.NoError(t, rootCmd.Run(ctx, args))
+
+			output, err := os.ReadFile(outPath)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"itemCount":5,"categories":["watched"]}`, string(output))
+		})
+	}
+}
+
+func TestEmptyRequest_CatalogStatsHelp(t *testing.T) {
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService())
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+
+	var help bytes.Buffer
+	rootCmd.Writer = &help
+	require.NoError(t, rootCmd.Run(ctx, []string{"streamcli", "streaming-service", "catalog-stats", "--help"}))
+
+	assert.Contains(t, help.String(), "Show catalog statistics")
+	assert.Contains(t, help.String(), "Read request from file (JSON or YAML)")
+	assert.NotContains(t, help.String(), "CLI flags override file values", "there are no request flags to override with")
+}
+
+// writeFile writes content to name in a temp dir and returns its path.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := t.TempDir() + "/" + name
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
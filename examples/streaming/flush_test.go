@@ -0,0 +1,78 @@
+package streaming_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flushCountingWriter records the writes and flushes that reach it.
+type flushCountingWriter struct {
+	bytes.Buffer
+	writes  int
+	flushes int
+}
+
+func (w *flushCountingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func (w *flushCountingWriter) Flush() error {
+	w.flushes++
+	return nil
+}
+
+func runFlushedListItems(t *testing.T, args ...string) (*flushCountingWriter, error) {
+	t.Helper()
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+
+	// Set the writer on the leaf command, which otherwise writes to stdout
+	out := &flushCountingWriter{}
+	rootCmd.Command("streaming-service").Command("list-items").Writer = out
+	err = rootCmd.Run(ctx, append([]string{
+		"streamcli", "streaming-service", "list-items", "--format", "json", "--limit", "3",
+	}, args...))
+	return out, err
+}
+
+// TestServerStreaming_Flush tests that --flush controls whether the output is
+// flushed after each streamed message. Output still buffered when the stream
+// ends is flushed then.
+func TestServerStreaming_Flush(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantFlushes int
+	}{
+		{name: "always flushes per message", args: []string{"--flush", "always"}, wantFlushes: 3},
+		{name: "never flushes only at the end", args: []string{"--flush", "never"}, wantFlushes: 1},
+		{name: "auto does not flush per message when not a terminal", args: nil, wantFlushes: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runFlushedListItems(t, tt.args...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFlushes, out.flushes)
+			assert.Equal(t, 3, bytes.Count(out.Bytes(), []byte(`"item"`)), "every message is written: %s", out.String())
+			if tt.wantFlushes == 1 {
+				assert.Equal(t, 1, out.writes, "buffered output reaches the writer in one write")
+			}
+		})
+	}
+}
+
+func TestServerStreaming_FlushUnknownMode(t *testing.T) {
+	_, err := runFlushedListItems(t, "--flush", "sometimes")
+	require.ErrorIs(t, err, protocli.ErrUnknownFlushMode)
+}
@@ -0,0 +1,61 @@
+package streaming_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// panickingFormat renders the first message and panics on the second,
+// simulating a buggy custom format failing mid-stream.
+type panickingFormat struct {
+	calls int
+}
+
+func (f *panickingFormat) Name() string { return "boom" }
+
+func (f *panickingFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, _ proto.Message) error {
+	f.calls++
+	if f.calls > 1 {
+		panic("nil map write")
+	}
+	_, err := w.Write([]byte("ok"))
+	return err
+}
+
+func runPanickingFormat(t *testing.T, opts ...protocli.RootOption) error {
+	t.Helper()
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+		protocli.WithOutputFormats(&panickingFormat{}),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli", append([]protocli.RootOption{protocli.Service(serviceCLI)}, opts...)...)
+	require.NoError(t, err)
+
+	return rootCmd.Run(ctx, []string{
+		"streamcli", "streaming-service", "list-items",
+		"--format", "boom",
+		"--output", t.TempDir() + "/output.txt",
+	})
+}
+
+// TestServerStreaming_FormatPanicRecovered tests that a panicking format ends
+// the stream with an error instead of crashing the CLI.
+func TestServerStreaming_FormatPanicRecovered(t *testing.T) {
+	err := runPanickingFormat(t)
+	require.ErrorIs(t, err, protocli.ErrFormatPanic)
+	assert.Contains(t, err.Error(), `"boom": nil map write`)
+}
+
+func TestServerStreaming_FormatPanicRecoveryDisabled(t *testing.T) {
+	assert.PanicsWithValue(t, "nil map write", func() {
+		_ = runPanickingFormat(t, protocli.WithFormatPanicRecovery(false))
+	})
+}
@@ -0,0 +1,61 @@
+package streaming_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestServerStreaming_Raw tests that --raw writes each message as a 4-byte
+// big-endian length prefix followed by its protobuf encoding.
+func TestServerStreaming_Raw(t *testing.T) {
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+
+	outPath := t.TempDir() + "/output.bin"
+	require.NoError(t, rootCmd.Run(ctx, []string{
+		"streamcli", "streaming-service", "list-items",
+		"--limit", "3",
+		"--output", outPath,
+		"--raw",
+	}))
+
+	output, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	r := bytes.NewReader(output)
+
+	var ids []int64
+	for {
+		msg := &streaming.ItemResponse{}
+		err := protocli.ReadRawMessage(r, msg)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		ids = append(ids, msg.GetItem().GetId())
+	}
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+}
+
+func TestUnit_ReadRawMessage_Truncated(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, protocli.WriteRawMessage(&buf, &streaming.ItemResponse{
+		Item: &streaming.Item{Id: 1, Name: "Item 1"},
+	}))
+	truncated := bytes.NewReader(buf.Bytes()[:buf.Len()-1])
+
+	err := protocli.ReadRawMessage(truncated, &streaming.ItemResponse{})
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
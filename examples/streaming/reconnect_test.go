@@ -0,0 +1,241 @@
+package streaming_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyWatchServer drops every WatchItems stream after a single event. The
+// first stream fails with Unavailable, the second ends cleanly (EOF), the third
+// fails again and the fourth cancels the test context so the CLI stops.
+type flakyWatchServer struct {
+	streaming.UnimplementedStreamingServiceServer
+
+	mu       sync.Mutex
+	startIDs []int64
+	cancel   context.CancelFunc
+}
+
+func (s *flakyWatchServer) WatchItems(req *streaming.WatchRequest, stream grpc.ServerStreamingServer[streaming.ItemEvent]) error {
+	s.mu.Lock()
+	s.startIDs = append(s.startIDs, req.GetStartId())
+	call := len(s.startIDs)
+	s.mu.Unlock()
+
+	if call == 4 {
+		s.cancel()
+		<-stream.Context().Done()
+		return stream.Context().Err()
+	}
+
+	id := req.GetStartId() + 1
+	if err := stream.Send(&streaming.ItemEvent{EventType: "created", Item: &streaming.Item{Id: id}}); err != nil {
+		return err
+	}
+	if call == 2 {
+		return nil
+	}
+	return status.Error(codes.Unavailable, "connection dropped")
+}
+
+func startWatchServer(t *testing.T, srv streaming.StreamingServiceServer) string {
+	t.Helper()
+	lis, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "localhost:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	streaming.RegisterStreamingServiceServer(server, srv)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func runWatchItems(ctx context.Context, t *testing.T, stderr *bytes.Buffer, args ...string) (string, error) {
+	t.Helper()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+	rootCmd.ErrWriter = stderr
+
+	outPath := t.TempDir() + "/output.txt"
+	runErr := rootCmd.Run(ctx, append([]string{
+		"streamcli", "streaming-service", "watch-items",
+		"--format", "json",
+		"--output", outPath,
+	}, args...))
+
+	output, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	return string(output), runErr
+}
+
+// TestServerStreaming_Reconnect tests that --reconnect re-establishes dropped
+// and completed streams, resuming from the last item ID via the
+// resume_token_field annotation.
+func TestServerStreaming_Reconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := &flakyWatchServer{cancel: cancel}
+	addr := startWatchServer(t, srv)
+
+	var stderr bytes.Buffer
+	output, err := runWatchItems(ctx, t, &stderr,
+		"--remote", addr,
+		"--reconnect",
+		"--reconnect-backoff", "1ms",
+	)
+	require.ErrorIs(t, err, context.Canceled)
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(t, lines, 3)
+	for i, line := range lines {
+		assert.Contains(t, line, fmt.Sprintf(`"id":"%d"`, i+1))
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, []int64{0, 1, 2, 3}, srv.startIDs, "each reconnect resumes after the last item")
+
+	notices := stderr.String()
+	assert.Contains(t, notices, "connection dropped")
+	assert.Contains(t, notices, "stream ended")
+	assert.Equal(t, 3, strings.Count(notices, "reconnecting in"))
+}
+
+func TestServerStreaming_ReconnectQuiet(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	addr := startWatchServer(t, &flakyWatchServer{cancel: cancel})
+
+	var stderr bytes.Buffer
+	_, err := runWatchItems(ctx, t, &stderr,
+		"--remote", addr,
+		"--reconnect",
+		"--reconnect-backoff", "1ms",
+		"--quiet",
+	)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, stderr.String())
+}
+
+// TestServerStreaming_QuietSuppressesBanner tests that --quiet given after a
+// streaming command sets the global flag, silencing the banner as well as
+// the reconnect notices.
+func TestServerStreaming_QuietSuppressesBanner(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	addr := startWatchServer(t, &flakyWatchServer{cancel: cancel})
+
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli",
+		protocli.Service(serviceCLI),
+		protocli.WithStartupBanner("== streamcli =="),
+	)
+	require.NoError(t, err)
+	var stderr bytes.Buffer
+	rootCmd.ErrWriter = &stderr
+
+	err = rootCmd.Run(ctx, []string{
+		"streamcli", "streaming-service", "watch-items",
+		"--format", "json",
+		"--output", t.TempDir() + "/output.txt",
+		"--remote", addr,
+		"--reconnect",
+		"--reconnect-backoff", "1ms",
+		"--quiet",
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, stderr.String())
+}
+
+// TestServerStreaming_NoReconnect tests that a dropped stream is an error
+// without --reconnect.
+func TestServerStreaming_NoReconnect(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := &flakyWatchServer{cancel: cancel}
+	addr := startWatchServer(t, srv)
+
+	var stderr bytes.Buffer
+	output, err := runWatchItems(ctx, t, &stderr, "--remote", addr)
+	require.Error(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, strings.Count(output, `"id"`))
+	assert.Len(t, srv.startIDs, 1)
+}
+
+// failingWatchServer fails every WatchItems stream with code before sending
+// anything.
+type failingWatchServer struct {
+	streaming.UnimplementedStreamingServiceServer
+
+	code  codes.Code
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *failingWatchServer) WatchItems(_ *streaming.WatchRequest, _ grpc.ServerStreamingServer[streaming.ItemEvent]) error {
+	s.mu.Lock()
+	s.calls++
+	s.mu.Unlock()
+	return status.Error(s.code, "watch failed")
+}
+
+// TestServerStreaming_ReconnectPermanentError tests that --reconnect doesn't
+// retry errors that would fail the same way again.
+func TestServerStreaming_ReconnectPermanentError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := &failingWatchServer{code: codes.PermissionDenied}
+	addr := startWatchServer(t, srv)
+
+	var stderr bytes.Buffer
+	_, err := runWatchItems(ctx, t, &stderr, "--remote", addr, "--reconnect", "--reconnect-backoff", "1ms")
+	require.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, 1, srv.calls)
+	assert.NotContains(t, stderr.String(), "reconnecting in")
+}
+
+// TestServerStreaming_ReconnectGivesUp tests that --reconnect stops after
+// MaxReconnectAttempts attempts that receive nothing.
+func TestServerStreaming_ReconnectGivesUp(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := &failingWatchServer{code: codes.Unavailable}
+	addr := startWatchServer(t, srv)
+
+	var stderr bytes.Buffer
+	_, err := runWatchItems(ctx, t, &stderr, "--remote", addr, "--reconnect", "--reconnect-backoff", "1ms")
+	require.ErrorIs(t, err, protocli.ErrReconnectFailed)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1+protocli.MaxReconnectAttempts, srv.calls)
+	assert.Equal(t, protocli.MaxReconnectAttempts, strings.Count(stderr.String(), "reconnecting in"))
+}
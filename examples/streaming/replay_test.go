@@ -0,0 +1,91 @@
+package streaming_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// runListItems runs list-items against mock with the given root options and
+// returns stdout.
+func runListItems(t *testing.T, mock *streaming.MockStreamingServiceServer, opts []protocli.RootOption, args ...string) (string, error) {
+	t.Helper()
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, mock, protocli.WithOutputFormats(protocli.JSON()))
+	rootCmd, err := protocli.RootCommand("streamcli", append([]protocli.RootOption{protocli.Service(serviceCLI)}, opts...)...)
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	rootCmd.Writer = &stdout
+	for _, sub := range rootCmd.Commands {
+		sub.Writer = &stdout
+		for _, leaf := range sub.Commands {
+			leaf.Writer = &stdout
+		}
+	}
+	err = rootCmd.Run(ctx, append([]string{"streamcli"}, args...))
+	return stdout.String(), err
+}
+
+// TestReplay_StreamRecordThenReplay tests that a recorded stream replays with
+// identical output without calling the implementation.
+func TestReplay_StreamRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	recorder := &streaming.MockStreamingServiceServer{
+		ListItemsFunc: func(req *streaming.ListItemsRequest, stream grpc.ServerStreamingServer[streaming.ItemResponse]) error {
+			for i := range 3 {
+				if err := stream.Send(&streaming.ItemResponse{
+					Item:    &streaming.Item{Id: int64(i + 1), Name: fmt.Sprintf("item-%d", i+1), Category: req.GetCategory()},
+					Message: "recorded",
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	args := []string{"streaming-service", "list-items", "--category", "tools", "--format", "json"}
+
+	recorded, err := runListItems(t, recorder, []protocli.RootOption{protocli.WithRecord(dir)}, args...)
+	require.NoError(t, err)
+	require.Len(t, recorder.Calls(), 1)
+
+	impl := &streaming.MockStreamingServiceServer{}
+	replayOpts := []protocli.RootOption{protocli.WithReplay(dir)}
+	replayed, err := runListItems(t, impl, replayOpts, append([]string{"--replay"}, args...)...)
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+	assert.Empty(t, impl.Calls(), "the implementation is not called")
+
+	_, err = runListItems(t, impl, replayOpts, "--replay", "streaming-service", "list-items", "--category", "other")
+	require.ErrorIs(t, err, protocli.ErrNoRecording, "a different request has no recording")
+}
+
+// TestReplay_FailedStreamNotRecorded tests that a stream ending in an error
+// leaves no recording behind.
+func TestReplay_FailedStreamNotRecorded(t *testing.T) {
+	dir := t.TempDir()
+	failing := &streaming.MockStreamingServiceServer{
+		ListItemsFunc: func(_ *streaming.ListItemsRequest, stream grpc.ServerStreamingServer[streaming.ItemResponse]) error {
+			if err := stream.Send(&streaming.ItemResponse{Message: "partial"}); err != nil {
+				return err
+			}
+			return errors.New("boom")
+		},
+	}
+	args := []string{"streaming-service", "list-items"}
+
+	_, err := runListItems(t, failing, []protocli.RootOption{protocli.WithRecord(dir)}, args...)
+	require.Error(t, err)
+
+	_, err = runListItems(t, failing, []protocli.RootOption{protocli.WithReplay(dir)}, append([]string{"--replay"}, args...)...)
+	require.ErrorIs(t, err, protocli.ErrNoRecording)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type StreamingService struct { //nolint:revive // Name matches proto-generated type
@@ -34,6 +35,7 @@ func (s *StreamingService) ListItems(req *ListItemsRequest, stream grpc.ServerSt
 		if err := stream.Send(&ItemResponse{
 			Item:    &items[i],
 			Message: "Success",
+			Total:   int32(limit), //nolint:gosec // limit is bounded by len(items)
 		}); err != nil {
 			return err
 		}
@@ -72,6 +74,10 @@ func (s *StreamingService) WatchItems(req *WatchRequest, stream grpc.ServerStrea
 	return nil
 }
 
+func (s *StreamingService) GetCatalogStats(_ context.Context, _ *emptypb.Empty) (*CatalogStats, error) {
+	return &CatalogStats{ItemCount: 5, Categories: []string{"watched"}}, nil
+}
+
 func (s *StreamingService) Register(_ context.Context) error {
 	return nil
 }
@@ -0,0 +1,44 @@
+package streaming_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestServerStreaming_ShowInput tests that --show-input writes the request to
+// stderr before the stream is opened, leaving the streamed output untouched.
+func TestServerStreaming_ShowInput(t *testing.T) {
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+
+	var stderr bytes.Buffer
+	rootCmd.ErrWriter = &stderr
+
+	outPath := t.TempDir() + "/output.txt"
+	require.NoError(t, rootCmd.Run(ctx, []string{
+		"streamcli", "streaming-service", "list-items",
+		"--limit", "2",
+		"--format", "json",
+		"--output", outPath,
+		"--show-input",
+	}))
+
+	assert.Regexp(t, `"limit":\s*2`, stderr.String())
+
+	output, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(output), "\n"))
+	assert.NotContains(t, string(output), "limit")
+}
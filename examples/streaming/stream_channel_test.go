@@ -0,0 +1,88 @@
+package streaming_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// collectEvents drains events and returns their item IDs followed by the
+// stream's error, failing the test if either channel stays open.
+func collectEvents(t *testing.T, events <-chan *streaming.ItemEvent, errs <-chan error) ([]int64, error) {
+	t.Helper()
+	var ids []int64
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				select {
+				case err := <-errs:
+					return ids, err
+				case <-timeout:
+					t.Fatal("error channel was not closed")
+				}
+			}
+			ids = append(ids, event.GetItem().GetId())
+		case <-timeout:
+			t.Fatal("event channel was not closed")
+		}
+	}
+}
+
+// TestStreamChannel_DeliversAllEvents tests that the generated
+// StreamingServiceWatchItems helper delivers every streamed message in order
+// and closes the error channel without an error.
+func TestStreamChannel_DeliversAllEvents(t *testing.T) {
+	export := &interruptedExport{failAfter: 6}
+	mock := &streaming.MockStreamingServiceServer{WatchItemsFunc: export.watch}
+
+	events, errs := streaming.StreamingServiceWatchItems(context.Background(), mock, &streaming.WatchRequest{StartId: 2})
+	ids, err := collectEvents(t, events, errs)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 4, 5, 6}, ids)
+	assert.Equal(t, []int64{2}, export.startIDs)
+
+	_, open := <-errs
+	assert.False(t, open, "the error channel is closed after the stream ends")
+}
+
+func TestStreamChannel_DeliversMethodError(t *testing.T) {
+	export := &interruptedExport{failAfter: 2}
+	mock := &streaming.MockStreamingServiceServer{WatchItemsFunc: export.watch}
+
+	events, errs := streaming.StreamingServiceWatchItems(context.Background(), mock, &streaming.WatchRequest{})
+	ids, err := collectEvents(t, events, errs)
+	assert.Equal(t, []int64{1, 2}, ids, "messages sent before the failure are delivered")
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+// TestStreamChannel_CancelStopsStream tests that cancelling the context ends
+// an endless stream once the consumer stops reading.
+func TestStreamChannel_CancelStopsStream(t *testing.T) {
+	mock := &streaming.MockStreamingServiceServer{
+		WatchItemsFunc: func(_ *streaming.WatchRequest, stream grpc.ServerStreamingServer[streaming.ItemEvent]) error {
+			for id := int64(1); ; id++ {
+				if err := stream.Send(&streaming.ItemEvent{Item: &streaming.Item{Id: id}}); err != nil {
+					return err
+				}
+			}
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errs := streaming.StreamingServiceWatchItems(ctx, mock, &streaming.WatchRequest{})
+	first := <-events
+	assert.Equal(t, int64(1), first.GetItem().GetId())
+	cancel()
+
+	_, err := collectEvents(t, events, errs)
+	require.ErrorIs(t, err, context.Canceled)
+}
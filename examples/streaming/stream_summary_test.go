@@ -0,0 +1,63 @@
+package streaming_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestServerStreaming_StreamSummary tests that --stream-summary writes the
+// message count and duration to stderr, leaving stdout untouched.
+func TestServerStreaming_StreamSummary(t *testing.T) {
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+
+	var stderr bytes.Buffer
+	rootCmd.ErrWriter = &stderr
+
+	outPath := t.TempDir() + "/output.txt"
+	require.NoError(t, rootCmd.Run(ctx, []string{
+		"streamcli", "streaming-service", "list-items",
+		"--limit", "3",
+		"--format", "json",
+		"--output", outPath,
+		"--stream-summary",
+	}))
+
+	assert.Regexp(t, `^3 messages in [0-9.]+m?s\n$`, stderr.String())
+
+	output, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(output), "\n"))
+	assert.NotContains(t, string(output), "messages in")
+}
+
+func TestServerStreaming_StreamSummaryOff(t *testing.T) {
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+
+	var stderr bytes.Buffer
+	rootCmd.ErrWriter = &stderr
+
+	require.NoError(t, rootCmd.Run(ctx, []string{
+		"streamcli", "streaming-service", "list-items",
+		"--limit", "1",
+		"--output", t.TempDir() + "/output.txt",
+	}))
+	assert.Empty(t, stderr.String())
+}
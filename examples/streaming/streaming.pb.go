@@ -10,6 +10,7 @@ import (
 	_ "github.com/drewfead/proto-cli/proto/cli/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -103,6 +104,7 @@ type ItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Item          *Item                  `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"` // Total number of items in the stream (drives progress reporting)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -151,6 +153,13 @@ func (x *ItemResponse) GetMessage() string {
 	return ""
 }
 
+func (x *ItemResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
 type Item struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -259,7 +268,7 @@ type ItemEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // "created", "updated", "deleted"
 	Item          *Item                  `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix seconds (drives --since/--until)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -315,11 +324,63 @@ func (x *ItemEvent) GetTimestamp() int64 {
 	return 0
 }
 
+type CatalogStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemCount     int32                  `protobuf:"varint,1,opt,name=item_count,json=itemCount,proto3" json:"item_count,omitempty"`
+	Categories    []string               `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogStats) Reset() {
+	*x = CatalogStats{}
+	mi := &file_examples_streaming_streaming_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogStats) ProtoMessage() {}
+
+func (x *CatalogStats) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_streaming_streaming_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogStats.ProtoReflect.Descriptor instead.
+func (*CatalogStats) Descriptor() ([]byte, []int) {
+	return file_examples_streaming_streaming_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CatalogStats) GetItemCount() int32 {
+	if x != nil {
+		return x.ItemCount
+	}
+	return 0
+}
+
+func (x *CatalogStats) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
 var File_examples_streaming_streaming_proto protoreflect.FileDescriptor
 
 const file_examples_streaming_streaming_proto_rawDesc = "" +
 	"\n" +
-	"\"examples/streaming/streaming.proto\x12\tstreaming\x1a\x16proto/cli/v1/cli.proto\"\xa3\x03\n" +
+	"\"examples/streaming/streaming.proto\x12\tstreaming\x1a\x1bgoogle/protobuf/empty.proto\x1a\x16proto/cli/v1/cli.proto\"\xa3\x03\n" +
 	"\x10ListItemsRequest\x12>\n" +
 	"\bcategory\x18\x01 \x01(\tB\"\x92\xb5\x18\x1e\n" +
 	"\bcategory\x1a\x12Filter by categoryR\bcategory\x126\n" +
@@ -334,10 +395,11 @@ const file_examples_streaming_streaming_proto_rawDesc = "" +
 	"\a_offsetB\n" +
 	"\n" +
 	"\b_sort_byB\x12\n" +
-	"\x10_include_deleted\"M\n" +
+	"\x10_include_deleted\"c\n" +
 	"\fItemResponse\x12#\n" +
 	"\x04item\x18\x01 \x01(\v2\x0f.streaming.ItemR\x04item\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"F\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"F\n" +
 	"\x04Item\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
@@ -349,14 +411,22 @@ const file_examples_streaming_streaming_proto_rawDesc = "" +
 	"\n" +
 	"event_type\x18\x01 \x01(\tR\teventType\x12#\n" +
 	"\x04item\x18\x02 \x01(\v2\x0f.streaming.ItemR\x04item\x12\x1c\n" +
-	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp2\xb2\x02\n" +
-	"\x10StreamingService\x12s\n" +
-	"\tListItems\x12\x1b.streaming.ListItemsRequest\x1a\x17.streaming.ItemResponse\".\x8a\xb5\x18*\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\"M\n" +
+	"\fCatalogStats\x12\x1d\n" +
+	"\n" +
+	"item_count\x18\x01 \x01(\x05R\titemCount\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\tR\n" +
+	"categories2\xcb\x03\n" +
+	"\x10StreamingService\x12z\n" +
+	"\tListItems\x12\x1b.streaming.ListItemsRequest\x1a\x17.streaming.ItemResponse\"5\x8a\xb5\x181\n" +
 	"\n" +
-	"list-items\x12\x1cStream items from the server0\x01\x12u\n" +
+	"list-items\x12\x1cStream items from the server:\x05total0\x01\x12\x94\x01\n" +
 	"\n" +
-	"WatchItems\x12\x17.streaming.WatchRequest\x1a\x14.streaming.ItemEvent\"6\x8a\xb5\x182\n" +
-	"\vwatch-items\x12#Watch for item changes in real-time0\x01\x1a2\x82\xb5\x18.\n" +
+	"WatchItems\x12\x17.streaming.WatchRequest\x1a\x14.streaming.ItemEvent\"U\x8a\xb5\x18Q\n" +
+	"\vwatch-items\x12#Watch for item changes in real-timeZ\aitem.idb\bstart_id\xa2\x01\ttimestamp0\x01\x12p\n" +
+	"\x0fGetCatalogStats\x12\x16.google.protobuf.Empty\x1a\x17.streaming.CatalogStats\",\x8a\xb5\x18(\n" +
+	"\rcatalog-stats\x12\x17Show catalog statistics\x1a2\x82\xb5\x18.\n" +
 	"\x11streaming-service\x12\x19Example streaming serviceB2Z0github.com/drewfead/proto-cli/examples/streamingb\x06proto3"
 
 var (
@@ -371,23 +441,27 @@ func file_examples_streaming_streaming_proto_rawDescGZIP() []byte {
 	return file_examples_streaming_streaming_proto_rawDescData
 }
 
-var file_examples_streaming_streaming_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_examples_streaming_streaming_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_examples_streaming_streaming_proto_goTypes = []any{
 	(*ListItemsRequest)(nil), // 0: streaming.ListItemsRequest
 	(*ItemResponse)(nil),     // 1: streaming.ItemResponse
 	(*Item)(nil),             // 2: streaming.Item
 	(*WatchRequest)(nil),     // 3: streaming.WatchRequest
 	(*ItemEvent)(nil),        // 4: streaming.ItemEvent
+	(*CatalogStats)(nil),     // 5: streaming.CatalogStats
+	(*emptypb.Empty)(nil),    // 6: google.protobuf.Empty
 }
 var file_examples_streaming_streaming_proto_depIdxs = []int32{
 	2, // 0: streaming.ItemResponse.item:type_name -> streaming.Item
 	2, // 1: streaming.ItemEvent.item:type_name -> streaming.Item
 	0, // 2: streaming.StreamingService.ListItems:input_type -> streaming.ListItemsRequest
 	3, // 3: streaming.StreamingService.WatchItems:input_type -> streaming.WatchRequest
-	1, // 4: streaming.StreamingService.ListItems:output_type -> streaming.ItemResponse
-	4, // 5: streaming.StreamingService.WatchItems:output_type -> streaming.ItemEvent
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
+	6, // 4: streaming.StreamingService.GetCatalogStats:input_type -> google.protobuf.Empty
+	1, // 5: streaming.StreamingService.ListItems:output_type -> streaming.ItemResponse
+	4, // 6: streaming.StreamingService.WatchItems:output_type -> streaming.ItemEvent
+	5, // 7: streaming.StreamingService.GetCatalogStats:output_type -> streaming.CatalogStats
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
 	2, // [2:2] is the sub-list for extension extendee
 	0, // [0:2] is the sub-list for field type_name
@@ -405,7 +479,7 @@ func file_examples_streaming_streaming_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_examples_streaming_streaming_proto_rawDesc), len(file_examples_streaming_streaming_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -8,12 +8,13 @@ import (
 	protocli "github.com/drewfead/proto-cli"
 	v3 "github.com/urfave/cli/v3"
 	grpc "google.golang.org/grpc"
-	insecure "google.golang.org/grpc/credentials/insecure"
 	metadata "google.golang.org/grpc/metadata"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	"io"
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 )
 
 // getStreamingServiceOutputWriter opens the specified output file or returns cmd.Writer (if set) or stdout
@@ -28,7 +29,11 @@ func getStreamingServiceOutputWriter(cmd *v3.Command, path string) (io.Writer, e
 		}
 		return os.Stdout, nil
 	}
-	return os.Create(path)
+	path, err := protocli.OutputPath(cmd, path)
+	if err != nil {
+		return nil, err
+	}
+	return protocli.OpenOutputFile(path, cmd.String("output-mode"), cmd.Bool("output-append"))
 }
 
 // localServerStream_StreamingService_ListItems is a helper type for local server streaming calls to ListItems
@@ -73,6 +78,27 @@ func (s *localServerStream_StreamingService_ListItems) RecvMsg(m any) error {
 	return fmt.Errorf("RecvMsg not supported on server streaming")
 }
 
+// StreamingServiceListItems runs impl.ListItems in-process.
+// The streamed messages arrive on the first channel, which is closed when the
+// stream ends; the second then yields the method's error, if any, and is
+// closed. Cancel ctx to stop the stream early.
+func StreamingServiceListItems(ctx context.Context, impl StreamingServiceServer, req *ListItemsRequest) (<-chan *ItemResponse, <-chan error) {
+	localStream := &localServerStream_StreamingService_ListItems{
+		ctx:       ctx,
+		errors:    make(chan error, 1),
+		responses: make(chan *ItemResponse),
+	}
+	go func() {
+		methodErr := impl.ListItems(req, localStream)
+		close(localStream.responses)
+		if methodErr != nil {
+			localStream.errors <- methodErr
+		}
+		close(localStream.errors)
+	}()
+	return localStream.responses, localStream.errors
+}
+
 // localServerStream_StreamingService_WatchItems is a helper type for local server streaming calls to WatchItems
 type localServerStream_StreamingService_WatchItems struct {
 	ctx       context.Context
@@ -115,6 +141,27 @@ func (s *localServerStream_StreamingService_WatchItems) RecvMsg(m any) error {
 	return fmt.Errorf("RecvMsg not supported on server streaming")
 }
 
+// StreamingServiceWatchItems runs impl.WatchItems in-process.
+// The streamed messages arrive on the first channel, which is closed when the
+// stream ends; the second then yields the method's error, if any, and is
+// closed. Cancel ctx to stop the stream early.
+func StreamingServiceWatchItems(ctx context.Context, impl StreamingServiceServer, req *WatchRequest) (<-chan *ItemEvent, <-chan error) {
+	localStream := &localServerStream_StreamingService_WatchItems{
+		ctx:       ctx,
+		errors:    make(chan error, 1),
+		responses: make(chan *ItemEvent),
+	}
+	go func() {
+		methodErr := impl.WatchItems(req, localStream)
+		close(localStream.responses)
+		if methodErr != nil {
+			localStream.errors <- methodErr
+		}
+		close(localStream.errors)
+	}()
+	return localStream.responses, localStream.errors
+}
+
 // StreamingServiceCommand creates a CLI for StreamingService with options
 // The implOrFactory parameter can be either a direct service implementation or a factory function
 func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) *protocli.ServiceCLI {
@@ -132,6 +179,16 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 	flags_list_items := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}, &v3.BoolFlag{
+		Name:  "reconnect",
+		Usage: "Re-establish the remote stream with backoff when it fails or ends (like tail -f)",
+	}, &v3.DurationFlag{
+		Name:  "reconnect-backoff",
+		Usage: "Delay before the first reconnect attempt; doubles on each failure up to 30s",
+		Value: protocli.DefaultReconnectBackoff,
 	}, &v3.StringFlag{
 		Name:  "format",
 		Usage: "Output format (use --format to see available formats)",
@@ -140,16 +197,42 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "delimiter",
 		Usage: "Delimiter between streamed messages",
 		Value: "\n",
+	}, &v3.BoolFlag{
+		Name:  "raw",
+		Usage: "Write each message as binary protobuf with a 4-byte big-endian length prefix instead of formatting it",
+	}, &v3.BoolFlag{
+		Name:  "collect",
+		Usage: "Write the whole stream once it ends, as a single ListItemsResults message with an items list",
+	}, &v3.BoolFlag{
+		Name:  "stream-summary",
+		Usage: "Print the message count and duration to stderr when the stream ends",
+	}, &v3.StringFlag{
+		Name:  "flush",
+		Usage: "Flush output after each message: auto (only when writing to a terminal), always, or never",
+		Value: "auto",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
 	}}
 
 	flags_list_items = append(flags_list_items, &v3.StringFlag{
@@ -174,19 +257,22 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_list_items = append(flags_list_items, flagConfigured.Flags()...)
-		}
-	}
+	flags_list_items = append(flags_list_items, protocli.FormatFlags(options.OutputFormats())...)
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return fmt.Errorf("unsupported argument: %s", cmd.Args().Get(0))
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -202,6 +288,7 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ListItemsRequest
 
@@ -215,7 +302,11 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("category") {
-					req.Category = cmd.String("category")
+					val, err := protocli.TransformFlagValue("streaming.ListItemsRequest", "category", cmd.String("category"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --category: %w", err)
+					}
+					req.Category = val
 				}
 				if cmd.IsSet("limit") {
 					req.Limit = cmd.Int32("limit")
@@ -225,7 +316,10 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 					req.Offset = &val
 				}
 				if cmd.IsSet("sort-by") {
-					val := cmd.String("sort-by")
+					val, err := protocli.TransformFlagValue("streaming.ListItemsRequest", "sort_by", cmd.String("sort-by"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --sort-by: %w", err)
+					}
 					req.SortBy = &val
 				}
 				if cmd.IsSet("include-deleted") {
@@ -237,11 +331,13 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				deserializer, hasDeserializer := options.FlagDeserializer("streaming.ListItemsRequest")
 				if hasDeserializer {
 					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
 					requestFlags := protocli.NewFlagContainer(cmd, "")
 					msg, err := deserializer(cmdCtx, requestFlags)
 					if err != nil {
 						return fmt.Errorf("custom deserializer failed: %w", err)
 					}
+					// Handle nil return from deserializer
 					if msg == nil {
 						return fmt.Errorf("custom deserializer returned nil message")
 					}
@@ -253,14 +349,23 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				} else {
 					// Use auto-generated flag parsing
 					req = &ListItemsRequest{}
-					req.Category = cmd.String("category")
+					if s := cmd.String("category"); s != "" {
+						val, err := protocli.TransformFlagValue("streaming.ListItemsRequest", "category", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --category: %w", err)
+						}
+						req.Category = val
+					}
 					req.Limit = cmd.Int32("limit")
 					if cmd.IsSet("offset") {
 						val := cmd.Int32("offset")
 						req.Offset = &val
 					}
 					if cmd.IsSet("sort-by") {
-						val := cmd.String("sort-by")
+						val, err := protocli.TransformFlagValue("streaming.ListItemsRequest", "sort_by", cmd.String("sort-by"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --sort-by: %w", err)
+						}
 						req.SortBy = &val
 					}
 					if cmd.IsSet("include-deleted") {
@@ -270,6 +375,21 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/streaming.StreamingService/ListItems", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/streaming.StreamingService/ListItems")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ItemResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
 			// Open output writer
 			outputWriter, err := getStreamingServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -279,6 +399,14 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				defer closer.Close()
 			}
 
+			// Buffer the output, flushing it after each message per --flush
+			streamOutput, err := protocli.NewStreamOutput(cmd, outputWriter)
+			if err != nil {
+				return err
+			}
+			defer streamOutput.Flush()
+			outputWriter = streamOutput
+
 			// Find the appropriate output format
 			formatName := cmd.String("format")
 			var outputFmt protocli.OutputFormat
@@ -295,40 +423,174 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				}
 				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), formatName)
 
 			// Get delimiter for separating streamed messages
 			delimiter := cmd.String("delimiter")
+			raw := cmd.Bool("raw")
+
+			// Gather the stream into one container message for --collect
+			collector, err := protocli.NewStreamCollector(cmd, "streaming.ListItemsResults", &ItemResponse{})
+			if err != nil {
+				return err
+			}
+
+			// Report progress using the total from the first streamed message
+			progress := protocli.NewStreamProgress(cmd, "total")
+			defer progress.Finish()
+
+			// Start timing for --stream-summary
+			streamStart := time.Now()
+
+			if protocli.Replaying(cmd) {
+				// Replay the recorded stream instead of calling the service
+				timings.Phase("service call")
+				replayed, err := protocli.ReplayStream[*ItemResponse](cmd, "/streaming.StreamingService/ListItems", req)
+				if err != nil {
+					return err
+				}
+				var messageCount int
+				for _, msg := range replayed {
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
+
+					// Format and write the message
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
+				return nil
+			}
+
+			// Record the stream for --replay (nil unless WithRecord is set)
+			recording := protocli.NewStreamRecording(cmd, "/streaming.StreamingService/ListItems", req)
 
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 
 			if remoteAddr != "" {
 				// Remote gRPC streaming call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewStreamingServiceClient(conn)
-				stream, err := client.ListItems(cmdCtx, req)
+				stream, err := client.ListItems(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("failed to start stream: %w", err)
 				}
 
+				// Re-establish the stream on failure or EOF when --reconnect is set
+				reconnector := protocli.NewStreamReconnector(cmd, "", "")
+
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					msg, recvErr := stream.Recv()
+					if recvErr != nil && reconnector.Enabled() {
+						stream, err = protocli.ReconnectStream(cmdCtx, reconnector, req, recvErr, func() (grpc.ServerStreamingClient[ItemResponse], error) {
+							return client.ListItems(cmdCtx, req, callOpts...)
+						})
+						if err != nil {
+							return fmt.Errorf("stream receive error: %w", err)
+						}
+						continue
+					}
 					if recvErr == io.EOF {
 						break
 					}
 					if recvErr != nil {
 						return fmt.Errorf("stream receive error: %w", recvErr)
 					}
+					progress.Observe(msg)
+					reconnector.Observe(msg)
+					recording.Observe(msg)
+
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
 
 					// Format and write the message
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 						return fmt.Errorf("format failed: %w", err)
 					}
 
@@ -337,29 +599,51 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 						return fmt.Errorf("failed to write delimiter: %w", err)
 					}
 					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				recording.Finish()
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
 				}
 
 				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 					if _, err := outputWriter.Write([]byte("\n")); err != nil {
 						return fmt.Errorf("failed to write final newline: %w", err)
 					}
 				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(StreamingServiceServer)
 
+				timings.Phase("service call")
+				// Cancel the stream if we stop receiving early (e.g. a format error)
+				streamCtx, cancelStream := context.WithCancel(cmdCtx)
+				defer cancelStream()
+
 				// Create local stream wrapper for direct call
 				localStream := &localServerStream_StreamingService_ListItems{
-					ctx:       cmdCtx,
-					errors:    make(chan error),
+					ctx:       streamCtx,
+					errors:    make(chan error, 1),
 					responses: make(chan *ItemResponse),
 				}
 
 				// Call streaming method in goroutine
 				go func() {
 					var methodErr error
-					methodErr = svcImpl.ListItems(req, localStream)
+					methodErr = protocli.RecoverMethodPanic(cmd, "StreamingService.ListItems", func() error {
+						return svcImpl.ListItems(req, localStream)
+					})
 					close(localStream.responses)
 					if methodErr != nil {
 						localStream.errors <- methodErr
@@ -370,6 +654,7 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					select {
 					case msg, ok := <-localStream.responses:
 						if !ok {
@@ -377,17 +662,55 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 							if streamErr := <-localStream.errors; streamErr != nil {
 								return fmt.Errorf("stream error: %w", streamErr)
 							}
+							recording.Finish()
+							timings.Phase("format")
+							// Write the --collect container now that the stream has ended
+							if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+								return err
+							}
+
 							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+							if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 								if _, err := outputWriter.Write([]byte("\n")); err != nil {
 									return fmt.Errorf("failed to write final newline: %w", err)
 								}
 							}
+							if err := streamOutput.Flush(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 							return nil
 						}
+						progress.Observe(msg)
+						recording.Observe(msg)
+
+						timings.Phase("format")
+						// Drop --fields-exclude paths from the response
+						msg, err := protocli.ExcludeResponseFields(cmd, msg)
+						if err != nil {
+							return err
+						}
+
+						// Hold the message for --collect; the container is written when the stream ends
+						if collector.Add(msg) {
+							messageCount++
+							continue
+						}
+
+						// Write length-prefixed binary frames for --raw
+						if raw {
+							if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+								return fmt.Errorf("failed to write message: %w", err)
+							}
+							messageCount++
+							if err := streamOutput.MessageDone(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							continue
+						}
 
 						// Format and write the message
-						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 							return fmt.Errorf("format failed: %w", err)
 						}
 
@@ -396,6 +719,10 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 							return fmt.Errorf("failed to write delimiter: %w", err)
 						}
 						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+
 					case <-cmdCtx.Done():
 						return cmdCtx.Err()
 					}
@@ -405,6 +732,10 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 			return nil
 		},
 		Flags: flags_list_items,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/streaming.StreamingService/ListItems",
+			protocli.RequestFlagsMetadataKey: []string{"category", "limit", "offset", "sort-by", "include-deleted"},
+		},
 		Name:  "list-items",
 		Usage: "Stream items from the server",
 	})
@@ -413,6 +744,16 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 	flags_watch_items := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}, &v3.BoolFlag{
+		Name:  "reconnect",
+		Usage: "Re-establish the remote stream with backoff when it fails or ends (like tail -f)",
+	}, &v3.DurationFlag{
+		Name:  "reconnect-backoff",
+		Usage: "Delay before the first reconnect attempt; doubles on each failure up to 30s",
+		Value: protocli.DefaultReconnectBackoff,
 	}, &v3.StringFlag{
 		Name:  "format",
 		Usage: "Output format (use --format to see available formats)",
@@ -421,16 +762,54 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "delimiter",
 		Usage: "Delimiter between streamed messages",
 		Value: "\n",
+	}, &v3.BoolFlag{
+		Name:  "raw",
+		Usage: "Write each message as binary protobuf with a 4-byte big-endian length prefix instead of formatting it",
+	}, &v3.BoolFlag{
+		Name:  "collect",
+		Usage: "Write the whole stream once it ends, as a single WatchItemsResults message with an items list",
+	}, &v3.BoolFlag{
+		Name:  "stream-summary",
+		Usage: "Print the message count and duration to stderr when the stream ends",
+	}, &v3.StringFlag{
+		Name:  "flush",
+		Usage: "Flush output after each message: auto (only when writing to a terminal), always, or never",
+		Value: "auto",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "checkpoint-file",
+		Usage: "Record the last written message in this file so --resume can continue an interrupted stream",
+	}, &v3.BoolFlag{
+		Name:  "resume",
+		Usage: "Continue after the message recorded in --checkpoint-file, appending to --output",
+	}, &v3.StringFlag{
+		Name:  "since",
+		Usage: "Only write messages at or after this time, e.g. 1h, \"2d ago\" or 2024-01-02T15:04:05Z",
+	}, &v3.StringFlag{
+		Name:  "until",
+		Usage: "Only write messages at or before this time, e.g. 10m, \"1h ago\" or 2024-01-02T15:04:05Z",
 	}}
 
 	flags_watch_items = append(flags_watch_items, &v3.Int64Flag{
@@ -439,19 +818,22 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_watch_items = append(flags_watch_items, flagConfigured.Flags()...)
-		}
-	}
+	flags_watch_items = append(flags_watch_items, protocli.FormatFlags(options.OutputFormats())...)
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return fmt.Errorf("unsupported argument: %s", cmd.Args().Get(0))
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
 			}
 
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -467,6 +849,7 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *WatchRequest
 
@@ -487,11 +870,13 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				deserializer, hasDeserializer := options.FlagDeserializer("streaming.WatchRequest")
 				if hasDeserializer {
 					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
 					requestFlags := protocli.NewFlagContainer(cmd, "")
 					msg, err := deserializer(cmdCtx, requestFlags)
 					if err != nil {
 						return fmt.Errorf("custom deserializer failed: %w", err)
 					}
+					// Handle nil return from deserializer
 					if msg == nil {
 						return fmt.Errorf("custom deserializer returned nil message")
 					}
@@ -507,6 +892,27 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/streaming.StreamingService/WatchItems", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/streaming.StreamingService/WatchItems")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ItemEvent{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+			// Continue from --checkpoint-file when --resume is set
+			checkpoint, err := protocli.LoadStreamCheckpoint(cmd, "item.id", "start_id", req)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getStreamingServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -516,6 +922,14 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				defer closer.Close()
 			}
 
+			// Buffer the output, flushing it after each message per --flush
+			streamOutput, err := protocli.NewStreamOutput(cmd, outputWriter)
+			if err != nil {
+				return err
+			}
+			defer streamOutput.Flush()
+			outputWriter = streamOutput
+
 			// Find the appropriate output format
 			formatName := cmd.String("format")
 			var outputFmt protocli.OutputFormat
@@ -532,40 +946,189 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				}
 				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), formatName)
 
 			// Get delimiter for separating streamed messages
 			delimiter := cmd.String("delimiter")
+			raw := cmd.Bool("raw")
+
+			// Gather the stream into one container message for --collect
+			collector, err := protocli.NewStreamCollector(cmd, "streaming.WatchItemsResults", &ItemEvent{})
+			if err != nil {
+				return err
+			}
+
+			// Drop messages outside the --since/--until window
+			window, err := protocli.NewStreamWindow(cmd, "timestamp")
+			if err != nil {
+				return err
+			}
+
+			// Start timing for --stream-summary
+			streamStart := time.Now()
+
+			if protocli.Replaying(cmd) {
+				// Replay the recorded stream instead of calling the service
+				timings.Phase("service call")
+				replayed, err := protocli.ReplayStream[*ItemEvent](cmd, "/streaming.StreamingService/WatchItems", req)
+				if err != nil {
+					return err
+				}
+				var messageCount int
+				for _, msg := range replayed {
+					timings.Phase("format")
+					// Skip messages outside the --since/--until window
+					if !window.Contains(msg) {
+						continue
+					}
+
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
+
+					// Format and write the message
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
+				return nil
+			}
+
+			// Record the stream for --replay (nil unless WithRecord is set)
+			recording := protocli.NewStreamRecording(cmd, "/streaming.StreamingService/WatchItems", req)
 
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 
 			if remoteAddr != "" {
 				// Remote gRPC streaming call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewStreamingServiceClient(conn)
-				stream, err := client.WatchItems(cmdCtx, req)
+				stream, err := client.WatchItems(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("failed to start stream: %w", err)
 				}
 
+				// Re-establish the stream on failure or EOF when --reconnect is set
+				reconnector := protocli.NewStreamReconnector(cmd, "item.id", "start_id")
+
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					msg, recvErr := stream.Recv()
+					if recvErr != nil && reconnector.Enabled() {
+						stream, err = protocli.ReconnectStream(cmdCtx, reconnector, req, recvErr, func() (grpc.ServerStreamingClient[ItemEvent], error) {
+							return client.WatchItems(cmdCtx, req, callOpts...)
+						})
+						if err != nil {
+							return fmt.Errorf("stream receive error: %w", err)
+						}
+						continue
+					}
 					if recvErr == io.EOF {
 						break
 					}
 					if recvErr != nil {
 						return fmt.Errorf("stream receive error: %w", recvErr)
 					}
+					reconnector.Observe(msg)
+					recording.Observe(msg)
+					checkpoint.Observe(msg)
+
+					timings.Phase("format")
+					// Skip messages outside the --since/--until window
+					if !window.Contains(msg) {
+						continue
+					}
+
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.Flush(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						if err := checkpoint.Save(); err != nil {
+							return err
+						}
+						continue
+					}
 
 					// Format and write the message
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 						return fmt.Errorf("format failed: %w", err)
 					}
 
@@ -574,29 +1137,53 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 						return fmt.Errorf("failed to write delimiter: %w", err)
 					}
 					messageCount++
+					if err := streamOutput.Flush(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+					if err := checkpoint.Save(); err != nil {
+						return err
+					}
+				}
+
+				recording.Finish()
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
 				}
 
 				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 					if _, err := outputWriter.Write([]byte("\n")); err != nil {
 						return fmt.Errorf("failed to write final newline: %w", err)
 					}
 				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(StreamingServiceServer)
 
+				timings.Phase("service call")
+				// Cancel the stream if we stop receiving early (e.g. a format error)
+				streamCtx, cancelStream := context.WithCancel(cmdCtx)
+				defer cancelStream()
+
 				// Create local stream wrapper for direct call
 				localStream := &localServerStream_StreamingService_WatchItems{
-					ctx:       cmdCtx,
-					errors:    make(chan error),
+					ctx:       streamCtx,
+					errors:    make(chan error, 1),
 					responses: make(chan *ItemEvent),
 				}
 
 				// Call streaming method in goroutine
 				go func() {
 					var methodErr error
-					methodErr = svcImpl.WatchItems(req, localStream)
+					methodErr = protocli.RecoverMethodPanic(cmd, "StreamingService.WatchItems", func() error {
+						return svcImpl.WatchItems(req, localStream)
+					})
 					close(localStream.responses)
 					if methodErr != nil {
 						localStream.errors <- methodErr
@@ -607,6 +1194,7 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					select {
 					case msg, ok := <-localStream.responses:
 						if !ok {
@@ -614,17 +1202,63 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 							if streamErr := <-localStream.errors; streamErr != nil {
 								return fmt.Errorf("stream error: %w", streamErr)
 							}
+							recording.Finish()
+							timings.Phase("format")
+							// Write the --collect container now that the stream has ended
+							if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+								return err
+							}
+
 							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+							if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 								if _, err := outputWriter.Write([]byte("\n")); err != nil {
 									return fmt.Errorf("failed to write final newline: %w", err)
 								}
 							}
+							if err := streamOutput.Flush(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 							return nil
 						}
+						recording.Observe(msg)
+						checkpoint.Observe(msg)
+
+						timings.Phase("format")
+						// Skip messages outside the --since/--until window
+						if !window.Contains(msg) {
+							continue
+						}
+
+						// Drop --fields-exclude paths from the response
+						msg, err := protocli.ExcludeResponseFields(cmd, msg)
+						if err != nil {
+							return err
+						}
+
+						// Hold the message for --collect; the container is written when the stream ends
+						if collector.Add(msg) {
+							messageCount++
+							continue
+						}
+
+						// Write length-prefixed binary frames for --raw
+						if raw {
+							if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+								return fmt.Errorf("failed to write message: %w", err)
+							}
+							messageCount++
+							if err := streamOutput.Flush(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							if err := checkpoint.Save(); err != nil {
+								return err
+							}
+							continue
+						}
 
 						// Format and write the message
-						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 							return fmt.Errorf("format failed: %w", err)
 						}
 
@@ -633,6 +1267,12 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 							return fmt.Errorf("failed to write delimiter: %w", err)
 						}
 						messageCount++
+						if err := streamOutput.Flush(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						if err := checkpoint.Save(); err != nil {
+							return err
+						}
 					case <-cmdCtx.Done():
 						return cmdCtx.Err()
 					}
@@ -642,42 +1282,16 @@ func StreamingServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 			return nil
 		},
 		Flags: flags_watch_items,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/streaming.StreamingService/WatchItems",
+			protocli.RequestFlagsMetadataKey: []string{"start-id"},
+		},
 		Name:  "watch-items",
 		Usage: "Watch for item changes in real-time",
 	})
 
-	return &protocli.ServiceCLI{
-		Command: &v3.Command{
-			Commands: commands,
-			Name:     "streaming-service",
-			Usage:    "Example streaming service",
-		},
-		ConfigMessageType: "",
-		FactoryOrImpl:     implOrFactory,
-		RegisterFunc: func(s *grpc.Server, impl interface{}) {
-			RegisterStreamingServiceServer(s, impl.(StreamingServiceServer))
-		},
-		ServiceName: "streaming-service",
-	}
-}
-
-// StreamingServiceCommandsFlat creates a flat command structure for StreamingService (for single-service CLIs)
-// This returns RPC commands directly at the root level instead of nested under a service command.
-// The implOrFactory parameter can be either a direct service implementation or a factory function
-// The returned slice includes all RPC commands plus a daemonize command for starting a gRPC server.
-func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) []*v3.Command {
-	options := protocli.ApplyServiceOptions(opts...)
-
-	// Determine default format (first registered format, or empty if none)
-	var defaultFormat string
-	if len(options.OutputFormats()) > 0 {
-		defaultFormat = options.OutputFormats()[0].Name()
-	}
-
-	var commands []*v3.Command
-
-	// Build flags for list-items
-	flags_list_items := []v3.Flag{&v3.StringFlag{
+	// Build flags for catalog-stats
+	flags_catalog_stats := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
 	}, &v3.StringFlag{
@@ -688,16 +1302,352 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML)",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	// Add format-specific flags from registered formats
+	flags_catalog_stats = append(flags_catalog_stats, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *emptypb.Empty
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &emptypb.Empty{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+			} else {
+				// Check for custom flag deserializer for google.protobuf.Empty
+				deserializer, hasDeserializer := options.FlagDeserializer("google.protobuf.Empty")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*emptypb.Empty)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "Empty", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &emptypb.Empty{}
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/streaming.StreamingService/GetCatalogStats", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/streaming.StreamingService/GetCatalogStats")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &CatalogStats{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *CatalogStats
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*CatalogStats](cmd, "/streaming.StreamingService/GetCatalogStats", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewStreamingServiceClient(conn)
+				resp, err = client.GetCatalogStats(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(StreamingServiceServer)
+				resp, err = protocli.CallLocal(cmd, "StreamingService.GetCatalogStats", func() (*CatalogStats, error) {
+					return svcImpl.GetCatalogStats(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/streaming.StreamingService/GetCatalogStats", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getStreamingServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Flags: flags_catalog_stats,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/streaming.StreamingService/GetCatalogStats",
+			protocli.RequestFlagsMetadataKey: []string{},
+		},
+		Name:  "catalog-stats",
+		Usage: "Show catalog statistics",
+	})
+
+	return &protocli.ServiceCLI{
+		Command: &v3.Command{
+			Commands: commands,
+			Metadata: map[string]any{protocli.ServiceMetadataKey: "streaming-service"},
+			Name:     "streaming-service",
+			Usage:    "Example streaming service",
+		},
+		ConfigMessageType: "",
+		FactoryOrImpl:     implOrFactory,
+		InputFormats:      options.InputFormats(),
+		OutputFormats:     options.OutputFormats(),
+		RegisterFunc: func(s *grpc.Server, impl interface{}) {
+			RegisterStreamingServiceServer(s, impl.(StreamingServiceServer))
+		},
+		ServiceName: "streaming-service",
+		ValidateCommands: []*v3.Command{
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &ListItemsRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "list-items",
+				Usage: "Check that --input-file is a valid list-items request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &WatchRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "watch-items",
+				Usage: "Check that --input-file is a valid watch-items request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &emptypb.Empty{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "catalog-stats",
+				Usage: "Check that --input-file is a valid catalog-stats request",
+			},
+		},
+	}
+}
+
+// StreamingServiceCommandsFlat creates a flat command structure for StreamingService (for single-service CLIs)
+// This returns RPC commands directly at the root level instead of nested under a service command.
+// The implOrFactory parameter can be either a direct service implementation or a factory function
+// The returned slice includes all RPC commands plus a daemonize command for starting a gRPC server.
+func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) []*v3.Command {
+	options := protocli.ApplyServiceOptions(opts...)
+
+	// Determine default format (first registered format, or empty if none)
+	var defaultFormat string
+	if len(options.OutputFormats()) > 0 {
+		defaultFormat = options.OutputFormats()[0].Name()
+	}
+
+	var commands []*v3.Command
+
+	// Build flags for list-items
+	flags_list_items := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}, &v3.BoolFlag{
+		Name:  "reconnect",
+		Usage: "Re-establish the remote stream with backoff when it fails or ends (like tail -f)",
+	}, &v3.DurationFlag{
+		Name:  "reconnect-backoff",
+		Usage: "Delay before the first reconnect attempt; doubles on each failure up to 30s",
+		Value: protocli.DefaultReconnectBackoff,
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "delimiter",
 		Usage: "Delimiter between streamed messages",
 		Value: "\n",
+	}, &v3.BoolFlag{
+		Name:  "raw",
+		Usage: "Write each message as binary protobuf with a 4-byte big-endian length prefix instead of formatting it",
+	}, &v3.BoolFlag{
+		Name:  "collect",
+		Usage: "Write the whole stream once it ends, as a single ListItemsResults message with an items list",
+	}, &v3.BoolFlag{
+		Name:  "stream-summary",
+		Usage: "Print the message count and duration to stderr when the stream ends",
+	}, &v3.StringFlag{
+		Name:  "flush",
+		Usage: "Flush output after each message: auto (only when writing to a terminal), always, or never",
+		Value: "auto",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
 	}}
 
 	flags_list_items = append(flags_list_items, &v3.StringFlag{
@@ -722,19 +1672,22 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_list_items = append(flags_list_items, flagConfigured.Flags()...)
-		}
-	}
+	flags_list_items = append(flags_list_items, protocli.FormatFlags(options.OutputFormats())...)
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return fmt.Errorf("unsupported argument: %s", cmd.Args().Get(0))
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -750,6 +1703,7 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ListItemsRequest
 
@@ -763,7 +1717,11 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("category") {
-					req.Category = cmd.String("category")
+					val, err := protocli.TransformFlagValue("streaming.ListItemsRequest", "category", cmd.String("category"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --category: %w", err)
+					}
+					req.Category = val
 				}
 				if cmd.IsSet("limit") {
 					req.Limit = cmd.Int32("limit")
@@ -773,7 +1731,10 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 					req.Offset = &val
 				}
 				if cmd.IsSet("sort-by") {
-					val := cmd.String("sort-by")
+					val, err := protocli.TransformFlagValue("streaming.ListItemsRequest", "sort_by", cmd.String("sort-by"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --sort-by: %w", err)
+					}
 					req.SortBy = &val
 				}
 				if cmd.IsSet("include-deleted") {
@@ -785,11 +1746,13 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				deserializer, hasDeserializer := options.FlagDeserializer("streaming.ListItemsRequest")
 				if hasDeserializer {
 					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
 					requestFlags := protocli.NewFlagContainer(cmd, "")
 					msg, err := deserializer(cmdCtx, requestFlags)
 					if err != nil {
 						return fmt.Errorf("custom deserializer failed: %w", err)
 					}
+					// Handle nil return from deserializer
 					if msg == nil {
 						return fmt.Errorf("custom deserializer returned nil message")
 					}
@@ -801,14 +1764,23 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				} else {
 					// Use auto-generated flag parsing
 					req = &ListItemsRequest{}
-					req.Category = cmd.String("category")
+					if s := cmd.String("category"); s != "" {
+						val, err := protocli.TransformFlagValue("streaming.ListItemsRequest", "category", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --category: %w", err)
+						}
+						req.Category = val
+					}
 					req.Limit = cmd.Int32("limit")
 					if cmd.IsSet("offset") {
 						val := cmd.Int32("offset")
 						req.Offset = &val
 					}
 					if cmd.IsSet("sort-by") {
-						val := cmd.String("sort-by")
+						val, err := protocli.TransformFlagValue("streaming.ListItemsRequest", "sort_by", cmd.String("sort-by"))
+						if err != nil {
+							return fmt.Errorf("invalid value for --sort-by: %w", err)
+						}
 						req.SortBy = &val
 					}
 					if cmd.IsSet("include-deleted") {
@@ -818,6 +1790,21 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/streaming.StreamingService/ListItems", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/streaming.StreamingService/ListItems")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ItemResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
 			// Open output writer
 			outputWriter, err := getStreamingServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -827,6 +1814,14 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				defer closer.Close()
 			}
 
+			// Buffer the output, flushing it after each message per --flush
+			streamOutput, err := protocli.NewStreamOutput(cmd, outputWriter)
+			if err != nil {
+				return err
+			}
+			defer streamOutput.Flush()
+			outputWriter = streamOutput
+
 			// Find the appropriate output format
 			formatName := cmd.String("format")
 			var outputFmt protocli.OutputFormat
@@ -843,40 +1838,174 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				}
 				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), formatName)
 
 			// Get delimiter for separating streamed messages
 			delimiter := cmd.String("delimiter")
+			raw := cmd.Bool("raw")
+
+			// Gather the stream into one container message for --collect
+			collector, err := protocli.NewStreamCollector(cmd, "streaming.ListItemsResults", &ItemResponse{})
+			if err != nil {
+				return err
+			}
+
+			// Report progress using the total from the first streamed message
+			progress := protocli.NewStreamProgress(cmd, "total")
+			defer progress.Finish()
+
+			// Start timing for --stream-summary
+			streamStart := time.Now()
+
+			if protocli.Replaying(cmd) {
+				// Replay the recorded stream instead of calling the service
+				timings.Phase("service call")
+				replayed, err := protocli.ReplayStream[*ItemResponse](cmd, "/streaming.StreamingService/ListItems", req)
+				if err != nil {
+					return err
+				}
+				var messageCount int
+				for _, msg := range replayed {
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
+
+					// Format and write the message
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
+				return nil
+			}
+
+			// Record the stream for --replay (nil unless WithRecord is set)
+			recording := protocli.NewStreamRecording(cmd, "/streaming.StreamingService/ListItems", req)
 
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 
 			if remoteAddr != "" {
 				// Remote gRPC streaming call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewStreamingServiceClient(conn)
-				stream, err := client.ListItems(cmdCtx, req)
+				stream, err := client.ListItems(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("failed to start stream: %w", err)
 				}
 
+				// Re-establish the stream on failure or EOF when --reconnect is set
+				reconnector := protocli.NewStreamReconnector(cmd, "", "")
+
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					msg, recvErr := stream.Recv()
+					if recvErr != nil && reconnector.Enabled() {
+						stream, err = protocli.ReconnectStream(cmdCtx, reconnector, req, recvErr, func() (grpc.ServerStreamingClient[ItemResponse], error) {
+							return client.ListItems(cmdCtx, req, callOpts...)
+						})
+						if err != nil {
+							return fmt.Errorf("stream receive error: %w", err)
+						}
+						continue
+					}
 					if recvErr == io.EOF {
 						break
 					}
 					if recvErr != nil {
 						return fmt.Errorf("stream receive error: %w", recvErr)
 					}
+					progress.Observe(msg)
+					reconnector.Observe(msg)
+					recording.Observe(msg)
+
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
 
 					// Format and write the message
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 						return fmt.Errorf("format failed: %w", err)
 					}
 
@@ -885,29 +2014,51 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 						return fmt.Errorf("failed to write delimiter: %w", err)
 					}
 					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				recording.Finish()
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
 				}
 
 				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 					if _, err := outputWriter.Write([]byte("\n")); err != nil {
 						return fmt.Errorf("failed to write final newline: %w", err)
 					}
 				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(StreamingServiceServer)
 
+				timings.Phase("service call")
+				// Cancel the stream if we stop receiving early (e.g. a format error)
+				streamCtx, cancelStream := context.WithCancel(cmdCtx)
+				defer cancelStream()
+
 				// Create local stream wrapper for direct call
 				localStream := &localServerStream_StreamingService_ListItems{
-					ctx:       cmdCtx,
-					errors:    make(chan error),
+					ctx:       streamCtx,
+					errors:    make(chan error, 1),
 					responses: make(chan *ItemResponse),
 				}
 
 				// Call streaming method in goroutine
 				go func() {
 					var methodErr error
-					methodErr = svcImpl.ListItems(req, localStream)
+					methodErr = protocli.RecoverMethodPanic(cmd, "StreamingService.ListItems", func() error {
+						return svcImpl.ListItems(req, localStream)
+					})
 					close(localStream.responses)
 					if methodErr != nil {
 						localStream.errors <- methodErr
@@ -918,6 +2069,7 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					select {
 					case msg, ok := <-localStream.responses:
 						if !ok {
@@ -925,17 +2077,55 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 							if streamErr := <-localStream.errors; streamErr != nil {
 								return fmt.Errorf("stream error: %w", streamErr)
 							}
+							recording.Finish()
+							timings.Phase("format")
+							// Write the --collect container now that the stream has ended
+							if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+								return err
+							}
+
 							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+							if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 								if _, err := outputWriter.Write([]byte("\n")); err != nil {
 									return fmt.Errorf("failed to write final newline: %w", err)
 								}
 							}
+							if err := streamOutput.Flush(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 							return nil
 						}
+						progress.Observe(msg)
+						recording.Observe(msg)
+
+						timings.Phase("format")
+						// Drop --fields-exclude paths from the response
+						msg, err := protocli.ExcludeResponseFields(cmd, msg)
+						if err != nil {
+							return err
+						}
+
+						// Hold the message for --collect; the container is written when the stream ends
+						if collector.Add(msg) {
+							messageCount++
+							continue
+						}
+
+						// Write length-prefixed binary frames for --raw
+						if raw {
+							if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+								return fmt.Errorf("failed to write message: %w", err)
+							}
+							messageCount++
+							if err := streamOutput.MessageDone(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							continue
+						}
 
 						// Format and write the message
-						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 							return fmt.Errorf("format failed: %w", err)
 						}
 
@@ -944,6 +2134,10 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 							return fmt.Errorf("failed to write delimiter: %w", err)
 						}
 						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+
 					case <-cmdCtx.Done():
 						return cmdCtx.Err()
 					}
@@ -953,6 +2147,10 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 			return nil
 		},
 		Flags: flags_list_items,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/streaming.StreamingService/ListItems",
+			protocli.RequestFlagsMetadataKey: []string{"category", "limit", "offset", "sort-by", "include-deleted"},
+		},
 		Name:  "list-items",
 		Usage: "Stream items from the server",
 	})
@@ -961,6 +2159,16 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 	flags_watch_items := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}, &v3.BoolFlag{
+		Name:  "reconnect",
+		Usage: "Re-establish the remote stream with backoff when it fails or ends (like tail -f)",
+	}, &v3.DurationFlag{
+		Name:  "reconnect-backoff",
+		Usage: "Delay before the first reconnect attempt; doubles on each failure up to 30s",
+		Value: protocli.DefaultReconnectBackoff,
 	}, &v3.StringFlag{
 		Name:  "format",
 		Usage: "Output format (use --format to see available formats)",
@@ -969,16 +2177,54 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "delimiter",
 		Usage: "Delimiter between streamed messages",
 		Value: "\n",
+	}, &v3.BoolFlag{
+		Name:  "raw",
+		Usage: "Write each message as binary protobuf with a 4-byte big-endian length prefix instead of formatting it",
+	}, &v3.BoolFlag{
+		Name:  "collect",
+		Usage: "Write the whole stream once it ends, as a single WatchItemsResults message with an items list",
+	}, &v3.BoolFlag{
+		Name:  "stream-summary",
+		Usage: "Print the message count and duration to stderr when the stream ends",
+	}, &v3.StringFlag{
+		Name:  "flush",
+		Usage: "Flush output after each message: auto (only when writing to a terminal), always, or never",
+		Value: "auto",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "checkpoint-file",
+		Usage: "Record the last written message in this file so --resume can continue an interrupted stream",
+	}, &v3.BoolFlag{
+		Name:  "resume",
+		Usage: "Continue after the message recorded in --checkpoint-file, appending to --output",
+	}, &v3.StringFlag{
+		Name:  "since",
+		Usage: "Only write messages at or after this time, e.g. 1h, \"2d ago\" or 2024-01-02T15:04:05Z",
+	}, &v3.StringFlag{
+		Name:  "until",
+		Usage: "Only write messages at or before this time, e.g. 10m, \"1h ago\" or 2024-01-02T15:04:05Z",
 	}}
 
 	flags_watch_items = append(flags_watch_items, &v3.Int64Flag{
@@ -987,19 +2233,22 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_watch_items = append(flags_watch_items, flagConfigured.Flags()...)
-		}
-	}
+	flags_watch_items = append(flags_watch_items, protocli.FormatFlags(options.OutputFormats())...)
 
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return fmt.Errorf("unsupported argument: %s", cmd.Args().Get(0))
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -1015,6 +2264,7 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *WatchRequest
 
@@ -1035,11 +2285,13 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				deserializer, hasDeserializer := options.FlagDeserializer("streaming.WatchRequest")
 				if hasDeserializer {
 					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
 					requestFlags := protocli.NewFlagContainer(cmd, "")
 					msg, err := deserializer(cmdCtx, requestFlags)
 					if err != nil {
 						return fmt.Errorf("custom deserializer failed: %w", err)
 					}
+					// Handle nil return from deserializer
 					if msg == nil {
 						return fmt.Errorf("custom deserializer returned nil message")
 					}
@@ -1055,6 +2307,27 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/streaming.StreamingService/WatchItems", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/streaming.StreamingService/WatchItems")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ItemEvent{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+			// Continue from --checkpoint-file when --resume is set
+			checkpoint, err := protocli.LoadStreamCheckpoint(cmd, "item.id", "start_id", req)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getStreamingServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -1064,6 +2337,14 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				defer closer.Close()
 			}
 
+			// Buffer the output, flushing it after each message per --flush
+			streamOutput, err := protocli.NewStreamOutput(cmd, outputWriter)
+			if err != nil {
+				return err
+			}
+			defer streamOutput.Flush()
+			outputWriter = streamOutput
+
 			// Find the appropriate output format
 			formatName := cmd.String("format")
 			var outputFmt protocli.OutputFormat
@@ -1080,40 +2361,189 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				}
 				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), formatName)
 
 			// Get delimiter for separating streamed messages
 			delimiter := cmd.String("delimiter")
+			raw := cmd.Bool("raw")
+
+			// Gather the stream into one container message for --collect
+			collector, err := protocli.NewStreamCollector(cmd, "streaming.WatchItemsResults", &ItemEvent{})
+			if err != nil {
+				return err
+			}
+
+			// Drop messages outside the --since/--until window
+			window, err := protocli.NewStreamWindow(cmd, "timestamp")
+			if err != nil {
+				return err
+			}
+
+			// Start timing for --stream-summary
+			streamStart := time.Now()
+
+			if protocli.Replaying(cmd) {
+				// Replay the recorded stream instead of calling the service
+				timings.Phase("service call")
+				replayed, err := protocli.ReplayStream[*ItemEvent](cmd, "/streaming.StreamingService/WatchItems", req)
+				if err != nil {
+					return err
+				}
+				var messageCount int
+				for _, msg := range replayed {
+					timings.Phase("format")
+					// Skip messages outside the --since/--until window
+					if !window.Contains(msg) {
+						continue
+					}
+
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
+
+					// Format and write the message
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
+				return nil
+			}
+
+			// Record the stream for --replay (nil unless WithRecord is set)
+			recording := protocli.NewStreamRecording(cmd, "/streaming.StreamingService/WatchItems", req)
 
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 
 			if remoteAddr != "" {
 				// Remote gRPC streaming call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewStreamingServiceClient(conn)
-				stream, err := client.WatchItems(cmdCtx, req)
+				stream, err := client.WatchItems(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("failed to start stream: %w", err)
 				}
 
+				// Re-establish the stream on failure or EOF when --reconnect is set
+				reconnector := protocli.NewStreamReconnector(cmd, "item.id", "start_id")
+
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					msg, recvErr := stream.Recv()
+					if recvErr != nil && reconnector.Enabled() {
+						stream, err = protocli.ReconnectStream(cmdCtx, reconnector, req, recvErr, func() (grpc.ServerStreamingClient[ItemEvent], error) {
+							return client.WatchItems(cmdCtx, req, callOpts...)
+						})
+						if err != nil {
+							return fmt.Errorf("stream receive error: %w", err)
+						}
+						continue
+					}
 					if recvErr == io.EOF {
 						break
 					}
 					if recvErr != nil {
 						return fmt.Errorf("stream receive error: %w", recvErr)
 					}
+					reconnector.Observe(msg)
+					recording.Observe(msg)
+					checkpoint.Observe(msg)
+
+					timings.Phase("format")
+					// Skip messages outside the --since/--until window
+					if !window.Contains(msg) {
+						continue
+					}
+
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.Flush(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						if err := checkpoint.Save(); err != nil {
+							return err
+						}
+						continue
+					}
 
 					// Format and write the message
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 						return fmt.Errorf("format failed: %w", err)
 					}
 
@@ -1122,29 +2552,53 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 						return fmt.Errorf("failed to write delimiter: %w", err)
 					}
 					messageCount++
+					if err := streamOutput.Flush(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+					if err := checkpoint.Save(); err != nil {
+						return err
+					}
+				}
+
+				recording.Finish()
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
 				}
 
 				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 					if _, err := outputWriter.Write([]byte("\n")); err != nil {
 						return fmt.Errorf("failed to write final newline: %w", err)
 					}
 				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(StreamingServiceServer)
 
+				timings.Phase("service call")
+				// Cancel the stream if we stop receiving early (e.g. a format error)
+				streamCtx, cancelStream := context.WithCancel(cmdCtx)
+				defer cancelStream()
+
 				// Create local stream wrapper for direct call
 				localStream := &localServerStream_StreamingService_WatchItems{
-					ctx:       cmdCtx,
-					errors:    make(chan error),
+					ctx:       streamCtx,
+					errors:    make(chan error, 1),
 					responses: make(chan *ItemEvent),
 				}
 
 				// Call streaming method in goroutine
 				go func() {
 					var methodErr error
-					methodErr = svcImpl.WatchItems(req, localStream)
+					methodErr = protocli.RecoverMethodPanic(cmd, "StreamingService.WatchItems", func() error {
+						return svcImpl.WatchItems(req, localStream)
+					})
 					close(localStream.responses)
 					if methodErr != nil {
 						localStream.errors <- methodErr
@@ -1155,6 +2609,7 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					select {
 					case msg, ok := <-localStream.responses:
 						if !ok {
@@ -1162,17 +2617,63 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 							if streamErr := <-localStream.errors; streamErr != nil {
 								return fmt.Errorf("stream error: %w", streamErr)
 							}
+							recording.Finish()
+							timings.Phase("format")
+							// Write the --collect container now that the stream has ended
+							if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+								return err
+							}
+
 							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+							if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 								if _, err := outputWriter.Write([]byte("\n")); err != nil {
 									return fmt.Errorf("failed to write final newline: %w", err)
 								}
 							}
+							if err := streamOutput.Flush(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 							return nil
 						}
+						recording.Observe(msg)
+						checkpoint.Observe(msg)
+
+						timings.Phase("format")
+						// Skip messages outside the --since/--until window
+						if !window.Contains(msg) {
+							continue
+						}
+
+						// Drop --fields-exclude paths from the response
+						msg, err := protocli.ExcludeResponseFields(cmd, msg)
+						if err != nil {
+							return err
+						}
+
+						// Hold the message for --collect; the container is written when the stream ends
+						if collector.Add(msg) {
+							messageCount++
+							continue
+						}
+
+						// Write length-prefixed binary frames for --raw
+						if raw {
+							if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+								return fmt.Errorf("failed to write message: %w", err)
+							}
+							messageCount++
+							if err := streamOutput.Flush(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							if err := checkpoint.Save(); err != nil {
+								return err
+							}
+							continue
+						}
 
 						// Format and write the message
-						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 							return fmt.Errorf("format failed: %w", err)
 						}
 
@@ -1181,6 +2682,12 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 							return fmt.Errorf("failed to write delimiter: %w", err)
 						}
 						messageCount++
+						if err := streamOutput.Flush(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						if err := checkpoint.Save(); err != nil {
+							return err
+						}
 					case <-cmdCtx.Done():
 						return cmdCtx.Err()
 					}
@@ -1190,10 +2697,219 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 			return nil
 		},
 		Flags: flags_watch_items,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/streaming.StreamingService/WatchItems",
+			protocli.RequestFlagsMetadataKey: []string{"start-id"},
+		},
 		Name:  "watch-items",
 		Usage: "Watch for item changes in real-time",
 	})
 
+	// Build flags for catalog-stats
+	flags_catalog_stats := []v3.Flag{&v3.StringFlag{
+		Name:  "remote",
+		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "format",
+		Usage: "Output format (use --format to see available formats)",
+		Value: defaultFormat,
+	}, &v3.StringFlag{
+		Name:  "output",
+		Usage: "Output file (- for stdout)",
+		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
+	}, &v3.StringFlag{
+		Name:  "input-file",
+		Usage: "Read request from file (JSON or YAML)",
+	}, &v3.StringFlag{
+		Name:  "input-format",
+		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}}
+
+	// Add format-specific flags from registered formats
+	flags_catalog_stats = append(flags_catalog_stats, protocli.FormatFlags(options.OutputFormats())...)
+
+	commands = append(commands, &v3.Command{
+		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
+			defer func() {
+				hooks := options.AfterCommandHooks()
+				for i := len(hooks) - 1; i >= 0; i-- {
+					if err := hooks[i](cmdCtx, cmd); err != nil {
+						slog.Warn("after hook failed", "error", err)
+					}
+				}
+			}()
+
+			for _, hook := range options.BeforeCommandHooks() {
+				if err := hook(cmdCtx, cmd); err != nil {
+					return fmt.Errorf("before hook failed: %w", err)
+				}
+			}
+
+			timings.Phase("request build")
+			// Build request message
+			var req *emptypb.Empty
+
+			// Check for file-based input
+			inputFile := cmd.String("input-file")
+			if inputFile != "" {
+				// Read request from file
+				req = &emptypb.Empty{}
+				if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+					return err
+				}
+			} else {
+				// Check for custom flag deserializer for google.protobuf.Empty
+				deserializer, hasDeserializer := options.FlagDeserializer("google.protobuf.Empty")
+				if hasDeserializer {
+					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
+					requestFlags := protocli.NewFlagContainer(cmd, "")
+					msg, err := deserializer(cmdCtx, requestFlags)
+					if err != nil {
+						return fmt.Errorf("custom deserializer failed: %w", err)
+					}
+					// Handle nil return from deserializer
+					if msg == nil {
+						return fmt.Errorf("custom deserializer returned nil message")
+					}
+					var ok bool
+					req, ok = msg.(*emptypb.Empty)
+					if !ok {
+						return fmt.Errorf("custom deserializer returned wrong type: expected *%s, got %T", "Empty", msg)
+					}
+				} else {
+					// Use auto-generated flag parsing
+					req = &emptypb.Empty{}
+				}
+			}
+
+			protocli.RecordAuditRequest(cmd, "/streaming.StreamingService/GetCatalogStats", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/streaming.StreamingService/GetCatalogStats")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &CatalogStats{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+			var resp *CatalogStats
+			var err error
+
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*CatalogStats](cmd, "/streaming.StreamingService/GetCatalogStats", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
+				// Remote gRPC call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewStreamingServiceClient(conn)
+				resp, err = client.GetCatalogStats(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("remote call failed: %w", err)
+				}
+			} else {
+				// Direct implementation call (no config)
+				timings.Phase("service call")
+				svcImpl := implOrFactory.(StreamingServiceServer)
+				resp, err = protocli.CallLocal(cmd, "StreamingService.GetCatalogStats", func() (*CatalogStats, error) {
+					return svcImpl.GetCatalogStats(cmdCtx, req)
+				})
+				if err != nil {
+					return fmt.Errorf("method failed: %w", err)
+				}
+			}
+
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/streaming.StreamingService/GetCatalogStats", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
+			// Open output writer
+			outputWriter, err := getStreamingServiceOutputWriter(cmd, cmd.String("output"))
+			if err != nil {
+				return fmt.Errorf("failed to open output: %w", err)
+			}
+			if closer, ok := outputWriter.(io.Closer); ok {
+				defer closer.Close()
+			}
+
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
+
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
+		},
+		Flags: flags_catalog_stats,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/streaming.StreamingService/GetCatalogStats",
+			protocli.RequestFlagsMetadataKey: []string{},
+		},
+		Name:  "catalog-stats",
+		Usage: "Show catalog statistics",
+	})
+
 	// Create ServiceCLI for daemonize command
 	serviceCLI := &protocli.ServiceCLI{
 		ConfigMessageType: "",
@@ -1212,3 +2928,23 @@ func StreamingServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 
 	return commands
 }
+
+// NewStreamingServiceCLIClient dials the remote given by WithRemote or resolved from a
+// command's --remote and --context flags (WithRemoteCommand), applying TLS,
+// retry and auth options, and returns the StreamingServiceClient. The caller must close the connection.
+func NewStreamingServiceCLIClient(opts ...protocli.ClientOption) (StreamingServiceClient, *grpc.ClientConn, error) {
+	conn, err := protocli.DialRemote(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewStreamingServiceClient(conn), conn, nil
+}
+
+// BuildAllServicesCLI creates a root command with every service in this file registered.
+// Each impl parameter can be either a direct service implementation or a factory function.
+// Additional root options are applied after the service registrations.
+func BuildAllServicesCLI(ctx context.Context, appName string, streamingServiceImpl interface{}, opts ...protocli.RootOption) (*v3.Command, error) {
+	rootOpts := []protocli.RootOption{protocli.Service(StreamingServiceCommand(ctx, streamingServiceImpl))}
+	rootOpts = append(rootOpts, opts...)
+	return protocli.RootCommand(appName, rootOpts...)
+}
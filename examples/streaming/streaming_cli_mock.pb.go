@@ -0,0 +1,51 @@
+// Code generated by protoc-gen-cli. DO NOT EDIT.
+
+package streaming
+
+import (
+	"context"
+	protocli "github.com/drewfead/proto-cli"
+	grpc "google.golang.org/grpc"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// MockStreamingServiceServer is an in-memory StreamingServiceServer for tests. Set a method's Func field
+// to return a canned response; methods without one return codes.Unimplemented.
+// Every call is recorded and can be inspected with Calls or CallsTo.
+type MockStreamingServiceServer struct {
+	UnimplementedStreamingServiceServer
+	protocli.CallRecorder
+
+	ListItemsFunc       func(req *ListItemsRequest, stream grpc.ServerStreamingServer[ItemResponse]) error
+	WatchItemsFunc      func(req *WatchRequest, stream grpc.ServerStreamingServer[ItemEvent]) error
+	GetCatalogStatsFunc func(ctx context.Context, req *emptypb.Empty) (*CatalogStats, error)
+}
+
+var _ StreamingServiceServer = (*MockStreamingServiceServer)(nil)
+
+// ListItems records the call and invokes ListItemsFunc when set.
+func (m *MockStreamingServiceServer) ListItems(req *ListItemsRequest, stream grpc.ServerStreamingServer[ItemResponse]) error {
+	m.Record("ListItems", req)
+	if m.ListItemsFunc != nil {
+		return m.ListItemsFunc(req, stream)
+	}
+	return m.UnimplementedStreamingServiceServer.ListItems(req, stream)
+}
+
+// WatchItems records the call and invokes WatchItemsFunc when set.
+func (m *MockStreamingServiceServer) WatchItems(req *WatchRequest, stream grpc.ServerStreamingServer[ItemEvent]) error {
+	m.Record("WatchItems", req)
+	if m.WatchItemsFunc != nil {
+		return m.WatchItemsFunc(req, stream)
+	}
+	return m.UnimplementedStreamingServiceServer.WatchItems(req, stream)
+}
+
+// GetCatalogStats records the call and invokes GetCatalogStatsFunc when set.
+func (m *MockStreamingServiceServer) GetCatalogStats(ctx context.Context, req *emptypb.Empty) (*CatalogStats, error) {
+	m.Record("GetCatalogStats", req)
+	if m.GetCatalogStatsFunc != nil {
+		return m.GetCatalogStatsFunc(ctx, req)
+	}
+	return m.UnimplementedStreamingServiceServer.GetCatalogStats(ctx, req)
+}
@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -19,8 +20,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StreamingService_ListItems_FullMethodName  = "/streaming.StreamingService/ListItems"
-	StreamingService_WatchItems_FullMethodName = "/streaming.StreamingService/WatchItems"
+	StreamingService_ListItems_FullMethodName       = "/streaming.StreamingService/ListItems"
+	StreamingService_WatchItems_FullMethodName      = "/streaming.StreamingService/WatchItems"
+	StreamingService_GetCatalogStats_FullMethodName = "/streaming.StreamingService/GetCatalogStats"
 )
 
 // StreamingServiceClient is the client API for StreamingService service.
@@ -31,6 +33,8 @@ type StreamingServiceClient interface {
 	ListItems(ctx context.Context, in *ListItemsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ItemResponse], error)
 	// Server streaming: watch for changes
 	WatchItems(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ItemEvent], error)
+	// Unary: summarize the catalog, taking no request fields
+	GetCatalogStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CatalogStats, error)
 }
 
 type streamingServiceClient struct {
@@ -79,6 +83,16 @@ func (c *streamingServiceClient) WatchItems(ctx context.Context, in *WatchReques
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StreamingService_WatchItemsClient = grpc.ServerStreamingClient[ItemEvent]
 
+func (c *streamingServiceClient) GetCatalogStats(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CatalogStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CatalogStats)
+	err := c.cc.Invoke(ctx, StreamingService_GetCatalogStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StreamingServiceServer is the server API for StreamingService service.
 // All implementations must embed UnimplementedStreamingServiceServer
 // for forward compatibility.
@@ -87,6 +101,8 @@ type StreamingServiceServer interface {
 	ListItems(*ListItemsRequest, grpc.ServerStreamingServer[ItemResponse]) error
 	// Server streaming: watch for changes
 	WatchItems(*WatchRequest, grpc.ServerStreamingServer[ItemEvent]) error
+	// Unary: summarize the catalog, taking no request fields
+	GetCatalogStats(context.Context, *emptypb.Empty) (*CatalogStats, error)
 	mustEmbedUnimplementedStreamingServiceServer()
 }
 
@@ -103,6 +119,9 @@ func (UnimplementedStreamingServiceServer) ListItems(*ListItemsRequest, grpc.Ser
 func (UnimplementedStreamingServiceServer) WatchItems(*WatchRequest, grpc.ServerStreamingServer[ItemEvent]) error {
 	return status.Error(codes.Unimplemented, "method WatchItems not implemented")
 }
+func (UnimplementedStreamingServiceServer) GetCatalogStats(context.Context, *emptypb.Empty) (*CatalogStats, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCatalogStats not implemented")
+}
 func (UnimplementedStreamingServiceServer) mustEmbedUnimplementedStreamingServiceServer() {}
 func (UnimplementedStreamingServiceServer) testEmbeddedByValue()                          {}
 
@@ -146,13 +165,36 @@ func _StreamingService_WatchItems_Handler(srv interface{}, stream grpc.ServerStr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StreamingService_WatchItemsServer = grpc.ServerStreamingServer[ItemEvent]
 
+func _StreamingService_GetCatalogStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamingServiceServer).GetCatalogStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamingService_GetCatalogStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamingServiceServer).GetCatalogStats(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StreamingService_ServiceDesc is the grpc.ServiceDesc for StreamingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var StreamingService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "streaming.StreamingService",
 	HandlerType: (*StreamingServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCatalogStats",
+			Handler:    _StreamingService_GetCatalogStats_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "ListItems",
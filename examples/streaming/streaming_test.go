@@ -215,3 +215,44 @@ func TestServerStreaming_DirectGRPCClient(t *testing.T) {
 		t.Errorf("Expected 3 messages, got %d", count)
 	}
 }
+
+// recordingProgress captures progress callbacks for assertions.
+type recordingProgress struct {
+	total    int64
+	updates  []int64
+	finished int
+}
+
+func (r *recordingProgress) Start(total int64)    { r.total = total }
+func (r *recordingProgress) Update(current int64) { r.updates = append(r.updates, current) }
+func (r *recordingProgress) Finish()              { r.finished++ }
+
+// TestServerStreaming_ListItems_Progress tests that the progress reporter is fed
+// from the progress_total_field annotation.
+func TestServerStreaming_ListItems_Progress(t *testing.T) {
+	ctx := context.Background()
+	service := streaming.NewStreamingService()
+
+	serviceCLI := streaming.StreamingServiceCommand(ctx, service,
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+
+	reporter := &recordingProgress{}
+	rootCmd, err := protocli.RootCommand("streamcli",
+		protocli.Service(serviceCLI),
+		protocli.WithProgress(reporter),
+	)
+	require.NoError(t, err)
+
+	args := []string{
+		"streamcli", "streaming-service", "list-items",
+		"--limit", "3",
+		"--format", "json",
+		"--output", t.TempDir() + "/output.txt",
+	}
+	require.NoError(t, rootCmd.Run(ctx, args))
+
+	require.Equal(t, int64(3), reporter.total)
+	require.Equal(t, []int64{1, 2, 3}, reporter.updates)
+	require.Equal(t, 1, reporter.finished)
+}
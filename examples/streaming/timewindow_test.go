@@ -0,0 +1,90 @@
+package streaming_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// runWatchWindow runs watch-items against a synthetic stream of one event per
+// hour over the last five hours (item ids 1-5, oldest first) and returns the
+// ids written.
+func runWatchWindow(t *testing.T, args ...string) ([]int64, error) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now()
+	mock := &streaming.MockStreamingServiceServer{
+		WatchItemsFunc: func(_ *streaming.WatchRequest, stream grpc.ServerStreamingServer[streaming.ItemEvent]) error {
+			for id := int64(1); id <= 5; id++ {
+				event := &streaming.ItemEvent{
+					EventType: "created",
+					Item:      &streaming.Item{Id: id},
+					Timestamp: now.Add(-time.Duration(5-id) * time.Hour).Add(-time.Minute).Unix(),
+				}
+				if err := stream.Send(event); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	serviceCLI := streaming.StreamingServiceCommand(ctx, mock, protocli.WithOutputFormats(protocli.JSON()))
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
+	rootCmd.Command("streaming-service").Command("watch-items").Writer = out
+
+	err = rootCmd.Run(ctx, append([]string{"streamcli", "streaming-service", "watch-items"}, args...))
+	ids := []int64{}
+	for line := range strings.SplitSeq(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		event := &streaming.ItemEvent{}
+		require.NoError(t, protojson.Unmarshal([]byte(line), event))
+		ids = append(ids, event.GetItem().GetId())
+	}
+	return ids, err
+}
+
+// TestServerStreaming_TimeWindow tests that --since and --until drop streamed
+// messages whose timestamp_field is outside the window.
+func TestServerStreaming_TimeWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []int64
+	}{
+		{name: "no window", want: []int64{1, 2, 3, 4, 5}},
+		{name: "since", args: []string{"--since", "2h ago"}, want: []int64{4, 5}},
+		{name: "until", args: []string{"--until", "3h"}, want: []int64{1, 2}},
+		{name: "since and until", args: []string{"--since", "4h ago", "--until", "1h ago"}, want: []int64{2, 3, 4}},
+		{name: "absolute", args: []string{"--since", time.Now().Add(-150 * time.Minute).Format(time.RFC3339)}, want: []int64{3, 4, 5}},
+		{name: "empty window", args: []string{"--since", "now"}, want: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := runWatchWindow(t, tt.args...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestServerStreaming_TimeWindow_Invalid(t *testing.T) {
+	_, err := runWatchWindow(t, "--since", "yesterday")
+	require.ErrorIs(t, err, protocli.ErrInvalidTime)
+	assert.Contains(t, err.Error(), "--since")
+
+	_, err = runWatchWindow(t, "--since", "1h ago", "--until", "2h ago")
+	require.ErrorIs(t, err, protocli.ErrInvalidTime)
+}
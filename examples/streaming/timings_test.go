@@ -0,0 +1,41 @@
+package streaming_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestServerStreaming_Timings tests that --timings reports the phases of a
+// streaming command once the stream ends.
+func TestServerStreaming_Timings(t *testing.T) {
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+		protocli.WithOutputFormats(protocli.JSON()),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+
+	var stderr bytes.Buffer
+	rootCmd.ErrWriter = &stderr
+
+	require.NoError(t, rootCmd.Run(ctx, []string{
+		"streamcli", "--timings", "streaming-service", "list-items",
+		"--limit", "3",
+		"--output", t.TempDir() + "/output.txt",
+	}))
+
+	require.True(t, strings.HasPrefix(stderr.String(), "Timings:\n"), stderr.String())
+	var phases []string
+	for _, line := range strings.Split(strings.TrimSpace(stderr.String()), "\n")[1:] {
+		fields := strings.Fields(line)
+		phases = append(phases, strings.Join(fields[:len(fields)-1], " "))
+	}
+	assert.Equal(t, []string{"request build", "service call", "format", "total"}, phases)
+}
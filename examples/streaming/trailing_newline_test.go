@@ -0,0 +1,67 @@
+package streaming_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// binaryFormat writes messages as binary protobuf, without a trailing newline.
+type binaryFormat struct{}
+
+func (binaryFormat) Name() string { return "binary" }
+
+func (binaryFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg proto.Message) error {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func (binaryFormat) NeedsTrailingNewline() bool { return false }
+
+func runDelimitedListItems(t *testing.T, format string) []byte {
+	t.Helper()
+	ctx := context.Background()
+	serviceCLI := streaming.StreamingServiceCommand(ctx, streaming.NewStreamingService(),
+		protocli.WithOutputFormats(protocli.JSON(), binaryFormat{}),
+	)
+	rootCmd, err := protocli.RootCommand("streamcli", protocli.Service(serviceCLI))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	rootCmd.Command("streaming-service").Command("list-items").Writer = &out
+	require.NoError(t, rootCmd.Run(ctx, []string{
+		"streamcli", "streaming-service", "list-items", "--format", format, "--limit", "2", "--delimiter", ",",
+	}))
+	return out.Bytes()
+}
+
+// TestServerStreaming_TrailingNewline tests that the final newline after a
+// stream is left out for formats that opt out of it.
+func TestServerStreaming_TrailingNewline(t *testing.T) {
+	var want []byte
+	for _, id := range []int64{1, 2} {
+		b, err := proto.Marshal(&streaming.ItemResponse{
+			Item:    &streaming.Item{Id: id, Name: fmt.Sprintf("Item %d", id)},
+			Message: "Success",
+			Total:   2,
+		})
+		require.NoError(t, err)
+		want = append(append(want, b...), ',')
+	}
+	assert.Equal(t, want, runDelimitedListItems(t, "binary"), "binary output ends with the last delimiter")
+
+	assert.True(t, bytes.HasSuffix(runDelimitedListItems(t, "json"), []byte(",\n")), "json output still ends with a newline")
+}
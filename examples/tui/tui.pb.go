@@ -1258,12 +1258,13 @@ const file_examples_tui_tui_proto_rawDesc = "" +
 	"\x05GreenR\x01g\x123\n" +
 	"\x01b\x18\x03 \x01(\x05B%\x92\xb5\x18!\n" +
 	"\x01b\x1a\x14Blue channel (0-255)R\x06\n" +
-	"\x04BlueR\x01b\"\xa2\x01\n" +
+	"\x04BlueR\x01b\"\xa7\x01\n" +
 	"\x13ColoredGreetRequest\x12/\n" +
 	"\x04name\x18\x01 \x01(\tB\x1b\x92\xb5\x18\x17\n" +
-	"\x04name\x1a\rName to greet \x01R\x04name\x12Z\n" +
-	"\x05color\x18\x02 \x01(\v2\x15.tui_example.RgbColorB-\x92\xb5\x18)\n" +
-	"\x05color\x1a\x17Greeting color as R,G,BR\a\n" +
+	"\x04name\x1a\rName to greet \x01R\x04name\x12_\n" +
+	"\x05color\x18\x02 \x01(\v2\x15.tui_example.RgbColorB2\x92\xb5\x18.\n" +
+	"\n" +
+	"text-color\x1a\x17Greeting color as R,G,BR\a\n" +
 	"\x05ColorR\x05color\"M\n" +
 	"\x14ColoredGreetResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1b\n" +
@@ -8,7 +8,6 @@ import (
 	protocli "github.com/drewfead/proto-cli"
 	v3 "github.com/urfave/cli/v3"
 	grpc "google.golang.org/grpc"
-	insecure "google.golang.org/grpc/credentials/insecure"
 	metadata "google.golang.org/grpc/metadata"
 	proto "google.golang.org/protobuf/proto"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -32,7 +31,11 @@ func getFarewellServiceOutputWriter(cmd *v3.Command, path string) (io.Writer, er
 		}
 		return os.Stdout, nil
 	}
-	return os.Create(path)
+	path, err := protocli.OutputPath(cmd, path)
+	if err != nil {
+		return nil, err
+	}
+	return protocli.OpenOutputFile(path, cmd.String("output-mode"), cmd.Bool("output-append"))
 }
 
 // localServerStream_FarewellService_CountdownFarewell is a helper type for local server streaming calls to CountdownFarewell
@@ -77,6 +80,27 @@ func (s *localServerStream_FarewellService_CountdownFarewell) RecvMsg(m any) err
 	return fmt.Errorf("RecvMsg not supported on server streaming")
 }
 
+// FarewellServiceCountdownFarewell runs impl.CountdownFarewell in-process.
+// The streamed messages arrive on the first channel, which is closed when the
+// stream ends; the second then yields the method's error, if any, and is
+// closed. Cancel ctx to stop the stream early.
+func FarewellServiceCountdownFarewell(ctx context.Context, impl FarewellServiceServer, req *CountdownFarewellRequest) (<-chan *CountdownFarewellResponse, <-chan error) {
+	localStream := &localServerStream_FarewellService_CountdownFarewell{
+		ctx:       ctx,
+		errors:    make(chan error, 1),
+		responses: make(chan *CountdownFarewellResponse),
+	}
+	go func() {
+		methodErr := impl.CountdownFarewell(req, localStream)
+		close(localStream.responses)
+		if methodErr != nil {
+			localStream.errors <- methodErr
+		}
+		close(localStream.errors)
+	}()
+	return localStream.responses, localStream.errors
+}
+
 // FarewellServiceCommand creates a CLI for FarewellService with options
 // The implOrFactory parameter can be either a direct service implementation or a factory function
 func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) *protocli.ServiceCLI {
@@ -102,12 +126,34 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_farewell = append(flags_farewell, &v3.StringFlag{
@@ -121,12 +167,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_farewell = append(flags_farewell, flagConfigured.Flags()...)
-		}
-	}
+	flags_farewell = append(flags_farewell, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_farewell = append(flags_farewell, &v3.BoolFlag{
 		Name:  "interactive",
@@ -134,10 +175,18 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -153,6 +202,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *FarewellRequest
 
@@ -166,7 +216,11 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.FarewellRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("formal") {
 					req.Formal = cmd.Bool("formal")
@@ -194,38 +248,86 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				} else {
 					// Use auto-generated flag parsing
 					req = &FarewellRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.FarewellRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					req.Formal = cmd.Bool("formal")
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.FarewellService/Farewell", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.FarewellService/Farewell")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &FarewellResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *FarewellResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*FarewellResponse](cmd, "/tui_example.FarewellService/Farewell", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewFarewellServiceClient(conn)
-				resp, err = client.Farewell(cmdCtx, req)
+				resp, err = client.Farewell(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(FarewellServiceServer)
-				resp, err = svcImpl.Farewell(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "FarewellService.Farewell", func() (*FarewellResponse, error) {
+					return svcImpl.Farewell(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.FarewellService/Farewell", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getFarewellServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -235,36 +337,15 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -282,6 +363,10 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			return ctx, nil
 		},
 		Flags: flags_farewell,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.FarewellService/Farewell",
+			protocli.RequestFlagsMetadataKey: []string{"name", "formal"},
+		},
 		Name:  "farewell",
 		Usage: "Say goodbye to someone",
 	})
@@ -298,12 +383,34 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_farewell_many = append(flags_farewell_many, &v3.StringSliceFlag{
@@ -312,12 +419,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_farewell_many = append(flags_farewell_many, flagConfigured.Flags()...)
-		}
-	}
+	flags_farewell_many = append(flags_farewell_many, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_farewell_many = append(flags_farewell_many, &v3.BoolFlag{
 		Name:  "interactive",
@@ -325,10 +427,18 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -344,6 +454,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *FarewellManyRequest
 
@@ -386,33 +497,75 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.FarewellService/FarewellMany", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.FarewellService/FarewellMany")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &FarewellManyResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *FarewellManyResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*FarewellManyResponse](cmd, "/tui_example.FarewellService/FarewellMany", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewFarewellServiceClient(conn)
-				resp, err = client.FarewellMany(cmdCtx, req)
+				resp, err = client.FarewellMany(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(FarewellServiceServer)
-				resp, err = svcImpl.FarewellMany(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "FarewellService.FarewellMany", func() (*FarewellManyResponse, error) {
+					return svcImpl.FarewellMany(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.FarewellService/FarewellMany", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getFarewellServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -422,36 +575,15 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -463,6 +595,10 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			return ctx, nil
 		},
 		Flags: flags_farewell_many,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.FarewellService/FarewellMany",
+			protocli.RequestFlagsMetadataKey: []string{"names"},
+		},
 		Name:  "farewell-many",
 		Usage: "Say goodbye to multiple people",
 	})
@@ -479,12 +615,34 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_scheduled_farewell = append(flags_scheduled_farewell, &v3.StringFlag{
@@ -502,12 +660,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_scheduled_farewell = append(flags_scheduled_farewell, flagConfigured.Flags()...)
-		}
-	}
+	flags_scheduled_farewell = append(flags_scheduled_farewell, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_scheduled_farewell = append(flags_scheduled_farewell, &v3.BoolFlag{
 		Name:  "interactive",
@@ -515,10 +668,18 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -534,6 +695,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ScheduledFarewellRequest
 
@@ -547,7 +709,11 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.ScheduledFarewellRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("send-at") {
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
@@ -608,7 +774,13 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				} else {
 					// Use auto-generated flag parsing
 					req = &ScheduledFarewellRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.ScheduledFarewellRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					// Field SendAt: check for custom deserializer for google.protobuf.Timestamp
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
 						// Use custom deserializer for nested message
@@ -660,33 +832,75 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.FarewellService/ScheduledFarewell", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.FarewellService/ScheduledFarewell")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ScheduledFarewellResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *ScheduledFarewellResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*ScheduledFarewellResponse](cmd, "/tui_example.FarewellService/ScheduledFarewell", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewFarewellServiceClient(conn)
-				resp, err = client.ScheduledFarewell(cmdCtx, req)
+				resp, err = client.ScheduledFarewell(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(FarewellServiceServer)
-				resp, err = svcImpl.ScheduledFarewell(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "FarewellService.ScheduledFarewell", func() (*ScheduledFarewellResponse, error) {
+					return svcImpl.ScheduledFarewell(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.FarewellService/ScheduledFarewell", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getFarewellServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -696,36 +910,15 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -745,7 +938,12 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			}
 			return ctx, nil
 		},
-		Flags: flags_scheduled_farewell,
+		Description: "ScheduledFarewell schedules a farewell for a future time at an address.\nDemonstrates WKT (Timestamp) and nested message flattening in the TUI.",
+		Flags:       flags_scheduled_farewell,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.FarewellService/ScheduledFarewell",
+			protocli.RequestFlagsMetadataKey: []string{"name", "send-at", "address"},
+		},
 		Name:  "scheduled-farewell",
 		Usage: "Schedule a goodbye for a specific time and place",
 	})
@@ -762,12 +960,34 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_leave_note = append(flags_leave_note, &v3.StringFlag{
@@ -781,12 +1001,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_leave_note = append(flags_leave_note, flagConfigured.Flags()...)
-		}
-	}
+	flags_leave_note = append(flags_leave_note, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_leave_note = append(flags_leave_note, &v3.BoolFlag{
 		Name:  "interactive",
@@ -794,10 +1009,18 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
 			}
 
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -813,6 +1036,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *NoteRequest
 
@@ -826,10 +1050,18 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.NoteRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("metadata") {
-					req.Metadata = cmd.String("metadata")
+					val, err := protocli.TransformFlagValue("tui_example.NoteRequest", "metadata", cmd.String("metadata"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --metadata: %w", err)
+					}
+					req.Metadata = val
 				}
 			} else {
 				// Check for custom flag deserializer for tui_example.NoteRequest
@@ -854,38 +1086,92 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				} else {
 					// Use auto-generated flag parsing
 					req = &NoteRequest{}
-					req.Name = cmd.String("name")
-					req.Metadata = cmd.String("metadata")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.NoteRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
+					if s := cmd.String("metadata"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.NoteRequest", "metadata", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --metadata: %w", err)
+						}
+						req.Metadata = val
+					}
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.FarewellService/LeaveNote", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.FarewellService/LeaveNote")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &NoteResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *NoteResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*NoteResponse](cmd, "/tui_example.FarewellService/LeaveNote", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewFarewellServiceClient(conn)
-				resp, err = client.LeaveNote(cmdCtx, req)
+				resp, err = client.LeaveNote(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(FarewellServiceServer)
-				resp, err = svcImpl.LeaveNote(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "FarewellService.LeaveNote", func() (*NoteResponse, error) {
+					return svcImpl.LeaveNote(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.FarewellService/LeaveNote", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getFarewellServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -895,36 +1181,15 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -941,7 +1206,12 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			}
 			return ctx, nil
 		},
-		Flags: flags_leave_note,
+		Description: "LeaveNote attaches a free-form JSON note to a farewell.\nDemonstrates the JSON editor TUI control.",
+		Flags:       flags_leave_note,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.FarewellService/LeaveNote",
+			protocli.RequestFlagsMetadataKey: []string{"name", "metadata"},
+		},
 		Name:  "leave-note",
 		Usage: "Attach a JSON note to a farewell",
 	})
@@ -950,6 +1220,16 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	flags_countdown_farewell := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}, &v3.BoolFlag{
+		Name:  "reconnect",
+		Usage: "Re-establish the remote stream with backoff when it fails or ends (like tail -f)",
+	}, &v3.DurationFlag{
+		Name:  "reconnect-backoff",
+		Usage: "Delay before the first reconnect attempt; doubles on each failure up to 30s",
+		Value: protocli.DefaultReconnectBackoff,
 	}, &v3.StringFlag{
 		Name:  "format",
 		Usage: "Output format (use --format to see available formats)",
@@ -958,16 +1238,42 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "delimiter",
 		Usage: "Delimiter between streamed messages",
 		Value: "\n",
+	}, &v3.BoolFlag{
+		Name:  "raw",
+		Usage: "Write each message as binary protobuf with a 4-byte big-endian length prefix instead of formatting it",
+	}, &v3.BoolFlag{
+		Name:  "collect",
+		Usage: "Write the whole stream once it ends, as a single CountdownFarewellResults message with an items list",
+	}, &v3.BoolFlag{
+		Name:  "stream-summary",
+		Usage: "Print the message count and duration to stderr when the stream ends",
+	}, &v3.StringFlag{
+		Name:  "flush",
+		Usage: "Flush output after each message: auto (only when writing to a terminal), always, or never",
+		Value: "auto",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
 	}}
 
 	flags_countdown_farewell = append(flags_countdown_farewell, &v3.StringFlag{
@@ -987,12 +1293,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_countdown_farewell = append(flags_countdown_farewell, flagConfigured.Flags()...)
-		}
-	}
+	flags_countdown_farewell = append(flags_countdown_farewell, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_countdown_farewell = append(flags_countdown_farewell, &v3.BoolFlag{
 		Name:  "interactive",
@@ -1000,10 +1301,18 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return fmt.Errorf("unsupported argument: %s", cmd.Args().Get(0))
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -1019,6 +1328,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *CountdownFarewellRequest
 
@@ -1032,7 +1342,11 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.CountdownFarewellRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("from") {
 					req.From = cmd.Int32("from")
@@ -1045,11 +1359,13 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				deserializer, hasDeserializer := options.FlagDeserializer("tui_example.CountdownFarewellRequest")
 				if hasDeserializer {
 					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
 					requestFlags := protocli.NewFlagContainer(cmd, "")
 					msg, err := deserializer(cmdCtx, requestFlags)
 					if err != nil {
 						return fmt.Errorf("custom deserializer failed: %w", err)
 					}
+					// Handle nil return from deserializer
 					if msg == nil {
 						return fmt.Errorf("custom deserializer returned nil message")
 					}
@@ -1061,12 +1377,33 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				} else {
 					// Use auto-generated flag parsing
 					req = &CountdownFarewellRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.CountdownFarewellRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					req.From = cmd.Int32("from")
 					req.DelayMs = cmd.Int32("delay-ms")
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.FarewellService/CountdownFarewell", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.FarewellService/CountdownFarewell")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &CountdownFarewellResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
 			// Open output writer
 			outputWriter, err := getFarewellServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -1076,6 +1413,14 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer closer.Close()
 			}
 
+			// Buffer the output, flushing it after each message per --flush
+			streamOutput, err := protocli.NewStreamOutput(cmd, outputWriter)
+			if err != nil {
+				return err
+			}
+			defer streamOutput.Flush()
+			outputWriter = streamOutput
+
 			// Find the appropriate output format
 			formatName := cmd.String("format")
 			var outputFmt protocli.OutputFormat
@@ -1092,40 +1437,169 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), formatName)
 
 			// Get delimiter for separating streamed messages
 			delimiter := cmd.String("delimiter")
+			raw := cmd.Bool("raw")
+
+			// Gather the stream into one container message for --collect
+			collector, err := protocli.NewStreamCollector(cmd, "tui_example.CountdownFarewellResults", &CountdownFarewellResponse{})
+			if err != nil {
+				return err
+			}
+
+			// Start timing for --stream-summary
+			streamStart := time.Now()
+
+			if protocli.Replaying(cmd) {
+				// Replay the recorded stream instead of calling the service
+				timings.Phase("service call")
+				replayed, err := protocli.ReplayStream[*CountdownFarewellResponse](cmd, "/tui_example.FarewellService/CountdownFarewell", req)
+				if err != nil {
+					return err
+				}
+				var messageCount int
+				for _, msg := range replayed {
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
+
+					// Format and write the message
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
+				return nil
+			}
+
+			// Record the stream for --replay (nil unless WithRecord is set)
+			recording := protocli.NewStreamRecording(cmd, "/tui_example.FarewellService/CountdownFarewell", req)
 
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 
 			if remoteAddr != "" {
 				// Remote gRPC streaming call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewFarewellServiceClient(conn)
-				stream, err := client.CountdownFarewell(cmdCtx, req)
+				stream, err := client.CountdownFarewell(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("failed to start stream: %w", err)
 				}
 
+				// Re-establish the stream on failure or EOF when --reconnect is set
+				reconnector := protocli.NewStreamReconnector(cmd, "", "")
+
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					msg, recvErr := stream.Recv()
+					if recvErr != nil && reconnector.Enabled() {
+						stream, err = protocli.ReconnectStream(cmdCtx, reconnector, req, recvErr, func() (grpc.ServerStreamingClient[CountdownFarewellResponse], error) {
+							return client.CountdownFarewell(cmdCtx, req, callOpts...)
+						})
+						if err != nil {
+							return fmt.Errorf("stream receive error: %w", err)
+						}
+						continue
+					}
 					if recvErr == io.EOF {
 						break
 					}
 					if recvErr != nil {
 						return fmt.Errorf("stream receive error: %w", recvErr)
 					}
+					reconnector.Observe(msg)
+					recording.Observe(msg)
+
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
 
 					// Format and write the message
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 						return fmt.Errorf("format failed: %w", err)
 					}
 
@@ -1134,29 +1608,51 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 						return fmt.Errorf("failed to write delimiter: %w", err)
 					}
 					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
 				}
 
-				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+				recording.Finish()
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
 						return fmt.Errorf("failed to write final newline: %w", err)
 					}
 				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(FarewellServiceServer)
 
+				timings.Phase("service call")
+				// Cancel the stream if we stop receiving early (e.g. a format error)
+				streamCtx, cancelStream := context.WithCancel(cmdCtx)
+				defer cancelStream()
+
 				// Create local stream wrapper for direct call
 				localStream := &localServerStream_FarewellService_CountdownFarewell{
-					ctx:       cmdCtx,
-					errors:    make(chan error),
+					ctx:       streamCtx,
+					errors:    make(chan error, 1),
 					responses: make(chan *CountdownFarewellResponse),
 				}
 
 				// Call streaming method in goroutine
 				go func() {
 					var methodErr error
-					methodErr = svcImpl.CountdownFarewell(req, localStream)
+					methodErr = protocli.RecoverMethodPanic(cmd, "FarewellService.CountdownFarewell", func() error {
+						return svcImpl.CountdownFarewell(req, localStream)
+					})
 					close(localStream.responses)
 					if methodErr != nil {
 						localStream.errors <- methodErr
@@ -1167,6 +1663,7 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					select {
 					case msg, ok := <-localStream.responses:
 						if !ok {
@@ -1174,17 +1671,54 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 							if streamErr := <-localStream.errors; streamErr != nil {
 								return fmt.Errorf("stream error: %w", streamErr)
 							}
+							recording.Finish()
+							timings.Phase("format")
+							// Write the --collect container now that the stream has ended
+							if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+								return err
+							}
+
 							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+							if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 								if _, err := outputWriter.Write([]byte("\n")); err != nil {
 									return fmt.Errorf("failed to write final newline: %w", err)
 								}
 							}
+							if err := streamOutput.Flush(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 							return nil
 						}
+						recording.Observe(msg)
+
+						timings.Phase("format")
+						// Drop --fields-exclude paths from the response
+						msg, err := protocli.ExcludeResponseFields(cmd, msg)
+						if err != nil {
+							return err
+						}
+
+						// Hold the message for --collect; the container is written when the stream ends
+						if collector.Add(msg) {
+							messageCount++
+							continue
+						}
+
+						// Write length-prefixed binary frames for --raw
+						if raw {
+							if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+								return fmt.Errorf("failed to write message: %w", err)
+							}
+							messageCount++
+							if err := streamOutput.MessageDone(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							continue
+						}
 
 						// Format and write the message
-						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 							return fmt.Errorf("format failed: %w", err)
 						}
 
@@ -1193,6 +1727,10 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 							return fmt.Errorf("failed to write delimiter: %w", err)
 						}
 						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+
 					case <-cmdCtx.Done():
 						return cmdCtx.Err()
 					}
@@ -1202,8 +1740,8 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			return nil
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -1223,7 +1761,12 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			}
 			return ctx, nil
 		},
-		Flags: flags_countdown_farewell,
+		Description: "CountdownFarewell streams a dramatic countdown before the final goodbye.\nDemonstrates server-streaming in the TUI.",
+		Flags:       flags_countdown_farewell,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.FarewellService/CountdownFarewell",
+			protocli.RequestFlagsMetadataKey: []string{"name", "from", "delay-ms"},
+		},
 		Name:  "countdown-farewell",
 		Usage: "Count down to a dramatic goodbye",
 	})
@@ -1231,8 +1774,8 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	return &protocli.ServiceCLI{
 		Command: &v3.Command{
 			Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-				if cmd.Args().Len() > 0 {
-					return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+				if err := protocli.CheckArgs(cmd); err != nil {
+					return ctx, err
 				}
 				if cmd.Bool("interactive") {
 					if err := protocli.InvokeTUI(ctx, cmd, protocli.StartAtService("farewell")); err != nil {
@@ -1247,11 +1790,14 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				Name:  "interactive",
 				Usage: "Open the interactive TUI at this service",
 			}},
-			Name:  "farewell",
-			Usage: "FarewellService demonstrates a second TUI-enabled service.",
+			Metadata: map[string]any{protocli.ServiceMetadataKey: "farewell"},
+			Name:     "farewell",
+			Usage:    "FarewellService demonstrates a second TUI-enabled service.",
 		},
 		ConfigMessageType: "",
 		FactoryOrImpl:     implOrFactory,
+		InputFormats:      options.InputFormats(),
+		OutputFormats:     options.OutputFormats(),
 		RegisterFunc: func(s *grpc.Server, impl interface{}) {
 			RegisterFarewellServiceServer(s, impl.(FarewellServiceServer))
 		},
@@ -1608,6 +2154,108 @@ func FarewellServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			}},
 			Name: "farewell",
 		},
+		ValidateCommands: []*v3.Command{
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &FarewellRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "farewell",
+				Usage: "Check that --input-file is a valid farewell request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &FarewellManyRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "farewell-many",
+				Usage: "Check that --input-file is a valid farewell-many request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &ScheduledFarewellRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "scheduled-farewell",
+				Usage: "Check that --input-file is a valid scheduled-farewell request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &NoteRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "leave-note",
+				Usage: "Check that --input-file is a valid leave-note request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &CountdownFarewellRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "countdown-farewell",
+				Usage: "Check that --input-file is a valid countdown-farewell request",
+			},
+		},
 	}
 }
 
@@ -1638,12 +2286,34 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_farewell = append(flags_farewell, &v3.StringFlag{
@@ -1657,12 +2327,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_farewell = append(flags_farewell, flagConfigured.Flags()...)
-		}
-	}
+	flags_farewell = append(flags_farewell, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_farewell = append(flags_farewell, &v3.BoolFlag{
 		Name:  "interactive",
@@ -1670,10 +2335,18 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
 			}
 
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -1689,6 +2362,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *FarewellRequest
 
@@ -1702,7 +2376,11 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.FarewellRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("formal") {
 					req.Formal = cmd.Bool("formal")
@@ -1730,38 +2408,86 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				} else {
 					// Use auto-generated flag parsing
 					req = &FarewellRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.FarewellRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					req.Formal = cmd.Bool("formal")
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.FarewellService/Farewell", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.FarewellService/Farewell")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &FarewellResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *FarewellResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*FarewellResponse](cmd, "/tui_example.FarewellService/Farewell", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewFarewellServiceClient(conn)
-				resp, err = client.Farewell(cmdCtx, req)
+				resp, err = client.Farewell(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(FarewellServiceServer)
-				resp, err = svcImpl.Farewell(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "FarewellService.Farewell", func() (*FarewellResponse, error) {
+					return svcImpl.Farewell(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.FarewellService/Farewell", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getFarewellServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -1771,36 +2497,15 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -1818,6 +2523,10 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			return ctx, nil
 		},
 		Flags: flags_farewell,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.FarewellService/Farewell",
+			protocli.RequestFlagsMetadataKey: []string{"name", "formal"},
+		},
 		Name:  "farewell",
 		Usage: "Say goodbye to someone",
 	})
@@ -1834,12 +2543,34 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_farewell_many = append(flags_farewell_many, &v3.StringSliceFlag{
@@ -1848,12 +2579,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_farewell_many = append(flags_farewell_many, flagConfigured.Flags()...)
-		}
-	}
+	flags_farewell_many = append(flags_farewell_many, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_farewell_many = append(flags_farewell_many, &v3.BoolFlag{
 		Name:  "interactive",
@@ -1861,10 +2587,18 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -1880,6 +2614,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *FarewellManyRequest
 
@@ -1922,33 +2657,75 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.FarewellService/FarewellMany", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.FarewellService/FarewellMany")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &FarewellManyResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *FarewellManyResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*FarewellManyResponse](cmd, "/tui_example.FarewellService/FarewellMany", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewFarewellServiceClient(conn)
-				resp, err = client.FarewellMany(cmdCtx, req)
+				resp, err = client.FarewellMany(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(FarewellServiceServer)
-				resp, err = svcImpl.FarewellMany(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "FarewellService.FarewellMany", func() (*FarewellManyResponse, error) {
+					return svcImpl.FarewellMany(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.FarewellService/FarewellMany", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getFarewellServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -1958,36 +2735,15 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -1999,6 +2755,10 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			return ctx, nil
 		},
 		Flags: flags_farewell_many,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.FarewellService/FarewellMany",
+			protocli.RequestFlagsMetadataKey: []string{"names"},
+		},
 		Name:  "farewell-many",
 		Usage: "Say goodbye to multiple people",
 	})
@@ -2015,12 +2775,34 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_scheduled_farewell = append(flags_scheduled_farewell, &v3.StringFlag{
@@ -2038,12 +2820,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_scheduled_farewell = append(flags_scheduled_farewell, flagConfigured.Flags()...)
-		}
-	}
+	flags_scheduled_farewell = append(flags_scheduled_farewell, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_scheduled_farewell = append(flags_scheduled_farewell, &v3.BoolFlag{
 		Name:  "interactive",
@@ -2051,10 +2828,18 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -2070,6 +2855,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ScheduledFarewellRequest
 
@@ -2083,7 +2869,11 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.ScheduledFarewellRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("send-at") {
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
@@ -2144,7 +2934,13 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				} else {
 					// Use auto-generated flag parsing
 					req = &ScheduledFarewellRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.ScheduledFarewellRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					// Field SendAt: check for custom deserializer for google.protobuf.Timestamp
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
 						// Use custom deserializer for nested message
@@ -2196,33 +2992,75 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.FarewellService/ScheduledFarewell", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.FarewellService/ScheduledFarewell")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ScheduledFarewellResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *ScheduledFarewellResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*ScheduledFarewellResponse](cmd, "/tui_example.FarewellService/ScheduledFarewell", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewFarewellServiceClient(conn)
-				resp, err = client.ScheduledFarewell(cmdCtx, req)
+				resp, err = client.ScheduledFarewell(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(FarewellServiceServer)
-				resp, err = svcImpl.ScheduledFarewell(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "FarewellService.ScheduledFarewell", func() (*ScheduledFarewellResponse, error) {
+					return svcImpl.ScheduledFarewell(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.FarewellService/ScheduledFarewell", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getFarewellServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -2232,36 +3070,15 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -2281,7 +3098,12 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			}
 			return ctx, nil
 		},
-		Flags: flags_scheduled_farewell,
+		Description: "ScheduledFarewell schedules a farewell for a future time at an address.\nDemonstrates WKT (Timestamp) and nested message flattening in the TUI.",
+		Flags:       flags_scheduled_farewell,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.FarewellService/ScheduledFarewell",
+			protocli.RequestFlagsMetadataKey: []string{"name", "send-at", "address"},
+		},
 		Name:  "scheduled-farewell",
 		Usage: "Schedule a goodbye for a specific time and place",
 	})
@@ -2298,12 +3120,34 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_leave_note = append(flags_leave_note, &v3.StringFlag{
@@ -2317,12 +3161,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_leave_note = append(flags_leave_note, flagConfigured.Flags()...)
-		}
-	}
+	flags_leave_note = append(flags_leave_note, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_leave_note = append(flags_leave_note, &v3.BoolFlag{
 		Name:  "interactive",
@@ -2330,10 +3169,18 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -2349,6 +3196,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *NoteRequest
 
@@ -2362,10 +3210,18 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.NoteRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("metadata") {
-					req.Metadata = cmd.String("metadata")
+					val, err := protocli.TransformFlagValue("tui_example.NoteRequest", "metadata", cmd.String("metadata"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --metadata: %w", err)
+					}
+					req.Metadata = val
 				}
 			} else {
 				// Check for custom flag deserializer for tui_example.NoteRequest
@@ -2390,38 +3246,92 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				} else {
 					// Use auto-generated flag parsing
 					req = &NoteRequest{}
-					req.Name = cmd.String("name")
-					req.Metadata = cmd.String("metadata")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.NoteRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
+					if s := cmd.String("metadata"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.NoteRequest", "metadata", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --metadata: %w", err)
+						}
+						req.Metadata = val
+					}
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.FarewellService/LeaveNote", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.FarewellService/LeaveNote")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &NoteResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *NoteResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*NoteResponse](cmd, "/tui_example.FarewellService/LeaveNote", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewFarewellServiceClient(conn)
-				resp, err = client.LeaveNote(cmdCtx, req)
+				resp, err = client.LeaveNote(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(FarewellServiceServer)
-				resp, err = svcImpl.LeaveNote(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "FarewellService.LeaveNote", func() (*NoteResponse, error) {
+					return svcImpl.LeaveNote(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.FarewellService/LeaveNote", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getFarewellServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -2431,36 +3341,15 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -2477,7 +3366,12 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			}
 			return ctx, nil
 		},
-		Flags: flags_leave_note,
+		Description: "LeaveNote attaches a free-form JSON note to a farewell.\nDemonstrates the JSON editor TUI control.",
+		Flags:       flags_leave_note,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.FarewellService/LeaveNote",
+			protocli.RequestFlagsMetadataKey: []string{"name", "metadata"},
+		},
 		Name:  "leave-note",
 		Usage: "Attach a JSON note to a farewell",
 	})
@@ -2486,6 +3380,16 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	flags_countdown_farewell := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}, &v3.BoolFlag{
+		Name:  "reconnect",
+		Usage: "Re-establish the remote stream with backoff when it fails or ends (like tail -f)",
+	}, &v3.DurationFlag{
+		Name:  "reconnect-backoff",
+		Usage: "Delay before the first reconnect attempt; doubles on each failure up to 30s",
+		Value: protocli.DefaultReconnectBackoff,
 	}, &v3.StringFlag{
 		Name:  "format",
 		Usage: "Output format (use --format to see available formats)",
@@ -2494,16 +3398,42 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "delimiter",
 		Usage: "Delimiter between streamed messages",
 		Value: "\n",
+	}, &v3.BoolFlag{
+		Name:  "raw",
+		Usage: "Write each message as binary protobuf with a 4-byte big-endian length prefix instead of formatting it",
+	}, &v3.BoolFlag{
+		Name:  "collect",
+		Usage: "Write the whole stream once it ends, as a single CountdownFarewellResults message with an items list",
+	}, &v3.BoolFlag{
+		Name:  "stream-summary",
+		Usage: "Print the message count and duration to stderr when the stream ends",
+	}, &v3.StringFlag{
+		Name:  "flush",
+		Usage: "Flush output after each message: auto (only when writing to a terminal), always, or never",
+		Value: "auto",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
 	}}
 
 	flags_countdown_farewell = append(flags_countdown_farewell, &v3.StringFlag{
@@ -2523,12 +3453,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_countdown_farewell = append(flags_countdown_farewell, flagConfigured.Flags()...)
-		}
-	}
+	flags_countdown_farewell = append(flags_countdown_farewell, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_countdown_farewell = append(flags_countdown_farewell, &v3.BoolFlag{
 		Name:  "interactive",
@@ -2536,10 +3461,18 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return fmt.Errorf("unsupported argument: %s", cmd.Args().Get(0))
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
 			}
 
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -2555,6 +3488,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *CountdownFarewellRequest
 
@@ -2568,7 +3502,11 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.CountdownFarewellRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("from") {
 					req.From = cmd.Int32("from")
@@ -2581,11 +3519,13 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				deserializer, hasDeserializer := options.FlagDeserializer("tui_example.CountdownFarewellRequest")
 				if hasDeserializer {
 					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
 					requestFlags := protocli.NewFlagContainer(cmd, "")
 					msg, err := deserializer(cmdCtx, requestFlags)
 					if err != nil {
 						return fmt.Errorf("custom deserializer failed: %w", err)
 					}
+					// Handle nil return from deserializer
 					if msg == nil {
 						return fmt.Errorf("custom deserializer returned nil message")
 					}
@@ -2597,12 +3537,33 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				} else {
 					// Use auto-generated flag parsing
 					req = &CountdownFarewellRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.CountdownFarewellRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					req.From = cmd.Int32("from")
 					req.DelayMs = cmd.Int32("delay-ms")
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.FarewellService/CountdownFarewell", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.FarewellService/CountdownFarewell")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &CountdownFarewellResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
 			// Open output writer
 			outputWriter, err := getFarewellServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -2612,6 +3573,14 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer closer.Close()
 			}
 
+			// Buffer the output, flushing it after each message per --flush
+			streamOutput, err := protocli.NewStreamOutput(cmd, outputWriter)
+			if err != nil {
+				return err
+			}
+			defer streamOutput.Flush()
+			outputWriter = streamOutput
+
 			// Find the appropriate output format
 			formatName := cmd.String("format")
 			var outputFmt protocli.OutputFormat
@@ -2628,40 +3597,58 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), formatName)
 
 			// Get delimiter for separating streamed messages
 			delimiter := cmd.String("delimiter")
+			raw := cmd.Bool("raw")
 
-			// Check if using remote gRPC call or direct implementation call
-			remoteAddr := cmd.String("remote")
+			// Gather the stream into one container message for --collect
+			collector, err := protocli.NewStreamCollector(cmd, "tui_example.CountdownFarewellResults", &CountdownFarewellResponse{})
+			if err != nil {
+				return err
+			}
 
-			if remoteAddr != "" {
-				// Remote gRPC streaming call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
-				if connErr != nil {
-					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
-				}
-				defer conn.Close()
+			// Start timing for --stream-summary
+			streamStart := time.Now()
 
-				client := NewFarewellServiceClient(conn)
-				stream, err := client.CountdownFarewell(cmdCtx, req)
+			if protocli.Replaying(cmd) {
+				// Replay the recorded stream instead of calling the service
+				timings.Phase("service call")
+				replayed, err := protocli.ReplayStream[*CountdownFarewellResponse](cmd, "/tui_example.FarewellService/CountdownFarewell", req)
 				if err != nil {
-					return fmt.Errorf("failed to start stream: %w", err)
+					return err
 				}
-
-				// Receive and format each message in the stream
 				var messageCount int
-				for {
-					msg, recvErr := stream.Recv()
-					if recvErr == io.EOF {
-						break
+				for _, msg := range replayed {
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
 					}
-					if recvErr != nil {
-						return fmt.Errorf("stream receive error: %w", recvErr)
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
 					}
 
 					// Format and write the message
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 						return fmt.Errorf("format failed: %w", err)
 					}
 
@@ -2670,29 +3657,162 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 						return fmt.Errorf("failed to write delimiter: %w", err)
 					}
 					messageCount++
-				}
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
+				}
 
 				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 					if _, err := outputWriter.Write([]byte("\n")); err != nil {
 						return fmt.Errorf("failed to write final newline: %w", err)
 					}
 				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
+				return nil
+			}
+
+			// Record the stream for --replay (nil unless WithRecord is set)
+			recording := protocli.NewStreamRecording(cmd, "/tui_example.FarewellService/CountdownFarewell", req)
+
+			// Check if using remote gRPC call or direct implementation call
+			remoteAddr := cmd.String("remote")
+
+			if remoteAddr != "" {
+				// Remote gRPC streaming call
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
+				if connErr != nil {
+					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
+				}
+				defer conn.Close()
+
+				client := NewFarewellServiceClient(conn)
+				stream, err := client.CountdownFarewell(cmdCtx, req, callOpts...)
+				if err != nil {
+					return fmt.Errorf("failed to start stream: %w", err)
+				}
+
+				// Re-establish the stream on failure or EOF when --reconnect is set
+				reconnector := protocli.NewStreamReconnector(cmd, "", "")
+
+				// Receive and format each message in the stream
+				var messageCount int
+				for {
+					timings.Phase("service call")
+					msg, recvErr := stream.Recv()
+					if recvErr != nil && reconnector.Enabled() {
+						stream, err = protocli.ReconnectStream(cmdCtx, reconnector, req, recvErr, func() (grpc.ServerStreamingClient[CountdownFarewellResponse], error) {
+							return client.CountdownFarewell(cmdCtx, req, callOpts...)
+						})
+						if err != nil {
+							return fmt.Errorf("stream receive error: %w", err)
+						}
+						continue
+					}
+					if recvErr == io.EOF {
+						break
+					}
+					if recvErr != nil {
+						return fmt.Errorf("stream receive error: %w", recvErr)
+					}
+					reconnector.Observe(msg)
+					recording.Observe(msg)
+
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
+
+					// Format and write the message
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				recording.Finish()
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(FarewellServiceServer)
 
+				timings.Phase("service call")
+				// Cancel the stream if we stop receiving early (e.g. a format error)
+				streamCtx, cancelStream := context.WithCancel(cmdCtx)
+				defer cancelStream()
+
 				// Create local stream wrapper for direct call
 				localStream := &localServerStream_FarewellService_CountdownFarewell{
-					ctx:       cmdCtx,
-					errors:    make(chan error),
+					ctx:       streamCtx,
+					errors:    make(chan error, 1),
 					responses: make(chan *CountdownFarewellResponse),
 				}
 
 				// Call streaming method in goroutine
 				go func() {
 					var methodErr error
-					methodErr = svcImpl.CountdownFarewell(req, localStream)
+					methodErr = protocli.RecoverMethodPanic(cmd, "FarewellService.CountdownFarewell", func() error {
+						return svcImpl.CountdownFarewell(req, localStream)
+					})
 					close(localStream.responses)
 					if methodErr != nil {
 						localStream.errors <- methodErr
@@ -2703,6 +3823,7 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					select {
 					case msg, ok := <-localStream.responses:
 						if !ok {
@@ -2710,17 +3831,54 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 							if streamErr := <-localStream.errors; streamErr != nil {
 								return fmt.Errorf("stream error: %w", streamErr)
 							}
+							recording.Finish()
+							timings.Phase("format")
+							// Write the --collect container now that the stream has ended
+							if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+								return err
+							}
+
 							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+							if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 								if _, err := outputWriter.Write([]byte("\n")); err != nil {
 									return fmt.Errorf("failed to write final newline: %w", err)
 								}
 							}
+							if err := streamOutput.Flush(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 							return nil
 						}
+						recording.Observe(msg)
+
+						timings.Phase("format")
+						// Drop --fields-exclude paths from the response
+						msg, err := protocli.ExcludeResponseFields(cmd, msg)
+						if err != nil {
+							return err
+						}
+
+						// Hold the message for --collect; the container is written when the stream ends
+						if collector.Add(msg) {
+							messageCount++
+							continue
+						}
+
+						// Write length-prefixed binary frames for --raw
+						if raw {
+							if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+								return fmt.Errorf("failed to write message: %w", err)
+							}
+							messageCount++
+							if err := streamOutput.MessageDone(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							continue
+						}
 
 						// Format and write the message
-						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 							return fmt.Errorf("format failed: %w", err)
 						}
 
@@ -2729,6 +3887,10 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 							return fmt.Errorf("failed to write delimiter: %w", err)
 						}
 						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+
 					case <-cmdCtx.Done():
 						return cmdCtx.Err()
 					}
@@ -2738,8 +3900,8 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			return nil
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -2759,7 +3921,12 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			}
 			return ctx, nil
 		},
-		Flags: flags_countdown_farewell,
+		Description: "CountdownFarewell streams a dramatic countdown before the final goodbye.\nDemonstrates server-streaming in the TUI.",
+		Flags:       flags_countdown_farewell,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.FarewellService/CountdownFarewell",
+			protocli.RequestFlagsMetadataKey: []string{"name", "from", "delay-ms"},
+		},
 		Name:  "countdown-farewell",
 		Usage: "Count down to a dramatic goodbye",
 	})
@@ -2783,6 +3950,17 @@ func FarewellServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	return commands
 }
 
+// NewFarewellServiceCLIClient dials the remote given by WithRemote or resolved from a
+// command's --remote and --context flags (WithRemoteCommand), applying TLS,
+// retry and auth options, and returns the FarewellServiceClient. The caller must close the connection.
+func NewFarewellServiceCLIClient(opts ...protocli.ClientOption) (FarewellServiceClient, *grpc.ClientConn, error) {
+	conn, err := protocli.DialRemote(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewFarewellServiceClient(conn), conn, nil
+}
+
 // getDirectoryServiceOutputWriter opens the specified output file or returns cmd.Writer (if set) or stdout
 func getDirectoryServiceOutputWriter(cmd *v3.Command, path string) (io.Writer, error) {
 	if path == "-" || path == "" {
@@ -2795,7 +3973,11 @@ func getDirectoryServiceOutputWriter(cmd *v3.Command, path string) (io.Writer, e
 		}
 		return os.Stdout, nil
 	}
-	return os.Create(path)
+	path, err := protocli.OutputPath(cmd, path)
+	if err != nil {
+		return nil, err
+	}
+	return protocli.OpenOutputFile(path, cmd.String("output-mode"), cmd.Bool("output-append"))
 }
 
 // localServerStream_DirectoryService_ListPeople is a helper type for local server streaming calls to ListPeople
@@ -2840,6 +4022,27 @@ func (s *localServerStream_DirectoryService_ListPeople) RecvMsg(m any) error {
 	return fmt.Errorf("RecvMsg not supported on server streaming")
 }
 
+// DirectoryServiceListPeople runs impl.ListPeople in-process.
+// The streamed messages arrive on the first channel, which is closed when the
+// stream ends; the second then yields the method's error, if any, and is
+// closed. Cancel ctx to stop the stream early.
+func DirectoryServiceListPeople(ctx context.Context, impl DirectoryServiceServer, req *ListPeopleRequest) (<-chan *PersonCard, <-chan error) {
+	localStream := &localServerStream_DirectoryService_ListPeople{
+		ctx:       ctx,
+		errors:    make(chan error, 1),
+		responses: make(chan *PersonCard),
+	}
+	go func() {
+		methodErr := impl.ListPeople(req, localStream)
+		close(localStream.responses)
+		if methodErr != nil {
+			localStream.errors <- methodErr
+		}
+		close(localStream.errors)
+	}()
+	return localStream.responses, localStream.errors
+}
+
 // DirectoryServiceCommand creates a CLI for DirectoryService with options
 // The implOrFactory parameter can be either a direct service implementation or a factory function
 func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opts ...protocli.ServiceOption) *protocli.ServiceCLI {
@@ -2857,6 +4060,16 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 	flags_list_people := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}, &v3.BoolFlag{
+		Name:  "reconnect",
+		Usage: "Re-establish the remote stream with backoff when it fails or ends (like tail -f)",
+	}, &v3.DurationFlag{
+		Name:  "reconnect-backoff",
+		Usage: "Delay before the first reconnect attempt; doubles on each failure up to 30s",
+		Value: protocli.DefaultReconnectBackoff,
 	}, &v3.StringFlag{
 		Name:  "format",
 		Usage: "Output format (use --format to see available formats)",
@@ -2865,16 +4078,42 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "delimiter",
 		Usage: "Delimiter between streamed messages",
 		Value: "\n",
+	}, &v3.BoolFlag{
+		Name:  "raw",
+		Usage: "Write each message as binary protobuf with a 4-byte big-endian length prefix instead of formatting it",
+	}, &v3.BoolFlag{
+		Name:  "collect",
+		Usage: "Write the whole stream once it ends, as a single ListPeopleResults message with an items list",
+	}, &v3.BoolFlag{
+		Name:  "stream-summary",
+		Usage: "Print the message count and duration to stderr when the stream ends",
+	}, &v3.StringFlag{
+		Name:  "flush",
+		Usage: "Flush output after each message: auto (only when writing to a terminal), always, or never",
+		Value: "auto",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
 	}}
 
 	flags_list_people = append(flags_list_people, &v3.StringFlag{
@@ -2883,12 +4122,7 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_list_people = append(flags_list_people, flagConfigured.Flags()...)
-		}
-	}
+	flags_list_people = append(flags_list_people, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_list_people = append(flags_list_people, &v3.BoolFlag{
 		Name:  "interactive",
@@ -2896,10 +4130,18 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return fmt.Errorf("unsupported argument: %s", cmd.Args().Get(0))
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
 			}
 
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -2915,6 +4157,7 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ListPeopleRequest
 
@@ -2928,18 +4171,24 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("filter") {
-					req.Filter = cmd.String("filter")
+					val, err := protocli.TransformFlagValue("tui_example.ListPeopleRequest", "filter", cmd.String("filter"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --filter: %w", err)
+					}
+					req.Filter = val
 				}
 			} else {
 				// Check for custom flag deserializer for tui_example.ListPeopleRequest
 				deserializer, hasDeserializer := options.FlagDeserializer("tui_example.ListPeopleRequest")
 				if hasDeserializer {
 					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
 					requestFlags := protocli.NewFlagContainer(cmd, "")
 					msg, err := deserializer(cmdCtx, requestFlags)
 					if err != nil {
 						return fmt.Errorf("custom deserializer failed: %w", err)
 					}
+					// Handle nil return from deserializer
 					if msg == nil {
 						return fmt.Errorf("custom deserializer returned nil message")
 					}
@@ -2951,10 +4200,31 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				} else {
 					// Use auto-generated flag parsing
 					req = &ListPeopleRequest{}
-					req.Filter = cmd.String("filter")
+					if s := cmd.String("filter"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.ListPeopleRequest", "filter", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --filter: %w", err)
+						}
+						req.Filter = val
+					}
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.DirectoryService/ListPeople", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.DirectoryService/ListPeople")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &PersonCard{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
 			// Open output writer
 			outputWriter, err := getDirectoryServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -2964,6 +4234,14 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				defer closer.Close()
 			}
 
+			// Buffer the output, flushing it after each message per --flush
+			streamOutput, err := protocli.NewStreamOutput(cmd, outputWriter)
+			if err != nil {
+				return err
+			}
+			defer streamOutput.Flush()
+			outputWriter = streamOutput
+
 			// Find the appropriate output format
 			formatName := cmd.String("format")
 			var outputFmt protocli.OutputFormat
@@ -2980,40 +4258,169 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				}
 				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), formatName)
 
 			// Get delimiter for separating streamed messages
 			delimiter := cmd.String("delimiter")
+			raw := cmd.Bool("raw")
+
+			// Gather the stream into one container message for --collect
+			collector, err := protocli.NewStreamCollector(cmd, "tui_example.ListPeopleResults", &PersonCard{})
+			if err != nil {
+				return err
+			}
+
+			// Start timing for --stream-summary
+			streamStart := time.Now()
+
+			if protocli.Replaying(cmd) {
+				// Replay the recorded stream instead of calling the service
+				timings.Phase("service call")
+				replayed, err := protocli.ReplayStream[*PersonCard](cmd, "/tui_example.DirectoryService/ListPeople", req)
+				if err != nil {
+					return err
+				}
+				var messageCount int
+				for _, msg := range replayed {
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
+
+					// Format and write the message
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
+				return nil
+			}
+
+			// Record the stream for --replay (nil unless WithRecord is set)
+			recording := protocli.NewStreamRecording(cmd, "/tui_example.DirectoryService/ListPeople", req)
 
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 
 			if remoteAddr != "" {
 				// Remote gRPC streaming call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewDirectoryServiceClient(conn)
-				stream, err := client.ListPeople(cmdCtx, req)
+				stream, err := client.ListPeople(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("failed to start stream: %w", err)
 				}
 
+				// Re-establish the stream on failure or EOF when --reconnect is set
+				reconnector := protocli.NewStreamReconnector(cmd, "", "")
+
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					msg, recvErr := stream.Recv()
+					if recvErr != nil && reconnector.Enabled() {
+						stream, err = protocli.ReconnectStream(cmdCtx, reconnector, req, recvErr, func() (grpc.ServerStreamingClient[PersonCard], error) {
+							return client.ListPeople(cmdCtx, req, callOpts...)
+						})
+						if err != nil {
+							return fmt.Errorf("stream receive error: %w", err)
+						}
+						continue
+					}
 					if recvErr == io.EOF {
 						break
 					}
 					if recvErr != nil {
 						return fmt.Errorf("stream receive error: %w", recvErr)
 					}
+					reconnector.Observe(msg)
+					recording.Observe(msg)
+
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
 
 					// Format and write the message
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 						return fmt.Errorf("format failed: %w", err)
 					}
 
@@ -3022,29 +4429,51 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 						return fmt.Errorf("failed to write delimiter: %w", err)
 					}
 					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				recording.Finish()
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
 				}
 
 				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 					if _, err := outputWriter.Write([]byte("\n")); err != nil {
 						return fmt.Errorf("failed to write final newline: %w", err)
 					}
 				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(DirectoryServiceServer)
 
+				timings.Phase("service call")
+				// Cancel the stream if we stop receiving early (e.g. a format error)
+				streamCtx, cancelStream := context.WithCancel(cmdCtx)
+				defer cancelStream()
+
 				// Create local stream wrapper for direct call
 				localStream := &localServerStream_DirectoryService_ListPeople{
-					ctx:       cmdCtx,
-					errors:    make(chan error),
+					ctx:       streamCtx,
+					errors:    make(chan error, 1),
 					responses: make(chan *PersonCard),
 				}
 
 				// Call streaming method in goroutine
 				go func() {
 					var methodErr error
-					methodErr = svcImpl.ListPeople(req, localStream)
+					methodErr = protocli.RecoverMethodPanic(cmd, "DirectoryService.ListPeople", func() error {
+						return svcImpl.ListPeople(req, localStream)
+					})
 					close(localStream.responses)
 					if methodErr != nil {
 						localStream.errors <- methodErr
@@ -3055,6 +4484,7 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					select {
 					case msg, ok := <-localStream.responses:
 						if !ok {
@@ -3062,17 +4492,54 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 							if streamErr := <-localStream.errors; streamErr != nil {
 								return fmt.Errorf("stream error: %w", streamErr)
 							}
+							recording.Finish()
+							timings.Phase("format")
+							// Write the --collect container now that the stream has ended
+							if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+								return err
+							}
+
 							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+							if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 								if _, err := outputWriter.Write([]byte("\n")); err != nil {
 									return fmt.Errorf("failed to write final newline: %w", err)
 								}
 							}
+							if err := streamOutput.Flush(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 							return nil
 						}
+						recording.Observe(msg)
+
+						timings.Phase("format")
+						// Drop --fields-exclude paths from the response
+						msg, err := protocli.ExcludeResponseFields(cmd, msg)
+						if err != nil {
+							return err
+						}
+
+						// Hold the message for --collect; the container is written when the stream ends
+						if collector.Add(msg) {
+							messageCount++
+							continue
+						}
+
+						// Write length-prefixed binary frames for --raw
+						if raw {
+							if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+								return fmt.Errorf("failed to write message: %w", err)
+							}
+							messageCount++
+							if err := streamOutput.MessageDone(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							continue
+						}
 
 						// Format and write the message
-						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 							return fmt.Errorf("format failed: %w", err)
 						}
 
@@ -3081,6 +4548,10 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 							return fmt.Errorf("failed to write delimiter: %w", err)
 						}
 						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+
 					case <-cmdCtx.Done():
 						return cmdCtx.Err()
 					}
@@ -3090,8 +4561,8 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 			return nil
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -3106,6 +4577,10 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 			return ctx, nil
 		},
 		Flags: flags_list_people,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.DirectoryService/ListPeople",
+			protocli.RequestFlagsMetadataKey: []string{"filter"},
+		},
 		Name:  "list-people",
 		Usage: "Browse the contact directory",
 	})
@@ -3113,8 +4588,8 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 	return &protocli.ServiceCLI{
 		Command: &v3.Command{
 			Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-				if cmd.Args().Len() > 0 {
-					return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+				if err := protocli.CheckArgs(cmd); err != nil {
+					return ctx, err
 				}
 				if cmd.Bool("interactive") {
 					if err := protocli.InvokeTUI(ctx, cmd, protocli.StartAtService("directory")); err != nil {
@@ -3129,11 +4604,14 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 				Name:  "interactive",
 				Usage: "Open the interactive TUI at this service",
 			}},
-			Name:  "directory",
-			Usage: "Contact directory",
+			Metadata: map[string]any{protocli.ServiceMetadataKey: "directory"},
+			Name:     "directory",
+			Usage:    "Contact directory",
 		},
 		ConfigMessageType: "",
 		FactoryOrImpl:     implOrFactory,
+		InputFormats:      options.InputFormats(),
+		OutputFormats:     options.OutputFormats(),
 		RegisterFunc: func(s *grpc.Server, impl interface{}) {
 			RegisterDirectoryServiceServer(s, impl.(DirectoryServiceServer))
 		},
@@ -3209,6 +4687,28 @@ func DirectoryServiceCommand(ctx context.Context, implOrFactory interface{}, opt
 			}},
 			Name: "directory",
 		},
+		ValidateCommands: []*v3.Command{
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &ListPeopleRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "list-people",
+				Usage: "Check that --input-file is a valid list-people request",
+			},
+		},
 	}
 }
 
@@ -3231,6 +4731,16 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 	flags_list_people := []v3.Flag{&v3.StringFlag{
 		Name:  "remote",
 		Usage: "Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
+	}, &v3.BoolFlag{
+		Name:  "reconnect",
+		Usage: "Re-establish the remote stream with backoff when it fails or ends (like tail -f)",
+	}, &v3.DurationFlag{
+		Name:  "reconnect-backoff",
+		Usage: "Delay before the first reconnect attempt; doubles on each failure up to 30s",
+		Value: protocli.DefaultReconnectBackoff,
 	}, &v3.StringFlag{
 		Name:  "format",
 		Usage: "Output format (use --format to see available formats)",
@@ -3239,16 +4749,42 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "delimiter",
 		Usage: "Delimiter between streamed messages",
 		Value: "\n",
+	}, &v3.BoolFlag{
+		Name:  "raw",
+		Usage: "Write each message as binary protobuf with a 4-byte big-endian length prefix instead of formatting it",
+	}, &v3.BoolFlag{
+		Name:  "collect",
+		Usage: "Write the whole stream once it ends, as a single ListPeopleResults message with an items list",
+	}, &v3.BoolFlag{
+		Name:  "stream-summary",
+		Usage: "Print the message count and duration to stderr when the stream ends",
+	}, &v3.StringFlag{
+		Name:  "flush",
+		Usage: "Flush output after each message: auto (only when writing to a terminal), always, or never",
+		Value: "auto",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
 	}}
 
 	flags_list_people = append(flags_list_people, &v3.StringFlag{
@@ -3257,12 +4793,7 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_list_people = append(flags_list_people, flagConfigured.Flags()...)
-		}
-	}
+	flags_list_people = append(flags_list_people, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_list_people = append(flags_list_people, &v3.BoolFlag{
 		Name:  "interactive",
@@ -3270,10 +4801,18 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return fmt.Errorf("unsupported argument: %s", cmd.Args().Get(0))
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -3289,6 +4828,7 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ListPeopleRequest
 
@@ -3302,18 +4842,24 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("filter") {
-					req.Filter = cmd.String("filter")
+					val, err := protocli.TransformFlagValue("tui_example.ListPeopleRequest", "filter", cmd.String("filter"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --filter: %w", err)
+					}
+					req.Filter = val
 				}
 			} else {
 				// Check for custom flag deserializer for tui_example.ListPeopleRequest
 				deserializer, hasDeserializer := options.FlagDeserializer("tui_example.ListPeopleRequest")
 				if hasDeserializer {
 					// Use custom deserializer for top-level request
+					// Create FlagContainer (deserializer can access multiple flags via Command())
 					requestFlags := protocli.NewFlagContainer(cmd, "")
 					msg, err := deserializer(cmdCtx, requestFlags)
 					if err != nil {
 						return fmt.Errorf("custom deserializer failed: %w", err)
 					}
+					// Handle nil return from deserializer
 					if msg == nil {
 						return fmt.Errorf("custom deserializer returned nil message")
 					}
@@ -3325,10 +4871,31 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				} else {
 					// Use auto-generated flag parsing
 					req = &ListPeopleRequest{}
-					req.Filter = cmd.String("filter")
+					if s := cmd.String("filter"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.ListPeopleRequest", "filter", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --filter: %w", err)
+						}
+						req.Filter = val
+					}
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.DirectoryService/ListPeople", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.DirectoryService/ListPeople")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &PersonCard{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
 			// Open output writer
 			outputWriter, err := getDirectoryServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -3338,6 +4905,14 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				defer closer.Close()
 			}
 
+			// Buffer the output, flushing it after each message per --flush
+			streamOutput, err := protocli.NewStreamOutput(cmd, outputWriter)
+			if err != nil {
+				return err
+			}
+			defer streamOutput.Flush()
+			outputWriter = streamOutput
+
 			// Find the appropriate output format
 			formatName := cmd.String("format")
 			var outputFmt protocli.OutputFormat
@@ -3354,40 +4929,169 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				}
 				return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
 			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), formatName)
 
 			// Get delimiter for separating streamed messages
 			delimiter := cmd.String("delimiter")
+			raw := cmd.Bool("raw")
+
+			// Gather the stream into one container message for --collect
+			collector, err := protocli.NewStreamCollector(cmd, "tui_example.ListPeopleResults", &PersonCard{})
+			if err != nil {
+				return err
+			}
+
+			// Start timing for --stream-summary
+			streamStart := time.Now()
+
+			if protocli.Replaying(cmd) {
+				// Replay the recorded stream instead of calling the service
+				timings.Phase("service call")
+				replayed, err := protocli.ReplayStream[*PersonCard](cmd, "/tui_example.DirectoryService/ListPeople", req)
+				if err != nil {
+					return err
+				}
+				var messageCount int
+				for _, msg := range replayed {
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
+
+					// Format and write the message
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
+						return fmt.Errorf("format failed: %w", err)
+					}
+
+					// Write delimiter
+					if _, err := outputWriter.Write([]byte(delimiter)); err != nil {
+						return fmt.Errorf("failed to write delimiter: %w", err)
+					}
+					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
+				}
+
+				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
+					if _, err := outputWriter.Write([]byte("\n")); err != nil {
+						return fmt.Errorf("failed to write final newline: %w", err)
+					}
+				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
+				return nil
+			}
+
+			// Record the stream for --replay (nil unless WithRecord is set)
+			recording := protocli.NewStreamRecording(cmd, "/tui_example.DirectoryService/ListPeople", req)
 
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 
 			if remoteAddr != "" {
 				// Remote gRPC streaming call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewDirectoryServiceClient(conn)
-				stream, err := client.ListPeople(cmdCtx, req)
+				stream, err := client.ListPeople(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("failed to start stream: %w", err)
 				}
 
+				// Re-establish the stream on failure or EOF when --reconnect is set
+				reconnector := protocli.NewStreamReconnector(cmd, "", "")
+
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					msg, recvErr := stream.Recv()
+					if recvErr != nil && reconnector.Enabled() {
+						stream, err = protocli.ReconnectStream(cmdCtx, reconnector, req, recvErr, func() (grpc.ServerStreamingClient[PersonCard], error) {
+							return client.ListPeople(cmdCtx, req, callOpts...)
+						})
+						if err != nil {
+							return fmt.Errorf("stream receive error: %w", err)
+						}
+						continue
+					}
 					if recvErr == io.EOF {
 						break
 					}
 					if recvErr != nil {
 						return fmt.Errorf("stream receive error: %w", recvErr)
 					}
+					reconnector.Observe(msg)
+					recording.Observe(msg)
+
+					timings.Phase("format")
+					// Drop --fields-exclude paths from the response
+					msg, err := protocli.ExcludeResponseFields(cmd, msg)
+					if err != nil {
+						return err
+					}
+
+					// Hold the message for --collect; the container is written when the stream ends
+					if collector.Add(msg) {
+						messageCount++
+						continue
+					}
+
+					// Write length-prefixed binary frames for --raw
+					if raw {
+						if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+							return fmt.Errorf("failed to write message: %w", err)
+						}
+						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+						continue
+					}
 
 					// Format and write the message
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+					if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 						return fmt.Errorf("format failed: %w", err)
 					}
 
@@ -3396,29 +5100,51 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 						return fmt.Errorf("failed to write delimiter: %w", err)
 					}
 					messageCount++
+					if err := streamOutput.MessageDone(); err != nil {
+						return fmt.Errorf("failed to flush output: %w", err)
+					}
+
+				}
+
+				recording.Finish()
+				timings.Phase("format")
+				// Write the --collect container now that the stream has ended
+				if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+					return err
 				}
 
 				// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-				if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+				if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 					if _, err := outputWriter.Write([]byte("\n")); err != nil {
 						return fmt.Errorf("failed to write final newline: %w", err)
 					}
 				}
+				if err := streamOutput.Flush(); err != nil {
+					return fmt.Errorf("failed to flush output: %w", err)
+				}
+				protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 			} else {
 				// Direct implementation call (no config)
 				svcImpl := implOrFactory.(DirectoryServiceServer)
 
+				timings.Phase("service call")
+				// Cancel the stream if we stop receiving early (e.g. a format error)
+				streamCtx, cancelStream := context.WithCancel(cmdCtx)
+				defer cancelStream()
+
 				// Create local stream wrapper for direct call
 				localStream := &localServerStream_DirectoryService_ListPeople{
-					ctx:       cmdCtx,
-					errors:    make(chan error),
+					ctx:       streamCtx,
+					errors:    make(chan error, 1),
 					responses: make(chan *PersonCard),
 				}
 
 				// Call streaming method in goroutine
 				go func() {
 					var methodErr error
-					methodErr = svcImpl.ListPeople(req, localStream)
+					methodErr = protocli.RecoverMethodPanic(cmd, "DirectoryService.ListPeople", func() error {
+						return svcImpl.ListPeople(req, localStream)
+					})
 					close(localStream.responses)
 					if methodErr != nil {
 						localStream.errors <- methodErr
@@ -3429,6 +5155,7 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 				// Receive and format each message in the stream
 				var messageCount int
 				for {
+					timings.Phase("service call")
 					select {
 					case msg, ok := <-localStream.responses:
 						if !ok {
@@ -3436,17 +5163,54 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 							if streamErr := <-localStream.errors; streamErr != nil {
 								return fmt.Errorf("stream error: %w", streamErr)
 							}
+							recording.Finish()
+							timings.Phase("format")
+							// Write the --collect container now that the stream has ended
+							if err := collector.Write(cmdCtx, cmd, outputWriter, outputFmt, raw); err != nil {
+								return err
+							}
+
 							// Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)
-							if messageCount > 0 && !strings.HasSuffix(delimiter, "\n") {
+							if messageCount > 0 && !raw && !collector.Collecting() && !strings.HasSuffix(delimiter, "\n") && protocli.NeedsTrailingNewline(outputFmt) {
 								if _, err := outputWriter.Write([]byte("\n")); err != nil {
 									return fmt.Errorf("failed to write final newline: %w", err)
 								}
 							}
+							if err := streamOutput.Flush(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							protocli.WriteStreamSummary(cmd, messageCount, streamStart)
 							return nil
 						}
+						recording.Observe(msg)
+
+						timings.Phase("format")
+						// Drop --fields-exclude paths from the response
+						msg, err := protocli.ExcludeResponseFields(cmd, msg)
+						if err != nil {
+							return err
+						}
+
+						// Hold the message for --collect; the container is written when the stream ends
+						if collector.Add(msg) {
+							messageCount++
+							continue
+						}
+
+						// Write length-prefixed binary frames for --raw
+						if raw {
+							if err := protocli.WriteRawMessage(outputWriter, msg); err != nil {
+								return fmt.Errorf("failed to write message: %w", err)
+							}
+							messageCount++
+							if err := streamOutput.MessageDone(); err != nil {
+								return fmt.Errorf("failed to flush output: %w", err)
+							}
+							continue
+						}
 
 						// Format and write the message
-						if err := outputFmt.Format(cmdCtx, cmd, outputWriter, msg); err != nil {
+						if err := protocli.FormatMessage(cmdCtx, cmd, outputFmt, outputWriter, msg); err != nil {
 							return fmt.Errorf("format failed: %w", err)
 						}
 
@@ -3455,6 +5219,10 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 							return fmt.Errorf("failed to write delimiter: %w", err)
 						}
 						messageCount++
+						if err := streamOutput.MessageDone(); err != nil {
+							return fmt.Errorf("failed to flush output: %w", err)
+						}
+
 					case <-cmdCtx.Done():
 						return cmdCtx.Err()
 					}
@@ -3464,8 +5232,8 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 			return nil
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -3480,6 +5248,10 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 			return ctx, nil
 		},
 		Flags: flags_list_people,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.DirectoryService/ListPeople",
+			protocli.RequestFlagsMetadataKey: []string{"filter"},
+		},
 		Name:  "list-people",
 		Usage: "Browse the contact directory",
 	})
@@ -3503,6 +5275,17 @@ func DirectoryServiceCommandsFlat(ctx context.Context, implOrFactory interface{}
 	return commands
 }
 
+// NewDirectoryServiceCLIClient dials the remote given by WithRemote or resolved from a
+// command's --remote and --context flags (WithRemoteCommand), applying TLS,
+// retry and auth options, and returns the DirectoryServiceClient. The caller must close the connection.
+func NewDirectoryServiceCLIClient(opts ...protocli.ClientOption) (DirectoryServiceClient, *grpc.ClientConn, error) {
+	conn, err := protocli.DialRemote(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewDirectoryServiceClient(conn), conn, nil
+}
+
 // getGreeterServiceOutputWriter opens the specified output file or returns cmd.Writer (if set) or stdout
 func getGreeterServiceOutputWriter(cmd *v3.Command, path string) (io.Writer, error) {
 	if path == "-" || path == "" {
@@ -3515,7 +5298,11 @@ func getGreeterServiceOutputWriter(cmd *v3.Command, path string) (io.Writer, err
 		}
 		return os.Stdout, nil
 	}
-	return os.Create(path)
+	path, err := protocli.OutputPath(cmd, path)
+	if err != nil {
+		return nil, err
+	}
+	return protocli.OpenOutputFile(path, cmd.String("output-mode"), cmd.Bool("output-append"))
 }
 
 // GreeterServiceCommand creates a CLI for GreeterService with options
@@ -3543,12 +5330,34 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_greet = append(flags_greet, &v3.StringFlag{
@@ -3568,12 +5377,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_greet = append(flags_greet, flagConfigured.Flags()...)
-		}
-	}
+	flags_greet = append(flags_greet, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_greet = append(flags_greet, &v3.BoolFlag{
 		Name:  "interactive",
@@ -3581,10 +5385,18 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
 			}
 
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -3600,6 +5412,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *GreetRequest
 
@@ -3613,7 +5426,11 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.GreetRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("repeat") {
 					req.Repeat = cmd.Int32("repeat")
@@ -3644,39 +5461,87 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				} else {
 					// Use auto-generated flag parsing
 					req = &GreetRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.GreetRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					req.Repeat = cmd.Int32("repeat")
 					req.Loud = cmd.Bool("loud")
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.GreeterService/Greet", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.GreeterService/Greet")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &GreetResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *GreetResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*GreetResponse](cmd, "/tui_example.GreeterService/Greet", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewGreeterServiceClient(conn)
-				resp, err = client.Greet(cmdCtx, req)
+				resp, err = client.Greet(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(GreeterServiceServer)
-				resp, err = svcImpl.Greet(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "GreeterService.Greet", func() (*GreetResponse, error) {
+					return svcImpl.Greet(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.GreeterService/Greet", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getGreeterServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -3686,36 +5551,15 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -3736,6 +5580,10 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			return ctx, nil
 		},
 		Flags: flags_greet,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.GreeterService/Greet",
+			protocli.RequestFlagsMetadataKey: []string{"name", "repeat", "loud"},
+		},
 		Name:  "greet",
 		Usage: "Say hello to someone",
 	})
@@ -3752,12 +5600,34 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_list_greetings = append(flags_list_greetings, &v3.StringSliceFlag{
@@ -3766,12 +5636,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_list_greetings = append(flags_list_greetings, flagConfigured.Flags()...)
-		}
-	}
+	flags_list_greetings = append(flags_list_greetings, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_list_greetings = append(flags_list_greetings, &v3.BoolFlag{
 		Name:  "interactive",
@@ -3779,10 +5644,18 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
 			}
 
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -3798,6 +5671,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ListGreetingsRequest
 
@@ -3840,33 +5714,75 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.GreeterService/ListGreetings", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.GreeterService/ListGreetings")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ListGreetingsResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *ListGreetingsResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*ListGreetingsResponse](cmd, "/tui_example.GreeterService/ListGreetings", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewGreeterServiceClient(conn)
-				resp, err = client.ListGreetings(cmdCtx, req)
+				resp, err = client.ListGreetings(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(GreeterServiceServer)
-				resp, err = svcImpl.ListGreetings(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "GreeterService.ListGreetings", func() (*ListGreetingsResponse, error) {
+					return svcImpl.ListGreetings(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.GreeterService/ListGreetings", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getGreeterServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -3876,36 +5792,15 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
-
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -3917,6 +5812,10 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			return ctx, nil
 		},
 		Flags: flags_list_greetings,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.GreeterService/ListGreetings",
+			protocli.RequestFlagsMetadataKey: []string{"names"},
+		},
 		Name:  "list-greetings",
 		Usage: "Say hello to multiple people",
 	})
@@ -3933,12 +5832,34 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_hidden = append(flags_hidden, &v3.StringFlag{
@@ -3958,12 +5879,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_hidden = append(flags_hidden, flagConfigured.Flags()...)
-		}
-	}
+	flags_hidden = append(flags_hidden, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_hidden = append(flags_hidden, &v3.BoolFlag{
 		Name:  "interactive",
@@ -3971,10 +5887,18 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -3990,6 +5914,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *GreetRequest
 
@@ -4003,7 +5928,11 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.GreetRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("repeat") {
 					req.Repeat = cmd.Int32("repeat")
@@ -4034,39 +5963,87 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				} else {
 					// Use auto-generated flag parsing
 					req = &GreetRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.GreetRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					req.Repeat = cmd.Int32("repeat")
 					req.Loud = cmd.Bool("loud")
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.GreeterService/HiddenMethod", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.GreeterService/HiddenMethod")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &GreetResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *GreetResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*GreetResponse](cmd, "/tui_example.GreeterService/HiddenMethod", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewGreeterServiceClient(conn)
-				resp, err = client.HiddenMethod(cmdCtx, req)
+				resp, err = client.HiddenMethod(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(GreeterServiceServer)
-				resp, err = svcImpl.HiddenMethod(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "GreeterService.HiddenMethod", func() (*GreetResponse, error) {
+					return svcImpl.HiddenMethod(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.GreeterService/HiddenMethod", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getGreeterServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -4076,36 +6053,15 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -4126,6 +6082,10 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			return ctx, nil
 		},
 		Flags: flags_hidden,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.GreeterService/HiddenMethod",
+			protocli.RequestFlagsMetadataKey: []string{"name", "repeat", "loud"},
+		},
 		Name:  "hidden",
 		Usage: "A method hidden from the TUI",
 	})
@@ -4142,12 +6102,34 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_colored_greet = append(flags_colored_greet, &v3.StringFlag{
@@ -4156,17 +6138,12 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Usage:    "Name to greet",
 	})
 	flags_colored_greet = append(flags_colored_greet, &v3.StringFlag{
-		Name:  "color",
+		Name:  "text-color",
 		Usage: "Greeting color as R,G,B",
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_colored_greet = append(flags_colored_greet, flagConfigured.Flags()...)
-		}
-	}
+	flags_colored_greet = append(flags_colored_greet, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_colored_greet = append(flags_colored_greet, &v3.BoolFlag{
 		Name:  "interactive",
@@ -4174,10 +6151,18 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -4193,6 +6178,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ColoredGreetRequest
 
@@ -4206,11 +6192,15 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.ColoredGreetRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
-				if cmd.IsSet("color") {
+				if cmd.IsSet("text-color") {
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("tui_example.RgbColor"); hasFieldDeserializer {
-						fieldFlags := protocli.NewFlagContainer(cmd, "color")
+						fieldFlags := protocli.NewFlagContainer(cmd, "text-color")
 						fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
 						if fieldErr != nil {
 							return fmt.Errorf("failed to deserialize field Color: %w", fieldErr)
@@ -4223,7 +6213,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 							req.Color = typedField
 						}
 					} else {
-						return fmt.Errorf("flag --color requires a custom deserializer for tui_example.RgbColor (register with protocli.WithFlagDeserializer)")
+						return fmt.Errorf("flag --text-color requires a custom deserializer for tui_example.RgbColor (register with protocli.WithFlagDeserializer)")
 					}
 				}
 			} else {
@@ -4249,12 +6239,18 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				} else {
 					// Use auto-generated flag parsing
 					req = &ColoredGreetRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.ColoredGreetRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					// Field Color: check for custom deserializer for tui_example.RgbColor
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("tui_example.RgbColor"); hasFieldDeserializer {
 						// Use custom deserializer for nested message
-						// Create FlagContainer for field flag: color
-						fieldFlags := protocli.NewFlagContainer(cmd, "color")
+						// Create FlagContainer for field flag: text-color
+						fieldFlags := protocli.NewFlagContainer(cmd, "text-color")
 						fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
 						if fieldErr != nil {
 							return fmt.Errorf("failed to deserialize field Color: %w", fieldErr)
@@ -4269,41 +6265,83 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 						}
 					} else {
 						// No custom deserializer - check if user provided a value
-						if cmd.IsSet("color") {
-							return fmt.Errorf("flag --color requires a custom deserializer for tui_example.RgbColor (register with protocli.WithFlagDeserializer)")
+						if cmd.IsSet("text-color") {
+							return fmt.Errorf("flag --text-color requires a custom deserializer for tui_example.RgbColor (register with protocli.WithFlagDeserializer)")
 						}
 						// No value provided - leave field as nil
 					}
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.GreeterService/ColoredGreet", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.GreeterService/ColoredGreet")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ColoredGreetResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *ColoredGreetResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*ColoredGreetResponse](cmd, "/tui_example.GreeterService/ColoredGreet", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewGreeterServiceClient(conn)
-				resp, err = client.ColoredGreet(cmdCtx, req)
+				resp, err = client.ColoredGreet(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(GreeterServiceServer)
-				resp, err = svcImpl.ColoredGreet(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "GreeterService.ColoredGreet", func() (*ColoredGreetResponse, error) {
+					return svcImpl.ColoredGreet(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.GreeterService/ColoredGreet", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getGreeterServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -4313,44 +6351,23 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
 				if cmd.IsSet("name") {
 					prefill["name"] = cmd.String("name")
 				}
-				if cmd.IsSet("color") {
-					prefill["color"] = cmd.String("color")
+				if cmd.IsSet("text-color") {
+					prefill["text-color"] = cmd.String("text-color")
 				}
 				if err := protocli.InvokeTUI(ctx, cmd, protocli.StartAtMethod("greeter", "colored-greet"), protocli.WithPrefillFields(prefill)); err != nil {
 					return ctx, err
@@ -4359,7 +6376,12 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			}
 			return ctx, nil
 		},
-		Flags: flags_colored_greet,
+		Description: "ColoredGreet says hello with a custom color.\nDemonstrates registering a custom TUI form control for RgbColor.",
+		Flags:       flags_colored_greet,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.GreeterService/ColoredGreet",
+			protocli.RequestFlagsMetadataKey: []string{"name", "text-color"},
+		},
 		Name:  "colored-greet",
 		Usage: "Say hello in a chosen color",
 	})
@@ -4376,12 +6398,34 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_schedule_call = append(flags_schedule_call, &v3.StringFlag{
@@ -4396,12 +6440,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_schedule_call = append(flags_schedule_call, flagConfigured.Flags()...)
-		}
-	}
+	flags_schedule_call = append(flags_schedule_call, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_schedule_call = append(flags_schedule_call, &v3.BoolFlag{
 		Name:  "interactive",
@@ -4409,10 +6448,18 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -4428,6 +6475,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ScheduleCallRequest
 
@@ -4441,7 +6489,11 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("with") {
-					req.With = cmd.String("with")
+					val, err := protocli.TransformFlagValue("tui_example.ScheduleCallRequest", "with", cmd.String("with"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --with: %w", err)
+					}
+					req.With = val
 				}
 				if cmd.IsSet("when") {
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
@@ -4484,7 +6536,13 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				} else {
 					// Use auto-generated flag parsing
 					req = &ScheduleCallRequest{}
-					req.With = cmd.String("with")
+					if s := cmd.String("with"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.ScheduleCallRequest", "with", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --with: %w", err)
+						}
+						req.With = val
+					}
 					// Field When: check for custom deserializer for google.protobuf.Timestamp
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
 						// Use custom deserializer for nested message
@@ -4512,33 +6570,75 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.GreeterService/ScheduleCall", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.GreeterService/ScheduleCall")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ScheduleCallResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *ScheduleCallResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*ScheduleCallResponse](cmd, "/tui_example.GreeterService/ScheduleCall", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewGreeterServiceClient(conn)
-				resp, err = client.ScheduleCall(cmdCtx, req)
+				resp, err = client.ScheduleCall(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(GreeterServiceServer)
-				resp, err = svcImpl.ScheduleCall(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "GreeterService.ScheduleCall", func() (*ScheduleCallResponse, error) {
+					return svcImpl.ScheduleCall(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.GreeterService/ScheduleCall", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getGreeterServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -4548,36 +6648,15 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -4594,7 +6673,12 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			}
 			return ctx, nil
 		},
-		Flags: flags_schedule_call,
+		Description: "ScheduleCall books a call at a time in the caller's local timezone.\nDemonstrates WithCustomControlForField overriding WithTimestampControl:\nthe \"when\" Timestamp field uses a date-time picker with SystemTimezone\nso the user enters local time that is normalised to UTC on submit.",
+		Flags:       flags_schedule_call,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.GreeterService/ScheduleCall",
+			protocli.RequestFlagsMetadataKey: []string{"with", "when"},
+		},
 		Name:  "schedule-call",
 		Usage: "Book a call in your local timezone",
 	})
@@ -4602,8 +6686,8 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 	return &protocli.ServiceCLI{
 		Command: &v3.Command{
 			Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-				if cmd.Args().Len() > 0 {
-					return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+				if err := protocli.CheckArgs(cmd); err != nil {
+					return ctx, err
 				}
 				if cmd.Bool("interactive") {
 					if err := protocli.InvokeTUI(ctx, cmd, protocli.StartAtService("greeter")); err != nil {
@@ -4618,11 +6702,14 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 				Name:  "interactive",
 				Usage: "Open the interactive TUI at this service",
 			}},
-			Name:  "greeter",
-			Usage: "Greeting commands",
+			Metadata: map[string]any{protocli.ServiceMetadataKey: "greeter"},
+			Name:     "greeter",
+			Usage:    "Greeting commands",
 		},
 		ConfigMessageType: "",
 		FactoryOrImpl:     implOrFactory,
+		InputFormats:      options.InputFormats(),
+		OutputFormats:     options.OutputFormats(),
 		RegisterFunc: func(s *grpc.Server, impl interface{}) {
 			RegisterGreeterServiceServer(s, impl.(GreeterServiceServer))
 		},
@@ -4903,7 +6990,7 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 					Kind:            protocli.TUIFieldKindMessage,
 					Label:           "Color",
 					MessageFullName: "tui_example.RgbColor",
-					Name:            "color",
+					Name:            "text-color",
 					Required:        false,
 					Usage:           "Greeting color as R,G,B",
 				}},
@@ -4984,6 +7071,108 @@ func GreeterServiceCommand(ctx context.Context, implOrFactory interface{}, opts
 			}},
 			Name: "greeter",
 		},
+		ValidateCommands: []*v3.Command{
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &GreetRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "greet",
+				Usage: "Check that --input-file is a valid greet request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &ListGreetingsRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "list-greetings",
+				Usage: "Check that --input-file is a valid list-greetings request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &GreetRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "hidden",
+				Usage: "Check that --input-file is a valid hidden request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &ColoredGreetRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "colored-greet",
+				Usage: "Check that --input-file is a valid colored-greet request",
+			},
+			&v3.Command{
+				Action: func(_ context.Context, cmd *v3.Command) error {
+					inputFile := cmd.String("input-file")
+					req := &ScheduleCallRequest{}
+					if err := protocli.ReadInputFile(inputFile, cmd.String("input-format"), options.InputFormats(), req); err != nil {
+						return err
+					}
+					return protocli.ReportInputValid(cmd, inputFile)
+				},
+				Flags: []v3.Flag{&v3.StringFlag{
+					Name:     "input-file",
+					Required: true,
+					Usage:    "Request file to validate (JSON or YAML)",
+				}, &v3.StringFlag{
+					Name:  "input-format",
+					Usage: "Input file format (auto-detected from extension if not set)",
+				}},
+				Name:  "schedule-call",
+				Usage: "Check that --input-file is a valid schedule-call request",
+			},
+		},
 	}
 }
 
@@ -5014,12 +7203,34 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_greet = append(flags_greet, &v3.StringFlag{
@@ -5039,12 +7250,7 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_greet = append(flags_greet, flagConfigured.Flags()...)
-		}
-	}
+	flags_greet = append(flags_greet, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_greet = append(flags_greet, &v3.BoolFlag{
 		Name:  "interactive",
@@ -5052,10 +7258,18 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
 			}
 
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
+			}
+
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -5071,6 +7285,7 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *GreetRequest
 
@@ -5084,7 +7299,11 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.GreetRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("repeat") {
 					req.Repeat = cmd.Int32("repeat")
@@ -5115,39 +7334,87 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				} else {
 					// Use auto-generated flag parsing
 					req = &GreetRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.GreetRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					req.Repeat = cmd.Int32("repeat")
 					req.Loud = cmd.Bool("loud")
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.GreeterService/Greet", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.GreeterService/Greet")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &GreetResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *GreetResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*GreetResponse](cmd, "/tui_example.GreeterService/Greet", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewGreeterServiceClient(conn)
-				resp, err = client.Greet(cmdCtx, req)
+				resp, err = client.Greet(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(GreeterServiceServer)
-				resp, err = svcImpl.Greet(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "GreeterService.Greet", func() (*GreetResponse, error) {
+					return svcImpl.Greet(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.GreeterService/Greet", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getGreeterServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -5157,36 +7424,15 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -5207,6 +7453,10 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			return ctx, nil
 		},
 		Flags: flags_greet,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.GreeterService/Greet",
+			protocli.RequestFlagsMetadataKey: []string{"name", "repeat", "loud"},
+		},
 		Name:  "greet",
 		Usage: "Say hello to someone",
 	})
@@ -5223,12 +7473,34 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_list_greetings = append(flags_list_greetings, &v3.StringSliceFlag{
@@ -5237,12 +7509,7 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_list_greetings = append(flags_list_greetings, flagConfigured.Flags()...)
-		}
-	}
+	flags_list_greetings = append(flags_list_greetings, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_list_greetings = append(flags_list_greetings, &v3.BoolFlag{
 		Name:  "interactive",
@@ -5250,10 +7517,18 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -5269,6 +7544,7 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ListGreetingsRequest
 
@@ -5311,33 +7587,75 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.GreeterService/ListGreetings", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.GreeterService/ListGreetings")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ListGreetingsResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *ListGreetingsResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*ListGreetingsResponse](cmd, "/tui_example.GreeterService/ListGreetings", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewGreeterServiceClient(conn)
-				resp, err = client.ListGreetings(cmdCtx, req)
+				resp, err = client.ListGreetings(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(GreeterServiceServer)
-				resp, err = svcImpl.ListGreetings(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "GreeterService.ListGreetings", func() (*ListGreetingsResponse, error) {
+					return svcImpl.ListGreetings(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.GreeterService/ListGreetings", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getGreeterServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -5347,36 +7665,15 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -5388,6 +7685,10 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			return ctx, nil
 		},
 		Flags: flags_list_greetings,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.GreeterService/ListGreetings",
+			protocli.RequestFlagsMetadataKey: []string{"names"},
+		},
 		Name:  "list-greetings",
 		Usage: "Say hello to multiple people",
 	})
@@ -5404,12 +7705,34 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_hidden = append(flags_hidden, &v3.StringFlag{
@@ -5429,12 +7752,7 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_hidden = append(flags_hidden, flagConfigured.Flags()...)
-		}
-	}
+	flags_hidden = append(flags_hidden, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_hidden = append(flags_hidden, &v3.BoolFlag{
 		Name:  "interactive",
@@ -5442,10 +7760,18 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -5461,6 +7787,7 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *GreetRequest
 
@@ -5474,7 +7801,11 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.GreetRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
 				if cmd.IsSet("repeat") {
 					req.Repeat = cmd.Int32("repeat")
@@ -5505,39 +7836,87 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				} else {
 					// Use auto-generated flag parsing
 					req = &GreetRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.GreetRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					req.Repeat = cmd.Int32("repeat")
 					req.Loud = cmd.Bool("loud")
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.GreeterService/HiddenMethod", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.GreeterService/HiddenMethod")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &GreetResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *GreetResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*GreetResponse](cmd, "/tui_example.GreeterService/HiddenMethod", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewGreeterServiceClient(conn)
-				resp, err = client.HiddenMethod(cmdCtx, req)
+				resp, err = client.HiddenMethod(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(GreeterServiceServer)
-				resp, err = svcImpl.HiddenMethod(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "GreeterService.HiddenMethod", func() (*GreetResponse, error) {
+					return svcImpl.HiddenMethod(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.GreeterService/HiddenMethod", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getGreeterServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -5547,36 +7926,15 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -5597,6 +7955,10 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			return ctx, nil
 		},
 		Flags: flags_hidden,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.GreeterService/HiddenMethod",
+			protocli.RequestFlagsMetadataKey: []string{"name", "repeat", "loud"},
+		},
 		Name:  "hidden",
 		Usage: "A method hidden from the TUI",
 	})
@@ -5613,12 +7975,34 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_colored_greet = append(flags_colored_greet, &v3.StringFlag{
@@ -5627,17 +8011,12 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Usage:    "Name to greet",
 	})
 	flags_colored_greet = append(flags_colored_greet, &v3.StringFlag{
-		Name:  "color",
+		Name:  "text-color",
 		Usage: "Greeting color as R,G,B",
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_colored_greet = append(flags_colored_greet, flagConfigured.Flags()...)
-		}
-	}
+	flags_colored_greet = append(flags_colored_greet, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_colored_greet = append(flags_colored_greet, &v3.BoolFlag{
 		Name:  "interactive",
@@ -5645,10 +8024,18 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -5664,6 +8051,7 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ColoredGreetRequest
 
@@ -5677,11 +8065,15 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("name") {
-					req.Name = cmd.String("name")
+					val, err := protocli.TransformFlagValue("tui_example.ColoredGreetRequest", "name", cmd.String("name"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --name: %w", err)
+					}
+					req.Name = val
 				}
-				if cmd.IsSet("color") {
+				if cmd.IsSet("text-color") {
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("tui_example.RgbColor"); hasFieldDeserializer {
-						fieldFlags := protocli.NewFlagContainer(cmd, "color")
+						fieldFlags := protocli.NewFlagContainer(cmd, "text-color")
 						fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
 						if fieldErr != nil {
 							return fmt.Errorf("failed to deserialize field Color: %w", fieldErr)
@@ -5694,7 +8086,7 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 							req.Color = typedField
 						}
 					} else {
-						return fmt.Errorf("flag --color requires a custom deserializer for tui_example.RgbColor (register with protocli.WithFlagDeserializer)")
+						return fmt.Errorf("flag --text-color requires a custom deserializer for tui_example.RgbColor (register with protocli.WithFlagDeserializer)")
 					}
 				}
 			} else {
@@ -5720,12 +8112,18 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				} else {
 					// Use auto-generated flag parsing
 					req = &ColoredGreetRequest{}
-					req.Name = cmd.String("name")
+					if s := cmd.String("name"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.ColoredGreetRequest", "name", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --name: %w", err)
+						}
+						req.Name = val
+					}
 					// Field Color: check for custom deserializer for tui_example.RgbColor
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("tui_example.RgbColor"); hasFieldDeserializer {
 						// Use custom deserializer for nested message
-						// Create FlagContainer for field flag: color
-						fieldFlags := protocli.NewFlagContainer(cmd, "color")
+						// Create FlagContainer for field flag: text-color
+						fieldFlags := protocli.NewFlagContainer(cmd, "text-color")
 						fieldMsg, fieldErr := fieldDeserializer(cmdCtx, fieldFlags)
 						if fieldErr != nil {
 							return fmt.Errorf("failed to deserialize field Color: %w", fieldErr)
@@ -5740,41 +8138,83 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 						}
 					} else {
 						// No custom deserializer - check if user provided a value
-						if cmd.IsSet("color") {
-							return fmt.Errorf("flag --color requires a custom deserializer for tui_example.RgbColor (register with protocli.WithFlagDeserializer)")
+						if cmd.IsSet("text-color") {
+							return fmt.Errorf("flag --text-color requires a custom deserializer for tui_example.RgbColor (register with protocli.WithFlagDeserializer)")
 						}
 						// No value provided - leave field as nil
 					}
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.GreeterService/ColoredGreet", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.GreeterService/ColoredGreet")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ColoredGreetResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *ColoredGreetResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*ColoredGreetResponse](cmd, "/tui_example.GreeterService/ColoredGreet", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewGreeterServiceClient(conn)
-				resp, err = client.ColoredGreet(cmdCtx, req)
+				resp, err = client.ColoredGreet(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(GreeterServiceServer)
-				resp, err = svcImpl.ColoredGreet(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "GreeterService.ColoredGreet", func() (*ColoredGreetResponse, error) {
+					return svcImpl.ColoredGreet(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.GreeterService/ColoredGreet", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getGreeterServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -5784,44 +8224,23 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
 				if cmd.IsSet("name") {
 					prefill["name"] = cmd.String("name")
 				}
-				if cmd.IsSet("color") {
-					prefill["color"] = cmd.String("color")
+				if cmd.IsSet("text-color") {
+					prefill["text-color"] = cmd.String("text-color")
 				}
 				if err := protocli.InvokeTUI(ctx, cmd, protocli.StartAtMethod("greeter", "colored-greet"), protocli.WithPrefillFields(prefill)); err != nil {
 					return ctx, err
@@ -5830,7 +8249,12 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			}
 			return ctx, nil
 		},
-		Flags: flags_colored_greet,
+		Description: "ColoredGreet says hello with a custom color.\nDemonstrates registering a custom TUI form control for RgbColor.",
+		Flags:       flags_colored_greet,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.GreeterService/ColoredGreet",
+			protocli.RequestFlagsMetadataKey: []string{"name", "text-color"},
+		},
 		Name:  "colored-greet",
 		Usage: "Say hello in a chosen color",
 	})
@@ -5847,12 +8271,34 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 		Name:  "output",
 		Usage: "Output file (- for stdout)",
 		Value: "-",
+	}, &v3.StringFlag{
+		Name:  "output-mode",
+		Usage: "Octal permissions for a file created by --output",
+		Value: protocli.DefaultOutputFileMode,
+	}, &v3.BoolFlag{
+		Name:  "output-append",
+		Usage: "Append to the --output file instead of truncating it",
+	}, &v3.StringSliceFlag{
+		Name:  "fields-exclude",
+		Usage: "Drop these field paths (e.g. user.address.city) from the response before formatting",
 	}, &v3.StringFlag{
 		Name:  "input-file",
 		Usage: "Read request from file (JSON or YAML). CLI flags override file values",
 	}, &v3.StringFlag{
 		Name:  "input-format",
 		Usage: "Input file format (auto-detected from extension if not set)",
+	}, &v3.BoolFlag{
+		Name:  "show-input",
+		Usage: "Print the request to stderr in the selected format before sending it",
+	}, &v3.StringFlag{
+		Name:  "get",
+		Usage: "Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)",
+	}, &v3.BoolFlag{
+		Name:  "echo-request",
+		Usage: "Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)",
+	}, &v3.StringFlag{
+		Name:  "compression",
+		Usage: "Compress remote calls with this gRPC compressor (gzip, or none)",
 	}}
 
 	flags_schedule_call = append(flags_schedule_call, &v3.StringFlag{
@@ -5867,12 +8313,7 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 
 	// Add format-specific flags from registered formats
-	for _, outputFmt := range options.OutputFormats() {
-		// Check if format implements FlagConfiguredOutputFormat
-		if flagConfigured, ok := outputFmt.(protocli.FlagConfiguredOutputFormat); ok {
-			flags_schedule_call = append(flags_schedule_call, flagConfigured.Flags()...)
-		}
-	}
+	flags_schedule_call = append(flags_schedule_call, protocli.FormatFlags(options.OutputFormats())...)
 
 	flags_schedule_call = append(flags_schedule_call, &v3.BoolFlag{
 		Name:  "interactive",
@@ -5880,10 +8321,18 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 	})
 	commands = append(commands, &v3.Command{
 		Action: func(cmdCtx context.Context, cmd *v3.Command) error {
-			if cmd.Args().Len() > 0 {
-				return v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return err
+			}
+
+			// Apply flag defaults from the commands section of the config files
+			if err := protocli.ApplyCommandConfig(cmd); err != nil {
+				return err
 			}
 
+			timings := protocli.NewCommandTimings(cmd)
+			defer timings.Report()
+
 			defer func() {
 				hooks := options.AfterCommandHooks()
 				for i := len(hooks) - 1; i >= 0; i-- {
@@ -5899,6 +8348,7 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			timings.Phase("request build")
 			// Build request message
 			var req *ScheduleCallRequest
 
@@ -5912,7 +8362,11 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 				// Apply flag overrides (only explicitly-set flags)
 				if cmd.IsSet("with") {
-					req.With = cmd.String("with")
+					val, err := protocli.TransformFlagValue("tui_example.ScheduleCallRequest", "with", cmd.String("with"))
+					if err != nil {
+						return fmt.Errorf("invalid value for --with: %w", err)
+					}
+					req.With = val
 				}
 				if cmd.IsSet("when") {
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
@@ -5955,7 +8409,13 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				} else {
 					// Use auto-generated flag parsing
 					req = &ScheduleCallRequest{}
-					req.With = cmd.String("with")
+					if s := cmd.String("with"); s != "" {
+						val, err := protocli.TransformFlagValue("tui_example.ScheduleCallRequest", "with", s)
+						if err != nil {
+							return fmt.Errorf("invalid value for --with: %w", err)
+						}
+						req.With = val
+					}
 					// Field When: check for custom deserializer for google.protobuf.Timestamp
 					if fieldDeserializer, hasFieldDeserializer := options.FlagDeserializer("google.protobuf.Timestamp"); hasFieldDeserializer {
 						// Use custom deserializer for nested message
@@ -5983,33 +8443,75 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				}
 			}
 
+			protocli.RecordAuditRequest(cmd, "/tui_example.GreeterService/ScheduleCall", req)
+			if err := protocli.CheckRequestSize(cmd, req); err != nil {
+				return err
+			}
+			// Check cross-flag rules
+			if err := protocli.CheckFlagRules(cmd, options.FlagRules("/tui_example.GreeterService/ScheduleCall")); err != nil {
+				return err
+			}
+
+			if err := protocli.CheckExcludedFields(cmd, &ScheduleCallResponse{}); err != nil {
+				return err
+			}
+			if err := protocli.ShowInput(cmdCtx, cmd, options.OutputFormats(), req); err != nil {
+				return err
+			}
+
 			// Check if using remote gRPC call or direct implementation call
 			remoteAddr := cmd.String("remote")
 			var resp *ScheduleCallResponse
 			var err error
 
-			if remoteAddr != "" {
+			if protocli.Replaying(cmd) {
+				timings.Phase("service call")
+				// Replay the recorded response instead of calling the service
+				resp, err = protocli.ReplayResponse[*ScheduleCallResponse](cmd, "/tui_example.GreeterService/ScheduleCall", req)
+				if err != nil {
+					return err
+				}
+			} else if remoteAddr != "" {
 				// Remote gRPC call
-				conn, connErr := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+				timings.Phase("service call")
+				callOpts, optsErr := protocli.RemoteCallOptions(cmd)
+				if optsErr != nil {
+					return optsErr
+				}
+				conn, connErr := grpc.NewClient(remoteAddr, protocli.RemoteDialOptions(cmd)...)
 				if connErr != nil {
 					return fmt.Errorf("failed to connect to remote %s: %w", remoteAddr, connErr)
 				}
 				defer conn.Close()
 
 				client := NewGreeterServiceClient(conn)
-				resp, err = client.ScheduleCall(cmdCtx, req)
+				resp, err = client.ScheduleCall(cmdCtx, req, callOpts...)
 				if err != nil {
 					return fmt.Errorf("remote call failed: %w", err)
 				}
 			} else {
 				// Direct implementation call (no config)
+				timings.Phase("service call")
 				svcImpl := implOrFactory.(GreeterServiceServer)
-				resp, err = svcImpl.ScheduleCall(cmdCtx, req)
+				resp, err = protocli.CallLocal(cmd, "GreeterService.ScheduleCall", func() (*ScheduleCallResponse, error) {
+					return svcImpl.ScheduleCall(cmdCtx, req)
+				})
 				if err != nil {
 					return fmt.Errorf("method failed: %w", err)
 				}
 			}
 
+			protocli.CacheResponse(cmd, resp)
+			// Record the response for --replay (no-op unless WithRecord is set)
+			protocli.RecordResponse(cmd, "/tui_example.GreeterService/ScheduleCall", req, resp)
+
+			timings.Phase("format")
+			// Drop --fields-exclude paths from the response
+			resp, err = protocli.ExcludeResponseFields(cmd, resp)
+			if err != nil {
+				return err
+			}
+
 			// Open output writer
 			outputWriter, err := getGreeterServiceOutputWriter(cmd, cmd.String("output"))
 			if err != nil {
@@ -6019,36 +8521,15 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 				defer closer.Close()
 			}
 
-			// Find and use the appropriate output format
-			formatName := cmd.String("format")
-
-			// Try registered formats
-			for _, outputFmt := range options.OutputFormats() {
-				if outputFmt.Name() == formatName {
-					if err := outputFmt.Format(cmdCtx, cmd, outputWriter, resp); err != nil {
-						return fmt.Errorf("format failed: %w", err)
-					}
-					// Write final newline to keep terminal clean
-					if _, err := outputWriter.Write([]byte("\n")); err != nil {
-						return fmt.Errorf("failed to write final newline: %w", err)
-					}
-					return nil
-				}
-			}
+			// Warn about flags of other formats that the selected one ignores
+			protocli.WarnIgnoredFormatFlags(cmd, options.OutputFormats(), cmd.String("format"))
 
-			// Format not found - build list of available formats
-			var availableFormats []string
-			for _, f := range options.OutputFormats() {
-				availableFormats = append(availableFormats, f.Name())
-			}
-			if len(availableFormats) == 0 {
-				return fmt.Errorf("no output formats registered (use WithOutputFormats to register formats)")
-			}
-			return fmt.Errorf("unknown format %q (available: %v)", formatName, availableFormats)
+			// Render the response (with the request for --echo-request) in the selected output format, or just its --get value
+			return protocli.WriteRequestResponse(cmdCtx, cmd, outputWriter, options.OutputFormats(), cmd.String("format"), req, resp)
 		},
 		Before: func(ctx context.Context, cmd *v3.Command) (context.Context, error) {
-			if cmd.Args().Len() > 0 {
-				return ctx, v3.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := protocli.CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if cmd.Bool("interactive") {
 				prefill := map[string]string{}
@@ -6065,7 +8546,12 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 			}
 			return ctx, nil
 		},
-		Flags: flags_schedule_call,
+		Description: "ScheduleCall books a call at a time in the caller's local timezone.\nDemonstrates WithCustomControlForField overriding WithTimestampControl:\nthe \"when\" Timestamp field uses a date-time picker with SystemTimezone\nso the user enters local time that is normalised to UTC on submit.",
+		Flags:       flags_schedule_call,
+		Metadata: map[string]any{
+			protocli.MethodMetadataKey:       "/tui_example.GreeterService/ScheduleCall",
+			protocli.RequestFlagsMetadataKey: []string{"with", "when"},
+		},
 		Name:  "schedule-call",
 		Usage: "Book a call in your local timezone",
 	})
@@ -6088,3 +8574,23 @@ func GreeterServiceCommandsFlat(ctx context.Context, implOrFactory interface{},
 
 	return commands
 }
+
+// NewGreeterServiceCLIClient dials the remote given by WithRemote or resolved from a
+// command's --remote and --context flags (WithRemoteCommand), applying TLS,
+// retry and auth options, and returns the GreeterServiceClient. The caller must close the connection.
+func NewGreeterServiceCLIClient(opts ...protocli.ClientOption) (GreeterServiceClient, *grpc.ClientConn, error) {
+	conn, err := protocli.DialRemote(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewGreeterServiceClient(conn), conn, nil
+}
+
+// BuildAllServicesCLI creates a root command with every service in this file registered.
+// Each impl parameter can be either a direct service implementation or a factory function.
+// Additional root options are applied after the service registrations.
+func BuildAllServicesCLI(ctx context.Context, appName string, farewellServiceImpl interface{}, directoryServiceImpl interface{}, greeterServiceImpl interface{}, opts ...protocli.RootOption) (*v3.Command, error) {
+	rootOpts := []protocli.RootOption{protocli.Service(FarewellServiceCommand(ctx, farewellServiceImpl)), protocli.Service(DirectoryServiceCommand(ctx, directoryServiceImpl)), protocli.Service(GreeterServiceCommand(ctx, greeterServiceImpl))}
+	rootOpts = append(rootOpts, opts...)
+	return protocli.RootCommand(appName, rootOpts...)
+}
@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-cli. DO NOT EDIT.
+
+package tui
+
+import (
+	"context"
+	protocli "github.com/drewfead/proto-cli"
+	grpc "google.golang.org/grpc"
+)
+
+// MockFarewellServiceServer is an in-memory FarewellServiceServer for tests. Set a method's Func field
+// to return a canned response; methods without one return codes.Unimplemented.
+// Every call is recorded and can be inspected with Calls or CallsTo.
+type MockFarewellServiceServer struct {
+	UnimplementedFarewellServiceServer
+	protocli.CallRecorder
+
+	FarewellFunc          func(ctx context.Context, req *FarewellRequest) (*FarewellResponse, error)
+	FarewellManyFunc      func(ctx context.Context, req *FarewellManyRequest) (*FarewellManyResponse, error)
+	ScheduledFarewellFunc func(ctx context.Context, req *ScheduledFarewellRequest) (*ScheduledFarewellResponse, error)
+	LeaveNoteFunc         func(ctx context.Context, req *NoteRequest) (*NoteResponse, error)
+	CountdownFarewellFunc func(req *CountdownFarewellRequest, stream grpc.ServerStreamingServer[CountdownFarewellResponse]) error
+}
+
+var _ FarewellServiceServer = (*MockFarewellServiceServer)(nil)
+
+// Farewell records the call and invokes FarewellFunc when set.
+func (m *MockFarewellServiceServer) Farewell(ctx context.Context, req *FarewellRequest) (*FarewellResponse, error) {
+	m.Record("Farewell", req)
+	if m.FarewellFunc != nil {
+		return m.FarewellFunc(ctx, req)
+	}
+	return m.UnimplementedFarewellServiceServer.Farewell(ctx, req)
+}
+
+// FarewellMany records the call and invokes FarewellManyFunc when set.
+func (m *MockFarewellServiceServer) FarewellMany(ctx context.Context, req *FarewellManyRequest) (*FarewellManyResponse, error) {
+	m.Record("FarewellMany", req)
+	if m.FarewellManyFunc != nil {
+		return m.FarewellManyFunc(ctx, req)
+	}
+	return m.UnimplementedFarewellServiceServer.FarewellMany(ctx, req)
+}
+
+// ScheduledFarewell records the call and invokes ScheduledFarewellFunc when set.
+func (m *MockFarewellServiceServer) ScheduledFarewell(ctx context.Context, req *ScheduledFarewellRequest) (*ScheduledFarewellResponse, error) {
+	m.Record("ScheduledFarewell", req)
+	if m.ScheduledFarewellFunc != nil {
+		return m.ScheduledFarewellFunc(ctx, req)
+	}
+	return m.UnimplementedFarewellServiceServer.ScheduledFarewell(ctx, req)
+}
+
+// LeaveNote records the call and invokes LeaveNoteFunc when set.
+func (m *MockFarewellServiceServer) LeaveNote(ctx context.Context, req *NoteRequest) (*NoteResponse, error) {
+	m.Record("LeaveNote", req)
+	if m.LeaveNoteFunc != nil {
+		return m.LeaveNoteFunc(ctx, req)
+	}
+	return m.UnimplementedFarewellServiceServer.LeaveNote(ctx, req)
+}
+
+// CountdownFarewell records the call and invokes CountdownFarewellFunc when set.
+func (m *MockFarewellServiceServer) CountdownFarewell(req *CountdownFarewellRequest, stream grpc.ServerStreamingServer[CountdownFarewellResponse]) error {
+	m.Record("CountdownFarewell", req)
+	if m.CountdownFarewellFunc != nil {
+		return m.CountdownFarewellFunc(req, stream)
+	}
+	return m.UnimplementedFarewellServiceServer.CountdownFarewell(req, stream)
+}
+
+// MockDirectoryServiceServer is an in-memory DirectoryServiceServer for tests. Set a method's Func field
+// to return a canned response; methods without one return codes.Unimplemented.
+// Every call is recorded and can be inspected with Calls or CallsTo.
+type MockDirectoryServiceServer struct {
+	UnimplementedDirectoryServiceServer
+	protocli.CallRecorder
+
+	ListPeopleFunc func(req *ListPeopleRequest, stream grpc.ServerStreamingServer[PersonCard]) error
+}
+
+var _ DirectoryServiceServer = (*MockDirectoryServiceServer)(nil)
+
+// ListPeople records the call and invokes ListPeopleFunc when set.
+func (m *MockDirectoryServiceServer) ListPeople(req *ListPeopleRequest, stream grpc.ServerStreamingServer[PersonCard]) error {
+	m.Record("ListPeople", req)
+	if m.ListPeopleFunc != nil {
+		return m.ListPeopleFunc(req, stream)
+	}
+	return m.UnimplementedDirectoryServiceServer.ListPeople(req, stream)
+}
+
+// MockGreeterServiceServer is an in-memory GreeterServiceServer for tests. Set a method's Func field
+// to return a canned response; methods without one return codes.Unimplemented.
+// Every call is recorded and can be inspected with Calls or CallsTo.
+type MockGreeterServiceServer struct {
+	UnimplementedGreeterServiceServer
+	protocli.CallRecorder
+
+	GreetFunc         func(ctx context.Context, req *GreetRequest) (*GreetResponse, error)
+	ListGreetingsFunc func(ctx context.Context, req *ListGreetingsRequest) (*ListGreetingsResponse, error)
+	HiddenMethodFunc  func(ctx context.Context, req *GreetRequest) (*GreetResponse, error)
+	ColoredGreetFunc  func(ctx context.Context, req *ColoredGreetRequest) (*ColoredGreetResponse, error)
+	ScheduleCallFunc  func(ctx context.Context, req *ScheduleCallRequest) (*ScheduleCallResponse, error)
+}
+
+var _ GreeterServiceServer = (*MockGreeterServiceServer)(nil)
+
+// Greet records the call and invokes GreetFunc when set.
+func (m *MockGreeterServiceServer) Greet(ctx context.Context, req *GreetRequest) (*GreetResponse, error) {
+	m.Record("Greet", req)
+	if m.GreetFunc != nil {
+		return m.GreetFunc(ctx, req)
+	}
+	return m.UnimplementedGreeterServiceServer.Greet(ctx, req)
+}
+
+// ListGreetings records the call and invokes ListGreetingsFunc when set.
+func (m *MockGreeterServiceServer) ListGreetings(ctx context.Context, req *ListGreetingsRequest) (*ListGreetingsResponse, error) {
+	m.Record("ListGreetings", req)
+	if m.ListGreetingsFunc != nil {
+		return m.ListGreetingsFunc(ctx, req)
+	}
+	return m.UnimplementedGreeterServiceServer.ListGreetings(ctx, req)
+}
+
+// HiddenMethod records the call and invokes HiddenMethodFunc when set.
+func (m *MockGreeterServiceServer) HiddenMethod(ctx context.Context, req *GreetRequest) (*GreetResponse, error) {
+	m.Record("HiddenMethod", req)
+	if m.HiddenMethodFunc != nil {
+		return m.HiddenMethodFunc(ctx, req)
+	}
+	return m.UnimplementedGreeterServiceServer.HiddenMethod(ctx, req)
+}
+
+// ColoredGreet records the call and invokes ColoredGreetFunc when set.
+func (m *MockGreeterServiceServer) ColoredGreet(ctx context.Context, req *ColoredGreetRequest) (*ColoredGreetResponse, error) {
+	m.Record("ColoredGreet", req)
+	if m.ColoredGreetFunc != nil {
+		return m.ColoredGreetFunc(ctx, req)
+	}
+	return m.UnimplementedGreeterServiceServer.ColoredGreet(ctx, req)
+}
+
+// ScheduleCall records the call and invokes ScheduleCallFunc when set.
+func (m *MockGreeterServiceServer) ScheduleCall(ctx context.Context, req *ScheduleCallRequest) (*ScheduleCallResponse, error) {
+	m.Record("ScheduleCall", req)
+	if m.ScheduleCallFunc != nil {
+		return m.ScheduleCallFunc(ctx, req)
+	}
+	return m.UnimplementedGreeterServiceServer.ScheduleCall(ctx, req)
+}
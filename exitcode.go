@@ -0,0 +1,38 @@
+package protocli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// ResponseExitCode returns an error that exits the process with the value of
+// the integer field at path (dot-separated, e.g. "status.code") of resp, as
+// named by a method's exit_code_field annotation. urfave/cli reports the code
+// and field on stderr. It returns nil when the value is 0 or the field is unset,
+// missing or not an integer.
+func ResponseExitCode(resp proto.Message, path string) error {
+	if resp == nil {
+		return nil
+	}
+	m := resp.ProtoReflect()
+	names := splitFieldPath(path)
+	for i, name := range names {
+		fd := m.Descriptor().Fields().ByName(name)
+		if fd == nil || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+			return nil
+		}
+		if i < len(names)-1 {
+			if fd.Message() == nil {
+				return nil
+			}
+			m = m.Get(fd).Message()
+			continue
+		}
+		if code, ok := integerValue(fd, m.Get(fd)); ok && code != 0 {
+			return cli.Exit(fmt.Sprintf("exit code %d from response field %s", code, path), int(code))
+		}
+	}
+	return nil
+}
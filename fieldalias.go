@@ -0,0 +1,158 @@
+package protocli
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// fieldAliasKey identifies an input file key by its message's full name and
+// the alias used for it.
+type fieldAliasKey struct {
+	message string
+	alias   string
+}
+
+var (
+	fieldAliasesMu sync.RWMutex
+	fieldAliases   = map[fieldAliasKey]string{}
+)
+
+// RegisterFieldAlias makes ReadInputFile accept alias as a key for the field
+// realName (its proto or JSON name) of messageName (e.g.
+// "example.CreateUserRequest"), so that input files produced by other systems
+// can be read as they are: after
+//
+//	protocli.RegisterFieldAlias("example.CreateUserRequest", "customerName", "name")
+//
+// {"customerName": "Ada"} sets name. Aliases apply wherever the message
+// appears in the input, including nested fields and ReadInputFileList
+// elements. When both the alias and the real key are given, the real key
+// wins. Registering an existing alias replaces it, and an empty realName
+// removes it. Only the built-in JSON and YAML input formats are rewritten.
+func RegisterFieldAlias(messageName, alias, realName string) {
+	fieldAliasesMu.Lock()
+	defer fieldAliasesMu.Unlock()
+	key := fieldAliasKey{message: messageName, alias: alias}
+	if realName == "" {
+		delete(fieldAliases, key)
+		return
+	}
+	fieldAliases[key] = realName
+}
+
+// hasFieldAliases reports whether any alias is registered, letting input
+// files skip the rewriting pass in the common case.
+func hasFieldAliases() bool {
+	fieldAliasesMu.RLock()
+	defer fieldAliasesMu.RUnlock()
+	return len(fieldAliases) > 0
+}
+
+func lookupFieldAlias(md protoreflect.MessageDescriptor, key string) (string, bool) {
+	fieldAliasesMu.RLock()
+	defer fieldAliasesMu.RUnlock()
+	realName, ok := fieldAliases[fieldAliasKey{message: string(md.FullName()), alias: key}]
+	return realName, ok
+}
+
+// unmarshalInput unmarshals data into msg with f, first renaming aliased keys
+// when f is a built-in JSON or YAML format.
+func unmarshalInput(f InputFormat, data []byte, msg proto.Message) error {
+	if hasFieldAliases() && isBuiltinInputFormat(f) {
+		var err error
+		if data, err = rewriteInputAliases(data, msg.ProtoReflect().Descriptor()); err != nil {
+			return err
+		}
+	}
+	return f.Unmarshal(data, msg)
+}
+
+func isBuiltinInputFormat(f InputFormat) bool {
+	switch f.(type) {
+	case *protoJSONInputFormat, *yamlInputFormat:
+		return true
+	default:
+		return false
+	}
+}
+
+// rewriteInputAliases returns data with the aliased keys of md and its nested
+// messages renamed, encoded as JSON (which both built-in formats read). data
+// is returned unchanged when it holds no aliased key.
+func rewriteInputAliases(data []byte, md protoreflect.MessageDescriptor) ([]byte, error) {
+	// YAML is a superset of JSON, so this parses both
+	var parsed any
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return data, nil //nolint:nilerr // the format reports the syntax error
+	}
+	if !renameFieldAliases(parsed, md) {
+		return data, nil
+	}
+	rewritten, err := json.Marshal(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode input with field aliases: %w", err)
+	}
+	return rewritten, nil
+}
+
+// renameFieldAliases renames the aliased keys of value, a parsed md message,
+// and of the messages nested in it, reporting whether any key was renamed.
+func renameFieldAliases(value any, md protoreflect.MessageDescriptor) bool {
+	obj, ok := value.(map[string]any)
+	if !ok || strings.HasPrefix(string(md.FullName()), "google.protobuf.") {
+		return false
+	}
+
+	renamed := false
+	for _, key := range slices.Collect(maps.Keys(obj)) {
+		realName, ok := lookupFieldAlias(md, key)
+		if !ok {
+			continue
+		}
+		v := obj[key]
+		delete(obj, key)
+		if _, exists := obj[realName]; !exists {
+			obj[realName] = v
+		}
+		renamed = true
+	}
+
+	for key, v := range obj {
+		fd := md.Fields().ByJSONName(key)
+		if fd == nil {
+			fd = md.Fields().ByName(protoreflect.Name(key))
+		}
+		if fd == nil {
+			continue
+		}
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+			if entries, ok := v.(map[string]any); ok {
+				for _, entry := range entries {
+					renamed = renameFieldAliases(entry, fd.MapValue().Message()) || renamed
+				}
+			}
+		case fd.Message() == nil:
+		case fd.IsList():
+			if elems, ok := v.([]any); ok {
+				for _, elem := range elems {
+					renamed = renameFieldAliases(elem, fd.Message()) || renamed
+				}
+			}
+		default:
+			renamed = renameFieldAliases(v, fd.Message()) || renamed
+		}
+	}
+	return renamed
+}
@@ -0,0 +1,93 @@
+package protocli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerFieldAlias registers an alias for the duration of the test.
+func registerFieldAlias(t *testing.T, messageName, alias, realName string) {
+	t.Helper()
+	protocli.RegisterFieldAlias(messageName, alias, realName)
+	t.Cleanup(func() { protocli.RegisterFieldAlias(messageName, alias, "") })
+}
+
+func writeInputFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestUnit_FieldAlias_ReadInputFile(t *testing.T) {
+	registerFieldAlias(t, "example.CreateUserRequest", "customerName", "name")
+	registerFieldAlias(t, "example.CreateUserRequest", "mail", "email")
+	registerFieldAlias(t, "example.Address", "town", "city")
+	registerFieldAlias(t, "example.Address", "postcode", "zip_code")
+
+	for _, tt := range []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name:    "json",
+			file:    "user.json",
+			content: `{"customerName": "Ada", "mail": "ada@example.com", "address": {"town": "London", "postcode": "NW1"}, "previousAddresses": [{"town": "Paris"}]}`,
+		},
+		{
+			name: "yaml",
+			file: "user.yaml",
+			content: "customerName: Ada\nmail: ada@example.com\naddress:\n  town: London\n  postcode: NW1\n" +
+				"previous_addresses:\n  - town: Paris\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &simple.CreateUserRequest{}
+			err := protocli.ReadInputFile(writeInputFile(t, tt.file, tt.content), "", protocli.DefaultInputFormats(), req)
+			require.NoError(t, err)
+			assert.Equal(t, "Ada", req.GetName())
+			assert.Equal(t, "ada@example.com", req.GetEmail())
+			assert.Equal(t, "London", req.GetAddress().GetCity(), "aliases apply to nested messages")
+			assert.Equal(t, "NW1", req.GetAddress().GetZipCode())
+			require.Len(t, req.GetPreviousAddresses(), 1)
+			assert.Equal(t, "Paris", req.GetPreviousAddresses()[0].GetCity(), "aliases apply to repeated messages")
+		})
+	}
+}
+
+func TestUnit_FieldAlias_RealKeyWins(t *testing.T) {
+	registerFieldAlias(t, "example.CreateUserRequest", "customerName", "name")
+
+	req := &simple.CreateUserRequest{}
+	path := writeInputFile(t, "user.json", `{"customerName": "alias", "name": "real"}`)
+	require.NoError(t, protocli.ReadInputFile(path, "", protocli.DefaultInputFormats(), req))
+	assert.Equal(t, "real", req.GetName())
+}
+
+func TestUnit_FieldAlias_Unregistered(t *testing.T) {
+	registerFieldAlias(t, "example.CreateUserRequest", "customerName", "name")
+	protocli.RegisterFieldAlias("example.CreateUserRequest", "customerName", "")
+
+	path := writeInputFile(t, "user.json", `{"customerName": "Ada"}`)
+	err := protocli.ReadInputFile(path, "", protocli.DefaultInputFormats(), &simple.CreateUserRequest{})
+	require.ErrorContains(t, err, "customerName")
+}
+
+func TestUnit_FieldAlias_ReadInputFileList(t *testing.T) {
+	registerFieldAlias(t, "example.Address", "town", "city")
+
+	path := writeInputFile(t, "addresses.yaml", "- town: London\n- town: Paris\n  country: FR\n")
+	addrs, err := protocli.ReadInputFileList(path, protocli.DefaultInputFormats(), func() *simple.Address { return &simple.Address{} })
+	require.NoError(t, err)
+	require.Len(t, addrs, 2)
+	assert.Equal(t, "London", addrs[0].GetCity())
+	assert.Equal(t, "Paris", addrs[1].GetCity())
+	assert.Equal(t, "FR", addrs[1].GetCountry())
+}
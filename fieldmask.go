@@ -0,0 +1,39 @@
+package protocli
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ParseFieldMask parses a comma-separated list of field paths, as given to a
+// google.protobuf.FieldMask flag (e.g. "--update-mask name,email"), into a
+// FieldMask. Surrounding whitespace and empty elements are ignored.
+//
+// When target is the full name of a message (e.g. "example.User"), every path
+// must name a field of that message. Generated commands pass the field's
+// (cli.flag).field_mask_target annotation, or "" when it is not set.
+func ParseFieldMask(value, target string) (*fieldmaskpb.FieldMask, error) {
+	var paths []string
+	for _, path := range strings.Split(value, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	if target == "" {
+		return &fieldmaskpb.FieldMask{Paths: paths}, nil
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(target))
+	if err != nil {
+		return nil, fmt.Errorf("unknown field mask target %s: %w", target, err)
+	}
+	mask, err := fieldmaskpb.New(mt.Zero().Interface(), paths...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field mask for %s: %w", target, err)
+	}
+	return mask, nil
+}
@@ -0,0 +1,143 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	// ErrUnknownFieldPath is returned when a --fields-exclude path does not name
+	// a field of the response message.
+	ErrUnknownFieldPath = errors.New("unknown field path")
+	// ErrFieldsExcludeConflict is returned when --fields and --fields-exclude are
+	// both given.
+	ErrFieldsExcludeConflict = errors.New("--fields and --fields-exclude are mutually exclusive")
+)
+
+// FieldFlags reads the flags that control response field projection. Both
+// *cli.Command and cobracli.FlagSet satisfy it.
+type FieldFlags interface {
+	IsSet(name string) bool
+	StringSlice(name string) []string
+}
+
+// ExcludeResponseFields applies the --fields-exclude flag to a response before
+// it is rendered. Generated commands call this for every response message. The
+// message is returned unchanged when the flag is not set.
+func ExcludeResponseFields[T proto.Message](flags FieldFlags, msg T) (T, error) {
+	paths := flags.StringSlice("fields-exclude")
+	if len(paths) == 0 {
+		return msg, nil
+	}
+	if flags.IsSet("fields") {
+		return msg, ErrFieldsExcludeConflict
+	}
+	return ExcludeFields(msg, paths)
+}
+
+// CheckExcludedFields checks the --fields-exclude flag against the response
+// message resp before the service is called, so that a path the response
+// doesn't have, or --fields given as well, fails without running the method.
+func CheckExcludedFields(flags FieldFlags, resp proto.Message) error {
+	paths := flags.StringSlice("fields-exclude")
+	if len(paths) == 0 {
+		return nil
+	}
+	if flags.IsSet("fields") {
+		return ErrFieldsExcludeConflict
+	}
+	desc := resp.ProtoReflect().Descriptor()
+	for _, path := range paths {
+		if _, err := resolveFieldPath(desc, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExcludeFields returns a copy of msg with the given field paths cleared. Paths
+// are dot-separated proto or JSON field names (e.g. "user.address.city");
+// intermediate repeated and map fields apply the rest of the path to every
+// element. The original message is not modified.
+func ExcludeFields[T proto.Message](msg T, paths []string) (T, error) {
+	desc := msg.ProtoReflect().Descriptor()
+	resolved := make([][]protoreflect.FieldDescriptor, 0, len(paths))
+	for _, path := range paths {
+		fields, err := resolveFieldPath(desc, path)
+		if err != nil {
+			return msg, err
+		}
+		resolved = append(resolved, fields)
+	}
+
+	clone, ok := proto.Clone(msg).(T)
+	if !ok {
+		return msg, fmt.Errorf("unexpected clone type %T", clone)
+	}
+	for _, fields := range resolved {
+		clearFieldPath(clone.ProtoReflect(), fields)
+	}
+	return clone, nil
+}
+
+// resolveFieldPath maps a dot-separated path to the field descriptors it
+// traverses, rejecting names that do not exist or that descend into scalars.
+func resolveFieldPath(desc protoreflect.MessageDescriptor, path string) ([]protoreflect.FieldDescriptor, error) {
+	segments := strings.Split(strings.TrimSpace(path), ".")
+	fields := make([]protoreflect.FieldDescriptor, 0, len(segments))
+	for i, segment := range segments {
+		if desc == nil {
+			return nil, fmt.Errorf("%w %q: %q is not a message field", ErrUnknownFieldPath, path, segments[i-1])
+		}
+		fd := desc.Fields().ByName(protoreflect.Name(segment))
+		if fd == nil {
+			fd = desc.Fields().ByJSONName(segment)
+		}
+		if fd == nil {
+			return nil, fmt.Errorf("%w %q: %s has no field %q", ErrUnknownFieldPath, path, desc.FullName(), segment)
+		}
+		fields = append(fields, fd)
+
+		desc = nil
+		switch {
+		case fd.IsMap():
+			desc = fd.MapValue().Message()
+		case fd.Message() != nil:
+			desc = fd.Message()
+		}
+	}
+	return fields, nil
+}
+
+// clearFieldPath clears the last field in fields on every message reached by
+// following the preceding ones from msg.
+func clearFieldPath(msg protoreflect.Message, fields []protoreflect.FieldDescriptor) {
+	fd := fields[0]
+	if len(fields) == 1 {
+		msg.Clear(fd)
+		return
+	}
+	if !msg.Has(fd) {
+		return
+	}
+
+	rest := fields[1:]
+	switch {
+	case fd.IsList():
+		list := msg.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			clearFieldPath(list.Get(i).Message(), rest)
+		}
+	case fd.IsMap():
+		msg.Get(fd).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+			clearFieldPath(v.Message(), rest)
+			return true
+		})
+	default:
+		clearFieldPath(msg.Mutable(fd).Message(), rest)
+	}
+}
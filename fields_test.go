@@ -0,0 +1,77 @@
+package protocli_test
+
+import (
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newExcludeFieldsResponse() *simple.UserResponse {
+	return &simple.UserResponse{
+		Message: "found",
+		User: &simple.User{
+			Id:    7,
+			Name:  "Alice",
+			Email: "alice@example.com",
+			Address: &simple.Address{
+				Street: "1 Main St",
+				City:   "Springfield",
+			},
+		},
+	}
+}
+
+func TestUnit_ExcludeFields_TopLevelAndNested(t *testing.T) {
+	orig := newExcludeFieldsResponse()
+
+	got, err := protocli.ExcludeFields(orig, []string{"message", "user.address.city", "user.email"})
+	require.NoError(t, err)
+
+	assert.Empty(t, got.GetMessage())
+	assert.Empty(t, got.GetUser().GetEmail())
+	assert.Empty(t, got.GetUser().GetAddress().GetCity())
+	assert.Equal(t, "Alice", got.GetUser().GetName())
+	assert.Equal(t, "1 Main St", got.GetUser().GetAddress().GetStreet())
+
+	// The input message is left untouched
+	assert.Equal(t, "found", orig.GetMessage())
+	assert.Equal(t, "Springfield", orig.GetUser().GetAddress().GetCity())
+}
+
+func TestUnit_ExcludeFields_WholeMessageAndJSONName(t *testing.T) {
+	got, err := protocli.ExcludeFields(newExcludeFieldsResponse(), []string{"user.address", "user.createdAt"})
+	require.NoError(t, err)
+	assert.Nil(t, got.GetUser().GetAddress())
+	assert.Equal(t, "Alice", got.GetUser().GetName())
+}
+
+func TestUnit_ExcludeFields_RepeatedAndMap(t *testing.T) {
+	list, err := structpb.NewList([]any{"a", 1.5, "b"})
+	require.NoError(t, err)
+
+	gotList, err := protocli.ExcludeFields(list, []string{"values.string_value"})
+	require.NoError(t, err)
+	require.Len(t, gotList.GetValues(), 3)
+	assert.Nil(t, gotList.GetValues()[0].GetKind())
+	assert.InDelta(t, 1.5, gotList.GetValues()[1].GetNumberValue(), 0)
+	assert.Nil(t, gotList.GetValues()[2].GetKind())
+
+	st, err := structpb.NewStruct(map[string]any{"name": "x", "ok": true})
+	require.NoError(t, err)
+
+	gotStruct, err := protocli.ExcludeFields(st, []string{"fields.bool_value"})
+	require.NoError(t, err)
+	assert.Equal(t, "x", gotStruct.GetFields()["name"].GetStringValue())
+	assert.Nil(t, gotStruct.GetFields()["ok"].GetKind())
+}
+
+func TestUnit_ExcludeFields_InvalidPath(t *testing.T) {
+	for _, path := range []string{"nope", "user.nope", "user.name.first", "user..name", ""} {
+		_, err := protocli.ExcludeFields(newExcludeFieldsResponse(), []string{path})
+		require.ErrorIs(t, err, protocli.ErrUnknownFieldPath, "path %q", path)
+	}
+}
@@ -0,0 +1,37 @@
+package protocli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadFileFlagValue resolves a string flag value that may refer to a file.
+// "@path" is replaced by the contents of path and "@-" by standard input, both
+// with surrounding whitespace trimmed. "@@" escapes a literal leading "@", so
+// "@@handle" yields "@handle". Any other value is returned unchanged.
+//
+// Generated commands call this for string fields annotated with
+// (cli.flag).allow_file, or for every string field when the generator is run
+// with file_flags=true.
+func ReadFileFlagValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, "@@"):
+		return value[1:], nil
+	case value == "@-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read value from stdin: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	case strings.HasPrefix(value, "@"):
+		data, err := os.ReadFile(value[1:])
+		if err != nil {
+			return "", fmt.Errorf("failed to read value from file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return value, nil
+	}
+}
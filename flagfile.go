@@ -0,0 +1,193 @@
+package protocli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// ErrInvalidFlagFile is returned when a --flag-file cannot be parsed or names
+// a flag the command does not have.
+var ErrInvalidFlagFile = errors.New("invalid flag file")
+
+// ParseFlagFile splits a flag file into arguments. Each line holds flags and
+// their values separated by whitespace, e.g. `--name "Alice Smith"`. Single
+// and double quotes group words, a backslash escapes the next character
+// outside single quotes, and an unquoted # starts a comment that runs to the
+// end of the line.
+func ParseFlagFile(r io.Reader) ([]string, error) {
+	var args []string
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		lineArgs, err := splitFlagFileLine(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidFlagFile, lineNo, err)
+		}
+		args = append(args, lineArgs...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read flag file: %w", err)
+	}
+	return args, nil
+}
+
+var (
+	errUnterminatedQuote = errors.New("unterminated quote")
+	errTrailingBackslash = errors.New("trailing backslash")
+)
+
+// splitFlagFileLine splits one line of a flag file into words.
+func splitFlagFileLine(line string) ([]string, error) {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, c := range line {
+		switch {
+		case escaped:
+			word.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote, inWord = c, true
+		case c == '#' && !inWord:
+			return words, nil
+		case c == ' ' || c == '\t' || c == '\r':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	switch {
+	case quote != 0:
+		return nil, errUnterminatedQuote
+	case escaped:
+		return nil, errTrailingBackslash
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// applyFlagFiles reads the files named by the root --flag-file flag and sets
+// their flags on the command that is about to run. Flags given on the command
+// line win over those in a file, and later files win over earlier ones.
+func applyFlagFiles(root *cli.Command) error {
+	paths := root.StringSlice("flag-file")
+	if len(paths) == 0 {
+		return nil
+	}
+
+	// Flags are parsed before Before hooks run, so set the file's flags on the
+	// command selected by the positional args directly
+	cmd := selectedCommand(root)
+
+	onCommandLine := make(map[cli.Flag]bool)
+	for _, c := range cmd.Lineage() {
+		for _, f := range c.Flags {
+			onCommandLine[f] = f.IsSet()
+		}
+	}
+
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open flag file: %w", err)
+		}
+		args, err := ParseFlagFile(file)
+		_ = file.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := setFlagFileArgs(cmd, args, onCommandLine); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// setFlagFileArgs sets the flags in args on cmd, skipping those in skip.
+func setFlagFileArgs(cmd *cli.Command, args []string, skip map[cli.Flag]bool) error {
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		if !strings.HasPrefix(args[i], "-") || name == "" {
+			return fmt.Errorf("%w: unexpected argument %q, flag files may only contain flags", ErrInvalidFlagFile, args[i])
+		}
+		if name == "flag-file" {
+			return fmt.Errorf("%w: flag files cannot be nested", ErrInvalidFlagFile)
+		}
+
+		f := lookupLineageFlag(cmd, name)
+		if f == nil {
+			return fmt.Errorf("%w: unknown flag %q for %q", ErrInvalidFlagFile, args[i], cmd.FullName())
+		}
+		if !hasValue {
+			if bf, ok := f.(interface{ IsBoolFlag() bool }); ok && bf.IsBoolFlag() {
+				value = "true"
+			} else if i+1 < len(args) {
+				i++
+				value = args[i]
+			} else {
+				return fmt.Errorf("%w: flag %q needs a value", ErrInvalidFlagFile, args[i])
+			}
+		}
+
+		if skip[f] {
+			continue
+		}
+		if err := cmd.Set(name, value); err != nil {
+			return fmt.Errorf("%w: --%s: %w", ErrInvalidFlagFile, name, err)
+		}
+	}
+	return nil
+}
+
+// selectedCommand returns the command under root selected by the positional
+// args, i.e. the command that is about to run.
+func selectedCommand(root *cli.Command) *cli.Command {
+	cmd := root
+	for cmd.Args().Present() {
+		sub := cmd.Command(cmd.Args().First())
+		if sub == nil {
+			break
+		}
+		cmd = sub
+	}
+	return cmd
+}
+
+// lookupLineageFlag returns the flag called name on cmd or one of its
+// ancestors, or nil if there is none.
+func lookupLineageFlag(cmd *cli.Command, name string) cli.Flag {
+	for _, c := range cmd.Lineage() {
+		for _, f := range c.Flags {
+			for _, n := range f.Names() {
+				if n == name {
+					return f
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_ParseFlagFile(t *testing.T) {
+	args, err := protocli.ParseFlagFile(strings.NewReader(`# Users created by CI
+--name "Alice Smith"   # the display name
+--email='alice@example.com'
+--nickname 'it''s' --verified
+
+--bio "say \"hi\" # not a comment"
+--tags a\ b
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"--name", "Alice Smith",
+		"--email=alice@example.com",
+		"--nickname", "its", "--verified",
+		"--bio", `say "hi" # not a comment`,
+		"--tags", "a b",
+	}, args)
+}
+
+func TestUnit_ParseFlagFile_Errors(t *testing.T) {
+	for name, content := range map[string]string{
+		"unterminated quote": "--name \"Alice\n",
+		"trailing backslash": "--name Alice\\",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := protocli.ParseFlagFile(strings.NewReader(content))
+			require.ErrorIs(t, err, protocli.ErrInvalidFlagFile)
+			assert.Contains(t, err.Error(), "line 1")
+		})
+	}
+}
+
+// runFlagFileCreate runs create-user with args and returns the request the
+// service received.
+func runFlagFileCreate(t *testing.T, args ...string) (*simple.CreateUserRequest, error) {
+	t.Helper()
+	setupTestCLI(t)
+	var received *simple.CreateUserRequest
+	mock := &simple.MockUserServiceServer{
+		CreateUserFunc: func(_ context.Context, req *simple.CreateUserRequest) (*simple.UserResponse, error) {
+			received = req
+			return &simple.UserResponse{}, nil
+		},
+	}
+	factory := func(_ *simple.UserServiceConfig) simple.UserServiceServer { return mock }
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(simple.UserServiceCommand(context.Background(), factory)),
+	)
+	require.NoError(t, err)
+	setWriterOnAllCommands(rootCmd, &bytes.Buffer{})
+	rootCmd.ErrWriter = &bytes.Buffer{}
+
+	err = rootCmd.Run(context.Background(), append([]string{"testcli"}, args...))
+	return received, err
+}
+
+func writeFlagFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "create.flags")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// TestIntegration_FlagFile tests that flags from --flag-file satisfy required
+// flags, keep quoted values intact and lose to flags on the command line.
+func TestIntegration_FlagFile(t *testing.T) {
+	path := writeFlagFile(t, `
+# Required flags
+--db-url postgres://localhost/db
+--name "Alice Smith"
+--email alice@example.com
+
+--nickname 'Al # Smith' --verified  # trailing comment
+`)
+
+	req, err := runFlagFileCreate(t, "--flag-file", path, "user-service", "create", "--email", "cli@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, req)
+	assert.Equal(t, "Alice Smith", req.GetName())
+	assert.Equal(t, "cli@example.com", req.GetEmail(), "the command line must win over the flag file")
+	assert.Equal(t, "Al # Smith", req.GetNickname())
+	assert.True(t, req.GetVerified())
+
+	// --flag-file is a global flag, so it may also follow the subcommand
+	req, err = runFlagFileCreate(t, "user-service", "create", "--flag-file", path)
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", req.GetEmail())
+}
+
+func TestIntegration_FlagFile_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown flag":  "--name Alice --email a@example.com --shoe-size 42",
+		"positional":    "--db-url x --name Alice --email a@example.com extra",
+		"nested":        "--flag-file other.flags",
+		"missing value": "--db-url x --email a@example.com --name",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := writeFlagFile(t, content)
+			req, err := runFlagFileCreate(t, "--flag-file", path, "user-service", "create")
+			require.ErrorIs(t, err, protocli.ErrInvalidFlagFile)
+			assert.Nil(t, req)
+		})
+	}
+}
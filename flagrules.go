@@ -0,0 +1,48 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+// ErrFlagRuleViolation is wrapped by the errors of the built-in flag rules.
+var ErrFlagRuleViolation = errors.New("invalid flag combination")
+
+// FlagRule checks the flags a command was run with against each other, e.g.
+// that two flags are not set together. Generated commands run the rules
+// declared with the cli.command requires and conflicts annotations and those
+// registered with WithFlagRules once the request is assembled, and report all
+// failures together.
+type FlagRule func(cmd *cli.Command) error
+
+// RequiresFlag returns a rule rejecting --flag unless --other is also set.
+func RequiresFlag(flag, other string) FlagRule {
+	return func(cmd *cli.Command) error {
+		if cmd.IsSet(flag) && !cmd.IsSet(other) {
+			return fmt.Errorf("%w: --%s requires --%s", ErrFlagRuleViolation, flag, other)
+		}
+		return nil
+	}
+}
+
+// ConflictingFlags returns a rule rejecting --flag and --other set together.
+func ConflictingFlags(flag, other string) FlagRule {
+	return func(cmd *cli.Command) error {
+		if cmd.IsSet(flag) && cmd.IsSet(other) {
+			return fmt.Errorf("%w: --%s and --%s can't be used together", ErrFlagRuleViolation, flag, other)
+		}
+		return nil
+	}
+}
+
+// CheckFlagRules runs the rules declared for a command and those registered
+// for it with WithFlagRules, joining their errors.
+func CheckFlagRules(cmd *cli.Command, registered []FlagRule, declared ...FlagRule) error {
+	var errs []error
+	for _, rule := range append(declared, registered...) {
+		errs = append(errs, rule(cmd))
+	}
+	return errors.Join(errs...)
+}
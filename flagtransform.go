@@ -0,0 +1,68 @@
+package protocli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FlagTransform normalizes a string or bytes flag value (trimming, case
+// folding, path expansion, ...) before it is assigned to its request field.
+type FlagTransform func(value string) (string, error)
+
+// flagTransformKey identifies a field by its message's full name and its
+// proto field name.
+type flagTransformKey struct {
+	message string
+	field   string
+}
+
+var (
+	flagTransformsMu sync.RWMutex
+	flagTransforms   = map[flagTransformKey]FlagTransform{}
+)
+
+// RegisterFlagTransform makes generated commands pass the flag value of the
+// singular string or bytes field fieldName (its proto name, e.g. "email") of
+// messageName (e.g. "example.CreateUserRequest") through fn before assigning
+// it. This is a lighter alternative to a FlagDeserializer for the whole
+// message. Registering an existing field replaces its transform, and a nil fn
+// removes it.
+func RegisterFlagTransform(messageName, fieldName string, fn func(string) (string, error)) {
+	flagTransformsMu.Lock()
+	defer flagTransformsMu.Unlock()
+	key := flagTransformKey{message: messageName, field: fieldName}
+	if fn == nil {
+		delete(flagTransforms, key)
+		return
+	}
+	flagTransforms[key] = fn
+}
+
+// TransformFlagValue returns value passed through the transform registered for
+// fieldName of messageName, or value unchanged if there is none. Generated
+// commands call this for every string and bytes flag value they assign.
+func TransformFlagValue(messageName, fieldName, value string) (string, error) {
+	flagTransformsMu.RLock()
+	fn, ok := flagTransforms[flagTransformKey{message: messageName, field: fieldName}]
+	flagTransformsMu.RUnlock()
+	if !ok {
+		return value, nil
+	}
+	return fn(value)
+}
+
+// ExpandHomeDir is a FlagTransform that replaces a leading "~" (alone or
+// followed by a path separator) with the current user's home directory.
+func ExpandHomeDir(value string) (string, error) {
+	if value != "~" && !strings.HasPrefix(value, "~/") && !strings.HasPrefix(value, "~"+string(filepath.Separator)) {
+		return value, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand ~: %w", err)
+	}
+	return filepath.Join(home, value[1:]), nil
+}
@@ -0,0 +1,90 @@
+package protocli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v3"
+)
+
+// Modes of the --flush flag of server-streaming commands.
+const (
+	flushAuto   = "auto"
+	flushAlways = "always"
+	flushNever  = "never"
+)
+
+// ErrUnknownFlushMode is returned for a --flush value other than auto,
+// always, or never.
+var ErrUnknownFlushMode = errors.New("unknown flush mode")
+
+// StreamOutput buffers the output of a server-streaming command. Generated
+// commands call MessageDone after writing each message, which flushes the
+// buffer when --flush is "always", or "auto" (the default) and the output is a
+// terminal, so interactive streams show each message as it arrives while piped
+// output is written in larger chunks.
+type StreamOutput struct {
+	w          io.Writer
+	buf        *bufio.Writer
+	perMessage bool
+	pending    bool // written since the last Flush
+}
+
+var _ io.Writer = (*StreamOutput)(nil)
+
+// NewStreamOutput wraps w, the command's output writer, in a buffer flushed
+// according to the --flush flag.
+func NewStreamOutput(cmd *cli.Command, w io.Writer) (*StreamOutput, error) {
+	var perMessage bool
+	switch mode := cmd.String("flush"); mode {
+	case "", flushAuto:
+		perMessage = isTerminal(w)
+	case flushAlways:
+		perMessage = true
+	case flushNever:
+		perMessage = false
+	default:
+		return nil, fmt.Errorf("%w %q (available: %s, %s, %s)", ErrUnknownFlushMode, mode, flushAuto, flushAlways, flushNever)
+	}
+	return &StreamOutput{w: w, buf: bufio.NewWriter(w), perMessage: perMessage}, nil
+}
+
+// Unwrap returns the writer the output is buffered for, so that checks such
+// as whether the output is a terminal look through the buffer.
+func (o *StreamOutput) Unwrap() io.Writer {
+	return o.w
+}
+
+// Write buffers p.
+func (o *StreamOutput) Write(p []byte) (int, error) {
+	o.pending = true
+	return o.buf.Write(p)
+}
+
+// MessageDone marks the end of a streamed message, flushing the output if
+// --flush asks for it after every message.
+func (o *StreamOutput) MessageDone() error {
+	if !o.perMessage {
+		return nil
+	}
+	return o.Flush()
+}
+
+// Flush writes any buffered output to the underlying writer, then flushes
+// that writer too if it has a Flush method. It does nothing if nothing was
+// written since the last Flush.
+func (o *StreamOutput) Flush() error {
+	if !o.pending {
+		return nil
+	}
+	o.pending = false
+	if err := o.buf.Flush(); err != nil {
+		return err
+	}
+	if f, ok := o.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package protocli
+
+import (
+	"log/slog"
+
+	"github.com/urfave/cli/v3"
+)
+
+// formatFlagWarningsKey is the Metadata key tracking which ignored format
+// flag warnings were already logged during the current run.
+const formatFlagWarningsKey = "protocli:formatFlagWarnings"
+
+// FormatFlags returns the flags declared by the FlagConfiguredOutputFormat
+// formats in formats, e.g. --pretty, for a method command. A flag declared by
+// more than one format (such as the json flags json-color shares) is returned
+// once. Called by generated commands.
+func FormatFlags(formats []OutputFormat) []cli.Flag {
+	var flags []cli.Flag
+	seen := make(map[string]bool)
+	for _, f := range formats {
+		fc, ok := f.(FlagConfiguredOutputFormat)
+		if !ok {
+			continue
+		}
+		for _, flag := range fc.Flags() {
+			if name := flag.Names()[0]; !seen[name] {
+				seen[name] = true
+				flags = append(flags, flag)
+			}
+		}
+	}
+	return flags
+}
+
+// WarnIgnoredFormatFlags logs a warning for each flag set on cmd that belongs
+// to a FlagConfiguredOutputFormat other than the selected formatName, e.g.
+// --pretty with --format yaml, since that format never reads it. Flags the
+// selected format declares as well are not reported. Called by generated
+// actions before rendering; each warning is logged at most once per run.
+func WarnIgnoredFormatFlags(cmd *cli.Command, formats []OutputFormat, formatName string) {
+	selected := make(map[string]bool)
+	for _, f := range formats {
+		if fc, ok := f.(FlagConfiguredOutputFormat); ok && f.Name() == formatName {
+			for _, flag := range fc.Flags() {
+				for _, name := range flag.Names() {
+					selected[name] = true
+				}
+			}
+		}
+	}
+
+	for _, f := range formats {
+		fc, ok := f.(FlagConfiguredOutputFormat)
+		if !ok || f.Name() == formatName {
+			continue
+		}
+		for _, flag := range fc.Flags() {
+			name := flag.Names()[0]
+			if selected[name] || !cmd.IsSet(name) {
+				continue
+			}
+			if !markWarned(cmd, formatFlagWarningsKey, commandPath(cmd)+":"+name) {
+				continue
+			}
+			slog.Warn("Flag is ignored by the selected output format",
+				"flag", "--"+name, "format", formatName, "used_by", f.Name())
+		}
+	}
+}
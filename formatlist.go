@@ -0,0 +1,118 @@
+package protocli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+)
+
+// NewFormatsCommand returns `formats`, which lists the output formats
+// registered with a service (and the flags each one adds to its commands) and
+// the input formats accepted by --input-file. Flat service commands include it
+// unless a method command is already named "formats"; RootCommand adds one
+// `formats` for all services instead.
+func NewFormatsCommand(options ServiceConfig) *cli.Command {
+	return &cli.Command{
+		Name:  "formats",
+		Usage: "List the registered output and input formats",
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() > 0 {
+				return cli.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			}
+			return writeFormatList(commandWriter(cmd), "", options.OutputFormats(), options.InputFormats())
+		},
+	}
+}
+
+// newFormatsListCommand returns the root `formats` command, which lists the
+// formats of every service, each under its name, or of the service named by
+// its argument (e.g. `formats user-service`).
+func newFormatsListCommand(services []*ServiceCLI) *cli.Command {
+	return &cli.Command{
+		Name:      "formats",
+		Usage:     "List the registered output and input formats of each service",
+		ArgsUsage: "[service]",
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			listed := make([]*ServiceCLI, 0, len(services))
+			for _, svc := range services {
+				if svc.OutputFormats != nil || svc.InputFormats != nil {
+					listed = append(listed, svc)
+				}
+			}
+			if cmd.Args().Len() > 1 {
+				return cli.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(1)), 3)
+			}
+			if name := cmd.Args().First(); name != "" {
+				i := slices.IndexFunc(listed, func(svc *ServiceCLI) bool {
+					return svc.ServiceName == name || svc.Command != nil && slices.Contains(svc.Command.Names(), name)
+				})
+				if i < 0 {
+					return cli.Exit(fmt.Sprintf("unknown service: %q", name), 3)
+				}
+				listed = listed[i : i+1]
+			}
+
+			w := commandWriter(cmd)
+			if len(listed) == 1 {
+				return writeFormatList(w, "", listed[0].OutputFormats, listed[0].InputFormats)
+			}
+			for i, svc := range listed {
+				if i > 0 {
+					_, _ = fmt.Fprintln(w)
+				}
+				_, _ = fmt.Fprintf(w, "%s:\n", svc.ServiceName)
+				if err := writeFormatList(w, "  ", svc.OutputFormats, svc.InputFormats); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// commandWriter returns the root command's writer, or stdout when unset.
+func commandWriter(cmd *cli.Command) io.Writer {
+	if w := cmd.Root().Writer; w != nil {
+		return w
+	}
+	return os.Stdout
+}
+
+// writeFormatList writes the output formats, default first as commands use
+// it when --format is not given, followed by the input formats, with every
+// line prefixed by indent.
+func writeFormatList(w io.Writer, indent string, outputs []OutputFormat, inputs []InputFormat) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "%sOutput formats:\n", indent)
+	if len(outputs) == 0 {
+		_, _ = fmt.Fprintf(tw, "%s  (none registered)\n", indent)
+	}
+	for i, f := range outputs {
+		name := f.Name()
+		if i == 0 {
+			name += " (default)"
+		}
+		_, _ = fmt.Fprintf(tw, "%s  %s\n", indent, name)
+		if fc, ok := f.(FlagConfiguredOutputFormat); ok {
+			for _, flag := range fc.Flags() {
+				usage := ""
+				if doc, ok := flag.(cli.DocGenerationFlag); ok {
+					usage = doc.GetUsage()
+				}
+				_, _ = fmt.Fprintf(tw, "%s      --%s\t%s\n", indent, flag.Names()[0], usage)
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintf(tw, "%sInput formats:\n", indent)
+	for _, f := range inputs {
+		_, _ = fmt.Fprintf(tw, "%s  %s\t%s\n", indent, f.Name(), strings.Join(f.Extensions(), ", "))
+	}
+	return tw.Flush()
+}
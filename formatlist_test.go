@@ -0,0 +1,84 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUnit_FormatsCommand lists a service's output formats, default first with
+// the flags each adds, including a custom template format, and its input formats.
+func TestUnit_FormatsCommand(t *testing.T) {
+	ctx := context.Background()
+	table := protocli.MustTemplateFormat("table", map[string]string{
+		"example.UserResponse": "{{.user.name}}",
+	})
+	userCLI := simple.UserServiceCommand(ctx, newMockUserService(nil),
+		protocli.WithOutputFormats(protocli.JSON(), protocli.YAML(), table),
+	)
+	rootCmd, err := protocli.RootCommand("testcli", protocli.Service(userCLI))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "formats"}))
+
+	out := buf.String()
+	assert.Contains(t, out, "Output formats:\n  json (default)\n")
+	assert.Regexp(t, `--pretty +Pretty-print JSON output with indentation`, out)
+	assert.Regexp(t, `--yaml-flow +Write YAML`, out)
+	assert.Contains(t, out, "\n  table\n", "custom formats are listed")
+	assert.Contains(t, out, "Input formats:\n")
+	assert.Regexp(t, `json +\.json`, out)
+	assert.Regexp(t, `yaml +\.yaml, \.yml`, out)
+}
+
+// TestUnit_FormatsCommand_DefaultFormat lists the fallback format used when a
+// service registers none.
+func TestUnit_FormatsCommand_DefaultFormat(t *testing.T) {
+	ctx := context.Background()
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(simple.UserServiceCommand(ctx, newMockUserService(nil))),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "formats"}))
+	assert.Contains(t, buf.String(), "Output formats:\n  go (default)\nInput formats:\n")
+}
+
+// TestUnit_FormatsCommand_MultipleServices lists each service's formats under
+// its name, or only the formats of the service given as the argument.
+func TestUnit_FormatsCommand_MultipleServices(t *testing.T) {
+	preventExit(t)
+	ctx := context.Background()
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(simple.UserServiceCommand(ctx, newMockUserService(nil),
+			protocli.WithOutputFormats(protocli.JSON()))),
+		protocli.Service(simple.AdminServiceCommand(ctx, &simple.UnimplementedAdminServiceServer{},
+			protocli.WithOutputFormats(protocli.YAML()))),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "formats"}))
+	out := buf.String()
+	assert.Contains(t, out, "user-service:\n  Output formats:\n    json (default)\n")
+	assert.Contains(t, out, "\nadmin:\n  Output formats:\n    yaml (default)\n")
+
+	buf.Reset()
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "formats", "admin"}))
+	assert.True(t, strings.HasPrefix(buf.String(), "Output formats:\n  yaml (default)\n"), buf.String())
+
+	err = rootCmd.Run(ctx, []string{"testcli", "formats", "nope"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown service: "nope"`)
+}
@@ -8,17 +8,102 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/urfave/cli/v3"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
 )
 
 // ErrNoTemplate is returned when no template is registered for a message type.
 var ErrNoTemplate = errors.New("no template registered for message type")
 
+var (
+	ErrUnknownFormat   = errors.New("unknown format")
+	ErrNoOutputFormats = errors.New("no output formats registered")
+)
+
+// WriteFormatted renders msg with the registered output format named formatName,
+// followed by a trailing newline to keep the terminal clean unless the format
+// opts out with TrailingNewlineControl. Generated unary commands call this for
+// every CLI backend; cmd supplies format-specific flags.
+func WriteFormatted(ctx context.Context, cmd *cli.Command, w io.Writer, formats []OutputFormat, formatName string, msg proto.Message) error {
+	for _, outputFmt := range formats {
+		if outputFmt.Name() != formatName {
+			continue
+		}
+		if err := FormatMessage(ctx, cmd, outputFmt, w, msg); err != nil {
+			return fmt.Errorf("format failed: %w", err)
+		}
+		if !NeedsTrailingNewline(outputFmt) {
+			return nil
+		}
+		if _, err := w.Write([]byte("\n")); err != nil {
+			return fmt.Errorf("failed to write final newline: %w", err)
+		}
+		return nil
+	}
+
+	if len(formats) == 0 {
+		return fmt.Errorf("%w (use WithOutputFormats to register formats)", ErrNoOutputFormats)
+	}
+	availableFormats := make([]string, 0, len(formats))
+	for _, f := range formats {
+		availableFormats = append(availableFormats, f.Name())
+	}
+	return fmt.Errorf("%w %q (available: %v)", ErrUnknownFormat, formatName, availableFormats)
+}
+
+// NeedsTrailingNewline reports whether a newline should be written after
+// output rendered with outputFmt: true unless the format implements
+// TrailingNewlineControl and returns false.
+func NeedsTrailingNewline(outputFmt OutputFormat) bool {
+	if tnc, ok := outputFmt.(TrailingNewlineControl); ok {
+		return tnc.NeedsTrailingNewline()
+	}
+	return true
+}
+
+// formatPanicRecoveryKey is the Metadata key storing the WithFormatPanicRecovery setting on the root command.
+const formatPanicRecoveryKey = "protocli:formatPanicRecovery"
+
+// ErrFormatPanic is returned when an output format panics while rendering.
+var ErrFormatPanic = errors.New("output format panicked")
+
+// FormatMessage renders msg with outputFmt. A panic inside the format is
+// converted into an ErrFormatPanic error so a buggy custom format cannot crash
+// the CLI, unless recovery was disabled with WithFormatPanicRecovery(false).
+// Generated commands render every message through this function.
+func FormatMessage(ctx context.Context, cmd *cli.Command, outputFmt OutputFormat, w io.Writer, msg proto.Message) (err error) {
+	if enabled, ok := cmd.Root().Metadata[formatPanicRecoveryKey].(bool); ok && !enabled {
+		return outputFmt.Format(ctx, cmd, w, msg)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %q: %v", ErrFormatPanic, outputFmt.Name(), r)
+		}
+	}()
+	return outputFmt.Format(ctx, cmd, w, msg)
+}
+
+// applyFormatEnv makes the --format flag of every command under cmd default to
+// the envVar environment variable (e.g. USERCLI_FORMAT) when the flag is not
+// given on the command line.
+func applyFormatEnv(cmd *cli.Command, envVar string) {
+	for _, sub := range cmd.Commands {
+		applyFormatEnv(sub, envVar)
+		for _, flag := range sub.Flags {
+			if f, ok := flag.(*cli.StringFlag); ok && f.Name == "format" && len(f.Sources.Chain) == 0 {
+				f.Sources = cli.EnvVars(envVar)
+			}
+		}
+	}
+}
+
 // jsonFormat formats proto messages as JSON.
 type jsonFormat struct{}
 
@@ -32,25 +117,89 @@ func (f *jsonFormat) Flags() []cli.Flag {
 			Name:  "pretty",
 			Usage: "Pretty-print JSON output with indentation",
 		},
+		&cli.BoolFlag{
+			Name:  "json-null-optionals",
+			Usage: "Emit null for unset optional fields instead of omitting them",
+		},
+		&cli.BoolFlag{
+			Name:  "json-enum-numbers",
+			Usage: "Render enums as their integer values instead of their names",
+		},
+		&cli.StringFlag{
+			Name:  "json-allow-special",
+			Usage: "Render NaN and Infinity floats as strings (\"NaN\", \"Infinity\") or nulls: string or null",
+		},
 	}
 }
 
 func (f *jsonFormat) Format(_ context.Context, cmd *cli.Command, w io.Writer, msg proto.Message) error {
+	indent := ""
+	if cmd.Bool("pretty") {
+		indent = "  "
+	}
+	jsonBytes, err := f.render(cmd, msg, indent)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(jsonBytes)
+	return err
+}
+
+// render marshals msg as JSON according to the json format flags of cmd,
+// indented by indent if it is not empty.
+func (f *jsonFormat) render(cmd *cli.Command, msg proto.Message, indent string) ([]byte, error) {
+	if s, ok, err := formatRegistered(msg); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		var jsonBytes []byte
+		if indent != "" {
+			jsonBytes, err = json.MarshalIndent(s, "", indent)
+		} else {
+			jsonBytes, err = json.Marshal(s)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return jsonBytes, nil
+	}
+
 	marshaler := protojson.MarshalOptions{
 		EmitUnpopulated: true,
+		UseEnumNumbers:  cmd.Bool("json-enum-numbers"),
+		Indent:          indent,
 	}
 
-	if cmd.Bool("pretty") {
-		marshaler.Indent = "  "
+	special := cmd.String("json-allow-special")
+	switch special {
+	case "":
+	case specialFloatsString, specialFloatsNull:
+		msg = replaceSpecialValues(msg, special)
+	default:
+		return nil, fmt.Errorf("%w %q (must be %s or %s)", ErrInvalidSpecialFloats, special, specialFloatsString, specialFloatsNull)
 	}
 
 	jsonBytes, err := marshaler.Marshal(msg)
 	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %w", err)
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
 	}
 
-	_, err = w.Write(jsonBytes)
-	return err
+	if special == specialFloatsNull {
+		if jsonBytes, err = nullSpecialFloats(msg.ProtoReflect(), jsonBytes, marshaler.Indent); err != nil {
+			return nil, fmt.Errorf("failed to null special floats: %w", err)
+		}
+	}
+
+	if cmd.Bool("json-null-optionals") {
+		if jsonBytes, err = nullUnsetOptionals(msg.ProtoReflect(), jsonBytes, marshaler.Indent); err != nil {
+			return nil, fmt.Errorf("failed to add null optionals: %w", err)
+		}
+	}
+
+	if jsonBytes, err = applyFormatters(msg.ProtoReflect(), jsonBytes, marshaler.Indent); err != nil {
+		return nil, fmt.Errorf("failed to apply formatters: %w", err)
+	}
+	return jsonBytes, nil
 }
 
 // goFormat formats proto messages using Go's default %+v formatting.
@@ -61,6 +210,13 @@ func (f *goFormat) Name() string {
 }
 
 func (f *goFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg proto.Message) error {
+	if s, ok, err := formatRegistered(msg); ok || err != nil {
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, s)
+		return err
+	}
 	_, err := fmt.Fprintf(w, "%+v", msg)
 	return err
 }
@@ -68,15 +224,52 @@ func (f *goFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg pr
 // yamlFormat formats proto messages as YAML.
 type yamlFormat struct{}
 
+// defaultYAMLIndent is the number of spaces per nesting level in block style.
+const defaultYAMLIndent = 2
+
+// ErrInvalidYAMLIndent is returned when --yaml-indent is less than 1.
+var ErrInvalidYAMLIndent = errors.New("invalid YAML indent")
+
 func (f *yamlFormat) Name() string {
 	return "yaml"
 }
 
-func (f *yamlFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg proto.Message) error {
+func (f *yamlFormat) MediaType() string {
+	return "application/yaml"
+}
+
+func (f *yamlFormat) Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "yaml-flow",
+			Usage: "Write YAML in compact flow style ({a: 1, b: 2}) instead of block style",
+		},
+		&cli.IntFlag{
+			Name:  "yaml-indent",
+			Value: defaultYAMLIndent,
+			Usage: "Number of spaces per nesting level in block-style YAML",
+		},
+		&cli.BoolFlag{
+			Name:  "yaml-enum-numbers",
+			Usage: "Render enums as their integer values instead of their names",
+		},
+	}
+}
+
+func (f *yamlFormat) Format(_ context.Context, cmd *cli.Command, w io.Writer, msg proto.Message) error {
+	if s, ok, err := formatRegistered(msg); ok || err != nil {
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, s)
+		return err
+	}
+
 	// Convert to JSON first, then to YAML-like format
 	marshaler := protojson.MarshalOptions{
 		EmitUnpopulated: true,
 		Indent:          "  ",
+		UseEnumNumbers:  cmd.Bool("yaml-enum-numbers"),
 	}
 
 	jsonBytes, err := marshaler.Marshal(msg)
@@ -84,19 +277,76 @@ func (f *yamlFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
 
+	if jsonBytes, err = applyFormatters(msg.ProtoReflect(), jsonBytes, ""); err != nil {
+		return fmt.Errorf("failed to apply formatters: %w", err)
+	}
+
 	// Parse JSON to map for YAML-style output
 	var data map[string]any
 	if err := json.Unmarshal(jsonBytes, &data); err != nil {
 		return fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
 
+	if cmd.Bool("yaml-flow") {
+		flow, err := yamlFlow(data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		_, err = io.WriteString(w, flow)
+		return err
+	}
+
+	unit := strings.Repeat(" ", defaultYAMLIndent)
+	if cmd.IsSet("yaml-indent") {
+		n := cmd.Int("yaml-indent")
+		if n < 1 {
+			return fmt.Errorf("%w: %d (must be at least 1)", ErrInvalidYAMLIndent, n)
+		}
+		unit = strings.Repeat(" ", n)
+	}
+
 	// Simple YAML-like output (for full YAML support, use gopkg.in/yaml.v3)
 	// Use internal function that tracks last item to avoid trailing newline
-	return writeYAMLMap(w, data, 0)
+	return writeYAMLMap(w, data, 0, unit)
+}
+
+// yamlFlow renders a JSON-decoded value in YAML flow style, with map keys
+// sorted for deterministic output. Example: {a: 1, b: [x, y]}. Scalars are
+// quoted wherever YAML needs it, e.g. 'a, b' or "yes".
+func yamlFlow(data any) (string, error) {
+	var node yaml.Node
+	if err := node.Encode(data); err != nil {
+		return "", err
+	}
+	setYAMLFlowStyle(&node)
+	out, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// setYAMLFlowStyle switches every mapping and sequence under node to flow style.
+func setYAMLFlowStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = yaml.FlowStyle
+	}
+	for _, child := range node.Content {
+		setYAMLFlowStyle(child)
+	}
+}
+
+// yamlKey quotes map keys that YAML reserves as indicators, such as the "@type"
+// key of an unpacked google.protobuf.Any.
+func yamlKey(key string) string {
+	if strings.HasPrefix(key, "@") {
+		return strconv.Quote(key)
+	}
+	return key
 }
 
 // writeMapFields writes a map's key-value pairs with proper YAML formatting
-func writeMapFields(w io.Writer, data map[string]any, prefix string, indent int) error {
+func writeMapFields(w io.Writer, data map[string]any, prefix string, indent int, unit string) error {
 	// Get keys in a sorted slice for deterministic output
 	keys := make([]string, 0, len(data))
 	for k := range data {
@@ -104,15 +354,16 @@ func writeMapFields(w io.Writer, data map[string]any, prefix string, indent int)
 	}
 	sort.Strings(keys)
 
-	for i, key := range keys {
-		val := data[key]
+	for i, k := range keys {
+		val := data[k]
+		key := yamlKey(k)
 		isLast := i == len(keys)-1
 
 		if subMap, ok := val.(map[string]any); ok {
 			if _, err := fmt.Fprintf(w, "%s%s:\n", prefix, key); err != nil {
 				return err
 			}
-			if err := writeYAMLValue(w, subMap, indent+1, false); err != nil {
+			if err := writeYAMLValue(w, subMap, indent+1, unit); err != nil {
 				return err
 			}
 			// Add newline after nested structure unless it's the last field
@@ -125,7 +376,7 @@ func writeMapFields(w io.Writer, data map[string]any, prefix string, indent int)
 			if _, err := fmt.Fprintf(w, "%s%s:\n", prefix, key); err != nil {
 				return err
 			}
-			if err := writeYAMLValue(w, subSlice, indent+1, false); err != nil {
+			if err := writeYAMLValue(w, subSlice, indent+1, unit); err != nil {
 				return err
 			}
 			// Add newline after nested structure unless it's the last field
@@ -149,23 +400,16 @@ func writeMapFields(w io.Writer, data map[string]any, prefix string, indent int)
 	return nil
 }
 
-func writeYAMLMap(w io.Writer, data map[string]any, indent int) error {
-	prefix := ""
-	for range indent {
-		prefix += "  "
-	}
-	return writeMapFields(w, data, prefix, indent)
+func writeYAMLMap(w io.Writer, data map[string]any, indent int, unit string) error {
+	return writeMapFields(w, data, strings.Repeat(unit, indent), indent, unit)
 }
 
-func writeYAMLValue(w io.Writer, data any, indent int, _ bool) error {
-	prefix := ""
-	for range indent {
-		prefix += "  "
-	}
+func writeYAMLValue(w io.Writer, data any, indent int, unit string) error {
+	prefix := strings.Repeat(unit, indent)
 
 	switch v := data.(type) {
 	case map[string]any:
-		return writeMapFields(w, v, prefix, indent)
+		return writeMapFields(w, v, prefix, indent, unit)
 	case []any:
 		for i, item := range v {
 			itemIsLast := i == len(v)-1
@@ -176,7 +420,7 @@ func writeYAMLValue(w io.Writer, data any, indent int, _ bool) error {
 				if _, err := fmt.Fprintln(w); err != nil {
 					return err
 				}
-				if err := writeYAMLValue(w, subMap, indent+1, false); err != nil {
+				if err := writeYAMLValue(w, subMap, indent+1, unit); err != nil {
 					return err
 				}
 				if !itemIsLast {
@@ -207,12 +451,25 @@ func writeYAMLValue(w io.Writer, data any, indent int, _ bool) error {
 
 // Factory functions for built-in formats
 
-// JSON returns a new JSON output format with optional --pretty flag.
+// JSON returns a new JSON output format with optional --pretty,
+// --json-null-optionals, --json-enum-numbers and --json-allow-special flags.
 func JSON() OutputFormat {
 	return &jsonFormat{}
 }
 
-// YAML returns a new YAML output format.
+// JSONColor returns a new "json-color" output format for humans at a
+// terminal: indented JSON with keys, strings, numbers and literals highlighted
+// in ANSI colors. It is rendered like the json format and reads the same
+// --json-null-optionals, --json-enum-numbers and --json-allow-special flags.
+// Whether output is highlighted follows the global --color flag, so by default
+// it is plain when not writing to a terminal or when the NO_COLOR env var is
+// set.
+func JSONColor() OutputFormat {
+	return &jsonColorFormat{terminal: isTerminal}
+}
+
+// YAML returns a new YAML output format with optional --yaml-flow,
+// --yaml-indent and --yaml-enum-numbers flags.
 func YAML() OutputFormat {
 	return &yamlFormat{}
 }
@@ -225,25 +482,43 @@ func Go() OutputFormat {
 // templateFormat renders proto messages using Go text templates.
 // Templates are keyed by fully qualified message type name.
 type templateFormat struct {
-	name      string
-	templates map[string]*template.Template // Parsed templates keyed by message type name
-	funcMap   template.FuncMap              // Custom template functions
+	name       string
+	templates  map[string]*template.Template // Parsed templates keyed by message type name
+	funcMap    template.FuncMap              // Custom template functions
+	cachedLast bool                          // Whether `last` reads the WithResponseCache cache of the root
 }
 
 func (f *templateFormat) Name() string {
 	return f.name
 }
 
-func (f *templateFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg proto.Message) error {
+func (f *templateFormat) Format(_ context.Context, cmd *cli.Command, w io.Writer, msg proto.Message) error {
 	// Get the fully qualified message type name
 	msgType := string(msg.ProtoReflect().Descriptor().FullName())
 
 	// Look up the template for this message type
 	tmpl, ok := f.templates[msgType]
 	if !ok {
+		// Fall back to a registered formatter for types without a template
+		if s, found, err := formatRegistered(msg); found || err != nil {
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, s)
+			return err
+		}
 		return fmt.Errorf("%w: %s (available: %v)", ErrNoTemplate, msgType, f.availableTypes())
 	}
 
+	// Bind `last` to the response cache of the root running this command
+	if cache := rootResponseCache(cmd); f.cachedLast && cache != nil {
+		clone, err := tmpl.Clone()
+		if err != nil {
+			return fmt.Errorf("failed to clone template for %s: %w", msgType, err)
+		}
+		tmpl = clone.Funcs(template.FuncMap{"last": cache.Field})
+	}
+
 	// Pass the proto message directly to templates
 	// Custom template functions receive actual proto types
 	// Templates can use the 'field' helper for field access or 'json' for JSON conversion
@@ -326,6 +601,9 @@ func DefaultTemplateFunctions() template.FuncMap {
 				if err != nil {
 					return nil
 				}
+				if jsonBytes, err = applyFormatters(m.ProtoReflect(), jsonBytes, ""); err != nil {
+					return nil
+				}
 
 				var result map[string]any
 				if err := json.Unmarshal(jsonBytes, &result); err != nil {
@@ -457,18 +735,23 @@ func getProtoField(msg proto.Message, fieldName string) any {
 //
 //	format := protocli.TemplateFormat("user-table", templates)
 func TemplateFormat(name string, templates map[string]string, funcMaps ...template.FuncMap) (OutputFormat, error) {
-	// Start with default functions
+	// Start with default functions. `last` is bound to the response cache of
+	// the root when the template runs, unless a function map replaces it.
 	funcMap := DefaultTemplateFunctions()
+	funcMap["last"] = lastWithoutResponseCache
+	cachedLast := true
 
 	// Merge global registry
 	for k, v := range globalTemplateFunctionRegistry.Functions() {
 		funcMap[k] = v
+		cachedLast = cachedLast && k != "last"
 	}
 
 	// Merge provided function maps (later maps override earlier ones)
 	for _, fm := range funcMaps {
 		for k, v := range fm {
 			funcMap[k] = v
+			cachedLast = cachedLast && k != "last"
 		}
 	}
 
@@ -483,9 +766,10 @@ func TemplateFormat(name string, templates map[string]string, funcMaps ...templa
 	}
 
 	return &templateFormat{
-		name:      name,
-		templates: parsed,
-		funcMap:   funcMap,
+		name:       name,
+		templates:  parsed,
+		funcMap:    funcMap,
+		cachedLast: cachedLast,
 	}, nil
 }
 
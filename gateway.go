@@ -0,0 +1,170 @@
+package protocli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// gatewayMarshaler renders transcoded HTTP responses with an output format for
+// clients that ask for its media type. Request bodies of that media type are
+// decoded with the input format of the same name, if any.
+type gatewayMarshaler struct {
+	cmd      *cli.Command // Command passed to the format, normally daemonize
+	format   MediaTypeOutputFormat
+	input    InputFormat       // nil decodes with fallback
+	fallback runtime.Marshaler // Values other than messages, e.g. stream chunk wrappers
+}
+
+// gatewayMarshalerOptions registers a gateway marshaler for the media type of
+// every format in formats that has one, and the Server-Sent Events marshaler.
+// JSON is left to the gateway's own marshaler.
+func gatewayMarshalerOptions(cmd *cli.Command, formats []OutputFormat) []runtime.ServeMuxOption {
+	fallback := &runtime.JSONPb{
+		MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+		UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}
+	opts := []runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(sseMediaType, &sseMarshaler{json: fallback}),
+	}
+	for _, f := range formats {
+		mf, ok := f.(MediaTypeOutputFormat)
+		if !ok || mf.MediaType() == "" {
+			continue
+		}
+		m := &gatewayMarshaler{cmd: cmd, format: mf, fallback: fallback}
+		for _, in := range DefaultInputFormats() {
+			if in.Name() == f.Name() {
+				m.input = in
+			}
+		}
+		opts = append(opts, runtime.WithMarshalerOption(mf.MediaType(), m))
+	}
+	return opts
+}
+
+func (m *gatewayMarshaler) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return m.fallback.Marshal(v)
+	}
+	var buf bytes.Buffer
+	// The Marshaler interface carries no request context
+	if err := FormatMessage(context.Background(), m.cmd, m.format, &buf, msg); err != nil {
+		return nil, fmt.Errorf("format failed: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (m *gatewayMarshaler) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok || m.input == nil {
+		return m.fallback.Unmarshal(data, v)
+	}
+	return m.input.Unmarshal(data, msg)
+}
+
+func (m *gatewayMarshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	return runtime.DecoderFunc(func(v any) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return m.Unmarshal(data, v)
+	})
+}
+
+func (m *gatewayMarshaler) NewEncoder(w io.Writer) runtime.Encoder {
+	return runtime.EncoderFunc(func(v any) error {
+		data, err := m.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+}
+
+func (m *gatewayMarshaler) ContentType(any) string {
+	return m.format.MediaType()
+}
+
+// sseMediaType is the media type of Server-Sent Events, requested by browser
+// EventSource clients.
+const sseMediaType = "text/event-stream"
+
+// sseMarshaler renders transcoded responses as Server-Sent Events for clients
+// that accept text/event-stream, so browsers can consume server-streaming
+// methods with EventSource. Each message becomes one event whose data is the
+// message as JSON, and a stream error becomes an "error" event holding the
+// status. Request bodies are decoded as JSON.
+type sseMarshaler struct {
+	json runtime.Marshaler
+}
+
+func (m *sseMarshaler) Marshal(v any) ([]byte, error) {
+	var event string
+	switch chunk := v.(type) {
+	case map[string]any:
+		// Streamed messages arrive wrapped as {"result": msg}
+		if result, ok := chunk["result"]; ok && len(chunk) == 1 {
+			v = result
+		}
+	case map[string]proto.Message:
+		// Stream errors arrive as {"error": status}
+		if st, ok := chunk["error"]; ok && len(chunk) == 1 {
+			event, v = "error", st
+		}
+	}
+	data, err := m.json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if event != "" {
+		buf.WriteString("event: " + event + "\n")
+	}
+	for line := range bytes.SplitSeq(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	// A blank line ends the event
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func (m *sseMarshaler) Unmarshal(data []byte, v any) error {
+	return m.json.Unmarshal(data, v)
+}
+
+func (m *sseMarshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	return m.json.NewDecoder(r)
+}
+
+func (m *sseMarshaler) NewEncoder(w io.Writer) runtime.Encoder {
+	return runtime.EncoderFunc(func(v any) error {
+		data, err := m.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	})
+}
+
+func (m *sseMarshaler) ContentType(any) string {
+	return sseMediaType
+}
+
+// Delimiter is empty because every event already ends with a blank line.
+func (m *sseMarshaler) Delimiter() []byte {
+	return []byte{}
+}
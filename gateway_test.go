@@ -0,0 +1,145 @@
+package protocli_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// serveUserRoute registers GET /v1/users/1 on the gateway mux the way generated
+// gateway handlers forward responses.
+func serveUserRoute(_ context.Context, _ *grpc.Server, mux *runtime.ServeMux) error {
+	return mux.HandlePath(http.MethodGet, "/v1/users/1", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		resp := &simple.UserResponse{User: &simple.User{Id: 1, Name: "Alice"}, Message: "found"}
+		runtime.ForwardResponseMessage(r.Context(), mux, outbound, w, r, resp)
+	})
+}
+
+// getWithAccept issues a GET with the given Accept header and returns the
+// response's content type and body.
+func getWithAccept(t *testing.T, url, accept string) (string, string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.Header.Get("Content-Type"), string(body)
+}
+
+// TestIntegration_Transcoding_NegotiatesOutputFormat verifies the gateway
+// renders responses with the output format whose media type the client accepts.
+func TestIntegration_Transcoding_NegotiatesOutputFormat(t *testing.T) {
+	startProbedDaemon(t, []string{"--port", "50210"},
+		protocli.WithTranscoding(50211),
+		protocli.WithOutputFormats(protocli.JSON(), protocli.YAML()),
+		protocli.OnDaemonStartup(serveUserRoute),
+	)
+
+	contentType, body := getWithAccept(t, "http://127.0.0.1:50211/v1/users/1", "application/yaml")
+	assert.Equal(t, "application/yaml", contentType)
+	assert.Contains(t, body, "name: Alice")
+	assert.Contains(t, body, "message: found")
+
+	contentType, body = getWithAccept(t, "http://127.0.0.1:50211/v1/users/1", "")
+	assert.Equal(t, "application/json", contentType, "JSON stays the default")
+	assert.Contains(t, body, `"name":"Alice"`)
+}
+
+// serveUserStreamRoute registers GET /v1/users:watch on the gateway mux the way
+// generated gateway handlers forward server-streaming responses: it streams
+// users 1-3, then fails with UNAVAILABLE.
+func serveUserStreamRoute(_ context.Context, _ *grpc.Server, mux *runtime.ServeMux) error {
+	return mux.HandlePath(http.MethodGet, "/v1/users:watch", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		ctx := runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{})
+		var sent int64
+		recv := func() (proto.Message, error) {
+			if sent == 3 {
+				return nil, status.Error(codes.Unavailable, "server going away")
+			}
+			sent++
+			return &simple.UserResponse{User: &simple.User{Id: sent}}, nil
+		}
+		runtime.ForwardResponseStream(ctx, mux, outbound, w, r, recv)
+	})
+}
+
+// sseEvent is a parsed Server-Sent Event.
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readSSEEvents reads the events of a text/event-stream body until it ends.
+func readSSEEvents(t *testing.T, body io.Reader) []sseEvent {
+	t.Helper()
+	var events []sseEvent
+	var current sseEvent
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			events = append(events, current)
+			current = sseEvent{}
+		case strings.HasPrefix(line, "event: "):
+			current.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data += strings.TrimPrefix(line, "data: ")
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+// TestIntegration_Transcoding_ServerSentEvents verifies that server-streaming
+// routes answer EventSource clients with one JSON event per message and an
+// error event when the stream fails.
+func TestIntegration_Transcoding_ServerSentEvents(t *testing.T) {
+	startProbedDaemon(t, []string{"--port", "50216"},
+		protocli.WithTranscoding(50217),
+		protocli.OnDaemonStartup(serveUserStreamRoute),
+	)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://127.0.0.1:50217/v1/users:watch", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := readSSEEvents(t, resp.Body)
+	require.Len(t, events, 4)
+	for i, event := range events[:3] {
+		assert.Empty(t, event.name)
+		user := &simple.UserResponse{}
+		require.NoError(t, protojson.Unmarshal([]byte(event.data), user), event.data)
+		assert.Equal(t, int64(i+1), user.GetUser().GetId())
+	}
+	assert.Equal(t, "error", events[3].name)
+	assert.Contains(t, events[3].data, "server going away")
+}
@@ -1,12 +1,15 @@
 package protocli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/urfave/cli/v3"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v3"
@@ -82,6 +85,8 @@ func DefaultInputFormats() []InputFormat {
 //  1. If formatName is non-empty, find the format by name. Error if not found.
 //  2. Match filepath.Ext(filePath) against each format's Extensions(). Use first match.
 //  3. Try all formats in order, use the first one that succeeds. If all fail, return a combined error.
+//
+// Keys registered with RegisterFieldAlias are renamed before unmarshaling.
 func ReadInputFile(filePath, formatName string, formats []InputFormat, msg proto.Message) error {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -92,7 +97,7 @@ func ReadInputFile(filePath, formatName string, formats []InputFormat, msg proto
 	if formatName != "" {
 		for _, f := range formats {
 			if f.Name() == formatName {
-				if err := f.Unmarshal(data, msg); err != nil {
+				if err := unmarshalInput(f, data, msg); err != nil {
 					return fmt.Errorf("failed to unmarshal input file %s as %s: %w", filePath, formatName, err)
 				}
 				return nil
@@ -110,7 +115,7 @@ func ReadInputFile(filePath, formatName string, formats []InputFormat, msg proto
 	for _, f := range formats {
 		for _, fExt := range f.Extensions() {
 			if ext == fExt {
-				if err := f.Unmarshal(data, msg); err != nil {
+				if err := unmarshalInput(f, data, msg); err != nil {
 					return fmt.Errorf("failed to unmarshal input file %s as %s: %w", filePath, f.Name(), err)
 				}
 				return nil
@@ -123,7 +128,7 @@ func ReadInputFile(filePath, formatName string, formats []InputFormat, msg proto
 	for _, f := range formats {
 		// Reset the message before each attempt
 		proto.Reset(msg)
-		if err := f.Unmarshal(data, msg); err != nil {
+		if err := unmarshalInput(f, data, msg); err != nil {
 			errs = append(errs, fmt.Sprintf("%s: %v", f.Name(), err))
 			continue
 		}
@@ -132,3 +137,70 @@ func ReadInputFile(filePath, formatName string, formats []InputFormat, msg proto
 
 	return fmt.Errorf("failed to unmarshal input file %s: no format matched (tried: %s)", filePath, strings.Join(errs, "; "))
 }
+
+// ReadInputFileList reads the JSON or YAML array in the file at filePath and
+// returns one message per element. Each element is unmarshaled into a message
+// from newElem by the format matching the file's extension, or by the first
+// format that accepts it. Generated commands call this for repeated message
+// flags given as @path when no flag deserializer is registered.
+func ReadInputFileList[T proto.Message](filePath string, formats []InputFormat, newElem func() T) ([]T, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input file %s: %w", filePath, err)
+	}
+	// YAML is a superset of JSON, so this parses both
+	var elements []any
+	if err := yaml.Unmarshal(data, &elements); err != nil {
+		return nil, fmt.Errorf("input file %s must hold a JSON or YAML array: %w", filePath, err)
+	}
+
+	candidates := formats
+	ext := strings.ToLower(filepath.Ext(filePath))
+	for _, f := range formats {
+		if slices.Contains(f.Extensions(), ext) {
+			candidates = []InputFormat{f}
+			break
+		}
+	}
+
+	msgs := make([]T, 0, len(elements))
+	for i, element := range elements {
+		if hasFieldAliases() {
+			renameFieldAliases(element, newElem().ProtoReflect().Descriptor())
+		}
+		// Elements are passed on as JSON, which both built-in formats read
+		elemData, err := json.Marshal(element)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert element %d of %s to JSON: %w", i, filePath, err)
+		}
+		var errs []string
+		for _, f := range candidates {
+			msg := newElem()
+			if err := f.Unmarshal(elemData, msg); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", f.Name(), err))
+				continue
+			}
+			msgs = append(msgs, msg)
+			break
+		}
+		if len(msgs) <= i {
+			return nil, fmt.Errorf("failed to unmarshal element %d of %s (tried: %s)", i, filePath, strings.Join(errs, "; "))
+		}
+	}
+	return msgs, nil
+}
+
+// ShowInput writes req to stderr in the --format output format when
+// --show-input is set, so the result of merging --input-file with flag
+// overrides can be checked. Unlike a dry run, the method is still invoked;
+// generated commands call this just before sending the request.
+func ShowInput(ctx context.Context, cmd *cli.Command, formats []OutputFormat, req proto.Message) error {
+	if !cmd.Bool("show-input") {
+		return nil
+	}
+	w := cmd.Root().ErrWriter
+	if w == nil {
+		w = os.Stderr
+	}
+	return WriteFormatted(ctx, cmd, w, formats, cmd.String("format"), req)
+}
@@ -134,6 +134,32 @@ func TestIntegration_HoistedService_FlatCommandStructure(t *testing.T) {
 	assert.False(t, commandNames["user-service"], "user-service nested command should not exist when hoisted")
 }
 
+// TestIntegration_HoistedService_MultipleServices tests that hoisting two
+// services doesn't collide on the formats and validate commands, which
+// RootCommand adds once for all services.
+func TestIntegration_HoistedService_MultipleServices(t *testing.T) {
+	ctx := context.Background()
+
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(simple.UserServiceCommand(ctx, &simple.UnimplementedUserServiceServer{}), protocli.Hoisted()),
+		protocli.Service(simple.AdminServiceCommand(ctx, &simple.UnimplementedAdminServiceServer{}), protocli.Hoisted()),
+	)
+	require.NoError(t, err)
+
+	counts := make(map[string]int)
+	for _, cmd := range rootCmd.Commands {
+		counts[cmd.Name]++
+	}
+	assert.Equal(t, 1, counts["formats"])
+	assert.Equal(t, 1, counts["validate"])
+
+	var validated []string
+	for _, cmd := range rootCmd.Command("validate").Commands {
+		validated = append(validated, cmd.Name)
+	}
+	assert.Equal(t, []string{"user-service", "admin"}, validated, "validate is nested by service")
+}
+
 // TestHoistedService_NamingCollision tests that naming collisions return an error.
 func TestIntegration_HoistedService_NamingCollision(t *testing.T) {
 	ctx := context.Background()
@@ -154,6 +180,20 @@ func TestIntegration_HoistedService_NamingCollision(t *testing.T) {
 		"error message should mention collision: %s", err.Error())
 }
 
+// TestIntegration_RootFlags_BuiltinConflict tests that a proto-declared root flag
+// clashing with a framework flag is rejected.
+func TestIntegration_RootFlags_BuiltinConflict(t *testing.T) {
+	ctx := context.Background()
+
+	adminServiceCLI := simple.AdminServiceCommand(ctx, &simple.UnimplementedAdminServiceServer{})
+	adminServiceCLI.RootFlags = append(adminServiceCLI.RootFlags, &cli.StringFlag{Name: "verbosity"})
+
+	_, err := protocli.RootCommand("testcli", protocli.Service(adminServiceCLI))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, protocli.ErrRootFlagConflict)
+	assert.Contains(t, err.Error(), "--verbosity")
+}
+
 // TestHoistedService_DaemonizeCollision tests that 'daemonize' collision is detected.
 func TestIntegration_HoistedService_DaemonizeCollision(t *testing.T) {
 	// This test would require a service with an RPC named "daemonize" to properly test
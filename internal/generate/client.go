@@ -0,0 +1,28 @@
+package generate
+
+import (
+	"github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateCLIClient generates New<Service>CLIClient, which dials the remote
+// the way the CLI would and wraps the connection in the typed gRPC client
+func generateCLIClient(f *jen.File, service *protogen.Service) {
+	funcName := "New" + service.GoName + "CLIClient"
+	clientType := service.GoName + "Client"
+
+	f.Commentf("%s dials the remote given by WithRemote or resolved from a", funcName)
+	f.Commentf("command's --remote and --context flags (WithRemoteCommand), applying TLS,")
+	f.Commentf("retry and auth options, and returns the %s. The caller must close the connection.", clientType)
+	f.Func().Id(funcName).Params(
+		jen.Id("opts").Op("...").Qual("github.com/drewfead/proto-cli", "ClientOption"),
+	).Params(jen.Id(clientType), jen.Op("*").Qual("google.golang.org/grpc", "ClientConn"), jen.Error()).Block(
+		jen.List(jen.Id("conn"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "DialRemote").Call(
+			jen.Id("opts").Op("..."),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Nil(), jen.Nil(), jen.Err()),
+		),
+		jen.Return(jen.Id("New"+clientType).Call(jen.Id("conn")), jen.Id("conn"), jen.Nil()),
+	)
+}
@@ -0,0 +1,409 @@
+package generate
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	cobraPkg    = "github.com/spf13/cobra"
+	cobraCLIPkg = "github.com/drewfead/proto-cli/contrib/cobracli"
+)
+
+// generateCobraServiceCLI emits <Service>CobraCommand, which builds a cobra command
+// tree for the service. Request building, validation and output formatting are
+// shared with the urfave/cli backend; cobracli adapts cobra's flags to that runtime.
+// Streaming methods, lifecycle hooks and the TUI are only supported by the urfave/cli backend.
+func generateCobraServiceCLI(f *jen.File, file *protogen.File, service *protogen.Service, genOpts Options) {
+	funcName := service.GoName + "CobraCommand"
+
+	var configMessageType string
+	if configOpts := getServiceConfigOptions(service); configOpts != nil {
+		configMessageType = configOpts.ConfigMessage
+	}
+
+	serviceName := toKebabCase(service.GoName)
+	serviceDescription := stripServiceSuffix(service.GoName) + " commands"
+	var serviceLongDescription string
+	serviceOpts := getServiceOptions(service)
+	if serviceOpts.GetName() != "" {
+		serviceName = serviceOpts.GetName()
+	}
+	if serviceOpts.GetDescription() != "" {
+		serviceDescription = serviceOpts.GetDescription()
+	} else if comment := cleanProtoComment(service.Comments.Leading); comment != "" {
+		serviceDescription = firstLine(comment)
+	}
+	serviceLongDescription = serviceOpts.GetLongDescription()
+
+	serviceCmdDict := jen.Dict{
+		jen.Id("Use"):   jen.Lit(serviceName),
+		jen.Id("Short"): jen.Lit(serviceDescription),
+		jen.Id("Annotations"): jen.Map(jen.String()).String().Values(jen.Dict{
+			jen.Qual("github.com/drewfead/proto-cli", "ServiceMetadataKey"): jen.Lit(serviceName),
+		}),
+	}
+	if serviceLongDescription != "" {
+		serviceCmdDict[jen.Id("Long")] = jen.Lit(serviceLongDescription)
+	}
+	if aliases := serviceOpts.GetAliases(); len(aliases) > 0 {
+		serviceCmdDict[jen.Id("Aliases")] = aliasesCode(aliases)
+	}
+
+	statements := []jen.Code{
+		jen.Id("options").Op(":=").Qual("github.com/drewfead/proto-cli", "ApplyServiceOptions").Call(jen.Id("opts").Op("...")),
+		jen.Line(),
+		jen.Comment("Determine default format (first registered format, or empty if none)"),
+		jen.Var().Id("defaultFormat").String(),
+		jen.If(jen.Len(jen.Id("options").Dot("OutputFormats").Call()).Op(">").Lit(0)).Block(
+			jen.Id("defaultFormat").Op("=").Id("options").Dot("OutputFormats").Call().Index(jen.Lit(0)).Dot("Name").Call(),
+		),
+		jen.Line(),
+		jen.Id("serviceCmd").Op(":=").Op("&").Qual(cobraPkg, "Command").Values(serviceCmdDict),
+		jen.Line(),
+	}
+
+	var groups []string
+	for _, method := range orderedMethods(service) {
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			// Streaming commands are only generated for the urfave/cli backend
+			continue
+		}
+		// Add each group command before its first method
+		if group := methodCommandGroup(service, method); group != "" && !slices.Contains(groups, group) {
+			groups = append(groups, group)
+			statements = append(statements,
+				jen.Id("group_"+groupIdent(group)).Op(":=").Op("&").Qual(cobraPkg, "Command").Values(jen.Dict{
+					jen.Id("Use"):   jen.Lit(group),
+					jen.Id("Short"): jen.Lit(toTitleCase(group) + " commands"),
+				}),
+				jen.Id("serviceCmd").Dot("AddCommand").Call(jen.Id("group_"+groupIdent(group))),
+				jen.Line(),
+			)
+		}
+		statements = append(statements, generateCobraMethodCommand(file, service, method, configMessageType, genOpts)...)
+	}
+
+	statements = append(statements, jen.Return(jen.Id("serviceCmd")))
+
+	f.Commentf("%s creates a cobra command tree for %s with options", funcName, service.GoName)
+	f.Commentf("The implOrFactory parameter can be either a direct service implementation or a factory function")
+	f.Func().Id(funcName).Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("implOrFactory").Interface(),
+		jen.Id("opts").Op("...").Qual("github.com/drewfead/proto-cli", "ServiceOption"),
+	).Op("*").Qual(cobraPkg, "Command").Block(statements...)
+	f.Line()
+}
+
+// generateCobraMethodCommand returns statements that build the cobra command for a
+// unary method and register it on serviceCmd.
+func generateCobraMethodCommand(file *protogen.File, service *protogen.Service, method *protogen.Method, configMessageType string, genOpts Options) []jen.Code {
+	cmdName := toKebabCase(method.GoName)
+	cmdUsage := method.GoName
+	cmdOpts := getMethodCommandOptions(method)
+	if cmdOpts.GetName() != "" {
+		cmdName = cmdOpts.GetName()
+	}
+	if cmdOpts.GetDescription() != "" {
+		cmdUsage = cmdOpts.GetDescription()
+	} else if comment := cleanProtoComment(method.Comments.Leading); comment != "" {
+		cmdUsage = firstLine(comment)
+	}
+	localOnly := cmdOpts.GetLocalOnly()
+
+	cmdVar := "cmd_" + strings.ReplaceAll(cmdName, "-", "_")
+
+	// Grouped methods are added to their group command
+	parentVar := "serviceCmd"
+	if group := methodCommandGroup(service, method); group != "" {
+		parentVar = "group_" + groupIdent(group)
+	}
+
+	// Passthrough methods take positional arguments into the request
+	argsValidator, argsParam := jen.Qual(cobraPkg, "NoArgs"), jen.Id("_")
+	if passthroughField(method) != nil {
+		argsValidator, argsParam = jen.Qual(cobraPkg, "ArbitraryArgs"), jen.Id("args")
+	}
+
+	cmdDict := jen.Dict{
+		jen.Id("Use"):   jen.Lit(cmdName),
+		jen.Id("Short"): jen.Lit(cmdUsage),
+		jen.Id("Args"):  argsValidator,
+	}
+	if cmdOpts.GetLongDescription() != "" {
+		cmdDict[jen.Id("Long")] = jen.Lit(cmdOpts.GetLongDescription())
+	} else if long := commentDescription(method.Comments.Leading); long != "" {
+		cmdDict[jen.Id("Long")] = jen.Lit(long)
+	}
+	if cmdOpts.GetHidden() {
+		cmdDict[jen.Id("Hidden")] = jen.True()
+	}
+	if aliases := methodCommandAliases(service, method); len(aliases) > 0 {
+		cmdDict[jen.Id("Aliases")] = aliasesCode(aliases)
+	}
+	if cmdOpts.GetDeprecated() != "" {
+		cmdDict[jen.Id("Deprecated")] = jen.Lit(cmdOpts.GetDeprecated())
+	}
+	if cmdOpts.GetConfirm() {
+		cmdDict[jen.Id("PreRunE")] = jen.Qual(cobraCLIPkg, "ConfirmPreRun")
+	}
+
+	flags := jen.Id(cmdVar).Dot("Flags").Call()
+	statements := []jen.Code{
+		jen.Comment("Build command for " + cmdName),
+		jen.Id(cmdVar).Op(":=").Op("&").Qual(cobraPkg, "Command").Values(cmdDict),
+	}
+	if !localOnly {
+		statements = append(statements,
+			jen.Add(flags).Dot("String").Call(jen.Lit("remote"), jen.Lit(""), jen.Lit("Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call")),
+		)
+	}
+	statements = append(statements,
+		jen.Add(flags).Dot("String").Call(jen.Lit("format"), jen.Id("defaultFormat"), jen.Lit("Output format (use --format to see available formats)")),
+		jen.Add(flags).Dot("String").Call(jen.Lit("output"), jen.Lit("-"), jen.Lit("Output file (- for stdout)")),
+		jen.Add(flags).Dot("String").Call(jen.Lit("output-mode"), jen.Qual("github.com/drewfead/proto-cli", "DefaultOutputFileMode"), jen.Lit("Octal permissions for a file created by --output")),
+		jen.Add(flags).Dot("Bool").Call(jen.Lit("output-append"), jen.False(), jen.Lit("Append to the --output file instead of truncating it")),
+		jen.Add(flags).Dot("StringSlice").Call(jen.Lit("fields-exclude"), jen.Nil(), jen.Lit("Drop these field paths (e.g. user.address.city) from the response before formatting")),
+		jen.Add(flags).Dot("String").Call(jen.Lit("input-file"), jen.Lit(""), jen.Lit(inputFileUsage(method))),
+		jen.Add(flags).Dot("String").Call(jen.Lit("input-format"), jen.Lit(""), jen.Lit("Input file format (auto-detected from extension if not set)")),
+		jen.Add(flags).Dot("Bool").Call(jen.Lit("show-input"), jen.False(), jen.Lit("Print the request to stderr in the selected format before sending it")),
+	)
+	if cmdOpts.GetConfirm() {
+		statements = append(statements, jen.Add(flags).Dot("Bool").Call(jen.Lit("yes"), jen.False(), jen.Lit(confirmFlagUsage)))
+	}
+	for _, field := range method.Input.Fields {
+		statements = append(statements, generateCobraFlag(cmdVar, field, genOpts)...)
+	}
+	statements = append(statements,
+		jen.Qual(cobraCLIPkg, "AddFormatFlags").Call(jen.Id(cmdVar), jen.Id("options").Dot("OutputFormats").Call()),
+		jen.Line(),
+		jen.Id(cmdVar).Dot("RunE").Op("=").Func().Params(
+			jen.Id("c").Op("*").Qual(cobraPkg, "Command"),
+			argsParam.Index().String(),
+		).Error().Block(
+			generateCobraRunBody(file, service, method, configMessageType, localOnly, genOpts)...,
+		),
+		jen.Id(parentVar).Dot("AddCommand").Call(jen.Id(cmdVar)),
+		jen.Line(),
+	)
+	return statements
+}
+
+// generateCobraFlag returns statements registering the pflag for a request field.
+// Flag types mirror the urfave/cli backend so the shared request builder can read them.
+func generateCobraFlag(cmdVar string, field *protogen.Field, genOpts Options) []jen.Code {
+	kind := field.Desc.Kind()
+	flagName := genOpts.fieldFlagName(field)
+	flagOpts := getFieldFlagOptions(field)
+
+	// pflag shorthands are limited to a single character
+	var shorthand string
+	if len(flagOpts.GetShorthand()) == 1 {
+		shorthand = flagOpts.GetShorthand()
+	}
+
+	var method string
+	var defaultValue jen.Code
+	ft, scalar := scalarFlagTypes[kind]
+	switch {
+	case field.Desc.IsList() && scalar:
+		method = ft.SliceAccessor + "P"
+		if ft.SliceAccessor == "Uint32Slice" || ft.SliceAccessor == "Uint64Slice" {
+			method = "UintSliceP"
+		}
+		defaultValue = jen.Nil()
+	case field.Desc.IsList() && kind == protoreflect.MessageKind:
+		method = "StringSliceP"
+		defaultValue = jen.Nil()
+	case scalar:
+		method = ft.SingularAccessor + "P"
+		defaultValue = defaultValueCode(kind, fieldDefaultValue(field))
+		if defaultValue == nil {
+			switch kind {
+			case protoreflect.BoolKind:
+				defaultValue = jen.False()
+			case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.EnumKind:
+				defaultValue = jen.Lit("")
+			default:
+				defaultValue = jen.Lit(0)
+			}
+		}
+	case kind == protoreflect.MessageKind:
+		method = "StringP"
+		defaultValue = jen.Lit(fieldDefaultValue(field))
+	default:
+		return nil
+	}
+
+	statements := []jen.Code{
+		jen.Id(cmdVar).Dot("Flags").Call().Dot(method).Call(
+			jen.Lit(flagName), jen.Lit(shorthand), defaultValue, jen.Lit(fieldFlagUsage(field)),
+		),
+	}
+	if flagOpts.GetRequired() {
+		statements = append(statements,
+			jen.Id("_").Op("=").Id(cmdVar).Dot("MarkFlagRequired").Call(jen.Lit(flagName)),
+		)
+	}
+	if msg := flagOpts.GetDeprecated(); msg != "" {
+		statements = append(statements,
+			jen.Id("_").Op("=").Id(cmdVar).Dot("Flags").Call().Dot("MarkDeprecated").Call(jen.Lit(flagName), jen.Lit(msg)),
+		)
+	}
+	return statements
+}
+
+// generateCobraRunBody returns the body of a cobra RunE function for a unary method.
+func generateCobraRunBody(file *protogen.File, service *protogen.Service, method *protogen.Method, configMessageType string, localOnly bool, genOpts Options) []jen.Code {
+	statements := []jen.Code{
+		jen.Id("cmdCtx").Op(":=").Id("c").Dot("Context").Call(),
+		jen.Id("cmd").Op(":=").Qual(cobraCLIPkg, "Flags").Call(jen.Id("c")),
+		jen.Line(),
+	}
+
+	statements = append(statements, generateRequestBuild(file, service, method, genOpts)...)
+	statements = append(statements, generatePassthroughAssignment(method, jen.Id("args"))...)
+	statements = append(statements, generateFieldConstraintChecks(service, method, genOpts)...)
+	statements = append(statements, generateFieldsExcludeCheck(file, method))
+	statements = append(statements,
+		jen.If(
+			jen.Err().Op(":=").Qual(cobraCLIPkg, "ShowInput").Call(
+				jen.Id("cmdCtx"), jen.Id("c"), jen.Id("options").Dot("OutputFormats").Call(), jen.Id("req"),
+			),
+			jen.Err().Op("!=").Nil(),
+		).Block(jen.Return(jen.Err())),
+		jen.Line(),
+	)
+
+	statements = append(statements,
+		jen.Var().Id("resp").Op("*").Id(method.Output.GoIdent.GoName),
+		jen.Var().Err().Error(),
+	)
+	localCall := generateCobraLocalCallLogic(service, method, configMessageType)
+	if localOnly {
+		statements = append(statements, jen.Comment("Local-only command: always use direct implementation call"))
+		statements = append(statements, localCall...)
+	} else {
+		statements = append(statements,
+			jen.If(jen.Id("remoteAddr").Op(":=").Id("cmd").Dot("String").Call(jen.Lit("remote")), jen.Id("remoteAddr").Op("!=").Lit("")).Block(
+				jen.Comment("Remote gRPC call"),
+				jen.List(jen.Id("conn"), jen.Id("connErr")).Op(":=").Qual("google.golang.org/grpc", "NewClient").Call(
+					jen.Id("remoteAddr"),
+					jen.Qual("google.golang.org/grpc", "WithTransportCredentials").Call(
+						jen.Qual("google.golang.org/grpc/credentials/insecure", "NewCredentials").Call(),
+					),
+				),
+				jen.If(jen.Id("connErr").Op("!=").Nil()).Block(
+					jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to connect to remote %s: %w"), jen.Id("remoteAddr"), jen.Id("connErr"))),
+				),
+				jen.Defer().Id("conn").Dot("Close").Call(),
+				jen.List(jen.Id("resp"), jen.Err()).Op("=").Id("New"+service.GoName+"Client").Call(jen.Id("conn")).Dot(method.GoName).Call(
+					jen.Id("cmdCtx"), jen.Id("req"),
+				),
+				jen.If(jen.Err().Op("!=").Nil()).Block(
+					jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("remote call failed: %w"), jen.Err())),
+				),
+			).Else().Block(localCall...),
+		)
+	}
+	statements = append(statements, jen.Line())
+	statements = append(statements, generateFieldsExclude("resp", "="), jen.Line())
+
+	statements = append(statements,
+		jen.Comment("Open output writer"),
+		jen.List(jen.Id("outputWriter"), jen.Err()).Op(":=").Qual(cobraCLIPkg, "OutputWriter").Call(
+			jen.Id("c"),
+			jen.Id("cmd").Dot("String").Call(jen.Lit("output")),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to open output: %w"), jen.Err())),
+		),
+		jen.If(jen.Id("closer").Op(",").Id("ok").Op(":=").Id("outputWriter").Assert(jen.Qual("io", "Closer")), jen.Id("ok")).Block(
+			jen.Defer().Id("closer").Dot("Close").Call(),
+		),
+		jen.Line(),
+		jen.Comment("Expose format-specific flags (e.g. --pretty) to the output format"),
+		jen.List(jen.Id("formatCmd"), jen.Err()).Op(":=").Qual(cobraCLIPkg, "FormatCommand").Call(
+			jen.Id("cmdCtx"), jen.Id("c"), jen.Id("options").Dot("OutputFormats").Call(),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())),
+		jen.Line(),
+		jen.Comment("Render the response with the selected output format"),
+		jen.Return(jen.Qual("github.com/drewfead/proto-cli", "WriteFormatted").Call(
+			jen.Id("cmdCtx"),
+			jen.Id("formatCmd"),
+			jen.Id("outputWriter"),
+			jen.Id("options").Dot("OutputFormats").Call(),
+			jen.Id("cmd").Dot("String").Call(jen.Lit("format")),
+			jen.Id("resp"),
+		)),
+	)
+	return statements
+}
+
+// generateCobraLocalCallLogic mirrors generateLocalCallLogic, loading service config
+// through cobracli instead of urfave/cli root flags.
+func generateCobraLocalCallLogic(service *protogen.Service, method *protogen.Method, configMessageType string) []jen.Code {
+	var statements []jen.Code
+	svcImpl := jen.Id("implOrFactory")
+	if configMessageType != "" {
+		statements = append(statements,
+			jen.Comment("Load config and create service implementation"),
+			jen.Id("config").Op(":=").Op("&").Id(configMessageType).Values(),
+			jen.If(
+				jen.Err().Op(":=").Qual(cobraCLIPkg, "LoadServiceConfig").Call(
+					jen.Id("c"), jen.Lit(strings.ToLower(service.GoName)), jen.Id("config"),
+				),
+				jen.Err().Op("!=").Nil(),
+			).Block(
+				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to load config: %w"), jen.Err())),
+			),
+			jen.List(jen.Id("svcImpl"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "CallFactory").Call(
+				jen.Id("implOrFactory"), jen.Id("config"),
+			),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to create service: %w"), jen.Err())),
+			),
+		)
+		svcImpl = jen.Id("svcImpl")
+	}
+	statements = append(statements,
+		jen.List(jen.Id("resp"), jen.Err()).Op("=").Add(svcImpl).Assert(jen.Id(service.GoName+"Server")).Dot(method.GoName).Call(
+			jen.Id("cmdCtx"), jen.Id("req"),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("method failed: %w"), jen.Err())),
+		),
+	)
+	return statements
+}
+
+// generateAllServicesCobraCLI is the cobra counterpart of generateAllServicesCLI.
+func generateAllServicesCobraCLI(f *jen.File, file *protogen.File) {
+	params := []jen.Code{
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("appName").String(),
+	}
+	var serviceCmds []jen.Code
+	for _, service := range file.Services {
+		implParam := toLowerCamelCase(service.GoName) + "Impl"
+		params = append(params, jen.Id(implParam).Interface())
+		serviceCmds = append(serviceCmds,
+			jen.Id(service.GoName+"CobraCommand").Call(jen.Id("ctx"), jen.Id(implParam), jen.Id("opts").Op("...")),
+		)
+	}
+	params = append(params, jen.Id("opts").Op("...").Qual("github.com/drewfead/proto-cli", "ServiceOption"))
+
+	f.Comment("BuildAllServicesCLI creates a cobra root command with every service in this file registered.")
+	f.Comment("Each impl parameter can be either a direct service implementation or a factory function.")
+	f.Comment("The service options are applied to every service.")
+	f.Func().Id("BuildAllServicesCLI").Params(params...).Op("*").Qual(cobraPkg, "Command").Block(
+		jen.Return(jen.Qual(cobraCLIPkg, "RootCommand").Call(append([]jen.Code{jen.Id("appName")}, serviceCmds...)...)),
+	)
+	f.Line()
+}
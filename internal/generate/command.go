@@ -3,9 +3,11 @@ package generate
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
+	annotations "github.com/drewfead/proto-cli/proto/cli/v1"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
@@ -37,7 +39,7 @@ func generateAfterHooksDefer() jen.Code {
 	).Call()
 }
 
-func generateMethodCommand(service *protogen.Service, method *protogen.Method, configMessageType string, file *protogen.File) []jen.Code {
+func generateMethodCommand(service *protogen.Service, method *protogen.Method, configMessageType string, file *protogen.File, genOpts Options) []jen.Code {
 	var statements []jen.Code
 
 	// Get command name and help fields from annotation or use defaults
@@ -45,7 +47,7 @@ func generateMethodCommand(service *protogen.Service, method *protogen.Method, c
 	cmdUsage := method.GoName                             // Short description for Usage field
 	var cmdDescription, cmdUsageText, cmdArgsUsage string // Long description, custom usage line, args
 
-	var localOnly bool
+	var localOnly, hidden bool
 
 	// Determine whether the parent service is TUI-enabled (for --interactive flag generation).
 	serviceOpts := getServiceOptions(service)
@@ -81,6 +83,8 @@ func generateMethodCommand(service *protogen.Service, method *protogen.Method, c
 		}
 		// Check if command should always run locally
 		localOnly = cmdOpts.GetLocalOnly()
+		// Hidden commands are omitted from help but remain invocable
+		hidden = cmdOpts.GetHidden()
 	}
 
 	// Fallback to proto source comment if no annotation provided a description
@@ -89,6 +93,12 @@ func generateMethodCommand(service *protogen.Service, method *protogen.Method, c
 			cmdUsage = firstLine(comment)
 		}
 	}
+	if cmdDescription == "" {
+		cmdDescription = commentDescription(method.Comments.Leading)
+	}
+	if msg := cmdOpts.GetDeprecated(); msg != "" {
+		cmdUsage = fmt.Sprintf("%s (deprecated: %s)", cmdUsage, msg)
+	}
 
 	// Create a safe variable name (replace hyphens with underscores)
 	cmdVarName := strings.ReplaceAll(cmdName, "-", "_")
@@ -105,14 +115,31 @@ func generateMethodCommand(service *protogen.Service, method *protogen.Method, c
 			jen.Id("Value"): jen.Lit("-"),
 			jen.Id("Usage"): jen.Lit("Output file (- for stdout)"),
 		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("output-mode"),
+			jen.Id("Value"): jen.Qual("github.com/drewfead/proto-cli", "DefaultOutputFileMode"),
+			jen.Id("Usage"): jen.Lit("Octal permissions for a file created by --output"),
+		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("output-append"),
+			jen.Id("Usage"): jen.Lit("Append to the --output file instead of truncating it"),
+		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringSliceFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("fields-exclude"),
+			jen.Id("Usage"): jen.Lit("Drop these field paths (e.g. user.address.city) from the response before formatting"),
+		}),
 		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
 			jen.Id("Name"):  jen.Lit("input-file"),
-			jen.Id("Usage"): jen.Lit("Read request from file (JSON or YAML). CLI flags override file values"),
+			jen.Id("Usage"): jen.Lit(inputFileUsage(method)),
 		}),
 		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
 			jen.Id("Name"):  jen.Lit("input-format"),
 			jen.Id("Usage"): jen.Lit("Input file format (auto-detected from extension if not set)"),
 		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("show-input"),
+			jen.Id("Usage"): jen.Lit("Print the request to stderr in the selected format before sending it"),
+		}),
 	}
 	if !localOnly {
 		initialFlags = append([]jen.Code{
@@ -122,6 +149,27 @@ func generateMethodCommand(service *protogen.Service, method *protogen.Method, c
 			}),
 		}, initialFlags...)
 	}
+	initialFlags = append(initialFlags,
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("get"),
+			jen.Id("Usage"): jen.Lit("Print only the value at this JSON pointer of the response (RFC 6901, e.g. /user/email)"),
+		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("echo-request"),
+			jen.Id("Usage"): jen.Lit("Wrap the output in an envelope holding both the request and the response (JSON or YAML formats)"),
+		}),
+	)
+	if !localOnly {
+		initialFlags = append(initialFlags,
+			jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+				jen.Id("Name"):  jen.Lit("compression"),
+				jen.Id("Usage"): jen.Lit("Compress remote calls with this gRPC compressor (gzip, or none)"),
+			}),
+		)
+	}
+	if cmdOpts.GetConfirm() {
+		initialFlags = append(initialFlags, confirmFlag())
+	}
 	statements = append(statements,
 		jen.Comment("Build flags for "+cmdName),
 		jen.Id("flags_"+cmdVarName).Op(":=").Index().Qual("github.com/urfave/cli/v3", "Flag").Values(initialFlags...),
@@ -130,7 +178,7 @@ func generateMethodCommand(service *protogen.Service, method *protogen.Method, c
 
 	// Add request field flags
 	for _, field := range method.Input.Fields {
-		flagCode := generateFlag(field)
+		flagCode := generateFlag(field, genOpts)
 		if flagCode != nil {
 			statements = append(statements,
 				jen.Id("flags_"+cmdVarName).Op("=").Append(jen.Id("flags_"+cmdVarName), flagCode),
@@ -145,21 +193,9 @@ func generateMethodCommand(service *protogen.Service, method *protogen.Method, c
 	statements = append(statements,
 		jen.Line(),
 		jen.Comment("Add format-specific flags from registered formats"),
-		jen.For(
-			jen.List(jen.Id("_"), jen.Id("outputFmt")).Op(":=").Range().Id("options").Dot("OutputFormats").Call(),
-		).Block(
-			jen.Comment("Check if format implements FlagConfiguredOutputFormat"),
-			jen.If(
-				jen.List(jen.Id("flagConfigured"), jen.Id("ok")).Op(":=").Id("outputFmt").Assert(
-					jen.Qual("github.com/drewfead/proto-cli", "FlagConfiguredOutputFormat"),
-				),
-				jen.Id("ok"),
-			).Block(
-				jen.Id("flags_"+cmdVarName).Op("=").Append(
-					jen.Id("flags_"+cmdVarName),
-					jen.Id("flagConfigured").Dot("Flags").Call().Op("..."),
-				),
-			),
+		jen.Id("flags_"+cmdVarName).Op("=").Append(
+			jen.Id("flags_"+cmdVarName),
+			jen.Qual("github.com/drewfead/proto-cli", "FormatFlags").Call(jen.Id("options").Dot("OutputFormats").Call()).Op("..."),
 		),
 		jen.Line(),
 	)
@@ -187,14 +223,18 @@ func generateMethodCommand(service *protogen.Service, method *protogen.Method, c
 			jen.Id("cmdCtx").Qual("context", "Context"),
 			jen.Id("cmd").Op("*").Qual("github.com/urfave/cli/v3", "Command"),
 		).Error().Block(
-			generateActionBodyWithHooks(file, service, method, configMessageType, localOnly)...,
+			generateActionBodyWithHooks(file, service, method, configMessageType, localOnly, genOpts)...,
 		),
 	}
 
 	// For TUI-enabled services, add a Before hook that intercepts --interactive and
 	// deep-links into the TUI at this method's request form.
-	if tuiEnabled {
-		cmdDict[jen.Id("Before")] = generateTUIBeforeHook(method, serviceCLIName, cmdName)
+	// Commands annotated with confirm prompt for confirmation in either hook.
+	switch {
+	case tuiEnabled:
+		cmdDict[jen.Id("Before")] = generateTUIBeforeHook(method, serviceCLIName, cmdName, genOpts)
+	case cmdOpts.GetConfirm():
+		cmdDict[jen.Id("Before")] = jen.Qual("github.com/drewfead/proto-cli", "ConfirmBefore")
 	}
 
 	// Add optional help fields if provided
@@ -207,11 +247,21 @@ func generateMethodCommand(service *protogen.Service, method *protogen.Method, c
 	if cmdArgsUsage != "" {
 		cmdDict[jen.Id("ArgsUsage")] = jen.Lit(cmdArgsUsage)
 	}
+	if hidden {
+		cmdDict[jen.Id("Hidden")] = jen.True()
+	}
+	if aliases := methodCommandAliases(service, method); len(aliases) > 0 {
+		cmdDict[jen.Id("Aliases")] = aliasesCode(aliases)
+	}
+	cmdDict[jen.Id("Metadata")] = methodMetadata(service, method, genOpts)
+
+	// Commands of grouped methods are collected for their group command
+	commandsVar := commandsVarName(methodCommandGroup(service, method))
 
 	// Generate the command with lifecycle hooks
 	statements = append(statements,
-		jen.Id("commands").Op("=").Append(
-			jen.Id("commands"),
+		jen.Id(commandsVar).Op("=").Append(
+			jen.Id(commandsVar),
 			jen.Op("&").Qual("github.com/urfave/cli/v3", "Command").Values(cmdDict),
 		),
 		jen.Line(),
@@ -220,34 +270,39 @@ func generateMethodCommand(service *protogen.Service, method *protogen.Method, c
 	return statements
 }
 
-func generateFlag(field *protogen.Field) jen.Code {
-	flagName := toKebabCase(field.GoName)
-	usage := field.GoName
-	var shorthand string
-
-	// Check for flag annotation
+// fieldFlagUsage returns the help text for a request field flag: the (cli.flag).usage
+// annotation, the leading proto comment, or the field name, followed by the valid
+// values for enums or the type name for messages, and any deprecation notice.
+func fieldFlagUsage(field *protogen.Field) string {
 	flagOpts := getFieldFlagOptions(field)
-	if flagOpts != nil {
-		if flagOpts.Name != "" {
-			flagName = flagOpts.Name
-		}
-		if flagOpts.Usage != "" {
-			usage = flagOpts.Usage
-		}
+	usage := field.GoName
+	if flagOpts.GetUsage() != "" {
+		usage = flagOpts.GetUsage()
+	} else if comment := cleanProtoComment(field.Comments.Leading); comment != "" {
+		// Fallback to proto source comment if no annotation provided usage text
+		usage = firstLine(comment)
 	}
 
-	// Fallback to proto source comment if no annotation provided usage text
-	if usage == field.GoName {
-		if comment := cleanProtoComment(field.Comments.Leading); comment != "" {
-			usage = firstLine(comment)
+	switch field.Desc.Kind() {
+	case protoreflect.EnumKind:
+		usage = usage + " [" + getEnumValuesPiped(field.Enum) + "]"
+	case protoreflect.MessageKind:
+		// Name the message type unless usage was customized
+		if flagOpts.GetUsage() == "" {
+			usage = fmt.Sprintf("%s (%s)", field.GoName, field.Message.Desc.FullName())
 		}
 	}
 
-	if flagOpts != nil {
-		if flagOpts.Shorthand != "" {
-			shorthand = flagOpts.Shorthand
-		}
+	if msg := flagOpts.GetDeprecated(); msg != "" {
+		usage = fmt.Sprintf("%s (deprecated: %s)", usage, msg)
 	}
+	return usage
+}
+
+func generateFlag(field *protogen.Field, genOpts Options) jen.Code {
+	flagName := genOpts.fieldFlagName(field)
+	usage := fieldFlagUsage(field)
+	flagOpts := getFieldFlagOptions(field)
 
 	// Helper function to build flag dict with optional Aliases, Required, DefaultText
 	buildFlagDict := func() jen.Dict {
@@ -255,8 +310,8 @@ func generateFlag(field *protogen.Field) jen.Code {
 			jen.Id("Name"):  jen.Lit(flagName),
 			jen.Id("Usage"): jen.Lit(usage),
 		}
-		if shorthand != "" {
-			dict[jen.Id("Aliases")] = jen.Index().String().Values(jen.Lit(shorthand))
+		if flagOpts.GetShorthand() != "" {
+			dict[jen.Id("Aliases")] = jen.Index().String().Values(jen.Lit(flagOpts.GetShorthand()))
 		}
 		if flagOpts != nil && flagOpts.GetRequired() {
 			dict[jen.Id("Required")] = jen.True()
@@ -268,39 +323,37 @@ func generateFlag(field *protogen.Field) jen.Code {
 	}
 
 	// defaultStr is the annotation default_value, used to set the flag's Value field.
-	var defaultStr string
-	if flagOpts != nil {
-		defaultStr = flagOpts.GetDefaultValue()
-	}
+	defaultStr := fieldDefaultValue(field)
 
 	// Handle repeated (list) fields — use slice flag types (no default Value for slices)
 	if field.Desc.IsList() {
-		if field.Desc.Kind() == protoreflect.EnumKind {
-			usage = usage + " [" + getEnumValuesPiped(field.Enum) + "]"
-		}
 		if ft, ok := scalarFlagTypes[field.Desc.Kind()]; ok {
 			return cliFlagRef(ft.SliceFlag, buildFlagDict())
 		}
 		if field.Desc.Kind() == protoreflect.MessageKind {
-			messageType := field.Message
-			fullyQualifiedName := string(messageType.Desc.FullName())
-			if flagOpts == nil || flagOpts.Usage == "" {
-				usage = fmt.Sprintf("%s (%s)", field.GoName, fullyQualifiedName)
-			}
 			return cliFlagRef("StringSliceFlag", buildFlagDict())
 		}
 		return nil
 	}
 
-	// Append valid enum values to usage text for singular enums
-	if field.Desc.Kind() == protoreflect.EnumKind {
-		usage = usage + " [" + getEnumValuesPiped(field.Enum) + "]"
+	// Enum and message defaults are shown unquoted in help, as the user would type them
+	showDefault := func(dict jen.Dict) {
+		if defaultStr != "" && flagOpts.GetPlaceholder() == "" {
+			dict[jen.Id("DefaultText")] = jen.Lit(defaultStr)
+		}
 	}
+
 	if ft, ok := scalarFlagTypes[field.Desc.Kind()]; ok {
 		dict := buildFlagDict()
 		if dv := defaultValueCode(field.Desc.Kind(), defaultStr); dv != nil {
 			dict[jen.Id("Value")] = dv
 		}
+		if field.Desc.Kind() == protoreflect.EnumKind {
+			showDefault(dict)
+		}
+		if generator := flagOpts.GetDefaultGenerator(); generator != "" && defaultStr == "" && flagOpts.GetPlaceholder() == "" {
+			dict[jen.Id("DefaultText")] = jen.Lit("generated " + generator)
+		}
 		return cliFlagRef(ft.SingularFlag, dict)
 	}
 
@@ -308,17 +361,10 @@ func generateFlag(field *protogen.Field) jen.Code {
 	case protoreflect.MessageKind:
 		// For message fields (e.g., google.protobuf.Timestamp, nested messages),
 		// generate a StringFlag that custom deserializers can parse
-		messageType := field.Message
-		fullyQualifiedName := string(messageType.Desc.FullName())
-
-		// Override usage for message types if not already customized
-		if flagOpts == nil || flagOpts.Usage == "" {
-			usage = fmt.Sprintf("%s (%s)", field.GoName, fullyQualifiedName)
-		}
-
 		dict := buildFlagDict()
 		if defaultStr != "" {
 			dict[jen.Id("Value")] = jen.Lit(defaultStr)
+			showDefault(dict)
 		}
 		return jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(dict)
 	case protoreflect.GroupKind:
@@ -441,11 +487,11 @@ func generateConfigFlags(file *protogen.File, configMessageType string, cmdVarNa
 // Handles both primitive types and nested messages (checking for custom deserializers)
 //
 //nolint:gocyclo,dupl,maintidx // Complexity comes from handling all proto kinds with optional field support
-func generateRequestFieldAssignments(file *protogen.File, service *protogen.Service, method *protogen.Method) []jen.Code {
+func generateRequestFieldAssignments(file *protogen.File, service *protogen.Service, method *protogen.Method, genOpts Options) []jen.Code {
 	var statements []jen.Code
 
 	for _, field := range method.Input.Fields {
-		flagName := toKebabCase(field.GoName)
+		flagName := genOpts.fieldFlagName(field)
 
 		// Handle repeated (list) fields
 		if field.Desc.IsList() {
@@ -542,11 +588,7 @@ func generateRequestFieldAssignments(file *protogen.File, service *protogen.Serv
 							),
 						),
 					).Else().Block(
-						jen.If(jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))).Block(
-							jen.Return(jen.Qual("fmt", "Errorf").Call(
-								jen.Lit(fmt.Sprintf("flag --%s requires a custom deserializer for %s (register with protocli.WithFlagDeserializer)", flagName, fullyQualifiedName)),
-							)),
-						),
+						generateMessageListFileAssignment(file, field, flagName),
 					),
 				)
 				continue
@@ -563,6 +605,15 @@ func generateRequestFieldAssignments(file *protogen.File, service *protogen.Serv
 
 		switch field.Desc.Kind() {
 		case protoreflect.MessageKind:
+			if isFieldMask(field) {
+				statements = append(statements, generateFieldMaskAssignment(field, flagName))
+				continue
+			}
+			if isAny(field) {
+				statements = append(statements, generateAnyAssignment(field, flagName))
+				continue
+			}
+
 			// For message fields, check if there's a custom deserializer
 			// Use fully qualified proto name
 			messageType := field.Message
@@ -712,16 +763,37 @@ func generateRequestFieldAssignments(file *protogen.File, service *protogen.Serv
 		case protoreflect.StringKind:
 			oneof := field.Desc.ContainingOneof()
 			isOptional := field.Desc.HasPresence() && (oneof == nil || (oneof != nil && oneof.IsSynthetic()))
-			if isOptional {
+			switch {
+			case genOpts.allowsFileValue(field) && isOptional:
 				statements = append(statements,
 					jen.If(jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))).Block(
-						jen.Id("val").Op(":=").Id("cmd").Dot("String").Call(jen.Lit(flagName)),
+						generateReadFileFlagValue(flagName, jen.Id("cmd").Dot("String").Call(jen.Lit(flagName))),
+						generateFlagTransform(field, flagName, jen.Id("val"), false),
 						jen.Id("req").Dot(field.GoName).Op("=").Op("&").Id("val"),
 					),
 				)
-			} else {
+			case genOpts.allowsFileValue(field):
+				statements = append(statements,
+					jen.If(jen.Id("s").Op(":=").Id("cmd").Dot("String").Call(jen.Lit(flagName)), jen.Id("s").Op("!=").Lit("")).Block(
+						generateReadFileFlagValue(flagName, jen.Id("s")),
+						generateFlagTransform(field, flagName, jen.Id("val"), false),
+						jen.Id("req").Dot(field.GoName).Op("=").Id("val"),
+					),
+				)
+			case isOptional:
+				statements = append(statements,
+					jen.If(jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))).Block(
+						generateFlagTransform(field, flagName, jen.Id("cmd").Dot("String").Call(jen.Lit(flagName)), true),
+						jen.Id("req").Dot(field.GoName).Op("=").Op("&").Id("val"),
+					),
+				)
+			default:
+				// An empty value leaves the field at its zero value
 				statements = append(statements,
-					jen.Id("req").Dot(field.GoName).Op("=").Id("cmd").Dot("String").Call(jen.Lit(flagName)),
+					jen.If(jen.Id("s").Op(":=").Id("cmd").Dot("String").Call(jen.Lit(flagName)), jen.Id("s").Op("!=").Lit("")).Block(
+						generateFlagTransform(field, flagName, jen.Id("s"), true),
+						jen.Id("req").Dot(field.GoName).Op("=").Id("val"),
+					),
 				)
 			}
 		case protoreflect.BoolKind:
@@ -740,10 +812,11 @@ func generateRequestFieldAssignments(file *protogen.File, service *protogen.Serv
 				)
 			}
 		case protoreflect.BytesKind:
-			// Bytes fields don't have explicit presence in proto3, always set
+			// Bytes fields don't have explicit presence in proto3, set when non-empty
 			statements = append(statements,
-				jen.Id("req").Dot(field.GoName).Op("=").Index().Byte().Call(
-					jen.Id("cmd").Dot("String").Call(jen.Lit(flagName)),
+				jen.If(jen.Id("s").Op(":=").Id("cmd").Dot("String").Call(jen.Lit(flagName)), jen.Id("s").Op("!=").Lit("")).Block(
+					generateFlagTransform(field, flagName, jen.Id("s"), true),
+					jen.Id("req").Dot(field.GoName).Op("=").Index().Byte().Call(jen.Id("val")),
 				),
 			)
 		case protoreflect.EnumKind:
@@ -770,9 +843,13 @@ func generateRequestFieldAssignments(file *protogen.File, service *protogen.Serv
 					),
 				)
 			} else {
-				// Regular enum field - always parse if provided, otherwise use zero value
+				// Regular enum field - parse if provided or defaulted, otherwise use zero value
+				cond := jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))
+				if fieldDefaultValue(field) != "" {
+					cond = jen.Id("cmd").Dot("String").Call(jen.Lit(flagName)).Op("!=").Lit("")
+				}
 				statements = append(statements,
-					jen.If(jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))).Block(
+					jen.If(cond).Block(
 						jen.List(jen.Id("val"), jen.Err()).Op(":=").Id(parserFuncName).Call(
 							jen.Id("cmd").Dot("String").Call(jen.Lit(flagName)),
 						),
@@ -800,16 +877,47 @@ func generateRequestFieldAssignments(file *protogen.File, service *protogen.Serv
 	return statements
 }
 
+// generateMessageListFileAssignment emits the fallback for a repeated message
+// flag without a flag deserializer: each value must be @path to a JSON or YAML
+// array, whose elements are appended to the field.
+func generateMessageListFileAssignment(file *protogen.File, field *protogen.Field, flagName string) jen.Code {
+	fullyQualifiedName := string(field.Message.Desc.FullName())
+	return jen.For(
+		jen.List(jen.Id("_"), jen.Id("s")).Op(":=").Range().Id("cmd").Dot("StringSlice").Call(jen.Lit(flagName)),
+	).Block(
+		jen.List(jen.Id("listPath"), jen.Id("isFile")).Op(":=").Qual("strings", "CutPrefix").Call(jen.Id("s"), jen.Lit("@")),
+		jen.If(jen.Op("!").Id("isFile")).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(
+				jen.Lit(fmt.Sprintf("flag --%s requires a custom deserializer for %s (register with protocli.WithFlagDeserializer) or an @file holding a JSON or YAML array", flagName, fullyQualifiedName)),
+			)),
+		),
+		jen.List(jen.Id("elems"), jen.Id("listErr")).Op(":=").Qual("github.com/drewfead/proto-cli", "ReadInputFileList").Call(
+			jen.Id("listPath"),
+			jen.Id("options").Dot("InputFormats").Call(),
+			jen.Func().Params().Add(qualifyType(file, field.Message, true)).Block(
+				jen.Return(jen.Op("&").Add(qualifyType(file, field.Message, false)).Values()),
+			),
+		),
+		jen.If(jen.Id("listErr").Op("!=").Nil()).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(
+				jen.Lit(fmt.Sprintf("invalid value for --%s: %%w", flagName)),
+				jen.Id("listErr"),
+			)),
+		),
+		jen.Id("req").Dot(field.GoName).Op("=").Append(jen.Id("req").Dot(field.GoName), jen.Id("elems").Op("...")),
+	)
+}
+
 // generateRequestFieldOverrides generates code to override request fields from CLI flags,
 // but ONLY when the flag was explicitly set (cmd.IsSet). This is used when a request
 // was loaded from an input file and flags should selectively override fields.
 //
 //nolint:gocyclo,dupl,maintidx // Complexity comes from handling all proto kinds with IsSet checks
-func generateRequestFieldOverrides(file *protogen.File, service *protogen.Service, method *protogen.Method) []jen.Code {
+func generateRequestFieldOverrides(file *protogen.File, service *protogen.Service, method *protogen.Method, genOpts Options) []jen.Code {
 	var statements []jen.Code
 
 	for _, field := range method.Input.Fields {
-		flagName := toKebabCase(field.GoName)
+		flagName := genOpts.fieldFlagName(field)
 
 		// Handle repeated (list) fields — only override if flag was explicitly set
 		if field.Desc.IsList() {
@@ -917,9 +1025,8 @@ func generateRequestFieldOverrides(file *protogen.File, service *protogen.Servic
 								),
 							),
 						).Else().Block(
-							jen.Return(jen.Qual("fmt", "Errorf").Call(
-								jen.Lit(fmt.Sprintf("flag --%s requires a custom deserializer for %s (register with protocli.WithFlagDeserializer)", flagName, fullyQualifiedName)),
-							)),
+							jen.Id("req").Dot(field.GoName).Op("=").Nil(),
+							generateMessageListFileAssignment(file, field, flagName),
 						),
 					),
 				)
@@ -939,6 +1046,15 @@ func generateRequestFieldOverrides(file *protogen.File, service *protogen.Servic
 
 		switch field.Desc.Kind() {
 		case protoreflect.MessageKind:
+			if isFieldMask(field) {
+				statements = append(statements, generateFieldMaskAssignment(field, flagName))
+				continue
+			}
+			if isAny(field) {
+				statements = append(statements, generateAnyAssignment(field, flagName))
+				continue
+			}
+
 			// For message fields, check if there's a custom deserializer
 			messageType := field.Message
 			fullyQualifiedName := string(messageType.Desc.FullName())
@@ -1090,17 +1206,28 @@ func generateRequestFieldOverrides(file *protogen.File, service *protogen.Servic
 		case protoreflect.StringKind:
 			oneof := field.Desc.ContainingOneof()
 			isOptional := field.Desc.HasPresence() && (oneof == nil || (oneof != nil && oneof.IsSynthetic()))
-			if isOptional {
+			switch {
+			case genOpts.allowsFileValue(field):
+				value := jen.Id("val")
+				if isOptional {
+					value = jen.Op("&").Id("val")
+				}
 				statements = append(statements,
 					jen.If(jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))).Block(
-						jen.Id("val").Op(":=").Id("cmd").Dot("String").Call(jen.Lit(flagName)),
-						jen.Id("req").Dot(field.GoName).Op("=").Op("&").Id("val"),
+						generateReadFileFlagValue(flagName, jen.Id("cmd").Dot("String").Call(jen.Lit(flagName))),
+						generateFlagTransform(field, flagName, jen.Id("val"), false),
+						jen.Id("req").Dot(field.GoName).Op("=").Add(value),
 					),
 				)
-			} else {
+			default:
+				value := jen.Id("val")
+				if isOptional {
+					value = jen.Op("&").Id("val")
+				}
 				statements = append(statements,
 					jen.If(jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))).Block(
-						jen.Id("req").Dot(field.GoName).Op("=").Id("cmd").Dot("String").Call(jen.Lit(flagName)),
+						generateFlagTransform(field, flagName, jen.Id("cmd").Dot("String").Call(jen.Lit(flagName)), true),
+						jen.Id("req").Dot(field.GoName).Op("=").Add(value),
 					),
 				)
 			}
@@ -1124,9 +1251,8 @@ func generateRequestFieldOverrides(file *protogen.File, service *protogen.Servic
 		case protoreflect.BytesKind:
 			statements = append(statements,
 				jen.If(jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))).Block(
-					jen.Id("req").Dot(field.GoName).Op("=").Index().Byte().Call(
-						jen.Id("cmd").Dot("String").Call(jen.Lit(flagName)),
-					),
+					generateFlagTransform(field, flagName, jen.Id("cmd").Dot("String").Call(jen.Lit(flagName)), true),
+					jen.Id("req").Dot(field.GoName).Op("=").Index().Byte().Call(jen.Id("val")),
 				),
 			)
 		case protoreflect.EnumKind:
@@ -1192,65 +1318,35 @@ func generateOutputWriterOpening(service *protogen.Service) []jen.Code {
 	}
 }
 
-func generateActionBodyWithHooks(file *protogen.File, service *protogen.Service, method *protogen.Method, configMessageType string, localOnly bool) []jen.Code {
-	var statements []jen.Code
-
-	// Reject extra positional arguments.
-	statements = append(statements,
-		jen.If(
-			jen.Id("cmd").Dot("Args").Call().Dot("Len").Call().Op(">").Lit(0),
-		).Block(
-			jen.Return(
-				jen.Qual("github.com/urfave/cli/v3", "Exit").Call(
-					jen.Qual("fmt", "Sprintf").Call(
-						jen.Lit("unsupported argument: %q"),
-						jen.Id("cmd").Dot("Args").Call().Dot("Get").Call(jen.Lit(0)),
-					),
-					jen.Lit(3),
-				),
-			),
-		),
-		jen.Line(),
-	)
-
-	// Defer after hooks in reverse order (LIFO)
-	// IMPORTANT: Register defer FIRST so it runs even if before hooks fail
-	statements = append(statements,
-		generateAfterHooksDefer(),
-		jen.Line(),
-	)
-
-	// Call before hooks in order (FIFO)
-	statements = append(statements,
-		jen.For(
-			jen.List(jen.Id("_"), jen.Id("hook")).Op(":=").Range().Id("options").Dot("BeforeCommandHooks").Call(),
-		).Block(
-			jen.If(
-				jen.Err().Op(":=").Id("hook").Call(
-					jen.Id("cmdCtx"),
-					jen.Id("cmd"),
-				),
-				jen.Err().Op("!=").Nil(),
-			).Block(
-				jen.Return(jen.Qual("fmt", "Errorf").Call(
-					jen.Lit("before hook failed: %w"),
-					jen.Err(),
-				)),
-			),
-		),
-		jen.Line(),
-	)
+// inputFileUsage returns the --input-file usage for method, which only
+// mentions flag overrides when the request has fields to set them.
+func inputFileUsage(method *protogen.Method) string {
+	if len(method.Input.Fields) == 0 {
+		return "Read request from file (JSON or YAML)"
+	}
+	return "Read request from file (JSON or YAML). CLI flags override file values"
+}
 
-	// Build request - check for input file, custom deserializer, or auto-generated flags
+// generateRequestBuild returns statements declaring req and populating it from
+// --input-file (plus flag overrides), a custom request deserializer, or the
+// generated per-field flag assignments. Flags are read through cmd, which may be
+// a *cli.Command or any other protocli.FlagReader.
+func generateRequestBuild(file *protogen.File, service *protogen.Service, method *protogen.Method, genOpts Options) []jen.Code {
 	requestFullyQualifiedName := string(method.Input.Desc.FullName())
 	requestTypeName := method.Input.GoIdent.GoName
 	requestQualifiedType := qualifyType(file, method.Input, true)
 
-	statements = append(statements,
+	statements := []jen.Code{
 		jen.Comment("Build request message"),
 		jen.Var().Id("req").Add(requestQualifiedType),
 		jen.Line(),
-	)
+	}
+
+	// Requests without fields have no flags to apply over the file
+	requestOverrides := generateRequestFieldOverrides(file, service, method, genOpts)
+	if len(requestOverrides) > 0 {
+		requestOverrides = append([]jen.Code{jen.Comment("Apply flag overrides (only explicitly-set flags)")}, requestOverrides...)
+	}
 
 	// Generate the if-else block: input-file → custom deserializer → auto-generated
 	requestBuildBlock := []jen.Code{
@@ -1271,8 +1367,7 @@ func generateActionBodyWithHooks(file *protogen.File, service *protogen.Service,
 				).Block(
 					jen.Return(jen.Err()),
 				),
-				jen.Comment("Apply flag overrides (only explicitly-set flags)"),
-			}, generateRequestFieldOverrides(file, service, method)...)...,
+			}, requestOverrides...)...,
 		).Else().Block(
 			// Inner if-else: custom deserializer vs auto-generated
 			jen.Comment(fmt.Sprintf("Check for custom flag deserializer for %s", requestFullyQualifiedName)),
@@ -1315,13 +1410,510 @@ func generateActionBodyWithHooks(file *protogen.File, service *protogen.Service,
 				append([]jen.Code{
 					jen.Comment("Use auto-generated flag parsing"),
 					jen.Id("req").Op("=").Op("&").Add(qualifyType(file, method.Input, false)).Values(),
-				}, generateRequestFieldAssignments(file, service, method)...)...,
+				}, generateRequestFieldAssignments(file, service, method, genOpts)...)...,
 			),
 		),
 		jen.Line(),
 	}
+	requestBuildBlock = append(requestBuildBlock, generateDefaultGenerators(method, genOpts)...)
+
+	return append(statements, requestBuildBlock...)
+}
+
+// generateReadFileFlagValue declares val from a string flag value that may be
+// "@path" or "@-".
+func generateReadFileFlagValue(flagName string, value jen.Code) jen.Code {
+	return jen.List(jen.Id("val"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "ReadFileFlagValue").Call(value).Line().
+		If(jen.Err().Op("!=").Nil()).Block(
+		jen.Return(jen.Qual("fmt", "Errorf").Call(
+			jen.Lit(fmt.Sprintf("invalid value for --%s: %%w", flagName)),
+			jen.Err(),
+		)),
+	)
+}
+
+// generateFlagTransform passes a string flag value through the transform
+// registered for field (see protocli.RegisterFlagTransform), declaring val
+// from value, or reassigning it when declare is false.
+func generateFlagTransform(field *protogen.Field, flagName string, value jen.Code, declare bool) jen.Code {
+	op := "="
+	if declare {
+		op = ":="
+	}
+	return jen.List(jen.Id("val"), jen.Err()).Op(op).Qual("github.com/drewfead/proto-cli", "TransformFlagValue").Call(
+		jen.Lit(string(field.Parent.Desc.FullName())),
+		jen.Lit(string(field.Desc.Name())),
+		value,
+	).Line().
+		If(jen.Err().Op("!=").Nil()).Block(
+		jen.Return(jen.Qual("fmt", "Errorf").Call(
+			jen.Lit(fmt.Sprintf("invalid value for --%s: %%w", flagName)),
+			jen.Err(),
+		)),
+	)
+}
+
+// isFieldMask reports whether field is a singular google.protobuf.FieldMask,
+// which gets built-in flag parsing instead of requiring a deserializer.
+func isFieldMask(field *protogen.Field) bool {
+	return field.Message != nil && field.Message.Desc.FullName() == "google.protobuf.FieldMask"
+}
 
-	statements = append(statements, requestBuildBlock...)
+// isAny reports whether field is a singular google.protobuf.Any, which gets
+// built-in <type URL>=<JSON> flag parsing instead of requiring a deserializer.
+func isAny(field *protogen.Field) bool {
+	return field.Message != nil && field.Message.Desc.FullName() == "google.protobuf.Any"
+}
+
+// generateAnyAssignment sets an Any field from a <type URL>=<JSON> flag value
+// when its flag was given.
+func generateAnyAssignment(field *protogen.Field, flagName string) jen.Code {
+	return jen.If(jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))).Block(
+		jen.List(jen.Id("packed"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "ParseAny").Call(
+			jen.Id("cmd").Dot("String").Call(jen.Lit(flagName)),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(
+				jen.Lit(fmt.Sprintf("invalid value for --%s: %%w", flagName)),
+				jen.Err(),
+			)),
+		),
+		jen.Id("req").Dot(field.GoName).Op("=").Id("packed"),
+	)
+}
+
+// generateFieldMaskAssignment sets a FieldMask field from a comma-separated
+// path list when its flag was given, validating paths against the
+// field_mask_target annotation if one is set.
+func generateFieldMaskAssignment(field *protogen.Field, flagName string) jen.Code {
+	target := getFieldFlagOptions(field).GetFieldMaskTarget()
+	return jen.If(jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))).Block(
+		jen.List(jen.Id("mask"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "ParseFieldMask").Call(
+			jen.Id("cmd").Dot("String").Call(jen.Lit(flagName)),
+			jen.Lit(target),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(
+				jen.Lit(fmt.Sprintf("invalid value for --%s: %%w", flagName)),
+				jen.Err(),
+			)),
+		),
+		jen.Id("req").Dot(field.GoName).Op("=").Id("mask"),
+	)
+}
+
+// generateDefaultGenerators fills fields annotated with default_generator when
+// neither the flag nor the input file provided a value.
+func generateDefaultGenerators(method *protogen.Method, genOpts Options) []jen.Code {
+	var statements []jen.Code
+	for _, field := range method.Input.Fields {
+		generator := fieldDefaultGenerator(field)
+		if generator == "" {
+			continue
+		}
+		flagName := genOpts.fieldFlagName(field)
+		value := jen.Id("v")
+		if field.Desc.HasPresence() {
+			value = jen.Op("&").Id("v")
+		}
+		statements = append(statements,
+			jen.Comment(fmt.Sprintf("Generate --%s with %q when not given", flagName, generator)),
+			jen.If(
+				jen.Op("!").Id("cmd").Dot("IsSet").Call(jen.Lit(flagName)).
+					Op("&&").Id("req").Dot("Get"+field.GoName).Call().Op("==").Lit(""),
+			).Block(
+				jen.List(jen.Id("v"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "GenerateDefault").Call(jen.Lit(generator)),
+				jen.If(jen.Err().Op("!=").Nil()).Block(
+					jen.Return(jen.Qual("fmt", "Errorf").Call(
+						jen.Lit(fmt.Sprintf("failed to generate --%s: %%w", flagName)),
+						jen.Err(),
+					)),
+				),
+				jen.Id("req").Dot(field.GoName).Op("=").Add(value),
+			),
+			jen.Line(),
+		)
+	}
+	return statements
+}
+
+// generateFieldsExclude emits the --fields-exclude projection of a response
+// variable. op is "=" when err is already declared in scope and ":=" otherwise.
+func generateFieldsExclude(varName, op string) jen.Code {
+	return jen.Comment("Drop --fields-exclude paths from the response").Line().
+		List(jen.Id(varName), jen.Err()).Op(op).Qual("github.com/drewfead/proto-cli", "ExcludeResponseFields").Call(jen.Id("cmd"), jen.Id(varName)).Line().
+		If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err()))
+}
+
+// generateFieldsExcludeCheck returns a statement checking the --fields-exclude
+// paths against the response message while the request is built, so bad paths
+// fail before the service is called.
+func generateFieldsExcludeCheck(file *protogen.File, method *protogen.Method) jen.Code {
+	return jen.If(
+		jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "CheckExcludedFields").Call(
+			jen.Id("cmd"), jen.Op("&").Add(qualifyType(file, method.Output, false)).Values(),
+		),
+		jen.Err().Op("!=").Nil(),
+	).Block(jen.Return(jen.Err()))
+}
+
+// generateAuditRequest returns a statement recording the built request for the
+// audit log (a no-op at runtime unless WithAuditLog is set).
+func generateAuditRequest(service *protogen.Service, method *protogen.Method) jen.Code {
+	return jen.Qual("github.com/drewfead/proto-cli", "RecordAuditRequest").Call(
+		jen.Id("cmd"), jen.Lit(rpcFullMethod(service, method)), jen.Id("req"),
+	)
+}
+
+// methodMetadata returns the Metadata of a method command, recording the gRPC
+// full method name for runtime lookups such as WithCommandFilter, and the
+// request field flags that --pick prompts for.
+func methodMetadata(service *protogen.Service, method *protogen.Method, genOpts Options) jen.Code {
+	requestFlags := make([]jen.Code, 0, len(method.Input.Fields))
+	for _, field := range method.Input.Fields {
+		requestFlags = append(requestFlags, jen.Lit(genOpts.fieldFlagName(field)))
+	}
+	return jen.Map(jen.String()).Any().Values(jen.Dict{
+		jen.Qual("github.com/drewfead/proto-cli", "MethodMetadataKey"):       jen.Lit(rpcFullMethod(service, method)),
+		jen.Qual("github.com/drewfead/proto-cli", "RequestFlagsMetadataKey"): jen.Index().String().Values(requestFlags...),
+	})
+}
+
+// rpcFullMethod returns the gRPC full method name, e.g. "/example.UserService/GetUser".
+func rpcFullMethod(service *protogen.Service, method *protogen.Method) string {
+	return fmt.Sprintf("/%s/%s", service.Desc.FullName(), method.Desc.Name())
+}
+
+// generateReplayCall generates the unary call served from a recording when
+// --replay is set.
+func generateReplayCall(service *protogen.Service, method *protogen.Method) []jen.Code {
+	return []jen.Code{
+		jen.Comment("Replay the recorded response instead of calling the service"),
+		jen.List(jen.Id("resp"), jen.Err()).Op("=").Qual("github.com/drewfead/proto-cli", "ReplayResponse").Types(
+			jen.Op("*").Id(method.Output.GoIdent.GoName),
+		).Call(jen.Id("cmd"), jen.Lit(rpcFullMethod(service, method)), jen.Id("req")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Err()),
+		),
+	}
+}
+
+// generateShowInput returns a statement printing the built request to stderr
+// when --show-input is set.
+func generateShowInput() jen.Code {
+	return jen.If(
+		jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "ShowInput").Call(
+			jen.Id("cmdCtx"), jen.Id("cmd"), jen.Id("options").Dot("OutputFormats").Call(), jen.Id("req"),
+		),
+		jen.Err().Op("!=").Nil(),
+	).Block(jen.Return(jen.Err()))
+}
+
+// generateRequestSizeCheck returns a statement rejecting requests larger than
+// the WithMaxRequestSize limit before they are sent.
+func generateRequestSizeCheck() jen.Code {
+	return jen.If(
+		jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "CheckRequestSize").Call(jen.Id("cmd"), jen.Id("req")),
+		jen.Err().Op("!=").Nil(),
+	).Block(jen.Return(jen.Err()))
+}
+
+// generateCommandConfigDefaults returns statements that fill in flags not
+// given on the command line from the command's entry in the config files.
+func generateCommandConfigDefaults() []jen.Code {
+	return []jen.Code{
+		jen.Comment("Apply flag defaults from the commands section of the config files"),
+		jen.If(
+			jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "ApplyCommandConfig").Call(jen.Id("cmd")),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			jen.Return(jen.Err()),
+		),
+		jen.Line(),
+	}
+}
+
+// generateDeprecationWarnings returns statements that log a warning when a
+// deprecated command runs or a deprecated flag is set.
+func generateDeprecationWarnings(method *protogen.Method, genOpts Options) []jen.Code {
+	var statements []jen.Code
+	if msg := getMethodCommandOptions(method).GetDeprecated(); msg != "" {
+		statements = append(statements,
+			jen.Qual("github.com/drewfead/proto-cli", "WarnDeprecatedCommand").Call(jen.Id("cmd"), jen.Lit(msg)),
+		)
+	}
+	for _, field := range method.Input.Fields {
+		if msg := getFieldFlagOptions(field).GetDeprecated(); msg != "" {
+			statements = append(statements,
+				jen.Qual("github.com/drewfead/proto-cli", "WarnDeprecatedFlag").Call(
+					jen.Id("cmd"), jen.Lit(genOpts.fieldFlagName(field)), jen.Lit(msg),
+				),
+			)
+		}
+	}
+	if len(statements) > 0 {
+		statements = append(statements, jen.Line())
+	}
+	return statements
+}
+
+// constrainedFieldKind reports whether the pattern, one_of, min and max flag
+// annotations apply to fields of kind.
+func constrainedFieldKind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.MessageKind, protoreflect.GroupKind, protoreflect.EnumKind, protoreflect.BoolKind, protoreflect.BytesKind:
+		return false
+	}
+	return true
+}
+
+// validFieldPattern reports whether field has a pattern annotation that
+// compiles as a regular expression.
+func validFieldPattern(field *protogen.Field) bool {
+	pattern := getFieldFlagOptions(field).GetPattern()
+	if pattern == "" || !constrainedFieldKind(field.Desc.Kind()) {
+		return false
+	}
+	_, err := regexp.Compile(pattern)
+	return err == nil
+}
+
+// generateFieldPatterns emits a package-level variable holding each compiled
+// pattern annotation on the request fields of service's methods, so generated
+// actions don't compile them per call. Invalid patterns are reported and
+// ignored.
+func generateFieldPatterns(f *jen.File, service *protogen.Service) {
+	for _, method := range service.Methods {
+		for _, field := range method.Input.Fields {
+			pattern := getFieldFlagOptions(field).GetPattern()
+			if pattern == "" || !constrainedFieldKind(field.Desc.Kind()) {
+				continue
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				fmt.Fprintf(os.Stderr, "WARNING: %s: pattern %q is not a valid regular expression: %v; ignoring\n",
+					field.Desc.FullName(), pattern, err)
+				continue
+			}
+			name := fieldPatternVarName(service, method, field)
+			f.Commentf("%s is the compiled pattern annotation of %s", name, field.Desc.FullName())
+			f.Var().Id(name).Op("=").Qual("regexp", "MustCompile").Call(jen.Lit(pattern))
+		}
+	}
+}
+
+// generateFieldConstraintChecks returns statements validating the request against
+// pattern, one_of, min and max flag annotations. All violations are reported together.
+func generateFieldConstraintChecks(service *protogen.Service, method *protogen.Method, genOpts Options) []jen.Code {
+	var checks []jen.Code
+	for _, field := range method.Input.Fields {
+		flagOpts := getFieldFlagOptions(field)
+		if flagOpts == nil || !constrainedFieldKind(field.Desc.Kind()) {
+			continue
+		}
+
+		dict := jen.Dict{}
+		if validFieldPattern(field) {
+			dict[jen.Id("Pattern")] = jen.Id(fieldPatternVarName(service, method, field))
+		}
+		if len(flagOpts.GetOneOf()) > 0 {
+			values := make([]jen.Code, 0, len(flagOpts.GetOneOf()))
+			for _, v := range flagOpts.GetOneOf() {
+				values = append(values, jen.Lit(v))
+			}
+			dict[jen.Id("OneOf")] = jen.Index().String().Values(values...)
+		}
+		if flagOpts.Min != nil {
+			dict[jen.Id("Min")] = jen.Qual("google.golang.org/protobuf/proto", "Float64").Call(jen.Lit(flagOpts.GetMin()))
+		}
+		if flagOpts.Max != nil {
+			dict[jen.Id("Max")] = jen.Qual("google.golang.org/protobuf/proto", "Float64").Call(jen.Lit(flagOpts.GetMax()))
+		}
+		if len(dict) == 0 {
+			continue
+		}
+		flagName := genOpts.fieldFlagName(field)
+		dict[jen.Id("Flag")] = jen.Lit(flagName)
+		dict[jen.Id("Set")] = jen.Id("cmd").Dot("IsSet").Call(jen.Lit(flagName))
+
+		checks = append(checks,
+			jen.Qual("github.com/drewfead/proto-cli", "FieldConstraint").Values(dict).Dot("Check").Call(
+				jen.Id("req").Dot(field.GoName),
+			),
+		)
+	}
+	if len(checks) == 0 {
+		return nil
+	}
+
+	return []jen.Code{
+		jen.Comment("Validate flag value constraints"),
+		jen.If(
+			jen.Err().Op(":=").Qual("errors", "Join").Custom(jen.Options{
+				Open: "(", Close: ")", Separator: ",", Multi: true,
+			}, checks...),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			jen.Return(jen.Err()),
+		),
+		jen.Line(),
+	}
+}
+
+// generateFlagRulesCheck returns the statement running the method's
+// cross-flag rules: those declared with the requires and conflicts annotations
+// and those registered with WithFlagRules. Pairs naming a flag the request
+// doesn't have are reported and ignored.
+func generateFlagRulesCheck(service *protogen.Service, method *protogen.Method, genOpts Options) []jen.Code {
+	flagNames := make(map[string]bool, len(method.Input.Fields))
+	for _, field := range method.Input.Fields {
+		flagNames[genOpts.fieldFlagName(field)] = true
+	}
+	cmdOpts := getMethodCommandOptions(method)
+	args := []jen.Code{
+		jen.Id("cmd"),
+		jen.Id("options").Dot("FlagRules").Call(jen.Lit(rpcFullMethod(service, method))),
+	}
+	for _, rule := range []struct {
+		annotation string
+		pairs      []*annotations.FlagPair
+		fn         string
+	}{
+		{"requires", cmdOpts.GetRequires(), "RequiresFlag"},
+		{"conflicts", cmdOpts.GetConflicts(), "ConflictingFlags"},
+	} {
+		for _, pair := range rule.pairs {
+			if !flagNames[pair.GetFlag()] || !flagNames[pair.GetOther()] {
+				fmt.Fprintf(os.Stderr, "WARNING: %s: %s pair (%q, %q) names a flag %s doesn't have; ignoring\n",
+					method.Desc.FullName(), rule.annotation, pair.GetFlag(), pair.GetOther(), method.Input.Desc.FullName())
+				continue
+			}
+			args = append(args, jen.Qual("github.com/drewfead/proto-cli", rule.fn).Call(jen.Lit(pair.GetFlag()), jen.Lit(pair.GetOther())))
+		}
+	}
+
+	return []jen.Code{
+		jen.Comment("Check cross-flag rules"),
+		jen.If(
+			jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "CheckFlagRules").Custom(jen.Options{
+				Open: "(", Close: ")", Separator: ",", Multi: len(args) > 2,
+			}, args...),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			jen.Return(jen.Err()),
+		),
+		jen.Line(),
+	}
+}
+
+// generateValidateMethodCommand returns the `validate <method>` command, which
+// reads --input-file into the method's request and runs the field constraint
+// checks without calling the service.
+func generateValidateMethodCommand(file *protogen.File, service *protogen.Service, method *protogen.Method, genOpts Options) jen.Code {
+	body := []jen.Code{
+		jen.Id("inputFile").Op(":=").Id("cmd").Dot("String").Call(jen.Lit("input-file")),
+		jen.Id("req").Op(":=").Op("&").Add(qualifyType(file, method.Input, false)).Values(),
+		jen.If(
+			jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "ReadInputFile").Call(
+				jen.Id("inputFile"),
+				jen.Id("cmd").Dot("String").Call(jen.Lit("input-format")),
+				jen.Id("options").Dot("InputFormats").Call(),
+				jen.Id("req"),
+			),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			jen.Return(jen.Err()),
+		),
+	}
+	body = append(body, generateFieldConstraintChecks(service, method, genOpts)...)
+	body = append(body,
+		jen.Return(jen.Qual("github.com/drewfead/proto-cli", "ReportInputValid").Call(jen.Id("cmd"), jen.Id("inputFile"))),
+	)
+
+	cmdDict := jen.Dict{
+		jen.Id("Name"):  jen.Lit(methodCommandName(method)),
+		jen.Id("Usage"): jen.Lit(fmt.Sprintf("Check that --input-file is a valid %s request", methodCommandName(method))),
+		jen.Id("Flags"): jen.Index().Qual("github.com/urfave/cli/v3", "Flag").Values(
+			jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+				jen.Id("Name"):     jen.Lit("input-file"),
+				jen.Id("Usage"):    jen.Lit("Request file to validate (JSON or YAML)"),
+				jen.Id("Required"): jen.True(),
+			}),
+			jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+				jen.Id("Name"):  jen.Lit("input-format"),
+				jen.Id("Usage"): jen.Lit("Input file format (auto-detected from extension if not set)"),
+			}),
+		),
+		jen.Id("Action"): jen.Func().Params(
+			jen.Id("_").Qual("context", "Context"),
+			jen.Id("cmd").Op("*").Qual("github.com/urfave/cli/v3", "Command"),
+		).Error().Block(body...),
+	}
+	if getMethodCommandOptions(method).GetHidden() {
+		cmdDict[jen.Id("Hidden")] = jen.True()
+	}
+	if aliases := methodCommandAliases(service, method); len(aliases) > 0 {
+		cmdDict[jen.Id("Aliases")] = aliasesCode(aliases)
+	}
+	return jen.Op("&").Qual("github.com/urfave/cli/v3", "Command").Values(cmdDict)
+}
+
+func generateActionBodyWithHooks(file *protogen.File, service *protogen.Service, method *protogen.Method, configMessageType string, localOnly bool, genOpts Options) []jen.Code {
+	var statements []jen.Code
+
+	// Reject extra positional arguments (or pass them to WithArgErrorHandler).
+	statements = append(statements, generateArgsCheck(method, func(err jen.Code) jen.Code { return jen.Return(err) })...)
+	statements = append(statements, jen.Line())
+
+	// Warn about deprecated command/flag usage
+	statements = append(statements, generateDeprecationWarnings(method, genOpts)...)
+	statements = append(statements, generateCommandConfigDefaults()...)
+
+	// Time each phase for --timings; reported after the after hooks have run
+	statements = append(statements, generateCommandTimings()...)
+
+	// Defer after hooks in reverse order (LIFO)
+	// IMPORTANT: Register defer FIRST so it runs even if before hooks fail
+	statements = append(statements,
+		generateAfterHooksDefer(),
+		jen.Line(),
+	)
+
+	// Call before hooks in order (FIFO)
+	statements = append(statements,
+		jen.For(
+			jen.List(jen.Id("_"), jen.Id("hook")).Op(":=").Range().Id("options").Dot("BeforeCommandHooks").Call(),
+		).Block(
+			jen.If(
+				jen.Err().Op(":=").Id("hook").Call(
+					jen.Id("cmdCtx"),
+					jen.Id("cmd"),
+				),
+				jen.Err().Op("!=").Nil(),
+			).Block(
+				jen.Return(jen.Qual("fmt", "Errorf").Call(
+					jen.Lit("before hook failed: %w"),
+					jen.Err(),
+				)),
+			),
+		),
+		jen.Line(),
+	)
+
+	// Build request - check for input file, custom deserializer, or auto-generated flags
+	statements = append(statements, timingsPhase("request build"))
+	statements = append(statements, generateOTelSpanStart(genOpts, "buildSpan", "request build")...)
+	statements = append(statements, generateRequestBuild(file, service, method, genOpts)...)
+	statements = append(statements, generatePassthroughAssignment(method, jen.Id("cmd").Dot("Args").Call().Dot("Slice").Call())...)
+	statements = append(statements, generateAuditRequest(service, method), generateRequestSizeCheck())
+	statements = append(statements, generateFieldConstraintChecks(service, method, genOpts)...)
+	statements = append(statements, generateFlagRulesCheck(service, method, genOpts)...)
+	statements = append(statements, generateFieldsExcludeCheck(file, method))
+	statements = append(statements, generateShowInput())
+	statements = append(statements, generateOTelSpanEnd(genOpts, "buildSpan")...)
+	statements = append(statements, jen.Line())
+
+	// Trace the call, whichever way it is served
+	statements = append(statements, generateOTelSpanStart(genOpts, "callSpan", "service call")...)
 
 	// Generate remote/local call logic
 	if localOnly {
@@ -1331,9 +1923,13 @@ func generateActionBodyWithHooks(file *protogen.File, service *protogen.Service,
 			jen.Var().Id("resp").Op("*").Id(method.Output.GoIdent.GoName),
 			jen.Var().Err().Error(),
 			jen.Line(),
+			jen.If(jen.Qual("github.com/drewfead/proto-cli", "Replaying").Call(jen.Id("cmd"))).Block(
+				append([]jen.Code{timingsPhase("service call")}, generateReplayCall(service, method)...)...,
+			).Else().Block(
+				generateLocalCallLogic(service, method, configMessageType)...,
+			),
+			jen.Line(),
 		)
-		statements = append(statements, generateLocalCallLogic(service, method, configMessageType)...)
-		statements = append(statements, jen.Line())
 	} else {
 		// Check if remote flag is set and call either remote or direct
 		clientType := "New" + service.GoName + "Client"
@@ -1343,13 +1939,18 @@ func generateActionBodyWithHooks(file *protogen.File, service *protogen.Service,
 			jen.Var().Id("resp").Op("*").Id(method.Output.GoIdent.GoName),
 			jen.Var().Err().Error(),
 			jen.Line(),
-			jen.If(jen.Id("remoteAddr").Op("!=").Lit("")).Block(
+			jen.If(jen.Qual("github.com/drewfead/proto-cli", "Replaying").Call(jen.Id("cmd"))).Block(
+				append([]jen.Code{timingsPhase("service call")}, generateReplayCall(service, method)...)...,
+			).Else().If(jen.Id("remoteAddr").Op("!=").Lit("")).Block(
 				jen.Comment("Remote gRPC call"),
+				timingsPhase("service call"),
+				jen.List(jen.Id("callOpts"), jen.Id("optsErr")).Op(":=").Qual("github.com/drewfead/proto-cli", "RemoteCallOptions").Call(jen.Id("cmd")),
+				jen.If(jen.Id("optsErr").Op("!=").Nil()).Block(
+					jen.Return(jen.Id("optsErr")),
+				),
 				jen.List(jen.Id("conn"), jen.Id("connErr")).Op(":=").Qual("google.golang.org/grpc", "NewClient").Call(
 					jen.Id("remoteAddr"),
-					jen.Qual("google.golang.org/grpc", "WithTransportCredentials").Call(
-						jen.Qual("google.golang.org/grpc/credentials/insecure", "NewCredentials").Call(),
-					),
+					jen.Qual("github.com/drewfead/proto-cli", "RemoteDialOptions").Call(jen.Id("cmd")).Op("..."),
 				),
 				jen.If(jen.Id("connErr").Op("!=").Nil()).Block(
 					jen.Return(jen.Qual("fmt", "Errorf").Call(
@@ -1364,6 +1965,7 @@ func generateActionBodyWithHooks(file *protogen.File, service *protogen.Service,
 				jen.List(jen.Id("resp"), jen.Err()).Op("=").Id("client").Dot(method.GoName).Call(
 					jen.Id("cmdCtx"),
 					jen.Id("req"),
+					jen.Id("callOpts").Op("..."),
 				),
 				jen.If(jen.Err().Op("!=").Nil()).Block(
 					jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("remote call failed: %w"), jen.Err())),
@@ -1375,65 +1977,150 @@ func generateActionBodyWithHooks(file *protogen.File, service *protogen.Service,
 		)
 	}
 
+	statements = append(statements, generateOTelSpanEnd(genOpts, "callSpan")...)
+
+	// Record the response for `last` queries (no-op unless WithResponseCache is set)
+	statements = append(statements,
+		jen.Qual("github.com/drewfead/proto-cli", "CacheResponse").Call(jen.Id("cmd"), jen.Id("resp")),
+		jen.Comment("Record the response for --replay (no-op unless WithRecord is set)"),
+		jen.Qual("github.com/drewfead/proto-cli", "RecordResponse").Call(
+			jen.Id("cmd"), jen.Lit(rpcFullMethod(service, method)), jen.Id("req"), jen.Id("resp"),
+		),
+		jen.Line(),
+	)
+
+	// Apply --fields-exclude before the output file is opened so bad paths leave it untouched
+	statements = append(statements, timingsPhase("format"))
+	statements = append(statements, generateFieldsExclude("resp", "="), jen.Line())
+
 	// Handle output formatting
 	statements = append(statements, generateOutputWriterOpening(service)...)
 
+	writeFormatted := jen.Qual("github.com/drewfead/proto-cli", "WriteRequestResponse").Call(
+		jen.Id("cmdCtx"),
+		jen.Id("cmd"),
+		jen.Id("outputWriter"),
+		jen.Id("options").Dot("OutputFormats").Call(),
+		jen.Id("cmd").Dot("String").Call(jen.Lit("format")),
+		jen.Id("req"),
+		jen.Id("resp"),
+	)
 	statements = append(statements,
-		jen.Comment("Find and use the appropriate output format"),
-		jen.Id("formatName").Op(":=").Id("cmd").Dot("String").Call(jen.Lit("format")),
-		jen.Line(),
-		jen.Comment("Try registered formats"),
-		jen.For(
-			jen.List(jen.Id("_"), jen.Id("outputFmt")).Op(":=").Range().Id("options").Dot("OutputFormats").Call(),
-		).Block(
-			jen.If(jen.Id("outputFmt").Dot("Name").Call().Op("==").Id("formatName")).Block(
-				jen.If(
-					jen.Err().Op(":=").Id("outputFmt").Dot("Format").Call(
-						jen.Id("cmdCtx"),
-						jen.Id("cmd"),
-						jen.Id("outputWriter"),
-						jen.Id("resp"),
-					),
-					jen.Err().Op("!=").Nil(),
-				).Block(
-					jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("format failed: %w"), jen.Err())),
-				),
-				jen.Comment("Write final newline to keep terminal clean"),
-				jen.If(
-					jen.List(jen.Id("_"), jen.Err()).Op(":=").Id("outputWriter").Dot("Write").Call(
-						jen.Index().Byte().Call(jen.Lit("\n")),
-					),
-					jen.Err().Op("!=").Nil(),
-				).Block(
-					jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to write final newline: %w"), jen.Err())),
-				),
-				jen.Return(jen.Nil()),
-			),
+		jen.Comment("Warn about flags of other formats that the selected one ignores"),
+		jen.Qual("github.com/drewfead/proto-cli", "WarnIgnoredFormatFlags").Call(
+			jen.Id("cmd"), jen.Id("options").Dot("OutputFormats").Call(), jen.Id("cmd").Dot("String").Call(jen.Lit("format")),
 		),
 		jen.Line(),
-		jen.Comment("Format not found - build list of available formats"),
-		jen.Var().Id("availableFormats").Index().String(),
-		jen.For(
-			jen.List(jen.Id("_"), jen.Id("f")).Op(":=").Range().Id("options").Dot("OutputFormats").Call(),
-		).Block(
-			jen.Id("availableFormats").Op("=").Append(
-				jen.Id("availableFormats"),
-				jen.Id("f").Dot("Name").Call(),
+	)
+	statements = append(statements, jen.Comment("Render the response (with the request for --echo-request) in the selected output format, or just its --get value"))
+	if field := exitCodeField(method); field != "" {
+		statements = append(statements,
+			jen.If(jen.Err().Op(":=").Add(writeFormatted), jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Err()),
 			),
+			jen.Line(),
+			jen.Comment("Exit with the response's "+field+" (exit_code_field)"),
+			jen.Return(jen.Qual("github.com/drewfead/proto-cli", "ResponseExitCode").Call(jen.Id("resp"), jen.Lit(field))),
+		)
+	} else {
+		statements = append(statements, jen.Return(writeFormatted))
+	}
+
+	return wrapOTelActionBody(file, service, method, genOpts, statements)
+}
+
+// exitCodeField returns the method's exit_code_field annotation after checking
+// that it names a singular integer field of the response. Invalid annotations
+// are reported and ignored.
+func exitCodeField(method *protogen.Method) string {
+	path := getMethodCommandOptions(method).GetExitCodeField()
+	if path == "" {
+		return ""
+	}
+	field := lookupFieldPath(method.Output, path)
+	switch {
+	case field == nil:
+		fmt.Fprintf(os.Stderr, "WARNING: %s: exit_code_field %q is not a field of %s; ignoring\n",
+			method.Desc.FullName(), path, method.Output.Desc.FullName())
+		return ""
+	case field.Desc.IsList() || field.Desc.IsMap() || !isIntegerKind(field.Desc.Kind()):
+		fmt.Fprintf(os.Stderr, "WARNING: %s: exit_code_field %q is not a singular integer field; ignoring\n",
+			method.Desc.FullName(), path)
+		return ""
+	}
+	return path
+}
+
+// passthroughField returns the request field named by the method's
+// passthrough annotation after checking that it is a top-level repeated string
+// field. Invalid annotations are reported and ignored.
+func passthroughField(method *protogen.Method) *protogen.Field {
+	name := getMethodCommandOptions(method).GetPassthrough()
+	if name == "" {
+		return nil
+	}
+	for _, field := range method.Input.Fields {
+		if string(field.Desc.Name()) != name {
+			continue
+		}
+		if !field.Desc.IsList() || field.Desc.Kind() != protoreflect.StringKind {
+			fmt.Fprintf(os.Stderr, "WARNING: %s: passthrough %q is not a repeated string field; ignoring\n",
+				method.Desc.FullName(), name)
+			return nil
+		}
+		return field
+	}
+	fmt.Fprintf(os.Stderr, "WARNING: %s: passthrough %q is not a field of %s; ignoring\n",
+		method.Desc.FullName(), name, method.Input.Desc.FullName())
+	return nil
+}
+
+// generateArgsCheck returns the statements rejecting positional arguments (or
+// passing them to WithArgErrorHandler), or nothing for passthrough methods,
+// whose arguments become part of the request. ret builds the return statement
+// from the error.
+func generateArgsCheck(method *protogen.Method, ret func(err jen.Code) jen.Code) []jen.Code {
+	if passthroughField(method) != nil {
+		return nil
+	}
+	return []jen.Code{
+		jen.If(
+			jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "CheckArgs").Call(jen.Id("cmd")),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			ret(jen.Err()),
 		),
-		jen.If(jen.Len(jen.Id("availableFormats")).Op("==").Lit(0)).Block(
-			jen.Return(jen.Qual("fmt", "Errorf").Call(
-				jen.Lit("no output formats registered (use WithOutputFormats to register formats)"),
-			)),
+	}
+}
+
+// generatePassthroughAssignment returns the statements copying args, the
+// command's positional arguments, into the method's passthrough field.
+func generatePassthroughAssignment(method *protogen.Method, args jen.Code) []jen.Code {
+	field := passthroughField(method)
+	if field == nil {
+		return nil
+	}
+	return []jen.Code{
+		jen.Comment("Positional arguments (everything after \"--\") go to the passthrough field"),
+		jen.If(jen.Id("passthrough").Op(":=").Add(args), jen.Len(jen.Id("passthrough")).Op(">").Lit(0)).Block(
+			jen.Id("req").Dot(field.GoName).Op("=").Id("passthrough"),
 		),
-		jen.Return(jen.Qual("fmt", "Errorf").Call(
-			jen.Lit("unknown format %q (available: %v)"),
-			jen.Id("formatName"),
-			jen.Id("availableFormats"),
-		)),
-	)
+	}
+}
 
-	return statements
+// generateCommandTimings returns the statements starting --timings for a
+// command action. Deferred before the after hooks, the report follows them.
+func generateCommandTimings() []jen.Code {
+	return []jen.Code{
+		jen.Id("timings").Op(":=").Qual("github.com/drewfead/proto-cli", "NewCommandTimings").Call(jen.Id("cmd")),
+		jen.Defer().Id("timings").Dot("Report").Call(),
+		jen.Line(),
+	}
+}
+
+// timingsPhase returns the statement starting the named --timings phase.
+func timingsPhase(name string) *jen.Statement {
+	return jen.Id("timings").Dot("Phase").Call(jen.Lit(name))
 }
 
 // generateLocalCallLogic generates the logic for calling the service implementation locally
@@ -1444,6 +2131,7 @@ func generateLocalCallLogic(service *protogen.Service, method *protogen.Method,
 		// Service has config - need to load it and call factory
 		statements = append(statements,
 			jen.Comment("Load config and create service implementation"),
+			timingsPhase("config load"),
 			jen.Comment("Get config paths and env prefix from root command"),
 			jen.Id("rootCmd").Op(":=").Id("cmd").Dot("Root").Call(),
 			jen.Id("configPaths").Op(":=").Id("rootCmd").Dot("StringSlice").Call(jen.Lit("config")),
@@ -1454,6 +2142,7 @@ func generateLocalCallLogic(service *protogen.Service, method *protogen.Method,
 				jen.Qual("github.com/drewfead/proto-cli", "SingleCommandMode"),
 				jen.Qual("github.com/drewfead/proto-cli", "FileConfig").Call(jen.Id("configPaths").Op("...")),
 				jen.Qual("github.com/drewfead/proto-cli", "EnvPrefix").Call(jen.Id("envPrefix")),
+				jen.Qual("github.com/drewfead/proto-cli", "SecretDir").Call(jen.Id("rootCmd").Dot("String").Call(jen.Lit("secret-dir"))),
 			),
 			jen.Line(),
 			jen.Comment("Create config instance and load configuration"),
@@ -1470,6 +2159,7 @@ func generateLocalCallLogic(service *protogen.Service, method *protogen.Method,
 			),
 			jen.Line(),
 			jen.Comment("Call factory to create service implementation"),
+			timingsPhase("service call"),
 			jen.List(jen.Id("svcImpl"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "CallFactory").Call(
 				jen.Id("implOrFactory"),
 				jen.Id("config"),
@@ -1479,10 +2169,7 @@ func generateLocalCallLogic(service *protogen.Service, method *protogen.Method,
 			),
 			jen.Line(),
 			jen.Comment("Call the RPC method"),
-			jen.List(jen.Id("resp"), jen.Err()).Op("=").Id("svcImpl").Assert(jen.Id(service.GoName+"Server")).Dot(method.GoName).Call(
-				jen.Id("cmdCtx"),
-				jen.Id("req"),
-			),
+			localUnaryCall(service, method, jen.Id("svcImpl").Assert(jen.Id(service.GoName+"Server"))),
 			jen.If(jen.Err().Op("!=").Nil()).Block(
 				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("method failed: %w"), jen.Err())),
 			),
@@ -1491,11 +2178,9 @@ func generateLocalCallLogic(service *protogen.Service, method *protogen.Method,
 		// No config - direct implementation call
 		statements = append(statements,
 			jen.Comment("Direct implementation call (no config)"),
+			timingsPhase("service call"),
 			jen.Id("svcImpl").Op(":=").Id("implOrFactory").Assert(jen.Id(service.GoName+"Server")),
-			jen.List(jen.Id("resp"), jen.Err()).Op("=").Id("svcImpl").Dot(method.GoName).Call(
-				jen.Id("cmdCtx"),
-				jen.Id("req"),
-			),
+			localUnaryCall(service, method, jen.Id("svcImpl")),
 			jen.If(jen.Err().Op("!=").Nil()).Block(
 				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("method failed: %w"), jen.Err())),
 			),
@@ -1505,10 +2190,27 @@ func generateLocalCallLogic(service *protogen.Service, method *protogen.Method,
 	return statements
 }
 
+// localUnaryCall assigns resp and err from a local call of method on impl,
+// made through CallLocal so a panic in the implementation becomes an error.
+func localUnaryCall(service *protogen.Service, method *protogen.Method, impl *jen.Statement) jen.Code {
+	return jen.List(jen.Id("resp"), jen.Err()).Op("=").Qual("github.com/drewfead/proto-cli", "CallLocal").Call(
+		jen.Id("cmd"),
+		jen.Lit(localMethodName(service, method)),
+		jen.Func().Params().Params(jen.Op("*").Id(method.Output.GoIdent.GoName), jen.Error()).Block(
+			jen.Return(impl.Dot(method.GoName).Call(jen.Id("cmdCtx"), jen.Id("req"))),
+		),
+	)
+}
+
+// localMethodName names method in errors about a panicking local call.
+func localMethodName(service *protogen.Service, method *protogen.Method) string {
+	return service.GoName + "." + method.GoName
+}
+
 // generateTUIBeforeHook returns a jen func literal for the Before hook that
 // intercepts --interactive, collects explicitly-set flag values into a prefill
 // map, and calls InvokeTUI with StartAtMethod + WithPrefillFields.
-func generateTUIBeforeHook(method *protogen.Method, serviceCLIName, cmdName string) jen.Code {
+func generateTUIBeforeHook(method *protogen.Method, serviceCLIName, cmdName string, genOpts Options) jen.Code {
 	// Build the statements that populate the prefill map.
 	var prefillStmts []jen.Code
 	prefillStmts = append(prefillStmts,
@@ -1518,12 +2220,8 @@ func generateTUIBeforeHook(method *protogen.Method, serviceCLIName, cmdName stri
 		if field.Desc.IsList() {
 			continue // skip repeated fields; Appender handles multi-value inputs
 		}
-		flagName := toKebabCase(field.GoName)
-		flagOpts := getFieldFlagOptions(field)
-		if flagOpts != nil && flagOpts.Name != "" {
-			flagName = flagOpts.Name
-		}
-		valExpr := flagToStringExpr(field)
+		flagName := genOpts.fieldFlagName(field)
+		valExpr := flagToStringExpr(field, genOpts)
 		if valExpr == nil {
 			continue
 		}
@@ -1554,40 +2252,40 @@ func generateTUIBeforeHook(method *protogen.Method, serviceCLIName, cmdName stri
 		jen.Return(jen.Id("ctx"), jen.Qual("github.com/urfave/cli/v3", "Exit").Call(jen.Lit(""), jen.Lit(0))),
 	)
 
-	argsCheck := jen.If(
-		jen.Id("cmd").Dot("Args").Call().Dot("Len").Call().Op(">").Lit(0),
-	).Block(
-		jen.Return(
-			jen.Id("ctx"),
-			jen.Qual("github.com/urfave/cli/v3", "Exit").Call(
-				jen.Qual("fmt", "Sprintf").Call(
-					jen.Lit("unsupported argument: %q"),
-					jen.Id("cmd").Dot("Args").Call().Dot("Get").Call(jen.Lit(0)),
-				),
-				jen.Lit(3),
-			),
-		),
+	// Commands that need confirmation prompt once --interactive is ruled out
+	ret := jen.Return(jen.Id("ctx"), jen.Nil())
+	if getMethodCommandOptions(method).GetConfirm() {
+		ret = jen.Return(jen.Qual("github.com/drewfead/proto-cli", "ConfirmBefore").Call(jen.Id("ctx"), jen.Id("cmd")))
+	}
+
+	body := generateArgsCheck(method, func(err jen.Code) jen.Code { return jen.Return(jen.Id("ctx"), err) })
+	body = append(body,
+		jen.If(jen.Id("cmd").Dot("Bool").Call(jen.Lit("interactive"))).Block(interactiveBlock...),
+		ret,
 	)
 
 	return jen.Func().Params(
 		jen.Id("ctx").Qual("context", "Context"),
 		jen.Id("cmd").Op("*").Qual("github.com/urfave/cli/v3", "Command"),
-	).Params(jen.Qual("context", "Context"), jen.Error()).Block(
-		argsCheck,
-		jen.If(jen.Id("cmd").Dot("Bool").Call(jen.Lit("interactive"))).Block(interactiveBlock...),
-		jen.Return(jen.Id("ctx"), jen.Nil()),
-	)
+	).Params(jen.Qual("context", "Context"), jen.Error()).Block(body...)
+}
+
+// confirmFlagUsage is the help text of the --yes flag.
+const confirmFlagUsage = "Run without asking for confirmation (required when stdin is not a terminal)"
+
+// confirmFlag is the --yes flag of commands annotated with cli.command.confirm.
+func confirmFlag() jen.Code {
+	return jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+		jen.Id("Name"):  jen.Lit("yes"),
+		jen.Id("Usage"): jen.Lit(confirmFlagUsage),
+	})
 }
 
 // flagToStringExpr returns a jen expression that converts a (non-list) field's CLI
 // flag value to a string suitable for TUI form prefill. Returns nil for unsupported kinds.
 // Message, string, enum, and bytes fields are read directly as strings from their StringFlag.
-func flagToStringExpr(field *protogen.Field) jen.Code {
-	flagName := toKebabCase(field.GoName)
-	flagOpts := getFieldFlagOptions(field)
-	if flagOpts != nil && flagOpts.Name != "" {
-		flagName = flagOpts.Name
-	}
+func flagToStringExpr(field *protogen.Field, genOpts Options) jen.Code {
+	flagName := genOpts.fieldFlagName(field)
 
 	switch field.Desc.Kind() {
 	case protoreflect.StringKind, protoreflect.EnumKind, protoreflect.MessageKind, protoreflect.BytesKind:
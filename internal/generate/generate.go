@@ -1,46 +1,37 @@
 package generate
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/dave/jennifer/jen"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-// GenerateFile generates CLI code for all services in a proto file.
-func GenerateFile(gen *protogen.Plugin, file *protogen.File) {
+// GenerateFile generates CLI code for all services in a proto file. It fails
+// when a request field's flag clashes with a global or built-in flag.
+func GenerateFile(gen *protogen.Plugin, file *protogen.File, genOpts Options) error {
 	if len(file.Services) == 0 {
-		return
+		return nil
+	}
+	if err := genOpts.checkFieldFlagNames(file); err != nil {
+		return err
 	}
 
 	filename := file.GeneratedFilenamePrefix + "_cli.pb.go"
 
-	// Create one jen file for all services in this proto file
-	f := jen.NewFile(string(file.GoPackageName))
-	f.HeaderComment("Code generated by protoc-gen-cli. DO NOT EDIT.")
-	f.Line()
+	// Create one jen file for all services in this proto file. With split_output
+	// it only holds the shared helpers and each service gets its own file.
+	f := newGeneratedJenFile(file)
+
+	cobra := genOpts.CLIFramework == CLIFrameworkCobra
 
 	// Generate per-service helpers and CLI code
 	for _, service := range file.Services {
-		// Generate service-prefixed output writer function
-		funcName := outputWriterFuncName(service)
-		f.Commentf("%s opens the specified output file or returns cmd.Writer (if set) or stdout", funcName)
-		f.Func().Id(funcName).Params(
-			jen.Id("cmd").Op("*").Qual("github.com/urfave/cli/v3", "Command"),
-			jen.Id("path").String(),
-		).Params(jen.Qual("io", "Writer"), jen.Error()).Block(
-			jen.If(jen.Id("path").Op("==").Lit("-").Op("||").Id("path").Op("==").Lit("")).Block(
-				jen.Comment("Use cmd.Writer if set, otherwise try root command's Writer, otherwise stdout"),
-				jen.If(jen.Id("cmd").Dot("Writer").Op("!=").Nil()).Block(
-					jen.Return(jen.Id("cmd").Dot("Writer"), jen.Nil()),
-				),
-				jen.If(jen.Id("cmd").Dot("Root").Call().Dot("Writer").Op("!=").Nil()).Block(
-					jen.Return(jen.Id("cmd").Dot("Root").Call().Dot("Writer"), jen.Nil()),
-				),
-				jen.Return(jen.Qual("os", "Stdout"), jen.Nil()),
-			),
-			jen.Return(jen.Qual("os", "Create").Call(jen.Id("path"))),
-		)
-		f.Line()
+		if !cobra {
+			generateOutputWriterFunc(f, service)
+		}
 
 		// Collect enums used by this service
 		enumsUsed := make(map[string]*protogen.Enum)
@@ -71,25 +62,186 @@ func GenerateFile(gen *protogen.Plugin, file *protogen.File) {
 		for _, enum := range enumsUsed {
 			generateEnumParser(f, service, enum)
 		}
+		generateFieldPatterns(f, service)
 
-		// Generate service-prefixed local stream wrapper types
-		for _, method := range service.Methods {
-			if method.Desc.IsStreamingServer() && !method.Desc.IsStreamingClient() {
-				generateLocalStreamWrapper(f, service, method)
+		serviceFile := f
+		if genOpts.SplitOutput.splits() {
+			serviceFile = newGeneratedJenFile(file)
+		}
+
+		if cobra {
+			generateCobraServiceCLI(serviceFile, file, service, genOpts)
+		} else {
+			// Generate service-prefixed local stream wrapper types and the
+			// typed channel helpers built on them
+			for _, method := range service.Methods {
+				if method.Desc.IsStreamingServer() && !method.Desc.IsStreamingClient() {
+					generateLocalStreamWrapper(f, service, method)
+					generateLocalStreamFunc(f, file, service, method)
+				}
+			}
+
+			// Generate CLI for this service
+			generateServiceCLI(serviceFile, file, service, genOpts)
+
+			if genOpts.SplitOutput == SplitOutputPerMethod {
+				generateMethodCommandFiles(gen, file, service, genOpts)
 			}
 		}
 
-		// Generate CLI for this service
-		generateServiceCLI(f, file, service)
+		generateCLIClient(serviceFile, service)
+
+		if serviceFile != f {
+			writeJenFile(gen, file, serviceFile, splitFileName(file, service.GoName))
+		}
+	}
+
+	if genOpts.AllServices {
+		if cobra {
+			generateAllServicesCobraCLI(f, file)
+		} else {
+			generateAllServicesCLI(f, file)
+		}
 	}
 
 	// Write the generated code
-	content := f.GoString()
+	writeJenFile(gen, file, f, filename)
+
+	if genOpts.Mocks {
+		generateMockFile(gen, file)
+	}
+	return nil
+}
+
+// newGeneratedJenFile returns an empty jen file in file's Go package carrying
+// the generated-code header.
+func newGeneratedJenFile(file *protogen.File) *jen.File {
+	f := jen.NewFile(string(file.GoPackageName))
+	f.HeaderComment("Code generated by protoc-gen-cli. DO NOT EDIT.")
+	f.Line()
+	return f
+}
+
+// writeJenFile renders f into a generated file named filename.
+func writeJenFile(gen *protogen.Plugin, file *protogen.File, f *jen.File, filename string) {
 	g := gen.NewGeneratedFile(filename, file.GoImportPath)
-	g.P(content)
+	g.P(f.GoString())
+}
+
+// splitFileName returns the output file for a split-out part of file, e.g.
+// "example_user_service_get_user_cli.pb.go" for parts UserService, GetUser.
+func splitFileName(file *protogen.File, parts ...string) string {
+	name := file.GeneratedFilenamePrefix
+	for _, part := range parts {
+		name += "_" + strings.ReplaceAll(toKebabCase(part), "-", "_")
+	}
+	return name + "_cli.pb.go"
 }
 
-func generateServiceCLI(f *jen.File, file *protogen.File, service *protogen.Service) {
+// generateMethodCommandFiles writes one file per method of service, each holding
+// the builder that generateMethodCommands calls in per-method mode.
+func generateMethodCommandFiles(gen *protogen.Plugin, file *protogen.File, service *protogen.Service, genOpts Options) {
+	var configMessageType string
+	if configOpts := getServiceConfigOptions(service); configOpts != nil {
+		configMessageType = configOpts.ConfigMessage
+	}
+
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() {
+			continue
+		}
+
+		// Grouped methods append to their group's variable
+		commandsVar := commandsVarName(methodCommandGroup(service, method))
+		var statements []jen.Code
+		statements = append(statements,
+			jen.Var().Id(commandsVar).Index().Op("*").Qual("github.com/urfave/cli/v3", "Command"),
+			jen.Line(),
+		)
+		if method.Desc.IsStreamingServer() {
+			statements = append(statements, generateServerStreamingCommand(service, method, configMessageType, file, genOpts)...)
+		} else {
+			statements = append(statements, generateMethodCommand(service, method, configMessageType, file, genOpts)...)
+		}
+		statements = append(statements, jen.Return(jen.Id(commandsVar)))
+
+		funcName := methodCommandsFuncName(service, method)
+		mf := newGeneratedJenFile(file)
+		mf.Commentf("%s builds the CLI command for %s.%s", funcName, service.GoName, method.GoName)
+		mf.Func().Id(funcName).Params(
+			jen.Id("ctx").Qual("context", "Context"),
+			jen.Id("implOrFactory").Interface(),
+			jen.Id("options").Qual("github.com/drewfead/proto-cli", "ServiceConfig"),
+			jen.Id("defaultFormat").String(),
+		).Index().Op("*").Qual("github.com/urfave/cli/v3", "Command").Block(statements...)
+		writeJenFile(gen, file, mf, splitFileName(file, service.GoName, method.GoName))
+	}
+}
+
+// generateOutputWriterFunc emits the service-prefixed output writer helper used by
+// urfave/cli actions.
+func generateOutputWriterFunc(f *jen.File, service *protogen.Service) {
+	funcName := outputWriterFuncName(service)
+	f.Commentf("%s opens the specified output file or returns cmd.Writer (if set) or stdout", funcName)
+	f.Func().Id(funcName).Params(
+		jen.Id("cmd").Op("*").Qual("github.com/urfave/cli/v3", "Command"),
+		jen.Id("path").String(),
+	).Params(jen.Qual("io", "Writer"), jen.Error()).Block(
+		jen.If(jen.Id("path").Op("==").Lit("-").Op("||").Id("path").Op("==").Lit("")).Block(
+			jen.Comment("Use cmd.Writer if set, otherwise try root command's Writer, otherwise stdout"),
+			jen.If(jen.Id("cmd").Dot("Writer").Op("!=").Nil()).Block(
+				jen.Return(jen.Id("cmd").Dot("Writer"), jen.Nil()),
+			),
+			jen.If(jen.Id("cmd").Dot("Root").Call().Dot("Writer").Op("!=").Nil()).Block(
+				jen.Return(jen.Id("cmd").Dot("Root").Call().Dot("Writer"), jen.Nil()),
+			),
+			jen.Return(jen.Qual("os", "Stdout"), jen.Nil()),
+		),
+		jen.List(jen.Id("path"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "OutputPath").Call(jen.Id("cmd"), jen.Id("path")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Nil(), jen.Err()),
+		),
+		jen.Return(jen.Qual("github.com/drewfead/proto-cli", "OpenOutputFile").Call(
+			jen.Id("path"),
+			jen.Id("cmd").Dot("String").Call(jen.Lit("output-mode")),
+			jen.Id("cmd").Dot("Bool").Call(jen.Lit("output-append")),
+		)),
+	)
+	f.Line()
+}
+
+// generateAllServicesCLI emits BuildAllServicesCLI, a builder that registers every
+// service in the file with a root command so callers don't assemble it by hand.
+func generateAllServicesCLI(f *jen.File, file *protogen.File) {
+	params := []jen.Code{
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("appName").String(),
+	}
+	var serviceOpts []jen.Code
+	for _, service := range file.Services {
+		implParam := toLowerCamelCase(service.GoName) + "Impl"
+		params = append(params, jen.Id(implParam).Interface())
+		serviceOpts = append(serviceOpts, jen.Qual("github.com/drewfead/proto-cli", "Service").Call(
+			jen.Id(service.GoName+"Command").Call(jen.Id("ctx"), jen.Id(implParam)),
+		))
+	}
+	params = append(params, jen.Id("opts").Op("...").Qual("github.com/drewfead/proto-cli", "RootOption"))
+
+	f.Comment("BuildAllServicesCLI creates a root command with every service in this file registered.")
+	f.Comment("Each impl parameter can be either a direct service implementation or a factory function.")
+	f.Comment("Additional root options are applied after the service registrations.")
+	f.Func().Id("BuildAllServicesCLI").Params(params...).Params(
+		jen.Op("*").Qual("github.com/urfave/cli/v3", "Command"),
+		jen.Error(),
+	).Block(
+		jen.Id("rootOpts").Op(":=").Index().Qual("github.com/drewfead/proto-cli", "RootOption").Values(serviceOpts...),
+		jen.Id("rootOpts").Op("=").Append(jen.Id("rootOpts"), jen.Id("opts").Op("...")),
+		jen.Return(jen.Qual("github.com/drewfead/proto-cli", "RootCommand").Call(jen.Id("appName"), jen.Id("rootOpts").Op("..."))),
+	)
+	f.Line()
+}
+
+func generateServiceCLI(f *jen.File, file *protogen.File, service *protogen.Service, genOpts Options) {
 	// Generate the Command function
 	funcName := service.GoName + "Command"
 
@@ -100,7 +252,7 @@ func generateServiceCLI(f *jen.File, file *protogen.File, service *protogen.Serv
 		jen.Id("implOrFactory").Interface(),
 		jen.Id("opts").Op("...").Qual("github.com/drewfead/proto-cli", "ServiceOption"),
 	).Op("*").Qual("github.com/drewfead/proto-cli", "ServiceCLI").Block(
-		generateServiceCommands(file, service)...,
+		generateServiceCommands(file, service, genOpts)...,
 	)
 	f.Line()
 
@@ -116,12 +268,140 @@ func generateServiceCLI(f *jen.File, file *protogen.File, service *protogen.Serv
 		jen.Id("implOrFactory").Interface(),
 		jen.Id("opts").Op("...").Qual("github.com/drewfead/proto-cli", "ServiceOption"),
 	).Index().Op("*").Qual("github.com/urfave/cli/v3", "Command").Block(
-		generateServiceCommandsFlat(file, service)...,
+		generateServiceCommandsFlat(file, service, genOpts)...,
 	)
 	f.Line()
 }
 
-func generateServiceCommands(file *protogen.File, service *protogen.Service) []jen.Code {
+// generateFormatsCommand returns the statements appending the `formats` command,
+// which lists the service's registered formats, to a flat command list unless
+// one of the service's method commands already uses the name. Nested services
+// are listed by the root formats command instead.
+func generateFormatsCommand(service *protogen.Service) []jen.Code {
+	for _, method := range service.Methods {
+		if methodCommandName(method) == "formats" || slices.Contains(methodCommandAliases(service, method), "formats") {
+			return nil
+		}
+	}
+	return []jen.Code{
+		jen.Comment("List the registered output and input formats"),
+		jen.Id("commands").Op("=").Append(
+			jen.Id("commands"),
+			jen.Qual("github.com/drewfead/proto-cli", "NewFormatsCommand").Call(jen.Id("options")),
+		),
+		jen.Line(),
+	}
+}
+
+// generateValidateCommand returns the statements appending the `validate`
+// command, which checks request files against each method's request message
+// without calling the service, to a flat command list unless one of the
+// service's method commands already uses the name.
+func generateValidateCommand(file *protogen.File, service *protogen.Service, genOpts Options) []jen.Code {
+	for _, method := range service.Methods {
+		if methodCommandName(method) == "validate" || slices.Contains(methodCommandAliases(service, method), "validate") {
+			return nil
+		}
+	}
+	methodCommands := generateValidateMethodCommands(file, service, genOpts)
+	if len(methodCommands) == 0 {
+		return nil
+	}
+	return []jen.Code{
+		jen.Comment("Check request files without calling the service"),
+		jen.Id("commands").Op("=").Append(
+			jen.Id("commands"),
+			jen.Qual("github.com/drewfead/proto-cli", "NewValidateCommand").Custom(jen.Options{
+				Open: "(", Close: ")", Separator: ",", Multi: true,
+			}, methodCommands...),
+		),
+		jen.Line(),
+	}
+}
+
+// generateValidateMethodCommands returns a validate subcommand for each method
+// that takes a single request.
+func generateValidateMethodCommands(file *protogen.File, service *protogen.Service, genOpts Options) []jen.Code {
+	var methodCommands []jen.Code
+	for _, method := range orderedMethods(service) {
+		if method.Desc.IsStreamingClient() {
+			continue
+		}
+		methodCommands = append(methodCommands, generateValidateMethodCommand(file, service, method, genOpts))
+	}
+	return methodCommands
+}
+
+// generateMethodCommands returns the statements appending each method's command
+// to commands, along with the local-only method paths for server-side enforcement.
+// In per-method split mode the commands are built by per-method functions.
+func generateMethodCommands(file *protogen.File, service *protogen.Service, configMessageType string, genOpts Options) ([]jen.Code, []string) {
+	var statements []jen.Code
+	var localOnlyMethods []string
+	var groups []string
+
+	for _, method := range orderedMethods(service) {
+		isClientStreaming := method.Desc.IsStreamingClient()
+		isServerStreaming := method.Desc.IsStreamingServer()
+
+		if isClientStreaming {
+			// Skip client streaming and bidi for Phase 1
+			continue
+		}
+
+		// Declare the commands of each group before its first method
+		group := methodCommandGroup(service, method)
+		if group != "" && !slices.Contains(groups, group) {
+			groups = append(groups, group)
+			statements = append(statements,
+				jen.Var().Id(commandsVarName(group)).Index().Op("*").Qual("github.com/urfave/cli/v3", "Command"),
+			)
+		}
+
+		// Check if method is local-only
+		if cmdOpts := getMethodCommandOptions(method); cmdOpts != nil && cmdOpts.GetLocalOnly() {
+			fullPath := "/" + string(service.Desc.FullName()) + "/" + string(method.Desc.Name())
+			localOnlyMethods = append(localOnlyMethods, fullPath)
+		}
+
+		switch {
+		case genOpts.SplitOutput == SplitOutputPerMethod:
+			statements = append(statements,
+				jen.Id(commandsVarName(group)).Op("=").Append(
+					jen.Id(commandsVarName(group)),
+					jen.Id(methodCommandsFuncName(service, method)).Call(
+						jen.Id("ctx"), jen.Id("implOrFactory"), jen.Id("options"), jen.Id("defaultFormat"),
+					).Op("..."),
+				),
+			)
+		case isServerStreaming:
+			// Generate server streaming command
+			statements = append(statements, generateServerStreamingCommand(service, method, configMessageType, file, genOpts)...)
+		default:
+			// Generate unary command (existing logic)
+			statements = append(statements, generateMethodCommand(service, method, configMessageType, file, genOpts)...)
+		}
+	}
+
+	// Nest the commands of each group under a group command
+	for _, group := range groups {
+		statements = append(statements,
+			jen.Id("commands").Op("=").Append(
+				jen.Id("commands"),
+				jen.Op("&").Qual("github.com/urfave/cli/v3", "Command").Values(jen.Dict{
+					jen.Id("Name"):     jen.Lit(group),
+					jen.Id("Usage"):    jen.Lit(toTitleCase(group) + " commands"),
+					jen.Id("Commands"): jen.Id(commandsVarName(group)),
+				}),
+			),
+			jen.Line(),
+		)
+	}
+
+	return statements, localOnlyMethods
+}
+
+func generateServiceCommands(file *protogen.File, service *protogen.Service, genOpts Options) []jen.Code {
 	var statements []jen.Code
 
 	// Check for service config annotation
@@ -153,33 +433,9 @@ func generateServiceCommands(file *protogen.File, service *protogen.Service) []j
 		jen.Line(),
 	)
 
-	// Collect local-only method paths for server-side enforcement
-	var localOnlyMethods []string
-
 	// Generate command for each method
-	for _, method := range service.Methods {
-		isClientStreaming := method.Desc.IsStreamingClient()
-		isServerStreaming := method.Desc.IsStreamingServer()
-
-		if isClientStreaming {
-			// Skip client streaming and bidi for Phase 1
-			continue
-		}
-
-		// Check if method is local-only
-		if cmdOpts := getMethodCommandOptions(method); cmdOpts != nil && cmdOpts.GetLocalOnly() {
-			fullPath := "/" + string(service.Desc.FullName()) + "/" + string(method.Desc.Name())
-			localOnlyMethods = append(localOnlyMethods, fullPath)
-		}
-
-		if isServerStreaming {
-			// Generate server streaming command
-			statements = append(statements, generateServerStreamingCommand(service, method, configMessageType, file)...)
-		} else {
-			// Generate unary command (existing logic)
-			statements = append(statements, generateMethodCommand(service, method, configMessageType, file)...)
-		}
-	}
+	methodStatements, localOnlyMethods := generateMethodCommands(file, service, configMessageType, genOpts)
+	statements = append(statements, methodStatements...)
 
 	// Get service name and help fields from annotation or use defaults
 	serviceName := toKebabCase(service.GoName)
@@ -220,6 +476,9 @@ func generateServiceCommands(file *protogen.File, service *protogen.Service) []j
 		jen.Id("Name"):     jen.Lit(serviceName),
 		jen.Id("Usage"):    jen.Lit(serviceDescription),
 		jen.Id("Commands"): jen.Id("commands"),
+		jen.Id("Metadata"): jen.Map(jen.String()).Any().Values(jen.Dict{
+			jen.Qual("github.com/drewfead/proto-cli", "ServiceMetadataKey"): jen.Lit(serviceName),
+		}),
 	}
 
 	// For TUI-enabled services, add --interactive flag and a Before hook that
@@ -237,18 +496,10 @@ func generateServiceCommands(file *protogen.File, service *protogen.Service) []j
 			jen.Id("cmd").Op("*").Qual("github.com/urfave/cli/v3", "Command"),
 		).Params(jen.Qual("context", "Context"), jen.Error()).Block(
 			jen.If(
-				jen.Id("cmd").Dot("Args").Call().Dot("Len").Call().Op(">").Lit(0),
+				jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "CheckArgs").Call(jen.Id("cmd")),
+				jen.Err().Op("!=").Nil(),
 			).Block(
-				jen.Return(
-				jen.Id("ctx"),
-				jen.Qual("github.com/urfave/cli/v3", "Exit").Call(
-					jen.Qual("fmt", "Sprintf").Call(
-						jen.Lit("unsupported argument: %q"),
-						jen.Id("cmd").Dot("Args").Call().Dot("Get").Call(jen.Lit(0)),
-					),
-					jen.Lit(3),
-				),
-			),
+				jen.Return(jen.Id("ctx"), jen.Err()),
 			),
 			jen.If(jen.Id("cmd").Dot("Bool").Call(jen.Lit("interactive"))).Block(
 				jen.If(
@@ -275,6 +526,9 @@ func generateServiceCommands(file *protogen.File, service *protogen.Service) []j
 	if serviceArgsUsage != "" {
 		serviceCommandDict[jen.Id("ArgsUsage")] = jen.Lit(serviceArgsUsage)
 	}
+	if aliases := serviceOpts.GetAliases(); len(aliases) > 0 {
+		serviceCommandDict[jen.Id("Aliases")] = aliasesCode(aliases)
+	}
 
 	// Build the ServiceCLI dict
 	serviceCLIDict := jen.Dict{
@@ -298,6 +552,21 @@ func generateServiceCommands(file *protogen.File, service *protogen.Service) []j
 		serviceCLIDict[jen.Id("ConfigPrototype")] = jen.Op("&").Id(configMessageType).Values()
 	}
 
+	// Add the formats and request checks that RootCommand lists under its own
+	// formats and validate commands
+	serviceCLIDict[jen.Id("OutputFormats")] = jen.Id("options").Dot("OutputFormats").Call()
+	serviceCLIDict[jen.Id("InputFormats")] = jen.Id("options").Dot("InputFormats").Call()
+	if validateCommands := generateValidateMethodCommands(file, service, genOpts); len(validateCommands) > 0 {
+		serviceCLIDict[jen.Id("ValidateCommands")] = jen.Index().Op("*").Qual("github.com/urfave/cli/v3", "Command").Custom(jen.Options{
+			Open: "{", Close: "}", Separator: ",", Multi: true,
+		}, validateCommands...)
+	}
+
+	// Add Order so RootCommand can sort services for display
+	if order := serviceOpts.GetOrder(); order != 0 {
+		serviceCLIDict[jen.Id("Order")] = jen.Lit(int(order))
+	}
+
 	// Add LocalOnlyMethods if any methods are marked local-only
 	if len(localOnlyMethods) > 0 {
 		methodLiterals := make([]jen.Code, len(localOnlyMethods))
@@ -307,8 +576,13 @@ func generateServiceCommands(file *protogen.File, service *protogen.Service) []j
 		serviceCLIDict[jen.Id("LocalOnlyMethods")] = jen.Index().String().Values(methodLiterals...)
 	}
 
+	// Add RootFlags if the file declares global flags
+	if rootFlags := generateRootFlags(file); rootFlags != nil {
+		serviceCLIDict[jen.Id("RootFlags")] = rootFlags
+	}
+
 	// Add TUIDescriptor if service has tui=true annotation
-	if tuiDesc := generateTUIDescriptor(file, service); tuiDesc != nil {
+	if tuiDesc := generateTUIDescriptor(file, service, genOpts); tuiDesc != nil {
 		serviceCLIDict[jen.Id("TUIDescriptor")] = tuiDesc
 	}
 
@@ -320,7 +594,7 @@ func generateServiceCommands(file *protogen.File, service *protogen.Service) []j
 	return statements
 }
 
-func generateServiceCommandsFlat(file *protogen.File, service *protogen.Service) []jen.Code {
+func generateServiceCommandsFlat(file *protogen.File, service *protogen.Service, genOpts Options) []jen.Code {
 	var statements []jen.Code
 
 	// Check for service config annotation
@@ -352,33 +626,9 @@ func generateServiceCommandsFlat(file *protogen.File, service *protogen.Service)
 		jen.Line(),
 	)
 
-	// Collect local-only method paths for server-side enforcement
-	var localOnlyMethods []string
-
 	// Generate command for each method
-	for _, method := range service.Methods {
-		isClientStreaming := method.Desc.IsStreamingClient()
-		isServerStreaming := method.Desc.IsStreamingServer()
-
-		if isClientStreaming {
-			// Skip client streaming and bidi for Phase 1
-			continue
-		}
-
-		// Check if method is local-only
-		if cmdOpts := getMethodCommandOptions(method); cmdOpts != nil && cmdOpts.GetLocalOnly() {
-			fullPath := "/" + string(service.Desc.FullName()) + "/" + string(method.Desc.Name())
-			localOnlyMethods = append(localOnlyMethods, fullPath)
-		}
-
-		if isServerStreaming {
-			// Generate server streaming command
-			statements = append(statements, generateServerStreamingCommand(service, method, configMessageType, file)...)
-		} else {
-			// Generate unary command (existing logic)
-			statements = append(statements, generateMethodCommand(service, method, configMessageType, file)...)
-		}
-	}
+	methodStatements, localOnlyMethods := generateMethodCommands(file, service, configMessageType, genOpts)
+	statements = append(statements, methodStatements...)
 
 	// Get service name and register func
 	serviceName := toKebabCase(service.GoName)
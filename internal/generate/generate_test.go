@@ -0,0 +1,780 @@
+package generate
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/drewfead/proto-cli/examples/streaming"
+	annotations "github.com/drewfead/proto-cli/proto/cli/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// assertGolden compares got with testdata/<name>.golden, rewriting it when -update is set.
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.MkdirAll("testdata", 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o600))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), got)
+}
+
+// generateForTest runs the generator over fd as protoc would and returns the
+// contents of the generated _cli.pb.go file.
+func generateForTest(t *testing.T, fd protoreflect.FileDescriptor, opts Options) string {
+	t.Helper()
+	files := generateFilesForTest(t, fd, opts)
+	require.Len(t, files, 1)
+	for _, content := range files {
+		return content
+	}
+	return ""
+}
+
+// generateFilesForTest runs the generator over fd and returns every generated
+// file's contents keyed by file name.
+func generateFilesForTest(t *testing.T, fd protoreflect.FileDescriptor, opts Options) map[string]string {
+	t.Helper()
+
+	// Dependencies must precede the files that import them.
+	var files []*descriptorpb.FileDescriptorProto
+	seen := map[string]bool{}
+	var add func(protoreflect.FileDescriptor)
+	add = func(f protoreflect.FileDescriptor) {
+		if seen[f.Path()] {
+			return
+		}
+		seen[f.Path()] = true
+		for i := 0; i < f.Imports().Len(); i++ {
+			add(f.Imports().Get(i).FileDescriptor)
+		}
+		files = append(files, protodesc.ToFileDescriptorProto(f))
+	}
+	add(fd)
+	return generateProtosForTest(t, files, opts)
+}
+
+// generateProtosForTest runs the generator over the last of files, which must
+// be ordered so that dependencies come first.
+func generateProtosForTest(t *testing.T, files []*descriptorpb.FileDescriptorProto, opts Options) map[string]string {
+	t.Helper()
+
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{files[len(files)-1].GetName()},
+		ProtoFile:      files,
+	})
+	require.NoError(t, err)
+
+	for _, f := range gen.Files {
+		if f.Generate {
+			require.NoError(t, GenerateFile(gen, f, opts))
+		}
+	}
+
+	resp := gen.Response()
+	require.Empty(t, resp.GetError())
+	out := make(map[string]string, len(resp.GetFile()))
+	for _, f := range resp.GetFile() {
+		out[filepath.Base(f.GetName())] = f.GetContent()
+	}
+	return out
+}
+
+// generateErrorForTest runs the generator over files and returns the error of
+// the last one.
+func generateErrorForTest(t *testing.T, files []*descriptorpb.FileDescriptorProto, opts Options) error {
+	t.Helper()
+
+	gen, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{files[len(files)-1].GetName()},
+		ProtoFile:      files,
+	})
+	require.NoError(t, err)
+	return GenerateFile(gen, gen.FilesByPath[files[len(files)-1].GetName()], opts)
+}
+
+func TestGenerateFile_FlagCase(t *testing.T) {
+	tests := []struct {
+		flagCase FlagCase
+		want     []string
+	}{
+		{flagCase: "", want: []string{"phone-number", "include-details", "registration-date"}},
+		{flagCase: FlagCaseKebab, want: []string{"phone-number", "include-details", "registration-date"}},
+		{flagCase: FlagCaseSnake, want: []string{"phone_number", "include_details", "registration_date"}},
+		{flagCase: FlagCaseCamel, want: []string{"phoneNumber", "includeDetails", "registrationDate"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flagCase.String(), func(t *testing.T) {
+			content := generateForTest(t, simple.File_examples_simple_example_proto, Options{FlagCase: tt.flagCase})
+
+			for _, name := range tt.want {
+				assert.Regexp(t, `Name:\s+"`+name+`"`, content)
+				assert.Contains(t, content, `cmd.IsSet("`+name+`")`)
+			}
+			// Annotation-provided names are used verbatim regardless of casing
+			assert.Regexp(t, `Name:\s+"log-level"`, content)
+			assert.Contains(t, content, `cmd.IsSet("fields")`)
+			assert.Contains(t, content, `cmd.Int32("timeout")`)
+		})
+	}
+}
+
+func TestFlagCase_Set(t *testing.T) {
+	var c FlagCase
+	require.NoError(t, c.Set("snake"))
+	assert.Equal(t, FlagCaseSnake, c)
+
+	require.Error(t, c.Set("SCREAMING"))
+	assert.Equal(t, FlagCaseSnake, c)
+}
+
+func TestGenerateFile_AllServices(t *testing.T) {
+	content := generateForTest(t, simple.File_examples_simple_example_proto, Options{AllServices: true})
+
+	start := strings.Index(content, "// BuildAllServicesCLI")
+	require.GreaterOrEqual(t, start, 0, "BuildAllServicesCLI not generated")
+	end := strings.Index(content[start:], "\n}\n")
+	require.GreaterOrEqual(t, end, 0)
+
+	assertGolden(t, "all_services", content[start:start+end+3])
+}
+
+func TestGenerateFile_AllServicesDisabled(t *testing.T) {
+	content := generateForTest(t, simple.File_examples_simple_example_proto, Options{})
+	assert.NotContains(t, content, "BuildAllServicesCLI")
+}
+
+func TestGenerateFile_FileFlags(t *testing.T) {
+	content := generateForTest(t, simple.File_examples_simple_example_proto, Options{})
+	assert.Contains(t, content, `protocli.ReadFileFlagValue(cmd.String("bio"))`, "allow_file fields accept @file")
+	assert.NotContains(t, content, `protocli.ReadFileFlagValue(cmd.String("nickname"))`)
+
+	content = generateForTest(t, simple.File_examples_simple_example_proto, Options{FileFlags: true})
+	assert.Contains(t, content, `protocli.ReadFileFlagValue(cmd.String("nickname"))`, "file_flags applies to every string field")
+	assert.Contains(t, content, `protocli.ReadFileFlagValue(cmd.String("phone-number"))`)
+}
+
+func TestGenerateFile_CobraFramework(t *testing.T) {
+	content := generateForTest(t, simple.File_examples_simple_example_proto, Options{CLIFramework: CLIFrameworkCobra})
+
+	assert.NotContains(t, content, "github.com/urfave/cli/v3")
+	assertGolden(t, "cobra_simple", content)
+}
+
+func TestCLIFramework_Set(t *testing.T) {
+	var c CLIFramework
+	assert.Equal(t, "urfave", c.String())
+	require.NoError(t, c.Set("cobra"))
+	assert.Equal(t, CLIFrameworkCobra, c)
+
+	require.Error(t, c.Set("kingpin"))
+	assert.Equal(t, CLIFrameworkCobra, c)
+}
+
+func TestGenerateFile_Mocks(t *testing.T) {
+	files := generateFilesForTest(t, simple.File_examples_simple_example_proto, Options{Mocks: true})
+
+	require.Contains(t, files, "example_cli.pb.go")
+	require.Contains(t, files, "example_cli_mock.pb.go")
+	assertGolden(t, "mocks", files["example_cli_mock.pb.go"])
+}
+
+func TestGenerateFile_SplitOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		split     SplitOutput
+		wantFiles []string
+	}{
+		{
+			name:      "none",
+			split:     SplitOutputNone,
+			wantFiles: []string{"example_cli.pb.go"},
+		},
+		{
+			name:  "per-service",
+			split: SplitOutputPerService,
+			wantFiles: []string{
+				"example_cli.pb.go",
+				"example_user_service_cli.pb.go",
+				"example_admin_service_cli.pb.go",
+			},
+		},
+		{
+			name:  "per-method",
+			split: SplitOutputPerMethod,
+			wantFiles: []string{
+				"example_cli.pb.go",
+				"example_user_service_cli.pb.go",
+				"example_user_service_get_user_cli.pb.go",
+				"example_user_service_create_user_cli.pb.go",
+				"example_admin_service_cli.pb.go",
+				"example_admin_service_health_check_cli.pb.go",
+				"example_admin_service_ping_cli.pb.go",
+				"example_admin_service_diagnostics_cli.pb.go",
+				"example_admin_service_run_check_cli.pb.go",
+				"example_admin_service_purge_cache_cli.pb.go",
+				"example_admin_service_exec_cli.pb.go",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := generateFilesForTest(t, simple.File_examples_simple_example_proto, Options{SplitOutput: tt.split, AllServices: true})
+
+			var names []string
+			var all strings.Builder
+			for name, content := range files {
+				names = append(names, name)
+				all.WriteString(content)
+			}
+			assert.ElementsMatch(t, tt.wantFiles, names)
+
+			// Shared helpers are emitted exactly once, in the common file
+			common := files["example_cli.pb.go"]
+			for _, helper := range []string{
+				"func parseUserServiceLogLevel(",
+				"func getUserServiceOutputWriter(",
+				"func BuildAllServicesCLI(",
+			} {
+				assert.Equal(t, 1, strings.Count(all.String(), helper), helper)
+				assert.Contains(t, common, helper)
+			}
+
+			if tt.split == SplitOutputNone {
+				return
+			}
+			assert.NotContains(t, common, "func UserServiceCommand(")
+			assert.Contains(t, files["example_user_service_cli.pb.go"], "func UserServiceCommand(")
+			assert.Contains(t, files["example_user_service_cli.pb.go"], "func UserServiceCommandsFlat(")
+		})
+	}
+}
+
+func TestGenerateFile_SplitOutputPerMethod(t *testing.T) {
+	files := generateFilesForTest(t, simple.File_examples_simple_example_proto, Options{SplitOutput: SplitOutputPerMethod})
+
+	serviceFile := files["example_user_service_cli.pb.go"]
+	assert.Equal(t, 2, strings.Count(serviceFile, "userServiceGetUserCommands(ctx, implOrFactory, options, defaultFormat)..."),
+		"nested and flat builders both call the per-method builder")
+	assert.NotContains(t, serviceFile, "GetUser(cmdCtx, req)")
+
+	methodFile := files["example_user_service_get_user_cli.pb.go"]
+	assert.Contains(t, methodFile, "func userServiceGetUserCommands(ctx context.Context, implOrFactory interface{}, options protocli.ServiceConfig, defaultFormat string) []*v3.Command")
+	assert.Contains(t, methodFile, "GetUser(cmdCtx, req)")
+	assert.NotContains(t, methodFile, "CreateUser(cmdCtx, req)")
+}
+
+func TestSplitOutput_Set(t *testing.T) {
+	var s SplitOutput
+	assert.Equal(t, "none", s.String())
+	require.NoError(t, s.Set("per-method"))
+	assert.Equal(t, SplitOutputPerMethod, s)
+
+	require.Error(t, s.Set("per-file"))
+	assert.Equal(t, SplitOutputPerMethod, s)
+}
+
+// commentedServiceProto returns a file with one unary and one server-streaming
+// method, each led by the given comment.
+func commentedServiceProto(comment string) *descriptorpb.FileDescriptorProto {
+	msg := func(name string) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("name"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				JsonName: proto.String("name"),
+			}},
+		}
+	}
+	return &descriptorpb.FileDescriptorProto{
+		Name:        proto.String("comments/comments.proto"),
+		Package:     proto.String("comments"),
+		Syntax:      proto.String("proto3"),
+		Options:     &descriptorpb.FileOptions{GoPackage: proto.String("example.com/comments;comments")},
+		MessageType: []*descriptorpb.DescriptorProto{msg("HelloRequest"), msg("HelloResponse")},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("GreeterService"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("SayHello"), InputType: proto.String(".comments.HelloRequest"), OutputType: proto.String(".comments.HelloResponse")},
+				{Name: proto.String("StreamHello"), InputType: proto.String(".comments.HelloRequest"), OutputType: proto.String(".comments.HelloResponse"), ServerStreaming: proto.Bool(true)},
+			},
+		}},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{
+				{Path: []int32{6, 0, 2, 0}, Span: []int32{10, 2, 40}, LeadingComments: proto.String(comment)},
+				{Path: []int32{6, 0, 2, 1}, Span: []int32{16, 2, 40}, LeadingComments: proto.String(comment)},
+			},
+		},
+	}
+}
+
+func TestGenerateFile_CommentDescription(t *testing.T) {
+	// As protoc reports "// Says hello.\n//\n// Greets   the caller by name.\n"
+	fd := commentedServiceProto(" Says hello.\n\n Greets   the caller by name.\n   Indented line kept.\n")
+
+	for _, tt := range []struct {
+		framework CLIFramework
+		field     string
+	}{
+		{framework: CLIFrameworkUrfave, field: "Description"},
+		{framework: CLIFrameworkCobra, field: "Long"},
+	} {
+		t.Run(tt.framework.String(), func(t *testing.T) {
+			content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{fd}, Options{CLIFramework: tt.framework})["comments_cli.pb.go"]
+			require.NotEmpty(t, content)
+
+			assert.Regexp(t, `(Usage|Short):\s+"Says hello\."`, content, "the first line stays the usage")
+			assert.Regexp(t, tt.field+`:\s+"Says hello\.\\n\\nGreets   the caller by name\.\\n  Indented line kept\."`, content)
+		})
+	}
+}
+
+func TestGenerateFile_CommentDescriptionSingleLine(t *testing.T) {
+	fd := commentedServiceProto(" Says hello.\n")
+	content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{fd}, Options{})["comments_cli.pb.go"]
+
+	assert.Regexp(t, `Usage:\s+"Says hello\."`, content)
+	assert.NotContains(t, content, "Description:", "a one-line comment is only the usage")
+}
+
+// emptyRequestProto returns a file whose service takes google.protobuf.Empty and
+// a local message without fields.
+func emptyRequestProto() []*descriptorpb.FileDescriptorProto {
+	return []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(emptypb.File_google_protobuf_empty_proto),
+		{
+			Name:       proto.String("empty.proto"),
+			Package:    proto.String("emptyreq"),
+			Syntax:     proto.String("proto3"),
+			Dependency: []string{"google/protobuf/empty.proto"},
+			Options:    &descriptorpb.FileOptions{GoPackage: proto.String("example.com/emptyreq")},
+			MessageType: []*descriptorpb.DescriptorProto{
+				{Name: proto.String("Nothing")},
+				{Name: proto.String("Pong")},
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("Svc"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{Name: proto.String("Ping"), InputType: proto.String(".google.protobuf.Empty"), OutputType: proto.String(".emptyreq.Pong")},
+					{Name: proto.String("Noop"), InputType: proto.String(".emptyreq.Nothing"), OutputType: proto.String(".emptyreq.Pong")},
+					{Name: proto.String("Watch"), InputType: proto.String(".google.protobuf.Empty"), OutputType: proto.String(".emptyreq.Pong"), ServerStreaming: proto.Bool(true)},
+				},
+			}},
+		},
+	}
+}
+
+func TestGenerateFile_EmptyRequest(t *testing.T) {
+	for _, framework := range []CLIFramework{CLIFrameworkUrfave, CLIFrameworkCobra} {
+		t.Run(framework.String(), func(t *testing.T) {
+			content := generateProtosForTest(t, emptyRequestProto(), Options{CLIFramework: framework})["empty_cli.pb.go"]
+			require.NotEmpty(t, content)
+
+			assert.Contains(t, content, "req = &emptypb.Empty{}", "Empty is qualified with its own package")
+			assert.Contains(t, content, "req = &Nothing{}")
+			assert.Contains(t, content, `"Read request from file (JSON or YAML)"`)
+			assert.NotContains(t, content, "CLI flags override file values", "there are no request flags")
+			assert.NotContains(t, content, "Apply flag overrides", "there are no request flags")
+		})
+	}
+}
+
+// exitCodeProto returns a file with one method per exit_code_field annotation,
+// each returning a response with an integer code and a string message.
+func exitCodeProto(fields ...string) *descriptorpb.FileDescriptorProto {
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("exitcode.proto"),
+		Package: proto.String("exitcode"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/exitcode")},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Request")},
+			{Name: proto.String("Response"), Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("code"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(), JsonName: proto.String("code")},
+				{Name: proto.String("message"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), JsonName: proto.String("message")},
+			}},
+		},
+	}
+	svc := &descriptorpb.ServiceDescriptorProto{Name: proto.String("Svc")}
+	for i, field := range fields {
+		opts := &descriptorpb.MethodOptions{}
+		proto.SetExtension(opts, annotations.E_Command, &annotations.CommandOptions{ExitCodeField: field})
+		svc.Method = append(svc.Method, &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(fmt.Sprintf("Check%d", i)),
+			InputType:  proto.String(".exitcode.Request"),
+			OutputType: proto.String(".exitcode.Response"),
+			Options:    opts,
+		})
+	}
+	fd.Service = []*descriptorpb.ServiceDescriptorProto{svc}
+	return fd
+}
+
+func TestGenerateFile_ExitCodeField(t *testing.T) {
+	content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{exitCodeProto("code")}, Options{})["exitcode_cli.pb.go"]
+	assert.Contains(t, content, `return protocli.ResponseExitCode(resp, "code")`)
+}
+
+func TestGenerateFile_ExitCodeFieldInvalid(t *testing.T) {
+	// A string field and a missing field are reported and ignored
+	content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{exitCodeProto("message", "missing")}, Options{})["exitcode_cli.pb.go"]
+	require.NotEmpty(t, content)
+	assert.NotContains(t, content, "ResponseExitCode")
+}
+
+func TestGenerateFile_ProgressTotalField(t *testing.T) {
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("progress.proto"),
+		Package: proto.String("progress"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/progress")},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Request")},
+			{Name: proto.String("Item"), Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("total"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(), JsonName: proto.String("total")},
+				{Name: proto.String("label"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), JsonName: proto.String("label")},
+			}},
+		},
+	}
+	svc := &descriptorpb.ServiceDescriptorProto{Name: proto.String("Svc")}
+	for i, field := range []string{"total", "label", "missing"} {
+		opts := &descriptorpb.MethodOptions{}
+		proto.SetExtension(opts, annotations.E_Command, &annotations.CommandOptions{ProgressTotalField: field})
+		svc.Method = append(svc.Method, &descriptorpb.MethodDescriptorProto{
+			Name:            proto.String(fmt.Sprintf("Watch%d", i)),
+			InputType:       proto.String(".progress.Request"),
+			OutputType:      proto.String(".progress.Item"),
+			ServerStreaming: proto.Bool(true),
+			Options:         opts,
+		})
+	}
+	fd.Service = []*descriptorpb.ServiceDescriptorProto{svc}
+
+	// A string field and a missing field are reported and ignored
+	content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{fd}, Options{})["progress_cli.pb.go"]
+	require.NotEmpty(t, content)
+	assert.Contains(t, content, `protocli.NewStreamProgress(cmd, "total")`)
+	assert.NotContains(t, content, `NewStreamProgress(cmd, "label")`)
+	assert.NotContains(t, content, `NewStreamProgress(cmd, "missing")`)
+}
+
+// otelSpanStatements returns the statements of the action traced as rpc that
+// start, end or record on a span, one per line without indentation.
+func otelSpanStatements(t *testing.T, content, rpc string) string {
+	t.Helper()
+	start := strings.Index(content, `tracer.Start(cmdCtx, "`+rpc+`"`)
+	require.GreaterOrEqual(t, start, 0, "no span for %s", rpc)
+	start = strings.LastIndex(content[:start], "// Trace the command with OpenTelemetry")
+	end := strings.Index(content[start:], "\n\t\t\treturn err\n")
+	require.GreaterOrEqual(t, end, 0)
+
+	var lines []string
+	for _, line := range strings.Split(content[start:start+end], "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "tracer") || strings.Contains(line, "span") || strings.Contains(line, "Span") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestGenerateFile_InstrumentOTel(t *testing.T) {
+	content := generateForTest(t, simple.File_examples_simple_example_proto, Options{Instrument: InstrumentOTel})
+	streamingContent := generateForTest(t, streaming.File_examples_streaming_streaming_proto, Options{Instrument: InstrumentOTel})
+
+	assertGolden(t, "instrument_otel",
+		otelSpanStatements(t, content, "example.UserService/CreateUser")+"\n"+
+			otelSpanStatements(t, streamingContent, "streaming.StreamingService/ListItems"))
+	assert.Contains(t, content, "err := func(cmdCtx context.Context) error {", "the action runs under the span's context")
+}
+
+func TestGenerateFile_InstrumentDisabled(t *testing.T) {
+	content := generateForTest(t, simple.File_examples_simple_example_proto, Options{})
+	assert.NotContains(t, content, "go.opentelemetry.io/otel")
+}
+
+func TestInstrument_Set(t *testing.T) {
+	var i Instrument
+	assert.Equal(t, "none", i.String())
+	require.NoError(t, i.Set("otel"))
+	assert.Equal(t, InstrumentOTel, i)
+
+	require.Error(t, i.Set("zipkin"))
+	assert.Equal(t, InstrumentOTel, i)
+}
+
+// oneofRequestProto returns a TUI-enabled file whose request has a two-member
+// oneof and a proto3 optional field.
+func oneofRequestProto() *descriptorpb.FileDescriptorProto {
+	svcOpts := &descriptorpb.ServiceOptions{}
+	proto.SetExtension(svcOpts, annotations.E_Service, &annotations.ServiceOptions{Tui: &annotations.TUIServiceOptions{}})
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("oneof.proto"),
+		Package: proto.String("oneofreq"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/oneofreq")},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("NotifyRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("email"), Number: proto.Int32(1), Type: str, JsonName: proto.String("email"), OneofIndex: proto.Int32(0)},
+					{Name: proto.String("phone"), Number: proto.Int32(2), Type: str, JsonName: proto.String("phone"), OneofIndex: proto.Int32(0)},
+					{Name: proto.String("note"), Number: proto.Int32(3), Type: str, JsonName: proto.String("note"), OneofIndex: proto.Int32(1), Proto3Optional: proto.Bool(true)},
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("target")}, {Name: proto.String("_note")}},
+			},
+			{Name: proto.String("NotifyResponse")},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name:    proto.String("Notifier"),
+			Options: svcOpts,
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("Notify"), InputType: proto.String(".oneofreq.NotifyRequest"), OutputType: proto.String(".oneofreq.NotifyResponse")},
+			},
+		}},
+	}
+}
+
+func TestGenerateFile_TUIOneofGroup(t *testing.T) {
+	content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{oneofRequestProto()}, Options{})["oneof_cli.pb.go"]
+	require.NotEmpty(t, content)
+
+	assert.Len(t, regexp.MustCompile(`OneofGroup:\s+"target"`).FindAllString(content, -1), 2, "both members are grouped")
+	assert.NotContains(t, content, `"_note"`, "proto3 optional fields are not oneof members")
+}
+
+// groupedMethodsProto returns a service whose methods are annotated with the
+// given command groups, in order; an empty group leaves the method ungrouped.
+func groupedMethodsProto(methods map[string]string, order ...string) *descriptorpb.FileDescriptorProto {
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("grouped.proto"),
+		Package: proto.String("grouped"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/grouped")},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Request")},
+			{Name: proto.String("Response")},
+		},
+	}
+	svc := &descriptorpb.ServiceDescriptorProto{Name: proto.String("UserService")}
+	for _, name := range order {
+		opts := &descriptorpb.MethodOptions{}
+		proto.SetExtension(opts, annotations.E_Command, &annotations.CommandOptions{Group: methods[name]})
+		svc.Method = append(svc.Method, &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String(".grouped.Request"),
+			OutputType: proto.String(".grouped.Response"),
+			Options:    opts,
+		})
+	}
+	fd.Service = []*descriptorpb.ServiceDescriptorProto{svc}
+	return fd
+}
+
+func TestGenerateFile_CommandGroup(t *testing.T) {
+	fd := groupedMethodsProto(map[string]string{
+		"GetUser":       "",
+		"ResetPassword": "admin",
+		"BanUser":       "admin",
+		"ListUsers":     "get-user", // collides with the get-user command
+	}, "GetUser", "ResetPassword", "BanUser", "ListUsers")
+
+	content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{fd}, Options{})["grouped_cli.pb.go"]
+	require.NotEmpty(t, content)
+
+	// Grouped methods are collected in the group's slice...
+	assert.Contains(t, content, "var commands_admin []*v3.Command")
+	assert.Len(t, regexp.MustCompile(`commands_admin = append\(commands_admin, &v3\.Command\{`).FindAllString(content, -1), 4,
+		"reset-password and ban-user, in the nested and flat builders")
+	// ...which becomes the subcommands of a group command within the service
+	assert.Regexp(t, `commands = append\(commands, &v3\.Command\{\s+Commands:\s+commands_admin,\s+Name:\s+"admin",\s+Usage:\s+"Admin commands",\s+\}\)`, content)
+	// Ungrouped methods and methods of a clashing group stay at the service level
+	assert.Len(t, regexp.MustCompile(`commands = append\(commands, &v3\.Command\{`).FindAllString(content, -1), 2*3,
+		"get-user, list-users and the admin group, in both builders")
+	assert.NotContains(t, content, "commands_get_user")
+
+	cobra := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{fd}, Options{CLIFramework: CLIFrameworkCobra})["grouped_cli.pb.go"]
+	assert.Contains(t, cobra, `serviceCmd.AddCommand(group_admin)`)
+	assert.Contains(t, cobra, `group_admin.AddCommand(cmd_reset_password)`)
+	assert.Contains(t, cobra, `group_admin.AddCommand(cmd_ban_user)`)
+	assert.Contains(t, cobra, `serviceCmd.AddCommand(cmd_list_users)`)
+}
+
+func TestGenerateFile_CommandGroupCollisions(t *testing.T) {
+	fd := groupedMethodsProto(map[string]string{
+		"GetUser":       "",
+		"ResetPassword": "formats",
+		"BanUser":       "validate",
+		"ListUsers":     "fetch", // collides with an alias of get-user
+	}, "GetUser", "ResetPassword", "BanUser", "ListUsers")
+	opts := &descriptorpb.MethodOptions{}
+	proto.SetExtension(opts, annotations.E_Command, &annotations.CommandOptions{Aliases: []string{"fetch"}})
+	fd.Service[0].Method[0].Options = opts
+
+	content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{fd}, Options{})["grouped_cli.pb.go"]
+	require.NotEmpty(t, content)
+	assert.NotContains(t, content, "commands_formats")
+	assert.NotContains(t, content, "commands_validate")
+	assert.NotContains(t, content, "commands_fetch")
+	assert.Len(t, regexp.MustCompile(`commands = append\(commands, &v3\.Command\{`).FindAllString(content, -1), 2*4,
+		"every method stays at the service level, in both builders")
+}
+
+func TestGenerateFile_ServiceMetadata(t *testing.T) {
+	fd := groupedMethodsProto(map[string]string{"GetUser": "", "BanUser": "admin"}, "GetUser", "BanUser")
+	content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{fd}, Options{})["grouped_cli.pb.go"]
+	require.NotEmpty(t, content)
+	assert.Regexp(t, `Metadata:\s+map\[string\]any\{protocli\.ServiceMetadataKey:\s+"user-service"\}`, content)
+}
+
+func TestGenerateFile_RequestFlagsMetadata(t *testing.T) {
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("reqflags.proto"),
+		Package: proto.String("reqflags"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/reqflags")},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Request"), Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("slug"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), JsonName: proto.String("slug")},
+				{Name: proto.String("display_name"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), JsonName: proto.String("displayName")},
+			}},
+			{Name: proto.String("Response")},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Svc"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("Put"), InputType: proto.String(".reqflags.Request"), OutputType: proto.String(".reqflags.Response")},
+			},
+		}},
+	}
+
+	content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{fd}, Options{})["reqflags_cli.pb.go"]
+	require.NotEmpty(t, content)
+	assert.Regexp(t, `protocli\.RequestFlagsMetadataKey:\s+\[\]string\{"slug", "display-name"\}`, content)
+}
+
+// TestGenerateFile_FlagNameClash tests that generation fails when a request
+// field flag takes the name of a global or built-in flag, whether the name is
+// derived from the field or set with (cli.flag).name.
+func TestGenerateFile_FlagNameClash(t *testing.T) {
+	clashProto := func(streaming bool, field *descriptorpb.FieldDescriptorProto) *descriptorpb.FileDescriptorProto {
+		return &descriptorpb.FileDescriptorProto{
+			Name:    proto.String("clash.proto"),
+			Package: proto.String("clash"),
+			Syntax:  proto.String("proto3"),
+			Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/clash")},
+			MessageType: []*descriptorpb.DescriptorProto{
+				{Name: proto.String("Request"), Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("label"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), JsonName: proto.String("label")},
+					field,
+				}},
+				{Name: proto.String("Response")},
+			},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: proto.String("Svc"),
+				Method: []*descriptorpb.MethodDescriptorProto{
+					{Name: proto.String("Paint"), InputType: proto.String(".clash.Request"), OutputType: proto.String(".clash.Response"), ServerStreaming: proto.Bool(streaming)},
+				},
+			}},
+		}
+	}
+	stringField := func(name, flagName string) *descriptorpb.FieldDescriptorProto {
+		field := &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), JsonName: proto.String(name)}
+		if flagName != "" {
+			field.Options = &descriptorpb.FieldOptions{}
+			proto.SetExtension(field.Options, annotations.E_Flag, &annotations.FlagOptions{Name: flagName})
+		}
+		return field
+	}
+
+	for _, tt := range []struct {
+		name      string
+		streaming bool
+		field     *descriptorpb.FieldDescriptorProto
+		wantErr   string
+	}{
+		{name: "global", field: stringField("color", ""), wantErr: "clash.Svc.Paint: field color: flag --color clashes with the global --color flag"},
+		{name: "annotated global", field: stringField("tint", "quiet"), wantErr: "flag --quiet clashes with the global --quiet flag"},
+		{name: "built-in", field: stringField("get", ""), wantErr: "flag --get clashes with the built-in --get flag"},
+		{name: "annotated built-in", field: stringField("shown", "show-input"), wantErr: "flag --show-input clashes with the built-in --show-input flag"},
+		{name: "streaming built-in", streaming: true, field: stringField("since", ""), wantErr: "flag --since clashes with the built-in --since flag"},
+		{name: "streaming flag on a unary command", field: stringField("since", "")},
+		{name: "no clash", field: stringField("tint", "")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := clashProto(tt.streaming, tt.field)
+			for _, framework := range []CLIFramework{CLIFrameworkUrfave, CLIFrameworkCobra} {
+				err := generateErrorForTest(t, []*descriptorpb.FileDescriptorProto{fd}, Options{CLIFramework: framework})
+				if tt.wantErr == "" {
+					require.NoError(t, err)
+					continue
+				}
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestGenerateFile_FieldPatterns tests that pattern annotations are compiled
+// once into package-level variables, that invalid ones are dropped at
+// generation time, and that checks see whether the flag was given.
+func TestGenerateFile_FieldPatterns(t *testing.T) {
+	patternOpts := func(pattern string) *descriptorpb.FieldOptions {
+		opts := &descriptorpb.FieldOptions{}
+		proto.SetExtension(opts, annotations.E_Flag, &annotations.FlagOptions{Pattern: pattern})
+		return opts
+	}
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("patterns.proto"),
+		Package: proto.String("patterns"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/patterns")},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Request"), Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("slug"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), JsonName: proto.String("slug"), Options: patternOpts(`^[a-z-]+$`)},
+				{Name: proto.String("broken"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), JsonName: proto.String("broken"), Options: patternOpts(`(`)},
+			}},
+			{Name: proto.String("Response")},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Svc"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("Put"), InputType: proto.String(".patterns.Request"), OutputType: proto.String(".patterns.Response")},
+			},
+		}},
+	}
+
+	content := generateProtosForTest(t, []*descriptorpb.FileDescriptorProto{fd}, Options{})["patterns_cli.pb.go"]
+	require.NotEmpty(t, content)
+	assert.Contains(t, content, "var svcPutSlugPattern = regexp.MustCompile(\"^[a-z-]+$\")")
+	assert.Regexp(t, `Pattern:\s+svcPutSlugPattern,`, content)
+	assert.Regexp(t, `Set:\s+cmd\.IsSet\("slug"\),`, content)
+	assert.NotContains(t, content, "svcPutBrokenPattern", "invalid patterns are ignored")
+	assert.NotContains(t, content, `"("`)
+}
@@ -0,0 +1,265 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// FlagCase selects how flag names are derived from proto field names.
+// Names set via the (cli.flag).name annotation are used verbatim.
+type FlagCase string
+
+const (
+	FlagCaseKebab FlagCase = "kebab" // StartTime -> start-time (default)
+	FlagCaseSnake FlagCase = "snake" // StartTime -> start_time
+	FlagCaseCamel FlagCase = "camel" // StartTime -> startTime
+)
+
+// String implements flag.Value.
+func (c *FlagCase) String() string {
+	if c == nil || *c == "" {
+		return string(FlagCaseKebab)
+	}
+	return string(*c)
+}
+
+// Set implements flag.Value so FlagCase can be bound to a plugin parameter.
+func (c *FlagCase) Set(value string) error {
+	switch FlagCase(value) {
+	case FlagCaseKebab, FlagCaseSnake, FlagCaseCamel:
+		*c = FlagCase(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid flag_case %q: must be one of kebab, snake, camel", value)
+	}
+}
+
+// CLIFramework selects the command-line library generated commands target.
+type CLIFramework string
+
+const (
+	CLIFrameworkUrfave CLIFramework = "urfave" // github.com/urfave/cli/v3 (default)
+	CLIFrameworkCobra  CLIFramework = "cobra"  // github.com/spf13/cobra
+)
+
+// String implements flag.Value.
+func (c *CLIFramework) String() string {
+	if c == nil || *c == "" {
+		return string(CLIFrameworkUrfave)
+	}
+	return string(*c)
+}
+
+// Set implements flag.Value so CLIFramework can be bound to a plugin parameter.
+func (c *CLIFramework) Set(value string) error {
+	switch CLIFramework(value) {
+	case CLIFrameworkUrfave, CLIFrameworkCobra:
+		*c = CLIFramework(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid cli_framework %q: must be one of urfave, cobra", value)
+	}
+}
+
+// SplitOutput selects how generated CLI code is divided across files.
+type SplitOutput string
+
+const (
+	SplitOutputNone       SplitOutput = "none"        // everything in <file>_cli.pb.go (default)
+	SplitOutputPerService SplitOutput = "per-service" // one file per service
+	SplitOutputPerMethod  SplitOutput = "per-method"  // one file per service plus one per method
+)
+
+// String implements flag.Value.
+func (s *SplitOutput) String() string {
+	if s == nil || *s == "" {
+		return string(SplitOutputNone)
+	}
+	return string(*s)
+}
+
+// Set implements flag.Value so SplitOutput can be bound to a plugin parameter.
+func (s *SplitOutput) Set(value string) error {
+	switch SplitOutput(value) {
+	case SplitOutputNone, SplitOutputPerService, SplitOutputPerMethod:
+		*s = SplitOutput(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid split_output %q: must be one of none, per-service, per-method", value)
+	}
+}
+
+// splits reports whether services are written to their own files.
+func (s SplitOutput) splits() bool {
+	return s == SplitOutputPerService || s == SplitOutputPerMethod
+}
+
+// Instrument selects tracing instrumentation baked into generated actions.
+type Instrument string
+
+const (
+	InstrumentNone Instrument = "none" // no instrumentation (default)
+	InstrumentOTel Instrument = "otel" // OpenTelemetry spans via the global tracer provider
+)
+
+// String implements flag.Value.
+func (i *Instrument) String() string {
+	if i == nil || *i == "" {
+		return string(InstrumentNone)
+	}
+	return string(*i)
+}
+
+// Set implements flag.Value so Instrument can be bound to a plugin parameter.
+func (i *Instrument) Set(value string) error {
+	switch Instrument(value) {
+	case InstrumentNone, InstrumentOTel:
+		*i = Instrument(value)
+		return nil
+	default:
+		return fmt.Errorf("invalid instrument %q: must be one of none, otel", value)
+	}
+}
+
+// Options holds generator-level settings supplied as plugin parameters.
+// Example: --cli_opt=flag_case=snake.
+type Options struct {
+	FlagCase FlagCase
+
+	// AllServices emits BuildAllServicesCLI, which wires every service in the
+	// file into a single root command.
+	AllServices bool
+
+	// CLIFramework selects the generated command backend. The cobra backend
+	// emits <Service>CobraCommand builders backed by the contrib/cobracli runtime.
+	CLIFramework CLIFramework
+
+	// Mocks emits Mock<Service>Server test doubles into <file>_cli_mock.pb.go.
+	Mocks bool
+
+	// SplitOutput divides generated code across files to speed up incremental
+	// compilation of large protos. Shared helpers stay in <file>_cli.pb.go.
+	SplitOutput SplitOutput
+
+	// FileFlags lets every string flag read its value from a file ("@path") or
+	// stdin ("@-"), as if each field were annotated with (cli.flag).allow_file.
+	FileFlags bool
+
+	// Instrument bakes tracing into generated unary actions. With otel, each
+	// action runs in a span named after the RPC, with child spans for the
+	// request build and the service call.
+	Instrument Instrument
+}
+
+// flagName converts a Go field name to a CLI flag name using the configured casing.
+func (o Options) flagName(goName string) string {
+	switch o.FlagCase {
+	case FlagCaseSnake:
+		return strings.ReplaceAll(toKebabCase(goName), "-", "_")
+	case FlagCaseCamel:
+		return toLowerCamelCase(goName)
+	default:
+		return toKebabCase(goName)
+	}
+}
+
+// allowsFileValue reports whether a string field's flag accepts "@path" values.
+func (o Options) allowsFileValue(field *protogen.Field) bool {
+	return o.FileFlags || getFieldFlagOptions(field).GetAllowFile()
+}
+
+// globalFlagNames are the flags the runtime defines on the root command
+// (protocli.RootCommand and cobracli.NewRootCommand). A request field flag of
+// the same name would shadow the global flag on its command.
+var globalFlagNames = map[string]bool{
+	"config":      true,
+	"env-prefix":  true,
+	"secret-dir":  true,
+	"verbosity":   true,
+	"timings":     true,
+	"deadline":    true,
+	"flag-file":   true,
+	"color":       true,
+	"no-color":    true,
+	"interactive": true,
+	"pick":        true,
+	"quiet":       true,
+	"context":     true,
+	"replay":      true,
+}
+
+// methodFlagNames are the flags generated unary and streaming method commands
+// define next to their request field flags, on either CLI framework; some only
+// with remote calls, --confirm or a TUI-enabled service.
+var methodFlagNames = map[string]bool{
+	"help":           true,
+	"remote":         true,
+	"compression":    true,
+	"format":         true,
+	"output":         true,
+	"output-mode":    true,
+	"output-append":  true,
+	"fields-exclude": true,
+	"input-file":     true,
+	"input-format":   true,
+	"show-input":     true,
+	"get":            true,
+	"echo-request":   true,
+	"yes":            true,
+	"interactive":    true,
+}
+
+// streamingFlagNames are the flags server-streaming method commands add; some
+// only with remote calls, a resume token or timestamped messages.
+var streamingFlagNames = map[string]bool{
+	"delimiter":         true,
+	"raw":               true,
+	"collect":           true,
+	"stream-summary":    true,
+	"flush":             true,
+	"reconnect":         true,
+	"reconnect-backoff": true,
+	"checkpoint-file":   true,
+	"resume":            true,
+	"since":             true,
+	"until":             true,
+}
+
+// fieldFlagName returns the CLI flag name for a request field: the (cli.flag).name
+// annotation when present, otherwise the field name in the configured casing.
+func (o Options) fieldFlagName(field *protogen.Field) string {
+	if flagOpts := getFieldFlagOptions(field); flagOpts != nil && flagOpts.Name != "" {
+		return flagOpts.Name
+	}
+	return o.flagName(field.GoName)
+}
+
+// checkFieldFlagNames returns an error when the flag of a request field of a
+// method command in file has the name of a global flag or of a flag the
+// framework adds to the command, which the field flag would shadow or clash with.
+func (o Options) checkFieldFlagNames(file *protogen.File) error {
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			if method.Desc.IsStreamingClient() {
+				continue
+			}
+			for _, field := range method.Input.Fields {
+				name := o.fieldFlagName(field)
+				var kind string
+				switch {
+				case globalFlagNames[name]:
+					kind = "global"
+				case methodFlagNames[name], method.Desc.IsStreamingServer() && streamingFlagNames[name]:
+					kind = "built-in"
+				default:
+					continue
+				}
+				return fmt.Errorf("%s: field %s: flag --%s clashes with the %s --%s flag; rename it with (cli.v1.flag).name",
+					method.Desc.FullName(), field.Desc.Name(), name, kind, name)
+			}
+		}
+	}
+	return nil
+}
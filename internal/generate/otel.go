@@ -0,0 +1,68 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const (
+	otelPkg      = "go.opentelemetry.io/otel"
+	otelAttrPkg  = "go.opentelemetry.io/otel/attribute"
+	otelCodesPkg = "go.opentelemetry.io/otel/codes"
+	otelTracePkg = "go.opentelemetry.io/otel/trace"
+)
+
+// generateOTelSpanStart starts the child span spanVar named name under
+// cmdCtx when instrument=otel. The span is also ended by defer so that early
+// returns don't leak it; ending it twice is a no-op.
+func generateOTelSpanStart(genOpts Options, spanVar, name string) []jen.Code {
+	if genOpts.Instrument != InstrumentOTel {
+		return nil
+	}
+	return []jen.Code{
+		jen.List(jen.Id("_"), jen.Id(spanVar)).Op(":=").Id("tracer").Dot("Start").Call(jen.Id("cmdCtx"), jen.Lit(name)),
+		jen.Defer().Id(spanVar).Dot("End").Call(),
+	}
+}
+
+// generateOTelSpanEnd ends the child span spanVar when instrument=otel.
+func generateOTelSpanEnd(genOpts Options, spanVar string) []jen.Code {
+	if genOpts.Instrument != InstrumentOTel {
+		return nil
+	}
+	return []jen.Code{jen.Id(spanVar).Dot("End").Call()}
+}
+
+// wrapOTelActionBody wraps an action body in an OpenTelemetry span named
+// after the RPC (e.g. "example.UserService/GetUser") when instrument=otel.
+// The body runs in a closure under the span's context so that the span can
+// record the error of whichever statement returns.
+func wrapOTelActionBody(file *protogen.File, service *protogen.Service, method *protogen.Method, genOpts Options, body []jen.Code) []jen.Code {
+	if genOpts.Instrument != InstrumentOTel {
+		return body
+	}
+	return []jen.Code{
+		jen.Comment("Trace the command with OpenTelemetry (instrument=otel)"),
+		jen.Id("tracer").Op(":=").Qual(otelPkg, "Tracer").Call(jen.Lit(string(file.GoImportPath))),
+		jen.List(jen.Id("spanCtx"), jen.Id("span")).Op(":=").Id("tracer").Dot("Start").Call(
+			jen.Id("cmdCtx"),
+			jen.Lit(strings.TrimPrefix(rpcFullMethod(service, method), "/")),
+			jen.Qual(otelTracePkg, "WithSpanKind").Call(jen.Qual(otelTracePkg, "SpanKindClient")),
+			jen.Qual(otelTracePkg, "WithAttributes").Call(
+				jen.Qual(otelAttrPkg, "String").Call(jen.Lit("rpc.system"), jen.Lit("grpc")),
+				jen.Qual(otelAttrPkg, "String").Call(jen.Lit("rpc.service"), jen.Lit(string(service.Desc.FullName()))),
+				jen.Qual(otelAttrPkg, "String").Call(jen.Lit("rpc.method"), jen.Lit(string(method.Desc.Name()))),
+			),
+		),
+		jen.Defer().Id("span").Dot("End").Call(),
+		jen.Line(),
+		jen.Err().Op(":=").Func().Params(jen.Id("cmdCtx").Qual("context", "Context")).Error().Block(body...).Call(jen.Id("spanCtx")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Id("span").Dot("RecordError").Call(jen.Err()),
+			jen.Id("span").Dot("SetStatus").Call(jen.Qual(otelCodesPkg, "Error"), jen.Err().Dot("Error").Call()),
+		),
+		jen.Return(jen.Err()),
+	}
+}
@@ -0,0 +1,104 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dave/jennifer/jen"
+	annotations "github.com/drewfead/proto-cli/proto/cli/v1"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateRootFlags returns a []cli.Flag literal for the root flags declared with
+// the (cli.file) option, or nil when the file declares none.
+func generateRootFlags(file *protogen.File) jen.Code {
+	rootFlags := getFileOptions(file).GetRootFlags()
+	if len(rootFlags) == 0 {
+		return nil
+	}
+
+	flags := make([]jen.Code, 0, len(rootFlags))
+	for _, rf := range rootFlags {
+		if rf.GetName() == "" {
+			fmt.Fprintf(os.Stderr, "WARNING: %s declares a root flag without a name; skipping\n", file.Desc.Path())
+			continue
+		}
+
+		dict := jen.Dict{
+			jen.Id("Name"): jen.Lit(rf.GetName()),
+		}
+		if rf.GetUsage() != "" {
+			dict[jen.Id("Usage")] = jen.Lit(rf.GetUsage())
+		}
+		if rf.GetShorthand() != "" {
+			dict[jen.Id("Aliases")] = jen.Index().String().Values(jen.Lit(rf.GetShorthand()))
+		}
+		if rf.GetEnvVar() != "" {
+			dict[jen.Id("Sources")] = jen.Qual("github.com/urfave/cli/v3", "EnvVars").Call(jen.Lit(rf.GetEnvVar()))
+		}
+
+		flagType, value, err := rootFlagTypeAndValue(rf)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: root flag --%s has invalid default_value %q: %v\n", rf.GetName(), rf.GetDefaultValue(), err)
+		} else if value != nil {
+			dict[jen.Id("Value")] = value
+		}
+		flags = append(flags, cliFlagRef(flagType, dict))
+	}
+
+	return jen.Index().Qual("github.com/urfave/cli/v3", "Flag").Custom(jen.Options{
+		Open: "{", Close: "}", Separator: ",", Multi: true,
+	}, flags...)
+}
+
+// rootFlagTypeAndValue returns the urfave/cli flag type for a root flag and its
+// parsed default value, or a nil value when no default is set.
+func rootFlagTypeAndValue(rf *annotations.RootFlag) (string, jen.Code, error) {
+	def := rf.GetDefaultValue()
+	switch rf.GetType() {
+	case annotations.RootFlagType_ROOT_FLAG_TYPE_BOOL:
+		if def == "" {
+			return "BoolFlag", nil, nil
+		}
+		b, err := strconv.ParseBool(def)
+		return "BoolFlag", jen.Lit(b), err
+	case annotations.RootFlagType_ROOT_FLAG_TYPE_INT:
+		if def == "" {
+			return "IntFlag", nil, nil
+		}
+		n, err := strconv.Atoi(def)
+		return "IntFlag", jen.Lit(n), err
+	case annotations.RootFlagType_ROOT_FLAG_TYPE_FLOAT:
+		if def == "" {
+			return "FloatFlag", nil, nil
+		}
+		f, err := strconv.ParseFloat(def, 64)
+		return "FloatFlag", jen.Lit(f), err
+	case annotations.RootFlagType_ROOT_FLAG_TYPE_DURATION:
+		if def == "" {
+			return "DurationFlag", nil, nil
+		}
+		d, err := time.ParseDuration(def)
+		if d%time.Second == 0 {
+			return "DurationFlag", jen.Lit(int(d/time.Second)).Op("*").Qual("time", "Second"), err
+		}
+		return "DurationFlag", jen.Qual("time", "Duration").Call(jen.Lit(int64(d))), err
+	case annotations.RootFlagType_ROOT_FLAG_TYPE_STRING_SLICE:
+		if def == "" {
+			return "StringSliceFlag", nil, nil
+		}
+		var values []jen.Code
+		for _, v := range strings.Split(def, ",") {
+			values = append(values, jen.Lit(strings.TrimSpace(v)))
+		}
+		return "StringSliceFlag", jen.Index().String().Values(values...), nil
+	default:
+		if def == "" {
+			return "StringFlag", nil, nil
+		}
+		return "StringFlag", jen.Lit(def), nil
+	}
+}
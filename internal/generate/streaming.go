@@ -2,14 +2,16 @@ package generate
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/dave/jennifer/jen"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // generateServerStreamingCommand generates a CLI command for server streaming RPC methods
-func generateServerStreamingCommand(service *protogen.Service, method *protogen.Method, configMessageType string, file *protogen.File) []jen.Code {
+func generateServerStreamingCommand(service *protogen.Service, method *protogen.Method, configMessageType string, file *protogen.File, genOpts Options) []jen.Code {
 	var statements []jen.Code
 
 	// Get command name and help fields from annotation or use defaults
@@ -17,7 +19,7 @@ func generateServerStreamingCommand(service *protogen.Service, method *protogen.
 	cmdUsage := method.GoName + " (streaming)"            // Short description for Usage field
 	var cmdDescription, cmdUsageText, cmdArgsUsage string // Long description, custom usage line, args
 
-	var localOnly bool
+	var localOnly, hidden bool
 
 	// Determine whether the parent service is TUI-enabled (for --interactive flag generation).
 	serviceOpts := getServiceOptions(service)
@@ -52,6 +54,8 @@ func generateServerStreamingCommand(service *protogen.Service, method *protogen.
 		}
 		// Check if command should always run locally
 		localOnly = cmdOpts.GetLocalOnly()
+		// Hidden commands are omitted from help but remain invocable
+		hidden = cmdOpts.GetHidden()
 	}
 
 	// Fallback to proto source comment if no annotation provided a description
@@ -61,6 +65,12 @@ func generateServerStreamingCommand(service *protogen.Service, method *protogen.
 			cmdUsage = firstLine(comment)
 		}
 	}
+	if cmdDescription == "" {
+		cmdDescription = commentDescription(method.Comments.Leading)
+	}
+	if msg := cmdOpts.GetDeprecated(); msg != "" {
+		cmdUsage = fmt.Sprintf("%s (deprecated: %s)", cmdUsage, msg)
+	}
 
 	// Create a safe variable name (replace hyphens with underscores)
 	cmdVarName := strings.ReplaceAll(cmdName, "-", "_")
@@ -77,19 +87,53 @@ func generateServerStreamingCommand(service *protogen.Service, method *protogen.
 			jen.Id("Value"): jen.Lit("-"),
 			jen.Id("Usage"): jen.Lit("Output file (- for stdout)"),
 		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("output-mode"),
+			jen.Id("Value"): jen.Qual("github.com/drewfead/proto-cli", "DefaultOutputFileMode"),
+			jen.Id("Usage"): jen.Lit("Octal permissions for a file created by --output"),
+		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("output-append"),
+			jen.Id("Usage"): jen.Lit("Append to the --output file instead of truncating it"),
+		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringSliceFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("fields-exclude"),
+			jen.Id("Usage"): jen.Lit("Drop these field paths (e.g. user.address.city) from the response before formatting"),
+		}),
 		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
 			jen.Id("Name"):  jen.Lit("delimiter"),
 			jen.Id("Value"): jen.Lit("\n"),
 			jen.Id("Usage"): jen.Lit("Delimiter between streamed messages"),
 		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("raw"),
+			jen.Id("Usage"): jen.Lit("Write each message as binary protobuf with a 4-byte big-endian length prefix instead of formatting it"),
+		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("collect"),
+			jen.Id("Usage"): jen.Lit(fmt.Sprintf("Write the whole stream once it ends, as a single %s message with an items list", collectResultsName(method))),
+		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("stream-summary"),
+			jen.Id("Usage"): jen.Lit("Print the message count and duration to stderr when the stream ends"),
+		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("flush"),
+			jen.Id("Value"): jen.Lit("auto"),
+			jen.Id("Usage"): jen.Lit("Flush output after each message: auto (only when writing to a terminal), always, or never"),
+		}),
 		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
 			jen.Id("Name"):  jen.Lit("input-file"),
-			jen.Id("Usage"): jen.Lit("Read request from file (JSON or YAML). CLI flags override file values"),
+			jen.Id("Usage"): jen.Lit(inputFileUsage(method)),
 		}),
 		jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
 			jen.Id("Name"):  jen.Lit("input-format"),
 			jen.Id("Usage"): jen.Lit("Input file format (auto-detected from extension if not set)"),
 		}),
+		jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+			jen.Id("Name"):  jen.Lit("show-input"),
+			jen.Id("Usage"): jen.Lit("Print the request to stderr in the selected format before sending it"),
+		}),
 	}
 	if !localOnly {
 		initialFlags = append([]jen.Code{
@@ -97,8 +141,50 @@ func generateServerStreamingCommand(service *protogen.Service, method *protogen.
 				jen.Id("Name"):  jen.Lit("remote"),
 				jen.Id("Usage"): jen.Lit("Remote gRPC server address (host:port). If set, uses gRPC client instead of direct call"),
 			}),
+			jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+				jen.Id("Name"):  jen.Lit("compression"),
+				jen.Id("Usage"): jen.Lit("Compress remote calls with this gRPC compressor (gzip, or none)"),
+			}),
+			jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+				jen.Id("Name"):  jen.Lit("reconnect"),
+				jen.Id("Usage"): jen.Lit("Re-establish the remote stream with backoff when it fails or ends (like tail -f)"),
+			}),
+			jen.Op("&").Qual("github.com/urfave/cli/v3", "DurationFlag").Values(jen.Dict{
+				jen.Id("Name"):  jen.Lit("reconnect-backoff"),
+				jen.Id("Value"): jen.Qual("github.com/drewfead/proto-cli", "DefaultReconnectBackoff"),
+				jen.Id("Usage"): jen.Lit("Delay before the first reconnect attempt; doubles on each failure up to 30s"),
+			}),
 		}, initialFlags...)
 	}
+	tokenField, requestField := resumeTokenFields(method)
+	if tokenField != "" {
+		initialFlags = append(initialFlags,
+			jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+				jen.Id("Name"):  jen.Lit("checkpoint-file"),
+				jen.Id("Usage"): jen.Lit("Record the last written message in this file so --resume can continue an interrupted stream"),
+			}),
+			jen.Op("&").Qual("github.com/urfave/cli/v3", "BoolFlag").Values(jen.Dict{
+				jen.Id("Name"):  jen.Lit("resume"),
+				jen.Id("Usage"): jen.Lit("Continue after the message recorded in --checkpoint-file, appending to --output"),
+			}),
+		)
+	}
+	tsField := timestampField(method)
+	if tsField != "" {
+		initialFlags = append(initialFlags,
+			jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+				jen.Id("Name"):  jen.Lit("since"),
+				jen.Id("Usage"): jen.Lit("Only write messages at or after this time, e.g. 1h, \"2d ago\" or 2024-01-02T15:04:05Z"),
+			}),
+			jen.Op("&").Qual("github.com/urfave/cli/v3", "StringFlag").Values(jen.Dict{
+				jen.Id("Name"):  jen.Lit("until"),
+				jen.Id("Usage"): jen.Lit("Only write messages at or before this time, e.g. 10m, \"1h ago\" or 2024-01-02T15:04:05Z"),
+			}),
+		)
+	}
+	if cmdOpts.GetConfirm() {
+		initialFlags = append(initialFlags, confirmFlag())
+	}
 	statements = append(statements,
 		jen.Comment("Build flags for "+cmdName),
 		jen.Id("flags_"+cmdVarName).Op(":=").Index().Qual("github.com/urfave/cli/v3", "Flag").Values(initialFlags...),
@@ -107,7 +193,7 @@ func generateServerStreamingCommand(service *protogen.Service, method *protogen.
 
 	// Add request field flags
 	for _, field := range method.Input.Fields {
-		flagCode := generateFlag(field)
+		flagCode := generateFlag(field, genOpts)
 		if flagCode != nil {
 			statements = append(statements,
 				jen.Id("flags_"+cmdVarName).Op("=").Append(jen.Id("flags_"+cmdVarName), flagCode),
@@ -122,21 +208,9 @@ func generateServerStreamingCommand(service *protogen.Service, method *protogen.
 	statements = append(statements,
 		jen.Line(),
 		jen.Comment("Add format-specific flags from registered formats"),
-		jen.For(
-			jen.List(jen.Id("_"), jen.Id("outputFmt")).Op(":=").Range().Id("options").Dot("OutputFormats").Call(),
-		).Block(
-			jen.Comment("Check if format implements FlagConfiguredOutputFormat"),
-			jen.If(
-				jen.List(jen.Id("flagConfigured"), jen.Id("ok")).Op(":=").Id("outputFmt").Assert(
-					jen.Qual("github.com/drewfead/proto-cli", "FlagConfiguredOutputFormat"),
-				),
-				jen.Id("ok"),
-			).Block(
-				jen.Id("flags_"+cmdVarName).Op("=").Append(
-					jen.Id("flags_"+cmdVarName),
-					jen.Id("flagConfigured").Dot("Flags").Call().Op("..."),
-				),
-			),
+		jen.Id("flags_"+cmdVarName).Op("=").Append(
+			jen.Id("flags_"+cmdVarName),
+			jen.Qual("github.com/drewfead/proto-cli", "FormatFlags").Call(jen.Id("options").Dot("OutputFormats").Call()).Op("..."),
 		),
 		jen.Line(),
 	)
@@ -164,14 +238,18 @@ func generateServerStreamingCommand(service *protogen.Service, method *protogen.
 			jen.Id("cmdCtx").Qual("context", "Context"),
 			jen.Id("cmd").Op("*").Qual("github.com/urfave/cli/v3", "Command"),
 		).Error().Block(
-			generateServerStreamingActionBody(file, service, method, configMessageType, localOnly)...,
+			generateServerStreamingActionBody(file, service, method, configMessageType, localOnly, tokenField, requestField, tsField, genOpts)...,
 		),
 	}
 
 	// For TUI-enabled services, add a Before hook that intercepts --interactive
 	// and deep-links into the TUI at this streaming method's request form.
-	if tuiEnabled {
-		cmdDict[jen.Id("Before")] = generateTUIBeforeHook(method, serviceCLIName, cmdName)
+	// Commands annotated with confirm prompt for confirmation in either hook.
+	switch {
+	case tuiEnabled:
+		cmdDict[jen.Id("Before")] = generateTUIBeforeHook(method, serviceCLIName, cmdName, genOpts)
+	case cmdOpts.GetConfirm():
+		cmdDict[jen.Id("Before")] = jen.Qual("github.com/drewfead/proto-cli", "ConfirmBefore")
 	}
 
 	// Add optional help fields if provided
@@ -184,11 +262,21 @@ func generateServerStreamingCommand(service *protogen.Service, method *protogen.
 	if cmdArgsUsage != "" {
 		cmdDict[jen.Id("ArgsUsage")] = jen.Lit(cmdArgsUsage)
 	}
+	if hidden {
+		cmdDict[jen.Id("Hidden")] = jen.True()
+	}
+	if aliases := methodCommandAliases(service, method); len(aliases) > 0 {
+		cmdDict[jen.Id("Aliases")] = aliasesCode(aliases)
+	}
+	cmdDict[jen.Id("Metadata")] = methodMetadata(service, method, genOpts)
+
+	// Commands of grouped methods are collected for their group command
+	commandsVar := commandsVarName(methodCommandGroup(service, method))
 
 	// Generate the command with streaming action
 	statements = append(statements,
-		jen.Id("commands").Op("=").Append(
-			jen.Id("commands"),
+		jen.Id(commandsVar).Op("=").Append(
+			jen.Id(commandsVar),
 			jen.Op("&").Qual("github.com/urfave/cli/v3", "Command").Values(cmdDict),
 		),
 		jen.Line(),
@@ -198,23 +286,19 @@ func generateServerStreamingCommand(service *protogen.Service, method *protogen.
 }
 
 // generateServerStreamingActionBody generates the action body for server streaming commands
-func generateServerStreamingActionBody(file *protogen.File, service *protogen.Service, method *protogen.Method, configMessageType string, localOnly bool) []jen.Code {
+func generateServerStreamingActionBody(file *protogen.File, service *protogen.Service, method *protogen.Method, configMessageType string, localOnly bool, tokenField, requestField, tsField string, genOpts Options) []jen.Code {
 	var statements []jen.Code
 
-	// Reject extra positional arguments.
-	statements = append(statements,
-		jen.If(
-			jen.Id("cmd").Dot("Args").Call().Dot("Len").Call().Op(">").Lit(0),
-		).Block(
-			jen.Return(
-				jen.Qual("fmt", "Errorf").Call(
-					jen.Lit("unsupported argument: %s"),
-					jen.Id("cmd").Dot("Args").Call().Dot("Get").Call(jen.Lit(0)),
-				),
-			),
-		),
-		jen.Line(),
-	)
+	// Reject extra positional arguments (or pass them to WithArgErrorHandler).
+	statements = append(statements, generateArgsCheck(method, func(err jen.Code) jen.Code { return jen.Return(err) })...)
+	statements = append(statements, jen.Line())
+
+	// Warn about deprecated command/flag usage
+	statements = append(statements, generateDeprecationWarnings(method, genOpts)...)
+	statements = append(statements, generateCommandConfigDefaults()...)
+
+	// Time each phase for --timings; reported after the after hooks have run
+	statements = append(statements, generateCommandTimings()...)
 
 	// Defer after hooks in reverse order (LIFO)
 	// IMPORTANT: Register defer FIRST so it runs even if before hooks fail
@@ -245,87 +329,50 @@ func generateServerStreamingActionBody(file *protogen.File, service *protogen.Se
 	)
 
 	// Build request - check for input file, custom deserializer, or auto-generated flags
-	requestFullyQualifiedName := string(method.Input.Desc.FullName())
-	requestTypeName := method.Input.GoIdent.GoName
-	requestQualifiedType := qualifyType(file, method.Input, true)
+	statements = append(statements, timingsPhase("request build"))
+	statements = append(statements, generateOTelSpanStart(genOpts, "buildSpan", "request build")...)
+	statements = append(statements, generateRequestBuild(file, service, method, genOpts)...)
+	statements = append(statements, generatePassthroughAssignment(method, jen.Id("cmd").Dot("Args").Call().Dot("Slice").Call())...)
+	statements = append(statements, generateAuditRequest(service, method), generateRequestSizeCheck())
+	statements = append(statements, generateFieldConstraintChecks(service, method, genOpts)...)
+	statements = append(statements, generateFlagRulesCheck(service, method, genOpts)...)
+	statements = append(statements, generateFieldsExcludeCheck(file, method))
+	statements = append(statements, generateShowInput())
+	statements = append(statements, generateOTelSpanEnd(genOpts, "buildSpan")...)
 
-	statements = append(statements,
-		jen.Comment("Build request message"),
-		jen.Var().Id("req").Add(requestQualifiedType),
-		jen.Line(),
-	)
-
-	// Generate the if-else block: input-file → custom deserializer → auto-generated
-	requestBuildBlock := []jen.Code{
-		jen.Comment("Check for file-based input"),
-		jen.Id("inputFile").Op(":=").Id("cmd").Dot("String").Call(jen.Lit("input-file")),
-		jen.If(jen.Id("inputFile").Op("!=").Lit("")).Block(
-			append([]jen.Code{
-				jen.Comment("Read request from file"),
-				jen.Id("req").Op("=").Op("&").Add(qualifyType(file, method.Input, false)).Values(),
-				jen.If(
-					jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "ReadInputFile").Call(
-						jen.Id("inputFile"),
-						jen.Id("cmd").Dot("String").Call(jen.Lit("input-format")),
-						jen.Id("options").Dot("InputFormats").Call(),
-						jen.Id("req"),
-					),
-					jen.Err().Op("!=").Nil(),
-				).Block(
-					jen.Return(jen.Err()),
-				),
-				jen.Comment("Apply flag overrides (only explicitly-set flags)"),
-			}, generateRequestFieldOverrides(file, service, method)...)...,
-		).Else().Block(
-			// Inner if-else: custom deserializer vs auto-generated
-			jen.Comment(fmt.Sprintf("Check for custom flag deserializer for %s", requestFullyQualifiedName)),
-			jen.List(jen.Id("deserializer"), jen.Id("hasDeserializer")).Op(":=").Id("options").Dot("FlagDeserializer").Call(
-				jen.Lit(requestFullyQualifiedName),
+	// Load the checkpoint before opening the output, which --resume appends to
+	checkpointed := tokenField != ""
+	if checkpointed {
+		statements = append(statements,
+			jen.Comment("Continue from --checkpoint-file when --resume is set"),
+			jen.List(jen.Id("checkpoint"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "LoadStreamCheckpoint").Call(
+				jen.Id("cmd"),
+				jen.Lit(tokenField),
+				jen.Lit(requestField),
+				jen.Id("req"),
 			),
-			jen.If(jen.Id("hasDeserializer")).Block(
-				jen.Comment("Use custom deserializer for top-level request"),
-				jen.Id("requestFlags").Op(":=").Qual("github.com/drewfead/proto-cli", "NewFlagContainer").Call(
-					jen.Id("cmd"),
-					jen.Lit(""),
-				),
-				jen.List(jen.Id("msg"), jen.Err()).Op(":=").Id("deserializer").Call(
-					jen.Id("cmdCtx"),
-					jen.Id("requestFlags"),
-				),
-				jen.If(jen.Err().Op("!=").Nil()).Block(
-					jen.Return(jen.Qual("fmt", "Errorf").Call(
-						jen.Lit("custom deserializer failed: %w"),
-						jen.Err(),
-					)),
-				),
-				jen.If(jen.Id("msg").Op("==").Nil()).Block(
-					jen.Return(jen.Qual("fmt", "Errorf").Call(
-						jen.Lit("custom deserializer returned nil message"),
-					)),
-				),
-				jen.Var().Id("ok").Bool(),
-				jen.List(jen.Id("req"), jen.Id("ok")).Op("=").Id("msg").Assert(requestQualifiedType),
-				jen.If(jen.Op("!").Id("ok")).Block(
-					jen.Return(jen.Qual("fmt", "Errorf").Call(
-						jen.Lit("custom deserializer returned wrong type: expected *%s, got %T"),
-						jen.Lit(requestTypeName),
-						jen.Id("msg"),
-					)),
-				),
-			).Else().Block(
-				append([]jen.Code{
-					jen.Comment("Use auto-generated flag parsing"),
-					jen.Id("req").Op("=").Op("&").Add(qualifyType(file, method.Input, false)).Values(),
-				}, generateRequestFieldAssignments(file, service, method)...)...,
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Err()),
 			),
-		),
-		jen.Line(),
+			jen.Line(),
+		)
 	}
 
-	statements = append(statements, requestBuildBlock...)
-
 	// Open output writer
 	statements = append(statements, generateOutputWriterOpening(service)...)
+	statements = append(statements,
+		jen.Comment("Buffer the output, flushing it after each message per --flush"),
+		jen.List(jen.Id("streamOutput"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "NewStreamOutput").Call(
+			jen.Id("cmd"),
+			jen.Id("outputWriter"),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Err()),
+		),
+		jen.Defer().Id("streamOutput").Dot("Flush").Call(),
+		jen.Id("outputWriter").Op("=").Id("streamOutput"),
+		jen.Line(),
+	)
 
 	// Find output format
 	statements = append(statements,
@@ -356,6 +403,10 @@ func generateServerStreamingActionBody(file *protogen.File, service *protogen.Se
 				jen.Id("availableFormats"),
 			)),
 		),
+		jen.Comment("Warn about flags of other formats that the selected one ignores"),
+		jen.Qual("github.com/drewfead/proto-cli", "WarnIgnoredFormatFlags").Call(
+			jen.Id("cmd"), jen.Id("options").Dot("OutputFormats").Call(), jen.Id("formatName"),
+		),
 		jen.Line(),
 	)
 
@@ -363,6 +414,64 @@ func generateServerStreamingActionBody(file *protogen.File, service *protogen.Se
 	statements = append(statements,
 		jen.Comment("Get delimiter for separating streamed messages"),
 		jen.Id("delimiter").Op(":=").Id("cmd").Dot("String").Call(jen.Lit("delimiter")),
+		jen.Id("raw").Op(":=").Id("cmd").Dot("Bool").Call(jen.Lit("raw")),
+		jen.Line(),
+		jen.Comment("Gather the stream into one container message for --collect"),
+		jen.List(jen.Id("collector"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "NewStreamCollector").Call(
+			jen.Id("cmd"),
+			jen.Lit(string(method.Parent.Desc.ParentFile().Package().Append(protoreflect.Name(collectResultsName(method))))),
+			jen.Op("&").Add(qualifyType(file, method.Output, false)).Values(),
+		),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Err()),
+		),
+		jen.Line(),
+	)
+
+	// Track progress if the method names a total-count field in its response
+	totalField := progressTotalField(method)
+	if totalField != "" {
+		statements = append(statements,
+			jen.Comment("Report progress using the total from the first streamed message"),
+			jen.Id("progress").Op(":=").Qual("github.com/drewfead/proto-cli", "NewStreamProgress").Call(
+				jen.Id("cmd"),
+				jen.Lit(totalField),
+			),
+			jen.Defer().Id("progress").Dot("Finish").Call(),
+			jen.Line(),
+		)
+	}
+
+	// Drop messages outside --since/--until if the method names a timestamp field
+	windowed := tsField != ""
+	if windowed {
+		statements = append(statements,
+			jen.Comment("Drop messages outside the --since/--until window"),
+			jen.List(jen.Id("window"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "NewStreamWindow").Call(
+				jen.Id("cmd"),
+				jen.Lit(tsField),
+			),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Err()),
+			),
+			jen.Line(),
+		)
+	}
+
+	// Trace the stream, whichever way it is served, until it ends
+	statements = append(statements, generateOTelSpanStart(genOpts, "callSpan", "service call")...)
+	statements = append(statements,
+		jen.Comment("Start timing for --stream-summary"),
+		jen.Id("streamStart").Op(":=").Qual("time", "Now").Call(),
+		jen.Line(),
+		jen.If(jen.Qual("github.com/drewfead/proto-cli", "Replaying").Call(jen.Id("cmd"))).Block(
+			generateReplayStreamingCall(service, method, windowed)...,
+		),
+		jen.Line(),
+		jen.Comment("Record the stream for --replay (nil unless WithRecord is set)"),
+		jen.Id("recording").Op(":=").Qual("github.com/drewfead/proto-cli", "NewStreamRecording").Call(
+			jen.Id("cmd"), jen.Lit(rpcFullMethod(service, method)), jen.Id("req"),
+		),
 		jen.Line(),
 	)
 
@@ -372,7 +481,7 @@ func generateServerStreamingActionBody(file *protogen.File, service *protogen.Se
 		statements = append(statements,
 			jen.Comment("Local-only command: always use direct implementation call"),
 		)
-		statements = append(statements, generateLocalStreamingCall(service, method, configMessageType)...)
+		statements = append(statements, generateLocalStreamingCall(service, method, configMessageType, totalField != "", checkpointed, windowed)...)
 		statements = append(statements,
 			jen.Line(),
 			jen.Return(jen.Nil()),
@@ -385,27 +494,33 @@ func generateServerStreamingActionBody(file *protogen.File, service *protogen.Se
 			jen.Id("remoteAddr").Op(":=").Id("cmd").Dot("String").Call(jen.Lit("remote")),
 			jen.Line(),
 			jen.If(jen.Id("remoteAddr").Op("!=").Lit("")).Block(
-				generateRemoteStreamingCall(service, method, clientType)...,
+				generateRemoteStreamingCall(service, method, clientType, tokenField, requestField, totalField != "", windowed)...,
 			).Else().Block(
-				generateLocalStreamingCall(service, method, configMessageType)...,
+				generateLocalStreamingCall(service, method, configMessageType, totalField != "", checkpointed, windowed)...,
 			),
 			jen.Line(),
 			jen.Return(jen.Nil()),
 		)
 	}
 
-	return statements
+	return wrapOTelActionBody(file, service, method, genOpts, statements)
 }
 
 // generateRemoteStreamingCall generates code for remote streaming gRPC calls
-func generateRemoteStreamingCall(_ *protogen.Service, method *protogen.Method, clientType string) []jen.Code {
+func generateRemoteStreamingCall(_ *protogen.Service, method *protogen.Method, clientType, tokenField, requestField string, trackProgress, windowed bool) []jen.Code {
+	checkpointed := tokenField != ""
+	streamType := jen.Qual("google.golang.org/grpc", "ServerStreamingClient").Types(jen.Id(method.Output.GoIdent.GoName))
+
 	return []jen.Code{
 		jen.Comment("Remote gRPC streaming call"),
+		timingsPhase("service call"),
+		jen.List(jen.Id("callOpts"), jen.Id("optsErr")).Op(":=").Qual("github.com/drewfead/proto-cli", "RemoteCallOptions").Call(jen.Id("cmd")),
+		jen.If(jen.Id("optsErr").Op("!=").Nil()).Block(
+			jen.Return(jen.Id("optsErr")),
+		),
 		jen.List(jen.Id("conn"), jen.Id("connErr")).Op(":=").Qual("google.golang.org/grpc", "NewClient").Call(
 			jen.Id("remoteAddr"),
-			jen.Qual("google.golang.org/grpc", "WithTransportCredentials").Call(
-				jen.Qual("google.golang.org/grpc/credentials/insecure", "NewCredentials").Call(),
-			),
+			jen.Qual("github.com/drewfead/proto-cli", "RemoteDialOptions").Call(jen.Id("cmd")).Op("..."),
 		),
 		jen.If(jen.Id("connErr").Op("!=").Nil()).Block(
 			jen.Return(jen.Qual("fmt", "Errorf").Call(
@@ -420,68 +535,61 @@ func generateRemoteStreamingCall(_ *protogen.Service, method *protogen.Method, c
 		jen.List(jen.Id("stream"), jen.Err()).Op(":=").Id("client").Dot(method.GoName).Call(
 			jen.Id("cmdCtx"),
 			jen.Id("req"),
+			jen.Id("callOpts").Op("..."),
 		),
 		jen.If(jen.Err().Op("!=").Nil()).Block(
 			jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to start stream: %w"), jen.Err())),
 		),
 		jen.Line(),
+		jen.Comment("Re-establish the stream on failure or EOF when --reconnect is set"),
+		jen.Id("reconnector").Op(":=").Qual("github.com/drewfead/proto-cli", "NewStreamReconnector").Call(
+			jen.Id("cmd"),
+			jen.Lit(tokenField),
+			jen.Lit(requestField),
+		),
+		jen.Line(),
 		jen.Comment("Receive and format each message in the stream"),
 		jen.Var().Id("messageCount").Int(),
 		jen.For().Block(
+			timingsPhase("service call"),
 			jen.List(jen.Id("msg"), jen.Id("recvErr")).Op(":=").Id("stream").Dot("Recv").Call(),
+			jen.If(jen.Id("recvErr").Op("!=").Nil().Op("&&").Id("reconnector").Dot("Enabled").Call()).Block(
+				jen.List(jen.Id("stream"), jen.Err()).Op("=").Qual("github.com/drewfead/proto-cli", "ReconnectStream").Call(
+					jen.Id("cmdCtx"),
+					jen.Id("reconnector"),
+					jen.Id("req"),
+					jen.Id("recvErr"),
+					jen.Func().Params().Params(streamType, jen.Error()).Block(
+						jen.Return(jen.Id("client").Dot(method.GoName).Call(jen.Id("cmdCtx"), jen.Id("req"), jen.Id("callOpts").Op("..."))),
+					),
+				),
+				jen.If(jen.Err().Op("!=").Nil()).Block(
+					jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("stream receive error: %w"), jen.Err())),
+				),
+				jen.Continue(),
+			),
 			jen.If(jen.Id("recvErr").Op("==").Qual("io", "EOF")).Block(
 				jen.Break(),
 			),
 			jen.If(jen.Id("recvErr").Op("!=").Nil()).Block(
 				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("stream receive error: %w"), jen.Id("recvErr"))),
 			),
+			generateProgressObserve(trackProgress),
+			jen.Id("reconnector").Dot("Observe").Call(jen.Id("msg")),
+			jen.Id("recording").Dot("Observe").Call(jen.Id("msg")),
+			generateCheckpointObserve(checkpointed),
 			jen.Line(),
-			jen.Comment("Format and write the message"),
-			jen.If(
-				jen.Err().Op(":=").Id("outputFmt").Dot("Format").Call(
-					jen.Id("cmdCtx"),
-					jen.Id("cmd"),
-					jen.Id("outputWriter"),
-					jen.Id("msg"),
-				),
-				jen.Err().Op("!=").Nil(),
-			).Block(
-				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("format failed: %w"), jen.Err())),
-			),
-			jen.Line(),
-			jen.Comment("Write delimiter"),
-			jen.If(
-				jen.List(jen.Id("_"), jen.Err()).Op(":=").Id("outputWriter").Dot("Write").Call(
-					jen.Index().Byte().Call(jen.Id("delimiter")),
-				),
-				jen.Err().Op("!=").Nil(),
-			).Block(
-				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to write delimiter: %w"), jen.Err())),
-			),
-			jen.Id("messageCount").Op("++"),
+			generateStreamMessageWrite(checkpointed, windowed),
 		),
 		jen.Line(),
-		jen.Comment("Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)"),
-		jen.If(
-			jen.Id("messageCount").Op(">").Lit(0).Op("&&").Op("!").Qual("strings", "HasSuffix").Call(
-				jen.Id("delimiter"),
-				jen.Lit("\n"),
-			),
-		).Block(
-			jen.If(
-				jen.List(jen.Id("_"), jen.Err()).Op(":=").Id("outputWriter").Dot("Write").Call(
-					jen.Index().Byte().Call(jen.Lit("\n")),
-				),
-				jen.Err().Op("!=").Nil(),
-			).Block(
-				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to write final newline: %w"), jen.Err())),
-			),
-		),
+		jen.Id("recording").Dot("Finish").Call(),
+		generateStreamFinalNewline(),
+		jen.Qual("github.com/drewfead/proto-cli", "WriteStreamSummary").Call(jen.Id("cmd"), jen.Id("messageCount"), jen.Id("streamStart")),
 	}
 }
 
 // generateLocalStreamingCall generates code for local streaming calls
-func generateLocalStreamingCall(service *protogen.Service, method *protogen.Method, configMessageType string) []jen.Code {
+func generateLocalStreamingCall(service *protogen.Service, method *protogen.Method, configMessageType string, trackProgress, checkpointed, windowed bool) []jen.Code {
 	var statements []jen.Code
 
 	responseType := method.Output.GoIdent.GoName
@@ -491,6 +599,7 @@ func generateLocalStreamingCall(service *protogen.Service, method *protogen.Meth
 		// Service has config - need to load it and call factory
 		statements = append(statements,
 			jen.Comment("Load config and create service implementation"),
+			timingsPhase("config load"),
 			jen.Id("rootCmd").Op(":=").Id("cmd").Dot("Root").Call(),
 			jen.Id("configPaths").Op(":=").Id("rootCmd").Dot("StringSlice").Call(jen.Lit("config")),
 			jen.Id("envPrefix").Op(":=").Id("rootCmd").Dot("String").Call(jen.Lit("env-prefix")),
@@ -499,6 +608,7 @@ func generateLocalStreamingCall(service *protogen.Service, method *protogen.Meth
 				jen.Qual("github.com/drewfead/proto-cli", "SingleCommandMode"),
 				jen.Qual("github.com/drewfead/proto-cli", "FileConfig").Call(jen.Id("configPaths").Op("...")),
 				jen.Qual("github.com/drewfead/proto-cli", "EnvPrefix").Call(jen.Id("envPrefix")),
+				jen.Qual("github.com/drewfead/proto-cli", "SecretDir").Call(jen.Id("rootCmd").Dot("String").Call(jen.Lit("secret-dir"))),
 			),
 			jen.Line(),
 			jen.Id("config").Op(":=").Op("&").Id(configMessageType).Values(),
@@ -533,28 +643,32 @@ func generateLocalStreamingCall(service *protogen.Service, method *protogen.Meth
 
 	// Create local stream wrapper and call method
 	statements = append(statements,
+		timingsPhase("service call"),
+		jen.Comment("Cancel the stream if we stop receiving early (e.g. a format error)"),
+		jen.List(jen.Id("streamCtx"), jen.Id("cancelStream")).Op(":=").Qual("context", "WithCancel").Call(jen.Id("cmdCtx")),
+		jen.Defer().Id("cancelStream").Call(),
+		jen.Line(),
 		jen.Comment("Create local stream wrapper for direct call"),
 		jen.Id("localStream").Op(":=").Op("&").Id(streamTypeName).Values(jen.Dict{
-			jen.Id("ctx"):       jen.Id("cmdCtx"),
+			jen.Id("ctx"):       jen.Id("streamCtx"),
 			jen.Id("responses"): jen.Make(jen.Chan().Op("*").Id(responseType)),
-			jen.Id("errors"):    jen.Make(jen.Chan().Error()),
+			jen.Id("errors"):    jen.Make(jen.Chan().Error(), jen.Lit(1)),
 		}),
 		jen.Line(),
 	)
 
 	// Generate the method call inside goroutine (different based on config)
-	var methodCallStmt jen.Code
+	impl := jen.Id("svcImpl")
 	if configMessageType != "" {
-		methodCallStmt = jen.Id("methodErr").Op("=").Id("svcImpl").Assert(jen.Id(service.GoName+"Server")).Dot(method.GoName).Call(
-			jen.Id("req"),
-			jen.Id("localStream"),
-		)
-	} else {
-		methodCallStmt = jen.Id("methodErr").Op("=").Id("svcImpl").Dot(method.GoName).Call(
-			jen.Id("req"),
-			jen.Id("localStream"),
-		)
+		impl = jen.Id("svcImpl").Assert(jen.Id(service.GoName + "Server"))
 	}
+	methodCallStmt := jen.Id("methodErr").Op("=").Qual("github.com/drewfead/proto-cli", "RecoverMethodPanic").Call(
+		jen.Id("cmd"),
+		jen.Lit(localMethodName(service, method)),
+		jen.Func().Params().Error().Block(
+			jen.Return(impl.Dot(method.GoName).Call(jen.Id("req"), jen.Id("localStream"))),
+		),
+	)
 
 	statements = append(statements,
 		jen.Comment("Call streaming method in goroutine"),
@@ -571,6 +685,7 @@ func generateLocalStreamingCall(service *protogen.Service, method *protogen.Meth
 		jen.Comment("Receive and format each message in the stream"),
 		jen.Var().Id("messageCount").Int(),
 		jen.For().Block(
+			timingsPhase("service call"),
 			jen.Select().Block(
 				jen.Case(jen.List(jen.Id("msg"), jen.Id("ok")).Op(":=").Op("<-").Id("localStream").Dot("responses")).Block(
 					jen.If(jen.Op("!").Id("ok")).Block(
@@ -578,48 +693,16 @@ func generateLocalStreamingCall(service *protogen.Service, method *protogen.Meth
 						jen.If(jen.Id("streamErr").Op(":=").Op("<-").Id("localStream").Dot("errors"), jen.Id("streamErr").Op("!=").Nil()).Block(
 							jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("stream error: %w"), jen.Id("streamErr"))),
 						),
-						jen.Comment("Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)"),
-						jen.If(
-							jen.Id("messageCount").Op(">").Lit(0).Op("&&").Op("!").Qual("strings", "HasSuffix").Call(
-								jen.Id("delimiter"),
-								jen.Lit("\n"),
-							),
-						).Block(
-							jen.If(
-								jen.List(jen.Id("_"), jen.Err()).Op(":=").Id("outputWriter").Dot("Write").Call(
-									jen.Index().Byte().Call(jen.Lit("\n")),
-								),
-								jen.Err().Op("!=").Nil(),
-							).Block(
-								jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to write final newline: %w"), jen.Err())),
-							),
-						),
+						jen.Id("recording").Dot("Finish").Call(),
+						generateStreamFinalNewline(),
+						jen.Qual("github.com/drewfead/proto-cli", "WriteStreamSummary").Call(jen.Id("cmd"), jen.Id("messageCount"), jen.Id("streamStart")),
 						jen.Return(jen.Nil()),
 					),
+					generateProgressObserve(trackProgress),
+					jen.Id("recording").Dot("Observe").Call(jen.Id("msg")),
+					generateCheckpointObserve(checkpointed),
 					jen.Line(),
-					jen.Comment("Format and write the message"),
-					jen.If(
-						jen.Err().Op(":=").Id("outputFmt").Dot("Format").Call(
-							jen.Id("cmdCtx"),
-							jen.Id("cmd"),
-							jen.Id("outputWriter"),
-							jen.Id("msg"),
-						),
-						jen.Err().Op("!=").Nil(),
-					).Block(
-						jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("format failed: %w"), jen.Err())),
-					),
-					jen.Line(),
-					jen.Comment("Write delimiter"),
-					jen.If(
-						jen.List(jen.Id("_"), jen.Err()).Op(":=").Id("outputWriter").Dot("Write").Call(
-							jen.Index().Byte().Call(jen.Id("delimiter")),
-						),
-						jen.Err().Op("!=").Nil(),
-					).Block(
-						jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to write delimiter: %w"), jen.Err())),
-					),
-					jen.Id("messageCount").Op("++"),
+					generateStreamMessageWrite(checkpointed, windowed),
 				),
 				jen.Case(jen.Op("<-").Id("cmdCtx").Dot("Done").Call()).Block(
 					jen.Return(jen.Id("cmdCtx").Dot("Err").Call()),
@@ -631,6 +714,187 @@ func generateLocalStreamingCall(service *protogen.Service, method *protogen.Meth
 	return statements
 }
 
+// generateStreamMessageWrite generates the per-message output of a streaming
+// loop, timed as the --timings format phase: skip messages outside
+// --since/--until when windowed, drop --fields-exclude paths, then either
+// write msg length-prefixed for --raw or format it and write the delimiter.
+// When checkpointed, the --checkpoint-file is updated once the message is
+// written.
+func generateStreamMessageWrite(checkpointed, windowed bool) jen.Code {
+	filter := jen.Null()
+	if windowed {
+		filter = generateWindowFilter().Line().Line()
+	}
+	return timingsPhase("format").Line().
+		Add(filter).Add(generateFieldsExclude("msg", ":=")).Line().
+		Line().
+		Comment("Hold the message for --collect; the container is written when the stream ends").Line().
+		If(jen.Id("collector").Dot("Add").Call(jen.Id("msg"))).Block(
+			jen.Id("messageCount").Op("++"),
+			jen.Continue(),
+		).Line().
+		Line().
+		Comment("Write length-prefixed binary frames for --raw").Line().
+		If(jen.Id("raw")).Block(
+			jen.If(
+				jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "WriteRawMessage").Call(
+					jen.Id("outputWriter"),
+					jen.Id("msg"),
+				),
+				jen.Err().Op("!=").Nil(),
+			).Block(
+				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to write message: %w"), jen.Err())),
+			),
+			jen.Id("messageCount").Op("++"),
+			generateStreamFlush(checkpointed),
+			generateCheckpointSave(checkpointed),
+			jen.Continue(),
+		).Line().
+		Line().
+		Comment("Format and write the message").Line().
+		If(
+			jen.Err().Op(":=").Qual("github.com/drewfead/proto-cli", "FormatMessage").Call(
+				jen.Id("cmdCtx"),
+				jen.Id("cmd"),
+				jen.Id("outputFmt"),
+				jen.Id("outputWriter"),
+				jen.Id("msg"),
+			),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("format failed: %w"), jen.Err())),
+		).Line().
+		Line().
+		Comment("Write delimiter").Line().
+		If(
+			jen.List(jen.Id("_"), jen.Err()).Op(":=").Id("outputWriter").Dot("Write").Call(
+				jen.Index().Byte().Call(jen.Id("delimiter")),
+			),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to write delimiter: %w"), jen.Err())),
+		).Line().
+		Id("messageCount").Op("++").Line().
+		Add(generateStreamFlush(checkpointed)).Line().
+		Add(generateCheckpointSave(checkpointed))
+}
+
+// generateWindowFilter generates the skip of messages outside the --since/--until
+// window.
+func generateWindowFilter() *jen.Statement {
+	return jen.Comment("Skip messages outside the --since/--until window").Line().If(jen.Op("!").Id("window").Dot("Contains").Call(jen.Id("msg"))).Block(
+		jen.Continue(),
+	)
+}
+
+// generateStreamFlush generates the flush after a streamed message is written.
+// Checkpointed streams always flush, so the --checkpoint-file never records a
+// message that is still buffered; others flush per --flush.
+func generateStreamFlush(checkpointed bool) jen.Code {
+	flush := jen.Id("streamOutput").Dot("MessageDone").Call()
+	if checkpointed {
+		flush = jen.Id("streamOutput").Dot("Flush").Call()
+	}
+	return jen.If(jen.Err().Op(":=").Add(flush), jen.Err().Op("!=").Nil()).Block(
+		jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to flush output: %w"), jen.Err())),
+	)
+}
+
+// generateCheckpointObserve generates the per-message resume token capture for
+// --checkpoint-file. Returns an empty statement for methods without a resume token.
+func generateCheckpointObserve(checkpointed bool) jen.Code {
+	if !checkpointed {
+		return jen.Null()
+	}
+	return jen.Id("checkpoint").Dot("Observe").Call(jen.Id("msg"))
+}
+
+// generateCheckpointSave generates the checkpoint update after a message is
+// written. Returns an empty statement for methods without a resume token.
+func generateCheckpointSave(checkpointed bool) jen.Code {
+	if !checkpointed {
+		return jen.Null()
+	}
+	return jen.If(jen.Err().Op(":=").Id("checkpoint").Dot("Save").Call(), jen.Err().Op("!=").Nil()).Block(
+		jen.Return(jen.Err()),
+	)
+}
+
+// generateStreamFinalNewline generates the newline written after the last
+// message unless the delimiter already ends with one, the output is --raw, or
+// the format opts out with TrailingNewlineControl, then flushes the buffered
+// output.
+func generateStreamFinalNewline() jen.Code {
+	return timingsPhase("format").Line().
+		Comment("Write the --collect container now that the stream has ended").Line().
+		If(
+			jen.Err().Op(":=").Id("collector").Dot("Write").Call(
+				jen.Id("cmdCtx"), jen.Id("cmd"), jen.Id("outputWriter"), jen.Id("outputFmt"), jen.Id("raw"),
+			),
+			jen.Err().Op("!=").Nil(),
+		).Block(
+			jen.Return(jen.Err()),
+		).Line().
+		Line().
+		Comment("Write final newline to keep terminal clean (only if delimiter doesn't already end with newline)").Line().
+		If(
+			jen.Id("messageCount").Op(">").Lit(0).Op("&&").Op("!").Id("raw").Op("&&").Op("!").Id("collector").Dot("Collecting").Call().Op("&&").Op("!").Qual("strings", "HasSuffix").Call(
+				jen.Id("delimiter"),
+				jen.Lit("\n"),
+			).Op("&&").Qual("github.com/drewfead/proto-cli", "NeedsTrailingNewline").Call(jen.Id("outputFmt")),
+		).Block(
+			jen.If(
+				jen.List(jen.Id("_"), jen.Err()).Op(":=").Id("outputWriter").Dot("Write").Call(
+					jen.Index().Byte().Call(jen.Lit("\n")),
+				),
+				jen.Err().Op("!=").Nil(),
+			).Block(
+				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to write final newline: %w"), jen.Err())),
+			),
+		).Line().
+		If(jen.Err().Op(":=").Id("streamOutput").Dot("Flush").Call(), jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("failed to flush output: %w"), jen.Err())),
+		)
+}
+
+// collectResultsName returns the name of the --collect container message of a
+// server-streaming method, e.g. "WatchItemsResults".
+func collectResultsName(method *protogen.Method) string {
+	return method.GoName + "Results"
+}
+
+// generateReplayStreamingCall generates the stream served from a recording
+// when --replay is set.
+func generateReplayStreamingCall(service *protogen.Service, method *protogen.Method, windowed bool) []jen.Code {
+	return []jen.Code{
+		jen.Comment("Replay the recorded stream instead of calling the service"),
+		timingsPhase("service call"),
+		jen.List(jen.Id("replayed"), jen.Err()).Op(":=").Qual("github.com/drewfead/proto-cli", "ReplayStream").Types(
+			jen.Op("*").Id(method.Output.GoIdent.GoName),
+		).Call(jen.Id("cmd"), jen.Lit(rpcFullMethod(service, method)), jen.Id("req")),
+		jen.If(jen.Err().Op("!=").Nil()).Block(
+			jen.Return(jen.Err()),
+		),
+		jen.Var().Id("messageCount").Int(),
+		jen.For(jen.List(jen.Id("_"), jen.Id("msg")).Op(":=").Range().Id("replayed")).Block(
+			generateStreamMessageWrite(false, windowed),
+		),
+		jen.Line(),
+		generateStreamFinalNewline(),
+		jen.Qual("github.com/drewfead/proto-cli", "WriteStreamSummary").Call(jen.Id("cmd"), jen.Id("messageCount"), jen.Id("streamStart")),
+		jen.Return(jen.Nil()),
+	}
+}
+
+// generateProgressObserve generates the per-message progress update for streaming loops.
+// Returns an empty statement when the method has no progress_total_field annotation.
+func generateProgressObserve(trackProgress bool) jen.Code {
+	if !trackProgress {
+		return jen.Null()
+	}
+	return jen.Id("progress").Dot("Observe").Call(jen.Id("msg"))
+}
+
 // generateLocalStreamWrapper generates a helper type for local streaming calls
 // This is generated at the package level (not inside a function)
 func generateLocalStreamWrapper(f *jen.File, service *protogen.Service, method *protogen.Method) {
@@ -728,3 +992,163 @@ func generateLocalStreamWrapper(f *jen.File, service *protogen.Service, method *
 	)
 	f.Line()
 }
+
+// generateLocalStreamFunc generates <Service><Method>, which runs a server
+// streaming method in-process through its local stream wrapper and returns the
+// streamed messages on a channel, for Go programs embedding the CLI.
+func generateLocalStreamFunc(f *jen.File, file *protogen.File, service *protogen.Service, method *protogen.Method) {
+	funcName := service.GoName + method.GoName
+	streamTypeName := streamWrapperTypeName(service, method)
+	responseType := method.Output.GoIdent.GoName
+
+	f.Comment(fmt.Sprintf("%s runs impl.%s in-process.", funcName, method.GoName))
+	f.Comment("The streamed messages arrive on the first channel, which is closed when the")
+	f.Comment("stream ends; the second then yields the method's error, if any, and is")
+	f.Comment("closed. Cancel ctx to stop the stream early.")
+	f.Func().Id(funcName).Params(
+		jen.Id("ctx").Qual("context", "Context"),
+		jen.Id("impl").Id(service.GoName+"Server"),
+		jen.Id("req").Add(qualifyType(file, method.Input, true)),
+	).Params(
+		jen.Op("<-").Chan().Op("*").Id(responseType),
+		jen.Op("<-").Chan().Error(),
+	).Block(
+		jen.Id("localStream").Op(":=").Op("&").Id(streamTypeName).Values(jen.Dict{
+			jen.Id("ctx"):       jen.Id("ctx"),
+			jen.Id("responses"): jen.Make(jen.Chan().Op("*").Id(responseType)),
+			jen.Id("errors"):    jen.Make(jen.Chan().Error(), jen.Lit(1)),
+		}),
+		jen.Go().Func().Params().Block(
+			jen.Id("methodErr").Op(":=").Id("impl").Dot(method.GoName).Call(jen.Id("req"), jen.Id("localStream")),
+			jen.Close(jen.Id("localStream").Dot("responses")),
+			jen.If(jen.Id("methodErr").Op("!=").Nil()).Block(
+				jen.Id("localStream").Dot("errors").Op("<-").Id("methodErr"),
+			),
+			jen.Close(jen.Id("localStream").Dot("errors")),
+		).Call(),
+		jen.Return(jen.Id("localStream").Dot("responses"), jen.Id("localStream").Dot("errors")),
+	)
+	f.Line()
+}
+
+// resumeTokenFields returns the method's resume_token_field and
+// resume_request_field annotations after checking that both paths exist and
+// hold the same kind of value. Invalid annotations are reported and ignored.
+func resumeTokenFields(method *protogen.Method) (string, string) {
+	cmdOpts := getMethodCommandOptions(method)
+	tokenField := cmdOpts.GetResumeTokenField()
+	if tokenField == "" {
+		return "", ""
+	}
+	requestField := cmdOpts.GetResumeRequestField()
+	if requestField == "" {
+		requestField = tokenField
+	}
+
+	tokenDesc := lookupFieldPath(method.Output, tokenField)
+	requestDesc := lookupFieldPath(method.Input, requestField)
+	switch {
+	case tokenDesc == nil:
+		fmt.Fprintf(os.Stderr, "WARNING: %s: resume_token_field %q is not a field of %s; ignoring\n",
+			method.Desc.FullName(), tokenField, method.Output.Desc.FullName())
+		return "", ""
+	case requestDesc == nil:
+		fmt.Fprintf(os.Stderr, "WARNING: %s: resume_request_field %q is not a field of %s; ignoring\n",
+			method.Desc.FullName(), requestField, method.Input.Desc.FullName())
+		return "", ""
+	case tokenDesc.Desc.Kind() != requestDesc.Desc.Kind() || tokenDesc.Desc.Cardinality() != requestDesc.Desc.Cardinality():
+		fmt.Fprintf(os.Stderr, "WARNING: %s: resume token %q and request field %q have different types; ignoring\n",
+			method.Desc.FullName(), tokenField, requestField)
+		return "", ""
+	}
+	return tokenField, requestField
+}
+
+// progressTotalField returns the method's progress_total_field annotation
+// after checking that it names a singular integer field of the response.
+// Invalid annotations are reported and ignored.
+func progressTotalField(method *protogen.Method) string {
+	name := getMethodCommandOptions(method).GetProgressTotalField()
+	if name == "" {
+		return ""
+	}
+	var field *protogen.Field
+	for _, f := range method.Output.Fields {
+		if string(f.Desc.Name()) == name {
+			field = f
+			break
+		}
+	}
+	if field == nil {
+		fmt.Fprintf(os.Stderr, "WARNING: %s: progress_total_field %q is not a field of %s; ignoring\n",
+			method.Desc.FullName(), name, method.Output.Desc.FullName())
+		return ""
+	}
+	if !field.Desc.IsList() && !field.Desc.IsMap() {
+		switch field.Desc.Kind() {
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+			protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+			protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+			protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			return name
+		}
+	}
+	fmt.Fprintf(os.Stderr, "WARNING: %s: progress_total_field %q must be a singular integer field; ignoring\n",
+		method.Desc.FullName(), name)
+	return ""
+}
+
+// timestampField returns the method's timestamp_field annotation after checking
+// that the path is a singular google.protobuf.Timestamp, integer or string
+// field. Invalid annotations are reported and ignored.
+func timestampField(method *protogen.Method) string {
+	path := getMethodCommandOptions(method).GetTimestampField()
+	if path == "" {
+		return ""
+	}
+	field := lookupFieldPath(method.Output, path)
+	if field == nil {
+		fmt.Fprintf(os.Stderr, "WARNING: %s: timestamp_field %q is not a field of %s; ignoring\n",
+			method.Desc.FullName(), path, method.Output.Desc.FullName())
+		return ""
+	}
+	switch kind := field.Desc.Kind(); {
+	case field.Desc.IsList() || field.Desc.IsMap():
+	case kind == protoreflect.MessageKind && field.Message.Desc.FullName() == "google.protobuf.Timestamp",
+		kind == protoreflect.StringKind,
+		kind == protoreflect.Int32Kind, kind == protoreflect.Sint32Kind, kind == protoreflect.Sfixed32Kind,
+		kind == protoreflect.Int64Kind, kind == protoreflect.Sint64Kind, kind == protoreflect.Sfixed64Kind,
+		kind == protoreflect.Uint32Kind, kind == protoreflect.Fixed32Kind,
+		kind == protoreflect.Uint64Kind, kind == protoreflect.Fixed64Kind:
+		return path
+	}
+	fmt.Fprintf(os.Stderr, "WARNING: %s: timestamp_field %q must be a google.protobuf.Timestamp, integer or string field; ignoring\n",
+		method.Desc.FullName(), path)
+	return ""
+}
+
+// lookupFieldPath resolves a dot-separated proto field path within msg,
+// descending only through singular message fields.
+func lookupFieldPath(msg *protogen.Message, path string) *protogen.Field {
+	var field *protogen.Field
+	for _, name := range strings.Split(path, ".") {
+		if msg == nil {
+			return nil
+		}
+		field = nil
+		for _, f := range msg.Fields {
+			if string(f.Desc.Name()) == name {
+				field = f
+				break
+			}
+		}
+		if field == nil {
+			return nil
+		}
+		msg = nil
+		if !field.Desc.IsList() && !field.Desc.IsMap() {
+			msg = field.Message
+		}
+	}
+	return field
+}
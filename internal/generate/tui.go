@@ -13,7 +13,7 @@ const protocliPkg = "github.com/drewfead/proto-cli"
 
 // generateTUIDescriptor returns a jen expression initializing &protocli.TUIServiceDescriptor{...}.
 // Returns nil if the service does not have tui=true.
-func generateTUIDescriptor(file *protogen.File, service *protogen.Service) jen.Code {
+func generateTUIDescriptor(file *protogen.File, service *protogen.Service, genOpts Options) jen.Code {
 	serviceOpts := getServiceOptions(service)
 	if serviceOpts == nil || serviceOpts.GetTui() == nil {
 		return nil
@@ -43,11 +43,11 @@ func generateTUIDescriptor(file *protogen.File, service *protogen.Service) jen.C
 
 	// Generate method descriptors (skip client-streaming)
 	var methodElems []jen.Code
-	for _, method := range service.Methods {
+	for _, method := range orderedMethods(service) {
 		if method.Desc.IsStreamingClient() {
 			continue
 		}
-		methodElems = append(methodElems, generateTUIMethodDescriptor(file, service, method, configMessageType))
+		methodElems = append(methodElems, generateTUIMethodDescriptor(file, service, method, configMessageType, genOpts))
 	}
 
 	return jen.Op("&").Qual(protocliPkg, "TUIServiceDescriptor").Values(jen.Dict{
@@ -66,6 +66,7 @@ func generateTUIMethodDescriptor(
 	service *protogen.Service,
 	method *protogen.Method,
 	configMessageType string,
+	genOpts Options,
 ) jen.Code {
 	cmdName := toKebabCase(method.GoName)
 	displayName := method.GoName
@@ -100,7 +101,7 @@ func generateTUIMethodDescriptor(
 	)
 
 	// InputFields slice
-	fieldDescs := generateTUIFieldDescriptors(file, service, method.Input, reqQualifiedType, nil)
+	fieldDescs := generateTUIFieldDescriptors(file, service, method.Input, reqQualifiedType, nil, genOpts)
 
 	responseDescriptor := jen.Qual(protocliPkg, "TUIResponseDescriptor").Values(jen.Dict{
 		jen.Id("MethodName"):      jen.Lit(cmdName),
@@ -136,11 +137,12 @@ func generateTUIFieldDescriptors(
 	message *protogen.Message,
 	reqQualifiedType *jen.Statement,
 	parentChain []fieldChainEntry,
+	genOpts Options,
 ) []jen.Code {
 	var descriptors []jen.Code
 
 	for _, field := range message.Fields {
-		desc := generateTUIFieldDescriptor(file, service, field, reqQualifiedType, parentChain)
+		desc := generateTUIFieldDescriptor(file, service, field, reqQualifiedType, parentChain, genOpts)
 		if desc != nil {
 			descriptors = append(descriptors, desc)
 		}
@@ -164,11 +166,12 @@ func generateTUIFieldDescriptor(
 	field *protogen.Field,
 	reqQualifiedType *jen.Statement,
 	parentChain []fieldChainEntry,
+	genOpts Options,
 ) jen.Code {
 	flagOpts := getFieldFlagOptions(field)
 
 	// Determine flag name and label
-	flagName := toKebabCase(field.GoName)
+	flagName := genOpts.fieldFlagName(field)
 	label := flagName
 	usage := ""
 	description := ""
@@ -177,10 +180,6 @@ func generateTUIFieldDescriptor(
 	hidden := false
 
 	if flagOpts != nil {
-		if flagOpts.Name != "" {
-			flagName = flagOpts.Name
-			label = flagName
-		}
 		if flagOpts.GetTui().GetLabel() != "" {
 			label = flagOpts.GetTui().GetLabel()
 		}
@@ -215,7 +214,7 @@ func generateTUIFieldDescriptor(
 
 	// Determine kind and generate setter/appender
 	kind, setter, appender := generateTUIFieldKindAndSetters(
-		file, service, field, reqQualifiedType, parentChain,
+		file, service, field, reqQualifiedType, parentChain, genOpts,
 	)
 	if kind < 0 {
 		// Unsupported field kind (GroupKind)
@@ -245,6 +244,12 @@ func generateTUIFieldDescriptor(
 		dict[jen.Id("MessageFullName")] = jen.Lit(string(field.Message.Desc.FullName()))
 	}
 
+	// Members of a real oneof are grouped so the TUI offers them as a choice;
+	// the synthetic oneofs of proto3 optional fields are not.
+	if oneof := field.Desc.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+		dict[jen.Id("OneofGroup")] = jen.Lit(string(oneof.Name()))
+	}
+
 	if setter != nil {
 		dict[jen.Id("Setter")] = setter
 	}
@@ -261,7 +266,7 @@ func generateTUIFieldDescriptor(
 				typeName: field.Message.GoIdent.GoName,
 				typeCode: qualifyType(file, field.Message, false),
 			})
-			nestedFields := generateTUIFieldDescriptors(file, service, field.Message, reqQualifiedType, chain)
+			nestedFields := generateTUIFieldDescriptors(file, service, field.Message, reqQualifiedType, chain, genOpts)
 			if len(nestedFields) > 0 {
 				dict[jen.Id("Fields")] = jen.Index().Qual(protocliPkg, "TUIFieldDescriptor").Values(nestedFields...)
 			}
@@ -337,7 +342,7 @@ func elementKindForField(field *protogen.Field) int {
 // isWellKnownType returns true for proto well-known types that have special string-input handling.
 func isWellKnownType(fullName string) bool {
 	switch fullName {
-	case "google.protobuf.Timestamp", "google.protobuf.Duration":
+	case "google.protobuf.Timestamp", "google.protobuf.Duration", "google.protobuf.FieldMask":
 		return true
 	default:
 		return false
@@ -351,23 +356,24 @@ func tuiWKTUsageHint(fullName string) string {
 		return "RFC3339 timestamp (e.g. 2006-01-02T15:04:05Z)"
 	case "google.protobuf.Duration":
 		return "Go duration (e.g. 1h30m, 300ms)"
+	case "google.protobuf.FieldMask":
+		return "comma-separated field paths (e.g. name,email)"
 	default:
 		return ""
 	}
 }
 
 // generateWKTSetterClosure generates a setter for well-known type message fields.
-// Timestamp expects RFC3339; Duration expects Go duration notation.
+// Timestamp expects RFC3339; Duration expects Go duration notation; FieldMask
+// expects comma-separated field paths.
 func generateWKTSetterClosure(
 	field *protogen.Field,
 	reqQualifiedType *jen.Statement,
 	parentChain []fieldChainEntry,
+	genOpts Options,
 ) jen.Code {
 	fullName := string(field.Message.Desc.FullName())
-	flagName := toKebabCase(field.GoName)
-	if flagOpts := getFieldFlagOptions(field); flagOpts != nil && flagOpts.Name != "" {
-		flagName = flagOpts.Name
-	}
+	flagName := genOpts.fieldFlagName(field)
 
 	initStmts, fieldAccess := buildAccessorChain(parentChain, field.GoName)
 
@@ -404,6 +410,20 @@ func generateWKTSetterClosure(
 			fieldAccess.Clone().Op("=").Qual("google.golang.org/protobuf/types/known/durationpb", "New").Call(jen.Id("d")),
 		)
 
+	case "google.protobuf.FieldMask":
+		body = append(body,
+			jen.List(jen.Id("mask"), jen.Err()).Op(":=").Qual(protocliPkg, "ParseFieldMask").Call(
+				jen.Id("s"), jen.Lit(getFieldFlagOptions(field).GetFieldMaskTarget()),
+			),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.Return(jen.Qual("fmt", "Errorf").Call(
+					jen.Lit(fmt.Sprintf("invalid field mask for %s: %%w", flagName)),
+					jen.Err(),
+				)),
+			),
+			fieldAccess.Clone().Op("=").Id("mask"),
+		)
+
 	default:
 		return nil
 	}
@@ -445,6 +465,7 @@ func generateTUIFieldKindAndSetters(
 	field *protogen.Field,
 	reqQualifiedType *jen.Statement,
 	parentChain []fieldChainEntry,
+	genOpts Options,
 ) (kind int, setter jen.Code, appender jen.Code) {
 	k := field.Desc.Kind()
 
@@ -461,7 +482,7 @@ func generateTUIFieldKindAndSetters(
 			// Can't auto-generate for repeated messages
 			return kindRepeated, nil, nil
 		}
-		appender = generateTUIAppenderClosure(service, field, reqQualifiedType, parentChain)
+		appender = generateTUIAppenderClosure(service, field, reqQualifiedType, parentChain, genOpts)
 		return kindRepeated, nil, appender
 	}
 
@@ -469,7 +490,7 @@ func generateTUIFieldKindAndSetters(
 		// Well-known types get a string setter instead of nested Fields.
 		fullName := string(field.Message.Desc.FullName())
 		if isWellKnownType(fullName) {
-			setter = generateWKTSetterClosure(field, reqQualifiedType, parentChain)
+			setter = generateWKTSetterClosure(field, reqQualifiedType, parentChain, genOpts)
 			return kindString, setter, nil
 		}
 		// Other message fields: no setter, nested Fields are handled separately
@@ -482,7 +503,7 @@ func generateTUIFieldKindAndSetters(
 		return kindSkip, nil, nil
 	}
 
-	setter = generateTUISetterClosure(service, field, reqQualifiedType, parentChain)
+	setter = generateTUISetterClosure(service, field, reqQualifiedType, parentChain, genOpts)
 	return tKind, setter, nil
 }
 
@@ -538,11 +559,9 @@ func generateTUISetterClosure(
 	field *protogen.Field,
 	reqQualifiedType *jen.Statement,
 	parentChain []fieldChainEntry,
+	genOpts Options,
 ) jen.Code {
-	flagName := toKebabCase(field.GoName)
-	if flagOpts := getFieldFlagOptions(field); flagOpts != nil && flagOpts.Name != "" {
-		flagName = flagOpts.Name
-	}
+	flagName := genOpts.fieldFlagName(field)
 
 	oneof := field.Desc.ContainingOneof()
 	isOptional := field.Desc.HasPresence() && (oneof == nil || (oneof != nil && oneof.IsSynthetic()))
@@ -738,11 +757,9 @@ func generateTUIAppenderClosure(
 	field *protogen.Field,
 	reqQualifiedType *jen.Statement,
 	parentChain []fieldChainEntry,
+	genOpts Options,
 ) jen.Code {
-	flagName := toKebabCase(field.GoName)
-	if flagOpts := getFieldFlagOptions(field); flagOpts != nil && flagOpts.Name != "" {
-		flagName = flagOpts.Name
-	}
+	flagName := genOpts.fieldFlagName(field)
 
 	initStmts, fieldAccess := buildAccessorChain(parentChain, field.GoName)
 
@@ -901,6 +918,7 @@ func generateTUIInvokeClosure(
 				jen.Qual(protocliPkg, "SingleCommandMode"),
 				jen.Qual(protocliPkg, "FileConfig").Call(jen.Id("configPaths").Op("...")),
 				jen.Qual(protocliPkg, "EnvPrefix").Call(jen.Id("envPrefix")),
+				jen.Qual(protocliPkg, "SecretDir").Call(jen.Id("rootCmd").Dot("String").Call(jen.Lit("secret-dir"))),
 			),
 			jen.Id("config").Op(":=").Op("&").Id(configMessageType).Values(),
 			jen.If(
@@ -1002,6 +1020,7 @@ func generateTUIInvokeStreamClosure(
 				jen.Qual(protocliPkg, "SingleCommandMode"),
 				jen.Qual(protocliPkg, "FileConfig").Call(jen.Id("configPaths").Op("...")),
 				jen.Qual(protocliPkg, "EnvPrefix").Call(jen.Id("envPrefix")),
+				jen.Qual(protocliPkg, "SecretDir").Call(jen.Id("rootCmd").Dot("String").Call(jen.Lit("secret-dir"))),
 			),
 			jen.Id("config").Op(":=").Op("&").Id(configMessageType).Values(),
 			jen.If(
@@ -1,8 +1,13 @@
 package generate
 
 import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/dave/jennifer/jen"
@@ -63,6 +68,17 @@ func toKebabCase(s string) string {
 	return result.String()
 }
 
+// toLowerCamelCase lowercases the first letter of a Go identifier.
+// Examples: StartTime -> startTime, UserService -> userService.
+func toLowerCamelCase(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
 // toTitleCase converts a kebab-case or snake_case identifier to Title Case.
 // Each word separated by '-' or '_' is capitalised and words are joined with spaces.
 // Examples: send-at → "Send At", name → "Name", color_hex → "Color Hex".
@@ -87,6 +103,21 @@ func cleanProtoComment(comment protogen.Comments) string {
 	return strings.TrimSpace(string(comment))
 }
 
+// commentDescription returns a multi-line proto comment with the space after
+// each "//" removed, for use as a command's long description. Single-line
+// comments return "" since they already serve as the usage line.
+func commentDescription(comment protogen.Comments) string {
+	cleaned := cleanProtoComment(comment)
+	if !strings.Contains(cleaned, "\n") {
+		return ""
+	}
+	lines := strings.Split(string(comment), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(strings.TrimPrefix(line, " "), " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
 // firstLine returns the first line of a multiline string.
 func firstLine(s string) string {
 	if i := strings.IndexByte(s, '\n'); i >= 0 {
@@ -140,6 +171,106 @@ func getServiceConfigOptions(service *protogen.Service) *annotations.ServiceConf
 	return configOpts
 }
 
+// methodCommandName returns the CLI command name for a method: the
+// (cli.command).name annotation when present, otherwise kebab-case.
+func methodCommandName(method *protogen.Method) string {
+	if name := getMethodCommandOptions(method).GetName(); name != "" {
+		return name
+	}
+	return toKebabCase(method.GoName)
+}
+
+// orderedMethods returns the methods of service sorted by (cli.command).order,
+// keeping declaration order for ties.
+func orderedMethods(service *protogen.Service) []*protogen.Method {
+	methods := slices.Clone(service.Methods)
+	slices.SortStableFunc(methods, func(a, b *protogen.Method) int {
+		return cmp.Compare(getMethodCommandOptions(a).GetOrder(), getMethodCommandOptions(b).GetOrder())
+	})
+	return methods
+}
+
+// methodCommandGroup returns the (cli.command).group of method, or "" when the
+// method is not grouped. A group named like a command or alias of the service,
+// or like the daemonize, formats and validate commands listed beside flat and
+// hoisted method commands, would shadow it, so it is reported and ignored.
+func methodCommandGroup(service *protogen.Service, method *protogen.Method) string {
+	group := getMethodCommandOptions(method).GetGroup()
+	if group == "" {
+		return ""
+	}
+	taken := slices.Contains([]string{"daemonize", "formats", "validate"}, group)
+	for _, m := range service.Methods {
+		if getMethodCommandOptions(m).GetGroup() == "" &&
+			(methodCommandName(m) == group || slices.Contains(methodCommandAliases(service, m), group)) {
+			taken = true
+		}
+	}
+	if taken {
+		fmt.Fprintf(os.Stderr, "WARNING: Method %s group %q collides with another command in %s; ignoring group\n",
+			method.Desc.FullName(), group, service.Desc.FullName())
+		return ""
+	}
+	return group
+}
+
+// commandsVarName returns the generated variable collecting the commands of
+// group, or the service's own commands when group is "".
+func commandsVarName(group string) string {
+	if group == "" {
+		return "commands"
+	}
+	return "commands_" + groupIdent(group)
+}
+
+// groupIdent turns a command group name into an identifier suffix, e.g.
+// "user-admin" -> "user_admin".
+func groupIdent(group string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, group)
+}
+
+// methodCommandAliases returns the (cli.command).aliases of method. Aliases
+// that repeat a command name or an earlier alias in the same service would make
+// invocation ambiguous, so they are reported and dropped.
+func methodCommandAliases(service *protogen.Service, method *protogen.Method) []string {
+	taken := make(map[string]bool, len(service.Methods))
+	for _, m := range service.Methods {
+		taken[methodCommandName(m)] = true
+	}
+	for _, m := range service.Methods {
+		var aliases []string
+		for _, alias := range getMethodCommandOptions(m).GetAliases() {
+			if taken[alias] {
+				if m == method {
+					fmt.Fprintf(os.Stderr, "WARNING: Method %s alias %q collides with another command in %s; ignoring alias\n",
+						m.Desc.FullName(), alias, service.Desc.FullName())
+				}
+				continue
+			}
+			taken[alias] = true
+			aliases = append(aliases, alias)
+		}
+		if m == method {
+			return aliases
+		}
+	}
+	return nil
+}
+
+// aliasesCode returns a []string literal of aliases.
+func aliasesCode(aliases []string) jen.Code {
+	values := make([]jen.Code, len(aliases))
+	for i, alias := range aliases {
+		values[i] = jen.Lit(alias)
+	}
+	return jen.Index().String().Values(values...)
+}
+
 // getServiceOptions extracts (cli.service) annotation from a service.
 func getServiceOptions(service *protogen.Service) *annotations.ServiceOptions {
 	opts := service.Desc.Options()
@@ -160,6 +291,21 @@ func getServiceOptions(service *protogen.Service) *annotations.ServiceOptions {
 	return serviceOpts
 }
 
+// getFileOptions extracts the (cli.file) annotation from a proto file.
+func getFileOptions(file *protogen.File) *annotations.FileOptions {
+	opts := file.Desc.Options()
+	if opts == nil {
+		return nil
+	}
+
+	fileOpts, ok := proto.GetExtension(opts, annotations.E_File).(*annotations.FileOptions)
+	if !ok {
+		return nil
+	}
+
+	return fileOpts
+}
+
 // getMethodCommandOptions extracts (cli.command) annotation from a method.
 func getMethodCommandOptions(method *protogen.Method) *annotations.CommandOptions {
 	opts := method.Desc.Options()
@@ -192,6 +338,20 @@ func enumParserFuncName(service *protogen.Service, enumTypeName string) string {
 	return "parse" + service.GoName + enumTypeName
 }
 
+// methodCommandsFuncName returns the service-prefixed builder name used for a
+// method's commands in per-method split output.
+// Example: UserService, GetUser → "userServiceGetUserCommands"
+func methodCommandsFuncName(service *protogen.Service, method *protogen.Method) string {
+	return toLowerCamelCase(service.GoName) + method.GoName + "Commands"
+}
+
+// fieldPatternVarName returns the service-prefixed variable holding the
+// compiled pattern annotation of a request field.
+// Example: UserService, CreateUser, Email → "userServiceCreateUserEmailPattern"
+func fieldPatternVarName(service *protogen.Service, method *protogen.Method, field *protogen.Field) string {
+	return toLowerCamelCase(service.GoName) + method.GoName + field.GoName + "Pattern"
+}
+
 // streamWrapperTypeName returns the service-prefixed stream wrapper type name.
 // Example: UserService, GetUser → "localServerStream_UserService_GetUser"
 func streamWrapperTypeName(service *protogen.Service, method *protogen.Method) string {
@@ -240,6 +400,81 @@ func defaultValueCode(kind protoreflect.Kind, defaultStr string) jen.Code {
 	return nil
 }
 
+// isIntegerKind reports whether k is a signed or unsigned integer field kind.
+func isIntegerKind(k protoreflect.Kind) bool {
+	switch k {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	default:
+		return false
+	}
+}
+
+// fieldDefaultValue returns the annotation default_value for a singular field,
+// normalized for the flag it will be attached to. Enum defaults may name a value
+// by its proto name or custom CLI name and resolve to the CLI name; well-known
+// type defaults (Duration, Timestamp) are validated in the format the built-in
+// deserializers accept. Invalid defaults are reported and dropped.
+func fieldDefaultValue(field *protogen.Field) string {
+	defaultStr := getFieldFlagOptions(field).GetDefaultValue()
+	if defaultStr == "" || field.Desc.IsList() {
+		return defaultStr
+	}
+
+	switch field.Desc.Kind() {
+	case protoreflect.EnumKind:
+		for _, value := range field.Enum.Values {
+			if value.Desc.Number() == 0 {
+				continue
+			}
+			cliName := getEnumValueCLIName(value)
+			if cliName == "" {
+				cliName = strings.ToLower(string(value.Desc.Name()))
+			}
+			if strings.EqualFold(defaultStr, string(value.Desc.Name())) || strings.EqualFold(defaultStr, cliName) {
+				return cliName
+			}
+		}
+		fmt.Fprintf(os.Stderr, "WARNING: Field %s has default_value %q which is not a value of %s (valid values: %s); ignoring default\n",
+			field.Desc.FullName(), defaultStr, field.Enum.Desc.FullName(), getEnumValidValues(field.Enum))
+		return ""
+	case protoreflect.MessageKind:
+		var err error
+		switch field.Message.Desc.FullName() {
+		case "google.protobuf.Duration":
+			_, err = time.ParseDuration(defaultStr)
+		case "google.protobuf.Timestamp":
+			_, err = time.Parse(time.RFC3339, defaultStr)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: Field %s has invalid default_value %q for %s: %v; ignoring default\n",
+				field.Desc.FullName(), defaultStr, field.Message.Desc.FullName(), err)
+			return ""
+		}
+	}
+	return defaultStr
+}
+
+// fieldDefaultGenerator returns the annotation default_generator for a field,
+// or "" when it has none. Only singular string fields outside a oneof can be
+// filled by a generator; others are reported and ignored.
+func fieldDefaultGenerator(field *protogen.Field) string {
+	name := getFieldFlagOptions(field).GetDefaultGenerator()
+	if name == "" {
+		return ""
+	}
+	oneof := field.Desc.ContainingOneof()
+	if field.Desc.Kind() != protoreflect.StringKind || field.Desc.IsList() || (oneof != nil && !oneof.IsSynthetic()) {
+		fmt.Fprintf(os.Stderr, "WARNING: Field %s has default_generator %q but only singular string fields support generated defaults; ignoring generator\n",
+			field.Desc.FullName(), name)
+		return ""
+	}
+	return name
+}
+
 // getFieldFlagOptions extracts the (cli.flag) annotation from a field
 func getFieldFlagOptions(field *protogen.Field) *annotations.FlagOptions {
 	opts := field.Desc.Options()
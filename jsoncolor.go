@@ -0,0 +1,111 @@
+package protocli
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// ANSI colors of the JSON tokens written by JSONColor.
+const (
+	jsonColorReset   = "\033[0m"
+	jsonColorKey     = "\033[1;34m" // Bold blue
+	jsonColorString  = "\033[32m"   // Green
+	jsonColorNumber  = "\033[36m"   // Cyan
+	jsonColorLiteral = "\033[35m"   // Magenta: true, false and null
+)
+
+// jsonColorFormat pretty-prints proto messages as JSON with syntax highlighting
+// for terminals. Messages are rendered by the json format, always indented.
+type jsonColorFormat struct {
+	json     jsonFormat
+	terminal func(w any) bool // Reports whether w is a terminal
+}
+
+func (f *jsonColorFormat) Name() string {
+	return "json-color"
+}
+
+// Flags returns the flags of the json format except --pretty, since json-color
+// output is always indented.
+func (f *jsonColorFormat) Flags() []cli.Flag {
+	var flags []cli.Flag
+	for _, flag := range f.json.Flags() {
+		if flag.Names()[0] != "pretty" {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+func (f *jsonColorFormat) Format(_ context.Context, cmd *cli.Command, w io.Writer, msg proto.Message) error {
+	jsonBytes, err := f.json.render(cmd, msg, "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(f.highlight(cmd, w, jsonBytes))
+	return err
+}
+
+// highlight returns doc highlighted if colored output is enabled for w, and
+// doc unchanged otherwise.
+func (f *jsonColorFormat) highlight(cmd *cli.Command, w io.Writer, doc []byte) []byte {
+	if color, _ := colorEnabled(cmd, w, f.terminal); color {
+		return highlightJSON(doc)
+	}
+	return doc
+}
+
+// highlightJSON wraps the keys, strings, numbers and literals of the valid
+// JSON document doc in ANSI colors, leaving whitespace and punctuation as is.
+func highlightJSON(doc []byte) []byte {
+	var out bytes.Buffer
+	for i := 0; i < len(doc); {
+		c := doc[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(doc) && doc[end] != '"' {
+				if doc[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(doc))
+			color := jsonColorString
+			if next := bytes.TrimLeft(doc[end:], " \t\r\n"); len(next) > 0 && next[0] == ':' {
+				color = jsonColorKey
+			}
+			writeColored(&out, color, doc[i:end])
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(doc) && bytes.IndexByte([]byte("0123456789.eE+-"), doc[end]) >= 0 {
+				end++
+			}
+			writeColored(&out, jsonColorNumber, doc[i:end])
+			i = end
+		case c == 't' || c == 'f' || c == 'n':
+			end := i + 1
+			for end < len(doc) && doc[end] >= 'a' && doc[end] <= 'z' {
+				end++
+			}
+			writeColored(&out, jsonColorLiteral, doc[i:end])
+			i = end
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.Bytes()
+}
+
+// writeColored writes token to out in color.
+func writeColored(out *bytes.Buffer, color string, token []byte) {
+	out.WriteString(color)
+	out.Write(token)
+	out.WriteString(jsonColorReset)
+}
@@ -0,0 +1,141 @@
+package protocli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/typepb"
+)
+
+// formatJSONColor renders a fixed message with a json-color format whose
+// writer is a terminal if terminal is set.
+func formatJSONColor(t *testing.T, terminal bool, args ...string) string {
+	t.Helper()
+	msg, err := structpb.NewStruct(map[string]any{"name": "Ada", "age": 36, "admin": true})
+	require.NoError(t, err)
+
+	f := &jsonColorFormat{terminal: func(any) bool { return terminal }}
+	var buf bytes.Buffer
+	cmd := &cli.Command{
+		Name:  "get",
+		Flags: ColorFlags(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return f.Format(ctx, cmd, &buf, msg)
+		},
+	}
+	require.NoError(t, cmd.Run(context.Background(), append([]string{"get"}, args...)))
+	return buf.String()
+}
+
+func TestUnit_JSONColor_HighlightsOnTerminal(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	out := formatJSONColor(t, true)
+
+	assert.Contains(t, out, jsonColorKey+`"name"`+jsonColorReset)
+	assert.Contains(t, out, jsonColorString+`"Ada"`+jsonColorReset)
+	assert.Contains(t, out, jsonColorNumber+`36`+jsonColorReset)
+	assert.Contains(t, out, jsonColorLiteral+`true`+jsonColorReset)
+	assert.Contains(t, out, "\n  ", "output must be indented")
+}
+
+func TestUnit_JSONColor_PlainWhenNotTerminal(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	for name, out := range map[string]string{
+		"not a terminal": formatJSONColor(t, false),
+		"--no-color":     formatJSONColor(t, true, "--no-color"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.NotContains(t, out, "\033[")
+			assert.JSONEq(t, `{"name":"Ada","age":36,"admin":true}`, out)
+		})
+	}
+}
+
+func TestUnit_JSONColor_NoColorEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	assert.NotContains(t, formatJSONColor(t, true), "\033[")
+}
+
+func TestUnit_HighlightJSON_EscapedQuotesAndNegativeNumbers(t *testing.T) {
+	out := string(highlightJSON([]byte(`{"a\"b": "c\\", "n": -1.5e3, "z": null}`)))
+	assert.Contains(t, out, jsonColorKey+`"a\"b"`+jsonColorReset)
+	assert.Contains(t, out, jsonColorString+`"c\\"`+jsonColorReset)
+	assert.Contains(t, out, jsonColorNumber+`-1.5e3`+jsonColorReset)
+	assert.Contains(t, out, jsonColorLiteral+`null`+jsonColorReset)
+
+	// Stripping the colors gives back the original document
+	plain := out
+	for _, color := range []string{jsonColorKey, jsonColorString, jsonColorNumber, jsonColorLiteral, jsonColorReset} {
+		plain = strings.ReplaceAll(plain, color, "")
+	}
+	assert.Equal(t, `{"a\"b": "c\\", "n": -1.5e3, "z": null}`, plain)
+}
+
+// runJSONColorResponse writes resp for request req with the json-color format
+// through WriteRequestResponse, on a terminal, and returns the output.
+func runJSONColorResponse(t *testing.T, req, resp proto.Message, args ...string) string {
+	t.Helper()
+	formats := []OutputFormat{&jsonColorFormat{terminal: func(any) bool { return true }}}
+	flags := append(ColorFlags(),
+		&cli.StringFlag{Name: "get"},
+		&cli.BoolFlag{Name: "echo-request"},
+	)
+	var buf bytes.Buffer
+	cmd := &cli.Command{
+		Name:  "get",
+		Flags: append(flags, FormatFlags(formats)...),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return WriteRequestResponse(ctx, cmd, &buf, formats, "json-color", req, resp)
+		},
+	}
+	require.NoError(t, cmd.Run(context.Background(), append([]string{"get"}, args...)))
+	return buf.String()
+}
+
+func TestUnit_JSONColor_ReadsJSONFlags(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	field := &typepb.Field{Name: "id", Kind: typepb.Field_TYPE_STRING}
+
+	out := runJSONColorResponse(t, field, field, "--no-color", "--json-enum-numbers")
+	assert.Regexp(t, `"kind":\s+9,`, out, "--json-enum-numbers must apply to json-color")
+	assert.Contains(t, out, "\n  ", "output must be indented")
+}
+
+func TestUnit_JSONColor_Get(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	msg, err := structpb.NewStruct(map[string]any{"name": "Ada", "age": 36})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Ada\n", runJSONColorResponse(t, msg, msg, "--get", "/name"))
+	assert.Equal(t, "36\n", runJSONColorResponse(t, msg, msg, "--echo-request", "--get", "/request/age"))
+}
+
+func TestUnit_JSONColor_EchoRequest(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	req, err := structpb.NewStruct(map[string]any{"id": "7"})
+	require.NoError(t, err)
+	resp, err := structpb.NewStruct(map[string]any{"name": "Ada"})
+	require.NoError(t, err)
+
+	out := runJSONColorResponse(t, req, resp, "--echo-request")
+	assert.Contains(t, out, jsonColorKey+`"request"`+jsonColorReset)
+	assert.Contains(t, out, jsonColorString+`"Ada"`+jsonColorReset)
+
+	out = runJSONColorResponse(t, req, resp, "--echo-request", "--no-color")
+	assert.JSONEq(t, `{"request":{"id":"7"},"response":{"name":"Ada"}}`, out)
+}
+
+func TestUnit_FormatFlags_SharedFlagsOnce(t *testing.T) {
+	var names []string
+	for _, flag := range FormatFlags([]OutputFormat{JSON(), JSONColor()}) {
+		names = append(names, flag.Names()[0])
+	}
+	assert.Equal(t, []string{"pretty", "json-null-optionals", "json-enum-numbers", "json-allow-special"}, names)
+}
@@ -0,0 +1,31 @@
+package protocli
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// nullUnsetOptionals rewrites protojson output for m so that unset optional
+// (presence-tracked) scalar fields appear as JSON null rather than being
+// omitted. Nested messages, repeated messages and map values are handled
+// recursively; well-known types keep their special JSON form. Field order is
+// preserved, and the result is indented with indent when it is non-empty.
+func nullUnsetOptionals(m protoreflect.Message, data []byte, indent string) ([]byte, error) {
+	r := &jsonRewriter{
+		missing: func(m protoreflect.Message, fd protoreflect.FieldDescriptor) ([]byte, bool) {
+			return []byte("null"), isUnsetOptional(m, fd)
+		},
+	}
+	return r.rewrite(m, data, indent)
+}
+
+// isUnsetOptional reports whether fd is an unset scalar field with explicit
+// presence outside a real oneof, e.g. a proto3 "optional string".
+func isUnsetOptional(m protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	if !fd.HasPresence() || fd.Message() != nil || fd.IsExtension() {
+		return false
+	}
+	if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+		return false
+	}
+	return !m.Has(fd)
+}
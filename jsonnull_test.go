@@ -0,0 +1,92 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// formatJSON renders msg with the JSON() format, parsing args as its flags.
+func formatJSON(t *testing.T, msg proto.Message, args ...string) string {
+	t.Helper()
+	out, err := formatWith(t, protocli.JSON(), msg, args...)
+	require.NoError(t, err)
+	return out
+}
+
+// formatWith renders msg with outputFmt, parsing args as its flags.
+func formatWith(t *testing.T, outputFmt protocli.OutputFormat, msg proto.Message, args ...string) (string, error) {
+	t.Helper()
+	flagged, ok := outputFmt.(protocli.FlagConfiguredOutputFormat)
+	require.True(t, ok)
+
+	var buf bytes.Buffer
+	cmd := &cli.Command{
+		Name:  "test",
+		Flags: flagged.Flags(),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return outputFmt.Format(ctx, cmd, &buf, msg)
+		},
+	}
+	err := cmd.Run(context.Background(), append([]string{"test"}, args...))
+	return buf.String(), err
+}
+
+func TestUnit_JSONNullOptionals(t *testing.T) {
+	tests := []struct {
+		name     string
+		nickname *string
+		want     any
+	}{
+		{name: "set", nickname: proto.String("ada"), want: "ada"},
+		{name: "unset", nickname: nil, want: nil},
+		{name: "set to empty", nickname: proto.String(""), want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &simple.CreateUserRequest{Name: "Ada", Nickname: tt.nickname}
+
+			var withNulls map[string]any
+			require.NoError(t, json.Unmarshal([]byte(formatJSON(t, req, "--json-null-optionals")), &withNulls))
+			got, present := withNulls["nickname"]
+			assert.True(t, present, "nickname is always emitted")
+			assert.Equal(t, tt.want, got)
+
+			var plain map[string]any
+			require.NoError(t, json.Unmarshal([]byte(formatJSON(t, req)), &plain))
+			_, present = plain["nickname"]
+			assert.Equal(t, tt.nickname != nil, present, "without the flag unset optionals are omitted")
+		})
+	}
+}
+
+func TestUnit_JSONNullOptionals_NestedAndOrdered(t *testing.T) {
+	req := &simple.CreateUserRequest{
+		Name:    "Ada",
+		Address: &simple.Address{City: "London"},
+	}
+
+	out := formatJSON(t, req, "--json-null-optionals", "--pretty")
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	assert.Nil(t, got["age"])
+	assert.Contains(t, got, "age")
+	assert.Equal(t, "London", got["address"].(map[string]any)["city"])
+	assert.Nil(t, got["registrationDate"], "unset messages stay null")
+
+	// Fields keep declaration order: name comes before the optional nickname,
+	// which comes before the later request_id.
+	assert.Less(t, bytes.Index([]byte(out), []byte(`"name"`)), bytes.Index([]byte(out), []byte(`"nickname"`)))
+	assert.Less(t, bytes.Index([]byte(out), []byte(`"nickname"`)), bytes.Index([]byte(out), []byte(`"requestId"`)))
+	assert.Contains(t, out, "\n  \"nickname\": null", "--pretty still indents")
+}
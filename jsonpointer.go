@@ -0,0 +1,145 @@
+package protocli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrGetRequiresJSON is returned when --get is used with an output format
+	// that does not render JSON.
+	ErrGetRequiresJSON = errors.New("--get requires a JSON output format")
+	// ErrInvalidJSONPointer is returned for a --get value that is not an
+	// RFC 6901 JSON pointer.
+	ErrInvalidJSONPointer = errors.New("invalid JSON pointer")
+	// ErrJSONPointerNotFound is returned when the --get pointer names no value
+	// of the response.
+	ErrJSONPointerNotFound = errors.New("no value at JSON pointer")
+)
+
+// WriteResponse renders a method response. With --get, only the value at that
+// JSON pointer (RFC 6901, e.g. /user/email) of the response's JSON rendering is
+// written: strings without quotes, anything else as compact JSON. Otherwise
+// msg is rendered with WriteFormatted. Generated unary commands call this.
+func WriteResponse(ctx context.Context, cmd *cli.Command, w io.Writer, formats []OutputFormat, formatName string, msg proto.Message) error {
+	pointer := cmd.String("get")
+	if pointer == "" {
+		return WriteFormatted(ctx, cmd, w, formats, formatName, msg)
+	}
+
+	outputFmt, err := jsonOutputFormat(cmd, formats, formatName)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := FormatMessage(ctx, cmd, outputFmt, &buf, msg); err != nil {
+		return fmt.Errorf("format failed: %w", err)
+	}
+	return writeJSONPointerValue(w, buf.Bytes(), pointer)
+}
+
+// writeJSONPointerValue writes the value at pointer in doc for --get: strings
+// without quotes, anything else as compact JSON.
+func writeJSONPointerValue(w io.Writer, doc []byte, pointer string) error {
+	value, err := evalJSONPointer(doc, pointer)
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	if value = bytes.TrimSpace(value); len(value) > 0 && value[0] == '"' {
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return fmt.Errorf("failed to decode JSON string: %w", err)
+		}
+		out.WriteString(s)
+	} else if err := json.Compact(&out, value); err != nil {
+		return fmt.Errorf("failed to compact JSON: %w", err)
+	}
+	out.WriteByte('\n')
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+// jsonOutputFormat returns the format --get evaluates its pointer against: the
+// selected format if it renders JSON, otherwise the first registered JSON
+// format unless --format was given explicitly.
+func jsonOutputFormat(cmd *cli.Command, formats []OutputFormat, formatName string) (OutputFormat, error) {
+	for _, f := range formats {
+		if f.Name() == formatName && isJSONFormat(f) {
+			return plainJSONFormat(f), nil
+		}
+	}
+	if cmd.IsSet("format") {
+		return nil, fmt.Errorf("%w, not %q", ErrGetRequiresJSON, formatName)
+	}
+	for _, f := range formats {
+		if isJSONFormat(f) {
+			return plainJSONFormat(f), nil
+		}
+	}
+	return nil, fmt.Errorf("%w (use WithOutputFormats to register protocli.JSON())", ErrGetRequiresJSON)
+}
+
+// isJSONFormat reports whether f renders JSON: the built-in "json" and
+// "json-color" formats or one whose media type is application/json.
+func isJSONFormat(f OutputFormat) bool {
+	if _, ok := f.(*jsonColorFormat); ok || f.Name() == "json" {
+		return true
+	}
+	mf, ok := f.(MediaTypeOutputFormat)
+	return ok && mf.MediaType() == "application/json"
+}
+
+// plainJSONFormat returns the json format a json-color format renders with, so
+// its output can be parsed, and f itself otherwise.
+func plainJSONFormat(f OutputFormat) OutputFormat {
+	if colored, ok := f.(*jsonColorFormat); ok {
+		return &colored.json
+	}
+	return f
+}
+
+// evalJSONPointer returns the raw JSON value at pointer in doc. The empty
+// pointer refers to the whole document.
+func evalJSONPointer(doc []byte, pointer string) (json.RawMessage, error) {
+	if pointer == "" {
+		return doc, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w %q: must start with /", ErrInvalidJSONPointer, pointer)
+	}
+
+	value := json.RawMessage(doc)
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		var object map[string]json.RawMessage
+		var array []json.RawMessage
+		switch {
+		case json.Unmarshal(value, &object) == nil:
+			next, ok := object[token]
+			if !ok {
+				return nil, fmt.Errorf("%w %q", ErrJSONPointerNotFound, pointer)
+			}
+			value = next
+		case json.Unmarshal(value, &array) == nil:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(array) || (len(token) > 1 && token[0] == '0') {
+				return nil, fmt.Errorf("%w %q", ErrJSONPointerNotFound, pointer)
+			}
+			value = array[i]
+		default:
+			return nil, fmt.Errorf("%w %q", ErrJSONPointerNotFound, pointer)
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,237 @@
+package protocli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// jsonRewriter rewrites the protojson encoding of a message in step with the
+// message itself, so that values can be replaced based on their descriptors.
+// It backs --json-null-optionals, --json-allow-special=null and registered
+// message formatters. Every hook is optional.
+type jsonRewriter struct {
+	// message replaces the encoding of a nested message. ok reports whether
+	// out replaces it; otherwise its fields are rewritten in turn.
+	message func(m protoreflect.Message, raw json.RawMessage) (out []byte, ok bool, err error)
+	// scalar replaces the encoding of a non-message value, list element or
+	// map value described by fd.
+	scalar func(fd protoreflect.FieldDescriptor, v protoreflect.Value) (out []byte, ok bool)
+	// missing returns the encoding of field fd of m when the JSON omits it.
+	missing func(m protoreflect.Message, fd protoreflect.FieldDescriptor) (out []byte, ok bool)
+}
+
+// rewrite returns the protojson encoding data of m with the hooks applied.
+// Keys keep their order and well-known types keep their special JSON form.
+// The result is indented with indent when it is non-empty.
+func (r *jsonRewriter) rewrite(m protoreflect.Message, data []byte, indent string) ([]byte, error) {
+	out, err := r.object(m, data)
+	if err != nil {
+		return nil, err
+	}
+	if indent == "" {
+		return out, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, out, "", indent); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// object returns compact JSON for a message object. Its fields are written
+// in declaration order, followed by anything that is not a regular field,
+// such as extensions.
+func (r *jsonRewriter) object(m protoreflect.Message, data []byte) ([]byte, error) {
+	desc := m.Descriptor()
+	if strings.HasPrefix(string(desc.FullName()), "google.protobuf.") {
+		return compactJSON(data)
+	}
+
+	keys, values, err := decodeJSONObject(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj jsonObjectWriter
+	written := make(map[string]bool, len(keys))
+	fields := desc.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		key := fd.JSONName()
+		raw, ok := values[key]
+		if !ok {
+			if r.missing != nil {
+				if value, ok := r.missing(m, fd); ok {
+					obj.write(key, value)
+				}
+			}
+			continue
+		}
+		value, err := r.field(m, fd, raw)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", fd.Name(), err)
+		}
+		obj.write(key, value)
+		written[key] = true
+	}
+
+	for _, key := range keys {
+		if !written[key] {
+			value, err := compactJSON(values[key])
+			if err != nil {
+				return nil, err
+			}
+			obj.write(key, value)
+		}
+	}
+	return obj.close(), nil
+}
+
+// field rewrites the values held by field fd of m.
+func (r *jsonRewriter) field(m protoreflect.Message, fd protoreflect.FieldDescriptor, raw json.RawMessage) ([]byte, error) {
+	switch {
+	case fd.IsMap():
+		keys, values, err := decodeJSONObject(raw)
+		if err != nil {
+			return nil, err
+		}
+		entries := make(map[string]protoreflect.Value)
+		m.Get(fd).Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			entries[k.String()] = v
+			return true
+		})
+		var obj jsonObjectWriter
+		for _, key := range keys {
+			var value []byte
+			if entry, ok := entries[key]; ok {
+				value, err = r.value(fd.MapValue(), entry, values[key])
+			} else {
+				value, err = compactJSON(values[key])
+			}
+			if err != nil {
+				return nil, err
+			}
+			obj.write(key, value)
+		}
+		return obj.close(), nil
+	case fd.IsList():
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return nil, err
+		}
+		list := m.Get(fd).List()
+		if list.Len() != len(elems) {
+			return compactJSON(raw)
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, elem := range elems {
+			value, err := r.value(fd, list.Get(i), elem)
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(value)
+		}
+		buf.WriteByte(']')
+		return buf.Bytes(), nil
+	case fd.Message() != nil && !m.Has(fd):
+		return compactJSON(raw)
+	default:
+		return r.value(fd, m.Get(fd), raw)
+	}
+}
+
+// value rewrites a singular value, list element or map value described by fd.
+func (r *jsonRewriter) value(fd protoreflect.FieldDescriptor, v protoreflect.Value, raw json.RawMessage) ([]byte, error) {
+	if fd.Message() == nil {
+		if r.scalar != nil {
+			if out, ok := r.scalar(fd, v); ok {
+				return out, nil
+			}
+		}
+		return compactJSON(raw)
+	}
+	if r.message != nil {
+		if out, ok, err := r.message(v.Message(), raw); ok || err != nil {
+			return out, err
+		}
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		// Well-known types such as Timestamp encode as scalars
+		return compactJSON(raw)
+	}
+	return r.object(v.Message(), raw)
+}
+
+// jsonObjectWriter builds a compact JSON object one member at a time.
+type jsonObjectWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *jsonObjectWriter) write(key string, value []byte) {
+	if w.buf.Len() == 0 {
+		w.buf.WriteByte('{')
+	} else {
+		w.buf.WriteByte(',')
+	}
+	name, _ := json.Marshal(key)
+	w.buf.Write(name)
+	w.buf.WriteByte(':')
+	w.buf.Write(value)
+}
+
+func (w *jsonObjectWriter) close() []byte {
+	if w.buf.Len() == 0 {
+		return []byte("{}")
+	}
+	w.buf.WriteByte('}')
+	return w.buf.Bytes()
+}
+
+// decodeJSONObject splits a JSON object into its keys, in document order, and
+// their raw values.
+func decodeJSONObject(data []byte) ([]string, map[string]json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, nil, fmt.Errorf("expected JSON object, got %v", tok)
+	}
+	var keys []string
+	values := make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected JSON token %v", tok)
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		values[key] = raw
+	}
+	return keys, values, nil
+}
+
+// compactJSON strips insignificant whitespace from a JSON value.
+func compactJSON(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
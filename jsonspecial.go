@@ -0,0 +1,127 @@
+package protocli
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ErrInvalidSpecialFloats is returned for an unknown --json-allow-special mode.
+var ErrInvalidSpecialFloats = errors.New("invalid --json-allow-special mode")
+
+// Values of the JSON format's --json-allow-special flag.
+const (
+	specialFloatsString = "string" // NaN and Infinity render as "NaN", "Infinity" and "-Infinity"
+	specialFloatsNull   = "null"   // NaN and Infinity render as null
+)
+
+// isSpecialFloat reports whether f is NaN or infinite.
+func isSpecialFloat(f float64) bool {
+	return math.IsNaN(f) || math.IsInf(f, 0)
+}
+
+// specialFloatString returns the protojson spelling of a NaN or infinite f.
+func specialFloatString(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	default:
+		return "NaN"
+	}
+}
+
+// replaceSpecialValues returns msg with every google.protobuf.Value holding a
+// NaN or infinite number replaced by its string spelling, or by a null value
+// when mode is "null", since protojson refuses to marshal them. msg is cloned
+// only if it holds such a value.
+func replaceSpecialValues(msg proto.Message, mode string) proto.Message {
+	if !hasSpecialValues(msg.ProtoReflect()) {
+		return msg
+	}
+	msg = proto.Clone(msg)
+	rangeMessages(msg.ProtoReflect(), func(m protoreflect.Message) {
+		v, ok := m.Interface().(*structpb.Value)
+		if !ok || !isSpecialFloat(v.GetNumberValue()) {
+			return
+		}
+		if mode == specialFloatsNull {
+			v.Kind = &structpb.Value_NullValue{}
+		} else {
+			v.Kind = &structpb.Value_StringValue{StringValue: specialFloatString(v.GetNumberValue())}
+		}
+	})
+	return msg
+}
+
+// hasSpecialValues reports whether m holds a google.protobuf.Value with a NaN
+// or infinite number.
+func hasSpecialValues(m protoreflect.Message) bool {
+	found := false
+	rangeMessages(m, func(m protoreflect.Message) {
+		if v, ok := m.Interface().(*structpb.Value); ok && isSpecialFloat(v.GetNumberValue()) {
+			found = true
+		}
+	})
+	return found
+}
+
+// rangeMessages calls fn for m and every message nested in it.
+func rangeMessages(m protoreflect.Message, fn func(protoreflect.Message)) {
+	fn(m)
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				rangeMessages(mv.Message(), fn)
+				return true
+			})
+		case fd.IsList() && fd.Message() != nil:
+			for i := range v.List().Len() {
+				rangeMessages(v.List().Get(i).Message(), fn)
+			}
+		case !fd.IsMap() && !fd.IsList() && fd.Message() != nil:
+			rangeMessages(v.Message(), fn)
+		}
+		return true
+	})
+}
+
+// nullSpecialFloats rewrites the protojson encoding of m so that float and
+// double fields holding NaN or Infinity are null instead of strings, keeping
+// key order. The result is indented with indent when it is non-empty.
+func nullSpecialFloats(m protoreflect.Message, data []byte, indent string) ([]byte, error) {
+	if out, ok := nullSpecialWrapper(m); ok {
+		return out, nil
+	}
+	r := &jsonRewriter{
+		message: func(m protoreflect.Message, _ json.RawMessage) ([]byte, bool, error) {
+			out, ok := nullSpecialWrapper(m)
+			return out, ok, nil
+		},
+		scalar: func(fd protoreflect.FieldDescriptor, v protoreflect.Value) ([]byte, bool) {
+			return []byte("null"), isFloatKind(fd.Kind()) && isSpecialFloat(v.Float())
+		},
+	}
+	return r.rewrite(m, data, indent)
+}
+
+// nullSpecialWrapper returns null for a google.protobuf.DoubleValue or
+// FloatValue holding NaN or Infinity.
+func nullSpecialWrapper(m protoreflect.Message) ([]byte, bool) {
+	desc := m.Descriptor()
+	if name := desc.FullName(); name != "google.protobuf.DoubleValue" && name != "google.protobuf.FloatValue" {
+		return nil, false
+	}
+	return []byte("null"), isSpecialFloat(m.Get(desc.Fields().ByName("value")).Float())
+}
+
+// isFloatKind reports whether k is a float or double field kind.
+func isFloatKind(k protoreflect.Kind) bool {
+	return k == protoreflect.FloatKind || k == protoreflect.DoubleKind
+}
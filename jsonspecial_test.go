@@ -0,0 +1,128 @@
+package protocli_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newReading builds a telemetry.Reading message with a double value, repeated
+// float samples, a string label and a nested reading.
+func newReading(t *testing.T) *dynamicpb.Message {
+	t.Helper()
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("telemetry.proto"),
+		Package: proto.String("telemetry"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Reading"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("value"), Number: proto.Int32(1), Label: optional, Type: descriptorpb.FieldDescriptorProto_TYPE_DOUBLE.Enum(), JsonName: proto.String("value")},
+				{Name: proto.String("samples"), Number: proto.Int32(2), Label: descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_FLOAT.Enum(), JsonName: proto.String("samples")},
+				{Name: proto.String("label"), Number: proto.Int32(3), Label: optional, Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), JsonName: proto.String("label")},
+				{Name: proto.String("nested"), Number: proto.Int32(4), Label: optional, Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".telemetry.Reading"), JsonName: proto.String("nested")},
+			},
+		}},
+	}, nil)
+	require.NoError(t, err)
+	return dynamicpb.NewMessage(fd.Messages().Get(0))
+}
+
+// setReading sets field name of reading to v.
+func setReading(reading *dynamicpb.Message, name string, v protoreflect.Value) {
+	reading.Set(reading.Descriptor().Fields().ByName(protoreflect.Name(name)), v)
+}
+
+func TestUnit_JSONAllowSpecial_DoubleFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		value float64
+		mode  string
+		want  any
+	}{
+		{name: "NaN as string", value: math.NaN(), mode: "string", want: "NaN"},
+		{name: "Inf as string", value: math.Inf(1), mode: "string", want: "Infinity"},
+		{name: "NaN as null", value: math.NaN(), mode: "null", want: nil},
+		{name: "negative Inf as null", value: math.Inf(-1), mode: "null", want: nil},
+		{name: "finite unchanged", value: 1.5, mode: "null", want: 1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := newReading(t)
+			setReading(msg, "value", protoreflect.ValueOfFloat64(tt.value))
+			out, err := formatWith(t, protocli.JSON(), msg, "--json-allow-special", tt.mode)
+			require.NoError(t, err)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal([]byte(out), &got))
+			require.Contains(t, got, "value")
+			assert.Equal(t, tt.want, got["value"])
+		})
+	}
+}
+
+func TestUnit_JSONAllowSpecial_NestedAndPretty(t *testing.T) {
+	nested := newReading(t)
+	setReading(nested, "value", protoreflect.ValueOfFloat64(math.Inf(1)))
+	setReading(nested, "label", protoreflect.ValueOfString("NaN"))
+	samples := nested.NewField(nested.Descriptor().Fields().ByName("samples")).List()
+	samples.Append(protoreflect.ValueOfFloat32(float32(math.NaN())))
+	samples.Append(protoreflect.ValueOfFloat32(2))
+	setReading(nested, "samples", protoreflect.ValueOfList(samples))
+	msg := newReading(t)
+	setReading(msg, "nested", protoreflect.ValueOfMessage(nested))
+
+	out, err := formatWith(t, protocli.JSON(), msg, "--json-allow-special", "null", "--pretty")
+	require.NoError(t, err)
+	assert.Contains(t, out, "\n  ", "stays indented")
+
+	var got struct {
+		Nested map[string]any `json:"nested"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	assert.Nil(t, got.Nested["value"])
+	assert.Equal(t, []any{nil, 2.0}, got.Nested["samples"])
+	// String fields that happen to read "NaN" are left alone
+	assert.Equal(t, "NaN", got.Nested["label"])
+}
+
+func TestUnit_JSONAllowSpecial_StructValues(t *testing.T) {
+	msg, err := structpb.NewStruct(map[string]any{"temp": math.NaN(), "max": math.Inf(1), "min": 1.0})
+	require.NoError(t, err)
+
+	// protojson refuses NaN in google.protobuf.Value without the flag
+	_, err = formatWith(t, protocli.JSON(), msg)
+	require.Error(t, err)
+
+	out, err := formatWith(t, protocli.JSON(), msg, "--json-allow-special", "string")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"temp":"NaN","max":"Infinity","min":1}`, out)
+
+	out, err = formatWith(t, protocli.JSON(), msg, "--json-allow-special", "null")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"temp":null,"max":null,"min":1}`, out)
+	assert.True(t, math.IsNaN(msg.GetFields()["temp"].GetNumberValue()), "original message is not modified")
+}
+
+func TestUnit_JSONAllowSpecial_Wrappers(t *testing.T) {
+	out, err := formatWith(t, protocli.JSON(), wrapperspb.Double(math.NaN()), "--json-allow-special", "null")
+	require.NoError(t, err)
+	assert.Equal(t, "null", out)
+}
+
+func TestUnit_JSONAllowSpecial_InvalidMode(t *testing.T) {
+	_, err := formatWith(t, protocli.JSON(), wrapperspb.Double(1), "--json-allow-special", "zero")
+	require.ErrorIs(t, err, protocli.ErrInvalidSpecialFloats)
+}
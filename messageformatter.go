@@ -0,0 +1,105 @@
+package protocli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MessageFormatter renders a message in its canonical display form, e.g. a
+// money type as "$12.50".
+type MessageFormatter func(proto.Message) (string, error)
+
+//nolint:gochecknoglobals // intentional global registry for message formatters
+var (
+	messageFormattersMu sync.RWMutex
+	messageFormatters   = map[string]MessageFormatter{}
+)
+
+// RegisterFormatter makes the built-in output formats render messages of type
+// messageFullName (e.g. "example.Money") with fn instead of their default
+// marshaling. JSON renders the result as a string; YAML and Go write it as-is,
+// as do template formats for types without a template. JSON, YAML and the
+// protoFields template function also apply it to nested fields. Registering a
+// nil fn removes the formatter.
+//
+// Example:
+//
+//	protocli.RegisterFormatter("example.Money", func(msg proto.Message) (string, error) {
+//	    m := msg.(*example.Money)
+//	    return fmt.Sprintf("%s %d.%02d", m.GetCurrency(), m.GetUnits(), m.GetCents()), nil
+//	})
+func RegisterFormatter(messageFullName string, fn MessageFormatter) {
+	messageFormattersMu.Lock()
+	defer messageFormattersMu.Unlock()
+	if fn == nil {
+		delete(messageFormatters, messageFullName)
+		return
+	}
+	messageFormatters[messageFullName] = fn
+}
+
+// lookupFormatter returns the formatter registered for m's type.
+func lookupFormatter(m protoreflect.Message) (MessageFormatter, bool) {
+	messageFormattersMu.RLock()
+	defer messageFormattersMu.RUnlock()
+	fn, ok := messageFormatters[string(m.Descriptor().FullName())]
+	return fn, ok
+}
+
+// hasFormatters reports whether any formatter is registered, letting formats
+// skip rewriting their output in the common case.
+func hasFormatters() bool {
+	messageFormattersMu.RLock()
+	defer messageFormattersMu.RUnlock()
+	return len(messageFormatters) > 0
+}
+
+// formatRegistered renders msg with its registered formatter. ok is false
+// when none is registered for its type.
+func formatRegistered(msg proto.Message) (s string, ok bool, err error) {
+	fn, ok := lookupFormatter(msg.ProtoReflect())
+	if !ok {
+		return "", false, nil
+	}
+	s, err = fn(msg)
+	if err != nil {
+		return "", true, fmt.Errorf("formatter for %s: %w", msg.ProtoReflect().Descriptor().FullName(), err)
+	}
+	return s, true, nil
+}
+
+// applyFormatters rewrites the protojson encoding of m so that nested messages
+// with a registered formatter become JSON strings, keeping key order. data is
+// returned unchanged when no message in m has a formatter.
+func applyFormatters(m protoreflect.Message, data []byte, indent string) ([]byte, error) {
+	if !hasFormatters() || !hasFormattedMessages(m) {
+		return data, nil
+	}
+	r := &jsonRewriter{
+		message: func(m protoreflect.Message, _ json.RawMessage) ([]byte, bool, error) {
+			s, ok, err := formatRegistered(m.Interface())
+			if !ok || err != nil {
+				return nil, ok, err
+			}
+			value, err := json.Marshal(s)
+			return value, true, err
+		},
+	}
+	return r.rewrite(m, data, indent)
+}
+
+// hasFormattedMessages reports whether m or a message nested in it has a
+// registered formatter.
+func hasFormattedMessages(m protoreflect.Message) bool {
+	found := false
+	rangeMessages(m, func(m protoreflect.Message) {
+		if _, ok := lookupFormatter(m); ok {
+			found = true
+		}
+	})
+	return found
+}
@@ -0,0 +1,122 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// registerAddressFormatter renders example.Address as "street, city" for the
+// duration of the test.
+func registerAddressFormatter(t *testing.T) {
+	t.Helper()
+	protocli.RegisterFormatter("example.Address", func(msg proto.Message) (string, error) {
+		addr := msg.(*simple.Address)
+		return fmt.Sprintf("%s, %s", addr.GetStreet(), addr.GetCity()), nil
+	})
+	t.Cleanup(func() { protocli.RegisterFormatter("example.Address", nil) })
+}
+
+func formatGo(t *testing.T, outputFmt protocli.OutputFormat, msg proto.Message) (string, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	err := outputFmt.Format(context.Background(), &cli.Command{}, &buf, msg)
+	return buf.String(), err
+}
+
+func newAddressResponse() *simple.UserResponse {
+	return &simple.UserResponse{
+		User: &simple.User{
+			Id:      1,
+			Name:    "Ada",
+			Address: &simple.Address{Street: "1 Main St", City: "Springfield"},
+		},
+	}
+}
+
+func TestUnit_RegisterFormatter_TopLevel(t *testing.T) {
+	registerAddressFormatter(t)
+	addr := &simple.Address{Street: "1 Main St", City: "Springfield"}
+
+	out, err := formatWith(t, protocli.JSON(), addr)
+	require.NoError(t, err)
+	assert.Equal(t, `"1 Main St, Springfield"`, out)
+
+	out, err = formatWith(t, protocli.YAML(), addr)
+	require.NoError(t, err)
+	assert.Equal(t, "1 Main St, Springfield", out)
+
+	out, err = formatGo(t, protocli.Go(), addr)
+	require.NoError(t, err)
+	assert.Equal(t, "1 Main St, Springfield", out)
+
+	// Template formats use the formatter only for types without a template
+	tmpl := protocli.MustTemplateFormat("table", map[string]string{"example.User": "{{.Name}}"})
+	out, err = formatGo(t, tmpl, addr)
+	require.NoError(t, err)
+	assert.Equal(t, "1 Main St, Springfield", out)
+}
+
+func TestUnit_RegisterFormatter_NestedJSON(t *testing.T) {
+	registerAddressFormatter(t)
+
+	out, err := formatWith(t, protocli.JSON(), newAddressResponse())
+	require.NoError(t, err)
+	assert.Contains(t, out, `"address":"1 Main St, Springfield"`)
+
+	out, err = formatWith(t, protocli.JSON(), newAddressResponse(), "--pretty")
+	require.NoError(t, err)
+	assert.Contains(t, out, "\n    \"address\": \"1 Main St, Springfield\"")
+}
+
+func TestUnit_RegisterFormatter_NestedYAML(t *testing.T) {
+	registerAddressFormatter(t)
+
+	out, err := formatWith(t, protocli.YAML(), newAddressResponse())
+	require.NoError(t, err)
+	assert.Contains(t, out, "  address: 1 Main St, Springfield\n")
+}
+
+func TestUnit_RegisterFormatter_ProtoFieldsTemplate(t *testing.T) {
+	registerAddressFormatter(t)
+
+	tmpl := protocli.MustTemplateFormat("table", map[string]string{
+		"example.UserResponse": `{{$f := protoFields .}}{{$f.user.name}} lives at {{$f.user.address}}`,
+	})
+	out, err := formatGo(t, tmpl, newAddressResponse())
+	require.NoError(t, err)
+	assert.Equal(t, "Ada lives at 1 Main St, Springfield", out)
+}
+
+func TestUnit_RegisterFormatter_UnregisteredOutputUnchanged(t *testing.T) {
+	want, err := formatWith(t, protocli.JSON(), newAddressResponse(), "--pretty")
+	require.NoError(t, err)
+
+	protocli.RegisterFormatter("example.Unused", func(proto.Message) (string, error) { return "unused", nil })
+	t.Cleanup(func() { protocli.RegisterFormatter("example.Unused", nil) })
+
+	got, err := formatWith(t, protocli.JSON(), newAddressResponse(), "--pretty")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestUnit_RegisterFormatter_Error(t *testing.T) {
+	errBadAddress := errors.New("bad address")
+	protocli.RegisterFormatter("example.Address", func(proto.Message) (string, error) { return "", errBadAddress })
+	t.Cleanup(func() { protocli.RegisterFormatter("example.Address", nil) })
+
+	_, err := formatWith(t, protocli.JSON(), newAddressResponse())
+	require.ErrorIs(t, err, errBadAddress)
+
+	_, err = formatWith(t, protocli.YAML(), &simple.Address{})
+	require.ErrorIs(t, err, errBadAddress)
+}
@@ -0,0 +1,48 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/urfave/cli/v3"
+)
+
+// methodPanicRecoveryKey is the Metadata key storing the WithMethodPanicRecovery setting on the root command.
+const methodPanicRecoveryKey = "protocli:methodPanicRecovery"
+
+// ErrMethodPanic is wrapped by the error returned when a service
+// implementation panics during a local (non-daemon) call.
+var ErrMethodPanic = errors.New("panicked")
+
+// CallLocal calls a service implementation directly, as generated commands do
+// in local (non-daemon) mode, converting a panic into an error as described
+// for RecoverMethodPanic.
+func CallLocal[T any](cmd *cli.Command, method string, call func() (T, error)) (T, error) {
+	var resp T
+	err := RecoverMethodPanic(cmd, method, func() error {
+		var callErr error
+		resp, callErr = call()
+		return callErr
+	})
+	return resp, err
+}
+
+// RecoverMethodPanic runs fn, a local call of method, and converts a panic in
+// it into an error ("method %s panicked: %v") wrapping ErrMethodPanic, so a
+// buggy implementation fails the command instead of crashing the CLI. The
+// stack is logged at debug level. Panics propagate when recovery was disabled
+// with WithMethodPanicRecovery(false).
+func RecoverMethodPanic(cmd *cli.Command, method string, fn func() error) (err error) {
+	if enabled, ok := cmd.Root().Metadata[methodPanicRecoveryKey].(bool); ok && !enabled {
+		return fn()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Debug("Service method panicked", "method", method, "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("method %s %w: %v", method, ErrMethodPanic, r)
+		}
+	}()
+	return fn()
+}
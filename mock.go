@@ -0,0 +1,54 @@
+package protocli
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// RecordedCall is a single RPC invocation captured by a generated mock server.
+type RecordedCall struct {
+	Method  string        // RPC method name, e.g. "GetUser"
+	Request proto.Message // Request message as received by the server
+}
+
+// CallRecorder records the calls made to a generated Mock<Service>Server.
+// The zero value is ready to use and safe for concurrent use.
+type CallRecorder struct {
+	mu    sync.Mutex
+	calls []RecordedCall
+}
+
+// Record appends a call to the recorder. Called by generated mock methods.
+func (r *CallRecorder) Record(method string, req proto.Message) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, RecordedCall{Method: method, Request: req})
+}
+
+// Calls returns every recorded call in the order it was received.
+func (r *CallRecorder) Calls() []RecordedCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedCall(nil), r.calls...)
+}
+
+// CallsTo returns the requests recorded for the named method, in order.
+func (r *CallRecorder) CallsTo(method string) []proto.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var reqs []proto.Message
+	for _, call := range r.calls {
+		if call.Method == method {
+			reqs = append(reqs, call.Request)
+		}
+	}
+	return reqs
+}
+
+// ResetCalls discards all recorded calls.
+func (r *CallRecorder) ResetCalls() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
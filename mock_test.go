@@ -0,0 +1,36 @@
+package protocli_test
+
+import (
+	"sync"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestUnit_CallRecorder_ConcurrentRecord(t *testing.T) {
+	var rec protocli.CallRecorder
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			method := "Get"
+			if i%2 == 0 {
+				method = "List"
+			}
+			rec.Record(method, wrapperspb.Int32(int32(i)))
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, rec.Calls(), 10)
+	assert.Len(t, rec.CallsTo("Get"), 5)
+	assert.Len(t, rec.CallsTo("List"), 5)
+	assert.Empty(t, rec.CallsTo("Delete"))
+
+	rec.ResetCalls()
+	assert.Empty(t, rec.Calls())
+}
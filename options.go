@@ -1,15 +1,20 @@
 package protocli
 
 import (
+	"cmp"
 	"context"
 	"io"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 	"text/template"
 	"time"
 
 	"github.com/drewfead/proto-cli/cliauth"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/urfave/cli/v3"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 )
@@ -54,9 +59,36 @@ type FlagContainer interface {
 	FlagName() string
 }
 
-// flagContainer implements FlagContainer by wrapping a cli.Command and flag name.
+// FlagReader is the flag access surface shared by CLI backends. *cli.Command
+// implements it; other backends (e.g. contrib/cobracli) provide adapters so that
+// generated request-building code and FlagContainer work unchanged.
+type FlagReader interface {
+	IsSet(name string) bool
+	String(name string) string
+	Bool(name string) bool
+	Int(name string) int
+	Int32(name string) int32
+	Int64(name string) int64
+	Uint(name string) uint
+	Uint32(name string) uint32
+	Uint64(name string) uint64
+	Float(name string) float64
+	Float32(name string) float32
+	Float64(name string) float64
+	StringSlice(name string) []string
+	Int32Slice(name string) []int32
+	Int64Slice(name string) []int64
+	Uint32Slice(name string) []uint32
+	Uint64Slice(name string) []uint64
+	Float32Slice(name string) []float32
+	Float64Slice(name string) []float64
+}
+
+var _ FlagReader = (*cli.Command)(nil)
+
+// flagContainer implements FlagContainer by wrapping a FlagReader and flag name.
 type flagContainer struct {
-	cmd      *cli.Command
+	cmd      FlagReader
 	flagName string
 }
 
@@ -84,7 +116,8 @@ func (f *flagContainer) IsSetNamed(name string) bool           { return f.cmd.Is
 func (f *flagContainer) FlagName() string { return f.flagName }
 
 // NewFlagContainer creates a new FlagContainer for the given command and flag name.
-func NewFlagContainer(cmd *cli.Command, flagName string) FlagContainer {
+// cmd is typically a *cli.Command.
+func NewFlagContainer(cmd FlagReader, flagName string) FlagContainer {
 	return &flagContainer{cmd: cmd, flagName: flagName}
 }
 
@@ -93,7 +126,7 @@ func NewFlagContainer(cmd *cli.Command, flagName string) FlagContainer {
 // String() returns the element value directly; Named accessors delegate to cmd.
 type stringValueFlagContainer struct {
 	value string
-	cmd   *cli.Command
+	cmd   FlagReader
 }
 
 func (f *stringValueFlagContainer) String() string        { return f.value }
@@ -118,7 +151,7 @@ func (f *stringValueFlagContainer) FlagName() string                      { retu
 // NewStringValueFlagContainer creates a FlagContainer whose primary String() accessor
 // returns value directly. Named accessors delegate to cmd.
 // Used to pass individual repeated-message elements to a FlagDeserializer.
-func NewStringValueFlagContainer(value string, cmd *cli.Command) FlagContainer {
+func NewStringValueFlagContainer(value string, cmd FlagReader) FlagContainer {
 	return &stringValueFlagContainer{value: value, cmd: cmd}
 }
 
@@ -144,6 +177,12 @@ type FlagDeserializer func(ctx context.Context, flags FlagContainer) (proto.Mess
 // Returning an error prevents the daemon from starting.
 type DaemonStartupHook func(ctx context.Context, server *grpc.Server, mux *runtime.ServeMux) error
 
+// DaemonStartupContextHook is a DaemonStartupHook that can also return a
+// context derived from ctx, e.g. carrying a DB pool opened at startup. Later
+// startup hooks and all ready and shutdown hooks receive the returned context;
+// a nil context leaves it unchanged.
+type DaemonStartupContextHook func(ctx context.Context, server *grpc.Server, mux *runtime.ServeMux) (context.Context, error)
+
 // DaemonReadyHook is called after the gRPC server is listening and ready to accept connections
 // Errors must be handled within the hook (no error return).
 type DaemonReadyHook func(ctx context.Context)
@@ -153,6 +192,10 @@ type DaemonReadyHook func(ctx context.Context)
 // Errors must be handled within the hook (no error return).
 type DaemonShutdownHook func(ctx context.Context)
 
+// ConfigLoadedHook is called with a service's config once it has been loaded
+// and merged from all sources. cfg must not be modified.
+type ConfigLoadedHook func(serviceName string, cfg proto.Message)
+
 // FlagConfiguredOutputFormat is an optional interface for formats that need custom flags.
 type FlagConfiguredOutputFormat interface {
 	OutputFormat
@@ -161,6 +204,27 @@ type FlagConfiguredOutputFormat interface {
 	Flags() []cli.Flag
 }
 
+// MediaTypeOutputFormat is an optional interface for formats that can render
+// transcoded HTTP responses. With WithTranscoding, the gateway uses the format
+// for requests whose Accept (or Content-Type) header names its media type.
+type MediaTypeOutputFormat interface {
+	OutputFormat
+
+	// MediaType returns the HTTP media type of the output (e.g., "application/yaml").
+	MediaType() string
+}
+
+// TrailingNewlineControl is an optional interface for formats that decide
+// whether a newline is written after their output. Formats that don't
+// implement it get one, keeping the shell prompt on its own line; binary
+// formats return false so their output is written unchanged.
+type TrailingNewlineControl interface {
+	OutputFormat
+
+	// NeedsTrailingNewline reports whether a newline should follow the output.
+	NeedsTrailingNewline() bool
+}
+
 // Public interfaces - minimal API surface
 
 // ServiceConfig is the configuration returned by ApplyServiceOptions.
@@ -171,6 +235,7 @@ type ServiceConfig interface {
 	OutputFormats() []OutputFormat
 	InputFormats() []InputFormat
 	FlagDeserializer(messageName string) (FlagDeserializer, bool)
+	FlagRules(fullMethod string) []FlagRule
 }
 
 // CLIService is the interface for services registered in the root CLI command.
@@ -187,11 +252,13 @@ type RootConfig interface {
 	GRPCServerOptions() []grpc.ServerOption
 	EnableTranscoding() bool
 	TranscodingPort() int
+	OutputFormats() []OutputFormat
 	ConfigPaths() []string
 	EnvPrefix() string
+	SecretDir() string
 	ServiceFactory(serviceName string) (any, bool)
 	GracefulShutdownTimeout() time.Duration
-	DaemonStartupHooks() []DaemonStartupHook
+	DaemonStartupHooks() []DaemonStartupContextHook
 	DaemonReadyHooks() []DaemonReadyHook
 	DaemonShutdownHooks() []DaemonShutdownHook
 	LoggingConfig() LoggingConfigCallback
@@ -201,6 +268,33 @@ type RootConfig interface {
 	LoginProvider() cliauth.LoginProvider
 	AuthOptions() []cliauth.Option
 	TUIProvider() TUIProvider
+	ProgressReporter() ProgressReporter
+	ResponseCache() *ResponseCache
+	Recorder() *ResponseRecorder
+	Replayer() *ResponseRecorder
+	ProbesEnabled() bool
+	ReadinessChecks() []ReadinessCheck
+	AuditSink() AuditSink
+	ContextValues() []ContextValuesFunc
+	MaxRequestSize() int
+	DefaultBindHost() string
+	RateLimits() map[string]rate.Limit
+	ServiceDisplayOrder() []string
+	FormatPanicRecovery() bool
+	MethodPanicRecovery() bool
+	BannerFunc() func(io.Writer)
+	CommandFilter() CommandFilter
+	InteractivePrompt() bool
+	GlobalTimeout() time.Duration
+	ConfigMigrations() []ConfigMigration
+	RequiredConfigValidation() bool
+	ArgErrorHandler() ArgErrorHandler
+	AccessLogEnabled() bool
+	AccessLogSampling() (every int, perMethod map[string]int)
+	UpdateCheckURL() string
+	RemoteCompression() string
+	RemotesFile() string
+	ConfigLoadedHooks() []ConfigLoadedHook
 }
 
 // HelpCustomization holds options for customizing help text display.
@@ -239,6 +333,7 @@ type serviceCommandOptions struct {
 	outputFormats      []OutputFormat
 	flagDeserializers  map[string]FlagDeserializer // messageName -> deserializer
 	inputFormats       []InputFormat
+	flagRules          map[string][]FlagRule // fullMethod -> rules
 }
 
 // AddBeforeCommand adds a before command hook.
@@ -290,12 +385,20 @@ func (o *serviceCommandOptions) FlagDeserializer(messageName string) (FlagDeseri
 	return deserializer, ok
 }
 
+// FlagRules returns the rules registered for a method with WithFlagRules.
+func (o *serviceCommandOptions) FlagRules(fullMethod string) []FlagRule {
+	return o.flagRules[fullMethod]
+}
+
 // SlogConfigurationContext provides context information for slog configuration.
 type SlogConfigurationContext interface {
 	// IsDaemon returns true if the logger is being configured for daemon mode.
 	IsDaemon() bool
 	// Level returns the configured log level from the --verbosity flag.
 	Level() slog.Level
+	// Color reports whether logs written to stderr should be colored, as
+	// decided by the --color flag.
+	Color() bool
 }
 
 // LoggingConfigCallback is a function that configures the slog logger.
@@ -306,6 +409,7 @@ type LoggingConfigCallback func(ctx context.Context, config SlogConfigurationCon
 type slogConfigContext struct {
 	isDaemon bool
 	level    slog.Level
+	color    bool
 }
 
 func (c *slogConfigContext) IsDaemon() bool {
@@ -316,6 +420,10 @@ func (c *slogConfigContext) Level() slog.Level {
 	return c.level
 }
 
+func (c *slogConfigContext) Color() bool {
+	return c.color
+}
+
 // rootCommandOptions holds configuration for the root CLI command.
 // serviceRegistration tracks a service and how it should be registered.
 type serviceRegistration struct {
@@ -332,24 +440,53 @@ type rootCommandOptions struct {
 	grpcServerOptions       []grpc.ServerOption
 	enableTranscoding       bool
 	transcodingPort         int
-	configPaths             []string              // Config file paths for loading
-	envPrefix               string                // Environment variable prefix
-	serviceFactories        map[string]any        // Service name -> factory function
-	gracefulShutdownTimeout time.Duration         // Timeout for graceful shutdown
-	daemonStartupHooks      []DaemonStartupHook   // Hooks called before server starts
-	daemonReadyHooks        []DaemonReadyHook     // Hooks called after server is ready
-	daemonShutdownHooks     []DaemonShutdownHook  // Hooks called during graceful shutdown
-	loggingConfig           LoggingConfigCallback // Function to configure slog logger
-	defaultVerbosity        *slog.Level           // Default verbosity level (nil = info)
-	helpCustomization       *HelpCustomization    // Help text customization options
-	configManager           proto.Message         // Config message for config management command suite
-	configServiceName       string                // Service name for config management
-	globalConfigPath        string                // Custom global config path
-	localConfigPath         string                // Custom local config path
-	ignoreLocalOnly         bool                  // If true, skip local-only interceptors in daemon mode
-	loginProvider           cliauth.LoginProvider // Auth login provider
-	authOptions             []cliauth.Option      // Auth configuration options
-	tuiProvider             TUIProvider           // Interactive TUI provider (nil if not configured)
+	configPaths             []string                   // Config file paths for loading
+	envPrefix               string                     // Environment variable prefix
+	secretDir               string                     // Directory of single-value secret files
+	serviceFactories        map[string]any             // Service name -> factory function
+	gracefulShutdownTimeout time.Duration              // Timeout for graceful shutdown
+	daemonStartupHooks      []DaemonStartupContextHook // Hooks called before server starts
+	daemonReadyHooks        []DaemonReadyHook          // Hooks called after server is ready
+	daemonShutdownHooks     []DaemonShutdownHook       // Hooks called during graceful shutdown
+	loggingConfig           LoggingConfigCallback      // Function to configure slog logger
+	defaultVerbosity        *slog.Level                // Default verbosity level (nil = info)
+	helpCustomization       *HelpCustomization         // Help text customization options
+	configManager           proto.Message              // Config message for config management command suite
+	configServiceName       string                     // Service name for config management
+	globalConfigPath        string                     // Custom global config path
+	localConfigPath         string                     // Custom local config path
+	ignoreLocalOnly         bool                       // If true, skip local-only interceptors in daemon mode
+	loginProvider           cliauth.LoginProvider      // Auth login provider
+	authOptions             []cliauth.Option           // Auth configuration options
+	tuiProvider             TUIProvider                // Interactive TUI provider (nil if not configured)
+	progressReporter        ProgressReporter           // Progress reporter for streaming commands (nil = disabled)
+	responseCache           *ResponseCache             // Last-response cache (nil = disabled)
+	recorder                *ResponseRecorder          // Records responses for replay (nil = disabled)
+	replayer                *ResponseRecorder          // Serves --replay from recordings (nil = disabled)
+	probesEnabled           bool                       // Serve /livez and /readyz from the daemon
+	readinessChecks         []ReadinessCheck           // Extra checks consulted by /readyz
+	auditSink               AuditSink                  // Receives a record per command invocation (nil = disabled)
+	contextValues           []ContextValuesFunc        // Inject app state into command contexts
+	maxRequestSize          int                        // Largest request in bytes sent or accepted (0 = unlimited)
+	bindLoopback            bool                       // daemonize binds 127.0.0.1 unless --bind/--host is given
+	rateLimits              map[string]rate.Limit      // Daemon calls per second by full method name (nil = unlimited)
+	noFormatRecovery        bool                       // Let panics in output formats propagate
+	noMethodRecovery        bool                       // Let panics in local service calls propagate
+	serviceDisplayOrder     []string                   // Service names listed first, in this order
+	bannerFunc              func(io.Writer)            // Writes the startup banner to stderr (nil = no banner)
+	interactivePrompt       bool                       // Add --pick to choose and fill in a command from prompts
+	commandFilter           CommandFilter              // Hides or disables method commands (nil = all available)
+	globalTimeout           time.Duration              // Default --deadline for the whole invocation (0 = none)
+	configMigrations        []ConfigMigration          // Renamed config keys, applied on read and by `config migrate`
+	validateRequiredConfig  bool                       // Reject loaded config missing fields annotated required
+	argErrorHandler         ArgErrorHandler            // Handles positional args given to generated commands (nil = DefaultArgErrorHandler)
+	accessLog               bool                       // Daemon logs each call (see WithAccessLog)
+	accessLogEvery          int                        // Log 1 in N successful calls (0/1 = all)
+	accessLogMethods        map[string]int             // Per-method access log sampling by full method name
+	updateCheckURL          string                     // Latest-version endpoint for update notices ("" = disabled)
+	remoteCompression       string                     // Default --compression for remote calls ("" = none)
+	remotesFile             string                     // YAML file of named remotes selected with --context
+	configLoadedHooks       []ConfigLoadedHook         // Called after each service config load
 }
 
 // AddBeforeCommand adds a before command hook.
@@ -377,6 +514,21 @@ func (o *rootCommandOptions) AddService(service *ServiceCLI, hoisted bool) {
 	})
 }
 
+// sortServiceRegistrations orders services for help output and the TUI:
+// services named by WithServiceDisplayOrder come first in that order, then the
+// rest by ServiceCLI.Order. Ties keep registration order.
+func (o *rootCommandOptions) sortServiceRegistrations() {
+	rank := func(reg *serviceRegistration) int {
+		if i := slices.Index(o.serviceDisplayOrder, reg.service.ServiceName); i >= 0 {
+			return i - len(o.serviceDisplayOrder)
+		}
+		return 0
+	}
+	slices.SortStableFunc(o.serviceRegistrations, func(a, b *serviceRegistration) int {
+		return cmp.Or(cmp.Compare(rank(a), rank(b)), cmp.Compare(a.service.Order, b.service.Order))
+	})
+}
+
 // CLIServices returns the registered services as CLIService interfaces.
 func (o *rootCommandOptions) CLIServices() []CLIService {
 	services := make([]CLIService, 0, len(o.serviceRegistrations))
@@ -447,6 +599,11 @@ func (o *rootCommandOptions) EnvPrefix() string {
 	return o.envPrefix
 }
 
+// SecretDir returns the directory of single-value secret files.
+func (o *rootCommandOptions) SecretDir() string {
+	return o.secretDir
+}
+
 // ServiceFactory returns the factory function for a service, if registered.
 func (o *rootCommandOptions) ServiceFactory(serviceName string) (any, bool) {
 	if o.serviceFactories == nil {
@@ -465,7 +622,7 @@ func (o *rootCommandOptions) GracefulShutdownTimeout() time.Duration {
 }
 
 // DaemonStartupHooks returns the registered daemon startup hooks.
-func (o *rootCommandOptions) DaemonStartupHooks() []DaemonStartupHook {
+func (o *rootCommandOptions) DaemonStartupHooks() []DaemonStartupContextHook {
 	return o.daemonStartupHooks
 }
 
@@ -518,6 +675,148 @@ func (o *rootCommandOptions) TUIProvider() TUIProvider {
 	return o.tuiProvider
 }
 
+// ProgressReporter returns the configured progress reporter for streaming commands.
+func (o *rootCommandOptions) ProgressReporter() ProgressReporter {
+	return o.progressReporter
+}
+
+// ResponseCache returns the configured last-response cache, or nil if disabled.
+func (o *rootCommandOptions) ResponseCache() *ResponseCache {
+	return o.responseCache
+}
+
+// Recorder returns the recorder configured via WithRecord, or nil if disabled.
+func (o *rootCommandOptions) Recorder() *ResponseRecorder {
+	return o.recorder
+}
+
+// Replayer returns the recordings configured via WithReplay, or nil if disabled.
+func (o *rootCommandOptions) Replayer() *ResponseRecorder {
+	return o.replayer
+}
+
+// ProbesEnabled returns true if the daemon serves /livez and /readyz.
+func (o *rootCommandOptions) ProbesEnabled() bool {
+	return o.probesEnabled
+}
+
+// ReadinessChecks returns the extra checks consulted by /readyz.
+func (o *rootCommandOptions) ReadinessChecks() []ReadinessCheck {
+	return o.readinessChecks
+}
+
+// AuditSink returns the configured audit sink, or nil if auditing is disabled.
+func (o *rootCommandOptions) AuditSink() AuditSink {
+	return o.auditSink
+}
+
+// ContextValues returns the functions that inject values into command contexts.
+func (o *rootCommandOptions) ContextValues() []ContextValuesFunc {
+	return o.contextValues
+}
+
+// MaxRequestSize returns the request size limit in bytes, or 0 if unlimited.
+func (o *rootCommandOptions) MaxRequestSize() int {
+	return o.maxRequestSize
+}
+
+// DefaultBindHost returns the host daemonize binds to when --bind/--host is
+// not given: 127.0.0.1 with WithDefaultBindLoopback, otherwise all interfaces.
+func (o *rootCommandOptions) DefaultBindHost() string {
+	if o.bindLoopback {
+		return loopbackHost
+	}
+	return allInterfacesHost
+}
+
+// RateLimits returns the daemon's per-method rate limits set with WithRateLimit.
+func (o *rootCommandOptions) RateLimits() map[string]rate.Limit {
+	return o.rateLimits
+}
+
+// ServiceDisplayOrder returns the service names set with WithServiceDisplayOrder.
+func (o *rootCommandOptions) ServiceDisplayOrder() []string {
+	return o.serviceDisplayOrder
+}
+
+// FormatPanicRecovery reports whether panics in output formats become errors.
+func (o *rootCommandOptions) FormatPanicRecovery() bool {
+	return !o.noFormatRecovery
+}
+
+// MethodPanicRecovery reports whether panics in local service calls become errors.
+func (o *rootCommandOptions) MethodPanicRecovery() bool {
+	return !o.noMethodRecovery
+}
+
+// BannerFunc returns the startup banner writer set with WithStartupBanner or
+// WithBannerFunc.
+func (o *rootCommandOptions) BannerFunc() func(io.Writer) {
+	return o.bannerFunc
+}
+
+// CommandFilter returns the filter set with WithCommandFilter.
+func (o *rootCommandOptions) CommandFilter() CommandFilter {
+	return o.commandFilter
+}
+
+// InteractivePrompt reports whether WithInteractivePrompt was given.
+func (o *rootCommandOptions) InteractivePrompt() bool {
+	return o.interactivePrompt
+}
+
+// GlobalTimeout returns the default of the --deadline flag (0 = no deadline).
+func (o *rootCommandOptions) GlobalTimeout() time.Duration {
+	return o.globalTimeout
+}
+
+// ConfigMigrations returns the config key renames registered with WithConfigMigrations.
+func (o *rootCommandOptions) ConfigMigrations() []ConfigMigration {
+	return o.configMigrations
+}
+
+// RequiredConfigValidation returns whether loaded config is checked for
+// missing required fields.
+func (o *rootCommandOptions) RequiredConfigValidation() bool {
+	return o.validateRequiredConfig
+}
+
+// ArgErrorHandler returns the handler set with WithArgErrorHandler, or nil.
+func (o *rootCommandOptions) ArgErrorHandler() ArgErrorHandler {
+	return o.argErrorHandler
+}
+
+// AccessLogEnabled reports whether the daemon logs the calls it handles.
+func (o *rootCommandOptions) AccessLogEnabled() bool {
+	return o.accessLog
+}
+
+// UpdateCheckURL returns the latest-version endpoint set with WithUpdateCheck.
+func (o *rootCommandOptions) UpdateCheckURL() string {
+	return o.updateCheckURL
+}
+
+// RemoteCompression returns the default compressor set with WithRemoteCompression.
+func (o *rootCommandOptions) RemoteCompression() string {
+	return o.remoteCompression
+}
+
+// RemotesFile returns the remotes file set with WithRemotesFile.
+func (o *rootCommandOptions) RemotesFile() string {
+	return o.remotesFile
+}
+
+// ConfigLoadedHooks returns the hooks added with WithConfigLoadedHook.
+func (o *rootCommandOptions) ConfigLoadedHooks() []ConfigLoadedHook {
+	return o.configLoadedHooks
+}
+
+// AccessLogSampling returns the default and per-method 1-in-N access log
+// sampling rates.
+func (o *rootCommandOptions) AccessLogSampling() (int, map[string]int) {
+	return o.accessLogEvery, o.accessLogMethods
+}
+
 // slogLevelToString converts an slog.Level to the CLI verbosity string format.
 // Note: In slog, higher numeric values = less verbose logging.
 func slogLevelToString(level slog.Level) string {
@@ -667,6 +966,31 @@ func WithInputFormat(format InputFormat) ServiceOnlyOption {
 	})
 }
 
+// WithFlagRules registers cross-flag checks for the command of a method, named
+// by its gRPC full method name (e.g. "/example.UserService/GetUser"). The
+// rules run after the request is assembled, alongside those declared with the
+// cli.command requires and conflicts annotations; all failures are reported
+// together and the method is not called.
+//
+// Example:
+//
+//	WithFlagRules("/example.ReportService/Export", func(cmd *cli.Command) error {
+//	    if cmd.Timestamp("end").Before(cmd.Timestamp("start")) {
+//	        return errors.New("--end must not be before --start")
+//	    }
+//	    return nil
+//	})
+//
+// Type-safe: only works with ServiceOptions.
+func WithFlagRules(fullMethod string, rules ...FlagRule) ServiceOnlyOption {
+	return ServiceOnlyOption(func(o *serviceCommandOptions) {
+		if o.flagRules == nil {
+			o.flagRules = make(map[string][]FlagRule)
+		}
+		o.flagRules[fullMethod] = append(o.flagRules[fullMethod], rules...)
+	})
+}
+
 // Root-only options
 
 // ServiceRegistrationOption configures how a service is registered in the root command.
@@ -758,6 +1082,10 @@ func WithStreamInterceptor(interceptor grpc.StreamServerInterceptor) RootOnlyOpt
 
 // WithTranscoding enables gRPC-Gateway transcoding (HTTP/JSON to gRPC).
 // This allows clients to call gRPC services via REST/JSON on the specified port.
+// Root output formats implementing MediaTypeOutputFormat (such as YAML) also
+// serve responses to clients that request their media type in an Accept header.
+// Clients accepting text/event-stream, such as browser EventSource, receive
+// server-streaming responses as Server-Sent Events with one JSON event per message.
 // Type-safe: only works with RootOptions.
 func WithTranscoding(httpPort int) RootOnlyOption {
 	return RootOnlyOption(func(o *rootCommandOptions) {
@@ -784,6 +1112,18 @@ func WithEnvPrefix(prefix string) RootOnlyOption {
 	})
 }
 
+// WithSecretDir reads config overrides from a directory of single-value files,
+// such as a Kubernetes secret mounted as a volume. Each file named after a
+// config field path in snake_case or kebab-case sets that field to its trimmed
+// contents, taking precedence over env vars. See SecretDir.
+// Example: WithSecretDir("/etc/usercli/secrets") with a file database-password.
+// Type-safe: only works with RootOptions.
+func WithSecretDir(path string) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.secretDir = path
+	})
+}
+
 // WithConfigFactory registers a factory function for a service.
 // The factory function takes a config message and returns a service implementation.
 // Example: WithConfigFactory("userservice", func(cfg *UserServiceConfig) UserServiceServer { ... }).
@@ -814,6 +1154,31 @@ func WithGracefulShutdownTimeout(timeout time.Duration) RootOnlyOption {
 // Returning an error prevents the daemon from starting.
 // Type-safe: only works with RootOptions.
 func OnDaemonStartup(hook DaemonStartupHook) RootOnlyOption {
+	return OnDaemonStartupContext(func(ctx context.Context, server *grpc.Server, mux *runtime.ServeMux) (context.Context, error) {
+		return ctx, hook(ctx, server, mux)
+	})
+}
+
+// OnDaemonStartupContext registers a startup hook like OnDaemonStartup whose
+// returned context is passed to the hooks after it, so resources opened at
+// startup can be closed at shutdown. Hooks registered with either option run
+// together in registration order. The daemon itself keeps listening to the
+// original context for cancellation.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.OnDaemonStartupContext(func(ctx context.Context, _ *grpc.Server, _ *runtime.ServeMux) (context.Context, error) {
+//	    pool, err := pgxpool.New(ctx, dsn)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return context.WithValue(ctx, poolKey{}, pool), nil
+//	}),
+//	protocli.OnDaemonShutdown(func(ctx context.Context) {
+//	    ctx.Value(poolKey{}).(*pgxpool.Pool).Close()
+//	}),
+func OnDaemonStartupContext(hook DaemonStartupContextHook) RootOnlyOption {
 	return RootOnlyOption(func(o *rootCommandOptions) {
 		o.daemonStartupHooks = append(o.daemonStartupHooks, hook)
 	})
@@ -1032,6 +1397,432 @@ func WithInteractive(provider TUIProvider) RootOnlyOption {
 	})
 }
 
+// WithProgress registers a progress reporter for streaming commands.
+// Only methods annotated with progress_total_field report progress: the first
+// streamed message supplies the expected total, and each received message advances
+// the count. Use StderrProgressBar for a TTY-aware textual bar on stderr.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithProgress(protocli.StderrProgressBar())
+func WithProgress(reporter ProgressReporter) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.progressReporter = reporter
+	})
+}
+
+// WithResponseCache stores the last response of every unary command as JSON in dir
+// and adds a `last` command for reading fields back out of it. The `last`
+// function of template formats reads the cache of the root running the
+// command, so template formats can reference earlier results.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithResponseCache(filepath.Join(os.TempDir(), "usercli"))
+//
+//	$ usercli user-service create-user --name alice
+//	$ usercli user-service get-user --id $(usercli last create-user user.id)
+func WithResponseCache(dir string) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.responseCache = NewResponseCache(dir)
+	})
+}
+
+// WithRecord records the response of every command in dir, keyed by RPC method
+// and a hash of the request. Server-streaming commands record the whole
+// sequence of messages once the stream ends cleanly. Replay recordings with
+// WithReplay, e.g. for demos or offline tests.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithRecord("testdata/recordings")
+func WithRecord(dir string) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.recorder = NewResponseRecorder(dir)
+	})
+}
+
+// WithReplay adds a global --replay flag. When it is set, commands return the
+// response recorded in dir (see WithRecord) for a matching request instead of
+// calling the service, and fail with ErrNoRecording if there is none.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithReplay("testdata/recordings")
+//
+//	$ usercli --replay user-service get --id 1
+func WithReplay(dir string) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.replayer = NewResponseRecorder(dir)
+	})
+}
+
+// WithAuditLog records every command invocation to sink: start time, user (from
+// the auth provider's status, when WithAuth is set), command path, RPC method,
+// the request with sensitive fields redacted (see RedactSensitive), and whether
+// it succeeded. Use FileAuditSink for an append-only JSON lines file.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithAuditLog(protocli.FileAuditSink("/var/log/usercli/audit.jsonl"))
+func WithAuditLog(sink AuditSink) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.auditSink = sink
+	})
+}
+
+// ContextValuesFunc derives a command context carrying app state. See WithContextValues.
+type ContextValuesFunc func(ctx context.Context) context.Context
+
+// WithContextValues injects app-wide state (DB handles, clients, caches) into the
+// context of every command, so service implementations can retrieve it with
+// ctx.Value. Unlike config, these are live objects rather than serializable data.
+// Functions run in registration order once per invocation, before the request is
+// built and the local or remote call is made.
+//
+// Values are shared by everything the command runs, including stream handlers and
+// any goroutines the implementation starts, so they must be safe for concurrent use.
+// Daemon request contexts come from gRPC and are not affected; use interceptors there.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithContextValues(func(ctx context.Context) context.Context {
+//	    return context.WithValue(ctx, dbKey{}, db)
+//	})
+func WithContextValues(fn ContextValuesFunc) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.contextValues = append(o.contextValues, fn)
+	})
+}
+
+// WithMaxRequestSize rejects requests whose encoded size exceeds bytes before
+// they are sent, for both local and remote calls, so an oversized payload
+// fails fast with ErrRequestTooLarge. The daemon also uses it as the gRPC
+// server's maximum receive message size.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithMaxRequestSize(4 << 20) // 4 MiB
+func WithMaxRequestSize(bytes int) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.maxRequestSize = bytes
+	})
+}
+
+// WithDefaultBindLoopback makes `daemonize` bind to 127.0.0.1 unless a host is
+// given with --bind (or its alias --host), so the server is not reachable from
+// other machines by accident. Without it the daemon binds to all interfaces.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithDefaultBindLoopback()
+//
+//	$ usercli daemonize                  # listens on 127.0.0.1:50051
+//	$ usercli daemonize --bind 0.0.0.0   # listens on all interfaces
+func WithDefaultBindLoopback() RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.bindLoopback = true
+	})
+}
+
+// WithRateLimit limits the rate of calls the daemon accepts, in calls per
+// second, keyed by full gRPC method name (e.g. "/example.UserService/GetUser").
+// The DefaultRateLimit key sets the limit for every other method; without it,
+// unlisted methods are unlimited. Each method has its own token bucket holding
+// up to one second's worth of calls, and calls beyond it fail with
+// codes.ResourceExhausted until the bucket refills. Repeated uses merge, with
+// later entries winning.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithRateLimit(map[string]rate.Limit{
+//	    protocli.DefaultRateLimit:        100,
+//	    "/example.UserService/CreateUser": 5,
+//	})
+func WithRateLimit(perMethod map[string]rate.Limit) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		if o.rateLimits == nil {
+			o.rateLimits = make(map[string]rate.Limit, len(perMethod))
+		}
+		maps.Copy(o.rateLimits, perMethod)
+	})
+}
+
+// WithAccessLog makes the daemon log every call it handles at info level, with
+// the method, status code and duration. Failed calls are logged at warn level
+// with their error.
+// Type-safe: only works with RootOptions.
+func WithAccessLog() RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.accessLog = true
+	})
+}
+
+// WithAccessLogSampling enables the access log (see WithAccessLog) but logs
+// only the first of every n successful calls per method, to bound log volume
+// for busy daemons. Failed calls are always logged. Sampled lines carry a
+// sample_rate attribute. Use WithAccessLogMethodSampling to override the rate
+// for individual methods.
+// Type-safe: only works with RootOptions.
+func WithAccessLogSampling(n int) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.accessLog = true
+		o.accessLogEvery = n
+	})
+}
+
+// WithAccessLogMethodSampling enables the access log and logs 1 in n
+// successful calls to the method with the given full name (e.g.
+// "/example.UserService/GetUser"), overriding WithAccessLogSampling for it.
+// Pass 1 to log every call to a method.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithAccessLogSampling(100),
+//	protocli.WithAccessLogMethodSampling("/example.UserService/CreateUser", 1),
+func WithAccessLogMethodSampling(fullMethod string, n int) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.accessLog = true
+		if o.accessLogMethods == nil {
+			o.accessLogMethods = make(map[string]int)
+		}
+		o.accessLogMethods[fullMethod] = n
+	})
+}
+
+// WithFormatPanicRecovery controls whether a panic inside an OutputFormat is
+// recovered and returned as an ErrFormatPanic error (the default) so a buggy
+// custom format cannot crash the CLI mid-stream. Pass false to let panics
+// propagate, e.g. to get a stack trace while developing a format.
+// Type-safe: only works with RootOptions.
+func WithFormatPanicRecovery(enabled bool) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.noFormatRecovery = !enabled
+	})
+}
+
+// WithMethodPanicRecovery controls whether a panic inside a service
+// implementation called in local (non-daemon) mode is recovered and returned
+// as an ErrMethodPanic error (the default), with the stack logged at debug
+// level. Pass false to let panics propagate with a full stack trace.
+// Type-safe: only works with RootOptions.
+func WithMethodPanicRecovery(enabled bool) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.noMethodRecovery = !enabled
+	})
+}
+
+// WithServiceDisplayOrder lists services in help output and the TUI in the
+// given order, by service name. Services not named follow in (cli.service).order
+// and then registration order. This overrides the proto annotation for the
+// named services.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithServiceDisplayOrder("user-service", "admin")
+func WithServiceDisplayOrder(serviceNames ...string) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.serviceDisplayOrder = serviceNames
+	})
+}
+
+// WithStartupBanner prints text to stderr each time the CLI runs, before the
+// command executes, so branded CLIs can show ASCII art or a version without
+// affecting piped stdout. A global --quiet flag suppresses it.
+// Type-safe: only works with RootOptions.
+func WithStartupBanner(text string) RootOnlyOption {
+	return WithBannerFunc(func(w io.Writer) {
+		_, _ = io.WriteString(w, strings.TrimSuffix(text, "\n")+"\n")
+	})
+}
+
+// WithBannerFunc is like WithStartupBanner but lets fn write the banner, e.g.
+// to include a version computed at runtime.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithBannerFunc(func(w io.Writer) {
+//	    fmt.Fprintf(w, "usercli %s\n", version)
+//	})
+func WithBannerFunc(fn func(w io.Writer)) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.bannerFunc = fn
+	})
+}
+
+// WithUpdateCheck makes the CLI tell users when a newer version is available.
+// While a command runs, the latest version is fetched from url, which responds
+// with it as plain text ("1.4.0") or JSON ({"version": "1.4.0"}), and cached
+// for a day under the user cache dir. If it is newer than the root command's
+// Version, a notice is printed to stderr after the command finishes.
+//
+// The check never fails a command: network errors are logged at debug level.
+// A command that finishes before the fetch waits for it for up to a quarter
+// of a second, so that the result is cached; a slower fetch is abandoned and
+// tried again on the next run. It is skipped for daemonize, when the root command has no Version, with the global --quiet
+// flag, or when the UpdateCheckDisableEnvVar variable (e.g.
+// USERCLI_NO_UPDATE_CHECK) is set.
+// Type-safe: only works with RootOptions.
+func WithUpdateCheck(url string) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.updateCheckURL = url
+	})
+}
+
+// WithRemoteCompression compresses the remote calls of every generated command
+// with the named gRPC compressor (e.g. "gzip") unless --compression says
+// otherwise; --compression none turns it off for one call. The daemon always
+// accepts gzip-compressed calls and compresses its responses to them.
+// Type-safe: only works with RootOptions.
+func WithRemoteCompression(name string) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.remoteCompression = name
+	})
+}
+
+// WithRemotesFile adds a global --context flag that selects a named remote from
+// the YAML file at path, a structured alternative to passing --remote to every
+// command:
+//
+//	remotes:
+//	  staging:
+//	    address: staging.example.com:443
+//	    tls: true
+//	    auth: Bearer abc123
+//
+// `mycli --context staging users get-user --id 1` then calls staging over TLS
+// with the authorization header set. A --remote given on the command line
+// wins over --context. The file is only read when --context is used.
+// Type-safe: only works with RootOptions.
+func WithRemotesFile(path string) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.remotesFile = path
+	})
+}
+
+// WithInteractivePrompt adds a global --pick flag, a lightweight alternative to
+// the WithInteractive TUI: `mycli --pick` lists the commands with fuzzy search,
+// prompts for each flag of the chosen command on the terminal and then runs it.
+// Prompts are written to stderr so the command's output can still be piped.
+// Type-safe: only works with RootOptions.
+func WithInteractivePrompt() RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.interactivePrompt = true
+	})
+}
+
+// WithCommandFilter hides and/or disables method commands when the command
+// tree is assembled, e.g. for license-gated features or features behind a flag.
+// filter is called with each command's gRPC full method name: commands that are
+// not visible are left out of help, and commands that are not enabled fail
+// with an ErrCommandNotAvailable error when invoked. The daemon is unaffected.
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithCommandFilter(func(fullMethod string) (bool, bool) {
+//	    licensed := license.Allows(fullMethod)
+//	    return licensed, licensed
+//	})
+func WithCommandFilter(filter func(fullMethod string) (visible bool, enabled bool)) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.commandFilter = filter
+	})
+}
+
+// WithGlobalTimeout bounds every invocation of the CLI, e.g. a long batch or
+// watch, to d: once it passes, the context of the running command is canceled
+// and the command fails with ErrGlobalDeadlineExceeded. It sets the default of
+// the --deadline flag, which can override it per invocation (0 disables it).
+// Deadlines of individual commands, such as ping --timeout, still apply; the
+// earlier one wins. daemonize is never bounded.
+// Type-safe: only works with RootOptions.
+func WithGlobalTimeout(d time.Duration) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.globalTimeout = d
+	})
+}
+
+// WithConfigMigrations registers renamed config keys so that config files and
+// env vars written for an older version keep working. The loader applies the
+// migrations on read, logging a deprecation warning for each old key it finds,
+// and `config migrate` rewrites the config files to the new keys, keeping
+// their comments and a .bak copy of each original. Migrations apply in order, so a key renamed twice
+// needs one migration per rename.
+// Type-safe: only works with RootOptions.
+func WithConfigMigrations(migrations []ConfigMigration) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.configMigrations = append(o.configMigrations, migrations...)
+	})
+}
+
+// WithRequiredConfigValidation makes service config loading fail when a config
+// field annotated with (cli.flag).required is still unset once files, env vars,
+// secret files and flags are merged. The error names every missing field, so
+// e.g. a daemon reports all of them at startup instead of failing later.
+// Type-safe: only works with RootOptions.
+func WithRequiredConfigValidation() RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.validateRequiredConfig = true
+	})
+}
+
+// WithConfigLoadedHook calls hook with each service config loaded for a local
+// call, a TUI call or the daemon, after files, env vars, secret files and
+// flags are merged and validated. Unlike the service factory, which turns the
+// config into an implementation, the hook only observes it, e.g. to configure
+// tracing from it. Hooks run in the order they were added.
+// Type-safe: only works with RootOptions.
+func WithConfigLoadedHook(hook ConfigLoadedHook) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.configLoadedHooks = append(o.configLoadedHooks, hook)
+	})
+}
+
+// WithArgErrorHandler replaces the default handling of positional arguments
+// given to generated commands, which fails with "unsupported argument" and
+// exit code 3. The handler is called with the first argument; returning nil
+// lets the command run, leaving the arguments available through cmd.Args().
+// Type-safe: only works with RootOptions.
+//
+// Example:
+//
+//	protocli.WithArgErrorHandler(func(cmd *cli.Command, arg string) error {
+//		return cli.Exit(fmt.Sprintf("%s takes no arguments (got %q)", cmd.FullName(), arg), 64)
+//	})
+func WithArgErrorHandler(handler ArgErrorHandler) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.argErrorHandler = handler
+	})
+}
+
+// WithProbes makes the daemon serve HTTP liveness (/livez) and readiness (/readyz)
+// probes and register the standard gRPC health service. /readyz returns 200 only
+// while the gRPC health status is SERVING and every check passes; it reports 503
+// before the server is listening and once shutdown begins.
+//
+// Probes are served on the gateway mux when WithTranscoding is set, otherwise on a
+// dedicated HTTP server bound to the daemonize --probe-port flag.
+// Type-safe: only works with RootOptions.
+func WithProbes(checks ...ReadinessCheck) RootOnlyOption {
+	return RootOnlyOption(func(o *rootCommandOptions) {
+		o.probesEnabled = true
+		o.readinessChecks = append(o.readinessChecks, checks...)
+	})
+}
+
 // Helper functions to apply options
 
 // ApplyServiceOptions applies functional options and returns configured service settings.
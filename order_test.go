@@ -0,0 +1,79 @@
+package protocli_test
+
+import (
+	"context"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+// commandNames returns the names of cmds in order, skipping the built-ins that
+// RootCommand appends after the services.
+func commandNames(cmds []*cli.Command, stopAt string) []string {
+	var names []string
+	for _, cmd := range cmds {
+		if cmd.Name == stopAt {
+			break
+		}
+		names = append(names, cmd.Name)
+	}
+	return names
+}
+
+func TestUnit_CommandOrder_Annotation(t *testing.T) {
+	userCLI := simple.UserServiceCommand(context.Background(), &simple.UnimplementedUserServiceServer{})
+
+	// create (order: 1) sorts before get (order: 2) although get is declared first
+	assert.Equal(t, []string{"create", "get"}, commandNames(userCLI.Command.Commands, ""))
+}
+
+func TestUnit_ServiceOrder(t *testing.T) {
+	newService := func(name string, order int) *protocli.ServiceCLI {
+		return &protocli.ServiceCLI{
+			Command:     &cli.Command{Name: name},
+			ServiceName: name,
+			Order:       order,
+		}
+	}
+
+	tests := []struct {
+		name string
+		opts []protocli.RootOption
+		want []string
+	}{
+		{
+			name: "registration order by default",
+			want: []string{"alpha", "beta", "gamma"},
+		},
+		{
+			name: "annotation order with stable ties",
+			opts: []protocli.RootOption{protocli.Service(newService("zeta", 1))},
+			want: []string{"alpha", "beta", "gamma", "zeta"},
+		},
+		{
+			name: "WithServiceDisplayOrder first, then annotation order",
+			opts: []protocli.RootOption{
+				protocli.Service(newService("first", -1)),
+				protocli.WithServiceDisplayOrder("gamma", "alpha"),
+			},
+			want: []string{"gamma", "alpha", "first", "beta"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]protocli.RootOption{
+				protocli.Service(newService("alpha", 0)),
+				protocli.Service(newService("beta", 0)),
+				protocli.Service(newService("gamma", 0)),
+			}, tt.opts...)
+			rootCmd, err := protocli.RootCommand("testcli", opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, commandNames(rootCmd.Commands, "daemonize"))
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+// DefaultOutputFileMode is the permission used for files created by --output
+// when --output-mode is not set.
+const DefaultOutputFileMode = "0644"
+
+// ErrInvalidFileMode is returned when --output-mode is not an octal permission.
+var ErrInvalidFileMode = errors.New("invalid file mode")
+
+// ErrInvalidOutputPath is returned when a templated --output path cannot be
+// expanded.
+var ErrInvalidOutputPath = errors.New("invalid output path")
+
+// OutputPathData holds the values available to templated output paths, e.g.
+// "~/reports/{{.Service}}/{{.Method}}-{{.Time.Format \"20060102\"}}.{{.Format}}".
+type OutputPathData struct {
+	Service string    // Service command name, e.g. "user-service"
+	Method  string    // Method command name, e.g. "get"
+	Format  string    // Selected output format, e.g. "json"
+	Time    time.Time // When the command ran
+}
+
+// ParseFileMode parses an octal permission string such as "0600" or "644".
+// An empty string yields DefaultOutputFileMode.
+func ParseFileMode(mode string) (os.FileMode, error) {
+	if mode == "" {
+		mode = DefaultOutputFileMode
+	}
+	perm, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || perm > 0o777 {
+		return 0, fmt.Errorf("%w %q: expected octal permission bits such as 0600", ErrInvalidFileMode, mode)
+	}
+	return os.FileMode(perm), nil
+}
+
+// ExpandOutputPath resolves an --output path: template actions are executed
+// with data, a leading "~" is replaced by the home directory, and a path
+// ending in a separator or naming an existing directory gets the file name
+// <Method>.<Format> inside it.
+func ExpandOutputPath(path string, data OutputPathData) (string, error) {
+	if strings.Contains(path, "{{") {
+		tmpl, err := template.New("output").Option("missingkey=error").Parse(path)
+		if err != nil {
+			return "", fmt.Errorf("%w %q: %w", ErrInvalidOutputPath, path, err)
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return "", fmt.Errorf("%w %q: %w", ErrInvalidOutputPath, path, err)
+		}
+		path = b.String()
+	}
+
+	path, err := ExpandHomeDir(path)
+	if err != nil {
+		return "", err
+	}
+
+	if info, err := os.Stat(path); strings.HasSuffix(path, string(filepath.Separator)) || (err == nil && info.IsDir()) {
+		name := data.Method
+		if data.Format != "" {
+			name += "." + data.Format
+		}
+		path = filepath.Join(path, name)
+	}
+	return path, nil
+}
+
+// OutputPath expands the --output path of cmd with ExpandOutputPath, so that
+// defaults from the commands section of the config files can name a file per
+// command. Generated output writers call this before opening the file.
+func OutputPath(cmd *cli.Command, path string) (string, error) {
+	data := OutputPathData{Method: cmd.Name, Time: time.Now()}
+	for _, c := range cmd.Lineage() {
+		if service, ok := c.Metadata[ServiceMetadataKey].(string); ok {
+			data.Service = service
+			break
+		}
+	}
+	if lookupLineageFlag(cmd, "format") != nil {
+		data.Format = cmd.String("format")
+	}
+	return ExpandOutputPath(path, data)
+}
+
+// OpenOutputFile opens path for writing command output. The file is created
+// with the given octal mode (subject to the process umask) if it does not
+// exist; existing files keep their permissions. When appendMode is true output
+// is appended, otherwise the file is truncated.
+func OpenOutputFile(path, mode string, appendMode bool) (*os.File, error) {
+	perm, err := ParseFileMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	flags := os.O_WRONLY | os.O_CREATE
+	if appendMode {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+	}
+	return os.OpenFile(path, flags, perm) //nolint:gosec // path is the user's --output flag
+}
@@ -0,0 +1,81 @@
+package protocli_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc"
+)
+
+func TestUnit_ParseFileMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		want    os.FileMode
+		wantErr bool
+	}{
+		{mode: "", want: 0o644},
+		{mode: "0600", want: 0o600},
+		{mode: "755", want: 0o755},
+		{mode: "0o600", wantErr: true},
+		{mode: "0800", wantErr: true},
+		{mode: "1777", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			got, err := protocli.ParseFileMode(tt.mode)
+			if tt.wantErr {
+				require.ErrorIs(t, err, protocli.ErrInvalidFileMode)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// groupedServiceCLI returns a service whose "ban" command sits in an "admin"
+// group and stores its expanded output path in *path.
+func groupedServiceCLI(path *string) *protocli.ServiceCLI {
+	ban := &cli.Command{
+		Name: "ban",
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			var err error
+			*path, err = protocli.OutputPath(cmd, "{{.Service}}/{{.Method}}.out")
+			return err
+		},
+	}
+	return &protocli.ServiceCLI{
+		Command: &cli.Command{
+			Name:     "user-service",
+			Metadata: map[string]any{protocli.ServiceMetadataKey: "user-service"},
+			Commands: []*cli.Command{{Name: "admin", Commands: []*cli.Command{ban}}},
+		},
+		ServiceName:  "user-service",
+		RegisterFunc: func(*grpc.Server, any) {},
+	}
+}
+
+func TestUnit_OutputPath_ServiceOfGroupedAndHoistedCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []protocli.ServiceRegistrationOption
+		args []string
+	}{
+		{name: "nested", args: []string{"testcli", "user-service", "admin", "ban"}},
+		{name: "hoisted", opts: []protocli.ServiceRegistrationOption{protocli.Hoisted()}, args: []string{"testcli", "admin", "ban"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			root, err := protocli.RootCommand("testcli", protocli.Service(groupedServiceCLI(&path), tt.opts...))
+			require.NoError(t, err)
+			require.NoError(t, root.Run(context.Background(), tt.args))
+			assert.Equal(t, "user-service/ban.out", path)
+		})
+	}
+}
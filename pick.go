@@ -0,0 +1,262 @@
+package protocli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// ErrNoCommandPicked is returned by --pick when input ends before a command is chosen.
+var ErrNoCommandPicked = errors.New("no command picked")
+
+// pickCandidate is a runnable command offered by the --pick selector.
+type pickCandidate struct {
+	path []string // Command names below the root, e.g. ["user-service", "get"]
+	cmd  *cli.Command
+}
+
+func (c pickCandidate) String() string {
+	return strings.Join(c.path, " ")
+}
+
+// pickCandidates lists every visible command under cmd that has no subcommands
+// of its own, other than the help command urfave/cli adds and the validate
+// subcommands, which would match every search for a method too.
+func pickCandidates(cmd *cli.Command, parent []string) []pickCandidate {
+	var all []pickCandidate
+	for _, sub := range cmd.Commands {
+		if sub.Hidden || sub.Name == "help" || sub.Metadata[validateCommandKey] == true {
+			continue
+		}
+		path := append(slices.Clone(parent), sub.Name)
+		if children := pickCandidates(sub, path); len(children) > 0 {
+			all = append(all, children...)
+			continue
+		}
+		if sub.Action != nil {
+			all = append(all, pickCandidate{path: path, cmd: sub})
+		}
+	}
+	return all
+}
+
+// fuzzyScore reports how closely query matches s: the characters of query must
+// appear in s in order, case-insensitively. Lower scores are tighter matches;
+// -1 means no match.
+func fuzzyScore(s, query string) int {
+	s, query = strings.ToLower(s), strings.ToLower(query)
+	if query == "" {
+		return 0
+	}
+	start, qi := -1, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != query[qi] {
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		qi++
+		if qi == len(query) {
+			// Gaps inside the match weigh more than where it starts
+			return (i-start+1-len(query))*len(s) + start
+		}
+	}
+	return -1
+}
+
+// fuzzyFilter returns the candidates matching query, best match first.
+func fuzzyFilter(candidates []pickCandidate, query string) []pickCandidate {
+	type scored struct {
+		pickCandidate
+		score int
+	}
+	var matches []scored
+	for _, c := range candidates {
+		if score := fuzzyScore(c.String(), query); score >= 0 {
+			matches = append(matches, scored{c, score})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b scored) int { return a.score - b.score })
+	out := make([]pickCandidate, len(matches))
+	for i, m := range matches {
+		out[i] = m.pickCandidate
+	}
+	return out
+}
+
+// picker drives the --pick prompts. Prompts go to w (stderr by default) so
+// the picked command's output on stdout can still be piped.
+type picker struct {
+	in *bufio.Reader
+	w  io.Writer
+}
+
+// readLine prompts and returns the next trimmed input line.
+func (p *picker) readLine(prompt string) (string, error) {
+	_, _ = io.WriteString(p.w, prompt)
+	line, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// choose narrows candidates by fuzzy search until a single command is picked.
+func (p *picker) choose(candidates []pickCandidate) (pickCandidate, error) {
+	matches := candidates
+	for {
+		for i, c := range matches {
+			usage := ""
+			if c.cmd.Usage != "" {
+				usage = " - " + c.cmd.Usage
+			}
+			_, _ = fmt.Fprintf(p.w, "  %d) %s%s\n", i+1, c, usage)
+		}
+		answer, err := p.readLine("Pick a command (number, or text to search): ")
+		if errors.Is(err, io.EOF) {
+			return pickCandidate{}, ErrNoCommandPicked
+		}
+		if err != nil {
+			return pickCandidate{}, err
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(matches) {
+			return matches[n-1], nil
+		}
+		if answer == "" {
+			matches = candidates
+			continue
+		}
+
+		found := fuzzyFilter(candidates, answer)
+		switch len(found) {
+		case 0:
+			_, _ = fmt.Fprintf(p.w, "No commands match %q\n", answer)
+			matches = candidates
+		case 1:
+			return found[0], nil
+		default:
+			matches = found
+		}
+	}
+}
+
+// isRequiredFlag reports whether flag must be given.
+func isRequiredFlag(flag cli.Flag) bool {
+	req, ok := flag.(cli.RequiredFlag)
+	return ok && req.IsRequired()
+}
+
+// promptFlags asks for a value for the flags of cmd, required flags first, and
+// returns them as command-line arguments. Empty answers leave a flag unset
+// unless it is required. On generated method commands only required flags and
+// request field flags are asked for; framework flags such as --format or
+// --remote keep their defaults.
+func (p *picker) promptFlags(cmd *cli.Command) ([]string, error) {
+	requestFlags, generated := cmd.Metadata[RequestFlagsMetadataKey].([]string)
+	var required, optional []cli.Flag
+	for _, flag := range cmd.Flags {
+		switch {
+		case isRequiredFlag(flag):
+			required = append(required, flag)
+		case !generated || slices.Contains(requestFlags, flag.Names()[0]):
+			optional = append(optional, flag)
+		}
+	}
+
+	var args []string
+	for _, flag := range append(required, optional...) {
+		name := flag.Names()[0]
+		if vf, ok := flag.(cli.VisibleFlag); (ok && !vf.IsVisible()) || name == "help" {
+			continue
+		}
+		prompt := "--" + name
+		if doc, ok := flag.(cli.DocGenerationFlag); ok {
+			if usage := doc.GetUsage(); usage != "" {
+				prompt += " (" + usage + ")"
+			}
+			if def := doc.GetValue(); def != "" && doc.TakesValue() {
+				prompt += " [" + def + "]"
+			}
+		}
+		_, isBool := flag.(*cli.BoolFlag)
+		if isBool {
+			prompt += " [y/N]"
+		}
+		required := isRequiredFlag(flag)
+
+		for {
+			answer, err := p.readLine(prompt + ": ")
+			if errors.Is(err, io.EOF) {
+				return args, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case answer == "" && required:
+				_, _ = fmt.Fprintf(p.w, "--%s is required\n", name)
+				continue
+			case answer == "":
+			case isBool:
+				if b, _ := strconv.ParseBool(answer); b || strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes") {
+					args = append(args, "--"+name)
+				}
+			default:
+				args = append(args, "--"+name, answer)
+			}
+			break
+		}
+	}
+	return args, nil
+}
+
+// rootContext hides the running commands from ctx so the root can be run
+// again from inside the action of any of its commands.
+type rootContext struct {
+	context.Context
+}
+
+func (c rootContext) Value(key any) any {
+	v := c.Context.Value(key)
+	if _, ok := v.(*cli.Command); ok {
+		return nil
+	}
+	return v
+}
+
+// runPicker lets the user pick a command with fuzzy search, prompts for its
+// flags and runs it. Input is read from the root command's Reader (stdin by
+// default).
+func runPicker(ctx context.Context, root *cli.Command) error {
+	in := root.Reader
+	if in == nil {
+		in = os.Stdin
+	}
+	w := root.ErrWriter
+	if w == nil {
+		w = os.Stderr
+	}
+	p := &picker{in: bufio.NewReader(in), w: w}
+
+	picked, err := p.choose(pickCandidates(root, nil))
+	if err != nil {
+		return err
+	}
+	flagArgs, err := p.promptFlags(picked.cmd)
+	if err != nil {
+		return err
+	}
+
+	args := append(append([]string{root.Name}, picked.path...), flagArgs...)
+	_, _ = fmt.Fprintf(w, "Running: %s\n", shellJoin(args))
+	return root.Run(rootContext{ctx}, args)
+}
@@ -0,0 +1,85 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runPick runs `testcli args...` with a --pick enabled root, feeding script as
+// the terminal input. Returns stdout, the prompts written to stderr and the error.
+func runPick(t *testing.T, script string, args ...string) (string, string, error) {
+	t.Helper()
+	setupTestCLI(t)
+	ctx := context.Background()
+
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(simple.UserServiceCommand(ctx, newMockUserService)),
+		protocli.WithInteractivePrompt(),
+	)
+	require.NoError(t, err)
+
+	var out, prompts bytes.Buffer
+	setWriterOnAllCommands(rootCmd, &out)
+	rootCmd.ErrWriter = &prompts
+	rootCmd.Reader = strings.NewReader(script)
+	err = rootCmd.Run(ctx, append([]string{"testcli"}, args...))
+	return out.String(), prompts.String(), err
+}
+
+func TestIntegration_Pick_FuzzySearchAndPrompts(t *testing.T) {
+	// "usget" only matches "user-service get"; required flags are asked first
+	out, prompts, err := runPick(t, "usget\n7\npostgres://localhost/db\n", "--pick")
+	require.NoError(t, err)
+
+	assert.Contains(t, prompts, "1) user-service create - Create a new user")
+	assert.Contains(t, prompts, "--id (User ID to retrieve)")
+	assert.Less(t, strings.Index(prompts, "--db-url"), strings.Index(prompts, "--include-details"), "required flags come first")
+	assert.Contains(t, prompts, "Running: testcli user-service get --id 7 --db-url postgres://localhost/db\n")
+	assert.Contains(t, out, "id:7")
+	assert.NotContains(t, out, "Pick a command", "prompts stay off stdout")
+}
+
+func TestIntegration_Pick_NumberFromSearchResults(t *testing.T) {
+	// "user" matches both user-service commands; 2 picks the second of those
+	script := "user\n2\n\n42\npostgres://localhost/db\n"
+	out, prompts, err := runPick(t, script, "--pick")
+	require.NoError(t, err)
+
+	assert.Contains(t, prompts, "--id is required")
+	assert.Contains(t, prompts, "Running: testcli user-service get --id 42")
+	assert.Contains(t, out, "id:42")
+}
+
+func TestIntegration_Pick_BoolAndOptionalFlags(t *testing.T) {
+	// Optional request field flags follow in declaration order: include-details,
+	// fields, timeout. Framework flags such as --format are not asked for.
+	script := "usget\n1\npostgres://localhost/db\ny\n\n500\n"
+	out, prompts, err := runPick(t, script, "--pick")
+	require.NoError(t, err)
+
+	assert.Contains(t, prompts, "--include-details (Include detailed user information) [y/N]: ")
+	for _, flag := range []string{"--remote", "--format", "--output-mode", "--get", "--compression", "--show-input"} {
+		assert.NotContains(t, prompts, flag+" (", "framework flag %s is not prompted for", flag)
+	}
+	assert.Contains(t, prompts, "Running: testcli user-service get --id 1 --db-url postgres://localhost/db --include-details --timeout 500\n")
+	assert.Contains(t, out, "id:1")
+}
+
+func TestIntegration_Pick_EndOfInput(t *testing.T) {
+	_, _, err := runPick(t, "", "--pick")
+	require.ErrorIs(t, err, protocli.ErrNoCommandPicked)
+}
+
+func TestIntegration_Pick_WithoutFlagShowsHelp(t *testing.T) {
+	out, _, err := runPick(t, "")
+	require.NoError(t, err)
+	assert.Contains(t, out, "--pick")
+	assert.Contains(t, out, "USAGE:")
+}
@@ -0,0 +1,159 @@
+package protocli
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrRemoteUnreachable is returned by ping when the connection does not
+	// become READY before the timeout.
+	ErrRemoteUnreachable = errors.New("remote unreachable")
+	// ErrRemoteNotServing is returned by ping when the server's health service
+	// reports a status other than SERVING.
+	ErrRemoteNotServing = errors.New("remote not serving")
+)
+
+// defaultPingTimeout bounds how long ping waits for a connection to become READY.
+const defaultPingTimeout = 5 * time.Second
+
+// newPingCommand returns the built-in connectivity check for remote targets.
+func newPingCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "ping",
+		Usage: "Check that a remote gRPC server is reachable",
+		Description: "Dials --remote, waits for the connection to become READY and queries the\n" +
+			"standard gRPC health service when the server provides one.\n" +
+			"Example: mycli ping --remote localhost:50051",
+		Flags: remoteCheckFlags(true),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			creds, err := pingCredentials(cmd)
+			if err != nil {
+				return err
+			}
+			return ping(ctx, cmd, cmd.String("remote"), cmd.Duration("timeout"), creds)
+		},
+	}
+}
+
+// remoteCheckFlags returns the --remote, --timeout and --tls* flags shared by
+// ping and doctor.
+func remoteCheckFlags(remoteRequired bool) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "remote",
+			Usage:    "Remote gRPC server address (host:port)",
+			Required: remoteRequired,
+		},
+		&cli.DurationFlag{
+			Name:  "timeout",
+			Value: defaultPingTimeout,
+			Usage: "How long to wait for the connection to become ready",
+		},
+		&cli.BoolFlag{
+			Name:  "tls",
+			Usage: "Connect with TLS instead of plaintext",
+		},
+		&cli.StringFlag{
+			Name:  "tls-ca-file",
+			Usage: "PEM file of CA certificates used to verify the server (implies --tls)",
+		},
+		&cli.StringFlag{
+			Name:  "tls-server-name",
+			Usage: "Override the server name used to verify the certificate (implies --tls)",
+		},
+	}
+}
+
+// pingCredentials builds transport credentials from the --tls* flags.
+func pingCredentials(cmd *cli.Command) (credentials.TransportCredentials, error) {
+	caFile := cmd.String("tls-ca-file")
+	serverName := cmd.String("tls-server-name")
+	if !cmd.Bool("tls") && caFile == "" && serverName == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile) //nolint:gosec // path is the user's --tls-ca-file flag
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --tls-ca-file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// pingResult is what checkRemote measured against a ready remote.
+type pingResult struct {
+	connectLatency time.Duration
+	health         string // e.g. "SERVING in 1.2ms" or "no health service"
+}
+
+// ping dials addr and waits for READY, then reports latency and health status.
+func ping(ctx context.Context, cmd *cli.Command, addr string, timeout time.Duration, creds credentials.TransportCredentials) error {
+	result, err := checkRemote(ctx, addr, timeout, creds)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(cmd.Root().Writer, "%s: READY in %s (health: %s)\n",
+		addr, result.connectLatency.Round(time.Microsecond), result.health)
+	return err
+}
+
+// checkRemote dials addr, waits for READY and queries the standard health
+// service when the server provides one.
+func checkRemote(ctx context.Context, addr string, timeout time.Duration, creds credentials.TransportCredentials) (pingResult, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return pingResult{}, fmt.Errorf("failed to connect to remote %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	conn.Connect()
+	for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+		if !conn.WaitForStateChange(ctx, state) {
+			return pingResult{}, fmt.Errorf("%w: %s did not become ready within %s (last state %s)",
+				ErrRemoteUnreachable, addr, timeout, state)
+		}
+	}
+	result := pingResult{connectLatency: time.Since(start), health: "no health service"}
+
+	start = time.Now()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+	case err != nil:
+		return pingResult{}, fmt.Errorf("health check against %s failed: %w", addr, err)
+	case resp.GetStatus() != healthpb.HealthCheckResponse_SERVING:
+		return pingResult{}, fmt.Errorf("%w: %s reports %s", ErrRemoteNotServing, addr, resp.GetStatus())
+	default:
+		result.health = fmt.Sprintf("%s in %s", resp.GetStatus(), time.Since(start).Round(time.Microsecond))
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func startPingServer(t *testing.T, healthServer *health.Server) string {
+	t.Helper()
+	lis, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	if healthServer != nil {
+		healthpb.RegisterHealthServer(server, healthServer)
+	}
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func runPing(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	rootCmd, err := protocli.RootCommand("testcli")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	rootCmd.Writer = &buf
+	err = rootCmd.Run(context.Background(), append([]string{"testcli", "ping"}, args...))
+	return buf.String(), err
+}
+
+func TestIntegration_Ping_Healthy(t *testing.T) {
+	addr := startPingServer(t, health.NewServer())
+
+	out, err := runPing(t, "--remote", addr)
+	require.NoError(t, err)
+	assert.Contains(t, out, addr+": READY in ")
+	assert.Contains(t, out, "health: SERVING")
+}
+
+func TestIntegration_Ping_NoHealthService(t *testing.T) {
+	addr := startPingServer(t, nil)
+
+	out, err := runPing(t, "--remote", addr)
+	require.NoError(t, err)
+	assert.Contains(t, out, "health: no health service")
+}
+
+func TestIntegration_Ping_NotServing(t *testing.T) {
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	addr := startPingServer(t, healthServer)
+
+	_, err := runPing(t, "--remote", addr)
+	require.ErrorIs(t, err, protocli.ErrRemoteNotServing)
+}
+
+func TestIntegration_Ping_DeadAddress(t *testing.T) {
+	// Reserve a port and release it so nothing is listening there
+	lis, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	out, err := runPing(t, "--remote", addr, "--timeout", "300ms")
+	require.ErrorIs(t, err, protocli.ErrRemoteUnreachable)
+	assert.Empty(t, out)
+}
@@ -0,0 +1,76 @@
+package protocli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// httpReadHeaderTimeout bounds how long the daemon's HTTP servers wait for request headers.
+const httpReadHeaderTimeout = 10 * time.Second
+
+// ReadinessCheck reports whether a dependency the daemon relies on is ready.
+// A non-nil error makes /readyz respond 503 with the error message.
+type ReadinessCheck func(ctx context.Context) error
+
+// livezHandler reports that the daemon process is up.
+func livezHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, "ok")
+	}
+}
+
+// readyzHandler reports whether the gRPC health status is SERVING and all
+// readiness checks pass.
+func readyzHandler(healthServer *health.Server, checks []ReadinessCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := checkReady(r.Context(), healthServer, checks); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprintf(w, "not ready: %v\n", err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, "ok")
+	}
+}
+
+func checkReady(ctx context.Context, healthServer *health.Server, checks []ReadinessCheck) error {
+	resp, err := healthServer.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("gRPC health status is %s", resp.GetStatus())
+	}
+	for _, check := range checks {
+		if err := check(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serveHTTP serves handler on address in the background and returns the server
+// so the caller can shut it down.
+func serveHTTP(ctx context.Context, address string, handler http.Handler) (*http.Server, error) {
+	lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: httpReadHeaderTimeout}
+	go func() {
+		if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("HTTP server error", "address", address, "error", err)
+		}
+	}()
+	return server, nil
+}
@@ -0,0 +1,96 @@
+package protocli_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getProbe issues a GET against url and returns the status code and body.
+func getProbe(t *testing.T, url string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+// startProbedDaemon runs the daemon with probes enabled until the test ends.
+func startProbedDaemon(t *testing.T, args []string, opts ...protocli.RootOption) {
+	t.Helper()
+	preventExit(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	readyCh := make(chan struct{})
+	opts = append(opts,
+		protocli.Service(simple.UserServiceCommand(ctx, newUserService)),
+		protocli.OnDaemonReady(func(context.Context) { close(readyCh) }),
+		protocli.WithGracefulShutdownTimeout(2*time.Second),
+	)
+	rootCmd, err := protocli.RootCommand("testcli", opts...)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = rootCmd.Run(ctx, append([]string{"testcli", "daemonize"}, args...))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		waitForDone(t, done)
+	})
+	waitForReady(t, readyCh)
+}
+
+// TestIntegration_Probes_ReadyzTracksReadiness verifies /readyz follows readiness
+// checks while /livez stays healthy.
+func TestIntegration_Probes_ReadyzTracksReadiness(t *testing.T) {
+	var notReady atomic.Bool
+	check := func(context.Context) error {
+		if notReady.Load() {
+			return errors.New("database unavailable")
+		}
+		return nil
+	}
+
+	startProbedDaemon(t, []string{"--port", "50204", "--probe-port", "50205"}, protocli.WithProbes(check))
+
+	code, _ := getProbe(t, "http://127.0.0.1:50205/livez")
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = getProbe(t, "http://127.0.0.1:50205/readyz")
+	assert.Equal(t, http.StatusOK, code)
+
+	notReady.Store(true)
+	code, body := getProbe(t, "http://127.0.0.1:50205/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, body, "database unavailable")
+	code, _ = getProbe(t, "http://127.0.0.1:50205/livez")
+	assert.Equal(t, http.StatusOK, code, "liveness is independent of readiness")
+
+	notReady.Store(false)
+	code, _ = getProbe(t, "http://127.0.0.1:50205/readyz")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+// TestIntegration_Probes_OnGatewayMux verifies probes are mounted on the gateway
+// HTTP server when transcoding is enabled.
+func TestIntegration_Probes_OnGatewayMux(t *testing.T) {
+	startProbedDaemon(t, []string{"--port", "50206"}, protocli.WithProbes(), protocli.WithTranscoding(50207))
+
+	code, body := getProbe(t, "http://127.0.0.1:50207/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok\n", body)
+}
@@ -0,0 +1,247 @@
+package protocli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ProgressReporter receives progress updates for long-running streaming commands.
+// Generated streaming actions feed the reporter registered via WithProgress when
+// the method is annotated with progress_total_field.
+type ProgressReporter interface {
+	// Start is called once with the expected total number of messages.
+	Start(total int64)
+	// Update is called after each received message with the running count.
+	Update(current int64)
+	// Finish is called once when the stream ends (successfully or not).
+	Finish()
+}
+
+// progressReporterKey is the Metadata key used to store the progress reporter on the root command.
+const progressReporterKey = "protocli:progressReporter"
+
+// defaultProgressBarWidth is the number of cells used by TextProgressBar when no width is given.
+const defaultProgressBarWidth = 40
+
+// progressFraction returns current/total clamped to [0, 1].
+// A non-positive total yields 0 since progress is unknown.
+func progressFraction(current, total int64) float64 {
+	if total <= 0 || current <= 0 {
+		return 0
+	}
+	if current >= total {
+		return 1
+	}
+	return float64(current) / float64(total)
+}
+
+// progressPercent returns the whole-number percentage for current/total, rounded down.
+func progressPercent(current, total int64) int {
+	return int(progressFraction(current, total) * 100)
+}
+
+// renderProgressBar renders a single-line textual progress bar.
+// Example: renderProgressBar(3, 10, 10) → "[===       ]  30% (3/10)".
+func renderProgressBar(current, total int64, width int) string {
+	if width <= 0 {
+		width = defaultProgressBarWidth
+	}
+	filled := int(progressFraction(current, total) * float64(width))
+	return fmt.Sprintf("[%s%s] %3d%% (%d/%d)",
+		strings.Repeat("=", filled),
+		strings.Repeat(" ", width-filled),
+		progressPercent(current, total),
+		current,
+		total,
+	)
+}
+
+// isTerminal reports whether v, typically a writer or stdin, is a character
+// device (i.e. an interactive terminal). Writers that wrap another, such as
+// StreamOutput, are unwrapped first.
+func isTerminal(v any) bool {
+	for {
+		wrapper, ok := v.(interface{ Unwrap() io.Writer })
+		if !ok {
+			break
+		}
+		v = wrapper.Unwrap()
+	}
+	f, ok := v.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// TextProgressBar is a ProgressReporter that redraws a textual progress bar in place.
+// Rendering is suppressed when the writer is not a terminal, so piping or
+// redirecting stderr never produces carriage-return noise.
+type TextProgressBar struct {
+	w       io.Writer
+	width   int
+	enabled bool
+	total   int64
+	mu      sync.Mutex
+}
+
+var _ ProgressReporter = (*TextProgressBar)(nil)
+
+// NewTextProgressBar creates a progress bar that writes to w.
+// If w is not a terminal, the bar renders nothing.
+func NewTextProgressBar(w io.Writer) *TextProgressBar {
+	return &TextProgressBar{
+		w:       w,
+		width:   defaultProgressBarWidth,
+		enabled: isTerminal(w),
+	}
+}
+
+// StderrProgressBar creates a TTY-aware progress bar that writes to stderr.
+func StderrProgressBar() *TextProgressBar {
+	return NewTextProgressBar(os.Stderr)
+}
+
+// Start records the total and draws an empty bar.
+func (b *TextProgressBar) Start(total int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.total = total
+	b.draw(0)
+}
+
+// Update redraws the bar for the given count.
+func (b *TextProgressBar) Update(current int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.draw(current)
+}
+
+// Finish clears the bar so subsequent output starts on a clean line.
+func (b *TextProgressBar) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.enabled {
+		return
+	}
+	_, _ = fmt.Fprint(b.w, "\r\033[K")
+}
+
+func (b *TextProgressBar) draw(current int64) {
+	if !b.enabled {
+		return
+	}
+	_, _ = fmt.Fprintf(b.w, "\r%s", renderProgressBar(current, b.total, b.width))
+}
+
+// StreamProgress tracks messages received by a generated streaming command and
+// forwards progress to the root command's ProgressReporter.
+// A nil *StreamProgress is valid and does nothing.
+type StreamProgress struct {
+	reporter   ProgressReporter
+	totalField protoreflect.Name
+	count      int64
+	started    bool
+}
+
+// NewStreamProgress returns a tracker for a streaming command whose first message
+// carries the expected total in totalField (proto field name).
+// Returns nil if no reporter was registered via WithProgress.
+func NewStreamProgress(cmd *cli.Command, totalField string) *StreamProgress {
+	reporter, ok := cmd.Root().Metadata[progressReporterKey].(ProgressReporter)
+	if !ok || reporter == nil {
+		return nil
+	}
+	return &StreamProgress{
+		reporter:   reporter,
+		totalField: protoreflect.Name(totalField),
+	}
+}
+
+// Observe records a received message. The first message is inspected for the
+// total; if the total is missing or zero, progress is not reported.
+func (p *StreamProgress) Observe(msg proto.Message) {
+	if p == nil {
+		return
+	}
+	if p.count == 0 {
+		total := progressTotal(msg, p.totalField)
+		if total <= 0 {
+			p.reporter = nil
+		} else {
+			p.reporter.Start(total)
+			p.started = true
+		}
+	}
+	p.count++
+	if p.started {
+		p.reporter.Update(p.count)
+	}
+}
+
+// Finish signals the end of the stream to the reporter.
+func (p *StreamProgress) Finish() {
+	if p == nil || !p.started {
+		return
+	}
+	p.reporter.Finish()
+}
+
+// progressTotal reads an integer total from the named field of msg.
+// Returns 0 if the field does not exist or is not an integer.
+func progressTotal(msg proto.Message, name protoreflect.Name) int64 {
+	if msg == nil {
+		return 0
+	}
+	m := msg.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(name)
+	if fd == nil || fd.IsList() || fd.IsMap() {
+		return 0
+	}
+	total, _ := integerValue(fd, m.Get(fd))
+	return total
+}
+
+// integerValue returns v as an int64 if fd is an integer field.
+func integerValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (int64, bool) {
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int(), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(v.Uint()), true //nolint:gosec // values beyond int64 are not meaningful
+	default:
+		return 0, false
+	}
+}
+
+// WriteStreamSummary reports how many messages a streaming command received
+// and how long the stream took when --stream-summary is set, e.g.
+// "42 messages in 1.3s". The summary goes to stderr so piped output stays clean.
+func WriteStreamSummary(cmd *cli.Command, count int, start time.Time) {
+	if !cmd.Bool("stream-summary") {
+		return
+	}
+	w := cmd.Root().ErrWriter
+	if w == nil {
+		w = os.Stderr
+	}
+	noun := "messages"
+	if count == 1 {
+		noun = "message"
+	}
+	_, _ = fmt.Fprintf(w, "%d %s in %s\n", count, noun, time.Since(start).Round(time.Millisecond))
+}
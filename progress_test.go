@@ -0,0 +1,74 @@
+package protocli
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestUnit_ProgressFraction(t *testing.T) {
+	tests := []struct {
+		name           string
+		current, total int64
+		want           float64
+	}{
+		{name: "zero of ten", current: 0, total: 10, want: 0},
+		{name: "half", current: 5, total: 10, want: 0.5},
+		{name: "complete", current: 10, total: 10, want: 1},
+		{name: "overshoot clamps to one", current: 12, total: 10, want: 1},
+		{name: "negative current clamps to zero", current: -1, total: 10, want: 0},
+		{name: "unknown total", current: 3, total: 0, want: 0},
+		{name: "negative total", current: 3, total: -5, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, progressFraction(tt.current, tt.total), 1e-9)
+		})
+	}
+}
+
+func TestUnit_ProgressPercent_RoundsDown(t *testing.T) {
+	assert.Equal(t, 33, progressPercent(1, 3))
+	assert.Equal(t, 66, progressPercent(2, 3))
+	assert.Equal(t, 100, progressPercent(3, 3))
+}
+
+func TestUnit_RenderProgressBar(t *testing.T) {
+	assert.Equal(t, "[          ]   0% (0/10)", renderProgressBar(0, 10, 10))
+	assert.Equal(t, "[===       ]  30% (3/10)", renderProgressBar(3, 10, 10))
+	assert.Equal(t, "[==========] 100% (10/10)", renderProgressBar(10, 10, 10))
+	assert.Equal(t, "[==========] 100% (11/10)", renderProgressBar(11, 10, 10))
+}
+
+func TestUnit_TextProgressBar_NonTerminalIsSilent(t *testing.T) {
+	var buf bytes.Buffer
+	bar := NewTextProgressBar(&buf)
+	bar.Start(3)
+	bar.Update(1)
+	bar.Finish()
+	assert.Empty(t, buf.String())
+}
+
+// TestUnit_IsTerminal_Unwraps tests that a buffered StreamOutput reports the
+// writer it wraps. The null device stands in for a terminal, as both are
+// character devices.
+func TestUnit_IsTerminal_Unwraps(t *testing.T) {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	defer devNull.Close()
+
+	assert.True(t, isTerminal(devNull))
+	assert.True(t, isTerminal(&StreamOutput{w: devNull}))
+	assert.False(t, isTerminal(&StreamOutput{w: &bytes.Buffer{}}))
+}
+
+func TestUnit_ProgressTotal(t *testing.T) {
+	assert.Equal(t, int64(7), progressTotal(wrapperspb.Int32(7), "value"))
+	assert.Equal(t, int64(9), progressTotal(wrapperspb.UInt64(9), "value"))
+	assert.Equal(t, int64(0), progressTotal(wrapperspb.String("7"), "value"))
+	assert.Equal(t, int64(0), progressTotal(wrapperspb.Int32(7), "missing"))
+}
@@ -22,6 +22,69 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Value type of a root-level flag
+type RootFlagType int32
+
+const (
+	// Treated as a string flag
+	RootFlagType_ROOT_FLAG_TYPE_UNSPECIFIED  RootFlagType = 0
+	RootFlagType_ROOT_FLAG_TYPE_STRING       RootFlagType = 1
+	RootFlagType_ROOT_FLAG_TYPE_BOOL         RootFlagType = 2
+	RootFlagType_ROOT_FLAG_TYPE_INT          RootFlagType = 3
+	RootFlagType_ROOT_FLAG_TYPE_FLOAT        RootFlagType = 4
+	RootFlagType_ROOT_FLAG_TYPE_DURATION     RootFlagType = 5
+	RootFlagType_ROOT_FLAG_TYPE_STRING_SLICE RootFlagType = 6
+)
+
+// Enum value maps for RootFlagType.
+var (
+	RootFlagType_name = map[int32]string{
+		0: "ROOT_FLAG_TYPE_UNSPECIFIED",
+		1: "ROOT_FLAG_TYPE_STRING",
+		2: "ROOT_FLAG_TYPE_BOOL",
+		3: "ROOT_FLAG_TYPE_INT",
+		4: "ROOT_FLAG_TYPE_FLOAT",
+		5: "ROOT_FLAG_TYPE_DURATION",
+		6: "ROOT_FLAG_TYPE_STRING_SLICE",
+	}
+	RootFlagType_value = map[string]int32{
+		"ROOT_FLAG_TYPE_UNSPECIFIED":  0,
+		"ROOT_FLAG_TYPE_STRING":       1,
+		"ROOT_FLAG_TYPE_BOOL":         2,
+		"ROOT_FLAG_TYPE_INT":          3,
+		"ROOT_FLAG_TYPE_FLOAT":        4,
+		"ROOT_FLAG_TYPE_DURATION":     5,
+		"ROOT_FLAG_TYPE_STRING_SLICE": 6,
+	}
+)
+
+func (x RootFlagType) Enum() *RootFlagType {
+	p := new(RootFlagType)
+	*p = x
+	return p
+}
+
+func (x RootFlagType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RootFlagType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_cli_v1_cli_proto_enumTypes[0].Descriptor()
+}
+
+func (RootFlagType) Type() protoreflect.EnumType {
+	return &file_proto_cli_v1_cli_proto_enumTypes[0]
+}
+
+func (x RootFlagType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RootFlagType.Descriptor instead.
+func (RootFlagType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{0}
+}
+
 // TUI-specific options for an RPC method command.
 type TUICommandOptions struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -156,8 +219,65 @@ type CommandOptions struct {
 	// Note: the method is still available on the gRPC server if the service is
 	// registered; local_only only affects the generated CLI layer.
 	LocalOnly bool `protobuf:"varint,6,opt,name=local_only,json=localOnly,proto3" json:"local_only,omitempty"`
+	// For server-streaming methods: name of an integer field in the response
+	// message that carries the expected total number of messages. The first
+	// streamed message is inspected for the total, and progress is reported to
+	// the reporter registered with protocli.WithProgress.
+	ProgressTotalField string `protobuf:"bytes,7,opt,name=progress_total_field,json=progressTotalField,proto3" json:"progress_total_field,omitempty"`
+	// When true, the command is omitted from --help output and command lists
+	// but can still be invoked by name (useful for internal/debug commands).
+	Hidden bool `protobuf:"varint,8,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	// Marks the command as deprecated. The message (e.g. "use get-user instead")
+	// is shown in help text and logged as a warning once per run when the
+	// command is invoked.
+	Deprecated string `protobuf:"bytes,9,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
 	// TUI-specific overrides for this command.
-	Tui           *TUICommandOptions `protobuf:"bytes,10,opt,name=tui,proto3" json:"tui,omitempty"`
+	Tui *TUICommandOptions `protobuf:"bytes,10,opt,name=tui,proto3" json:"tui,omitempty"`
+	// For server-streaming methods: dot-separated path of a response field that
+	// carries a resume token (e.g. "cursor" or "item.id"). When --reconnect
+	// re-establishes a dropped stream, the last token received is copied into
+	// resume_request_field so the server can continue where it left off.
+	ResumeTokenField string `protobuf:"bytes,11,opt,name=resume_token_field,json=resumeTokenField,proto3" json:"resume_token_field,omitempty"`
+	// Request field that receives the resume token on reconnect.
+	// Defaults to resume_token_field.
+	ResumeRequestField string `protobuf:"bytes,12,opt,name=resume_request_field,json=resumeRequestField,proto3" json:"resume_request_field,omitempty"`
+	// Alternative names the command can be invoked by, e.g. ["g"] so that
+	// "mycli users g" runs "mycli users get". Aliases that repeat another
+	// command's name or alias in the same service are ignored with a warning.
+	Aliases []string `protobuf:"bytes,13,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// Position of the command in help output and the TUI method list. Commands
+	// are sorted by ascending order; ties keep declaration order.
+	Order int32 `protobuf:"varint,14,opt,name=order,proto3" json:"order,omitempty"`
+	// For unary methods: dot-separated path of an integer response field whose
+	// value becomes the process exit code once the response has been written,
+	// e.g. "status_code" for checks that report 0 (ok) through 3 (unknown).
+	// A value of 0 exits normally.
+	ExitCodeField string `protobuf:"bytes,15,opt,name=exit_code_field,json=exitCodeField,proto3" json:"exit_code_field,omitempty"`
+	// When true, the command asks "Are you sure? [y/N]" on stdin before
+	// running, e.g. for destructive methods. --yes skips the prompt and is
+	// required when stdin is not a terminal.
+	Confirm bool `protobuf:"varint,16,opt,name=confirm,proto3" json:"confirm,omitempty"`
+	// Name of a repeated string request field that receives the command's
+	// positional arguments instead of rejecting them, e.g. "args" so that
+	// "mycli run -- ls -la" sets args to ["ls", "-la"]. Arguments after "--"
+	// are never parsed as flags.
+	Passthrough string `protobuf:"bytes,17,opt,name=passthrough,proto3" json:"passthrough,omitempty"`
+	// Flags that need another flag, e.g. {flag: "start-date", other: "end-date"}
+	// rejects --start-date unless --end-date is also set.
+	Requires []*FlagPair `protobuf:"bytes,18,rep,name=requires,proto3" json:"requires,omitempty"`
+	// Flags that can't be set together, e.g. {flag: "id", other: "email"}
+	// rejects passing both --id and --email.
+	Conflicts []*FlagPair `protobuf:"bytes,19,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// For server-streaming methods: dot-separated path of a response field that
+	// carries the message's time, either a google.protobuf.Timestamp, an integer
+	// of Unix seconds or an RFC 3339 string. Adds --since and --until flags that
+	// drop messages outside the time window before they are written.
+	TimestampField string `protobuf:"bytes,20,opt,name=timestamp_field,json=timestampField,proto3" json:"timestamp_field,omitempty"`
+	// Nests the command under a group subcommand of its service, e.g. "admin"
+	// so that "mycli user admin reset-password" runs ResetPassword. Methods
+	// with the same group share one group command. A group named like another
+	// command of the service is reported and ignored.
+	Group         string `protobuf:"bytes,21,opt,name=group,proto3" json:"group,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -234,6 +354,27 @@ func (x *CommandOptions) GetLocalOnly() bool {
 	return false
 }
 
+func (x *CommandOptions) GetProgressTotalField() string {
+	if x != nil {
+		return x.ProgressTotalField
+	}
+	return ""
+}
+
+func (x *CommandOptions) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+func (x *CommandOptions) GetDeprecated() string {
+	if x != nil {
+		return x.Deprecated
+	}
+	return ""
+}
+
 func (x *CommandOptions) GetTui() *TUICommandOptions {
 	if x != nil {
 		return x.Tui
@@ -241,6 +382,137 @@ func (x *CommandOptions) GetTui() *TUICommandOptions {
 	return nil
 }
 
+func (x *CommandOptions) GetResumeTokenField() string {
+	if x != nil {
+		return x.ResumeTokenField
+	}
+	return ""
+}
+
+func (x *CommandOptions) GetResumeRequestField() string {
+	if x != nil {
+		return x.ResumeRequestField
+	}
+	return ""
+}
+
+func (x *CommandOptions) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *CommandOptions) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *CommandOptions) GetExitCodeField() string {
+	if x != nil {
+		return x.ExitCodeField
+	}
+	return ""
+}
+
+func (x *CommandOptions) GetConfirm() bool {
+	if x != nil {
+		return x.Confirm
+	}
+	return false
+}
+
+func (x *CommandOptions) GetPassthrough() string {
+	if x != nil {
+		return x.Passthrough
+	}
+	return ""
+}
+
+func (x *CommandOptions) GetRequires() []*FlagPair {
+	if x != nil {
+		return x.Requires
+	}
+	return nil
+}
+
+func (x *CommandOptions) GetConflicts() []*FlagPair {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+func (x *CommandOptions) GetTimestampField() string {
+	if x != nil {
+		return x.TimestampField
+	}
+	return ""
+}
+
+func (x *CommandOptions) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+// A pair of request flags, by flag name, for the requires and conflicts
+// command rules.
+type FlagPair struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Flag          string                 `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	Other         string                 `protobuf:"bytes,2,opt,name=other,proto3" json:"other,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlagPair) Reset() {
+	*x = FlagPair{}
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlagPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlagPair) ProtoMessage() {}
+
+func (x *FlagPair) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlagPair.ProtoReflect.Descriptor instead.
+func (*FlagPair) Descriptor() ([]byte, []int) {
+	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FlagPair) GetFlag() string {
+	if x != nil {
+		return x.Flag
+	}
+	return ""
+}
+
+func (x *FlagPair) GetOther() string {
+	if x != nil {
+		return x.Other
+	}
+	return ""
+}
+
 // CLI flag annotation for message fields
 // Maps message fields to CLI flags
 type FlagOptions struct {
@@ -255,19 +527,42 @@ type FlagOptions struct {
 	// Long-form description for detailed documentation
 	// Used in config file comments, extended help text, and generated docs
 	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	// Marks the flag as deprecated. The message (e.g. "use --name instead") is
+	// shown in help text and logged as a warning once per run when the flag is set.
+	Deprecated string `protobuf:"bytes,7,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// Regular expression the value must match (Go RE2 syntax). Applies to
+	// string fields; for repeated fields every element is checked.
+	Pattern string `protobuf:"bytes,8,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Allowed values for a string field, e.g. ["json", "yaml"].
+	OneOf []string `protobuf:"bytes,9,rep,name=one_of,json=oneOf,proto3" json:"one_of,omitempty"`
+	// Inclusive numeric bounds for integer and floating point fields.
+	Min *float64 `protobuf:"fixed64,11,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max *float64 `protobuf:"fixed64,13,opt,name=max,proto3,oneof" json:"max,omitempty"`
 	// TUI-specific overrides for this field.
 	Tui *TUIFlagOptions `protobuf:"bytes,10,opt,name=tui,proto3" json:"tui,omitempty"`
 	// Default value for this flag, as a string.
 	// Parsed to the appropriate Go type at code-generation time for CLI flags,
 	// and used directly to pre-populate TUI form fields.
-	DefaultValue  string `protobuf:"bytes,12,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	DefaultValue string `protobuf:"bytes,12,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Generator that fills the field when the flag is not given, e.g. "uuid",
+	// "ulid" or "timestamp". Applies to string fields. Additional generators
+	// can be registered at runtime with protocli.RegisterDefaultGenerator.
+	DefaultGenerator string `protobuf:"bytes,14,opt,name=default_generator,json=defaultGenerator,proto3" json:"default_generator,omitempty"`
+	// Accept "@path" to read a string field's value from a file and "@-" to read
+	// it from stdin (trimmed). "@@" escapes a literal leading "@". Generating
+	// with file_flags=true enables this for every string field.
+	AllowFile bool `protobuf:"varint,15,opt,name=allow_file,json=allowFile,proto3" json:"allow_file,omitempty"`
+	// Message that the paths of a google.protobuf.FieldMask field are checked
+	// against, by full name (e.g. "example.User"). Without it, paths are accepted
+	// as given.
+	FieldMaskTarget string `protobuf:"bytes,16,opt,name=field_mask_target,json=fieldMaskTarget,proto3" json:"field_mask_target,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *FlagOptions) Reset() {
 	*x = FlagOptions{}
-	mi := &file_proto_cli_v1_cli_proto_msgTypes[3]
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -279,7 +574,7 @@ func (x *FlagOptions) String() string {
 func (*FlagOptions) ProtoMessage() {}
 
 func (x *FlagOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cli_v1_cli_proto_msgTypes[3]
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -292,7 +587,7 @@ func (x *FlagOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlagOptions.ProtoReflect.Descriptor instead.
 func (*FlagOptions) Descriptor() ([]byte, []int) {
-	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{3}
+	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *FlagOptions) GetName() string {
@@ -337,6 +632,41 @@ func (x *FlagOptions) GetDescription() string {
 	return ""
 }
 
+func (x *FlagOptions) GetDeprecated() string {
+	if x != nil {
+		return x.Deprecated
+	}
+	return ""
+}
+
+func (x *FlagOptions) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *FlagOptions) GetOneOf() []string {
+	if x != nil {
+		return x.OneOf
+	}
+	return nil
+}
+
+func (x *FlagOptions) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *FlagOptions) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
 func (x *FlagOptions) GetTui() *TUIFlagOptions {
 	if x != nil {
 		return x.Tui
@@ -351,6 +681,27 @@ func (x *FlagOptions) GetDefaultValue() string {
 	return ""
 }
 
+func (x *FlagOptions) GetDefaultGenerator() string {
+	if x != nil {
+		return x.DefaultGenerator
+	}
+	return ""
+}
+
+func (x *FlagOptions) GetAllowFile() bool {
+	if x != nil {
+		return x.AllowFile
+	}
+	return false
+}
+
+func (x *FlagOptions) GetFieldMaskTarget() string {
+	if x != nil {
+		return x.FieldMaskTarget
+	}
+	return ""
+}
+
 // TUI-specific options for a service.
 // The presence of this message on a service enables it in the interactive TUI.
 // Set to {} to enable with all defaults, or set name to customize the display name.
@@ -365,7 +716,7 @@ type TUIServiceOptions struct {
 
 func (x *TUIServiceOptions) Reset() {
 	*x = TUIServiceOptions{}
-	mi := &file_proto_cli_v1_cli_proto_msgTypes[4]
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -377,7 +728,7 @@ func (x *TUIServiceOptions) String() string {
 func (*TUIServiceOptions) ProtoMessage() {}
 
 func (x *TUIServiceOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cli_v1_cli_proto_msgTypes[4]
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -390,7 +741,7 @@ func (x *TUIServiceOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TUIServiceOptions.ProtoReflect.Descriptor instead.
 func (*TUIServiceOptions) Descriptor() ([]byte, []int) {
-	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{4}
+	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TUIServiceOptions) GetName() string {
@@ -416,6 +767,14 @@ type ServiceOptions struct {
 	UsageText string `protobuf:"bytes,4,opt,name=usage_text,json=usageText,proto3" json:"usage_text,omitempty"`
 	// Description of arguments this service accepts
 	ArgsUsage string `protobuf:"bytes,5,opt,name=args_usage,json=argsUsage,proto3" json:"args_usage,omitempty"`
+	// Alternative names for the service command, e.g. ["u"] so that "mycli u get"
+	// runs "mycli user-service get". RootCommand rejects aliases that collide
+	// with another top-level command.
+	Aliases []string `protobuf:"bytes,6,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// Position of the service among the root command's services. Services are
+	// sorted by ascending order; ties keep registration order. Overridden by
+	// protocli.WithServiceDisplayOrder.
+	Order int32 `protobuf:"varint,7,opt,name=order,proto3" json:"order,omitempty"`
 	// TUI-specific options. Presence of this field includes the service in the
 	// interactive TUI. Use {} to enable with defaults, or set name to customize
 	// the display name shown in tab bars and headings.
@@ -426,7 +785,7 @@ type ServiceOptions struct {
 
 func (x *ServiceOptions) Reset() {
 	*x = ServiceOptions{}
-	mi := &file_proto_cli_v1_cli_proto_msgTypes[5]
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -438,7 +797,7 @@ func (x *ServiceOptions) String() string {
 func (*ServiceOptions) ProtoMessage() {}
 
 func (x *ServiceOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cli_v1_cli_proto_msgTypes[5]
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,7 +810,7 @@ func (x *ServiceOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceOptions.ProtoReflect.Descriptor instead.
 func (*ServiceOptions) Descriptor() ([]byte, []int) {
-	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{5}
+	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ServiceOptions) GetName() string {
@@ -489,6 +848,20 @@ func (x *ServiceOptions) GetArgsUsage() string {
 	return ""
 }
 
+func (x *ServiceOptions) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *ServiceOptions) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
 func (x *ServiceOptions) GetTui() *TUIServiceOptions {
 	if x != nil {
 		return x.Tui
@@ -508,7 +881,7 @@ type ServiceConfigOptions struct {
 
 func (x *ServiceConfigOptions) Reset() {
 	*x = ServiceConfigOptions{}
-	mi := &file_proto_cli_v1_cli_proto_msgTypes[6]
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -520,7 +893,7 @@ func (x *ServiceConfigOptions) String() string {
 func (*ServiceConfigOptions) ProtoMessage() {}
 
 func (x *ServiceConfigOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cli_v1_cli_proto_msgTypes[6]
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,7 +906,7 @@ func (x *ServiceConfigOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceConfigOptions.ProtoReflect.Descriptor instead.
 func (*ServiceConfigOptions) Descriptor() ([]byte, []int) {
-	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{6}
+	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ServiceConfigOptions) GetConfigMessage() string {
@@ -556,7 +929,7 @@ type EnumValueOptions struct {
 
 func (x *EnumValueOptions) Reset() {
 	*x = EnumValueOptions{}
-	mi := &file_proto_cli_v1_cli_proto_msgTypes[7]
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -568,7 +941,7 @@ func (x *EnumValueOptions) String() string {
 func (*EnumValueOptions) ProtoMessage() {}
 
 func (x *EnumValueOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cli_v1_cli_proto_msgTypes[7]
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,7 +954,7 @@ func (x *EnumValueOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EnumValueOptions.ProtoReflect.Descriptor instead.
 func (*EnumValueOptions) Descriptor() ([]byte, []int) {
-	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{7}
+	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *EnumValueOptions) GetName() string {
@@ -591,6 +964,146 @@ func (x *EnumValueOptions) GetName() string {
 	return ""
 }
 
+// A global flag added to the root command
+type RootFlag struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Flag name (required), e.g. "tenant" for --tenant
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Help text for the flag
+	Usage string `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	// Single-letter alias, e.g. "t" for -t
+	Shorthand string `protobuf:"bytes,3,opt,name=shorthand,proto3" json:"shorthand,omitempty"`
+	// Value type (defaults to string)
+	Type RootFlagType `protobuf:"varint,4,opt,name=type,proto3,enum=cli.v1.RootFlagType" json:"type,omitempty"`
+	// Default value, parsed according to type at code-generation time
+	DefaultValue string `protobuf:"bytes,5,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	// Environment variable read when the flag is not passed
+	EnvVar        string `protobuf:"bytes,6,opt,name=env_var,json=envVar,proto3" json:"env_var,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RootFlag) Reset() {
+	*x = RootFlag{}
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RootFlag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RootFlag) ProtoMessage() {}
+
+func (x *RootFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RootFlag.ProtoReflect.Descriptor instead.
+func (*RootFlag) Descriptor() ([]byte, []int) {
+	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RootFlag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RootFlag) GetUsage() string {
+	if x != nil {
+		return x.Usage
+	}
+	return ""
+}
+
+func (x *RootFlag) GetShorthand() string {
+	if x != nil {
+		return x.Shorthand
+	}
+	return ""
+}
+
+func (x *RootFlag) GetType() RootFlagType {
+	if x != nil {
+		return x.Type
+	}
+	return RootFlagType_ROOT_FLAG_TYPE_UNSPECIFIED
+}
+
+func (x *RootFlag) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+func (x *RootFlag) GetEnvVar() string {
+	if x != nil {
+		return x.EnvVar
+	}
+	return ""
+}
+
+// File options annotation
+// Defines CLI settings shared by every service in a proto file
+type FileOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Global flags added to the root command of any CLI that registers a service
+	// from this file. They are available on every subcommand and readable from
+	// hooks and deserializers via cmd.Root().
+	RootFlags     []*RootFlag `protobuf:"bytes,1,rep,name=root_flags,json=rootFlags,proto3" json:"root_flags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileOptions) Reset() {
+	*x = FileOptions{}
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileOptions) ProtoMessage() {}
+
+func (x *FileOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cli_v1_cli_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileOptions.ProtoReflect.Descriptor instead.
+func (*FileOptions) Descriptor() ([]byte, []int) {
+	return file_proto_cli_v1_cli_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FileOptions) GetRootFlags() []*RootFlag {
+	if x != nil {
+		return x.RootFlags
+	}
+	return nil
+}
+
 var file_proto_cli_v1_cli_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
@@ -632,6 +1145,14 @@ var file_proto_cli_v1_cli_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,50004,opt,name=enum_value",
 		Filename:      "proto/cli/v1/cli.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.FileOptions)(nil),
+		ExtensionType: (*FileOptions)(nil),
+		Field:         50005,
+		Name:          "cli.v1.file",
+		Tag:           "bytes,50005,opt,name=file",
+		Filename:      "proto/cli/v1/cli.proto",
+	},
 }
 
 // Extension fields to descriptorpb.MethodOptions.
@@ -660,6 +1181,12 @@ var (
 	E_EnumValue = &file_proto_cli_v1_cli_proto_extTypes[4]
 )
 
+// Extension fields to descriptorpb.FileOptions.
+var (
+	// optional cli.v1.FileOptions file = 50005;
+	E_File = &file_proto_cli_v1_cli_proto_extTypes[5]
+)
+
 var File_proto_cli_v1_cli_proto protoreflect.FileDescriptor
 
 const file_proto_cli_v1_cli_proto_rawDesc = "" +
@@ -670,7 +1197,7 @@ const file_proto_cli_v1_cli_proto_rawDesc = "" +
 	"\x06hidden\x18\x02 \x01(\bR\x06hidden\">\n" +
 	"\x0eTUIFlagOptions\x12\x14\n" +
 	"\x05label\x18\x01 \x01(\tR\x05label\x12\x16\n" +
-	"\x06hidden\x18\x02 \x01(\bR\x06hidden\"\xfb\x01\n" +
+	"\x06hidden\x18\x02 \x01(\bR\x06hidden\"\xf6\x05\n" +
 	"\x0eCommandOptions\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12)\n" +
@@ -680,21 +1207,53 @@ const file_proto_cli_v1_cli_proto_rawDesc = "" +
 	"\n" +
 	"args_usage\x18\x05 \x01(\tR\targsUsage\x12\x1d\n" +
 	"\n" +
-	"local_only\x18\x06 \x01(\bR\tlocalOnly\x12+\n" +
+	"local_only\x18\x06 \x01(\bR\tlocalOnly\x120\n" +
+	"\x14progress_total_field\x18\a \x01(\tR\x12progressTotalField\x12\x16\n" +
+	"\x06hidden\x18\b \x01(\bR\x06hidden\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\t \x01(\tR\n" +
+	"deprecated\x12+\n" +
 	"\x03tui\x18\n" +
-	" \x01(\v2\x19.cli.v1.TUICommandOptionsR\x03tui\"\x84\x02\n" +
+	" \x01(\v2\x19.cli.v1.TUICommandOptionsR\x03tui\x12,\n" +
+	"\x12resume_token_field\x18\v \x01(\tR\x10resumeTokenField\x120\n" +
+	"\x14resume_request_field\x18\f \x01(\tR\x12resumeRequestField\x12\x18\n" +
+	"\aaliases\x18\r \x03(\tR\aaliases\x12\x14\n" +
+	"\x05order\x18\x0e \x01(\x05R\x05order\x12&\n" +
+	"\x0fexit_code_field\x18\x0f \x01(\tR\rexitCodeField\x12\x18\n" +
+	"\aconfirm\x18\x10 \x01(\bR\aconfirm\x12 \n" +
+	"\vpassthrough\x18\x11 \x01(\tR\vpassthrough\x12,\n" +
+	"\brequires\x18\x12 \x03(\v2\x10.cli.v1.FlagPairR\brequires\x12.\n" +
+	"\tconflicts\x18\x13 \x03(\v2\x10.cli.v1.FlagPairR\tconflicts\x12'\n" +
+	"\x0ftimestamp_field\x18\x14 \x01(\tR\x0etimestampField\x12\x14\n" +
+	"\x05group\x18\x15 \x01(\tR\x05group\"4\n" +
+	"\bFlagPair\x12\x12\n" +
+	"\x04flag\x18\x01 \x01(\tR\x04flag\x12\x14\n" +
+	"\x05other\x18\x02 \x01(\tR\x05other\"\x8b\x04\n" +
 	"\vFlagOptions\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
 	"\tshorthand\x18\x02 \x01(\tR\tshorthand\x12\x14\n" +
 	"\x05usage\x18\x03 \x01(\tR\x05usage\x12\x1a\n" +
 	"\brequired\x18\x04 \x01(\bR\brequired\x12 \n" +
 	"\vplaceholder\x18\x05 \x01(\tR\vplaceholder\x12 \n" +
-	"\vdescription\x18\x06 \x01(\tR\vdescription\x12(\n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1e\n" +
+	"\n" +
+	"deprecated\x18\a \x01(\tR\n" +
+	"deprecated\x12\x18\n" +
+	"\apattern\x18\b \x01(\tR\apattern\x12\x15\n" +
+	"\x06one_of\x18\t \x03(\tR\x05oneOf\x12\x15\n" +
+	"\x03min\x18\v \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\r \x01(\x01H\x01R\x03max\x88\x01\x01\x12(\n" +
 	"\x03tui\x18\n" +
 	" \x01(\v2\x16.cli.v1.TUIFlagOptionsR\x03tui\x12#\n" +
-	"\rdefault_value\x18\f \x01(\tR\fdefaultValue\"'\n" +
+	"\rdefault_value\x18\f \x01(\tR\fdefaultValue\x12+\n" +
+	"\x11default_generator\x18\x0e \x01(\tR\x10defaultGenerator\x12\x1d\n" +
+	"\n" +
+	"allow_file\x18\x0f \x01(\bR\tallowFile\x12*\n" +
+	"\x11field_mask_target\x18\x10 \x01(\tR\x0ffieldMaskTargetB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"'\n" +
 	"\x11TUIServiceOptions\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"\xdc\x01\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x8c\x02\n" +
 	"\x0eServiceOptions\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12)\n" +
@@ -702,19 +1261,40 @@ const file_proto_cli_v1_cli_proto_rawDesc = "" +
 	"\n" +
 	"usage_text\x18\x04 \x01(\tR\tusageText\x12\x1d\n" +
 	"\n" +
-	"args_usage\x18\x05 \x01(\tR\targsUsage\x12+\n" +
+	"args_usage\x18\x05 \x01(\tR\targsUsage\x12\x18\n" +
+	"\aaliases\x18\x06 \x03(\tR\aaliases\x12\x14\n" +
+	"\x05order\x18\a \x01(\x05R\x05order\x12+\n" +
 	"\x03tui\x18\n" +
 	" \x01(\v2\x19.cli.v1.TUIServiceOptionsR\x03tui\"=\n" +
 	"\x14ServiceConfigOptions\x12%\n" +
 	"\x0econfig_message\x18\x01 \x01(\tR\rconfigMessage\"&\n" +
 	"\x10EnumValueOptions\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name:R\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xba\x01\n" +
+	"\bRootFlag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05usage\x18\x02 \x01(\tR\x05usage\x12\x1c\n" +
+	"\tshorthand\x18\x03 \x01(\tR\tshorthand\x12(\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x14.cli.v1.RootFlagTypeR\x04type\x12#\n" +
+	"\rdefault_value\x18\x05 \x01(\tR\fdefaultValue\x12\x17\n" +
+	"\aenv_var\x18\x06 \x01(\tR\x06envVar\">\n" +
+	"\vFileOptions\x12/\n" +
+	"\n" +
+	"root_flags\x18\x01 \x03(\v2\x10.cli.v1.RootFlagR\trootFlags*\xd2\x01\n" +
+	"\fRootFlagType\x12\x1e\n" +
+	"\x1aROOT_FLAG_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ROOT_FLAG_TYPE_STRING\x10\x01\x12\x17\n" +
+	"\x13ROOT_FLAG_TYPE_BOOL\x10\x02\x12\x16\n" +
+	"\x12ROOT_FLAG_TYPE_INT\x10\x03\x12\x18\n" +
+	"\x14ROOT_FLAG_TYPE_FLOAT\x10\x04\x12\x1b\n" +
+	"\x17ROOT_FLAG_TYPE_DURATION\x10\x05\x12\x1f\n" +
+	"\x1bROOT_FLAG_TYPE_STRING_SLICE\x10\x06:R\n" +
 	"\acommand\x12\x1e.google.protobuf.MethodOptions\x18ц\x03 \x01(\v2\x16.cli.v1.CommandOptionsR\acommand:H\n" +
 	"\x04flag\x12\x1d.google.protobuf.FieldOptions\x18҆\x03 \x01(\v2\x13.cli.v1.FlagOptionsR\x04flag:S\n" +
 	"\aservice\x12\x1f.google.protobuf.ServiceOptions\x18І\x03 \x01(\v2\x16.cli.v1.ServiceOptionsR\aservice:f\n" +
 	"\x0eservice_config\x12\x1f.google.protobuf.ServiceOptions\x18ӆ\x03 \x01(\v2\x1c.cli.v1.ServiceConfigOptionsR\rserviceConfig:\\\n" +
 	"\n" +
-	"enum_value\x12!.google.protobuf.EnumValueOptions\x18Ԇ\x03 \x01(\v2\x18.cli.v1.EnumValueOptionsR\tenumValueB0Z.github.com/drewfead/proto-cli/proto/cli/v1;clib\x06proto3"
+	"enum_value\x12!.google.protobuf.EnumValueOptions\x18Ԇ\x03 \x01(\v2\x18.cli.v1.EnumValueOptionsR\tenumValue:G\n" +
+	"\x04file\x12\x1c.google.protobuf.FileOptions\x18Ն\x03 \x01(\v2\x13.cli.v1.FileOptionsR\x04fileB0Z.github.com/drewfead/proto-cli/proto/cli/v1;clib\x06proto3"
 
 var (
 	file_proto_cli_v1_cli_proto_rawDescOnce sync.Once
@@ -728,40 +1308,52 @@ func file_proto_cli_v1_cli_proto_rawDescGZIP() []byte {
 	return file_proto_cli_v1_cli_proto_rawDescData
 }
 
-var file_proto_cli_v1_cli_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_cli_v1_cli_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_cli_v1_cli_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_proto_cli_v1_cli_proto_goTypes = []any{
-	(*TUICommandOptions)(nil),             // 0: cli.v1.TUICommandOptions
-	(*TUIFlagOptions)(nil),                // 1: cli.v1.TUIFlagOptions
-	(*CommandOptions)(nil),                // 2: cli.v1.CommandOptions
-	(*FlagOptions)(nil),                   // 3: cli.v1.FlagOptions
-	(*TUIServiceOptions)(nil),             // 4: cli.v1.TUIServiceOptions
-	(*ServiceOptions)(nil),                // 5: cli.v1.ServiceOptions
-	(*ServiceConfigOptions)(nil),          // 6: cli.v1.ServiceConfigOptions
-	(*EnumValueOptions)(nil),              // 7: cli.v1.EnumValueOptions
-	(*descriptorpb.MethodOptions)(nil),    // 8: google.protobuf.MethodOptions
-	(*descriptorpb.FieldOptions)(nil),     // 9: google.protobuf.FieldOptions
-	(*descriptorpb.ServiceOptions)(nil),   // 10: google.protobuf.ServiceOptions
-	(*descriptorpb.EnumValueOptions)(nil), // 11: google.protobuf.EnumValueOptions
+	(RootFlagType)(0),                     // 0: cli.v1.RootFlagType
+	(*TUICommandOptions)(nil),             // 1: cli.v1.TUICommandOptions
+	(*TUIFlagOptions)(nil),                // 2: cli.v1.TUIFlagOptions
+	(*CommandOptions)(nil),                // 3: cli.v1.CommandOptions
+	(*FlagPair)(nil),                      // 4: cli.v1.FlagPair
+	(*FlagOptions)(nil),                   // 5: cli.v1.FlagOptions
+	(*TUIServiceOptions)(nil),             // 6: cli.v1.TUIServiceOptions
+	(*ServiceOptions)(nil),                // 7: cli.v1.ServiceOptions
+	(*ServiceConfigOptions)(nil),          // 8: cli.v1.ServiceConfigOptions
+	(*EnumValueOptions)(nil),              // 9: cli.v1.EnumValueOptions
+	(*RootFlag)(nil),                      // 10: cli.v1.RootFlag
+	(*FileOptions)(nil),                   // 11: cli.v1.FileOptions
+	(*descriptorpb.MethodOptions)(nil),    // 12: google.protobuf.MethodOptions
+	(*descriptorpb.FieldOptions)(nil),     // 13: google.protobuf.FieldOptions
+	(*descriptorpb.ServiceOptions)(nil),   // 14: google.protobuf.ServiceOptions
+	(*descriptorpb.EnumValueOptions)(nil), // 15: google.protobuf.EnumValueOptions
+	(*descriptorpb.FileOptions)(nil),      // 16: google.protobuf.FileOptions
 }
 var file_proto_cli_v1_cli_proto_depIdxs = []int32{
-	0,  // 0: cli.v1.CommandOptions.tui:type_name -> cli.v1.TUICommandOptions
-	1,  // 1: cli.v1.FlagOptions.tui:type_name -> cli.v1.TUIFlagOptions
-	4,  // 2: cli.v1.ServiceOptions.tui:type_name -> cli.v1.TUIServiceOptions
-	8,  // 3: cli.v1.command:extendee -> google.protobuf.MethodOptions
-	9,  // 4: cli.v1.flag:extendee -> google.protobuf.FieldOptions
-	10, // 5: cli.v1.service:extendee -> google.protobuf.ServiceOptions
-	10, // 6: cli.v1.service_config:extendee -> google.protobuf.ServiceOptions
-	11, // 7: cli.v1.enum_value:extendee -> google.protobuf.EnumValueOptions
-	2,  // 8: cli.v1.command:type_name -> cli.v1.CommandOptions
-	3,  // 9: cli.v1.flag:type_name -> cli.v1.FlagOptions
-	5,  // 10: cli.v1.service:type_name -> cli.v1.ServiceOptions
-	6,  // 11: cli.v1.service_config:type_name -> cli.v1.ServiceConfigOptions
-	7,  // 12: cli.v1.enum_value:type_name -> cli.v1.EnumValueOptions
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	8,  // [8:13] is the sub-list for extension type_name
-	3,  // [3:8] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	1,  // 0: cli.v1.CommandOptions.tui:type_name -> cli.v1.TUICommandOptions
+	4,  // 1: cli.v1.CommandOptions.requires:type_name -> cli.v1.FlagPair
+	4,  // 2: cli.v1.CommandOptions.conflicts:type_name -> cli.v1.FlagPair
+	2,  // 3: cli.v1.FlagOptions.tui:type_name -> cli.v1.TUIFlagOptions
+	6,  // 4: cli.v1.ServiceOptions.tui:type_name -> cli.v1.TUIServiceOptions
+	0,  // 5: cli.v1.RootFlag.type:type_name -> cli.v1.RootFlagType
+	10, // 6: cli.v1.FileOptions.root_flags:type_name -> cli.v1.RootFlag
+	12, // 7: cli.v1.command:extendee -> google.protobuf.MethodOptions
+	13, // 8: cli.v1.flag:extendee -> google.protobuf.FieldOptions
+	14, // 9: cli.v1.service:extendee -> google.protobuf.ServiceOptions
+	14, // 10: cli.v1.service_config:extendee -> google.protobuf.ServiceOptions
+	15, // 11: cli.v1.enum_value:extendee -> google.protobuf.EnumValueOptions
+	16, // 12: cli.v1.file:extendee -> google.protobuf.FileOptions
+	3,  // 13: cli.v1.command:type_name -> cli.v1.CommandOptions
+	5,  // 14: cli.v1.flag:type_name -> cli.v1.FlagOptions
+	7,  // 15: cli.v1.service:type_name -> cli.v1.ServiceOptions
+	8,  // 16: cli.v1.service_config:type_name -> cli.v1.ServiceConfigOptions
+	9,  // 17: cli.v1.enum_value:type_name -> cli.v1.EnumValueOptions
+	11, // 18: cli.v1.file:type_name -> cli.v1.FileOptions
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	13, // [13:19] is the sub-list for extension type_name
+	7,  // [7:13] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_proto_cli_v1_cli_proto_init() }
@@ -769,18 +1361,20 @@ func file_proto_cli_v1_cli_proto_init() {
 	if File_proto_cli_v1_cli_proto != nil {
 		return
 	}
+	file_proto_cli_v1_cli_proto_msgTypes[4].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_cli_v1_cli_proto_rawDesc), len(file_proto_cli_v1_cli_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
-			NumExtensions: 5,
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 6,
 			NumServices:   0,
 		},
 		GoTypes:           file_proto_cli_v1_cli_proto_goTypes,
 		DependencyIndexes: file_proto_cli_v1_cli_proto_depIdxs,
+		EnumInfos:         file_proto_cli_v1_cli_proto_enumTypes,
 		MessageInfos:      file_proto_cli_v1_cli_proto_msgTypes,
 		ExtensionInfos:    file_proto_cli_v1_cli_proto_extTypes,
 	}.Build()
@@ -0,0 +1,88 @@
+package protocli
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultRateLimit is the WithRateLimit key whose limit applies to every
+// method without its own entry.
+const DefaultRateLimit = "*"
+
+// methodRateLimiter holds one token bucket per gRPC method.
+type methodRateLimiter struct {
+	limits map[string]rate.Limit
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func newMethodRateLimiter(limits map[string]rate.Limit) *methodRateLimiter {
+	return &methodRateLimiter{limits: limits, limiters: make(map[string]*rate.Limiter)}
+}
+
+// allow reports whether a call to fullMethod fits its method's bucket. Methods
+// with no limit of their own and no DefaultRateLimit are never limited.
+func (l *methodRateLimiter) allow(fullMethod string) bool {
+	l.mu.Lock()
+	limiter, ok := l.limiters[fullMethod]
+	if !ok {
+		limit, found := l.limits[fullMethod]
+		if !found {
+			limit, found = l.limits[DefaultRateLimit]
+		}
+		if found {
+			limiter = rate.NewLimiter(limit, rateLimitBurst(limit))
+		}
+		l.limiters[fullMethod] = limiter
+	}
+	l.mu.Unlock()
+	return limiter == nil || limiter.Allow()
+}
+
+// rateLimitBurst sizes a bucket to hold one second's worth of calls, and at
+// least one so that slow limits still admit calls.
+func rateLimitBurst(limit rate.Limit) int {
+	if limit == rate.Inf {
+		return 0
+	}
+	return max(1, int(math.Ceil(float64(limit))))
+}
+
+// rateLimitUnaryInterceptor returns a unary server interceptor that rejects
+// calls over their method's rate with codes.ResourceExhausted.
+func rateLimitUnaryInterceptor(l *methodRateLimiter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if !l.allow(info.FullMethod) {
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitStreamInterceptor returns a stream server interceptor that rejects
+// streams opened over their method's rate with codes.ResourceExhausted.
+func rateLimitStreamInterceptor(l *methodRateLimiter) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if !l.allow(info.FullMethod) {
+			return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", info.FullMethod)
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,97 @@
+package protocli_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// startRateLimitedDaemon runs the daemon on port with limits until the test
+// ends and returns a client for it. Every call succeeds unless rate limited.
+func startRateLimitedDaemon(t *testing.T, port string, limits map[string]rate.Limit) simple.UserServiceClient {
+	t.Helper()
+	preventExit(t)
+
+	mock := &simple.MockUserServiceServer{
+		GetUserFunc: func(context.Context, *simple.GetUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{}, nil
+		},
+		CreateUserFunc: func(context.Context, *simple.CreateUserRequest) (*simple.UserResponse, error) {
+			return &simple.UserResponse{}, nil
+		},
+	}
+	factory := func(*simple.UserServiceConfig) simple.UserServiceServer { return mock }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	readyCh := make(chan struct{})
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(simple.UserServiceCommand(ctx, factory)),
+		protocli.WithRateLimit(limits),
+		protocli.OnDaemonReady(func(context.Context) { close(readyCh) }),
+		protocli.WithGracefulShutdownTimeout(2*time.Second),
+	)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = rootCmd.Run(ctx, []string{"testcli", "daemonize", "--port", port})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		waitForDone(t, done)
+	})
+	waitForReady(t, readyCh)
+
+	conn, err := grpc.NewClient("localhost:"+port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return simple.NewUserServiceClient(conn)
+}
+
+func getUserCode(client simple.UserServiceClient) codes.Code {
+	_, err := client.GetUser(context.Background(), &simple.GetUserRequest{Id: 1})
+	return status.Code(err)
+}
+
+func TestIntegration_RateLimit_RejectsBurstAndRefills(t *testing.T) {
+	client := startRateLimitedDaemon(t, "50208", map[string]rate.Limit{
+		"/example.UserService/GetUser": 5,
+	})
+
+	for i := range 5 {
+		require.Equal(t, codes.OK, getUserCode(client), "call %d is within the burst", i+1)
+	}
+	assert.Equal(t, codes.ResourceExhausted, getUserCode(client), "the burst is exhausted")
+
+	time.Sleep(300 * time.Millisecond) // refills at least one token at 5/s
+	assert.Equal(t, codes.OK, getUserCode(client), "allowed again after refill")
+}
+
+func TestIntegration_RateLimit_DefaultAndOverrides(t *testing.T) {
+	client := startRateLimitedDaemon(t, "50209", map[string]rate.Limit{
+		protocli.DefaultRateLimit:      1,
+		"/example.UserService/GetUser": rate.Inf,
+	})
+
+	for range 10 {
+		require.Equal(t, codes.OK, getUserCode(client), "the override lifts the default")
+	}
+
+	create := func() codes.Code {
+		_, err := client.CreateUser(context.Background(), &simple.CreateUserRequest{Name: "ada", Email: "ada@example.com"})
+		return status.Code(err)
+	}
+	require.Equal(t, codes.OK, create())
+	assert.Equal(t, codes.ResourceExhausted, create(), "other methods get the default limit")
+}
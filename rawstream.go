@@ -0,0 +1,55 @@
+package protocli
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrRawMessageTooLarge is returned when a message does not fit the 4-byte
+// length prefix of --raw output.
+var ErrRawMessageTooLarge = errors.New("message too large for raw framing")
+
+// rawLengthPrefixSize is the size of the big-endian length prefix written
+// before each message by --raw.
+const rawLengthPrefixSize = 4
+
+// WriteRawMessage writes msg as --raw streaming output does: a 4-byte
+// big-endian length followed by the binary protobuf encoding of msg.
+func WriteRawMessage(w io.Writer, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	if uint64(len(data)) > uint64(^uint32(0)) {
+		return fmt.Errorf("%w: %d bytes", ErrRawMessageTooLarge, len(data))
+	}
+	frame := make([]byte, rawLengthPrefixSize, rawLengthPrefixSize+len(data))
+	binary.BigEndian.PutUint32(frame, uint32(len(data)))
+	_, err = w.Write(append(frame, data...))
+	return err
+}
+
+// ReadRawMessage reads one message written by WriteRawMessage from r into msg.
+// It returns io.EOF when r ends cleanly between messages and
+// io.ErrUnexpectedEOF when it ends inside one.
+func ReadRawMessage(r io.Reader, msg proto.Message) error {
+	var prefix [rawLengthPrefixSize]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return err
+	}
+	data := make([]byte, binary.BigEndian.Uint32(prefix[:]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+	return nil
+}
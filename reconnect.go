@@ -0,0 +1,214 @@
+package protocli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DefaultReconnectBackoff is the delay before the first reconnect attempt when
+// --reconnect-backoff is not set.
+const DefaultReconnectBackoff = 500 * time.Millisecond
+
+// maxReconnectBackoff caps the exponential backoff between reconnect attempts.
+const maxReconnectBackoff = 30 * time.Second
+
+// MaxReconnectAttempts is how many reconnect attempts in a row --reconnect
+// makes without receiving a message before giving up with ErrReconnectFailed.
+const MaxReconnectAttempts = 10
+
+// ErrReconnectFailed is returned when --reconnect gives up after
+// MaxReconnectAttempts attempts.
+var ErrReconnectFailed = errors.New("reconnect failed")
+
+// reconnectCodes are the status codes of transient failures worth reopening a
+// stream for. Other errors, such as InvalidArgument or PermissionDenied, would
+// fail the same way again.
+var reconnectCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted}
+
+// StreamReconnector re-establishes remote server streams for commands run with
+// --reconnect, in the spirit of "tail -f". Generated streaming commands create
+// one per invocation, feed it every received message and call ReconnectStream
+// when the stream ends or fails.
+type StreamReconnector struct {
+	enabled     bool
+	w           io.Writer
+	initial     time.Duration
+	backoff     time.Duration
+	attempt     int
+	tokenPath   []protoreflect.Name
+	requestPath []protoreflect.Name
+	token       protoreflect.Value
+	hasToken    bool
+}
+
+// NewStreamReconnector reads --reconnect and --reconnect-backoff from cmd, and
+// the global --quiet flag from its lineage. tokenField and requestField are the dot-separated resume_token_field and
+// resume_request_field annotations; both may be empty.
+func NewStreamReconnector(cmd *cli.Command, tokenField, requestField string) *StreamReconnector {
+	r := &StreamReconnector{
+		enabled: cmd.Bool("reconnect"),
+		w:       io.Discard,
+		initial: cmd.Duration("reconnect-backoff"),
+	}
+	if r.initial <= 0 {
+		r.initial = DefaultReconnectBackoff
+	}
+	r.backoff = r.initial
+	if !cmd.Bool("quiet") {
+		r.w = cmd.Root().ErrWriter
+		if r.w == nil {
+			r.w = os.Stderr
+		}
+	}
+	if requestField == "" {
+		requestField = tokenField
+	}
+	if tokenField != "" {
+		r.tokenPath = splitFieldPath(tokenField)
+		r.requestPath = splitFieldPath(requestField)
+	}
+	return r
+}
+
+// Enabled reports whether --reconnect was given.
+func (r *StreamReconnector) Enabled() bool {
+	return r.enabled
+}
+
+// Observe records the resume token carried by a received message and resets
+// the backoff, since the stream is healthy again.
+func (r *StreamReconnector) Observe(msg proto.Message) {
+	r.backoff = r.initial
+	r.attempt = 0
+	if len(r.tokenPath) == 0 {
+		return
+	}
+	if token, _, ok := getFieldPath(msg.ProtoReflect(), r.tokenPath); ok {
+		r.token = token
+		r.hasToken = true
+	}
+}
+
+// resume copies the last observed token into req.
+func (r *StreamReconnector) resume(req proto.Message) {
+	if !r.hasToken || len(r.requestPath) == 0 {
+		return
+	}
+	setFieldPath(req.ProtoReflect(), r.requestPath, r.token)
+}
+
+// ReconnectStream waits out the current backoff, applies the resume token to
+// req and reopens the stream with open, retrying until it succeeds or ctx is
+// done. cause is the error (or io.EOF) that ended the previous stream. Only
+// transient failures (Unavailable, ResourceExhausted, Aborted) and a stream
+// that ended are retried; any other cause is returned as is. After
+// MaxReconnectAttempts attempts without a message in between it gives up with
+// ErrReconnectFailed. A notice is written to stderr before each attempt unless
+// the global --quiet was given.
+func ReconnectStream[S any](ctx context.Context, r *StreamReconnector, req proto.Message, cause error, open func() (S, error)) (S, error) {
+	var zero S
+	for {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		if !reconnectable(cause) {
+			return zero, cause
+		}
+		if r.attempt >= MaxReconnectAttempts {
+			return zero, fmt.Errorf("%w after %d attempts: %w", ErrReconnectFailed, r.attempt, cause)
+		}
+
+		r.attempt++
+		reason := "stream ended"
+		if !errors.Is(cause, io.EOF) {
+			reason = cause.Error()
+		}
+		_, _ = fmt.Fprintf(r.w, "reconnecting in %s (attempt %d): %s\n", r.backoff, r.attempt, reason)
+
+		timer := time.NewTimer(r.backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, ctx.Err()
+		case <-timer.C:
+		}
+		r.backoff = min(2*r.backoff, maxReconnectBackoff)
+
+		r.resume(req)
+		stream, err := open()
+		if err == nil {
+			return stream, nil
+		}
+		cause = err
+	}
+}
+
+// reconnectable reports whether a stream that ended with err is worth
+// reopening: it ended cleanly (tail mode) or failed with a transient status.
+func reconnectable(err error) bool {
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	return slices.Contains(reconnectCodes, status.Code(err))
+}
+
+// splitFieldPath splits a dot-separated field path into proto field names.
+func splitFieldPath(path string) []protoreflect.Name {
+	parts := strings.Split(path, ".")
+	names := make([]protoreflect.Name, len(parts))
+	for i, part := range parts {
+		names[i] = protoreflect.Name(part)
+	}
+	return names
+}
+
+// getFieldPath returns the value and descriptor of the field at path in m.
+// ok is false when a field on the path is unknown or unset, or passes through
+// a repeated, map or non-message field.
+func getFieldPath(m protoreflect.Message, path []protoreflect.Name) (v protoreflect.Value, fd protoreflect.FieldDescriptor, ok bool) {
+	for i, name := range path {
+		fd = m.Descriptor().Fields().ByName(name)
+		if fd == nil || !m.Has(fd) {
+			return protoreflect.Value{}, nil, false
+		}
+		if i == len(path)-1 {
+			return m.Get(fd), fd, true
+		}
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return protoreflect.Value{}, nil, false
+		}
+		m = m.Get(fd).Message()
+	}
+	return protoreflect.Value{}, nil, false
+}
+
+// setFieldPath sets the field at path in m to v, creating intermediate
+// messages. Paths that do not resolve to a field are ignored.
+func setFieldPath(m protoreflect.Message, path []protoreflect.Name, v protoreflect.Value) {
+	for i, name := range path {
+		fd := m.Descriptor().Fields().ByName(name)
+		if fd == nil {
+			return
+		}
+		if i == len(path)-1 {
+			m.Set(fd, v)
+			return
+		}
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return
+		}
+		m = m.Mutable(fd).Message()
+	}
+}
@@ -0,0 +1,58 @@
+package protocli
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// RedactedPlaceholder replaces the value of sensitive string fields in redacted messages.
+const RedactedPlaceholder = "[REDACTED]"
+
+// RedactSensitive returns a copy of msg with sensitive fields masked. A field is
+// sensitive when declared with the standard `[debug_redact = true]` field option.
+// Sensitive string fields are replaced with RedactedPlaceholder; all other
+// sensitive fields are cleared. Nested, repeated and map message values are
+// redacted recursively. msg itself is not modified.
+func RedactSensitive(msg proto.Message) proto.Message {
+	if msg == nil {
+		return nil
+	}
+	clone := proto.Clone(msg)
+	redactMessage(clone.ProtoReflect())
+	return clone
+}
+
+func redactMessage(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if isSensitiveField(fd) {
+			if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+				m.Set(fd, protoreflect.ValueOfString(RedactedPlaceholder))
+			} else {
+				m.Clear(fd)
+			}
+			return true
+		}
+		switch {
+		case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redactMessage(list.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Kind() == protoreflect.MessageKind:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				redactMessage(mv.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Kind() == protoreflect.MessageKind:
+			redactMessage(v.Message())
+		}
+		return true
+	})
+}
+
+// isSensitiveField reports whether fd is declared with [debug_redact = true].
+func isSensitiveField(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	return ok && opts.GetDebugRedact()
+}
@@ -0,0 +1,151 @@
+package protocli
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrUnknownContext     = errors.New("unknown context")
+	ErrInvalidRemotesFile = errors.New("invalid remotes file")
+)
+
+// remoteContextKey is the root metadata key of the Remote selected by --context.
+const remoteContextKey = "protocli:remoteContext"
+
+// Remote is a named server in a remotes file (see WithRemotesFile).
+type Remote struct {
+	// Address is the server address (host:port).
+	Address string `yaml:"address"`
+	// TLS connects with TLS instead of plaintext.
+	TLS bool `yaml:"tls"`
+	// Auth, if set, is sent as the authorization header of every call,
+	// e.g. "Bearer abc123".
+	Auth string `yaml:"auth"`
+}
+
+// remotesFile is the on-disk form of a remotes file:
+//
+//	remotes:
+//	  staging:
+//	    address: staging.example.com:443
+//	    tls: true
+//	    auth: Bearer abc123
+//	  local:
+//	    address: localhost:50051
+type remotesFile struct {
+	Remotes map[string]Remote `yaml:"remotes"`
+}
+
+// LoadRemotes reads a remotes file and returns its remotes by name. A leading
+// "~" in path stands for the user's home directory.
+func LoadRemotes(path string) (map[string]Remote, error) {
+	path, err := ExpandHomeDir(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // path is set by the CLI author with WithRemotesFile
+	if err != nil {
+		return nil, fmt.Errorf("failed to read remotes file: %w", err)
+	}
+
+	var file remotesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrInvalidRemotesFile, path, err)
+	}
+	for name, remote := range file.Remotes {
+		if remote.Address == "" {
+			return nil, fmt.Errorf("%w %s: remote %q has no address", ErrInvalidRemotesFile, path, name)
+		}
+	}
+	return file.Remotes, nil
+}
+
+// ResolveRemote returns the remote called name in the remotes file at path.
+func ResolveRemote(path, name string) (Remote, error) {
+	remotes, err := LoadRemotes(path)
+	if err != nil {
+		return Remote{}, err
+	}
+	remote, ok := remotes[name]
+	if !ok {
+		names := make([]string, 0, len(remotes))
+		for n := range remotes {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		return Remote{}, fmt.Errorf("%w %q (available: %s)", ErrUnknownContext, name, strings.Join(names, ", "))
+	}
+	return remote, nil
+}
+
+// RemoteDialOptions returns the dial options generated commands use for remote
+// calls: TLS when the remote selected with --context asks for it, plaintext
+// otherwise.
+func RemoteDialOptions(cmd *cli.Command) []grpc.DialOption {
+	var tlsConfig *tls.Config
+	if remote, ok := cmd.Root().Metadata[remoteContextKey].(Remote); ok && remote.TLS {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials(tlsConfig))}
+}
+
+// transportCredentials returns TLS credentials for tlsConfig, or plaintext
+// when it is nil.
+func transportCredentials(tlsConfig *tls.Config) credentials.TransportCredentials {
+	if tlsConfig == nil {
+		return insecure.NewCredentials()
+	}
+	return credentials.NewTLS(tlsConfig)
+}
+
+// applyRemoteContext resolves the --context flag against the remotes file at
+// path. Unless --remote is given on the command line, the command about to run
+// connects to the remote: it gets the remote's address as its --remote (and
+// --tls for ping and doctor) and ctx gets its authorization header.
+func applyRemoteContext(ctx context.Context, root *cli.Command, path string) (context.Context, error) {
+	delete(root.Metadata, remoteContextKey)
+	name := root.String("context")
+	if name == "" {
+		return ctx, nil
+	}
+	remote, err := ResolveRemote(path, name)
+	if err != nil {
+		return ctx, err
+	}
+
+	cmd := selectedCommand(root)
+	if f := lookupLineageFlag(cmd, "remote"); f == nil || f.IsSet() {
+		return ctx, nil
+	}
+	if err := cmd.Set("remote", remote.Address); err != nil {
+		return ctx, fmt.Errorf("failed to set --remote from context %q: %w", name, err)
+	}
+	if f := lookupLineageFlag(cmd, "tls"); f != nil && remote.TLS {
+		if err := cmd.Set("tls", "true"); err != nil {
+			return ctx, fmt.Errorf("failed to set --tls from context %q: %w", name, err)
+		}
+	}
+
+	if root.Metadata == nil {
+		root.Metadata = make(map[string]interface{})
+	}
+	root.Metadata[remoteContextKey] = remote
+
+	if remote.Auth != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", remote.Auth)
+	}
+	return ctx, nil
+}
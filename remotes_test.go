@@ -0,0 +1,113 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	simple "github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const testRemotesFile = `remotes:
+  local:
+    address: localhost:50215
+    auth: Bearer local-token
+  staging:
+    address: staging.example.com:443
+    tls: true
+`
+
+// writeRemotesFile writes content to a remotes file in a temp dir and returns
+// its path.
+func writeRemotesFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "remotes.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestUnit_LoadRemotes(t *testing.T) {
+	remotes, err := protocli.LoadRemotes(writeRemotesFile(t, testRemotesFile))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]protocli.Remote{
+		"local":   {Address: "localhost:50215", Auth: "Bearer local-token"},
+		"staging": {Address: "staging.example.com:443", TLS: true},
+	}, remotes)
+}
+
+func TestUnit_LoadRemotes_Invalid(t *testing.T) {
+	_, err := protocli.LoadRemotes(writeRemotesFile(t, "remotes:\n  local:\n    tls: true\n"))
+	require.ErrorIs(t, err, protocli.ErrInvalidRemotesFile)
+	assert.Contains(t, err.Error(), `remote "local" has no address`)
+
+	_, err = protocli.LoadRemotes(writeRemotesFile(t, "remotes: [local]\n"))
+	require.ErrorIs(t, err, protocli.ErrInvalidRemotesFile)
+
+	_, err = protocli.LoadRemotes(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestUnit_ResolveRemote(t *testing.T) {
+	path := writeRemotesFile(t, testRemotesFile)
+
+	remote, err := protocli.ResolveRemote(path, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "staging.example.com:443", remote.Address)
+	assert.True(t, remote.TLS)
+
+	_, err = protocli.ResolveRemote(path, "prod")
+	require.ErrorIs(t, err, protocli.ErrUnknownContext)
+	assert.Contains(t, err.Error(), "available: local, staging")
+}
+
+// TestIntegration_RemotesFile tests that --context calls the remote named in
+// the remotes file with its authorization header, and that --remote wins.
+func TestIntegration_RemotesFile(t *testing.T) {
+	var mu sync.Mutex
+	var authHeaders []string
+	startAccessLoggedDaemon(t, "50215", protocli.WithUnaryInterceptor(
+		func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			mu.Lock()
+			authHeaders = append(authHeaders, md.Get("authorization")...)
+			mu.Unlock()
+			return handler(ctx, req)
+		}))
+	path := writeRemotesFile(t, testRemotesFile)
+
+	run := func(args ...string) error {
+		ctx := context.Background()
+		factory := func(*simple.UserServiceConfig) simple.UserServiceServer { return &simple.MockUserServiceServer{} }
+		rootCmd, err := protocli.RootCommand("testcli",
+			protocli.Service(simple.UserServiceCommand(ctx, factory, protocli.WithOutputFormats(protocli.JSON()))),
+			protocli.WithRemotesFile(path),
+		)
+		require.NoError(t, err)
+		setWriterOnAllCommands(rootCmd, &bytes.Buffer{})
+		return rootCmd.Run(ctx, append([]string{"testcli"}, args...))
+	}
+
+	require.NoError(t, run("--context", "local", "user-service", "get", "--id", "1", "--db-url", "postgres://localhost/test"))
+	mu.Lock()
+	assert.Equal(t, []string{"Bearer local-token"}, authHeaders)
+	mu.Unlock()
+
+	// --remote on the command line wins over the context's address
+	err := run("--context", "staging", "user-service", "get", "--id", "1", "--db-url", "postgres://localhost/test",
+		"--remote", "localhost:50215")
+	require.NoError(t, err)
+	mu.Lock()
+	assert.Equal(t, []string{"Bearer local-token"}, authHeaders, "no auth header without the context's remote")
+	mu.Unlock()
+
+	err = run("--context", "prod", "user-service", "get", "--id", "1", "--db-url", "postgres://localhost/test")
+	require.ErrorIs(t, err, protocli.ErrUnknownContext)
+}
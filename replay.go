@@ -0,0 +1,227 @@
+package protocli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrNoRecording      = errors.New("no recorded response")
+	ErrInvalidRecording = errors.New("invalid recorded response")
+)
+
+// Metadata keys used to store the recorders on the root command.
+const (
+	recordKey = "protocli:record"
+	replayKey = "protocli:replay"
+)
+
+// ResponseRecorder stores the responses of RPC calls on disk, keyed by the full
+// gRPC method name and a hash of the request, so they can be replayed later
+// without calling the service. Each recording is a JSON file holding the
+// request and the sequence of responses (one for unary methods).
+type ResponseRecorder struct {
+	dir string
+}
+
+// recording is the on-disk form of a recorded call.
+type recording struct {
+	Method    string            `json:"method"`
+	Request   json.RawMessage   `json:"request"`
+	Responses []json.RawMessage `json:"responses"`
+}
+
+// NewResponseRecorder creates a recorder that stores recordings under dir.
+func NewResponseRecorder(dir string) *ResponseRecorder {
+	return &ResponseRecorder{dir: dir}
+}
+
+// Dir returns the directory recordings are stored in.
+func (r *ResponseRecorder) Dir() string {
+	return r.dir
+}
+
+// fileName maps a method and request to its recording file, e.g.
+// "<dir>/example.UserService/GetUser/<sha256 of request>.json".
+func (r *ResponseRecorder) fileName(method string, req proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return filepath.Join(r.dir, filepath.FromSlash(strings.TrimPrefix(method, "/")), hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// Save records resps as the responses of method for req, replacing any
+// previous recording of the same request.
+func (r *ResponseRecorder) Save(method string, req proto.Message, resps ...proto.Message) error {
+	path, err := r.fileName(method, req)
+	if err != nil {
+		return err
+	}
+	rec := recording{Method: method, Responses: make([]json.RawMessage, 0, len(resps))}
+	if rec.Request, err = protojson.Marshal(req); err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	for _, resp := range resps {
+		data, err := protojson.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("failed to marshal response: %w", err)
+		}
+		rec.Responses = append(rec.Responses, data)
+	}
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create recording dir: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// Load returns the recorded responses of method for req, decoded as new
+// messages of prototype's type. ErrNoRecording is returned if the request was
+// never recorded.
+func (r *ResponseRecorder) Load(method string, req, prototype proto.Message) ([]proto.Message, error) {
+	path, err := r.fileName(method, req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w for %s in %s", ErrNoRecording, method, r.dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidRecording, path, err)
+	}
+	resps := make([]proto.Message, 0, len(rec.Responses))
+	for _, raw := range rec.Responses {
+		resp := prototype.ProtoReflect().Type().New().Interface()
+		if err := protojson.Unmarshal(raw, resp); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidRecording, path, err)
+		}
+		resps = append(resps, resp)
+	}
+	return resps, nil
+}
+
+// Replaying reports whether cmd should be served from recordings: a replay
+// directory was configured via WithReplay and --replay is set.
+func Replaying(cmd *cli.Command) bool {
+	r, ok := cmd.Root().Metadata[replayKey].(*ResponseRecorder)
+	return ok && r != nil && cmd.Root().Bool("replay")
+}
+
+// ReplayResponse returns the recorded response of a unary method for req.
+// Called by generated unary actions when Replaying reports true.
+func ReplayResponse[T proto.Message](cmd *cli.Command, method string, req proto.Message) (T, error) {
+	var zero T
+	resps, err := ReplayStream[T](cmd, method, req)
+	if err != nil {
+		return zero, err
+	}
+	if len(resps) != 1 {
+		return zero, fmt.Errorf("%w: %s has %d responses, want 1", ErrInvalidRecording, method, len(resps))
+	}
+	return resps[0], nil
+}
+
+// ReplayStream returns the recorded sequence of responses of a method for req.
+// Called by generated streaming actions when Replaying reports true.
+func ReplayStream[T proto.Message](cmd *cli.Command, method string, req proto.Message) ([]T, error) {
+	r, ok := cmd.Root().Metadata[replayKey].(*ResponseRecorder)
+	if !ok || r == nil {
+		return nil, fmt.Errorf("%w: replay is not configured", ErrNoRecording)
+	}
+	var prototype T
+	msgs, err := r.Load(method, req, prototype)
+	if err != nil {
+		return nil, err
+	}
+	resps := make([]T, 0, len(msgs))
+	for _, msg := range msgs {
+		resps = append(resps, msg.(T))
+	}
+	return resps, nil
+}
+
+// recorderFor returns the recorder configured via WithRecord, or nil when
+// recording is disabled or the command is itself being replayed.
+func recorderFor(cmd *cli.Command) *ResponseRecorder {
+	r, ok := cmd.Root().Metadata[recordKey].(*ResponseRecorder)
+	if !ok || r == nil || Replaying(cmd) {
+		return nil
+	}
+	return r
+}
+
+// RecordResponse stores resp as the recorded response of method for req when
+// WithRecord is set. Called by generated unary actions. Failures are logged
+// rather than returned so recording never breaks a command.
+func RecordResponse(cmd *cli.Command, method string, req, resp proto.Message) {
+	r := recorderFor(cmd)
+	if r == nil {
+		return
+	}
+	if err := r.Save(method, req, resp); err != nil {
+		slog.Warn("Failed to record response", "method", method, "error", err)
+	}
+}
+
+// StreamRecording collects the messages of a server stream for WithRecord.
+// A nil *StreamRecording is valid and records nothing.
+type StreamRecording struct {
+	recorder *ResponseRecorder
+	method   string
+	req      proto.Message
+	msgs     []proto.Message
+}
+
+// NewStreamRecording starts recording a stream of method for req. Returns nil
+// unless WithRecord is set.
+func NewStreamRecording(cmd *cli.Command, method string, req proto.Message) *StreamRecording {
+	r := recorderFor(cmd)
+	if r == nil {
+		return nil
+	}
+	return &StreamRecording{recorder: r, method: method, req: req}
+}
+
+// Observe adds a received message to the recording.
+func (s *StreamRecording) Observe(msg proto.Message) {
+	if s == nil {
+		return
+	}
+	s.msgs = append(s.msgs, proto.Clone(msg))
+}
+
+// Finish stores the messages observed so far. It is called only when the
+// stream completes cleanly, so failed streams are not recorded. Failures are
+// logged rather than returned.
+func (s *StreamRecording) Finish() {
+	if s == nil {
+		return
+	}
+	if err := s.recorder.Save(s.method, s.req, s.msgs...); err != nil {
+		slog.Warn("Failed to record stream", "method", s.method, "error", err)
+	}
+}
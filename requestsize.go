@@ -0,0 +1,29 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxRequestSizeKey is the Metadata key used to store the WithMaxRequestSize limit on the root command.
+const maxRequestSizeKey = "protocli:maxRequestSize"
+
+// ErrRequestTooLarge is returned when a request exceeds the WithMaxRequestSize limit.
+var ErrRequestTooLarge = errors.New("request too large")
+
+// CheckRequestSize rejects req if its encoded size exceeds the limit set with
+// WithMaxRequestSize. Generated commands call this after building the request
+// and before sending it, locally or remotely.
+func CheckRequestSize(cmd *cli.Command, req proto.Message) error {
+	limit, ok := cmd.Root().Metadata[maxRequestSizeKey].(int)
+	if !ok || limit <= 0 {
+		return nil
+	}
+	if size := proto.Size(req); size > limit {
+		return fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrRequestTooLarge, size, limit)
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package protocli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrNoCachedResponse is returned when no response is cached for a command
+	// reference, or when `last` is used without WithResponseCache.
+	ErrNoCachedResponse = errors.New("no cached response")
+	// ErrAmbiguousCachedResponse is returned when a command reference matches
+	// the cached responses of more than one command.
+	ErrAmbiguousCachedResponse = errors.New("ambiguous cached response")
+	// ErrCachedFieldNotFound is returned when a field path does not exist in
+	// the cached response.
+	ErrCachedFieldNotFound = errors.New("field not found in cached response")
+)
+
+// responseCacheKey is the Metadata key used to store the response cache on the root command.
+const responseCacheKey = "protocli:responseCache"
+
+// ResponseCache persists the most recent response of each command as JSON so
+// later invocations can reuse values (e.g. an ID returned by a create command).
+// Responses are keyed by command path relative to the root, e.g. "user-service/create-user".
+type ResponseCache struct {
+	dir string
+}
+
+// NewResponseCache creates a response cache that stores files under dir.
+func NewResponseCache(dir string) *ResponseCache {
+	return &ResponseCache{dir: dir}
+}
+
+// Dir returns the directory responses are stored in.
+func (c *ResponseCache) Dir() string {
+	return c.dir
+}
+
+// fileName maps a command path to its cache file name.
+func (c *ResponseCache) fileName(key string) string {
+	return filepath.Join(c.dir, strings.ReplaceAll(key, "/", "__")+".json")
+}
+
+// Store writes msg as the last response for key, replacing any previous entry.
+func (c *ResponseCache) Store(key string, msg proto.Message) error {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+	if err := os.MkdirAll(c.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create response cache dir: %w", err)
+	}
+	if err := os.WriteFile(c.fileName(key), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cached response: %w", err)
+	}
+	return nil
+}
+
+// Keys returns the command paths that have a cached response, sorted.
+func (c *ResponseCache) Keys() ([]string, error) {
+	entries, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response cache dir: %w", err)
+	}
+	var keys []string
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if e.IsDir() || !ok {
+			continue
+		}
+		keys = append(keys, strings.ReplaceAll(name, "__", "/"))
+	}
+	slices.Sort(keys)
+	return keys, nil
+}
+
+// resolve maps a user-supplied command reference to a stored key. An exact
+// path match wins; otherwise a unique trailing match (e.g. "create-user" for
+// "user-service/create-user") is accepted.
+func (c *ResponseCache) resolve(ref string) (string, error) {
+	ref = strings.Trim(strings.ReplaceAll(ref, " ", "/"), "/")
+	keys, err := c.Keys()
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, key := range keys {
+		if key == ref {
+			return key, nil
+		}
+		if strings.HasSuffix(key, "/"+ref) {
+			matches = append(matches, key)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w for %q", ErrNoCachedResponse, ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%w: %q matches %s", ErrAmbiguousCachedResponse, ref, strings.Join(matches, ", "))
+	}
+}
+
+// Load returns the last response for the referenced command as a JSON-decoded map.
+func (c *ResponseCache) Load(ref string) (map[string]any, error) {
+	key, err := c.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(c.fileName(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached response: %w", err)
+	}
+	var result map[string]any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse cached response: %w", err)
+	}
+	return result, nil
+}
+
+// Field returns a value from the last response of the referenced command.
+// path is a dot-separated chain of JSON field names; list elements are
+// addressed by index (e.g. "user.id" or "items.0.name"). An empty path
+// returns the whole response.
+func (c *ResponseCache) Field(ref, path string) (any, error) {
+	resp, err := c.Load(ref)
+	if err != nil {
+		return nil, err
+	}
+	var current any = resp
+	if path == "" {
+		return current, nil
+	}
+	for _, part := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]any:
+			next, ok := v[part]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrCachedFieldNotFound, path)
+			}
+			current = next
+		case []any:
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, fmt.Errorf("%w: %s", ErrCachedFieldNotFound, path)
+			}
+			current = v[idx]
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrCachedFieldNotFound, path)
+		}
+	}
+	return current, nil
+}
+
+// FieldString returns a cached field rendered for shell use: strings are
+// returned verbatim, everything else as compact JSON.
+func (c *ResponseCache) FieldString(ref, path string) (string, error) {
+	v, err := c.Field(ref, path)
+	if err != nil {
+		return "", err
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cached field: %w", err)
+	}
+	return string(data), nil
+}
+
+// rootResponseCache returns the WithResponseCache cache of the root of cmd, or
+// nil if it has none.
+func rootResponseCache(cmd *cli.Command) *ResponseCache {
+	if cmd == nil {
+		return nil
+	}
+	cache, _ := cmd.Root().Metadata[responseCacheKey].(*ResponseCache)
+	return cache
+}
+
+// lastWithoutResponseCache is the `last` template function of template
+// formats run without a response cache.
+func lastWithoutResponseCache(ref, _ string) (any, error) {
+	return nil, fmt.Errorf("%w for %s: WithResponseCache is not configured", ErrNoCachedResponse, ref)
+}
+
+// commandPath returns the command's path relative to the root, joined by "/".
+// Example: "usercli user-service create-user" → "user-service/create-user".
+func commandPath(cmd *cli.Command) string {
+	lineage := cmd.Lineage()
+	names := make([]string, 0, len(lineage))
+	for i := len(lineage) - 2; i >= 0; i-- {
+		names = append(names, lineage[i].Name)
+	}
+	return strings.Join(names, "/")
+}
+
+// CacheResponse stores resp as the last response for cmd when a response cache
+// was configured via WithResponseCache. Called by generated unary actions.
+// Failures are logged rather than returned so caching never breaks a command.
+func CacheResponse(cmd *cli.Command, resp proto.Message) {
+	cache := rootResponseCache(cmd)
+	if cache == nil {
+		return
+	}
+	if err := cache.Store(commandPath(cmd), resp); err != nil {
+		slog.Warn("Failed to cache response", "command", commandPath(cmd), "error", err)
+	}
+}
+
+// newLastCommand creates the `last` command that prints fields from cached responses.
+func newLastCommand(cache *ResponseCache) *cli.Command {
+	return &cli.Command{
+		Name:      "last",
+		Usage:     "Print a field from the last response of a command",
+		ArgsUsage: "<command> [field.path]",
+		Description: "Reads the most recent response stored by WithResponseCache.\n" +
+			"Example: mycli user-service get --id $(mycli last create-user id)",
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			if cmd.Args().Len() == 0 {
+				keys, err := cache.Keys()
+				if err != nil {
+					return err
+				}
+				for _, key := range keys {
+					_, _ = fmt.Fprintln(cmd.Root().Writer, key)
+				}
+				return nil
+			}
+			if cmd.Args().Len() > 2 {
+				return cli.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(2)), 3)
+			}
+			value, err := cache.FieldString(cmd.Args().Get(0), cmd.Args().Get(1))
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.Root().Writer, value)
+			return err
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestUnit_ResponseCache_StoreAndField(t *testing.T) {
+	cache := protocli.NewResponseCache(t.TempDir())
+
+	require.NoError(t, cache.Store("user-service/create", &simple.UserResponse{
+		User: &simple.User{Id: 42, Name: "Alice"},
+	}))
+
+	name, err := cache.Field("user-service/create", "user.name")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", name)
+
+	// Trailing command name resolves when unambiguous; int64 is a JSON string in protojson
+	id, err := cache.FieldString("create", "user.id")
+	require.NoError(t, err)
+	assert.Equal(t, "42", id)
+
+	// Non-string values render as JSON
+	user, err := cache.FieldString("create", "user")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"42","name":"Alice"}`, user)
+
+	_, err = cache.Field("create", "user.missing")
+	require.ErrorIs(t, err, protocli.ErrCachedFieldNotFound)
+
+	_, err = cache.Field("get", "")
+	require.ErrorIs(t, err, protocli.ErrNoCachedResponse)
+}
+
+func TestUnit_ResponseCache_AmbiguousReference(t *testing.T) {
+	cache := protocli.NewResponseCache(t.TempDir())
+	require.NoError(t, cache.Store("user-service/get", &simple.UserResponse{}))
+	require.NoError(t, cache.Store("admin-service/get", &simple.UserResponse{}))
+
+	_, err := cache.Field("get", "")
+	require.ErrorIs(t, err, protocli.ErrAmbiguousCachedResponse)
+
+	_, err = cache.Field("admin-service/get", "")
+	require.NoError(t, err)
+}
+
+func TestIntegration_ResponseCache_LastCommand(t *testing.T) {
+	setupTestCLI(t)
+	ctx := context.Background()
+
+	userServiceCLI := simple.UserServiceCommand(ctx, newMockUserService)
+	rootCmd, err := protocli.RootCommand("testcli",
+		protocli.Service(userServiceCLI),
+		protocli.WithResponseCache(t.TempDir()),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	setWriterOnAllCommands(rootCmd, &buf)
+
+	args := []string{"testcli", "user-service", "get", "--id", "7", "--db-url", "postgres://localhost:5432/testdb"}
+	require.NoError(t, rootCmd.Run(ctx, args))
+
+	buf.Reset()
+	require.NoError(t, rootCmd.Run(ctx, []string{"testcli", "last", "get", "user.id"}))
+	assert.Equal(t, "7\n", buf.String())
+}
+
+// TestIntegration_ResponseCache_TemplateLastPerRoot tests that the `last`
+// template function reads the cache of the root running the command, even
+// with several roots in one process, and is not registered globally.
+func TestIntegration_ResponseCache_TemplateLastPerRoot(t *testing.T) {
+	setupTestCLI(t)
+	ctx := context.Background()
+	summary := protocli.MustTemplateFormat("summary", map[string]string{
+		"example.UserResponse": `previous: {{last "create" "user.name"}}`,
+	})
+
+	newRoot := func(name string) (*cli.Command, *bytes.Buffer) {
+		dir := t.TempDir()
+		require.NoError(t, protocli.NewResponseCache(dir).Store("user-service/create", &simple.UserResponse{
+			User: &simple.User{Name: name},
+		}))
+		userServiceCLI := simple.UserServiceCommand(ctx, newMockUserService, protocli.WithOutputFormats(summary))
+		rootCmd, err := protocli.RootCommand("testcli",
+			protocli.Service(userServiceCLI),
+			protocli.WithResponseCache(dir),
+		)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		setWriterOnAllCommands(rootCmd, &buf)
+		return rootCmd, &buf
+	}
+	rootA, outA := newRoot("Alice")
+	rootB, outB := newRoot("Bob")
+
+	args := []string{"testcli", "user-service", "get", "--id", "7", "--db-url", "postgres://localhost:5432/testdb", "--format", "summary"}
+	require.NoError(t, rootA.Run(ctx, args))
+	require.NoError(t, rootB.Run(ctx, args))
+	assert.Equal(t, "previous: Alice", strings.TrimSpace(outA.String()))
+	assert.Equal(t, "previous: Bob", strings.TrimSpace(outB.String()))
+
+	assert.NotContains(t, protocli.TemplateFunctions().Functions(), "last")
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -19,6 +20,8 @@ import (
 	"github.com/urfave/cli/v3"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
@@ -34,6 +37,11 @@ type ServiceCLI struct {
 	GatewayRegisterFunc func(ctx context.Context, mux any) error // mux is *runtime.ServeMux from grpc-gateway
 	LocalOnlyMethods    []string                                 // Full gRPC method paths that are local-only (e.g., "/pkg.Svc/Method")
 	TUIDescriptor       *TUIServiceDescriptor                    // nil if tui=false on service annotation
+	RootFlags           []cli.Flag                               // Global flags declared with the (cli.v1.file) option
+	Order               int                                      // Display position from (cli.service).order; lower sorts first
+	OutputFormats       []OutputFormat                           // Registered output formats, listed by the root formats command
+	InputFormats        []InputFormat                            // Accepted input formats, listed by the root formats command
+	ValidateCommands    []*cli.Command                           // Per-method request file checks, nested under the root validate command
 }
 
 // CLIName returns the service name, satisfying the CLIService interface.
@@ -69,10 +77,13 @@ func setupSlog(ctx context.Context, cmd *cli.Command, isDaemon bool, loggingConf
 	verbosity := cmd.String("verbosity")
 	level := parseVerbosity(verbosity)
 
-	// Create configuration context
+	// Create configuration context; logs go to stderr unless a custom
+	// configuration says otherwise, so color is decided for stderr
+	color, _ := colorEnabled(cmd, os.Stderr, isTerminal)
 	configCtx := &slogConfigContext{
 		isDaemon: isDaemon,
 		level:    level,
+		color:    color,
 	}
 
 	var logger *slog.Logger
@@ -96,7 +107,7 @@ func setupSlog(ctx context.Context, cmd *cli.Command, isDaemon bool, loggingConf
 			output = os.Stderr
 			handler = clilog.HumanFriendlySlogHandler(output, &slog.HandlerOptions{
 				Level: level,
-			})
+			}).WithColor(color)
 		}
 
 		logger = slog.New(handler)
@@ -105,6 +116,12 @@ func setupSlog(ctx context.Context, cmd *cli.Command, isDaemon bool, loggingConf
 	slog.SetDefault(logger)
 }
 
+// Hosts the daemon binds to by default (see WithDefaultBindLoopback).
+const (
+	allInterfacesHost = "0.0.0.0"
+	loopbackHost      = "127.0.0.1"
+)
+
 // NewDaemonizeCommand creates a daemonize command for the given services.
 // This is useful for single-service CLIs using the flat command structure.
 func NewDaemonizeCommand(_ context.Context, services []*ServiceCLI, _ ServiceConfig) *cli.Command {
@@ -113,9 +130,10 @@ func NewDaemonizeCommand(_ context.Context, services []*ServiceCLI, _ ServiceCon
 		Usage: "Start a gRPC server",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:  "host",
-				Value: "0.0.0.0",
-				Usage: "Host to bind the gRPC server to",
+				Name:    "host",
+				Aliases: []string{"bind"},
+				Value:   allInterfacesHost,
+				Usage:   "Host to bind the gRPC server to",
 			},
 			&cli.IntFlag{
 				Name:  "port",
@@ -152,25 +170,35 @@ func RootCommand(appName string, opts ...RootOption) (*cli.Command, error) {
 	// Access service registrations to check hoisting
 	// Type assert to access internal registrations
 	if opts, ok := options.(*rootCommandOptions); ok {
+		opts.sortServiceRegistrations()
 		for _, reg := range opts.serviceRegistrations {
 			services = append(services, reg.service)
 			if reg.hoisted {
 				// Hoisted: add RPC commands directly to root level
 				for _, rpcCmd := range reg.service.Command.Commands {
-					if commandNames[rpcCmd.Name] {
-						return nil, fmt.Errorf("%w: command '%s' from service '%s'",
-							ErrAmbiguousCommandInvocation, rpcCmd.Name, reg.service.ServiceName)
+					for _, name := range rpcCmd.Names() {
+						if commandNames[name] {
+							return nil, fmt.Errorf("%w: command '%s' from service '%s'",
+								ErrAmbiguousCommandInvocation, name, reg.service.ServiceName)
+						}
+						commandNames[name] = true
 					}
-					commandNames[rpcCmd.Name] = true
+					// Keep the service name for commands that no longer sit under it
+					if rpcCmd.Metadata == nil {
+						rpcCmd.Metadata = make(map[string]interface{})
+					}
+					rpcCmd.Metadata[ServiceMetadataKey] = reg.service.Command.Name
 					commands = append(commands, rpcCmd)
 				}
 			} else {
 				// Not hoisted: add service command as nested
-				if commandNames[reg.service.Command.Name] {
-					return nil, fmt.Errorf("%w: service command '%s'",
-						ErrAmbiguousCommandInvocation, reg.service.Command.Name)
+				for _, name := range reg.service.Command.Names() {
+					if commandNames[name] {
+						return nil, fmt.Errorf("%w: service command '%s'",
+							ErrAmbiguousCommandInvocation, name)
+					}
+					commandNames[name] = true
 				}
-				commandNames[reg.service.Command.Name] = true
 				commands = append(commands, reg.service.Command)
 			}
 		}
@@ -190,26 +218,37 @@ func RootCommand(appName string, opts ...RootOption) (*cli.Command, error) {
 			ErrAmbiguousCommandInvocation)
 	}
 
+	daemonFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:    "host",
+			Aliases: []string{"bind"},
+			Value:   options.DefaultBindHost(),
+			Usage:   "Host to bind the gRPC server to",
+		},
+		&cli.IntFlag{
+			Name:  "port",
+			Value: 50051,
+			Usage: "Port to bind the gRPC server to",
+		},
+		&cli.StringSliceFlag{
+			Name:  "service",
+			Usage: "Service to enable (by name). Can be specified multiple times. If not specified, all services are enabled. Example: --service userservice --service productservice",
+		},
+	}
+	// Probes share the gateway's HTTP port when transcoding; otherwise they need their own
+	if options.ProbesEnabled() && !options.EnableTranscoding() {
+		daemonFlags = append(daemonFlags, &cli.IntFlag{
+			Name:  "probe-port",
+			Value: 8081,
+			Usage: "Port to serve the /livez and /readyz HTTP probes on",
+		})
+	}
+
 	// Add daemonize command that registers all services
 	commands = append(commands, &cli.Command{
 		Name:  "daemonize",
 		Usage: "Start a gRPC server with all services",
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:  "host",
-				Value: "0.0.0.0",
-				Usage: "Host to bind the gRPC server to",
-			},
-			&cli.IntFlag{
-				Name:  "port",
-				Value: 50051,
-				Usage: "Port to bind the gRPC server to",
-			},
-			&cli.StringSliceFlag{
-				Name:  "service",
-				Usage: "Service to enable (by name). Can be specified multiple times. If not specified, all services are enabled. Example: --service userservice --service productservice",
-			},
-		},
+		Flags: daemonFlags,
 		Action: func(ctx context.Context, cmd *cli.Command) error {
 			return runDaemon(ctx, cmd, services, options)
 		},
@@ -247,13 +286,27 @@ func RootCommand(appName string, opts ...RootOption) (*cli.Command, error) {
 				ErrAmbiguousCommandInvocation)
 		}
 		commandNames["config"] = true
-		commands = append(commands, cliconfig.Commands(manager))
+		configCmd := cliconfig.Commands(manager)
+		configCmd.Commands = append(configCmd.Commands, newConfigExplainCommand(opts.configManager, opts.configServiceName))
+		if len(opts.configMigrations) > 0 {
+			configCmd.Commands = append(configCmd.Commands, newConfigMigrateCommand(opts.configMigrations))
+		}
+		commands = append(commands, configCmd)
+
+		// Add env command listing the config env vars
+		if commandNames["env"] {
+			return nil, fmt.Errorf("%w: 'env' command conflicts with a service command",
+				ErrAmbiguousCommandInvocation)
+		}
+		commandNames["env"] = true
+		commands = append(commands, newEnvCommand(opts.configManager, opts.configServiceName))
 	}
 
 	// Add auth command suite if enabled
 	var authCfg *cliauth.Config
 	if opts, ok := options.(*rootCommandOptions); ok && opts.loginProvider != nil {
-		authCfg = cliauth.NewConfig(appName, opts.loginProvider, opts.authOptions...)
+		authOpts := append([]cliauth.Option{cliauth.WithStatusWriter(authStatusWriter(opts.OutputFormats()))}, opts.authOptions...)
+		authCfg = cliauth.NewConfig(appName, opts.loginProvider, authOpts...)
 		if commandNames["auth"] {
 			return nil, fmt.Errorf("%w: 'auth' command conflicts with a service command",
 				ErrAmbiguousCommandInvocation)
@@ -262,6 +315,57 @@ func RootCommand(appName string, opts ...RootOption) (*cli.Command, error) {
 		commands = append(commands, cliauth.Commands(authCfg))
 	}
 
+	// Add last-response command if a response cache is configured
+	if cache := options.ResponseCache(); cache != nil {
+		if commandNames["last"] {
+			return nil, fmt.Errorf("%w: 'last' command conflicts with a service command",
+				ErrAmbiguousCommandInvocation)
+		}
+		commandNames["last"] = true
+		commands = append(commands, newLastCommand(cache))
+	}
+
+	// Add the connectivity check unless a service already provides a ping command
+	if !commandNames["ping"] {
+		commandNames["ping"] = true
+		commands = append(commands, newPingCommand())
+	}
+
+	// Add the bulk apply command unless a service already provides one
+	if !commandNames["apply"] {
+		commandNames["apply"] = true
+		commands = append(commands, newApplyCommand())
+	}
+
+	// Add the schema diff tool unless a service already provides a schema command
+	if !commandNames["schema"] {
+		commandNames["schema"] = true
+		commands = append(commands, newSchemaCommand())
+	}
+
+	// Add the format list unless a service already provides a formats command
+	if !commandNames["formats"] {
+		commandNames["formats"] = true
+		commands = append(commands, newFormatsListCommand(services))
+	}
+
+	// Add request file checks unless a service already provides a validate command
+	if validateCmd := newRootValidateCommand(services); validateCmd != nil && !commandNames["validate"] {
+		commandNames["validate"] = true
+		commands = append(commands, validateCmd)
+	}
+
+	// Add environment diagnostics unless a service already provides a doctor command
+	if !commandNames["doctor"] {
+		commandNames["doctor"] = true
+		var configMsg proto.Message
+		var configServiceName string
+		if opts, ok := options.(*rootCommandOptions); ok && opts.configManager != nil {
+			configMsg, configServiceName = opts.configManager, opts.configServiceName
+		}
+		commands = append(commands, newDoctorCommand(doctorChecks(configMsg, configServiceName, authCfg, options.TUIProvider() != nil)))
+	}
+
 	// Global flags including --config and --verbosity
 	globalFlags := []cli.Flag{
 		&cli.StringSliceFlag{
@@ -275,13 +379,33 @@ func RootCommand(appName string, opts ...RootOption) (*cli.Command, error) {
 			Usage:  "Environment variable prefix for config overrides",
 			Hidden: true,
 		},
+		&cli.StringFlag{
+			Name:   "secret-dir",
+			Value:  options.SecretDir(),
+			Usage:  "Directory of single-value secret files for config overrides",
+			Hidden: true,
+		},
 		&cli.StringFlag{
 			Name:    "verbosity",
 			Aliases: []string{"v"},
 			Value:   options.DefaultVerbosity(),
 			Usage:   "Log verbosity level (debug/4, info/3, warn/2, error/1, none/0)",
 		},
+		&cli.BoolFlag{
+			Name:  "timings",
+			Usage: "Print how long each phase of a command took to stderr",
+		},
+		&cli.DurationFlag{
+			Name:  "deadline",
+			Value: options.GlobalTimeout(),
+			Usage: "Abort the whole invocation after this long, e.g. 5m (0 for no limit)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "flag-file",
+			Usage: "Read more flags from a file with one or more flags per line (can specify multiple)",
+		},
 	}
+	globalFlags = append(globalFlags, ColorFlags()...)
 
 	if options.TUIProvider() != nil {
 		globalFlags = append(globalFlags, &cli.BoolFlag{
@@ -290,6 +414,46 @@ func RootCommand(appName string, opts ...RootOption) (*cli.Command, error) {
 		})
 	}
 
+	if options.InteractivePrompt() {
+		globalFlags = append(globalFlags, &cli.BoolFlag{
+			Name:  "pick",
+			Usage: "Pick a command from a searchable list and fill in its flags interactively",
+		})
+	}
+
+	// A single --quiet covers every stderr notice; subcommands read it through
+	// their lineage, so it must not be redefined below the root.
+	globalFlags = append(globalFlags, &cli.BoolFlag{
+		Name:  "quiet",
+		Usage: "Suppress notices on stderr: the startup banner, new version notice and stream reconnects",
+	})
+
+	if options.RemotesFile() != "" {
+		globalFlags = append(globalFlags, &cli.StringFlag{
+			Name:  "context",
+			Usage: "Call the named remote from " + options.RemotesFile() + " instead of passing --remote",
+		})
+	}
+
+	if options.Replayer() != nil {
+		globalFlags = append(globalFlags, &cli.BoolFlag{
+			Name:  "replay",
+			Usage: "Return recorded responses instead of calling the service",
+		})
+	}
+
+	// Add proto-declared root flags. Services from the same file share a declaration,
+	// so repeats are skipped; clashes with framework flags are rejected.
+	globalFlags, err := appendServiceRootFlags(globalFlags, services)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hide or disable method commands rejected by WithCommandFilter
+	if filter := options.CommandFilter(); filter != nil {
+		applyCommandFilter(commands, filter)
+	}
+
 	rootCmd := &cli.Command{
 		Name:     appName,
 		Usage:    fmt.Sprintf("%s - gRPC service CLI", appName),
@@ -308,24 +472,162 @@ func RootCommand(appName string, opts ...RootOption) (*cli.Command, error) {
 		})
 	}
 
+	// Store the config migrations so every ConfigLoader applies them on read.
+	if len(options.ConfigMigrations()) > 0 {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[configMigrationsKey] = options.ConfigMigrations()
+	}
+
+	// Store the required config validation setting so every ConfigLoader checks it.
+	if options.RequiredConfigValidation() {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[requiredConfigValidationKey] = true
+	}
+
+	// Store the config loaded hooks so every ConfigLoader calls them.
+	if len(options.ConfigLoadedHooks()) > 0 {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[configLoadedHooksKey] = options.ConfigLoadedHooks()
+	}
+
+	// Store the progress reporter so generated streaming commands can feed it.
+	if options.ProgressReporter() != nil {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[progressReporterKey] = options.ProgressReporter()
+	}
+
+	// Store the response cache so generated unary commands can record responses.
+	if options.ResponseCache() != nil {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[responseCacheKey] = options.ResponseCache()
+	}
+
+	// Store the recorders so generated commands can record and replay responses.
+	if options.Recorder() != nil {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[recordKey] = options.Recorder()
+	}
+	if options.Replayer() != nil {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[replayKey] = options.Replayer()
+	}
+
+	// Store the request size limit so generated commands can enforce it.
+	if options.MaxRequestSize() > 0 {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[maxRequestSizeKey] = options.MaxRequestSize()
+	}
+
+	// Store the format panic recovery setting for FormatMessage.
+	if !options.FormatPanicRecovery() {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[formatPanicRecoveryKey] = false
+	}
+
+	// Store the positional argument handler for CheckArgs.
+	if handler := options.ArgErrorHandler(); handler != nil {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[argErrorHandlerKey] = handler
+	}
+
+	// Store the method panic recovery setting for RecoverMethodPanic.
+	if !options.MethodPanicRecovery() {
+		if rootCmd.Metadata == nil {
+			rootCmd.Metadata = make(map[string]interface{})
+		}
+		rootCmd.Metadata[methodPanicRecoveryKey] = false
+	}
+
+	// Without a subcommand, --pick chooses one interactively; otherwise show help as usual
+	if options.InteractivePrompt() {
+		rootCmd.Action = func(ctx context.Context, cmd *cli.Command) error {
+			if cmd.Bool("pick") {
+				return runPicker(ctx, cmd)
+			}
+			if cmd.Args().Present() {
+				return cli.ShowCommandHelp(ctx, cmd, cmd.Args().First())
+			}
+			return cli.ShowRootCommandHelp(cmd)
+		}
+	}
+
 	// Add Before hook to setup slog for non-daemon commands
 	rootCmd.Before = func(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+		// Apply --flag-file first so that it can also set root flags like --verbosity
+		if err := applyFlagFiles(cmd.Root()); err != nil {
+			return ctx, err
+		}
+
+		// Bound the rest of the run, hooks included, by --deadline
+		ctx = startGlobalDeadline(ctx, cmd.Root())
+
+		// Reject an unknown --color mode before anything is written
+		if _, err := colorEnabled(cmd.Root(), os.Stderr, isTerminal); err != nil {
+			return ctx, err
+		}
+
 		// Setup slog for single command mode (non-daemon)
 		// For daemon mode, setupSlog is called in runDaemon
 		if cmd.Name != "daemonize" {
 			setupSlog(ctx, cmd.Root(), false, options.LoggingConfig())
 		}
 
+		// Print the startup banner to stderr so piped stdout stays clean
+		if banner := options.BannerFunc(); banner != nil && !cmd.Root().Bool("quiet") {
+			w := cmd.Root().ErrWriter
+			if w == nil {
+				w = os.Stderr
+			}
+			banner(w)
+		}
+
+		// Deprecation and ignored format flag warnings are logged once per run
+		delete(cmd.Root().Metadata, deprecationWarningsKey)
+		delete(cmd.Root().Metadata, formatFlagWarningsKey)
+
+		// Point the command at the remote selected with --context
+		if path := options.RemotesFile(); path != "" {
+			var err error
+			if ctx, err = applyRemoteContext(ctx, cmd.Root(), path); err != nil {
+				return ctx, err
+			}
+		}
+
 		// Decorate context with auth metadata if configured
 		if authCfg != nil {
 			ctx = cliauth.DecorateContext(ctx, authCfg)
 		}
 
+		// Inject app state for service implementations
+		for _, fn := range options.ContextValues() {
+			ctx = fn(ctx)
+		}
+
 		// Launch interactive TUI if --interactive flag is set on the root command
 		// (deep-link cases are handled by generated Before hooks on service/method commands)
 		if options.TUIProvider() != nil && cmd.Root().Bool("interactive") {
-			if cmd.Args().Len() > 0 {
-				return ctx, cli.Exit(fmt.Sprintf("unsupported argument: %q", cmd.Args().Get(0)), 3)
+			if err := CheckArgs(cmd); err != nil {
+				return ctx, err
 			}
 			if err := InvokeTUI(ctx, cmd); err != nil {
 				return ctx, err
@@ -336,6 +638,41 @@ func RootCommand(appName string, opts ...RootOption) (*cli.Command, error) {
 		return ctx, nil
 	}
 
+	// Release the --deadline timer once the run is over
+	rootCmd.After = func(ctx context.Context, _ *cli.Command) error {
+		stopGlobalDeadline(ctx)
+		return nil
+	}
+
+	// Let scripts pick a default output format once via <PREFIX>_FORMAT
+	if prefix := options.EnvPrefix(); prefix != "" {
+		applyFormatEnv(rootCmd, prefix+"_FORMAT")
+	}
+
+	// Compress remote calls by default with WithRemoteCompression
+	if name := options.RemoteCompression(); name != "" {
+		if name != compressionNone {
+			if err := checkCompression(name); err != nil {
+				return nil, err
+			}
+		}
+		applyCompressionDefault(rootCmd, name)
+	}
+
+	// Report --deadline errors as such, inside auditing so that audit records
+	// report the deadline error
+	wrapDeadlineErrors(rootCmd)
+
+	// Audit every invocation once the command tree is complete
+	if sink := options.AuditSink(); sink != nil {
+		wrapAuditActions(rootCmd, sink, authCfg)
+	}
+
+	// Look for a newer version while commands run
+	if url := options.UpdateCheckURL(); url != "" {
+		wrapUpdateCheckActions(rootCmd, url)
+	}
+
 	// Apply help customization if provided
 	if helpCustom := options.HelpCustomization(); helpCustom != nil {
 		// Set custom help templates if provided
@@ -352,8 +689,39 @@ func RootCommand(appName string, opts ...RootOption) (*cli.Command, error) {
 var (
 	ErrWrongConfigType            = errors.New("wrong config type")
 	ErrAmbiguousCommandInvocation = errors.New("more than one action registered for the same command")
+	ErrRootFlagConflict           = errors.New("root flag conflicts with a built-in flag")
 )
 
+// appendServiceRootFlags appends the root flags declared by services to flags.
+// A flag whose name was already added by another service is skipped; one that
+// matches a built-in flag name or alias returns ErrRootFlagConflict.
+func appendServiceRootFlags(flags []cli.Flag, services []*ServiceCLI) ([]cli.Flag, error) {
+	builtin := make(map[string]bool)
+	for _, f := range flags {
+		for _, name := range f.Names() {
+			builtin[name] = true
+		}
+	}
+
+	added := make(map[string]bool)
+	for _, svc := range services {
+		for _, f := range svc.RootFlags {
+			name := f.Names()[0]
+			if added[name] {
+				continue
+			}
+			for _, n := range f.Names() {
+				if builtin[n] {
+					return nil, fmt.Errorf("%w: '--%s' from service '%s'", ErrRootFlagConflict, n, svc.ServiceName)
+				}
+			}
+			added[name] = true
+			flags = append(flags, f)
+		}
+	}
+	return flags, nil
+}
+
 // createServiceImpl loads config and creates service implementation.
 func createServiceImpl(
 	loader *ConfigLoader,
@@ -437,6 +805,7 @@ func runDaemon(ctx context.Context, cmd *cli.Command, services []*ServiceCLI, op
 	loader := NewConfigLoader(DaemonMode,
 		FileConfig(configFilePaths...),
 		EnvPrefix(options.EnvPrefix()),
+		SecretDir(options.SecretDir()),
 	)
 
 	// Create service implementations with config
@@ -467,6 +836,13 @@ func runDaemon(ctx context.Context, cmd *cli.Command, services []*ServiceCLI, op
 
 	// Create gRPC server with configured options
 	serverOpts := options.GRPCServerOptions()
+	if options.AccessLogEnabled() {
+		sampler := newAccessLogSampler(options.AccessLogSampling())
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(accessLogUnaryInterceptor(sampler)),
+			grpc.ChainStreamInterceptor(accessLogStreamInterceptor(sampler)),
+		)
+	}
 	if !options.IgnoreLocalOnly() {
 		localOnlySet := collectLocalOnlyMethods(servicesToRegister)
 		if len(localOnlySet) > 0 {
@@ -476,19 +852,35 @@ func runDaemon(ctx context.Context, cmd *cli.Command, services []*ServiceCLI, op
 			)
 		}
 	}
+	if limit := options.MaxRequestSize(); limit > 0 {
+		serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(limit))
+	}
+	if limits := options.RateLimits(); len(limits) > 0 {
+		limiter := newMethodRateLimiter(limits)
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(rateLimitUnaryInterceptor(limiter)),
+			grpc.ChainStreamInterceptor(rateLimitStreamInterceptor(limiter)),
+		)
+	}
 	grpcServer := grpc.NewServer(serverOpts...)
 
 	// Create gateway mux if transcoding is enabled
 	var gwMux *runtime.ServeMux
 	if options.EnableTranscoding() {
-		gwMux = runtime.NewServeMux()
+		gwMux = runtime.NewServeMux(gatewayMarshalerOptions(cmd, options.OutputFormats())...)
 	}
 
-	// Run OnDaemonStartup hooks (before server starts listening)
+	// Run OnDaemonStartup hooks (before server starts listening). hookCtx
+	// carries values they add on to the ready and shutdown hooks.
+	hookCtx := ctx
 	for i, hook := range options.DaemonStartupHooks() {
-		if err := hook(ctx, grpcServer, gwMux); err != nil {
+		next, err := hook(hookCtx, grpcServer, gwMux)
+		if err != nil {
 			return fmt.Errorf("daemon startup hook %d failed: %w", i, err)
 		}
+		if next != nil {
+			hookCtx = next
+		}
 	}
 
 	// Register selected services with their implementations
@@ -497,13 +889,55 @@ func runDaemon(ctx context.Context, cmd *cli.Command, services []*ServiceCLI, op
 		svc.RegisterFunc(grpcServer, impl)
 	}
 
+	// Register the gRPC health service backing the HTTP probes. It reports
+	// NOT_SERVING until the server is listening.
+	var healthServer *health.Server
+	var probeMux *http.ServeMux
+	if options.ProbesEnabled() {
+		healthServer = health.NewServer()
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+		probeMux = http.NewServeMux()
+		probeMux.Handle("GET /livez", livezHandler())
+		probeMux.Handle("GET /readyz", readyzHandler(healthServer, options.ReadinessChecks()))
+	}
+
 	// Create TCP listener
 	lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", address)
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %w", address, err)
 	}
 
-	slog.Info("Starting gRPC server", "address", address, "services", len(servicesToRegister))
+	// Start the HTTP side: the gateway (with probes mounted) or a probe-only server
+	var httpServer *http.Server
+	switch {
+	case gwMux != nil:
+		if probeMux != nil {
+			for _, path := range []string{"/livez", "/readyz"} {
+				if err := gwMux.HandlePath(http.MethodGet, path, func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+					probeMux.ServeHTTP(w, r)
+				}); err != nil {
+					return fmt.Errorf("failed to register %s probe: %w", path, err)
+				}
+			}
+		}
+		httpAddress := fmt.Sprintf("%s:%d", host, options.TranscodingPort())
+		if httpServer, err = serveHTTP(ctx, httpAddress, gwMux); err != nil {
+			_ = lis.Close()
+			return err
+		}
+		slog.Info("Starting HTTP gateway", "address", httpAddress)
+	case probeMux != nil:
+		probeAddress := fmt.Sprintf("%s:%d", host, cmd.Int("probe-port"))
+		if httpServer, err = serveHTTP(ctx, probeAddress, probeMux); err != nil {
+			_ = lis.Close()
+			return err
+		}
+		slog.Info("Serving HTTP probes", "address", probeAddress)
+	}
+
+	slog.Info("Starting gRPC server", "address", lis.Addr().String(), "services", len(servicesToRegister))
 
 	// Setup signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -516,19 +950,32 @@ func runDaemon(ctx context.Context, cmd *cli.Command, services []*ServiceCLI, op
 		servErr <- grpcServer.Serve(lis)
 	}()
 
+	if healthServer != nil {
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	}
+
 	// Run OnDaemonReady hooks (after server is ready to accept connections)
 	for _, hook := range options.DaemonReadyHooks() {
-		hook(ctx)
+		hook(hookCtx)
+	}
+
+	// Stop the HTTP side once the gRPC server is done
+	if httpServer != nil {
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), options.GracefulShutdownTimeout())
+			defer cancel()
+			_ = httpServer.Shutdown(shutdownCtx)
+		}()
 	}
 
 	// Wait for signal, context cancellation, or server error
 	select {
 	case sig := <-sigChan:
 		slog.Info("Received signal, initiating graceful shutdown", "signal", sig)
-		return gracefulShutdown(ctx, grpcServer, options)
+		return gracefulShutdown(hookCtx, grpcServer, healthServer, options)
 	case <-ctx.Done():
 		slog.Info("Context cancelled, initiating graceful shutdown")
-		return gracefulShutdown(ctx, grpcServer, options)
+		return gracefulShutdown(hookCtx, grpcServer, healthServer, options)
 	case err := <-servErr:
 		if err != nil {
 			return fmt.Errorf("server error: %w", err)
@@ -580,8 +1027,14 @@ func localOnlyStreamInterceptor(methods map[string]bool) grpc.StreamServerInterc
 	}
 }
 
-// gracefulShutdown handles graceful shutdown with timeout and hooks.
-func gracefulShutdown(ctx context.Context, grpcServer *grpc.Server, options RootConfig) error {
+// gracefulShutdown handles graceful shutdown with timeout and hooks. ctx is
+// the context returned by the startup hooks; only its values are used.
+// healthServer, when non-nil, is switched to NOT_SERVING first so readiness probes fail while draining.
+func gracefulShutdown(ctx context.Context, grpcServer *grpc.Server, healthServer *health.Server, options RootConfig) error {
+	if healthServer != nil {
+		healthServer.Shutdown()
+	}
+
 	timeout := options.GracefulShutdownTimeout()
 
 	// Create shutdown context with timeout
@@ -0,0 +1,315 @@
+package protocli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// SchemaChangeKind says whether a schema element was added, removed or changed.
+type SchemaChangeKind int
+
+const (
+	SchemaAdded SchemaChangeKind = iota
+	SchemaRemoved
+	SchemaChanged
+)
+
+// String returns the diff marker for k: "+", "-" or "~".
+func (k SchemaChangeKind) String() string {
+	switch k {
+	case SchemaAdded:
+		return "+"
+	case SchemaRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// SchemaChange is one difference reported by DiffSchemas.
+type SchemaChange struct {
+	Kind    SchemaChangeKind
+	Element string                // "message", "field", "service" or "method"
+	Name    protoreflect.FullName // Full name of the element, e.g. "example.User.email"
+	Detail  string                // Type of an added or removed field or method, or what changed
+}
+
+// String formats c as a diff line, e.g. "~ field example.User.age: int32 -> int64".
+func (c SchemaChange) String() string {
+	line := fmt.Sprintf("%s %s %s", c.Kind, c.Element, c.Name)
+	if c.Detail != "" {
+		line += ": " + c.Detail
+	}
+	return line
+}
+
+// DiffSchemas reports the messages, fields, services and methods of the given
+// packages that were added to, removed from or changed in current relative to
+// base, ordered by name. Elements are matched by full name, so a renamed field
+// shows up as removed and added.
+func DiffSchemas(base, current *protoregistry.Files, packages ...protoreflect.FullName) []SchemaChange {
+	baseMsgs, baseSvcs := collectSchema(base, packages)
+	curMsgs, curSvcs := collectSchema(current, packages)
+
+	var changes []SchemaChange
+	diffByName(baseMsgs, curMsgs, "message", &changes,
+		func(b, c protoreflect.MessageDescriptor) {
+			diffByName(fieldsByName(b), fieldsByName(c), "field", &changes, func(b, c protoreflect.FieldDescriptor) {
+				for _, d := range fieldDifferences(b, c) {
+					changes = append(changes, SchemaChange{Kind: SchemaChanged, Element: "field", Name: c.FullName(), Detail: d})
+				}
+			})
+		})
+	diffByName(baseSvcs, curSvcs, "service", &changes,
+		func(b, c protoreflect.ServiceDescriptor) {
+			diffByName(methodsByName(b), methodsByName(c), "method", &changes, func(b, c protoreflect.MethodDescriptor) {
+				if bs, cs := methodSignature(b), methodSignature(c); bs != cs {
+					changes = append(changes, SchemaChange{Kind: SchemaChanged, Element: "method", Name: c.FullName(), Detail: bs + " -> " + cs})
+				}
+			})
+		})
+
+	slices.SortStableFunc(changes, func(a, b SchemaChange) int {
+		return strings.Compare(string(a.Name), string(b.Name))
+	})
+	return changes
+}
+
+// diffByName appends a change for each element only in base or current and
+// calls both for elements in both.
+func diffByName[D protoreflect.Descriptor](base, current map[protoreflect.FullName]D, element string, changes *[]SchemaChange, both func(b, c D)) {
+	for name, b := range base {
+		if _, ok := current[name]; !ok {
+			*changes = append(*changes, SchemaChange{Kind: SchemaRemoved, Element: element, Name: name, Detail: describeElement(b)})
+		}
+	}
+	for name, c := range current {
+		b, ok := base[name]
+		if !ok {
+			*changes = append(*changes, SchemaChange{Kind: SchemaAdded, Element: element, Name: name, Detail: describeElement(c)})
+			continue
+		}
+		both(b, c)
+	}
+}
+
+// describeElement returns the detail shown for an added or removed field or
+// method; messages and services have none.
+func describeElement(d protoreflect.Descriptor) string {
+	switch d := d.(type) {
+	case protoreflect.FieldDescriptor:
+		return fmt.Sprintf("%s = %d", fieldTypeName(d), d.Number())
+	case protoreflect.MethodDescriptor:
+		return methodSignature(d)
+	default:
+		return ""
+	}
+}
+
+// collectSchema returns the messages, including nested ones, and services
+// declared in files of the given packages.
+func collectSchema(files *protoregistry.Files, packages []protoreflect.FullName) (map[protoreflect.FullName]protoreflect.MessageDescriptor, map[protoreflect.FullName]protoreflect.ServiceDescriptor) {
+	msgs := make(map[protoreflect.FullName]protoreflect.MessageDescriptor)
+	svcs := make(map[protoreflect.FullName]protoreflect.ServiceDescriptor)
+	var addMessages func(protoreflect.MessageDescriptors)
+	addMessages = func(mds protoreflect.MessageDescriptors) {
+		for i := range mds.Len() {
+			md := mds.Get(i)
+			if md.IsMapEntry() {
+				continue
+			}
+			msgs[md.FullName()] = md
+			addMessages(md.Messages())
+		}
+	}
+	for _, pkg := range packages {
+		files.RangeFilesByPackage(pkg, func(fd protoreflect.FileDescriptor) bool {
+			addMessages(fd.Messages())
+			for i := range fd.Services().Len() {
+				svcs[fd.Services().Get(i).FullName()] = fd.Services().Get(i)
+			}
+			return true
+		})
+	}
+	return msgs, svcs
+}
+
+// fieldsByName indexes the fields of md by full name.
+func fieldsByName(md protoreflect.MessageDescriptor) map[protoreflect.FullName]protoreflect.FieldDescriptor {
+	fields := make(map[protoreflect.FullName]protoreflect.FieldDescriptor, md.Fields().Len())
+	for i := range md.Fields().Len() {
+		fields[md.Fields().Get(i).FullName()] = md.Fields().Get(i)
+	}
+	return fields
+}
+
+// methodsByName indexes the methods of sd by full name.
+func methodsByName(sd protoreflect.ServiceDescriptor) map[protoreflect.FullName]protoreflect.MethodDescriptor {
+	methods := make(map[protoreflect.FullName]protoreflect.MethodDescriptor, sd.Methods().Len())
+	for i := range sd.Methods().Len() {
+		methods[sd.Methods().Get(i).FullName()] = sd.Methods().Get(i)
+	}
+	return methods
+}
+
+// fieldDifferences describes how field c differs from field b.
+func fieldDifferences(b, c protoreflect.FieldDescriptor) []string {
+	var diffs []string
+	if bt, ct := fieldTypeName(b), fieldTypeName(c); bt != ct {
+		diffs = append(diffs, bt+" -> "+ct)
+	}
+	if b.Number() != c.Number() {
+		diffs = append(diffs, fmt.Sprintf("number %d -> %d", b.Number(), c.Number()))
+	}
+	return diffs
+}
+
+// fieldTypeName returns the type of fd as written in a .proto file, e.g.
+// "repeated string", "optional int32" or "map<string, example.User>".
+func fieldTypeName(fd protoreflect.FieldDescriptor) string {
+	if fd.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", fieldTypeName(fd.MapKey()), fieldTypeName(fd.MapValue()))
+	}
+	name := fd.Kind().String()
+	switch {
+	case fd.Message() != nil:
+		name = string(fd.Message().FullName())
+	case fd.Enum() != nil:
+		name = string(fd.Enum().FullName())
+	}
+	switch {
+	case fd.IsList():
+		return "repeated " + name
+	case fd.HasOptionalKeyword():
+		return "optional " + name
+	default:
+		return name
+	}
+}
+
+// methodSignature returns the request and response types of md, e.g.
+// "(example.GetUserRequest) returns (stream example.UserResponse)".
+func methodSignature(md protoreflect.MethodDescriptor) string {
+	streamPrefix := func(streaming bool) string {
+		if streaming {
+			return "stream "
+		}
+		return ""
+	}
+	return fmt.Sprintf("(%s%s) returns (%s%s)",
+		streamPrefix(md.IsStreamingClient()), md.Input().FullName(),
+		streamPrefix(md.IsStreamingServer()), md.Output().FullName())
+}
+
+// LoadDescriptorSet reads a binary FileDescriptorSet, as written by
+// `buf build -o` or `protoc --descriptor_set_out`. Imports missing from the
+// set (e.g. without --include_imports) are left unresolved, which keeps type
+// names intact for DiffSchemas.
+func LoadDescriptorSet(path string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid descriptor set %s: %w", path, err)
+	}
+	files := new(protoregistry.Files)
+	for _, fdp := range set.GetFile() {
+		fd, err := protodesc.FileOptions{AllowUnresolvable: true}.New(fdp, files)
+		if err != nil {
+			return nil, fmt.Errorf("invalid descriptor set %s: %w", path, err)
+		}
+		if err := files.RegisterFile(fd); err != nil {
+			return nil, fmt.Errorf("invalid descriptor set %s: %w", path, err)
+		}
+	}
+	return files, nil
+}
+
+// cliPackages returns the proto packages of the services behind the method
+// commands under cmd, sorted.
+func cliPackages(cmd *cli.Command) []protoreflect.FullName {
+	var packages []protoreflect.FullName
+	var walk func(*cli.Command)
+	walk = func(cmd *cli.Command) {
+		for _, sub := range cmd.Commands {
+			walk(sub)
+			method, ok := sub.Metadata[MethodMetadataKey].(string)
+			if !ok {
+				continue
+			}
+			service, _, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+			d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+			if err != nil {
+				continue
+			}
+			if pkg := d.ParentFile().Package(); !slices.Contains(packages, pkg) {
+				packages = append(packages, pkg)
+			}
+		}
+	}
+	walk(cmd)
+	slices.Sort(packages)
+	return packages
+}
+
+// newSchemaCommand returns `schema`, whose diff subcommand compares the
+// schema compiled into the CLI with a descriptor set.
+func newSchemaCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "schema",
+		Usage: "Inspect the protobuf schema compiled into this CLI",
+		Commands: []*cli.Command{{
+			Name:      "diff",
+			Usage:     "Compare the compiled schema with a descriptor set",
+			ArgsUsage: "<descriptor-set>",
+			Description: "Lists the messages, fields, services and methods of this CLI's proto packages\n" +
+				"that were added (+), removed (-) or changed (~) relative to a FileDescriptorSet,\n" +
+				"such as the output of `buf build -o` for a previous release.\n" +
+				"Example: mycli schema diff v1.binpb",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "exit-code",
+					Usage: "Exit with status 1 when the schemas differ",
+				},
+			},
+			Action: func(_ context.Context, cmd *cli.Command) error {
+				if cmd.Args().Len() != 1 {
+					return cli.Exit("expected exactly one descriptor set path", 3)
+				}
+				base, err := LoadDescriptorSet(cmd.Args().First())
+				if err != nil {
+					return err
+				}
+				changes := DiffSchemas(base, protoregistry.GlobalFiles, cliPackages(cmd.Root())...)
+
+				w := cmd.Root().Writer
+				if w == nil {
+					w = os.Stdout
+				}
+				if len(changes) == 0 {
+					_, _ = fmt.Fprintln(w, "No schema changes")
+					return nil
+				}
+				for _, c := range changes {
+					_, _ = fmt.Fprintln(w, c)
+				}
+				if cmd.Bool("exit-code") {
+					return cli.Exit("", 1)
+				}
+				return nil
+			},
+		}},
+	}
+}
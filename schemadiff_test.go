@@ -0,0 +1,175 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/drewfead/proto-cli/examples/simple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// writeDescriptorSet writes files as a binary FileDescriptorSet and returns its path.
+func writeDescriptorSet(t *testing.T, files ...*descriptorpb.FileDescriptorProto) string {
+	t.Helper()
+	data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: files})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "schema.binpb")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func fixtureField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, repeated bool) *descriptorpb.FieldDescriptorProto {
+	label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	if repeated {
+		label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	}
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Type:     typ.Enum(),
+		Label:    label.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+func fixtureMethod(name string, serverStreaming bool) *descriptorpb.MethodDescriptorProto {
+	return &descriptorpb.MethodDescriptorProto{
+		Name:            proto.String(name),
+		InputType:       proto.String(".fixture.User"),
+		OutputType:      proto.String(".fixture.User"),
+		ServerStreaming: proto.Bool(serverStreaming),
+	}
+}
+
+func TestUnit_DiffSchemas(t *testing.T) {
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING
+	v1 := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("fixture/v1.proto"),
+		Package: proto.String("fixture"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				fixtureField("name", 1, str, "", false),
+				fixtureField("age", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, "", false),
+				fixtureField("email", 3, str, "", false),
+			},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name:   proto.String("Users"),
+			Method: []*descriptorpb.MethodDescriptorProto{fixtureMethod("Get", false), fixtureMethod("Delete", false)},
+		}},
+	}
+	v2 := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("fixture/v2.proto"),
+		Package: proto.String("fixture"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					fixtureField("name", 1, str, "", false),
+					fixtureField("age", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, "", false),
+					fixtureField("tags", 4, str, "", true),
+					fixtureField("address", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".fixture.Address", false),
+				},
+			},
+			{
+				Name:  proto.String("Address"),
+				Field: []*descriptorpb.FieldDescriptorProto{fixtureField("city", 1, str, "", false)},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name:   proto.String("Users"),
+			Method: []*descriptorpb.MethodDescriptorProto{fixtureMethod("Get", true), fixtureMethod("List", false)},
+		}},
+	}
+
+	base, err := protocli.LoadDescriptorSet(writeDescriptorSet(t, v1))
+	require.NoError(t, err)
+	current, err := protocli.LoadDescriptorSet(writeDescriptorSet(t, v2))
+	require.NoError(t, err)
+
+	var lines []string
+	for _, c := range protocli.DiffSchemas(base, current, "fixture") {
+		lines = append(lines, c.String())
+	}
+	assert.Equal(t, []string{
+		"+ message fixture.Address",
+		"+ field fixture.User.address: fixture.Address = 5",
+		"~ field fixture.User.age: int32 -> int64",
+		"- field fixture.User.email: string = 3",
+		"+ field fixture.User.tags: repeated string = 4",
+		"- method fixture.Users.Delete: (fixture.User) returns (fixture.User)",
+		"~ method fixture.Users.Get: (fixture.User) returns (fixture.User) -> (fixture.User) returns (stream fixture.User)",
+		"+ method fixture.Users.List: (fixture.User) returns (fixture.User)",
+	}, lines)
+
+	assert.Empty(t, protocli.DiffSchemas(base, base, "fixture"))
+}
+
+// TestIntegration_SchemaDiffCommand compares the schema compiled into the
+// example CLI with an older descriptor set of it that lacks a field and a method.
+func TestIntegration_SchemaDiffCommand(t *testing.T) {
+	old := protodesc.ToFileDescriptorProto(simple.File_examples_simple_example_proto)
+	for _, msg := range old.GetMessageType() {
+		if msg.GetName() == "User" {
+			msg.Field = slices.DeleteFunc(msg.Field, func(f *descriptorpb.FieldDescriptorProto) bool {
+				return f.GetName() == "email"
+			})
+		}
+	}
+	for _, svc := range old.GetService() {
+		if svc.GetName() == "AdminService" {
+			svc.Method = slices.DeleteFunc(svc.Method, func(m *descriptorpb.MethodDescriptorProto) bool {
+				return m.GetName() == "RunCheck"
+			})
+		}
+	}
+	path := writeDescriptorSet(t, old)
+
+	run := func(args ...string) (string, error) {
+		ctx := context.Background()
+		rootCmd, err := protocli.RootCommand("testcli",
+			protocli.Service(simple.UserServiceCommand(ctx, newMockUserService(nil))),
+			protocli.Service(simple.AdminServiceCommand(ctx, &simple.MockAdminServiceServer{})),
+		)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		rootCmd.Writer = &buf
+		err = rootCmd.Run(ctx, append([]string{"testcli", "schema", "diff"}, args...))
+		return buf.String(), err
+	}
+
+	out, err := run(path)
+	require.NoError(t, err)
+	assert.Equal(t, "+ method example.AdminService.RunCheck: (example.CheckRequest) returns (example.CheckResponse)\n"+
+		"+ field example.User.email: string = 3\n", out)
+
+	exitCode := -1
+	origExiter := cli.OsExiter
+	t.Cleanup(func() { cli.OsExiter = origExiter })
+	cli.OsExiter = func(code int) { exitCode = code }
+	_, err = run("--exit-code", path)
+	require.Error(t, err)
+	assert.Equal(t, 1, exitCode)
+
+	current := protodesc.ToFileDescriptorProto(simple.File_examples_simple_example_proto)
+	out, err = run(writeDescriptorSet(t, current))
+	require.NoError(t, err)
+	assert.Equal(t, "No schema changes\n", out)
+}
@@ -0,0 +1,35 @@
+package protocli
+
+import "strings"
+
+// shellSafeChars are the characters that never need quoting in a POSIX shell word.
+const shellSafeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@%+,"
+
+// ShellQuote returns s quoted so a POSIX shell treats it as a single literal word.
+// Safe values are returned unchanged; everything else is wrapped in single quotes,
+// with each embedded single quote closed, escaped and reopened. For example:
+//
+//	hello       → hello
+//	hello world → 'hello world'
+//	it's        → 'it'\''s'
+//	*.go        → '*.go'
+//	(empty)     → ''
+func ShellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if strings.Trim(s, shellSafeChars) == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellJoin quotes each of args with ShellQuote and joins them into a command
+// line that a POSIX shell splits back into args.
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = ShellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
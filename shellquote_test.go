@@ -0,0 +1,33 @@
+package protocli_test
+
+import (
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnit_ShellQuote(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "hello", want: "hello"},
+		{in: "hello world", want: "'hello world'"},
+		{in: "it's", want: `'it'\''s'`},
+		{in: "'", want: `''\'''`},
+		{in: "*.go", want: "'*.go'"},
+		{in: "*", want: "'*'"},
+		{in: "", want: "''"},
+		{in: "a=b,c", want: "a=b,c"},
+		{in: "$HOME", want: "'$HOME'"},
+		{in: "`id`", want: "'`id`'"},
+		{in: `say "hi"`, want: `'say "hi"'`},
+		{in: "a;b|c&d", want: "'a;b|c&d'"},
+		{in: "é\x00", want: "'é\x00'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, protocli.ShellQuote(tt.in))
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrInvalidTime is returned for a --since or --until value that is neither a
+// duration nor a time.
+var ErrInvalidTime = errors.New("invalid time")
+
+// timeLayouts are the absolute time formats accepted by ParseTime, tried in
+// order. Layouts without a zone are read in local time.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTime parses a --since or --until value relative to now: "now", a
+// duration into the past such as "1h", "1h ago" or "2d ago" (d is 24h), or an
+// absolute time such as "2024-01-02T15:04:05Z", "2024-01-02 15:04" or
+// "2024-01-02".
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "now" {
+		return now, nil
+	}
+	if d, err := parseAgo(strings.TrimSpace(strings.TrimSuffix(value, "ago"))); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w %q (use a duration such as 1h or \"2d ago\", or a time such as 2024-01-02T15:04:05Z)", ErrInvalidTime, value)
+}
+
+// parseAgo parses a non-negative duration, also accepting a whole number of
+// days such as "2d".
+func parseAgo(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative duration %s", value)
+	}
+	return d, nil
+}
+
+// StreamWindow drops streamed messages whose timestamp_field falls outside the
+// window given by the --since and --until flags. Both bounds are inclusive.
+type StreamWindow struct {
+	path  []protoreflect.Name
+	since time.Time
+	until time.Time
+}
+
+// NewStreamWindow reads --since and --until from cmd. timestampField is the
+// dot-separated timestamp_field annotation of the method.
+func NewStreamWindow(cmd *cli.Command, timestampField string) (*StreamWindow, error) {
+	w := &StreamWindow{path: splitFieldPath(timestampField)}
+	now := time.Now()
+	for _, bound := range []struct {
+		flag string
+		t    *time.Time
+	}{{"since", &w.since}, {"until", &w.until}} {
+		value := cmd.String(bound.flag)
+		if value == "" {
+			continue
+		}
+		t, err := ParseTime(value, now)
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", bound.flag, err)
+		}
+		*bound.t = t
+	}
+	if !w.since.IsZero() && !w.until.IsZero() && w.until.Before(w.since) {
+		return nil, fmt.Errorf("%w: --until %s is before --since %s", ErrInvalidTime,
+			w.until.Format(time.RFC3339), w.since.Format(time.RFC3339))
+	}
+	return w, nil
+}
+
+// Contains reports whether msg should be written: always when neither --since
+// nor --until is set, otherwise only when its timestamp is within the window.
+// Messages without a readable timestamp are dropped while a window is set.
+func (w *StreamWindow) Contains(msg proto.Message) bool {
+	if w.since.IsZero() && w.until.IsZero() {
+		return true
+	}
+	t, ok := messageTime(msg.ProtoReflect(), w.path)
+	if !ok {
+		return false
+	}
+	return !t.Before(w.since) && (w.until.IsZero() || !t.After(w.until))
+}
+
+// messageTime reads the time at path in m from a google.protobuf.Timestamp,
+// an integer of Unix seconds or an RFC 3339 string field.
+func messageTime(m protoreflect.Message, path []protoreflect.Name) (time.Time, bool) {
+	v, fd, ok := getFieldPath(m, path)
+	if !ok || fd.IsList() || fd.IsMap() {
+		return time.Time{}, false
+	}
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		ts := v.Message()
+		desc := ts.Descriptor()
+		if desc.FullName() != "google.protobuf.Timestamp" {
+			return time.Time{}, false
+		}
+		seconds := ts.Get(desc.Fields().ByName("seconds")).Int()
+		nanos := ts.Get(desc.Fields().ByName("nanos")).Int()
+		return time.Unix(seconds, nanos), true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return time.Unix(v.Int(), 0), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return time.Unix(int64(v.Uint()), 0), true //nolint:gosec // Unix seconds fit in int64
+	case protoreflect.StringKind:
+		t, err := time.Parse(time.RFC3339Nano, v.String())
+		return t, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,41 @@
+package protocli_test
+
+import (
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnit_ParseTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"now", now},
+		{"1h", now.Add(-time.Hour)},
+		{"1h ago", now.Add(-time.Hour)},
+		{"90m ago", now.Add(-90 * time.Minute)},
+		{"2d ago", now.Add(-48 * time.Hour)},
+		{"2024-03-09T08:30:00Z", time.Date(2024, 3, 9, 8, 30, 0, 0, time.UTC)},
+		{"2024-03-09T08:30:00+02:00", time.Date(2024, 3, 9, 6, 30, 0, 0, time.UTC)},
+		{"2024-03-09 08:30", time.Date(2024, 3, 9, 8, 30, 0, 0, time.UTC)},
+		{"2024-03-09", time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := protocli.ParseTime(tt.value, now)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+
+	for _, value := range []string{"", "yesterday", "-1h", "1x ago", "2024-13-01"} {
+		_, err := protocli.ParseTime(value, now)
+		require.ErrorIs(t, err, protocli.ErrInvalidTime, value)
+	}
+}
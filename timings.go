@@ -0,0 +1,99 @@
+package protocli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CommandTimings records how long a generated command spends in each phase
+// (request build, config load, service call, format) for --timings.
+// A nil *CommandTimings is valid and does nothing.
+type CommandTimings struct {
+	w       io.Writer
+	start   time.Time
+	current int // Index of the running phase in phases, or -1
+	phases  []phaseTiming
+}
+
+// phaseTiming is the accumulated duration of one named phase.
+type phaseTiming struct {
+	name    string
+	elapsed time.Duration
+	started time.Time
+}
+
+// NewCommandTimings starts timing a generated command. Returns nil unless the
+// global --timings flag is set.
+func NewCommandTimings(cmd *cli.Command) *CommandTimings {
+	if !cmd.Root().Bool("timings") {
+		return nil
+	}
+	w := cmd.Root().ErrWriter
+	if w == nil {
+		w = os.Stderr
+	}
+	return &CommandTimings{w: w, start: time.Now(), current: -1}
+}
+
+// Phase ends the running phase and starts name. Time spent in a phase that is
+// entered again is added to its earlier total.
+func (t *CommandTimings) Phase(name string) {
+	if t == nil {
+		return
+	}
+	now := time.Now()
+	t.stop(now)
+	for i := range t.phases {
+		if t.phases[i].name == name {
+			t.current = i
+			t.phases[i].started = now
+			return
+		}
+	}
+	t.phases = append(t.phases, phaseTiming{name: name, started: now})
+	t.current = len(t.phases) - 1
+}
+
+// stop adds the time since the running phase started to its total.
+func (t *CommandTimings) stop(now time.Time) {
+	if t.current >= 0 {
+		t.phases[t.current].elapsed += now.Sub(t.phases[t.current].started)
+		t.current = -1
+	}
+}
+
+// Report ends the running phase and writes the breakdown, followed by the
+// total wall time of the command, to stderr.
+func (t *CommandTimings) Report() {
+	if t == nil {
+		return
+	}
+	now := time.Now()
+	t.stop(now)
+
+	tw := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "Timings:")
+	for _, p := range t.phases {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\n", p.name, roundDuration(p.elapsed))
+	}
+	_, _ = fmt.Fprintf(tw, "  total\t%s\n", roundDuration(now.Sub(t.start)))
+	_ = tw.Flush()
+}
+
+// roundDuration rounds d to a precision that stays readable for both
+// microsecond and multi-second phases.
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond)
+	default:
+		return d
+	}
+}
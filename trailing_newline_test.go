@@ -0,0 +1,52 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// binaryFormat writes messages as binary protobuf, without a trailing newline.
+type binaryFormat struct{}
+
+func (binaryFormat) Name() string { return "binary" }
+
+func (binaryFormat) Format(_ context.Context, _ *cli.Command, w io.Writer, msg proto.Message) error {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func (binaryFormat) NeedsTrailingNewline() bool { return false }
+
+func TestWriteFormatted_TrailingNewline(t *testing.T) {
+	msg := wrapperspb.String("hello")
+	formats := []protocli.OutputFormat{protocli.JSON(), binaryFormat{}}
+	cmd := &cli.Command{}
+
+	var buf bytes.Buffer
+	require.NoError(t, protocli.WriteFormatted(context.Background(), cmd, &buf, formats, "binary", msg))
+	want, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	assert.Equal(t, want, buf.Bytes(), "binary output is written unchanged")
+
+	buf.Reset()
+	require.NoError(t, protocli.WriteFormatted(context.Background(), cmd, &buf, formats, "json", msg))
+	assert.Equal(t, "\"hello\"\n", buf.String(), "formats without TrailingNewlineControl keep the newline")
+}
+
+func TestNeedsTrailingNewline(t *testing.T) {
+	assert.True(t, protocli.NeedsTrailingNewline(protocli.JSON()))
+	assert.False(t, protocli.NeedsTrailingNewline(binaryFormat{}))
+}
@@ -40,6 +40,7 @@ type TUIFieldDescriptor struct {
 	EnumValues      []TUIEnumValue   // for enum fields
 	ElementKind     TUIFieldKind     // for repeated fields
 	Fields          []TUIFieldDescriptor // for message fields (nested)
+	OneofGroup      string               // name of the containing oneof; members of a group are mutually exclusive
 	// Setter parses a string value and sets it on the request message.
 	Setter func(proto.Message, string) error
 	// Appender appends a parsed string element to a repeated field.
@@ -0,0 +1,250 @@
+package protocli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	// updateCheckInterval is how long a fetched latest version is reused
+	// before the endpoint is asked again.
+	updateCheckInterval = 24 * time.Hour
+	// updateCheckTimeout bounds a fetch, which starts with the command.
+	updateCheckTimeout = time.Second
+	// updateCheckWait is how long a command that finished before the fetch
+	// waits for it, so that fast commands still fill the cache.
+	updateCheckWait = 250 * time.Millisecond
+	// maxUpdateCheckResponse caps how much of the endpoint's response is read.
+	maxUpdateCheckResponse = 4096
+)
+
+// errInvalidUpdateResponse is logged when the update endpoint's response holds no version.
+var errInvalidUpdateResponse = errors.New("invalid update check response")
+
+// updateCheckCache is the cached result of the last fetch.
+type updateCheckCache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest"`
+}
+
+// UpdateCheckDisableEnvVar returns the environment variable that turns off
+// WithUpdateCheck for appName, e.g. "USERCLI_NO_UPDATE_CHECK" for "usercli".
+func UpdateCheckDisableEnvVar(appName string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(appName)) + "_NO_UPDATE_CHECK"
+}
+
+// wrapUpdateCheckActions makes every command except daemonize look up the
+// latest version from url while it runs, then print a notice to stderr when
+// it is newer than the root command's Version. A command that finishes first
+// waits up to updateCheckWait for the fetch, which caches its result before
+// the notice is printed; a fetch slower than that is abandoned when the
+// process exits and is retried on the next run.
+func wrapUpdateCheckActions(cmd *cli.Command, url string) {
+	for _, sub := range cmd.Commands {
+		wrapUpdateCheckActions(sub, url)
+		if sub.Action == nil || sub.Name == "daemonize" {
+			continue
+		}
+		action := sub.Action
+		sub.Action = func(ctx context.Context, c *cli.Command) error {
+			latest := startUpdateCheck(ctx, c, url)
+			err := action(ctx, c)
+			select {
+			case version, ok := <-latest:
+				if ok {
+					writeUpdateNotice(c.Root(), version)
+				}
+			case <-time.After(updateCheckWait):
+			}
+			return err
+		}
+	}
+}
+
+// startUpdateCheck returns a channel that yields the latest version, from the
+// cache when it was fetched within updateCheckInterval and from url otherwise.
+// The channel is closed without a value when the check is disabled (no root
+// Version, the global --quiet or the disable env var) or fails; failures are
+// only logged at debug level.
+func startUpdateCheck(ctx context.Context, cmd *cli.Command, url string) <-chan string {
+	latest := make(chan string, 1)
+	root := cmd.Root()
+	if root.Version == "" || cmd.Bool("quiet") || os.Getenv(UpdateCheckDisableEnvVar(root.Name)) != "" {
+		close(latest)
+		return latest
+	}
+
+	cachePath := updateCheckCachePath(root.Name)
+	if cached, ok := readUpdateCheckCache(cachePath); ok && time.Since(cached.CheckedAt) < updateCheckInterval {
+		latest <- cached.Latest
+		close(latest)
+		return latest
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), updateCheckTimeout)
+	go func() {
+		defer cancel()
+		defer close(latest)
+		version, err := fetchLatestVersion(ctx, url)
+		if err != nil {
+			slog.Debug("Update check failed", "url", url, "error", err)
+			return
+		}
+		writeUpdateCheckCache(cachePath, updateCheckCache{CheckedAt: time.Now(), Latest: version})
+		latest <- version
+	}()
+	return latest
+}
+
+// fetchLatestVersion GETs url, which responds with the latest version either
+// as plain text ("1.4.0") or as JSON ({"version": "1.4.0"}).
+func fetchLatestVersion(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: status %s", errInvalidUpdateResponse, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxUpdateCheckResponse))
+	if err != nil {
+		return "", err
+	}
+
+	version := strings.TrimSpace(string(body))
+	if strings.HasPrefix(version, "{") {
+		var parsed struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return "", fmt.Errorf("%w: %w", errInvalidUpdateResponse, err)
+		}
+		version = parsed.Version
+	}
+	if version == "" {
+		return "", fmt.Errorf("%w: no version", errInvalidUpdateResponse)
+	}
+	return version, nil
+}
+
+// updateCheckCachePath returns where the last fetch is cached for appName.
+func updateCheckCachePath(appName string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, appName, "update-check.json")
+}
+
+func readUpdateCheckCache(path string) (updateCheckCache, bool) {
+	var cached updateCheckCache
+	if path == "" {
+		return cached, false
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // path is under the user cache dir
+	if err != nil || json.Unmarshal(data, &cached) != nil {
+		return cached, false
+	}
+	return cached, cached.Latest != ""
+}
+
+func writeUpdateCheckCache(path string, cached updateCheckCache) {
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(cached)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0o755)
+	}
+	if err == nil {
+		err = os.WriteFile(path, data, 0o600)
+	}
+	if err != nil {
+		slog.Debug("Failed to cache update check", "path", path, "error", err)
+	}
+}
+
+// writeUpdateNotice prints a notice to stderr when latest is newer than the
+// root command's Version.
+func writeUpdateNotice(root *cli.Command, latest string) {
+	if compareVersions(latest, root.Version) <= 0 {
+		return
+	}
+	w := root.ErrWriter
+	if w == nil {
+		w = os.Stderr
+	}
+	_, _ = fmt.Fprintf(w, "A new version of %s is available: %s (current: %s)\n", root.Name, latest, root.Version)
+}
+
+// compareVersions compares dotted versions such as "v1.10.0" and "1.9.2",
+// returning -1, 0 or 1. A leading "v" and build metadata are ignored, and a
+// pre-release ("1.2.0-rc.1") sorts before its release. Non-numeric parts
+// compare as strings.
+func compareVersions(a, b string) int {
+	coreA, preA := splitVersion(a)
+	coreB, preB := splitVersion(b)
+	partsA, partsB := strings.Split(coreA, "."), strings.Split(coreB, ".")
+	for i := range max(len(partsA), len(partsB)) {
+		if c := compareVersionPart(versionPart(partsA, i), versionPart(partsB, i)); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	default:
+		return strings.Compare(preA, preB)
+	}
+}
+
+// splitVersion returns the numeric core and pre-release of version.
+func splitVersion(version string) (string, string) {
+	version, _, _ = strings.Cut(strings.TrimPrefix(strings.TrimSpace(version), "v"), "+")
+	core, pre, _ := strings.Cut(version, "-")
+	return core, pre
+}
+
+func versionPart(parts []string, i int) string {
+	if i < len(parts) {
+		return parts[i]
+	}
+	return "0"
+}
+
+func compareVersionPart(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	switch {
+	case na < nb:
+		return -1
+	case na > nb:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,146 @@
+package protocli_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startVersionEndpoint serves body as the latest version and counts requests.
+func startVersionEndpoint(t *testing.T, status int, body string) (string, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, &requests
+}
+
+// runWithUpdateCheck runs `testcli doctor` as version 1.2.0 with an update
+// check against url and returns what was written to stderr.
+func runWithUpdateCheck(t *testing.T, url string, args ...string) string {
+	t.Helper()
+	rootCmd, err := protocli.RootCommand("testcli", protocli.WithUpdateCheck(url))
+	require.NoError(t, err)
+	rootCmd.Version = "1.2.0"
+
+	var stdout, stderr bytes.Buffer
+	rootCmd.Writer = &stdout
+	rootCmd.ErrWriter = &stderr
+	require.NoError(t, rootCmd.Run(context.Background(), append(append([]string{"testcli"}, args...), "doctor")))
+	return stderr.String()
+}
+
+// assertUpdateCached asserts that a finished run cached the latest version
+// under the XDG_CACHE_HOME of the test.
+func assertUpdateCached(t *testing.T, latest string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(os.Getenv("XDG_CACHE_HOME"), "testcli", "update-check.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"latest":"`+latest+`"`)
+}
+
+func TestIntegration_UpdateCheck_Versions(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		body   string
+		notice bool
+	}{
+		{name: "newer", body: "1.10.0\n", notice: true},
+		{name: "newer as JSON", body: `{"version": "v1.3.0"}`, notice: true},
+		{name: "same", body: "v1.2.0", notice: false},
+		{name: "older", body: "1.1.9", notice: false},
+		{name: "pre-release of current", body: "1.2.0-rc.1", notice: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			url, _ := startVersionEndpoint(t, http.StatusOK, tt.body)
+			stderr := runWithUpdateCheck(t, url)
+			if tt.notice {
+				assert.Contains(t, stderr, "A new version of testcli is available: ")
+				assert.Contains(t, stderr, "(current: 1.2.0)")
+			} else {
+				assert.Empty(t, stderr)
+			}
+		})
+	}
+}
+
+// TestIntegration_UpdateCheck_CachedForADay tests that the endpoint is asked
+// once and later runs show the notice from the cache.
+func TestIntegration_UpdateCheck_CachedForADay(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	url, requests := startVersionEndpoint(t, http.StatusOK, "2.0.0")
+
+	assert.Contains(t, runWithUpdateCheck(t, url), "available: 2.0.0")
+	assertUpdateCached(t, "2.0.0")
+	assert.Contains(t, runWithUpdateCheck(t, url), "available: 2.0.0")
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestIntegration_UpdateCheck_Disabled(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	url, requests := startVersionEndpoint(t, http.StatusOK, "2.0.0")
+
+	assert.Empty(t, runWithUpdateCheck(t, url, "--quiet"))
+
+	t.Setenv(protocli.UpdateCheckDisableEnvVar("testcli"), "1")
+	assert.Empty(t, runWithUpdateCheck(t, url))
+	assert.Zero(t, requests.Load())
+}
+
+// TestIntegration_UpdateCheck_EndpointErrors tests that failed checks neither
+// fail the command nor print anything.
+func TestIntegration_UpdateCheck_EndpointErrors(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	url, _ := startVersionEndpoint(t, http.StatusInternalServerError, "2.0.0")
+	assert.Empty(t, runWithUpdateCheck(t, url))
+
+	assert.Empty(t, runWithUpdateCheck(t, "http://127.0.0.1:1/latest"))
+}
+
+// TestIntegration_UpdateCheck_SlowEndpoint tests that a command finishing
+// before the fetch waits for it briefly, so the result is cached and shown.
+func TestIntegration_UpdateCheck_SlowEndpoint(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte("2.0.0"))
+	}))
+	t.Cleanup(server.Close)
+
+	assert.Contains(t, runWithUpdateCheck(t, server.URL), "available: 2.0.0")
+	assertUpdateCached(t, "2.0.0")
+}
+
+// TestIntegration_UpdateCheck_HungEndpointDoesNotBlock tests that a command
+// waits only briefly for an endpoint that does not respond.
+func TestIntegration_UpdateCheck_HungEndpointDoesNotBlock(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	start := time.Now()
+	assert.Empty(t, runWithUpdateCheck(t, server.URL))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
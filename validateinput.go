@@ -0,0 +1,65 @@
+package protocli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+)
+
+// validateCommandKey marks the command returned by NewValidateCommand, whose
+// subcommands --pick leaves out since they don't call the service.
+const validateCommandKey = "protocli:validate"
+
+// NewValidateCommand returns `validate`, whose subcommands check that a request
+// file parses and meets the request's field constraints without calling the
+// service. Flat service commands include it with one subcommand per method
+// (e.g. `validate get --input-file req.json`) unless a method command is
+// already named "validate"; RootCommand adds one `validate` nested by service
+// and then method instead.
+func NewValidateCommand(subcommands ...*cli.Command) *cli.Command {
+	return &cli.Command{
+		Name:     "validate",
+		Usage:    "Check request files without calling the service",
+		Commands: subcommands,
+		Metadata: map[string]any{validateCommandKey: true},
+	}
+}
+
+// newRootValidateCommand returns the root `validate` command, with a
+// subcommand per service holding its ValidateCommands, e.g.
+// `validate user-service get --input-file req.json`. It returns nil when no
+// service has a method to validate.
+func newRootValidateCommand(services []*ServiceCLI) *cli.Command {
+	var serviceCmds []*cli.Command
+	for _, svc := range services {
+		if len(svc.ValidateCommands) == 0 {
+			continue
+		}
+		name, aliases := svc.ServiceName, []string(nil)
+		if svc.Command != nil {
+			name, aliases = svc.Command.Name, svc.Command.Aliases
+		}
+		serviceCmds = append(serviceCmds, &cli.Command{
+			Name:     name,
+			Aliases:  aliases,
+			Usage:    "Check " + name + " request files",
+			Commands: svc.ValidateCommands,
+		})
+	}
+	if len(serviceCmds) == 0 {
+		return nil
+	}
+	return NewValidateCommand(serviceCmds...)
+}
+
+// ReportInputValid prints "<file>: valid" once a validate subcommand has
+// checked inputFile.
+func ReportInputValid(cmd *cli.Command, inputFile string) error {
+	w := cmd.Root().Writer
+	if w == nil {
+		w = os.Stdout
+	}
+	_, err := fmt.Fprintf(w, "%s: valid\n", inputFile)
+	return err
+}
@@ -0,0 +1,86 @@
+package protocli
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var ErrConstraintViolation = errors.New("invalid flag value")
+
+// FieldConstraint describes the value constraints declared on a request field
+// via the cli.flag pattern, one_of, min and max annotations. Generated actions
+// check every constrained field after the request is assembled and join the
+// resulting errors so all violations are reported at once. Patterns are
+// compiled once, by generated package-level variables.
+type FieldConstraint struct {
+	Flag    string         // Flag name used in error messages
+	Set     bool           // Whether the flag was given, so a zero value is checked too
+	Pattern *regexp.Regexp // Regular expression string values must match
+	OneOf   []string       // Allowed string values
+	Min     *float64       // Inclusive lower bound for numeric values
+	Max     *float64       // Inclusive upper bound for numeric values
+}
+
+// Check validates value against the constraint. Pointers (proto3 optional
+// fields) are dereferenced and skipped when nil, slices are checked element by
+// element, and zero values of non-optional fields are treated as unset unless
+// the flag was given (Set), e.g. --email "" against a pattern.
+func (c FieldConstraint) Check(value any) error {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		return c.checkValue(v.Elem())
+	}
+	if v.Kind() == reflect.Slice {
+		var errs []error
+		for i := range v.Len() {
+			errs = append(errs, c.checkValue(v.Index(i)))
+		}
+		return errors.Join(errs...)
+	}
+	if !v.IsValid() || (v.IsZero() && !c.Set) {
+		return nil
+	}
+	return c.checkValue(v)
+}
+
+func (c FieldConstraint) checkValue(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		return c.checkString(v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return c.checkNumber(float64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return c.checkNumber(float64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return c.checkNumber(v.Float())
+	default:
+		return nil
+	}
+}
+
+func (c FieldConstraint) checkString(s string) error {
+	if c.Pattern != nil && !c.Pattern.MatchString(s) {
+		return fmt.Errorf("%w: --%s: %q does not match pattern %q", ErrConstraintViolation, c.Flag, s, c.Pattern)
+	}
+	if len(c.OneOf) > 0 && !slices.Contains(c.OneOf, s) {
+		return fmt.Errorf("%w: --%s: %q must be one of [%s]", ErrConstraintViolation, c.Flag, s, strings.Join(c.OneOf, ", "))
+	}
+	return nil
+}
+
+func (c FieldConstraint) checkNumber(n float64) error {
+	if c.Min != nil && n < *c.Min {
+		return fmt.Errorf("%w: --%s: %v is less than minimum %v", ErrConstraintViolation, c.Flag, n, *c.Min)
+	}
+	if c.Max != nil && n > *c.Max {
+		return fmt.Errorf("%w: --%s: %v is greater than maximum %v", ErrConstraintViolation, c.Flag, n, *c.Max)
+	}
+	return nil
+}
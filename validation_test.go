@@ -0,0 +1,43 @@
+package protocli_test
+
+import (
+	"regexp"
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUnit_FieldConstraint_Check(t *testing.T) {
+	pattern := protocli.FieldConstraint{Flag: "id", Pattern: regexp.MustCompile(`^[a-z]+-\d+$`)}
+	require.NoError(t, pattern.Check("user-1"))
+	require.ErrorIs(t, pattern.Check("User 1"), protocli.ErrConstraintViolation)
+
+	// Unset values are not checked, but zero values of given flags are
+	require.NoError(t, pattern.Check(""))
+	require.NoError(t, pattern.Check((*string)(nil)))
+	set := pattern
+	set.Set = true
+	require.ErrorIs(t, set.Check(""), protocli.ErrConstraintViolation)
+
+	// Every element of a repeated field is checked
+	err := pattern.Check([]string{"a-1", "bad", "b-2", "worse"})
+	require.ErrorIs(t, err, protocli.ErrConstraintViolation)
+	assert.Contains(t, err.Error(), `"bad"`)
+	assert.Contains(t, err.Error(), `"worse"`)
+
+	oneOf := protocli.FieldConstraint{Flag: "mode", OneOf: []string{"fast", "safe"}}
+	require.NoError(t, oneOf.Check("safe"))
+	require.ErrorIs(t, oneOf.Check("slow"), protocli.ErrConstraintViolation)
+
+	rng := protocli.FieldConstraint{Flag: "port", Min: proto.Float64(1), Max: proto.Float64(65535)}
+	require.NoError(t, rng.Check(int32(8080)))
+	require.ErrorIs(t, rng.Check(uint64(70000)), protocli.ErrConstraintViolation)
+	// Optional fields keep explicit zero values
+	require.ErrorIs(t, rng.Check(proto.Int32(0)), protocli.ErrConstraintViolation)
+	require.NoError(t, rng.Check(int32(0)))
+	rng.Set = true
+	require.ErrorIs(t, rng.Check(int32(0)), protocli.ErrConstraintViolation)
+}
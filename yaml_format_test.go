@@ -0,0 +1,75 @@
+package protocli_test
+
+import (
+	"testing"
+
+	protocli "github.com/drewfead/proto-cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+)
+
+func nestedYAMLMessage(t *testing.T) *structpb.Struct {
+	t.Helper()
+	msg, err := structpb.NewStruct(map[string]any{
+		"name": "ada",
+		"address": map[string]any{
+			"city": "London",
+			"zip":  "N1",
+		},
+		"tags": []any{"admin", "ops"},
+	})
+	require.NoError(t, err)
+	return msg
+}
+
+func TestUnit_YAMLFormat_BlockStyle(t *testing.T) {
+	out, err := formatWith(t, protocli.YAML(), nestedYAMLMessage(t))
+	require.NoError(t, err)
+	assert.Equal(t, "address:\n  city: London\n  zip: N1\nname: ada\ntags:\n  - admin\n  - ops", out)
+}
+
+func TestUnit_YAMLFormat_FlowStyle(t *testing.T) {
+	out, err := formatWith(t, protocli.YAML(), nestedYAMLMessage(t), "--yaml-flow")
+	require.NoError(t, err)
+	assert.Equal(t, "{address: {city: London, zip: N1}, name: ada, tags: [admin, ops]}", out)
+}
+
+// TestUnit_YAMLFormat_FlowStyleQuoting tests that --yaml-flow quotes strings
+// that would otherwise break the flow syntax or read back as another type.
+func TestUnit_YAMLFormat_FlowStyleQuoting(t *testing.T) {
+	values := map[string]any{
+		"comma":   "a, b",
+		"colon":   "key: value",
+		"braces":  "{x}",
+		"bracket": "[y]",
+		"comment": "# note",
+		"bool":    "yes",
+		"null":    "null",
+		"number":  "42",
+		"empty":   "",
+	}
+	msg, err := structpb.NewStruct(map[string]any{"values": values, "list": []any{"a, b", "null"}})
+	require.NoError(t, err)
+
+	out, err := formatWith(t, protocli.YAML(), msg, "--yaml-flow")
+	require.NoError(t, err)
+	assert.Contains(t, out, `comma: 'a, b'`)
+	assert.Contains(t, out, `bool: "yes"`)
+	assert.Contains(t, out, `list: ['a, b', "null"]`)
+
+	var parsed map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(out), &parsed))
+	assert.Equal(t, values, parsed["values"])
+	assert.Equal(t, []any{"a, b", "null"}, parsed["list"])
+}
+
+func TestUnit_YAMLFormat_Indent(t *testing.T) {
+	out, err := formatWith(t, protocli.YAML(), nestedYAMLMessage(t), "--yaml-indent", "4")
+	require.NoError(t, err)
+	assert.Equal(t, "address:\n    city: London\n    zip: N1\nname: ada\ntags:\n    - admin\n    - ops", out)
+
+	_, err = formatWith(t, protocli.YAML(), nestedYAMLMessage(t), "--yaml-indent", "0")
+	require.ErrorIs(t, err, protocli.ErrInvalidYAMLIndent)
+}